@@ -67,10 +67,14 @@ export const SignInPage: React.FunctionComponent<React.PropsWithChildren<SignInP
         return true
     }
 
-    const thirdPartyAuthProviders = nonBuiltinAuthProviders.filter(provider => shouldShowProvider(provider))
+    const [ldapAuthProviders, thirdPartyAuthProviders] = partition(
+        nonBuiltinAuthProviders.filter(provider => shouldShowProvider(provider)),
+        provider => provider.serviceType === 'ldap'
+    )
+    const usernamePasswordAuthProviders = [...(builtInAuthProvider ? [undefined] : []), ...ldapAuthProviders]
 
     const body =
-        !builtInAuthProvider && thirdPartyAuthProviders.length === 0 ? (
+        usernamePasswordAuthProviders.length === 0 && thirdPartyAuthProviders.length === 0 ? (
             <Alert className="mt-3" variant="info">
                 No authentication providers are available. Contact a site administrator for help.
             </Alert>
@@ -84,14 +88,27 @@ export const SignInPage: React.FunctionComponent<React.PropsWithChildren<SignInP
                         error ? 'mt-3' : 'mt-4'
                     )}
                 >
-                    {builtInAuthProvider && (
-                        <UsernamePasswordSignInForm
-                            {...props}
-                            onAuthError={setError}
-                            noThirdPartyProviders={thirdPartyAuthProviders.length === 0}
-                        />
+                    {usernamePasswordAuthProviders.map((provider, index) => (
+                        // Use index as key because the list will not be updated during this
+                        // component's lifetime.
+                        /* eslint-disable react/no-array-index-key */
+                        <React.Fragment key={index}>
+                            {index > 0 && <OrDivider className="mb-3 py-1" />}
+                            <UsernamePasswordSignInForm
+                                {...props}
+                                authProvider={provider}
+                                autoFocus={index === 0}
+                                onAuthError={setError}
+                                noThirdPartyProviders={
+                                    index === usernamePasswordAuthProviders.length - 1 &&
+                                    thirdPartyAuthProviders.length === 0
+                                }
+                            />
+                        </React.Fragment>
+                    ))}
+                    {usernamePasswordAuthProviders.length > 0 && thirdPartyAuthProviders.length > 0 && (
+                        <OrDivider className="mb-3 py-1" />
                     )}
-                    {builtInAuthProvider && thirdPartyAuthProviders.length > 0 && <OrDivider className="mb-3 py-1" />}
                     {thirdPartyAuthProviders.map((provider, index) => (
                         // Use index as key because display name may not be unique. This is OK
                         // here because this list will not be updated during this component's lifetime.
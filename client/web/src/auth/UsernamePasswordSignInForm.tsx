@@ -7,7 +7,7 @@ import { Form } from '@sourcegraph/branded/src/components/Form'
 import { asError, logger } from '@sourcegraph/common'
 import { Label, Button, LoadingSpinner, Link, Text, Input } from '@sourcegraph/wildcard'
 
-import { SourcegraphContext } from '../jscontext'
+import { AuthProvider, SourcegraphContext } from '../jscontext'
 import { eventLogger } from '../tracking/eventLogger'
 
 import { getReturnTo, PasswordInput } from './SignInSignUpCommon'
@@ -15,6 +15,13 @@ import { getReturnTo, PasswordInput } from './SignInSignUpCommon'
 interface Props {
     onAuthError: (error: Error | null) => void
     noThirdPartyProviders?: boolean
+    /**
+     * The username/password auth provider (such as LDAP) to sign in with. If not set, the
+     * builtin auth provider is used.
+     */
+    authProvider?: AuthProvider
+    /** Whether to focus the username field on mount. Defaults to true. */
+    autoFocus?: boolean
     context: Pick<
         SourcegraphContext,
         'allowSignup' | 'authProviders' | 'sourcegraphDotComMode' | 'xhrHeaders' | 'resetPasswordEnabled'
@@ -27,6 +34,8 @@ interface Props {
 export const UsernamePasswordSignInForm: React.FunctionComponent<React.PropsWithChildren<Props>> = ({
     onAuthError,
     noThirdPartyProviders,
+    authProvider,
+    autoFocus = true,
     context,
 }) => {
     const location = useLocation()
@@ -34,6 +43,9 @@ export const UsernamePasswordSignInForm: React.FunctionComponent<React.PropsWith
     const [password, setPassword] = useState('')
     const [loading, setLoading] = useState(false)
 
+    const usernameInputID = authProvider ? `username-${authProvider.serviceID}` : 'username-or-email'
+    const passwordInputID = authProvider ? `password-${authProvider.serviceID}` : 'password'
+
     const onUsernameOrEmailFieldChange = useCallback((event: React.ChangeEvent<HTMLInputElement>): void => {
         setUsernameOrEmail(event.target.value)
     }, [])
@@ -51,7 +63,7 @@ export const UsernamePasswordSignInForm: React.FunctionComponent<React.PropsWith
 
             setLoading(true)
             eventLogger.log('InitiateSignIn')
-            fetch('/-/sign-in', {
+            fetch(authProvider ? authProvider.authenticationURL : '/-/sign-in', {
                 credentials: 'same-origin',
                 method: 'POST',
                 headers: {
@@ -59,10 +71,9 @@ export const UsernamePasswordSignInForm: React.FunctionComponent<React.PropsWith
                     Accept: 'application/json',
                     'Content-Type': 'application/json',
                 },
-                body: JSON.stringify({
-                    email: usernameOrEmail,
-                    password,
-                }),
+                body: JSON.stringify(
+                    authProvider ? { username: usernameOrEmail, password } : { email: usernameOrEmail, password }
+                ),
             })
                 .then(response => {
                     if (response.status === 200) {
@@ -86,21 +97,21 @@ export const UsernamePasswordSignInForm: React.FunctionComponent<React.PropsWith
                     onAuthError(asError(error))
                 })
         },
-        [usernameOrEmail, loading, location, password, onAuthError, context]
+        [usernameOrEmail, loading, location, password, onAuthError, context, authProvider]
     )
 
     return (
         <>
             <Form onSubmit={handleSubmit}>
                 <Input
-                    id="username-or-email"
-                    label={<Text alignment="left">Username or email</Text>}
+                    id={usernameInputID}
+                    label={<Text alignment="left">{authProvider ? 'Username' : 'Username or email'}</Text>}
                     onChange={onUsernameOrEmailFieldChange}
                     required={true}
                     value={usernameOrEmail}
                     disabled={loading}
                     autoCapitalize="off"
-                    autoFocus={true}
+                    autoFocus={autoFocus}
                     className="form-group"
                     // There is no well supported way to declare username OR email here.
                     // Using username seems to be the best approach and should still support this behaviour.
@@ -109,10 +120,11 @@ export const UsernamePasswordSignInForm: React.FunctionComponent<React.PropsWith
                 />
 
                 <div className="form-group d-flex flex-column align-content-start position-relative">
-                    <Label htmlFor="password" className="align-self-start">
+                    <Label htmlFor={passwordInputID} className="align-self-start">
                         Password
                     </Label>
                     <PasswordInput
+                        id={passwordInputID}
                         onChange={onPasswordFieldChange}
                         value={password}
                         required={true}
@@ -120,7 +132,7 @@ export const UsernamePasswordSignInForm: React.FunctionComponent<React.PropsWith
                         autoComplete="current-password"
                         placeholder=" "
                     />
-                    {context.resetPasswordEnabled && (
+                    {context.resetPasswordEnabled && !authProvider && (
                         <small className="form-text text-muted align-self-end position-absolute">
                             <Link to="/password-reset">Forgot password?</Link>
                         </small>
@@ -133,7 +145,13 @@ export const UsernamePasswordSignInForm: React.FunctionComponent<React.PropsWith
                     })}
                 >
                     <Button display="block" type="submit" disabled={loading} variant="primary">
-                        {loading ? <LoadingSpinner /> : 'Sign in'}
+                        {loading ? (
+                            <LoadingSpinner />
+                        ) : authProvider ? (
+                            `Sign in with ${authProvider.displayName}`
+                        ) : (
+                            'Sign in'
+                        )}
                     </Button>
                 </div>
             </Form>
@@ -8,7 +8,7 @@ import { AuthProvider } from '../../jscontext'
 
 export type ExternalAccountKind = Exclude<
     AuthProvider['serviceType'],
-    'http-header' | 'builtin' | 'sourcegraph-operator'
+    'http-header' | 'builtin' | 'sourcegraph-operator' | 'ldap'
 >
 
 export interface ExternalAccount {
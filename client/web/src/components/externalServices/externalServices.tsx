@@ -6,6 +6,7 @@ import BitbucketIcon from 'mdi-react/BitbucketIcon'
 import GithubIcon from 'mdi-react/GithubIcon'
 import GitIcon from 'mdi-react/GitIcon'
 import GitLabIcon from 'mdi-react/GitlabIcon'
+import LanguageCsharpIcon from 'mdi-react/LanguageCsharpIcon'
 import LanguageGoIcon from 'mdi-react/LanguageGoIcon'
 import LanguageJavaIcon from 'mdi-react/LanguageJavaIcon'
 import LanguagePythonIcon from 'mdi-react/LanguagePythonIcon'
@@ -17,15 +18,18 @@ import { PerforceIcon, PhabricatorIcon } from '@sourcegraph/shared/src/component
 import { Link, Code, Text } from '@sourcegraph/wildcard'
 
 import awsCodeCommitSchemaJSON from '../../../../../schema/aws_codecommit.schema.json'
+import azureDevOpsSchemaJSON from '../../../../../schema/azuredevops.schema.json'
 import bitbucketCloudSchemaJSON from '../../../../../schema/bitbucket_cloud.schema.json'
 import bitbucketServerSchemaJSON from '../../../../../schema/bitbucket_server.schema.json'
 import gerritSchemaJSON from '../../../../../schema/gerrit.schema.json'
+import giteaSchemaJSON from '../../../../../schema/gitea.schema.json'
 import githubSchemaJSON from '../../../../../schema/github.schema.json'
 import gitlabSchemaJSON from '../../../../../schema/gitlab.schema.json'
 import gitoliteSchemaJSON from '../../../../../schema/gitolite.schema.json'
 import goModulesSchemaJSON from '../../../../../schema/go-modules.schema.json'
 import jvmPackagesSchemaJSON from '../../../../../schema/jvm-packages.schema.json'
 import npmPackagesSchemaJSON from '../../../../../schema/npm-packages.schema.json'
+import nugetPackagesSchemaJSON from '../../../../../schema/nuget-packages.schema.json'
 import otherExternalServiceSchemaJSON from '../../../../../schema/other_external_service.schema.json'
 import pagureSchemaJSON from '../../../../../schema/pagure.schema.json'
 import perforceSchemaJSON from '../../../../../schema/perforce.schema.json'
@@ -1278,6 +1282,70 @@ const GERRIT: AddExternalServiceOptions = {
     editorActions: [],
 }
 
+const AZURE_DEVOPS: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.AZUREDEVOPS,
+    title: 'Azure DevOps',
+    icon: GitIcon,
+    jsonSchema: azureDevOpsSchemaJSON,
+    defaultDisplayName: 'Azure DevOps',
+    defaultConfig: `{
+  "url": "https://dev.azure.com",
+  "username": "<username>",
+  "token": "<personal access token>",
+  "orgs": []
+}`,
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>url</Field> to <Value>https://dev.azure.com</Value>, or to
+                    the URL of your Azure DevOps Server.
+                </li>
+                <li>
+                    Create a personal access token with the <strong>Code (Read)</strong> and{' '}
+                    <strong>Project and Team (Read)</strong> scopes and set{' '}
+                    <Field>username</Field> and <Field>token</Field> to your username and the token.
+                </li>
+                <li>
+                    Select repositories to sync with <Field>orgs</Field> or <Field>projects</Field>.
+                </li>
+            </ol>
+        </div>
+    ),
+    editorActions: [],
+}
+
+const GITEA: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.GITEA,
+    title: 'Gitea',
+    icon: GitIcon,
+    jsonSchema: giteaSchemaJSON,
+    defaultDisplayName: 'Gitea',
+    defaultConfig: `{
+  "url": "https://gitea.example.com",
+  "token": "<access token>",
+  "orgs": []
+}`,
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>url</Field> to the URL of your Gitea or Forgejo instance.
+                </li>
+                <li>
+                    Create an access token in <strong>Settings &gt; Applications</strong> on Gitea and set{' '}
+                    <Field>token</Field> to it. Without a token, only public repositories are synced.
+                </li>
+                <li>
+                    Select repositories to sync with <Field>orgs</Field>, <Field>users</Field> or{' '}
+                    <Field>repositoryQuery</Field>.
+                </li>
+            </ol>
+        </div>
+    ),
+    editorActions: [],
+}
+
 const NPM_PACKAGES: AddExternalServiceOptions = {
     kind: ExternalServiceKind.NPMPACKAGES,
     title: 'npm Dependencies',
@@ -1438,6 +1506,41 @@ const RUBY_PACKAGES: AddExternalServiceOptions = {
     editorActions: [],
 }
 
+const NUGET_PACKAGES: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.NUGETPACKAGES,
+    title: 'NuGet Dependencies',
+    icon: LanguageCsharpIcon,
+    jsonSchema: nugetPackagesSchemaJSON,
+    defaultDisplayName: 'NuGet Dependencies',
+    defaultConfig: `{
+  "repository": "https://api.nuget.org/v3/index.json",
+  "dependencies": []
+}`,
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>repository</Field> to the NuGet v3 service index of the
+                    feed you want to sync packages from. For example,{' '}
+                    <Code>"https://pkgs.dev.azure.com/contoso/_packaging/internal/nuget/v3/index.json"</Code>. The URL{' '}
+                    <Code>"https://api.nuget.org/v3/index.json"</Code> is used if the field is empty.
+                </li>
+                <li>
+                    If the feed requires authentication, set <Field>credentials</Field> to a username and a password,
+                    API key or personal access token.
+                </li>
+                <li>
+                    In the configuration below, set <Field>dependencies</Field> to the list of packages that you want to
+                    manually add. For example, <Code>"Newtonsoft.Json@13.0.1"</Code>.
+                </li>
+            </ol>
+            <Text>⚠️ NuGet package repositories are visible by all users of the Sourcegraph instance.</Text>
+            <Text>⚠️ It is only possible to register one NuGet packages code host per Sourcegraph instance.</Text>
+        </div>
+    ),
+    editorActions: [],
+}
+
 export const codeHostExternalServices: Record<string, AddExternalServiceOptions> = {
     github: GITHUB_DOTCOM,
     ghe: GITHUB_ENTERPRISE,
@@ -1446,12 +1549,15 @@ export const codeHostExternalServices: Record<string, AddExternalServiceOptions>
     bitbucket: BITBUCKET_CLOUD,
     bitbucketserver: BITBUCKET_SERVER,
     aws_codecommit: AWS_CODE_COMMIT,
+    azuredevops: AZURE_DEVOPS,
     srcservegit: SRC_SERVE_GIT,
     gitolite: GITOLITE,
+    gitea: GITEA,
     git: GENERIC_GIT,
     ...(window.context?.experimentalFeatures?.pythonPackages === 'enabled' ? { pythonPackages: PYTHON_PACKAGES } : {}),
     ...(window.context?.experimentalFeatures?.rustPackages === 'enabled' ? { rustPackages: RUST_PACKAGES } : {}),
     ...(window.context?.experimentalFeatures?.rubyPackages === 'enabled' ? { rubyPackages: RUBY_PACKAGES } : {}),
+    ...(window.context?.experimentalFeatures?.nugetPackages === 'enabled' ? { nugetPackages: NUGET_PACKAGES } : {}),
     ...(window.context?.experimentalFeatures?.goPackages === 'enabled' ? { goModules: GO_MODULES } : {}),
     ...(window.context?.experimentalFeatures?.jvmPackages === 'enabled' ? { jvmPackages: JVM_PACKAGES } : {}),
     ...(window.context?.experimentalFeatures?.npmPackages === 'enabled' ? { npmPackages: NPM_PACKAGES } : {}),
@@ -1478,8 +1584,10 @@ export const defaultExternalServices: Record<ExternalServiceKind, AddExternalSer
     [ExternalServiceKind.PHABRICATOR]: PHABRICATOR_SERVICE,
     [ExternalServiceKind.OTHER]: GENERIC_GIT,
     [ExternalServiceKind.AWSCODECOMMIT]: AWS_CODE_COMMIT,
+    [ExternalServiceKind.AZUREDEVOPS]: AZURE_DEVOPS,
     [ExternalServiceKind.PERFORCE]: PERFORCE,
     [ExternalServiceKind.GERRIT]: GERRIT,
+    [ExternalServiceKind.GITEA]: GITEA,
     [ExternalServiceKind.PAGURE]: PAGURE,
     [ExternalServiceKind.GOMODULES]: GO_MODULES,
     [ExternalServiceKind.JVMPACKAGES]: JVM_PACKAGES,
@@ -1487,6 +1595,7 @@ export const defaultExternalServices: Record<ExternalServiceKind, AddExternalSer
     [ExternalServiceKind.PYTHONPACKAGES]: PYTHON_PACKAGES,
     [ExternalServiceKind.RUSTPACKAGES]: RUST_PACKAGES,
     [ExternalServiceKind.RUBYPACKAGES]: RUBY_PACKAGES,
+    [ExternalServiceKind.NUGETPACKAGES]: NUGET_PACKAGES,
 }
 
 export const externalRepoIcon = (
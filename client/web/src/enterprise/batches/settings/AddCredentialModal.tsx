@@ -54,14 +54,25 @@ const scopeRequirements: Record<ExternalServiceKind, JSX.Element> = {
             <Code>pipeline:read</Code> permissions.
         </span>
     ),
+    [ExternalServiceKind.AZUREDEVOPS]: (
+        <span>
+            with <Code>Code (Read &amp; write)</Code> and <Code>Code (Status)</Code> scopes.
+        </span>
+    ),
+    [ExternalServiceKind.GERRIT]: (
+        <span>
+            with permission to push to <Code>refs/for/*</Code> and to abandon and submit changes.
+        </span>
+    ),
 
     // These are just for type completeness and serve as placeholders for a bright future.
-    [ExternalServiceKind.GERRIT]: <span>Unsupported</span>,
+    [ExternalServiceKind.GITEA]: <span>Unsupported</span>,
     [ExternalServiceKind.GITOLITE]: <span>Unsupported</span>,
     [ExternalServiceKind.GOMODULES]: <span>Unsupported</span>,
     [ExternalServiceKind.PYTHONPACKAGES]: <span>Unsupported</span>,
     [ExternalServiceKind.RUSTPACKAGES]: <span>Unsupported</span>,
     [ExternalServiceKind.RUBYPACKAGES]: <span>Unsupported</span>,
+    [ExternalServiceKind.NUGETPACKAGES]: <span>Unsupported</span>,
     [ExternalServiceKind.JVMPACKAGES]: <span>Unsupported</span>,
     [ExternalServiceKind.NPMPACKAGES]: <span>Unsupported</span>,
     [ExternalServiceKind.PERFORCE]: <span>Unsupported</span>,
@@ -138,7 +149,11 @@ export const AddCredentialModal: React.FunctionComponent<React.PropsWithChildren
     )
 
     const patLabel =
-        externalServiceKind === ExternalServiceKind.BITBUCKETCLOUD ? 'App password' : 'Personal access token'
+        externalServiceKind === ExternalServiceKind.BITBUCKETCLOUD
+            ? 'App password'
+            : externalServiceKind === ExternalServiceKind.GERRIT
+            ? 'HTTP password'
+            : 'Personal access token'
 
     return (
         <Modal onDismiss={onCancel} aria-labelledby={labelId}>
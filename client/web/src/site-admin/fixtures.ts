@@ -58,15 +58,18 @@ export const queryExternalServices: typeof _queryExternalServices = () =>
         },
         nodes: [
             createExternalService(ExternalServiceKind.GITHUB, 'https://github.com'),
+            createExternalService(ExternalServiceKind.AZUREDEVOPS, 'https://dev.azure.com'),
             createExternalService(ExternalServiceKind.BITBUCKETCLOUD, 'https://bitbucket.org'),
             createExternalService(ExternalServiceKind.BITBUCKETSERVER, 'https://sgdev.bitbucket.org'),
             createExternalService(ExternalServiceKind.BITBUCKETSERVER, 'https://sgprod.bitbucket.org'),
             createExternalService(ExternalServiceKind.GERRIT, 'https://gerrit.com'),
+            createExternalService(ExternalServiceKind.GITEA, 'https://gitea.com'),
             createExternalService(ExternalServiceKind.GITLAB, 'https://gitlab.com'),
             createExternalService(ExternalServiceKind.GITOLITE, 'https://gitolite.com'),
             createExternalService(ExternalServiceKind.GOMODULES, 'https://gomodules.com'),
             createExternalService(ExternalServiceKind.JVMPACKAGES, 'https://jvmpackages.com'),
             createExternalService(ExternalServiceKind.NPMPACKAGES, 'https://npmpackages.com'),
+            createExternalService(ExternalServiceKind.NUGETPACKAGES, 'https://nugetpackages.com'),
             createExternalService(ExternalServiceKind.OTHER, 'https://other.com'),
             createExternalService(ExternalServiceKind.PAGURE, 'https://pagure.com'),
             createExternalService(ExternalServiceKind.PERFORCE, 'https://perforce.com'),
@@ -104,7 +104,8 @@ const getNormalizedAccount = (
     if (
         authProvider.serviceType === 'builtin' ||
         authProvider.serviceType === 'http-header' ||
-        authProvider.serviceType === 'sourcegraph-operator'
+        authProvider.serviceType === 'sourcegraph-operator' ||
+        authProvider.serviceType === 'ldap'
     ) {
         return null
     }
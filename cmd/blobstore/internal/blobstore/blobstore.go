@@ -2,7 +2,19 @@
 package blobstore
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -10,23 +22,664 @@ import (
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // Service is the blobstore service. It is an http.Handler.
+//
+// Only the subset of the S3 API used by the uploadstore package is implemented, using
+// path-style addressing (e.g. PUT /bucket/key):
+//
+//   - CreateBucket
+//   - PutObject, GetObject, HeadObject, DeleteObject
+//   - CreateMultipartUpload, UploadPart, UploadPartCopy, CompleteMultipartUpload, AbortMultipartUpload
+//   - ListObjectsV2 (by prefix), DeleteObjects
+//
+// Object expiry is driven by clients, which list objects by prefix and delete the ones
+// that are too old (see uploadstore.NewExpirer).
+//
+// Data is stored on disk under DataDir with the following layout:
+//
+//	buckets/<bucket>/objects/<escaped key>
+//	buckets/<bucket>/multipart-uploads/<upload id>/key
+//	buckets/<bucket>/multipart-uploads/<upload id>/part-<part number>
+//	tmp/
+//
+// All writes go to a temporary file first which is renamed into place once complete, so
+// readers never observe partially written objects.
 type Service struct {
 	DataDir        string
 	Log            log.Logger
 	ObservationCtx *observation.Context
 }
 
-// ServeHTTP handles HTTP based search requests
+// ServeHTTP handles S3 API requests.
 func (s *Service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
 	metricRunning.Inc()
 	defer metricRunning.Dec()
 
-	// TODO(blobstore): handle requests
-	_ = ctx
+	sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+	if err := s.serve(sw, r); err != nil {
+		s.serveError(sw, r, err)
+	}
+	metricRequestTotal.WithLabelValues(strconv.Itoa(sw.status)).Inc()
+}
+
+func (s *Service) serve(w http.ResponseWriter, r *http.Request) error {
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket == "" {
+		// ListBuckets is not supported.
+		return errNotImplemented
+	}
+	if !validBucketName.MatchString(bucket) {
+		return errInvalidBucketName
+	}
+
+	query := r.URL.Query()
+	if key == "" {
+		switch r.Method {
+		case http.MethodPut:
+			return s.createBucket(w, bucket)
+		case http.MethodGet:
+			if query.Get("list-type") == "2" {
+				return s.listObjectsV2(w, r, bucket)
+			}
+		case http.MethodPost:
+			if query.Has("delete") {
+				return s.deleteObjects(w, r, bucket)
+			}
+		}
+		return errNotImplemented
+	}
+
+	if !validKey(key) {
+		return errInvalidKey
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		if query.Has("uploadId") {
+			if r.Header.Get("x-amz-copy-source") != "" {
+				return s.uploadPartCopy(w, r, bucket, key)
+			}
+			return s.uploadPart(w, r, bucket, key)
+		}
+		if r.Header.Get("x-amz-copy-source") != "" {
+			// CopyObject is not supported.
+			return errNotImplemented
+		}
+		return s.putObject(w, r, bucket, key)
+	case http.MethodGet, http.MethodHead:
+		return s.getObject(w, r, bucket, key)
+	case http.MethodDelete:
+		if query.Has("uploadId") {
+			return s.abortMultipartUpload(w, r, bucket, key)
+		}
+		return s.deleteObject(w, bucket, key)
+	case http.MethodPost:
+		if query.Has("uploads") {
+			return s.createMultipartUpload(w, bucket, key)
+		}
+		if query.Has("uploadId") {
+			return s.completeMultipartUpload(w, r, bucket, key)
+		}
+	}
+
+	return errNotImplemented
+}
+
+// serveError writes the given error as an S3 error response. Errors which are not S3
+// errors are logged and reported as internal errors.
+func (s *Service) serveError(w http.ResponseWriter, r *http.Request, err error) {
+	var s3Err *s3Error
+	if !errors.As(err, &s3Err) {
+		s.Log.Error("failed to handle request",
+			log.String("method", r.Method),
+			log.String("path", r.URL.Path),
+			log.Error(err))
+		s3Err = newS3Error(http.StatusInternalServerError, "InternalError", "We encountered an internal error. Please try again.")
+	}
+
+	body := *s3Err
+	body.Resource = r.URL.Path
+	writeXML(w, s3Err.status, &body)
+}
+
+func (s *Service) createBucket(w http.ResponseWriter, bucket string) error {
+	dir := s.bucketDir(bucket)
+	if _, err := os.Stat(dir); err == nil {
+		return errBucketAlreadyOwnedByYou
+	}
+
+	// Build the bucket directory layout in a temporary location and move it into place
+	// so that concurrent requests never observe a partially created bucket.
+	tmp, err := s.tempDir()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	for _, name := range []string{"objects", "multipart-uploads"} {
+		if err := os.Mkdir(filepath.Join(tmp, name), 0o755); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(dir), 0o755); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		if _, statErr := os.Stat(dir); statErr == nil {
+			return errBucketAlreadyOwnedByYou
+		}
+		return err
+	}
+
+	w.Header().Set("Location", "/"+bucket)
+	w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+func (s *Service) putObject(w http.ResponseWriter, r *http.Request, bucket, key string) error {
+	if err := s.checkBucket(bucket); err != nil {
+		return err
+	}
+
+	info, err := s.writeFile(s.objectPath(bucket, key), r.Body)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("ETag", etag(info))
+	w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+func (s *Service) getObject(w http.ResponseWriter, r *http.Request, bucket, key string) error {
+	if err := s.checkBucket(bucket); err != nil {
+		return err
+	}
+
+	f, err := os.Open(s.objectPath(bucket, key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errNoSuchKey
+		}
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("ETag", etag(info))
+	// ServeContent takes care of HEAD requests and Range headers.
+	http.ServeContent(w, r, "", info.ModTime(), f)
+	return nil
+}
+
+func (s *Service) deleteObject(w http.ResponseWriter, bucket, key string) error {
+	if err := s.checkBucket(bucket); err != nil {
+		return err
+	}
+
+	// Deleting a key which does not exist is not an error.
+	if err := os.Remove(s.objectPath(bucket, key)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *Service) createMultipartUpload(w http.ResponseWriter, bucket, key string) error {
+	if err := s.checkBucket(bucket); err != nil {
+		return err
+	}
+
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return err
+	}
+	uploadID := hex.EncodeToString(b[:])
+
+	dir := s.uploadDir(bucket, uploadID)
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		return err
+	}
+	if _, err := s.writeFile(filepath.Join(dir, "key"), strings.NewReader(key)); err != nil {
+		return err
+	}
+
+	writeXML(w, http.StatusOK, &initiateMultipartUploadResult{
+		Xmlns:    s3XMLNamespace,
+		Bucket:   bucket,
+		Key:      key,
+		UploadID: uploadID,
+	})
+	return nil
+}
+
+func (s *Service) uploadPart(w http.ResponseWriter, r *http.Request, bucket, key string) error {
+	partPath, err := s.partPath(r, bucket, key)
+	if err != nil {
+		return err
+	}
+
+	info, err := s.writeFile(partPath, r.Body)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("ETag", etag(info))
+	w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+func (s *Service) uploadPartCopy(w http.ResponseWriter, r *http.Request, bucket, key string) error {
+	if r.Header.Get("x-amz-copy-source-range") != "" {
+		return errNotImplemented
+	}
+
+	partPath, err := s.partPath(r, bucket, key)
+	if err != nil {
+		return err
+	}
+
+	// The copy source has the form "bucket/key" with an optional leading slash and
+	// an optional version ID suffix, and may be URL-encoded.
+	source := r.Header.Get("x-amz-copy-source")
+	source, _, _ = strings.Cut(source, "?versionId=")
+	if unescaped, err := url.PathUnescape(source); err == nil {
+		source = unescaped
+	}
+	sourceBucket, sourceKey, _ := strings.Cut(strings.TrimPrefix(source, "/"), "/")
+	if !validBucketName.MatchString(sourceBucket) {
+		return errInvalidBucketName
+	}
+	if !validKey(sourceKey) {
+		return errInvalidKey
+	}
+	if err := s.checkBucket(sourceBucket); err != nil {
+		return err
+	}
+
+	f, err := os.Open(s.objectPath(sourceBucket, sourceKey))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errNoSuchKey
+		}
+		return err
+	}
+	defer f.Close()
+
+	info, err := s.writeFile(partPath, f)
+	if err != nil {
+		return err
+	}
+
+	writeXML(w, http.StatusOK, &copyPartResult{
+		Xmlns:        s3XMLNamespace,
+		ETag:         etag(info),
+		LastModified: info.ModTime().UTC(),
+	})
+	return nil
+}
+
+func (s *Service) completeMultipartUpload(w http.ResponseWriter, r *http.Request, bucket, key string) error {
+	dir, err := s.checkUpload(r, bucket, key)
+	if err != nil {
+		return err
+	}
+
+	var req completeMultipartUpload
+	if err := xml.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errMalformedXML
+	}
+	if len(req.Parts) == 0 {
+		return errMalformedXML
+	}
+
+	var (
+		files   []*os.File
+		readers []io.Reader
+	)
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+	for i, part := range req.Parts {
+		if i > 0 && part.PartNumber <= req.Parts[i-1].PartNumber {
+			return newS3Error(http.StatusBadRequest, "InvalidPartOrder", "The list of parts was not in ascending order.")
+		}
+
+		f, err := os.Open(filepath.Join(dir, partFilename(part.PartNumber)))
+		if err != nil {
+			if os.IsNotExist(err) {
+				return errInvalidPart
+			}
+			return err
+		}
+		files = append(files, f)
+		readers = append(readers, f)
+	}
+
+	info, err := s.writeFile(s.objectPath(bucket, key), io.MultiReader(readers...))
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		s.Log.Warn("failed to remove completed multipart upload", log.String("dir", dir), log.Error(err))
+	}
+
+	writeXML(w, http.StatusOK, &completeMultipartUploadResult{
+		Xmlns:  s3XMLNamespace,
+		Bucket: bucket,
+		Key:    key,
+		ETag:   etag(info),
+	})
+	return nil
+}
+
+func (s *Service) abortMultipartUpload(w http.ResponseWriter, r *http.Request, bucket, key string) error {
+	dir, err := s.checkUpload(r, bucket, key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// defaultMaxKeys is the maximum number of keys returned by a single ListObjectsV2 request.
+const defaultMaxKeys = 1000
+
+func (s *Service) listObjectsV2(w http.ResponseWriter, r *http.Request, bucket string) error {
+	if err := s.checkBucket(bucket); err != nil {
+		return err
+	}
+
+	query := r.URL.Query()
+	if query.Get("delimiter") != "" {
+		return errNotImplemented
+	}
+
+	maxKeys := defaultMaxKeys
+	if v := query.Get("max-keys"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return newS3Error(http.StatusBadRequest, "InvalidArgument", "Invalid max-keys.")
+		}
+		if n < maxKeys {
+			maxKeys = n
+		}
+	}
+
+	// Continuation tokens are the last key returned by the previous page.
+	prefix := query.Get("prefix")
+	after := query.Get("start-after")
+	if token := query.Get("continuation-token"); token != "" {
+		decoded, err := hex.DecodeString(token)
+		if err != nil {
+			return newS3Error(http.StatusBadRequest, "InvalidArgument", "The continuation token provided is incorrect.")
+		}
+		after = string(decoded)
+	}
+
+	keys, err := s.listKeys(bucket, prefix, after)
+	if err != nil {
+		return err
+	}
+
+	result := &listBucketResult{
+		Xmlns:             s3XMLNamespace,
+		Name:              bucket,
+		Prefix:            prefix,
+		MaxKeys:           maxKeys,
+		ContinuationToken: query.Get("continuation-token"),
+		StartAfter:        query.Get("start-after"),
+	}
+	for _, key := range keys {
+		if len(result.Contents) == maxKeys {
+			result.IsTruncated = true
+			result.NextContinuationToken = hex.EncodeToString([]byte(result.Contents[len(result.Contents)-1].Key))
+			break
+		}
+
+		info, err := os.Stat(s.objectPath(bucket, key))
+		if err != nil {
+			if os.IsNotExist(err) {
+				// Deleted concurrently
+				continue
+			}
+			return err
+		}
+
+		result.Contents = append(result.Contents, objectResult{
+			Key:          key,
+			LastModified: info.ModTime().UTC(),
+			ETag:         etag(info),
+			Size:         info.Size(),
+			StorageClass: "STANDARD",
+		})
+	}
+	result.KeyCount = len(result.Contents)
+
+	writeXML(w, http.StatusOK, result)
+	return nil
+}
+
+// listKeys returns the sorted keys in the given bucket which have the given prefix and
+// sort after the given key.
+func (s *Service) listKeys(bucket, prefix, after string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(s.bucketDir(bucket), "objects"))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		key, err := url.PathUnescape(entry.Name())
+		if err != nil {
+			continue
+		}
+		if strings.HasPrefix(key, prefix) && key > after {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+func (s *Service) deleteObjects(w http.ResponseWriter, r *http.Request, bucket string) error {
+	if err := s.checkBucket(bucket); err != nil {
+		return err
+	}
+
+	var req deleteRequest
+	if err := xml.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errMalformedXML
+	}
+
+	result := &deleteResult{Xmlns: s3XMLNamespace}
+	for _, object := range req.Objects {
+		if !validKey(object.Key) {
+			return errInvalidKey
+		}
+		if err := os.Remove(s.objectPath(bucket, object.Key)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if !req.Quiet {
+			result.Deleted = append(result.Deleted, object)
+		}
+	}
+
+	writeXML(w, http.StatusOK, result)
+	return nil
+}
+
+func (s *Service) bucketDir(bucket string) string {
+	return filepath.Join(s.DataDir, "buckets", bucket)
+}
+
+func (s *Service) objectPath(bucket, key string) string {
+	return filepath.Join(s.bucketDir(bucket), "objects", url.PathEscape(key))
+}
+
+func (s *Service) uploadDir(bucket, uploadID string) string {
+	return filepath.Join(s.bucketDir(bucket), "multipart-uploads", uploadID)
+}
+
+// checkBucket returns errNoSuchBucket if the given bucket has not been created.
+func (s *Service) checkBucket(bucket string) error {
+	if _, err := os.Stat(s.bucketDir(bucket)); err != nil {
+		if os.IsNotExist(err) {
+			return errNoSuchBucket
+		}
+		return err
+	}
+	return nil
+}
+
+// checkUpload returns the directory of the multipart upload referenced by the uploadId
+// query parameter of the given request. An error is returned if the upload does not
+// exist or belongs to a different key.
+func (s *Service) checkUpload(r *http.Request, bucket, key string) (string, error) {
+	if err := s.checkBucket(bucket); err != nil {
+		return "", err
+	}
+
+	uploadID := r.URL.Query().Get("uploadId")
+	if !validUploadID.MatchString(uploadID) {
+		return "", errNoSuchUpload
+	}
+
+	dir := s.uploadDir(bucket, uploadID)
+	uploadKey, err := os.ReadFile(filepath.Join(dir, "key"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", errNoSuchUpload
+		}
+		return "", err
+	}
+	if string(uploadKey) != key {
+		return "", errNoSuchUpload
+	}
+
+	return dir, nil
+}
+
+// maxPartNumber is the largest part number accepted by S3.
+const maxPartNumber = 10000
+
+// partPath returns the path of the part referenced by the uploadId and partNumber query
+// parameters of the given request.
+func (s *Service) partPath(r *http.Request, bucket, key string) (string, error) {
+	dir, err := s.checkUpload(r, bucket, key)
+	if err != nil {
+		return "", err
+	}
+
+	partNumber, err := strconv.Atoi(r.URL.Query().Get("partNumber"))
+	if err != nil || partNumber < 1 || partNumber > maxPartNumber {
+		return "", newS3Error(http.StatusBadRequest, "InvalidArgument", fmt.Sprintf("Part number must be an integer between 1 and %d, inclusive.", maxPartNumber))
+	}
+
+	return filepath.Join(dir, partFilename(partNumber)), nil
+}
+
+func partFilename(partNumber int) string {
+	return fmt.Sprintf("part-%05d", partNumber)
+}
+
+// writeFile atomically writes the content of the given reader to the given path and
+// returns the file info of the written file.
+func (s *Service) writeFile(path string, r io.Reader) (_ os.FileInfo, err error) {
+	tmpDir := filepath.Join(s.DataDir, "tmp")
+	if err := os.MkdirAll(tmpDir, 0o755); err != nil {
+		return nil, err
+	}
+
+	f, err := os.CreateTemp(tmpDir, "object-*")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return nil, errors.Wrap(err, "writing object")
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return nil, err
+	}
+
+	return os.Stat(path)
+}
+
+// tempDir creates a new temporary directory on the same filesystem as the buckets.
+func (s *Service) tempDir() (string, error) {
+	tmpDir := filepath.Join(s.DataDir, "tmp")
+	if err := os.MkdirAll(tmpDir, 0o755); err != nil {
+		return "", err
+	}
+	return os.MkdirTemp(tmpDir, "bucket-*")
+}
+
+// validBucketName matches the S3 bucket naming rules.
+var validBucketName = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+// validUploadID matches the upload IDs created by createMultipartUpload.
+var validUploadID = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// maxEscapedKeyLength is the largest filename length supported by common filesystems.
+const maxEscapedKeyLength = 255
+
+func validKey(key string) bool {
+	if key == "" || key == "." || key == ".." {
+		return false
+	}
+	return len(url.PathEscape(key)) <= maxEscapedKeyLength
+}
+
+// etag returns an entity tag for the object with the given file info. It is derived from
+// the size and modification time of the file rather than its content, which is enough for
+// clients to detect changes.
+func etag(info os.FileInfo) string {
+	return fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size())
+}
+
+func writeXML(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, xml.Header)
+	_ = xml.NewEncoder(w).Encode(v)
+}
+
+// statusWriter records the status code written to the underlying response writer.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
 }
 
 var (
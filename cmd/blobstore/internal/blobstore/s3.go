@@ -0,0 +1,121 @@
+package blobstore
+
+import (
+	"encoding/xml"
+	"net/http"
+	"time"
+)
+
+// The types in this file mirror the XML request and response bodies of the subset of
+// the S3 API that blobstore implements. See https://docs.aws.amazon.com/AmazonS3/latest/API/
+
+const s3XMLNamespace = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+// s3Error is the body returned for any failed request.
+type s3Error struct {
+	XMLName   xml.Name `xml:"Error"`
+	Code      string   `xml:"Code"`
+	Message   string   `xml:"Message"`
+	Resource  string   `xml:"Resource,omitempty"`
+	RequestID string   `xml:"RequestId"`
+
+	status int
+}
+
+func (e *s3Error) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+func newS3Error(status int, code, message string) *s3Error {
+	return &s3Error{status: status, Code: code, Message: message}
+}
+
+var (
+	errNoSuchBucket            = newS3Error(http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist.")
+	errNoSuchKey               = newS3Error(http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+	errNoSuchUpload            = newS3Error(http.StatusNotFound, "NoSuchUpload", "The specified multipart upload does not exist.")
+	errBucketAlreadyOwnedByYou = newS3Error(http.StatusConflict, "BucketAlreadyOwnedByYou", "The bucket you tried to create already exists, and you own it.")
+	errInvalidBucketName       = newS3Error(http.StatusBadRequest, "InvalidBucketName", "The specified bucket is not valid.")
+	errInvalidKey              = newS3Error(http.StatusBadRequest, "InvalidArgument", "The specified key is not valid.")
+	errInvalidPart             = newS3Error(http.StatusBadRequest, "InvalidPart", "One or more of the specified parts could not be found.")
+	errMalformedXML            = newS3Error(http.StatusBadRequest, "MalformedXML", "The XML you provided was not well-formed or did not validate against our published schema.")
+	errNotImplemented          = newS3Error(http.StatusNotImplemented, "NotImplemented", "A header or query you provided implies functionality that is not implemented.")
+)
+
+// initiateMultipartUploadResult is the response body of CreateMultipartUpload.
+type initiateMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Bucket   string   `xml:"Bucket"`
+	Key      string   `xml:"Key"`
+	UploadID string   `xml:"UploadId"`
+}
+
+// copyPartResult is the response body of UploadPartCopy.
+type copyPartResult struct {
+	XMLName      xml.Name  `xml:"CopyPartResult"`
+	Xmlns        string    `xml:"xmlns,attr"`
+	ETag         string    `xml:"ETag"`
+	LastModified time.Time `xml:"LastModified"`
+}
+
+// completeMultipartUpload is the request body of CompleteMultipartUpload.
+type completeMultipartUpload struct {
+	XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+	Parts   []completedPart `xml:"Part"`
+}
+
+type completedPart struct {
+	ETag       string `xml:"ETag"`
+	PartNumber int    `xml:"PartNumber"`
+}
+
+// completeMultipartUploadResult is the response body of CompleteMultipartUpload.
+type completeMultipartUploadResult struct {
+	XMLName xml.Name `xml:"CompleteMultipartUploadResult"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Bucket  string   `xml:"Bucket"`
+	Key     string   `xml:"Key"`
+	ETag    string   `xml:"ETag"`
+}
+
+// listBucketResult is the response body of ListObjectsV2.
+type listBucketResult struct {
+	XMLName               xml.Name       `xml:"ListBucketResult"`
+	Xmlns                 string         `xml:"xmlns,attr"`
+	Name                  string         `xml:"Name"`
+	Prefix                string         `xml:"Prefix"`
+	KeyCount              int            `xml:"KeyCount"`
+	MaxKeys               int            `xml:"MaxKeys"`
+	IsTruncated           bool           `xml:"IsTruncated"`
+	ContinuationToken     string         `xml:"ContinuationToken,omitempty"`
+	NextContinuationToken string         `xml:"NextContinuationToken,omitempty"`
+	StartAfter            string         `xml:"StartAfter,omitempty"`
+	Contents              []objectResult `xml:"Contents"`
+}
+
+type objectResult struct {
+	Key          string    `xml:"Key"`
+	LastModified time.Time `xml:"LastModified"`
+	ETag         string    `xml:"ETag"`
+	Size         int64     `xml:"Size"`
+	StorageClass string    `xml:"StorageClass"`
+}
+
+// deleteRequest is the request body of DeleteObjects.
+type deleteRequest struct {
+	XMLName xml.Name           `xml:"Delete"`
+	Objects []objectIdentifier `xml:"Object"`
+	Quiet   bool               `xml:"Quiet"`
+}
+
+type objectIdentifier struct {
+	Key string `xml:"Key"`
+}
+
+// deleteResult is the response body of DeleteObjects.
+type deleteResult struct {
+	XMLName xml.Name           `xml:"DeleteResult"`
+	Xmlns   string             `xml:"xmlns,attr"`
+	Deleted []objectIdentifier `xml:"Deleted"`
+}
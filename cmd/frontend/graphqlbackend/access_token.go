@@ -69,3 +69,7 @@ func (r *accessTokenResolver) CreatedAt() gqlutil.DateTime {
 func (r *accessTokenResolver) LastUsedAt() *gqlutil.DateTime {
 	return gqlutil.DateTimeOrNil(r.accessToken.LastUsedAt)
 }
+
+func (r *accessTokenResolver) ExpiresAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.accessToken.ExpiresAt)
+}
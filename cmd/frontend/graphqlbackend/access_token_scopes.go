@@ -0,0 +1,285 @@
+package graphqlbackend
+
+import (
+	"strings"
+
+	"github.com/graph-gophers/graphql-go/types"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// scopedFields maps the top-level fields of the query and mutation types to
+// the narrow access token scope that is required to use them. Fields that are
+// not listed here can only be used with the user:all scope.
+var scopedFields = map[string]map[string]string{
+	"query": {
+		"search":             authz.ScopeSearchRead,
+		"repository":         authz.ScopeRepoRead,
+		"repositories":       authz.ScopeRepoRead,
+		"repositoryRedirect": authz.ScopeRepoRead,
+		"batchChange":        authz.ScopeBatchChangesWrite,
+		"batchChanges":       authz.ScopeBatchChangesWrite,
+		"batchSpecs":         authz.ScopeBatchChangesWrite,
+		"namespaceByName":    authz.ScopeBatchChangesWrite,
+	},
+	"mutation": {
+		"createBatchSpec":        authz.ScopeBatchChangesWrite,
+		"createBatchSpecFromRaw": authz.ScopeBatchChangesWrite,
+		"replaceBatchSpecInput":  authz.ScopeBatchChangesWrite,
+		"executeBatchSpec":       authz.ScopeBatchChangesWrite,
+		"createChangesetSpec":    authz.ScopeBatchChangesWrite,
+		"createBatchChange":      authz.ScopeBatchChangesWrite,
+		"applyBatchChange":       authz.ScopeBatchChangesWrite,
+		"closeBatchChange":       authz.ScopeBatchChangesWrite,
+		"moveBatchChange":        authz.ScopeBatchChangesWrite,
+		"deleteBatchChange":      authz.ScopeBatchChangesWrite,
+	},
+}
+
+// unscopedFields are top-level fields that may be used with any narrow scope,
+// for example so that clients can introspect the schema and verify the token.
+var unscopedFields = map[string]struct{}{
+	"__typename":  {},
+	"__schema":    {},
+	"__type":      {},
+	"currentUser": {},
+}
+
+// identityOnlyTypes are the types of which narrow scopes only grant access to
+// identityFields, wherever they occur in a query. Otherwise a user reachable
+// from any scoped field, such as the creator of a batch change or the author
+// of a commit, would give access to everything reachable from the user, such
+// as their settings, access tokens and organizations. Interfaces and unions
+// that these types implement or belong to, such as Namespace, are restricted
+// too.
+var identityOnlyTypes = map[string]struct{}{
+	"User": {},
+	"Org":  {},
+}
+
+// identityFields are the fields of identityOnlyTypes that narrow scopes grant
+// access to.
+var identityFields = map[string]struct{}{
+	"__typename":    {},
+	"id":            {},
+	"databaseID":    {},
+	"username":      {},
+	"name":          {},
+	"namespaceName": {},
+	"displayName":   {},
+	"avatarURL":     {},
+	"url":           {},
+	"siteAdmin":     {},
+}
+
+// CheckAccessTokenScopes returns an error if a request authenticated with an
+// access token that only has the given narrow scopes may not execute the
+// query. The top-level fields of the selected operations must be granted by
+// the scopes, and of identityOnlyTypes only identityFields may be selected at
+// any depth. Otherwise, the resolvers below the top-level fields enforce the
+// usual permissions of the token's user.
+func CheckAccessTokenScopes(schema *types.Schema, query, operationName string, scopes []string) error {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return errors.Wrap(err, "parsing query")
+	}
+
+	c := &scopeChecker{
+		schema:    schema,
+		fragments: map[string]*ast.FragmentDefinition{},
+		scopes:    scopes,
+	}
+	for _, def := range doc.Definitions {
+		if frag, ok := def.(*ast.FragmentDefinition); ok {
+			c.fragments[frag.Name.Value] = frag
+		}
+	}
+
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if operationName != "" && (op.Name == nil || op.Name.Value != operationName) {
+			continue
+		}
+		fields, ok := scopedFields[op.Operation]
+		if !ok {
+			return errors.Errorf("access token does not allow %s operations", op.Operation)
+		}
+		root, ok := schema.EntryPoints[op.Operation]
+		if !ok {
+			return errors.Errorf("schema has no %s type", op.Operation)
+		}
+		if err := c.checkRootSelection(root, op.SelectionSet, fields, map[string]struct{}{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type scopeChecker struct {
+	schema    *types.Schema
+	fragments map[string]*ast.FragmentDefinition
+	scopes    []string
+}
+
+// checkRootSelection checks the top-level fields of an operation against the
+// scopes, and the selections below them against identityOnlyTypes.
+func (c *scopeChecker) checkRootSelection(root types.NamedType, set *ast.SelectionSet, fields map[string]string, seenFragments map[string]struct{}) error {
+	if set == nil {
+		return nil
+	}
+	for _, sel := range set.Selections {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			name := sel.Name.Value
+			if _, ok := unscopedFields[name]; !ok {
+				scope, ok := fields[name]
+				if !ok || !authz.ScopesAllow(c.scopes, scope) {
+					return errors.Errorf("access token does not have the required scope to access field %q", name)
+				}
+			}
+			if err := c.checkFieldSelection(root, sel, map[string]struct{}{}); err != nil {
+				return err
+			}
+		case *ast.InlineFragment:
+			if err := c.checkRootSelection(root, sel.SelectionSet, fields, seenFragments); err != nil {
+				return err
+			}
+		case *ast.FragmentSpread:
+			frag, ok, err := c.fragment(sel, seenFragments)
+			if err != nil || !ok {
+				return err
+			}
+			if err := c.checkRootSelection(root, frag.SelectionSet, fields, seenFragments); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkSelection returns an error if the selection set, of the given parent
+// type, selects anything but identityFields of identityOnlyTypes.
+func (c *scopeChecker) checkSelection(parent types.NamedType, set *ast.SelectionSet, seenFragments map[string]struct{}) error {
+	if set == nil {
+		return nil
+	}
+	for _, sel := range set.Selections {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			if isIdentityOnlyType(parent) {
+				if _, ok := identityFields[sel.Name.Value]; !ok || sel.SelectionSet != nil {
+					return errors.Errorf("access token does not have the required scope to access field %q of %q", sel.Name.Value, typeName(parent))
+				}
+				continue
+			}
+			if err := c.checkFieldSelection(parent, sel, seenFragments); err != nil {
+				return err
+			}
+		case *ast.InlineFragment:
+			typ := parent
+			if sel.TypeCondition != nil {
+				typ = c.schema.Types[sel.TypeCondition.Name.Value]
+			}
+			if err := c.checkSelection(typ, sel.SelectionSet, seenFragments); err != nil {
+				return err
+			}
+		case *ast.FragmentSpread:
+			frag, ok, err := c.fragment(sel, seenFragments)
+			if err != nil || !ok {
+				return err
+			}
+			if err := c.checkSelection(c.schema.Types[frag.TypeCondition.Name.Value], frag.SelectionSet, seenFragments); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkFieldSelection checks the selection set of a field of the given parent
+// type against the type of the field.
+func (c *scopeChecker) checkFieldSelection(parent types.NamedType, field *ast.Field, seenFragments map[string]struct{}) error {
+	if field.SelectionSet == nil || strings.HasPrefix(field.Name.Value, "__") {
+		// Scalars, enums and introspection types do not lead to users.
+		return nil
+	}
+
+	var fields types.FieldsDefinition
+	switch t := parent.(type) {
+	case *types.ObjectTypeDefinition:
+		fields = t.Fields
+	case *types.InterfaceTypeDefinition:
+		fields = t.Fields
+	}
+	def := fields.Get(field.Name.Value)
+	if def == nil {
+		return errors.Errorf("unknown field %q of %q", field.Name.Value, typeName(parent))
+	}
+	return c.checkSelection(namedType(def.Type), field.SelectionSet, seenFragments)
+}
+
+// fragment returns the definition of a spread fragment, or false if it was
+// already checked.
+func (c *scopeChecker) fragment(spread *ast.FragmentSpread, seenFragments map[string]struct{}) (*ast.FragmentDefinition, bool, error) {
+	name := spread.Name.Value
+	if _, seen := seenFragments[name]; seen {
+		return nil, false, nil
+	}
+	seenFragments[name] = struct{}{}
+	frag, ok := c.fragments[name]
+	if !ok {
+		return nil, false, errors.Errorf("unknown fragment %q", name)
+	}
+	return frag, true, nil
+}
+
+// isIdentityOnlyType returns whether t is one of identityOnlyTypes, or an
+// interface or union that one of them implements or belongs to.
+func isIdentityOnlyType(t types.NamedType) bool {
+	switch t := t.(type) {
+	case *types.ObjectTypeDefinition:
+		_, ok := identityOnlyTypes[t.Name]
+		return ok
+	case *types.InterfaceTypeDefinition:
+		for _, possible := range t.PossibleTypes {
+			if isIdentityOnlyType(possible) {
+				return true
+			}
+		}
+	case *types.Union:
+		for _, member := range t.UnionMemberTypes {
+			if isIdentityOnlyType(member) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// namedType unwraps the list and non-null types around t.
+func namedType(t types.Type) types.NamedType {
+	for {
+		switch u := t.(type) {
+		case *types.List:
+			t = u.OfType
+		case *types.NonNull:
+			t = u.OfType
+		default:
+			named, _ := t.(types.NamedType)
+			return named
+		}
+	}
+}
+
+func typeName(t types.NamedType) string {
+	if t == nil {
+		return ""
+	}
+	return t.TypeName()
+}
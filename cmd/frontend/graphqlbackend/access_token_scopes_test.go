@@ -0,0 +1,145 @@
+package graphqlbackend
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+)
+
+func TestCheckAccessTokenScopes(t *testing.T) {
+	schema := graphql.MustParseSchema(strings.Join([]string{mainSchema, batchesSchema}, "\n"), nil).ASTSchema()
+
+	tests := []struct {
+		name          string
+		query         string
+		operationName string
+		scopes        []string
+		wantErr       bool
+	}{
+		{
+			name:   "search with search scope",
+			query:  `query { search(query: "foo") { results { matchCount } } }`,
+			scopes: []string{authz.ScopeSearchRead},
+		},
+		{
+			name:    "search without search scope",
+			query:   `query { search(query: "foo") { results { matchCount } } }`,
+			scopes:  []string{authz.ScopeRepoRead},
+			wantErr: true,
+		},
+		{
+			name:   "implied scope",
+			query:  `query { repository(name: "r") { id } }`,
+			scopes: []string{authz.ScopeExecutor},
+		},
+		{
+			name:   "introspection and current user",
+			query:  `query { __typename currentUser { username } }`,
+			scopes: []string{authz.ScopeCodeIntelUpload},
+		},
+		{
+			name:    "current user beyond identity fields",
+			query:   `query { currentUser { username accessTokens { nodes { id } } } }`,
+			scopes:  []string{authz.ScopeSearchRead},
+			wantErr: true,
+		},
+		{
+			name:    "current user beyond identity fields in fragment",
+			query:   `query { currentUser { ...U } } fragment U on User { id settingsCascade { final } }`,
+			scopes:  []string{authz.ScopeSearchRead},
+			wantErr: true,
+		},
+		{
+			name:   "namespace identity fields",
+			query:  `query { namespaceByName(name: "n") { __typename id namespaceName ... on User { username } ... on Org { name } } }`,
+			scopes: []string{authz.ScopeBatchChangesWrite},
+		},
+		{
+			name:    "namespace beyond identity fields",
+			query:   `query { namespaceByName(name: "n") { ... on User { organizations { nodes { id } } } } }`,
+			scopes:  []string{authz.ScopeBatchChangesWrite},
+			wantErr: true,
+		},
+		{
+			name:   "nested user identity fields",
+			query:  `query { batchChanges { nodes { creator { username avatarURL } lastApplier { ...U } } } } fragment U on User { id url }`,
+			scopes: []string{authz.ScopeBatchChangesWrite},
+		},
+		{
+			name:    "nested user beyond identity fields",
+			query:   `query { batchChanges { nodes { creator { username emails { email } } } } }`,
+			scopes:  []string{authz.ScopeBatchChangesWrite},
+			wantErr: true,
+		},
+		{
+			name:    "nested user beyond identity fields in fragment",
+			query:   `query { batchChange(name: "b", namespace: "n") { lastApplier { ...U } } } fragment U on User { settingsCascade { final } }`,
+			scopes:  []string{authz.ScopeBatchChangesWrite},
+			wantErr: true,
+		},
+		{
+			name:    "nested namespace beyond identity fields",
+			query:   `query { batchChanges { nodes { namespace { namespaceName ... on User { emails { email } } } } } }`,
+			scopes:  []string{authz.ScopeBatchChangesWrite},
+			wantErr: true,
+		},
+		{
+			name:   "commit author identity fields",
+			query:  `query { repository(name: "r") { commit(rev: "HEAD") { author { person { email user { username } } } } } }`,
+			scopes: []string{authz.ScopeRepoRead},
+		},
+		{
+			name:    "commit author beyond identity fields",
+			query:   `query { repository(name: "r") { commit(rev: "HEAD") { author { person { user { accessTokens { nodes { id } } } } } } } }`,
+			scopes:  []string{authz.ScopeRepoRead},
+			wantErr: true,
+		},
+		{
+			name:    "search commit author beyond identity fields",
+			query:   `query { search(query: "type:commit") { results { results { ... on CommitSearchResult { commit { author { person { user { organizations { nodes { name } } } } } } } } } } }`,
+			scopes:  []string{authz.ScopeSearchRead},
+			wantErr: true,
+		},
+		{
+			name:    "unscoped field",
+			query:   `query { site { id } }`,
+			scopes:  []string{authz.ScopeSearchRead, authz.ScopeRepoRead},
+			wantErr: true,
+		},
+		{
+			name:    "unscoped field in fragment",
+			query:   `query { ...F } fragment F on Query { search(query: "foo") { __typename } users { totalCount } }`,
+			scopes:  []string{authz.ScopeSearchRead},
+			wantErr: true,
+		},
+		{
+			name:   "batch changes mutation",
+			query:  `mutation { createBatchSpecFromRaw(batchSpec: "", namespace: "n") { id } }`,
+			scopes: []string{authz.ScopeBatchChangesWrite},
+		},
+		{
+			name:    "unscoped mutation",
+			query:   `mutation { createAccessToken(user: "u", scopes: ["user:all"], note: "n") { token } }`,
+			scopes:  []string{authz.ScopeBatchChangesWrite},
+			wantErr: true,
+		},
+		{
+			name:          "only selected operation is checked",
+			query:         `query A { search(query: "foo") { __typename } } query B { site { id } }`,
+			operationName: "A",
+			scopes:        []string{authz.ScopeSearchRead},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckAccessTokenScopes(schema, tc.query, tc.operationName, tc.scopes)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("got error %v, want error %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/graph-gophers/graphql-go"
 
@@ -17,13 +18,15 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type createAccessTokenInput struct {
-	User   graphql.ID
-	Scopes []string
-	Note   string
+	User      graphql.ID
+	Scopes    []string
+	Note      string
+	ExpiresAt *gqlutil.DateTime
 }
 
 func (r *schemaResolver) CreateAccessToken(ctx context.Context, args *createAccessTokenInput) (*createAccessTokenResult, error) {
@@ -60,7 +63,7 @@ func (r *schemaResolver) CreateAccessToken(ctx context.Context, args *createAcce
 	}
 
 	// Validate scopes.
-	var hasUserAllScope bool
+	var hasUserAllScope, hasNarrowScope bool
 	seenScope := map[string]struct{}{}
 	sort.Strings(args.Scopes)
 	for _, scope := range args.Scopes {
@@ -75,7 +78,10 @@ func (r *schemaResolver) CreateAccessToken(ctx context.Context, args *createAcce
 				return nil, errors.Errorf("creation of access tokens with scope %q is disabled on Sourcegraph.com", authz.ScopeSiteAdminSudo)
 			}
 		default:
-			return nil, errors.Errorf("unknown access token scope %q (valid scopes: %q)", scope, authz.AllScopes)
+			if !authz.IsNarrowScope(scope) {
+				return nil, errors.Errorf("unknown access token scope %q (valid scopes: %q)", scope, authz.AllScopes)
+			}
+			hasNarrowScope = true
 		}
 
 		if _, seen := seenScope[scope]; seen {
@@ -83,11 +89,24 @@ func (r *schemaResolver) CreateAccessToken(ctx context.Context, args *createAcce
 		}
 		seenScope[scope] = struct{}{}
 	}
-	if !hasUserAllScope {
-		return nil, errors.Errorf("all access tokens must have scope %q", authz.ScopeUserAll)
+	if !hasUserAllScope && !hasNarrowScope {
+		return nil, errors.Errorf("access tokens must have scope %q or at least one of %q", authz.ScopeUserAll, authz.NarrowScopes)
+	}
+	// 🚨 SECURITY: The sudo scope is only meaningful together with the user:all scope,
+	// so we don't allow it to be combined with only narrow scopes.
+	if _, ok := seenScope[authz.ScopeSiteAdminSudo]; ok && !hasUserAllScope {
+		return nil, errors.Errorf("access tokens with scope %q must also have scope %q", authz.ScopeSiteAdminSudo, authz.ScopeUserAll)
+	}
+
+	var expiresAt *time.Time
+	if args.ExpiresAt != nil {
+		if !args.ExpiresAt.Time.After(time.Now()) {
+			return nil, errors.New("access token expiration date must be in the future")
+		}
+		expiresAt = &args.ExpiresAt.Time
 	}
 
-	id, token, err := r.db.AccessTokens().Create(ctx, userID, args.Scopes, args.Note, actor.FromContext(ctx).UID)
+	id, token, err := r.db.AccessTokens().Create(ctx, userID, args.Scopes, args.Note, actor.FromContext(ctx).UID, expiresAt)
 
 	if conf.CanSendEmail() {
 		if err := backend.NewUserEmailsService(r.db, r.logger).SendUserEmailOnFieldUpdate(ctx, userID, "created an access token"); err != nil {
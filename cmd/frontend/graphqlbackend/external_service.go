@@ -18,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
@@ -37,8 +38,8 @@ type externalServiceResolver struct {
 const externalServiceIDKind = "ExternalService"
 
 func externalServiceByID(ctx context.Context, db database.DB, gqlID graphql.ID) (*externalServiceResolver, error) {
-	// 🚨 SECURITY: check whether user is site-admin
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, db); err != nil {
+	// 🚨 SECURITY: check whether user is site-admin or may manage external services
+	if err := auth.CheckCurrentUserHasPermission(ctx, db, rbac.ExternalServicesManage); err != nil {
 		return nil, err
 	}
 
@@ -309,8 +310,8 @@ func unmarshalExternalServiceSyncJobID(id graphql.ID) (jobID int64, err error) {
 }
 
 func externalServiceSyncJobByID(ctx context.Context, db database.DB, gqlID graphql.ID) (Node, error) {
-	// Site-admin only for now.
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, db); err != nil {
+	// 🚨 SECURITY: check whether user is site-admin or may manage external services
+	if err := auth.CheckCurrentUserHasPermission(ctx, db, rbac.ExternalServicesManage); err != nil {
 		return nil, err
 	}
 
@@ -21,6 +21,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/internal/repos"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -53,7 +54,8 @@ type addExternalServiceInput struct {
 
 func (r *schemaResolver) AddExternalService(ctx context.Context, args *addExternalServiceArgs) (*externalServiceResolver, error) {
 	start := time.Now()
-	// 🚨 SECURITY: Only site admins may add external services. User's external services are not supported anymore.
+	// 🚨 SECURITY: Only site admins and users with the permission to manage external services may add external
+	// services. User's external services are not supported anymore.
 	var err error
 	defer reportExternalServiceDuration(start, Add, &err)
 
@@ -61,8 +63,7 @@ func (r *schemaResolver) AddExternalService(ctx context.Context, args *addExtern
 		return nil, err
 	}
 
-	if auth.CheckCurrentUserIsSiteAdmin(ctx, r.db) != nil {
-		err = auth.ErrMustBeSiteAdmin
+	if err = auth.CheckCurrentUserHasPermission(ctx, r.db, rbac.ExternalServicesManage); err != nil {
 		return nil, err
 	}
 
@@ -103,8 +104,8 @@ func (r *schemaResolver) UpdateExternalService(ctx context.Context, args *update
 		return nil, err
 	}
 
-	// 🚨 SECURITY: check whether user is site-admin
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+	// 🚨 SECURITY: check whether user is site-admin or may manage external services
+	if err := auth.CheckCurrentUserHasPermission(ctx, r.db, rbac.ExternalServicesManage); err != nil {
 		return nil, err
 	}
 
@@ -173,8 +174,8 @@ func (r *schemaResolver) DeleteExternalService(ctx context.Context, args *delete
 		return nil, err
 	}
 
-	// 🚨 SECURITY: check whether user is site-admin
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+	// 🚨 SECURITY: check whether user is site-admin or may manage external services
+	if err := auth.CheckCurrentUserHasPermission(ctx, r.db, rbac.ExternalServicesManage); err != nil {
 		return nil, err
 	}
 
@@ -212,8 +213,8 @@ type ExternalServicesArgs struct {
 }
 
 func (r *schemaResolver) ExternalServices(ctx context.Context, args *ExternalServicesArgs) (*externalServiceConnectionResolver, error) {
-	// 🚨 SECURITY: Check whether user is site-admin
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+	// 🚨 SECURITY: Check whether user is site-admin or may manage external services
+	if err := auth.CheckCurrentUserHasPermission(ctx, r.db, rbac.ExternalServicesManage); err != nil {
 		return nil, err
 	}
 
@@ -366,8 +367,8 @@ func (r *schemaResolver) SyncExternalService(ctx context.Context, args *syncExte
 	var err error
 	defer reportExternalServiceDuration(start, Update, &err)
 
-	// 🚨 SECURITY: check whether user is site-admin
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+	// 🚨 SECURITY: check whether user is site-admin or may manage external services
+	if err := auth.CheckCurrentUserHasPermission(ctx, r.db, rbac.ExternalServicesManage); err != nil {
 		return nil, err
 	}
 
@@ -399,8 +400,8 @@ func (r *schemaResolver) CancelExternalServiceSync(ctx context.Context, args *ca
 	var err error
 	defer reportExternalServiceDuration(start, Update, &err)
 
-	// 🚨 SECURITY: check whether user is site-admin
-	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+	// 🚨 SECURITY: check whether user is site-admin or may manage external services
+	if err := auth.CheckCurrentUserHasPermission(ctx, r.db, rbac.ExternalServicesManage); err != nil {
 		return nil, err
 	}
 
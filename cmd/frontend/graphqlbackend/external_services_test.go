@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
 	"github.com/google/go-cmp/cmp"
 	gqlerrors "github.com/graph-gophers/graphql-go/errors"
 
@@ -21,12 +23,15 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
+var errMustManageExternalServices = &auth.MissingPermissionError{Permission: rbac.ExternalServicesManage}
+
 func TestAddExternalService(t *testing.T) {
 	t.Run("authenticated as non-admin", func(t *testing.T) {
 		users := database.NewMockUserStore()
@@ -36,10 +41,11 @@ func TestAddExternalService(t *testing.T) {
 
 		db := database.NewMockDB()
 		db.UsersFunc.SetDefaultReturn(users)
+		db.RolesFunc.SetDefaultReturn(database.NewMockRoleStore())
 
 		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
 		result, err := newSchemaResolver(db, gitserver.NewClient(db)).AddExternalService(ctx, &addExternalServiceArgs{})
-		if want := auth.ErrMustBeSiteAdmin; err != want {
+		if want := errMustManageExternalServices; !reflect.DeepEqual(err, want) {
 			t.Errorf("err: want %q but got %q", want, err)
 		}
 		if result != nil {
@@ -94,6 +100,7 @@ func TestUpdateExternalService(t *testing.T) {
 		t.Run("cannot update external services", func(t *testing.T) {
 			db := database.NewMockDB()
 			db.UsersFunc.SetDefaultReturn(users)
+			db.RolesFunc.SetDefaultReturn(database.NewMockRoleStore())
 
 			ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
 			result, err := newSchemaResolver(db, nil).UpdateExternalService(ctx, &updateExternalServiceArgs{
@@ -101,7 +108,7 @@ func TestUpdateExternalService(t *testing.T) {
 					ID: "RXh0ZXJuYWxTZXJ2aWNlOjQ=",
 				},
 			})
-			if want := auth.ErrMustBeSiteAdmin; err != want {
+			if want := errMustManageExternalServices; !reflect.DeepEqual(err, want) {
 				t.Errorf("err: want %q but got %v", want, err)
 			}
 			if result != nil {
@@ -208,12 +215,13 @@ func TestDeleteExternalService(t *testing.T) {
 		t.Run("cannot delete external services", func(t *testing.T) {
 			db := database.NewMockDB()
 			db.UsersFunc.SetDefaultReturn(users)
+			db.RolesFunc.SetDefaultReturn(database.NewMockRoleStore())
 
 			ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
 			result, err := newSchemaResolver(db, gitserver.NewClient(db)).DeleteExternalService(ctx, &deleteExternalServiceArgs{
 				ExternalService: "RXh0ZXJuYWxTZXJ2aWNlOjQ=",
 			})
-			if want := auth.ErrMustBeSiteAdmin; err != want {
+			if want := errMustManageExternalServices; !reflect.DeepEqual(err, want) {
 				t.Errorf("err: want %q but got %v", want, err)
 			}
 			if result != nil {
@@ -270,9 +278,10 @@ func TestExternalServices(t *testing.T) {
 
 			db := database.NewMockDB()
 			db.UsersFunc.SetDefaultReturn(users)
+			db.RolesFunc.SetDefaultReturn(database.NewMockRoleStore())
 
 			result, err := newSchemaResolver(db, gitserver.NewClient(db)).ExternalServices(context.Background(), &ExternalServicesArgs{})
-			if want := auth.ErrMustBeSiteAdmin; err != want {
+			if want := errMustManageExternalServices; !reflect.DeepEqual(err, want) {
 				t.Errorf("err: want %q but got %v", want, err)
 			}
 			if result != nil {
@@ -281,6 +290,26 @@ func TestExternalServices(t *testing.T) {
 		})
 	})
 
+	t.Run("authenticated as non-admin with permission to manage external services", func(t *testing.T) {
+		users := database.NewMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1}, nil)
+
+		roles := database.NewMockRoleStore()
+		roles.UserHasPermissionFunc.SetDefaultHook(func(_ context.Context, userID int32, p rbac.Permission) (bool, error) {
+			return userID == 1 && p == rbac.ExternalServicesManage, nil
+		})
+
+		db := database.NewMockDB()
+		db.UsersFunc.SetDefaultReturn(users)
+		db.RolesFunc.SetDefaultReturn(roles)
+
+		_, err := newSchemaResolver(db, gitserver.NewClient(db)).ExternalServices(context.Background(), &ExternalServicesArgs{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		mockrequire.Called(t, roles.UserHasPermissionFunc)
+	})
+
 	t.Run("authenticated as admin", func(t *testing.T) {
 		t.Run("can read site-level external service", func(t *testing.T) {
 			users := database.NewMockUserStore()
@@ -639,6 +668,7 @@ func TestCancelExternalServiceSync(t *testing.T) {
 
 		db := database.NewMockDB()
 		db.UsersFunc.SetDefaultReturn(users)
+		db.RolesFunc.SetDefaultReturn(database.NewMockRoleStore())
 
 		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
 		syncJobIDGraphQL := marshalExternalServiceSyncJobID(syncJobID)
@@ -650,8 +680,8 @@ func TestCancelExternalServiceSync(t *testing.T) {
 			ExpectedErrors: []*gqlerrors.QueryError{
 				{
 					Path:          []any{"cancelExternalServiceSync"},
-					Message:       auth.ErrMustBeSiteAdmin.Error(),
-					ResolverError: auth.ErrMustBeSiteAdmin,
+					Message:       errMustManageExternalServices.Error(),
+					ResolverError: errMustManageExternalServices,
 				},
 			},
 			Context: ctx,
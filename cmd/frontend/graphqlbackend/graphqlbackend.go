@@ -607,6 +607,9 @@ func newSchemaResolver(db database.DB, gitserverClient gitserver.Client) *schema
 		"WebhookLog": func(ctx context.Context, id graphql.ID) (Node, error) {
 			return webhookLogByID(ctx, db, id)
 		},
+		"OutboundWebhook": func(ctx context.Context, id graphql.ID) (Node, error) {
+			return outboundWebhookByID(ctx, db, id)
+		},
 		"OutboundRequest": func(ctx context.Context, id graphql.ID) (Node, error) {
 			return r.outboundRequestByID(ctx, id)
 		},
@@ -622,6 +625,9 @@ func newSchemaResolver(db database.DB, gitserverClient gitserver.Client) *schema
 		"ExecutorSecretAccessLog": func(ctx context.Context, id graphql.ID) (Node, error) {
 			return executorSecretAccessLogByID(ctx, db, id)
 		},
+		"SearchJob": func(ctx context.Context, id graphql.ID) (Node, error) {
+			return searchJobByID(ctx, db, id)
+		},
 	}
 	return r
 }
@@ -274,6 +274,11 @@ func (r *NodeResolver) ToWebhookLog() (*webhookLogResolver, bool) {
 	return n, ok
 }
 
+func (r *NodeResolver) ToOutboundWebhook() (*outboundWebhookResolver, bool) {
+	n, ok := r.Node.(*outboundWebhookResolver)
+	return n, ok
+}
+
 func (r *NodeResolver) ToOutboundRequest() (*OutboundRequestResolver, bool) {
 	n, ok := r.Node.(*OutboundRequestResolver)
 	return n, ok
@@ -313,3 +318,8 @@ func (r *NodeResolver) ToPermissionsSyncJob() (PermissionsSyncJobResolver, bool)
 	n, ok := r.Node.(PermissionsSyncJobResolver)
 	return n, ok
 }
+
+func (r *NodeResolver) ToSearchJob() (*searchJobResolver, bool) {
+	n, ok := r.Node.(*searchJobResolver)
+	return n, ok
+}
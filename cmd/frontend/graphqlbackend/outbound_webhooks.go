@@ -0,0 +1,513 @@
+package graphqlbackend
+
+import (
+	"context"
+	"net/url"
+	"sync"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func marshalOutboundWebhookID(id int64) graphql.ID {
+	return relay.MarshalID("OutboundWebhook", id)
+}
+
+func unmarshalOutboundWebhookID(id graphql.ID) (webhookID int64, err error) {
+	err = relay.UnmarshalSpec(id, &webhookID)
+	return
+}
+
+type outboundWebhookEventTypeInput struct {
+	EventType string
+	Scope     *string
+}
+
+type outboundWebhookCreateInput struct {
+	URL        string
+	Secret     string
+	EventTypes []outboundWebhookEventTypeInput
+}
+
+type outboundWebhookUpdateInput struct {
+	URL        string
+	Secret     *string
+	EventTypes []outboundWebhookEventTypeInput
+}
+
+func (r *schemaResolver) CreateOutboundWebhook(ctx context.Context, args *struct {
+	Input outboundWebhookCreateInput
+}) (*outboundWebhookResolver, error) {
+	// 🚨 SECURITY: Only site admins may create outbound webhooks.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	if args.Input.Secret == "" {
+		return nil, errors.New("secret cannot be empty")
+	}
+	eventTypes, err := toOutboundWebhookEventTypes(args.Input.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateOutboundWebhookURL(args.Input.URL); err != nil {
+		return nil, err
+	}
+
+	webhook := &types.OutboundWebhook{
+		URL:        encryption.NewUnencrypted(args.Input.URL),
+		Secret:     encryption.NewUnencrypted(args.Input.Secret),
+		EventTypes: eventTypes,
+	}
+	if err := r.db.OutboundWebhooks(keyring.Default().OutboundWebhookKey).Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return &outboundWebhookResolver{db: r.db, webhook: webhook}, nil
+}
+
+func (r *schemaResolver) UpdateOutboundWebhook(ctx context.Context, args *struct {
+	ID    graphql.ID
+	Input outboundWebhookUpdateInput
+}) (*outboundWebhookResolver, error) {
+	// 🚨 SECURITY: Only site admins may update outbound webhooks.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	id, err := unmarshalOutboundWebhookID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	eventTypes, err := toOutboundWebhookEventTypes(args.Input.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateOutboundWebhookURL(args.Input.URL); err != nil {
+		return nil, err
+	}
+
+	store := r.db.OutboundWebhooks(keyring.Default().OutboundWebhookKey)
+	webhook, err := store.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.URL = encryption.NewUnencrypted(args.Input.URL)
+	if args.Input.Secret != nil {
+		if *args.Input.Secret == "" {
+			return nil, errors.New("secret cannot be empty")
+		}
+		webhook.Secret = encryption.NewUnencrypted(*args.Input.Secret)
+	}
+	webhook.EventTypes = eventTypes
+
+	if err := store.Update(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return &outboundWebhookResolver{db: r.db, webhook: webhook}, nil
+}
+
+func (r *schemaResolver) DeleteOutboundWebhook(ctx context.Context, args *struct {
+	ID graphql.ID
+}) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may delete outbound webhooks.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	id, err := unmarshalOutboundWebhookID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.db.OutboundWebhooks(keyring.Default().OutboundWebhookKey).Delete(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return &EmptyResponse{}, nil
+}
+
+type listOutboundWebhooksArgs struct {
+	graphqlutil.ConnectionArgs
+	After     *string
+	EventType *string
+}
+
+func (r *schemaResolver) OutboundWebhooks(ctx context.Context, args *listOutboundWebhooksArgs) (*outboundWebhookConnectionResolver, error) {
+	// 🚨 SECURITY: Only site admins may list outbound webhooks.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	offset, err := graphqlutil.DecodeIntCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := database.OutboundWebhookListOpts{
+		LimitOffset: &database.LimitOffset{Limit: 20, Offset: offset},
+	}
+	if args.First != nil {
+		opts.Limit = int(*args.First)
+	}
+	if args.EventType != nil {
+		opts.EventTypes = []string{*args.EventType}
+	}
+
+	return &outboundWebhookConnectionResolver{db: r.db, opts: opts}, nil
+}
+
+func (r *schemaResolver) OutboundWebhookEventTypes(ctx context.Context) ([]*outboundWebhookEventTypeResolver, error) {
+	// 🚨 SECURITY: Only site admins may list outbound webhook event types.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*outboundWebhookEventTypeResolver, 0, len(outbound.EventTypes))
+	for _, eventType := range outbound.EventTypes {
+		resolvers = append(resolvers, &outboundWebhookEventTypeResolver{eventType: eventType})
+	}
+	return resolvers, nil
+}
+
+func outboundWebhookByID(ctx context.Context, db database.DB, gqlID graphql.ID) (*outboundWebhookResolver, error) {
+	// 🚨 SECURITY: Only site admins may view outbound webhooks.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, db); err != nil {
+		return nil, err
+	}
+
+	id, err := unmarshalOutboundWebhookID(gqlID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook, err := db.OutboundWebhooks(keyring.Default().OutboundWebhookKey).GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &outboundWebhookResolver{db: db, webhook: webhook}, nil
+}
+
+// toOutboundWebhookEventTypes validates the given event types and converts them
+// to their database representation.
+func toOutboundWebhookEventTypes(inputs []outboundWebhookEventTypeInput) ([]types.OutboundWebhookEventType, error) {
+	if len(inputs) == 0 {
+		return nil, errors.New("at least one event type must be provided")
+	}
+
+	eventTypes := make([]types.OutboundWebhookEventType, 0, len(inputs))
+	for _, input := range inputs {
+		if !outbound.IsValidEventType(input.EventType) {
+			return nil, errors.Newf("unknown event type %q", input.EventType)
+		}
+		eventTypes = append(eventTypes, types.OutboundWebhookEventType{
+			EventType: input.EventType,
+			Scope:     input.Scope,
+		})
+	}
+	return eventTypes, nil
+}
+
+func validateOutboundWebhookURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.Wrap(err, "invalid URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.Newf("invalid URL scheme %q: must be http or https", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.New("invalid URL: missing host")
+	}
+	return nil
+}
+
+// outboundWebhookConnectionResolver resolves a list of outbound webhooks.
+//
+// 🚨 SECURITY: When instantiating an outboundWebhookConnectionResolver value,
+// the caller MUST check permissions.
+type outboundWebhookConnectionResolver struct {
+	db   database.DB
+	opts database.OutboundWebhookListOpts
+
+	once     sync.Once
+	webhooks []*types.OutboundWebhook
+	err      error
+}
+
+func (r *outboundWebhookConnectionResolver) compute(ctx context.Context) ([]*types.OutboundWebhook, error) {
+	r.once.Do(func() {
+		r.webhooks, r.err = r.db.OutboundWebhooks(keyring.Default().OutboundWebhookKey).List(ctx, r.opts)
+	})
+	return r.webhooks, r.err
+}
+
+func (r *outboundWebhookConnectionResolver) Nodes(ctx context.Context) ([]*outboundWebhookResolver, error) {
+	webhooks, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*outboundWebhookResolver, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		resolvers = append(resolvers, &outboundWebhookResolver{db: r.db, webhook: webhook})
+	}
+	return resolvers, nil
+}
+
+func (r *outboundWebhookConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	count, err := r.db.OutboundWebhooks(keyring.Default().OutboundWebhookKey).Count(ctx, r.opts)
+	return int32(count), err
+}
+
+func (r *outboundWebhookConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	webhooks, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	totalCount, err := r.TotalCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return graphqlutil.EncodeIntCursor(graphqlutil.NextOffset(r.opts.Offset, len(webhooks), int(totalCount))), nil
+}
+
+type outboundWebhookResolver struct {
+	db      database.DB
+	webhook *types.OutboundWebhook
+}
+
+func (r *outboundWebhookResolver) ID() graphql.ID {
+	return marshalOutboundWebhookID(r.webhook.ID)
+}
+
+func (r *outboundWebhookResolver) URL(ctx context.Context) (string, error) {
+	return r.webhook.URL.Decrypt(ctx)
+}
+
+func (r *outboundWebhookResolver) EventTypes() []*outboundWebhookScopedEventTypeResolver {
+	resolvers := make([]*outboundWebhookScopedEventTypeResolver, 0, len(r.webhook.EventTypes))
+	for _, eventType := range r.webhook.EventTypes {
+		resolvers = append(resolvers, &outboundWebhookScopedEventTypeResolver{eventType: eventType})
+	}
+	return resolvers
+}
+
+func (r *outboundWebhookResolver) Stats(ctx context.Context) (*outboundWebhookLogStatsResolver, error) {
+	total, errored, err := r.db.OutboundWebhookLogs(keyring.Default().OutboundWebhookKey).CountsForOutboundWebhook(ctx, r.webhook.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &outboundWebhookLogStatsResolver{total: total, errored: errored}, nil
+}
+
+type outboundWebhookLogsArgs struct {
+	graphqlutil.ConnectionArgs
+	After      *string
+	OnlyErrors *bool
+}
+
+func (r *outboundWebhookResolver) Logs(ctx context.Context, args *outboundWebhookLogsArgs) (*outboundWebhookLogConnectionResolver, error) {
+	offset, err := graphqlutil.DecodeIntCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := database.OutboundWebhookLogListOpts{
+		LimitOffset:       &database.LimitOffset{Limit: 50, Offset: offset},
+		OutboundWebhookID: r.webhook.ID,
+		OnlyErrors:        args.OnlyErrors != nil && *args.OnlyErrors,
+	}
+	if args.First != nil {
+		opts.Limit = int(*args.First)
+	}
+
+	return &outboundWebhookLogConnectionResolver{
+		store: r.db.OutboundWebhookLogs(keyring.Default().OutboundWebhookKey),
+		opts:  opts,
+	}, nil
+}
+
+func (r *outboundWebhookResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.webhook.CreatedAt}
+}
+
+func (r *outboundWebhookResolver) CreatedBy(ctx context.Context) (*UserResolver, error) {
+	return outboundWebhookUser(ctx, r.db, r.webhook.CreatedBy)
+}
+
+func (r *outboundWebhookResolver) UpdatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.webhook.UpdatedAt}
+}
+
+func (r *outboundWebhookResolver) UpdatedBy(ctx context.Context) (*UserResolver, error) {
+	return outboundWebhookUser(ctx, r.db, r.webhook.UpdatedBy)
+}
+
+func outboundWebhookUser(ctx context.Context, db database.DB, id int32) (*UserResolver, error) {
+	if id == 0 {
+		return nil, nil
+	}
+
+	user, err := UserByIDInt32(ctx, db, id)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+type outboundWebhookScopedEventTypeResolver struct {
+	eventType types.OutboundWebhookEventType
+}
+
+func (r *outboundWebhookScopedEventTypeResolver) EventType() string {
+	return r.eventType.EventType
+}
+
+func (r *outboundWebhookScopedEventTypeResolver) Scope() *string {
+	return r.eventType.Scope
+}
+
+type outboundWebhookEventTypeResolver struct {
+	eventType outbound.EventType
+}
+
+func (r *outboundWebhookEventTypeResolver) Key() string {
+	return r.eventType.Key
+}
+
+func (r *outboundWebhookEventTypeResolver) Description() string {
+	return r.eventType.Description
+}
+
+type outboundWebhookLogStatsResolver struct {
+	total   int
+	errored int
+}
+
+func (r *outboundWebhookLogStatsResolver) Total() int32 {
+	return int32(r.total)
+}
+
+func (r *outboundWebhookLogStatsResolver) Errored() int32 {
+	return int32(r.errored)
+}
+
+type outboundWebhookLogConnectionResolver struct {
+	store database.OutboundWebhookLogStore
+	opts  database.OutboundWebhookLogListOpts
+
+	once sync.Once
+	logs []*types.OutboundWebhookLog
+	err  error
+
+	countOnce sync.Once
+	total     int
+	errored   int
+	countErr  error
+}
+
+func (r *outboundWebhookLogConnectionResolver) compute(ctx context.Context) ([]*types.OutboundWebhookLog, error) {
+	r.once.Do(func() {
+		r.logs, r.err = r.store.ListForOutboundWebhook(ctx, r.opts)
+	})
+	return r.logs, r.err
+}
+
+func (r *outboundWebhookLogConnectionResolver) Nodes(ctx context.Context) ([]*outboundWebhookLogResolver, error) {
+	logs, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*outboundWebhookLogResolver, 0, len(logs))
+	for _, log := range logs {
+		resolvers = append(resolvers, &outboundWebhookLogResolver{log: log})
+	}
+	return resolvers, nil
+}
+
+func (r *outboundWebhookLogConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	r.countOnce.Do(func() {
+		r.total, r.errored, r.countErr = r.store.CountsForOutboundWebhook(ctx, r.opts.OutboundWebhookID)
+	})
+	if r.countErr != nil {
+		return 0, r.countErr
+	}
+
+	if r.opts.OnlyErrors {
+		return int32(r.errored), nil
+	}
+	return int32(r.total), nil
+}
+
+func (r *outboundWebhookLogConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	logs, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	totalCount, err := r.TotalCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return graphqlutil.EncodeIntCursor(graphqlutil.NextOffset(r.opts.Offset, len(logs), int(totalCount))), nil
+}
+
+type outboundWebhookLogResolver struct {
+	log *types.OutboundWebhookLog
+}
+
+func (r *outboundWebhookLogResolver) SentAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.log.SentAt}
+}
+
+func (r *outboundWebhookLogResolver) StatusCode() int32 {
+	return int32(r.log.StatusCode)
+}
+
+func (r *outboundWebhookLogResolver) Request(ctx context.Context) (*webhookLogRequestResolver, error) {
+	message, err := r.log.Request.Decrypt(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhookLogRequestResolver{webhookLogMessageResolver{message: &message}}, nil
+}
+
+func (r *outboundWebhookLogResolver) Response(ctx context.Context) (*webhookLogMessageResolver, error) {
+	// No response is recorded if the request couldn't be sent.
+	if r.log.StatusCode == types.OutboundWebhookLogUnsentStatusCode {
+		return nil, nil
+	}
+
+	message, err := r.log.Response.Decrypt(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhookLogMessageResolver{message: &message}, nil
+}
+
+func (r *outboundWebhookLogResolver) Error(ctx context.Context) (*string, error) {
+	message, err := r.log.Error.Decrypt(ctx)
+	if err != nil || message == "" {
+		return nil, err
+	}
+	return &message, nil
+}
@@ -0,0 +1,315 @@
+package graphqlbackend
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
+)
+
+func TestCreateOutboundWebhook(t *testing.T) {
+	validInput := outboundWebhookCreateInput{
+		URL:    "https://example.com/hook",
+		Secret: "s3cr3t",
+		EventTypes: []outboundWebhookEventTypeInput{
+			{EventType: outbound.EventTypeRepoCloned, Scope: stringPtr("github.com/sourcegraph/sourcegraph")},
+			{EventType: outbound.EventTypeBatchChangeApplied},
+		},
+	}
+
+	t.Run("authenticated as non-admin", func(t *testing.T) {
+		users := database.NewMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1}, nil)
+
+		webhooks := database.NewMockOutboundWebhookStore()
+
+		db := database.NewMockDB()
+		db.UsersFunc.SetDefaultReturn(users)
+		db.OutboundWebhooksFunc.SetDefaultReturn(webhooks)
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		_, err := newSchemaResolver(db, nil).CreateOutboundWebhook(ctx, &struct{ Input outboundWebhookCreateInput }{Input: validInput})
+		if want := auth.ErrMustBeSiteAdmin; err != want {
+			t.Errorf("got err %v, want %v", err, want)
+		}
+		mockrequire.NotCalled(t, webhooks.CreateFunc)
+	})
+
+	t.Run("authenticated as admin", func(t *testing.T) {
+		users := database.NewMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: true}, nil)
+
+		webhooks := database.NewMockOutboundWebhookStore()
+		webhooks.CreateFunc.SetDefaultHook(func(ctx context.Context, webhook *types.OutboundWebhook) error {
+			webhook.ID = 7
+			return nil
+		})
+
+		db := database.NewMockDB()
+		db.UsersFunc.SetDefaultReturn(users)
+		db.OutboundWebhooksFunc.SetDefaultReturn(webhooks)
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		webhook, err := newSchemaResolver(db, nil).CreateOutboundWebhook(ctx, &struct{ Input outboundWebhookCreateInput }{Input: validInput})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, marshalOutboundWebhookID(7), webhook.ID())
+
+		mockrequire.CalledOnce(t, webhooks.CreateFunc)
+		created := webhooks.CreateFunc.History()[0].Arg1
+		url, err := created.URL.Decrypt(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		secret, err := created.Secret.Decrypt(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "https://example.com/hook", url)
+		assert.Equal(t, "s3cr3t", secret)
+		assert.Equal(t, []types.OutboundWebhookEventType{
+			{EventType: outbound.EventTypeRepoCloned, Scope: stringPtr("github.com/sourcegraph/sourcegraph")},
+			{EventType: outbound.EventTypeBatchChangeApplied},
+		}, created.EventTypes)
+	})
+
+	for name, input := range map[string]outboundWebhookCreateInput{
+		"unknown event type": {
+			URL:        validInput.URL,
+			Secret:     validInput.Secret,
+			EventTypes: []outboundWebhookEventTypeInput{{EventType: "repo:exploded"}},
+		},
+		"no event types": {
+			URL:    validInput.URL,
+			Secret: validInput.Secret,
+		},
+		"invalid URL scheme": {
+			URL:        "ftp://example.com/hook",
+			Secret:     validInput.Secret,
+			EventTypes: validInput.EventTypes,
+		},
+		"empty secret": {
+			URL:        validInput.URL,
+			EventTypes: validInput.EventTypes,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			users := database.NewMockUserStore()
+			users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: true}, nil)
+
+			webhooks := database.NewMockOutboundWebhookStore()
+
+			db := database.NewMockDB()
+			db.UsersFunc.SetDefaultReturn(users)
+			db.OutboundWebhooksFunc.SetDefaultReturn(webhooks)
+
+			ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+			if _, err := newSchemaResolver(db, nil).CreateOutboundWebhook(ctx, &struct{ Input outboundWebhookCreateInput }{Input: input}); err == nil {
+				t.Fatal("expected error")
+			}
+			mockrequire.NotCalled(t, webhooks.CreateFunc)
+		})
+	}
+}
+
+func TestUpdateOutboundWebhook(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: true}, nil)
+
+	webhooks := database.NewMockOutboundWebhookStore()
+	webhooks.GetByIDFunc.SetDefaultHook(func(ctx context.Context, id int64) (*types.OutboundWebhook, error) {
+		return &types.OutboundWebhook{
+			ID:         id,
+			URL:        encryption.NewUnencrypted("https://example.com/old"),
+			Secret:     encryption.NewUnencrypted("old secret"),
+			EventTypes: []types.OutboundWebhookEventType{{EventType: outbound.EventTypeRepoCloned}},
+		}, nil
+	})
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.OutboundWebhooksFunc.SetDefaultReturn(webhooks)
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	_, err := newSchemaResolver(db, nil).UpdateOutboundWebhook(ctx, &struct {
+		ID    graphql.ID
+		Input outboundWebhookUpdateInput
+	}{
+		ID: marshalOutboundWebhookID(7),
+		Input: outboundWebhookUpdateInput{
+			URL:        "https://example.com/new",
+			EventTypes: []outboundWebhookEventTypeInput{{EventType: outbound.EventTypeRepoDeleted}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mockrequire.CalledOnce(t, webhooks.UpdateFunc)
+	updated := webhooks.UpdateFunc.History()[0].Arg1
+	url, err := updated.URL.Decrypt(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret, err := updated.Secret.Decrypt(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(7), updated.ID)
+	assert.Equal(t, "https://example.com/new", url)
+	// The secret is kept if no new secret is given.
+	assert.Equal(t, "old secret", secret)
+	assert.Equal(t, []types.OutboundWebhookEventType{{EventType: outbound.EventTypeRepoDeleted}}, updated.EventTypes)
+}
+
+func TestDeleteOutboundWebhook(t *testing.T) {
+	t.Run("authenticated as non-admin", func(t *testing.T) {
+		users := database.NewMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1}, nil)
+
+		webhooks := database.NewMockOutboundWebhookStore()
+
+		db := database.NewMockDB()
+		db.UsersFunc.SetDefaultReturn(users)
+		db.OutboundWebhooksFunc.SetDefaultReturn(webhooks)
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		_, err := newSchemaResolver(db, nil).DeleteOutboundWebhook(ctx, &struct{ ID graphql.ID }{ID: marshalOutboundWebhookID(7)})
+		if want := auth.ErrMustBeSiteAdmin; err != want {
+			t.Errorf("got err %v, want %v", err, want)
+		}
+		mockrequire.NotCalled(t, webhooks.DeleteFunc)
+	})
+
+	t.Run("authenticated as admin", func(t *testing.T) {
+		users := database.NewMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: true}, nil)
+
+		webhooks := database.NewMockOutboundWebhookStore()
+
+		db := database.NewMockDB()
+		db.UsersFunc.SetDefaultReturn(users)
+		db.OutboundWebhooksFunc.SetDefaultReturn(webhooks)
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		if _, err := newSchemaResolver(db, nil).DeleteOutboundWebhook(ctx, &struct{ ID graphql.ID }{ID: marshalOutboundWebhookID(7)}); err != nil {
+			t.Fatal(err)
+		}
+		mockrequire.CalledOnceWith(t, webhooks.DeleteFunc, mockrequire.Values(mockrequire.Skip, int64(7)))
+	})
+}
+
+func TestOutboundWebhooks(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: true}, nil)
+
+	webhooks := database.NewMockOutboundWebhookStore()
+	webhooks.ListFunc.SetDefaultReturn([]*types.OutboundWebhook{{
+		ID:     7,
+		URL:    encryption.NewUnencrypted("https://example.com/hook"),
+		Secret: encryption.NewUnencrypted("s3cr3t"),
+		EventTypes: []types.OutboundWebhookEventType{
+			{EventType: outbound.EventTypeRepoCloned, Scope: stringPtr("github.com/sourcegraph/sourcegraph")},
+		},
+	}}, nil)
+	webhooks.CountFunc.SetDefaultReturn(2, nil)
+
+	sentAt := time.Date(2022, 12, 13, 12, 0, 0, 0, time.UTC)
+	logs := database.NewMockOutboundWebhookLogStore()
+	logs.CountsForOutboundWebhookFunc.SetDefaultReturn(2, 1, nil)
+	logs.ListForOutboundWebhookFunc.SetDefaultReturn([]*types.OutboundWebhookLog{
+		{
+			ID:         2,
+			SentAt:     sentAt,
+			StatusCode: types.OutboundWebhookLogUnsentStatusCode,
+			Request: types.NewUnencryptedWebhookLogMessage(types.WebhookLogMessage{
+				Header: http.Header{"Content-Type": []string{"application/json"}},
+				Body:   []byte(`{}`),
+				Method: http.MethodPost,
+				URL:    "https://example.com/hook",
+			}),
+			Response: types.NewUnencryptedWebhookLogMessage(types.WebhookLogMessage{}),
+			Error:    encryption.NewUnencrypted("sending request: connection refused"),
+		},
+	}, nil)
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.OutboundWebhooksFunc.SetDefaultReturn(webhooks)
+	db.OutboundWebhookLogsFunc.SetDefaultReturn(logs)
+
+	RunTest(t, &Test{
+		Context: actor.WithActor(context.Background(), &actor.Actor{UID: 1}),
+		Schema:  mustParseGraphQLSchema(t, db),
+		Query: `
+			{
+				outboundWebhooks(first: 1, eventType: "repo:cloned") {
+					totalCount
+					pageInfo { hasNextPage }
+					nodes {
+						url
+						eventTypes { eventType scope }
+						stats { total errored }
+						logs(first: 1, onlyErrors: true) {
+							totalCount
+							pageInfo { hasNextPage }
+							nodes {
+								sentAt
+								statusCode
+								request { method url body }
+								response { body }
+								error
+							}
+						}
+					}
+				}
+			}
+		`,
+		ExpectedResult: `
+			{
+				"outboundWebhooks": {
+					"totalCount": 2,
+					"pageInfo": { "hasNextPage": true },
+					"nodes": [{
+						"url": "https://example.com/hook",
+						"eventTypes": [{ "eventType": "repo:cloned", "scope": "github.com/sourcegraph/sourcegraph" }],
+						"stats": { "total": 2, "errored": 1 },
+						"logs": {
+							"totalCount": 1,
+							"pageInfo": { "hasNextPage": false },
+							"nodes": [{
+								"sentAt": "2022-12-13T12:00:00Z",
+								"statusCode": 0,
+								"request": { "method": "POST", "url": "https://example.com/hook", "body": "{}" },
+								"response": null,
+								"error": "sending request: connection refused"
+							}]
+						}
+					}]
+				}
+			}
+		`,
+	})
+
+	mockrequire.CalledOnceWith(t, webhooks.ListFunc, mockrequire.Values(mockrequire.Skip, database.OutboundWebhookListOpts{
+		LimitOffset: &database.LimitOffset{Limit: 1},
+		EventTypes:  []string{outbound.EventTypeRepoCloned},
+	}))
+	mockrequire.CalledOnceWith(t, logs.ListForOutboundWebhookFunc, mockrequire.Values(mockrequire.Skip, database.OutboundWebhookLogListOpts{
+		LimitOffset:       &database.LimitOffset{Limit: 1},
+		OutboundWebhookID: 7,
+		OnlyErrors:        true,
+	}))
+}
@@ -0,0 +1,296 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// roleResolver resolves a role.
+type roleResolver struct {
+	db   database.DB
+	role *types.Role
+}
+
+func marshalRoleID(id int32) graphql.ID { return relay.MarshalID("Role", id) }
+
+func unmarshalRoleID(id graphql.ID) (roleID int32, err error) {
+	err = relay.UnmarshalSpec(id, &roleID)
+	return
+}
+
+func (r *roleResolver) ID() graphql.ID { return marshalRoleID(r.role.ID) }
+
+func (r *roleResolver) Name() string { return r.role.Name }
+
+func (r *roleResolver) ReadOnly() bool { return r.role.ReadOnly }
+
+func (r *roleResolver) Permissions(ctx context.Context) ([]string, error) {
+	permissions, err := r.db.Roles().ListPermissions(ctx, r.role.ID)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(permissions))
+	for _, p := range permissions {
+		names = append(names, p.String())
+	}
+	return names, nil
+}
+
+func (r *roleResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.role.CreatedAt}
+}
+
+type rolesArgs struct {
+	User         *graphql.ID
+	Organization *graphql.ID
+}
+
+func (r *schemaResolver) Roles(ctx context.Context, args *rolesArgs) ([]*roleResolver, error) {
+	// 🚨 SECURITY: Only site admins may list roles.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	var opts database.RolesListOptions
+	if args.User != nil {
+		userID, err := UnmarshalUserID(*args.User)
+		if err != nil {
+			return nil, err
+		}
+		opts.UserID = userID
+	}
+	if args.Organization != nil {
+		orgID, err := UnmarshalOrgID(*args.Organization)
+		if err != nil {
+			return nil, err
+		}
+		opts.OrgID = orgID
+	}
+
+	roles, err := r.db.Roles().List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*roleResolver, 0, len(roles))
+	for _, role := range roles {
+		resolvers = append(resolvers, &roleResolver{db: r.db, role: role})
+	}
+	return resolvers, nil
+}
+
+func (r *schemaResolver) Permissions(ctx context.Context) ([]string, error) {
+	// 🚨 SECURITY: Only site admins may list permissions.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(rbac.AllPermissions))
+	for _, p := range rbac.AllPermissions {
+		names = append(names, p.String())
+	}
+	return names, nil
+}
+
+func parsePermissions(names []string) ([]rbac.Permission, error) {
+	permissions := make([]rbac.Permission, 0, len(names))
+	seen := map[rbac.Permission]struct{}{}
+	for _, name := range names {
+		p, err := rbac.ParsePermission(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[p]; ok {
+			continue
+		}
+		seen[p] = struct{}{}
+		permissions = append(permissions, p)
+	}
+	return permissions, nil
+}
+
+type createRoleArgs struct {
+	Name        string
+	Permissions []string
+}
+
+func (r *schemaResolver) CreateRole(ctx context.Context, args *createRoleArgs) (_ *roleResolver, err error) {
+	// 🚨 SECURITY: Only site admins may create roles.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	if args.Name == "" {
+		return nil, errors.New("role name must not be empty")
+	}
+	permissions, err := parsePermissions(args.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.Roles().Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	role, err := tx.Create(ctx, args.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.SetPermissions(ctx, role.ID, permissions); err != nil {
+		return nil, err
+	}
+	return &roleResolver{db: r.db, role: role}, nil
+}
+
+type deleteRoleArgs struct {
+	Role graphql.ID
+}
+
+func (r *schemaResolver) DeleteRole(ctx context.Context, args *deleteRoleArgs) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may delete roles.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	roleID, err := unmarshalRoleID(args.Role)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.Roles().Delete(ctx, roleID); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+type setRolePermissionsArgs struct {
+	Role        graphql.ID
+	Permissions []string
+}
+
+func (r *schemaResolver) SetRolePermissions(ctx context.Context, args *setRolePermissionsArgs) (*roleResolver, error) {
+	// 🚨 SECURITY: Only site admins may change the permissions of roles.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	roleID, err := unmarshalRoleID(args.Role)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := parsePermissions(args.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.Roles().SetPermissions(ctx, roleID, permissions); err != nil {
+		return nil, err
+	}
+	role, err := r.db.Roles().GetByID(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+	return &roleResolver{db: r.db, role: role}, nil
+}
+
+// assignableRole returns the ID of the role if its assignments may be changed
+// by the current user.
+func (r *schemaResolver) assignableRole(ctx context.Context, id graphql.ID) (int32, error) {
+	// 🚨 SECURITY: Only site admins may assign roles.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return 0, err
+	}
+
+	roleID, err := unmarshalRoleID(id)
+	if err != nil {
+		return 0, err
+	}
+	role, err := r.db.Roles().GetByID(ctx, roleID)
+	if err != nil {
+		return 0, err
+	}
+	// The members of built-in roles are derived from user attributes, such as
+	// the site admin flag.
+	if role.ReadOnly {
+		return 0, errors.Errorf("the members of built-in role %q cannot be changed", role.Name)
+	}
+	return roleID, nil
+}
+
+type userRoleArgs struct {
+	Role graphql.ID
+	User graphql.ID
+}
+
+func (r *schemaResolver) AssignRoleToUser(ctx context.Context, args *userRoleArgs) (*EmptyResponse, error) {
+	roleID, err := r.assignableRole(ctx, args.Role)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := UnmarshalUserID(args.User)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.Roles().AssignToUser(ctx, roleID, userID); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+func (r *schemaResolver) RevokeRoleFromUser(ctx context.Context, args *userRoleArgs) (*EmptyResponse, error) {
+	roleID, err := r.assignableRole(ctx, args.Role)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := UnmarshalUserID(args.User)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.Roles().RevokeFromUser(ctx, roleID, userID); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+type orgRoleArgs struct {
+	Role         graphql.ID
+	Organization graphql.ID
+}
+
+func (r *schemaResolver) AssignRoleToOrganization(ctx context.Context, args *orgRoleArgs) (*EmptyResponse, error) {
+	roleID, err := r.assignableRole(ctx, args.Role)
+	if err != nil {
+		return nil, err
+	}
+	orgID, err := UnmarshalOrgID(args.Organization)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.Roles().AssignToOrg(ctx, roleID, orgID); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+func (r *schemaResolver) RevokeRoleFromOrganization(ctx context.Context, args *orgRoleArgs) (*EmptyResponse, error) {
+	roleID, err := r.assignableRole(ctx, args.Role)
+	if err != nil {
+		return nil, err
+	}
+	orgID, err := UnmarshalOrgID(args.Organization)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.Roles().RevokeFromOrg(ctx, roleID, orgID); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
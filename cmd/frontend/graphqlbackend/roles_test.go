@@ -0,0 +1,120 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestCreateRole(t *testing.T) {
+	t.Run("authenticated as non-admin", func(t *testing.T) {
+		users := database.NewMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1}, nil)
+
+		db := database.NewMockDB()
+		db.UsersFunc.SetDefaultReturn(users)
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		_, err := newSchemaResolver(db, nil).CreateRole(ctx, &createRoleArgs{Name: "CODE_HOST_ADMIN"})
+		if want := auth.ErrMustBeSiteAdmin; err != want {
+			t.Errorf("got err %v, want %v", err, want)
+		}
+	})
+
+	t.Run("authenticated as admin", func(t *testing.T) {
+		users := database.NewMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: true}, nil)
+
+		roles := database.NewMockRoleStore()
+		roles.TransactFunc.SetDefaultReturn(roles, nil)
+		roles.CreateFunc.SetDefaultHook(func(_ context.Context, name string) (*types.Role, error) {
+			return &types.Role{ID: 7, Name: name}, nil
+		})
+
+		db := database.NewMockDB()
+		db.UsersFunc.SetDefaultReturn(users)
+		db.RolesFunc.SetDefaultReturn(roles)
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		role, err := newSchemaResolver(db, nil).CreateRole(ctx, &createRoleArgs{
+			Name:        "CODE_HOST_ADMIN",
+			Permissions: []string{"EXTERNAL_SERVICES#MANAGE", "EXTERNAL_SERVICES#MANAGE"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "CODE_HOST_ADMIN", role.Name())
+
+		mockrequire.CalledOnceWith(t, roles.SetPermissionsFunc, mockrequire.Values(
+			mockrequire.Skip,
+			int32(7),
+			[]rbac.Permission{rbac.ExternalServicesManage},
+		))
+	})
+
+	t.Run("unknown permission", func(t *testing.T) {
+		users := database.NewMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: true}, nil)
+
+		db := database.NewMockDB()
+		db.UsersFunc.SetDefaultReturn(users)
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		_, err := newSchemaResolver(db, nil).CreateRole(ctx, &createRoleArgs{
+			Name:        "CODE_HOST_ADMIN",
+			Permissions: []string{"EXTERNAL_SERVICES#DESTROY"},
+		})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestAssignRoleToUser(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: true}, nil)
+
+	roles := database.NewMockRoleStore()
+	roles.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*types.Role, error) {
+		if id == 1 {
+			return &types.Role{ID: id, Name: rbac.SiteAdministratorRole, ReadOnly: true}, nil
+		}
+		return &types.Role{ID: id, Name: "CODE_HOST_ADMIN"}, nil
+	})
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.RolesFunc.SetDefaultReturn(roles)
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	t.Run("built-in role", func(t *testing.T) {
+		_, err := newSchemaResolver(db, nil).AssignRoleToUser(ctx, &userRoleArgs{
+			Role: marshalRoleID(1),
+			User: MarshalUserID(2),
+		})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		mockrequire.NotCalled(t, roles.AssignToUserFunc)
+	})
+
+	t.Run("custom role", func(t *testing.T) {
+		_, err := newSchemaResolver(db, nil).AssignRoleToUser(ctx, &userRoleArgs{
+			Role: marshalRoleID(2),
+			User: MarshalUserID(3),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		mockrequire.CalledOnceWith(t, roles.AssignToUserFunc, mockrequire.Values(mockrequire.Skip, int32(2), int32(3)))
+	})
+}
@@ -0,0 +1,257 @@
+package graphqlbackend
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/export"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// searchJobResolver resolves an exhaustive search job.
+type searchJobResolver struct {
+	db  database.DB
+	job *types.ExhaustiveSearchJob
+}
+
+func marshalSearchJobID(id int64) graphql.ID { return relay.MarshalID("SearchJob", id) }
+
+func unmarshalSearchJobID(id graphql.ID) (jobID int64, err error) {
+	err = relay.UnmarshalSpec(id, &jobID)
+	return
+}
+
+func searchJobByID(ctx context.Context, db database.DB, gqlID graphql.ID) (*searchJobResolver, error) {
+	id, err := unmarshalSearchJobID(gqlID)
+	if err != nil {
+		return nil, err
+	}
+	job, err := db.ExhaustiveSearchJobs().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: Only the creator of the job and site admins may view it.
+	if err := auth.CheckSiteAdminOrSameUser(ctx, db, job.InitiatorID); err != nil {
+		return nil, err
+	}
+	return &searchJobResolver{db: db, job: job}, nil
+}
+
+// SearchJobResultsURL returns the URL the results of the search job with the
+// given ID can be downloaded from.
+func SearchJobResultsURL(id int64) string {
+	return globals.ExternalURL().ResolveReference(&url.URL{
+		Path: "/.api/search/jobs/" + strconv.FormatInt(id, 10) + "/results",
+	}).String()
+}
+
+func (r *searchJobResolver) ID() graphql.ID { return marshalSearchJobID(r.job.ID) }
+
+func (r *searchJobResolver) Query() string { return r.job.Query }
+
+func (r *searchJobResolver) Format() string { return strings.ToUpper(r.job.Format) }
+
+func (r *searchJobResolver) State() string {
+	if r.job.Cancel {
+		if r.job.State == "processing" {
+			return "CANCELING"
+		}
+		// Running jobs which are canceled end up as failed.
+		return "CANCELED"
+	}
+	return strings.ToUpper(r.job.State)
+}
+
+func (r *searchJobResolver) FailureMessage() *string {
+	if r.job.Cancel {
+		return nil
+	}
+	return r.job.FailureMessage
+}
+
+func (r *searchJobResolver) Creator(ctx context.Context) (*UserResolver, error) {
+	user, err := UserByIDInt32(ctx, r.db, r.job.InitiatorID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *searchJobResolver) MatchCount() int32 { return int32(r.job.MatchCount) }
+
+func (r *searchJobResolver) RepositoriesSearched() int32 { return int32(r.job.RepositoriesSearched) }
+
+func (r *searchJobResolver) LimitHit() bool { return r.job.LimitHit }
+
+func (r *searchJobResolver) ResultSize() *BigInt {
+	if r.job.ResultSize == nil {
+		return nil
+	}
+	size := BigInt(*r.job.ResultSize)
+	return &size
+}
+
+func (r *searchJobResolver) DownloadURL() *string {
+	if r.job.ResultKey == nil {
+		return nil
+	}
+	u := SearchJobResultsURL(r.job.ID)
+	return &u
+}
+
+func (r *searchJobResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.job.CreatedAt}
+}
+
+func (r *searchJobResolver) StartedAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.job.StartedAt)
+}
+
+func (r *searchJobResolver) FinishedAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.job.FinishedAt)
+}
+
+type createSearchJobArgs struct {
+	Query  string
+	Format string
+}
+
+func (r *schemaResolver) CreateSearchJob(ctx context.Context, args *createSearchJobArgs) (*searchJobResolver, error) {
+	// 🚨 SECURITY: Search jobs run with the permissions of their creator, so
+	// they must be created by a user.
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		return nil, auth.ErrNotAuthenticated
+	}
+
+	format, err := export.ParseFormat(args.Format)
+	if err != nil {
+		return nil, err
+	}
+	// Reject invalid queries straight away, rather than only once the job
+	// runs.
+	if _, err := query.Pipeline(query.Init(args.Query, query.SearchTypeStandard)); err != nil {
+		return nil, err
+	}
+
+	job, err := r.db.ExhaustiveSearchJobs().Create(ctx, a.UID, args.Query, string(format))
+	if err != nil {
+		return nil, err
+	}
+	return &searchJobResolver{db: r.db, job: job}, nil
+}
+
+type cancelSearchJobArgs struct {
+	ID graphql.ID
+}
+
+func (r *schemaResolver) CancelSearchJob(ctx context.Context, args *cancelSearchJobArgs) (*searchJobResolver, error) {
+	// 🚨 SECURITY: searchJobByID checks that the user may access the job.
+	job, err := searchJobByID(ctx, r.db, args.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.ExhaustiveSearchJobs().Cancel(ctx, job.job.ID); err != nil {
+		return nil, err
+	}
+	return searchJobByID(ctx, r.db, args.ID)
+}
+
+type searchJobsArgs struct {
+	graphqlutil.ConnectionArgs
+	User *graphql.ID
+}
+
+func (r *schemaResolver) SearchJobs(ctx context.Context, args *searchJobsArgs) (*searchJobConnectionResolver, error) {
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		return nil, auth.ErrNotAuthenticated
+	}
+
+	userID := a.UID
+	if args.User != nil {
+		var err error
+		userID, err = UnmarshalUserID(*args.User)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// 🚨 SECURITY: Only site admins may list the search jobs of other users.
+	if err := auth.CheckSiteAdminOrSameUser(ctx, r.db, userID); err != nil {
+		return nil, err
+	}
+
+	opts := database.ExhaustiveSearchJobsListOptions{InitiatorID: userID}
+	args.ConnectionArgs.Set(&opts.LimitOffset)
+	return &searchJobConnectionResolver{db: r.db, opts: opts}, nil
+}
+
+// searchJobConnectionResolver resolves a list of search jobs.
+//
+// 🚨 SECURITY: When instantiating a searchJobConnectionResolver value, the
+// caller MUST check permissions.
+type searchJobConnectionResolver struct {
+	db   database.DB
+	opts database.ExhaustiveSearchJobsListOptions
+
+	// cache results because they are used by multiple fields
+	once sync.Once
+	jobs []*types.ExhaustiveSearchJob
+	err  error
+}
+
+func (r *searchJobConnectionResolver) compute(ctx context.Context) ([]*types.ExhaustiveSearchJob, error) {
+	r.once.Do(func() {
+		opts := r.opts
+		if opts.LimitOffset != nil {
+			tmp := *opts.LimitOffset
+			opts.LimitOffset = &tmp
+			opts.Limit++ // so we can detect if there is a next page
+		}
+		r.jobs, r.err = r.db.ExhaustiveSearchJobs().List(ctx, opts)
+	})
+	return r.jobs, r.err
+}
+
+func (r *searchJobConnectionResolver) Nodes(ctx context.Context) ([]*searchJobResolver, error) {
+	jobs, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.opts.LimitOffset != nil && len(jobs) > r.opts.Limit {
+		jobs = jobs[:r.opts.Limit]
+	}
+
+	resolvers := make([]*searchJobResolver, 0, len(jobs))
+	for _, job := range jobs {
+		resolvers = append(resolvers, &searchJobResolver{db: r.db, job: job})
+	}
+	return resolvers, nil
+}
+
+func (r *searchJobConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	count, err := r.db.ExhaustiveSearchJobs().Count(ctx, database.ExhaustiveSearchJobsListOptions{InitiatorID: r.opts.InitiatorID})
+	return int32(count), err
+}
+
+func (r *searchJobConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	jobs, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlutil.HasNextPage(r.opts.LimitOffset != nil && len(jobs) > r.opts.Limit), nil
+}
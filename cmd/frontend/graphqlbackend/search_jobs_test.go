@@ -0,0 +1,106 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestCreateSearchJob(t *testing.T) {
+	jobs := database.NewMockExhaustiveSearchJobStore()
+	jobs.CreateFunc.SetDefaultHook(func(_ context.Context, initiatorID int32, query, format string) (*types.ExhaustiveSearchJob, error) {
+		return &types.ExhaustiveSearchJob{ID: 1, InitiatorID: initiatorID, Query: query, Format: format, State: "queued"}, nil
+	})
+
+	db := database.NewMockDB()
+	db.ExhaustiveSearchJobsFunc.SetDefaultReturn(jobs)
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		_, err := newSchemaResolver(db, nil).CreateSearchJob(context.Background(), &createSearchJobArgs{Query: "foo", Format: "CSV"})
+		assert.Equal(t, auth.ErrNotAuthenticated, err)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		_, err := newSchemaResolver(db, nil).CreateSearchJob(ctx, &createSearchJobArgs{Query: "foo count:abc", Format: "CSV"})
+		assert.Error(t, err)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		job, err := newSchemaResolver(db, nil).CreateSearchJob(ctx, &createSearchJobArgs{Query: "foo", Format: "JSONL"})
+		require.NoError(t, err)
+		assert.Equal(t, "QUEUED", job.State())
+		assert.Equal(t, "JSONL", job.Format())
+		assert.Nil(t, job.DownloadURL())
+
+		mockrequire.CalledOnceWith(t, jobs.CreateFunc, mockrequire.Values(mockrequire.Skip, int32(1), "foo", "jsonl"))
+	})
+}
+
+func TestSearchJobs(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1}, nil)
+
+	jobs := database.NewMockExhaustiveSearchJobStore()
+	jobs.ListFunc.SetDefaultReturn([]*types.ExhaustiveSearchJob{{ID: 2, InitiatorID: 1}, {ID: 1, InitiatorID: 1}}, nil)
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.ExhaustiveSearchJobsFunc.SetDefaultReturn(jobs)
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	t.Run("own jobs", func(t *testing.T) {
+		first := int32(1)
+		conn, err := newSchemaResolver(db, nil).SearchJobs(ctx, &searchJobsArgs{ConnectionArgs: graphqlutil.ConnectionArgs{First: &first}})
+		require.NoError(t, err)
+
+		nodes, err := conn.Nodes(ctx)
+		require.NoError(t, err)
+		require.Len(t, nodes, 1)
+		assert.Equal(t, marshalSearchJobID(2), nodes[0].ID())
+
+		pageInfo, err := conn.PageInfo(ctx)
+		require.NoError(t, err)
+		assert.True(t, pageInfo.HasNextPage())
+
+		mockrequire.CalledOnceWith(t, jobs.ListFunc, mockrequire.Values(mockrequire.Skip, database.ExhaustiveSearchJobsListOptions{
+			InitiatorID: 1,
+			LimitOffset: &database.LimitOffset{Limit: 2},
+		}))
+	})
+
+	t.Run("jobs of another user as non-admin", func(t *testing.T) {
+		other := MarshalUserID(2)
+		_, err := newSchemaResolver(db, nil).SearchJobs(ctx, &searchJobsArgs{User: &other})
+		var authErr *auth.InsufficientAuthorizationError
+		assert.ErrorAs(t, err, &authErr)
+	})
+}
+
+func TestSearchJobState(t *testing.T) {
+	for _, tc := range []struct {
+		state  string
+		cancel bool
+		want   string
+	}{
+		{state: "queued", want: "QUEUED"},
+		{state: "completed", want: "COMPLETED"},
+		{state: "processing", cancel: true, want: "CANCELING"},
+		{state: "failed", cancel: true, want: "CANCELED"},
+		{state: "canceled", cancel: true, want: "CANCELED"},
+	} {
+		r := &searchJobResolver{job: &types.ExhaustiveSearchJob{State: tc.state, Cancel: tc.cancel}}
+		assert.Equal(t, tc.want, r.State(), "state %q, cancel %v", tc.state, tc.cancel)
+	}
+}
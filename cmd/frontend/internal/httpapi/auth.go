@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/sourcegraph/log"
@@ -39,7 +40,12 @@ func AccessTokenAuthMiddleware(db database.DB, logger log.Logger, next http.Hand
 		if headerValue := r.Header.Get("Authorization"); headerValue != "" && token == "" {
 			// Handle Authorization header
 			var err error
-			token, sudoUser, err = authz.ParseAuthorizationHeader(headerValue)
+			if isSCIMRequest(r) && strings.HasPrefix(headerValue, authz.SchemeBearer+" ") {
+				// SCIM clients only support sending the access token as a bearer token.
+				token, err = authz.ParseBearerAuthorizationHeader(headerValue)
+			} else {
+				token, sudoUser, err = authz.ParseAuthorizationHeader(headerValue)
+			}
 			if err != nil {
 				if authz.IsUnrecognizedScheme(err) {
 					// Ignore Authorization headers that we don't handle.
@@ -72,13 +78,16 @@ func AccessTokenAuthMiddleware(db database.DB, logger log.Logger, next http.Hand
 			//
 			// 🚨 SECURITY: It's important we check for the correct scopes to know what this token
 			// is allowed to do.
-			var requiredScope string
+			var (
+				subjectUserID int32
+				scopes        []string
+				err           error
+			)
 			if sudoUser == "" {
-				requiredScope = authz.ScopeUserAll
+				subjectUserID, scopes, err = db.AccessTokens().LookupAnyScope(r.Context(), token)
 			} else {
-				requiredScope = authz.ScopeSiteAdminSudo
+				subjectUserID, err = db.AccessTokens().Lookup(r.Context(), token, authz.ScopeSiteAdminSudo)
 			}
-			subjectUserID, err := db.AccessTokens().Lookup(r.Context(), token, requiredScope)
 			if err != nil {
 				if err == database.ErrAccessTokenNotFound || errors.HasType(err, database.InvalidTokenError{}) {
 					logger.Error(
@@ -115,6 +124,17 @@ func AccessTokenAuthMiddleware(db database.DB, logger log.Logger, next http.Hand
 				return
 			}
 
+			// 🚨 SECURITY: Tokens without the user:all scope may only be used for the parts of
+			// the API that their narrow scopes grant access to.
+			var restrictedScopes []string
+			if sudoUser == "" && !authz.ScopesAllow(scopes, authz.ScopeUserAll) {
+				restrictedScopes = scopes
+				if !scopesAllowPath(restrictedScopes, r.URL.Path) {
+					http.Error(w, "Access token does not have the required scope.", http.StatusForbidden)
+					return
+				}
+			}
+
 			// FIXME: Can we find a way to do this only for SOAP users?
 			soapCount, err := db.UserExternalAccounts().Count(
 				r.Context(),
@@ -247,6 +267,7 @@ func AccessTokenAuthMiddleware(db database.DB, logger log.Logger, next http.Hand
 					&actor.Actor{
 						UID:                 actorUserID,
 						SourcegraphOperator: sourcegraphOperator,
+						Scopes:              restrictedScopes,
 					},
 				),
 			)
@@ -255,3 +276,47 @@ func AccessTokenAuthMiddleware(db database.DB, logger log.Logger, next http.Hand
 		next.ServeHTTP(w, r)
 	})
 }
+
+// scopedPathPrefixes maps URL path prefixes to the narrow access token scope
+// that is required to access them. Requests to the GraphQL API are checked
+// per field by graphqlbackend.CheckAccessTokenScopes instead.
+var scopedPathPrefixes = []struct {
+	prefix string
+	scope  string
+}{
+	{"/.api/search/stream", authz.ScopeSearchRead},
+	{"/.api/compute/stream", authz.ScopeSearchRead},
+	{"/.api/lsif/upload", authz.ScopeCodeIntelUpload},
+	{"/.api/scip/upload", authz.ScopeCodeIntelUpload},
+	{"/.api/files/batch-changes/", authz.ScopeBatchChangesWrite},
+	{"/.api/blame/", authz.ScopeRepoRead},
+}
+
+// scopesAllowPath returns true if a token with the given narrow scopes may be
+// used to access the given URL path. Paths that are not known to be covered by
+// a narrow scope are denied.
+func scopesAllowPath(scopes []string, path string) bool {
+	switch {
+	case path == "/.api/graphql":
+		return true
+	case strings.HasPrefix(path, "/.api/src-cli/"):
+		// src-cli downloads are public, but src-cli sends its token along.
+		return true
+	case strings.Contains(path, "/-/raw/"):
+		return authz.ScopesAllow(scopes, authz.ScopeRepoRead)
+	}
+	for _, p := range scopedPathPrefixes {
+		if strings.HasPrefix(path, p.prefix) {
+			return authz.ScopesAllow(scopes, p.scope)
+		}
+	}
+	return false
+}
+
+// scimPathPrefix is the path prefix of the SCIM API, the only API that accepts
+// access tokens given as bearer tokens.
+const scimPathPrefix = "/.api/scim/v2/"
+
+func isSCIMRequest(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, scimPathPrefix)
+}
@@ -81,7 +81,7 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 		req.Header.Set("Authorization", "token badbad")
 
 		accessTokens := database.NewMockAccessTokenStore()
-		accessTokens.LookupFunc.SetDefaultReturn(0, database.InvalidTokenError{})
+		accessTokens.LookupAnyScopeFunc.SetDefaultReturn(0, nil, database.InvalidTokenError{})
 		db.AccessTokensFunc.SetDefaultReturn(accessTokens)
 
 		securityEventLogs := database.NewMockSecurityEventLogsStore()
@@ -93,7 +93,7 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 		db.SecurityEventLogsFunc.SetDefaultReturn(securityEventLogs)
 
 		checkHTTPResponse(t, db, req, http.StatusUnauthorized, "Invalid access token.\n")
-		mockrequire.Called(t, accessTokens.LookupFunc)
+		mockrequire.Called(t, accessTokens.LookupAnyScopeFunc)
 		mockrequire.Called(t, securityEventLogs.LogEventFunc)
 	})
 
@@ -103,22 +103,49 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 			req.Header.Set("Authorization", headerValue)
 
 			accessTokens := database.NewMockAccessTokenStore()
-			accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded, requiredScope string) (subjectUserID int32, err error) {
+			accessTokens.LookupAnyScopeFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded string) (subjectUserID int32, scopes []string, err error) {
 				if want := "abcdef"; tokenHexEncoded != want {
 					t.Errorf("got %q, want %q", tokenHexEncoded, want)
 				}
-				if want := authz.ScopeUserAll; requiredScope != want {
-					t.Errorf("got %q, want %q", requiredScope, want)
-				}
-				return 123, nil
+				return 123, []string{authz.ScopeUserAll}, nil
 			})
 			db.AccessTokensFunc.SetDefaultReturn(accessTokens)
 
 			checkHTTPResponse(t, db, req, http.StatusOK, "user 123")
-			mockrequire.Called(t, accessTokens.LookupFunc)
+			mockrequire.Called(t, accessTokens.LookupAnyScopeFunc)
 		})
 	}
 
+	t.Run("bearer token on SCIM API", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/.api/scim/v2/Users", nil)
+		req.Header.Set("Authorization", "Bearer abcdef")
+
+		accessTokens := database.NewMockAccessTokenStore()
+		accessTokens.LookupAnyScopeFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded string) (subjectUserID int32, scopes []string, err error) {
+			if want := "abcdef"; tokenHexEncoded != want {
+				t.Errorf("got %q, want %q", tokenHexEncoded, want)
+			}
+			return 123, []string{authz.ScopeUserAll}, nil
+		})
+		db.AccessTokensFunc.SetDefaultReturn(accessTokens)
+
+		checkHTTPResponse(t, db, req, http.StatusOK, "user 123")
+		mockrequire.Called(t, accessTokens.LookupAnyScopeFunc)
+	})
+
+	// Bearer tokens are left alone outside the SCIM API, since a proxy in front of
+	// Sourcegraph may set them for its own purposes.
+	t.Run("bearer token outside SCIM API", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/.api/graphql", nil)
+		req.Header.Set("Authorization", "Bearer abcdef")
+
+		accessTokens := database.NewMockAccessTokenStore()
+		db.AccessTokensFunc.SetDefaultReturn(accessTokens)
+
+		checkHTTPResponse(t, db, req, http.StatusOK, "no user")
+		mockrequire.NotCalled(t, accessTokens.LookupAnyScopeFunc)
+	})
+
 	// Test that an access token overwrites the actor set by a prior auth middleware.
 	t.Run("actor present, valid non-sudo token", func(t *testing.T) {
 		req, _ := http.NewRequest("GET", "/", nil)
@@ -126,19 +153,16 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 		req = req.WithContext(actor.WithActor(context.Background(), &actor.Actor{UID: 456}))
 
 		accessTokens := database.NewMockAccessTokenStore()
-		accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded, requiredScope string) (subjectUserID int32, err error) {
+		accessTokens.LookupAnyScopeFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded string) (subjectUserID int32, scopes []string, err error) {
 			if want := "abcdef"; tokenHexEncoded != want {
 				t.Errorf("got %q, want %q", tokenHexEncoded, want)
 			}
-			if want := authz.ScopeUserAll; requiredScope != want {
-				t.Errorf("got %q, want %q", requiredScope, want)
-			}
-			return 123, nil
+			return 123, []string{authz.ScopeUserAll}, nil
 		})
 		db.AccessTokensFunc.SetDefaultReturn(accessTokens)
 
 		checkHTTPResponse(t, db, req, http.StatusOK, "user 123")
-		mockrequire.Called(t, accessTokens.LookupFunc)
+		mockrequire.Called(t, accessTokens.LookupAnyScopeFunc)
 	})
 
 	// Test that an access token overwrites the actor set by a prior auth middleware.
@@ -159,19 +183,42 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 			req = req.WithContext(actor.WithActor(context.Background(), &actor.Actor{UID: 456}))
 
 			accessTokens := database.NewMockAccessTokenStore()
-			accessTokens.LookupFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded, requiredScope string) (subjectUserID int32, err error) {
+			accessTokens.LookupAnyScopeFunc.SetDefaultHook(func(_ context.Context, tokenHexEncoded string) (subjectUserID int32, scopes []string, err error) {
 				if want := "abcdef"; tokenHexEncoded != want {
 					t.Errorf("got %q, want %q", tokenHexEncoded, want)
 				}
-				if want := authz.ScopeUserAll; requiredScope != want {
-					t.Errorf("got %q, want %q", requiredScope, want)
-				}
-				return 123, nil
+				return 123, []string{authz.ScopeUserAll}, nil
 			})
 			db.AccessTokensFunc.SetDefaultReturn(accessTokens)
 
 			checkHTTPResponse(t, db, req, http.StatusOK, "user 123")
-			mockrequire.Called(t, accessTokens.LookupFunc)
+			mockrequire.Called(t, accessTokens.LookupAnyScopeFunc)
+		})
+	}
+
+	// 🚨 SECURITY: Tokens with only narrow scopes must be restricted to the paths their scopes
+	// grant access to.
+	for _, tc := range []struct {
+		path           string
+		wantStatusCode int
+		wantBody       string
+	}{
+		{"/.api/search/stream", http.StatusOK, "user 123"},
+		{"/.api/graphql", http.StatusOK, "user 123"},
+		{"/.api/lsif/upload", http.StatusForbidden, "Access token does not have the required scope.\n"},
+		{"/.api/telemetry", http.StatusForbidden, "Access token does not have the required scope.\n"},
+		{"/settings", http.StatusForbidden, "Access token does not have the required scope.\n"},
+	} {
+		t.Run("valid narrow-scoped token: "+tc.path, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tc.path, nil)
+			req.Header.Set("Authorization", "token abcdef")
+
+			accessTokens := database.NewMockAccessTokenStore()
+			accessTokens.LookupAnyScopeFunc.SetDefaultReturn(123, []string{authz.ScopeSearchRead}, nil)
+			db.AccessTokensFunc.SetDefaultReturn(accessTokens)
+
+			checkHTTPResponse(t, db, req, tc.wantStatusCode, tc.wantBody)
+			mockrequire.Called(t, accessTokens.LookupAnyScopeFunc)
 		})
 	}
 
@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/cookie"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
@@ -73,6 +74,15 @@ func serveGraphQL(logger sglog.Logger, schema *graphql.Schema, rlw graphqlbacken
 			recordAuditLog(r.Context(), logger, traceData)
 		}()
 
+		// 🚨 SECURITY: Access tokens that only have narrow scopes may only use the parts of the
+		// schema that their scopes grant access to.
+		if a := actor.FromContext(r.Context()); !authz.ActorHasScope(a, authz.ScopeUserAll) {
+			if err := graphqlbackend.CheckAccessTokenScopes(schema.ASTSchema(), params.Query, params.OperationName, a.Scopes); err != nil {
+				http.Error(w, err.Error(), http.StatusForbidden)
+				return nil
+			}
+		}
+
 		uid, isIP, anonymous := getUID(r)
 		traceData.uid = uid
 		traceData.anonymous = anonymous
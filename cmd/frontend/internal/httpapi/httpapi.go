@@ -23,6 +23,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/handlerutil"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/httpapi/releasecache"
 	apirouter "github.com/sourcegraph/sourcegraph/cmd/frontend/internal/httpapi/router"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/httpapi/scim"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/httpapi/webhookhandlers"
 	frontendsearch "github.com/sourcegraph/sourcegraph/cmd/frontend/internal/search"
 	registry "github.com/sourcegraph/sourcegraph/cmd/frontend/registry/api"
@@ -129,6 +130,14 @@ func NewHandler(
 	m.Get(apirouter.GraphQL).Handler(trace.Route(handler(serveGraphQL(logger, schema, rateLimiter, false))))
 
 	m.Get(apirouter.SearchStream).Handler(trace.Route(frontendsearch.StreamHandler(db)))
+	m.Get(apirouter.SearchJobResults).Handler(trace.Route(frontendsearch.JobResultsHandler(db)))
+
+	scimHandler := scim.NewHandler(logger, db)
+	m.Get(apirouter.SCIMServiceProviderConfig).Handler(trace.Route(http.HandlerFunc(scimHandler.ServeServiceProviderConfig)))
+	m.Get(apirouter.SCIMUsers).Handler(trace.Route(http.HandlerFunc(scimHandler.ServeUsers)))
+	m.Get(apirouter.SCIMUser).Handler(trace.Route(http.HandlerFunc(scimHandler.ServeUser)))
+	m.Get(apirouter.SCIMGroups).Handler(trace.Route(http.HandlerFunc(scimHandler.ServeGroups)))
+	m.Get(apirouter.SCIMGroup).Handler(trace.Route(http.HandlerFunc(scimHandler.ServeGroup)))
 
 	// Return the minimum src-cli version that's compatible with this instance
 	m.Get(apirouter.SrcCli).Handler(trace.Route(newSrcCliVersionHandler(logger)))
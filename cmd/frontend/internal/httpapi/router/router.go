@@ -14,9 +14,10 @@ const (
 	SCIPUpload       = "scip.upload"
 	SCIPUploadExists = "scip.upload.exists"
 
-	SearchStream   = "search.stream"
-	ComputeStream  = "compute.stream"
-	GitBlameStream = "git.blame.stream"
+	SearchStream     = "search.stream"
+	SearchJobResults = "search.job.results"
+	ComputeStream    = "compute.stream"
+	GitBlameStream   = "git.blame.stream"
 
 	SrcCli             = "src-cli"
 	SrcCliVersionCache = "src-cli.version-cache"
@@ -33,6 +34,12 @@ const (
 	BitbucketServerWebhooks = "bitbucketServer.webhooks"
 	BitbucketCloudWebhooks  = "bitbucketCloud.webhooks"
 
+	SCIMServiceProviderConfig = "scim.service-provider-config"
+	SCIMUsers                 = "scim.users"
+	SCIMUser                  = "scim.user"
+	SCIMGroups                = "scim.groups"
+	SCIMGroup                 = "scim.group"
+
 	BatchesFileGet    = "batches.file.get"
 	BatchesFileExists = "batches.file.exists"
 	BatchesFileUpload = "batches.file.upload"
@@ -74,8 +81,14 @@ func New(base *mux.Router) *mux.Router {
 	base.Path("/scip/upload").Methods("POST").Name(SCIPUpload)
 	base.Path("/scip/upload").Methods("HEAD").Name(SCIPUploadExists)
 	base.Path("/search/stream").Methods("GET").Name(SearchStream)
+	base.Path("/search/jobs/{id:[0-9]+}/results").Methods("GET").Name(SearchJobResults)
 	base.Path("/compute/stream").Methods("GET", "POST").Name(ComputeStream)
 	base.Path("/blame/" + routevar.Repo + routevar.RepoRevSuffix + "/stream/{Path:.*}").Methods("GET").Name(GitBlameStream)
+	base.Path("/scim/v2/ServiceProviderConfig").Methods("GET").Name(SCIMServiceProviderConfig)
+	base.Path("/scim/v2/Users").Methods("GET", "POST").Name(SCIMUsers)
+	base.Path("/scim/v2/Users/{id}").Methods("GET", "PUT", "PATCH", "DELETE").Name(SCIMUser)
+	base.Path("/scim/v2/Groups").Methods("GET", "POST").Name(SCIMGroups)
+	base.Path("/scim/v2/Groups/{id}").Methods("GET", "PUT", "PATCH", "DELETE").Name(SCIMGroup)
 	base.Path("/src-cli/versions/{rest:.*}").Methods("GET", "POST").Name(SrcCliVersionCache)
 	base.Path("/src-cli/{rest:.*}").Methods("GET").Name(SrcCli)
 
@@ -0,0 +1,37 @@
+package scim
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+)
+
+// filter is an equality filter on a single attribute, such as
+// `userName eq "alice"`. Identity providers only use these to look up existing
+// resources before provisioning them, so more complex filters are not
+// supported.
+type filter struct {
+	attribute string
+	value     string
+}
+
+var filterPattern = lazyregexp.New(`^\s*([A-Za-z][\w.]*)\s+(?i:eq)\s+("(?:[^"\\]|\\.)*")\s*$`)
+
+// parseFilter parses the filter query parameter. It returns nil if no filter
+// was given. Attribute names are case-insensitive, so the attribute of the
+// returned filter is lowercased.
+func parseFilter(s string) (*filter, error) {
+	if s == "" {
+		return nil, nil
+	}
+	m := filterPattern.FindStringSubmatch(s)
+	if m == nil {
+		return nil, badRequest("invalidFilter", "unsupported filter %q: only the eq operator is supported", s)
+	}
+	value, err := strconv.Unquote(m[2])
+	if err != nil {
+		return nil, badRequest("invalidFilter", "invalid filter value %s", m[2])
+	}
+	return &filter{attribute: strings.ToLower(m[1]), value: value}, nil
+}
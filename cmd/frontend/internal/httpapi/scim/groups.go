@@ -0,0 +1,429 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	feAuth "github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// groupResource is a SCIM group (RFC 7643 section 4.2), which is mapped to an
+// organization.
+//
+// Groups are identified by the database ID of the organization. The
+// organization name is derived from the displayName of the group when it is
+// created, and is not changed when the group is renamed afterwards.
+type groupResource struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	ExternalID  string      `json:"externalId,omitempty"`
+	DisplayName string      `json:"displayName"`
+	Members     []reference `json:"members,omitempty"`
+	Meta        *meta       `json:"meta,omitempty"`
+}
+
+func orgDisplayName(org *types.Org) string {
+	if org.DisplayName != nil && *org.DisplayName != "" {
+		return *org.DisplayName
+	}
+	return org.Name
+}
+
+func (h *Handler) toGroupResource(ctx context.Context, org *types.Org) (*groupResource, error) {
+	memberships, err := h.db.OrgMembers().GetByOrgID(ctx, org.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &groupResource{
+		Schemas:     []string{groupSchema},
+		ID:          strconv.Itoa(int(org.ID)),
+		DisplayName: orgDisplayName(org),
+		Meta:        newMeta("Group", "Groups", org.ID, org.CreatedAt, org.UpdatedAt),
+	}
+	if len(memberships) == 0 {
+		return res, nil
+	}
+
+	userIDs := make([]int32, 0, len(memberships))
+	for _, m := range memberships {
+		userIDs = append(userIDs, m.UserID)
+	}
+	users, err := h.db.Users().List(ctx, &database.UsersListOptions{UserIDs: userIDs})
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		res.Members = append(res.Members, reference{Value: strconv.Itoa(int(user.ID)), Display: user.Username})
+	}
+	return res, nil
+}
+
+func (h *Handler) writeGroup(ctx context.Context, w http.ResponseWriter, status int, org *types.Org) error {
+	res, err := h.toGroupResource(ctx, org)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusCreated {
+		w.Header().Set("Location", res.Meta.Location)
+	}
+	return writeResponse(w, status, res)
+}
+
+func (h *Handler) listGroups(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	f, err := parseFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		return err
+	}
+	var orgs []*types.Org
+	total, startIndex := 0, 1
+	if f != nil {
+		if f.attribute != "displayname" {
+			return badRequest("invalidFilter", "filtering groups by %q is not supported", f.attribute)
+		}
+		candidates, err := h.db.Orgs().List(ctx, &database.OrgsListOptions{Query: f.value})
+		if err != nil {
+			return err
+		}
+		for _, org := range candidates {
+			if strings.EqualFold(orgDisplayName(org), f.value) {
+				orgs = append(orgs, org)
+			}
+		}
+		total = len(orgs)
+	} else {
+		var count int
+		startIndex, count = pagination(r)
+		opts := &database.OrgsListOptions{}
+		if total, err = h.db.Orgs().Count(ctx, *opts); err != nil {
+			return err
+		}
+		opts.LimitOffset = &database.LimitOffset{Limit: count, Offset: startIndex - 1}
+		if orgs, err = h.db.Orgs().List(ctx, opts); err != nil {
+			return err
+		}
+	}
+
+	resources := make([]any, 0, len(orgs))
+	for _, org := range orgs {
+		res, err := h.toGroupResource(ctx, org)
+		if err != nil {
+			return err
+		}
+		resources = append(resources, res)
+	}
+	return writeList(w, total, startIndex, resources)
+}
+
+func (h *Handler) getGroup(w http.ResponseWriter, r *http.Request, id int32) error {
+	org, err := h.db.Orgs().GetByID(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	return h.writeGroup(r.Context(), w, http.StatusOK, org)
+}
+
+func (h *Handler) createGroup(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	var res groupResource
+	if err := readRequest(r, &res); err != nil {
+		return err
+	}
+	if res.DisplayName == "" {
+		return badRequest("invalidValue", "displayName is required")
+	}
+	name, err := feAuth.NormalizeUsername(res.DisplayName)
+	if err != nil {
+		return badRequest("invalidValue", "%s", err)
+	}
+	if _, err := h.db.Orgs().GetByName(ctx, name); err == nil {
+		return &scimError{status: http.StatusConflict, scimType: "uniqueness", detail: "an organization named " + strconv.Quote(name) + " already exists"}
+	} else if !errcode.IsNotFound(err) {
+		return err
+	}
+	memberIDs, err := h.memberIDs(ctx, res.Members)
+	if err != nil {
+		return err
+	}
+
+	org, err := h.insertGroup(ctx, name, res.DisplayName, memberIDs)
+	if err != nil {
+		return err
+	}
+	return h.writeGroup(ctx, w, http.StatusCreated, org)
+}
+
+func (h *Handler) insertGroup(ctx context.Context, name, displayName string, memberIDs []int32) (_ *types.Org, err error) {
+	tx, err := h.db.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	org, err := tx.Orgs().Create(ctx, name, &displayName)
+	if err != nil {
+		return nil, err
+	}
+	if err := setOrgMembers(ctx, tx, org.ID, memberIDs); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+func (h *Handler) replaceGroup(w http.ResponseWriter, r *http.Request, id int32) error {
+	ctx := r.Context()
+
+	org, err := h.db.Orgs().GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	var res groupResource
+	if err := readRequest(r, &res); err != nil {
+		return err
+	}
+	memberIDs, err := h.memberIDs(ctx, res.Members)
+	if err != nil {
+		return err
+	}
+	return h.updateGroup(w, r, org, res.DisplayName, memberIDs)
+}
+
+func (h *Handler) patchGroup(w http.ResponseWriter, r *http.Request, id int32) error {
+	ctx := r.Context()
+
+	org, err := h.db.Orgs().GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	ops, err := readPatchRequest(r)
+	if err != nil {
+		return err
+	}
+	memberships, err := h.db.OrgMembers().GetByOrgID(ctx, org.ID)
+	if err != nil {
+		return err
+	}
+
+	displayName := orgDisplayName(org)
+	members := make(map[int32]struct{}, len(memberships))
+	for _, m := range memberships {
+		members[m.UserID] = struct{}{}
+	}
+	for _, op := range ops {
+		if err := h.applyGroupPatch(ctx, op, &displayName, members); err != nil {
+			return err
+		}
+	}
+
+	memberIDs := make([]int32, 0, len(members))
+	for id := range members {
+		memberIDs = append(memberIDs, id)
+	}
+	return h.updateGroup(w, r, org, displayName, memberIDs)
+}
+
+// memberPath matches the path that identity providers use to remove a single
+// member from a group.
+var memberPath = lazyregexp.New(`^members\[value eq "(\d+)"\]$`)
+
+// applyGroupPatch applies a PATCH operation to the display name and the set of
+// members of a group.
+func (h *Handler) applyGroupPatch(ctx context.Context, op patchOperation, displayName *string, members map[int32]struct{}) error {
+	path := strings.ToLower(op.Path)
+	if m := memberPath.FindStringSubmatch(path); m != nil && op.Op == "remove" {
+		id, err := parseMemberID(m[1])
+		if err != nil {
+			return err
+		}
+		delete(members, id)
+		return nil
+	}
+
+	switch path {
+	case "":
+		if op.Op == "remove" {
+			return badRequest("noTarget", "remove operations require a path")
+		}
+		var attrs struct {
+			DisplayName *string     `json:"displayName"`
+			Members     []reference `json:"members"`
+		}
+		if err := json.Unmarshal(op.Value, &attrs); err != nil {
+			return badRequest("invalidValue", "patch value must be an object when no path is given")
+		}
+		if attrs.DisplayName != nil {
+			*displayName = *attrs.DisplayName
+		}
+		if attrs.Members != nil {
+			return h.applyMembersPatch(ctx, op.Op, attrs.Members, members)
+		}
+	case "displayname":
+		if op.Op == "remove" {
+			return badRequest("mutability", "displayName is required")
+		}
+		if err := json.Unmarshal(op.Value, displayName); err != nil {
+			return badRequest("invalidValue", "displayName must be a string")
+		}
+	case "members":
+		var refs []reference
+		if len(op.Value) > 0 {
+			if err := json.Unmarshal(op.Value, &refs); err != nil {
+				return badRequest("invalidValue", "members must be a list of references")
+			}
+		}
+		return h.applyMembersPatch(ctx, op.Op, refs, members)
+	}
+	return nil
+}
+
+func (h *Handler) applyMembersPatch(ctx context.Context, op string, refs []reference, members map[int32]struct{}) error {
+	switch op {
+	case "remove":
+		if len(refs) == 0 {
+			// Removing the members attribute without a value removes all of them.
+			for id := range members {
+				delete(members, id)
+			}
+			return nil
+		}
+		for _, ref := range refs {
+			id, err := parseMemberID(ref.Value)
+			if err != nil {
+				return err
+			}
+			delete(members, id)
+		}
+		return nil
+	case "replace":
+		for id := range members {
+			delete(members, id)
+		}
+	}
+
+	ids, err := h.memberIDs(ctx, refs)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		members[id] = struct{}{}
+	}
+	return nil
+}
+
+// updateGroup changes the display name and members of the organization.
+func (h *Handler) updateGroup(w http.ResponseWriter, r *http.Request, org *types.Org, displayName string, memberIDs []int32) error {
+	if displayName == "" {
+		return badRequest("invalidValue", "displayName is required")
+	}
+	if err := h.saveGroup(r.Context(), org, displayName, memberIDs); err != nil {
+		return err
+	}
+	org, err := h.db.Orgs().GetByID(r.Context(), org.ID)
+	if err != nil {
+		return err
+	}
+	return h.writeGroup(r.Context(), w, http.StatusOK, org)
+}
+
+func (h *Handler) saveGroup(ctx context.Context, org *types.Org, displayName string, memberIDs []int32) (err error) {
+	tx, err := h.db.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if displayName != orgDisplayName(org) {
+		if _, err := tx.Orgs().Update(ctx, org.ID, &displayName); err != nil {
+			return err
+		}
+	}
+	return setOrgMembers(ctx, tx, org.ID, memberIDs)
+}
+
+func (h *Handler) deleteGroup(w http.ResponseWriter, r *http.Request, id int32) error {
+	if err := h.db.Orgs().Delete(r.Context(), id); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// memberIDs returns the IDs of the users referenced by refs. It returns an
+// error if any of them does not exist, since SCIM groups only contain users
+// that are provisioned through this API.
+func (h *Handler) memberIDs(ctx context.Context, refs []reference) ([]int32, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	ids := make([]int32, 0, len(refs))
+	for _, ref := range refs {
+		id, err := parseMemberID(ref.Value)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	users, err := h.db.Users().List(ctx, &database.UsersListOptions{UserIDs: ids})
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[int32]struct{}, len(users))
+	for _, user := range users {
+		found[user.ID] = struct{}{}
+	}
+	for _, id := range ids {
+		if _, ok := found[id]; !ok {
+			return nil, badRequest("invalidValue", "member %d does not exist", id)
+		}
+	}
+	return ids, nil
+}
+
+func parseMemberID(value string) (int32, error) {
+	id, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || id <= 0 {
+		return 0, badRequest("invalidValue", "invalid member %q", value)
+	}
+	return int32(id), nil
+}
+
+// setOrgMembers makes the users the only members of the organization.
+func setOrgMembers(ctx context.Context, db database.DB, orgID int32, userIDs []int32) error {
+	memberships, err := db.OrgMembers().GetByOrgID(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	current := make(map[int32]struct{}, len(memberships))
+	for _, m := range memberships {
+		current[m.UserID] = struct{}{}
+	}
+	wanted := make(map[int32]struct{}, len(userIDs))
+	for _, id := range userIDs {
+		wanted[id] = struct{}{}
+		if _, ok := current[id]; ok {
+			continue
+		}
+		if _, err := db.OrgMembers().Create(ctx, orgID, id); err != nil {
+			return err
+		}
+		current[id] = struct{}{}
+	}
+	for id := range current {
+		if _, ok := wanted[id]; !ok {
+			if err := db.OrgMembers().Remove(ctx, orgID, id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,316 @@
+// Package scim implements a SCIM 2.0 (RFC 7643, RFC 7644) service provider,
+// which lets an identity provider create, update, deactivate and delete
+// Sourcegraph users and manage organization membership through SCIM groups.
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	userSchema                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	groupSchema                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	serviceProviderConfigSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	listResponseSchema          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	errorSchema                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	// basePath is the path of the SCIM API, relative to the external URL.
+	basePath = "/.api/scim/v2"
+
+	// maxResults is the maximum number of resources returned in a single list
+	// response.
+	maxResults = 100
+)
+
+// Handler serves the SCIM API.
+//
+// 🚨 SECURITY: The caller MUST wrap the handler in middleware that checks
+// authentication and sets the actor in the request context. Every endpoint
+// requires the actor to be a site admin.
+type Handler struct {
+	logger log.Logger
+	db     database.DB
+}
+
+// NewHandler returns a new SCIM handler.
+func NewHandler(logger log.Logger, db database.DB) *Handler {
+	return &Handler{
+		logger: logger.Scoped("scim", "SCIM user and group provisioning"),
+		db:     db,
+	}
+}
+
+// ServeServiceProviderConfig serves the /ServiceProviderConfig endpoint, which
+// tells identity providers which parts of the specification are supported.
+func (h *Handler) ServeServiceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, func(w http.ResponseWriter, r *http.Request) error {
+		supported := func(b bool) map[string]any { return map[string]any{"supported": b} }
+		return writeResponse(w, http.StatusOK, map[string]any{
+			"schemas":        []string{serviceProviderConfigSchema},
+			"patch":          supported(true),
+			"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+			"filter":         map[string]any{"supported": true, "maxResults": maxResults},
+			"changePassword": supported(false),
+			"sort":           supported(false),
+			"etag":           supported(false),
+			"authenticationSchemes": []map[string]any{{
+				"type":        "oauthbearertoken",
+				"name":        "OAuth Bearer Token",
+				"description": "Authentication with a site admin access token",
+				"primary":     true,
+			}},
+		})
+	})
+}
+
+// ServeUsers serves the /Users endpoint.
+func (h *Handler) ServeUsers(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, func(w http.ResponseWriter, r *http.Request) error {
+		switch r.Method {
+		case http.MethodGet:
+			return h.listUsers(w, r)
+		case http.MethodPost:
+			return h.createUser(w, r)
+		}
+		return errMethodNotAllowed
+	})
+}
+
+// ServeUser serves the /Users/{id} endpoint.
+func (h *Handler) ServeUser(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, func(w http.ResponseWriter, r *http.Request) error {
+		id, err := parseID(mux.Vars(r)["id"])
+		if err != nil {
+			return err
+		}
+		switch r.Method {
+		case http.MethodGet:
+			return h.getUser(w, r, id)
+		case http.MethodPut:
+			return h.replaceUser(w, r, id)
+		case http.MethodPatch:
+			return h.patchUser(w, r, id)
+		case http.MethodDelete:
+			return h.deleteUser(w, r, id)
+		}
+		return errMethodNotAllowed
+	})
+}
+
+// ServeGroups serves the /Groups endpoint.
+func (h *Handler) ServeGroups(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, func(w http.ResponseWriter, r *http.Request) error {
+		switch r.Method {
+		case http.MethodGet:
+			return h.listGroups(w, r)
+		case http.MethodPost:
+			return h.createGroup(w, r)
+		}
+		return errMethodNotAllowed
+	})
+}
+
+// ServeGroup serves the /Groups/{id} endpoint.
+func (h *Handler) ServeGroup(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, func(w http.ResponseWriter, r *http.Request) error {
+		id, err := parseID(mux.Vars(r)["id"])
+		if err != nil {
+			return err
+		}
+		switch r.Method {
+		case http.MethodGet:
+			return h.getGroup(w, r, id)
+		case http.MethodPut:
+			return h.replaceGroup(w, r, id)
+		case http.MethodPatch:
+			return h.patchGroup(w, r, id)
+		case http.MethodDelete:
+			return h.deleteGroup(w, r, id)
+		}
+		return errMethodNotAllowed
+	})
+}
+
+// serve checks that the current user is a site admin before calling handler,
+// and writes any error it returns as a SCIM error response.
+func (h *Handler) serve(w http.ResponseWriter, r *http.Request, handler func(http.ResponseWriter, *http.Request) error) {
+	// 🚨 SECURITY: Only site admins may provision users and groups.
+	if err := auth.CheckCurrentUserIsSiteAdmin(r.Context(), h.db); err != nil {
+		if err == auth.ErrNotAuthenticated {
+			writeError(w, &scimError{status: http.StatusUnauthorized, detail: "Authentication is required."})
+		} else {
+			writeError(w, &scimError{status: http.StatusForbidden, detail: "Must be authenticated as a site admin."})
+		}
+		return
+	}
+
+	err := handler(w, r)
+	if err == nil {
+		return
+	}
+
+	var e *scimError
+	switch {
+	case errors.As(err, &e):
+	case errcode.IsNotFound(err):
+		e = &scimError{status: http.StatusNotFound, detail: "Resource not found."}
+	case database.IsUsernameExists(err) || database.IsEmailExists(err):
+		e = &scimError{status: http.StatusConflict, scimType: "uniqueness", detail: err.Error()}
+	default:
+		h.logger.Error("SCIM request failed", log.String("method", r.Method), log.String("path", r.URL.Path), log.Error(err))
+		e = &scimError{status: http.StatusInternalServerError, detail: "Internal server error."}
+	}
+	writeError(w, e)
+}
+
+// scimError is an error that is reported to the client as a SCIM error
+// response (RFC 7644 section 3.12).
+type scimError struct {
+	status   int
+	scimType string
+	detail   string
+}
+
+func (e *scimError) Error() string { return e.detail }
+
+var errMethodNotAllowed = &scimError{status: http.StatusMethodNotAllowed, detail: "Method not allowed."}
+
+func badRequest(scimType, format string, args ...any) error {
+	return &scimError{status: http.StatusBadRequest, scimType: scimType, detail: fmt.Sprintf(format, args...)}
+}
+
+func writeError(w http.ResponseWriter, e *scimError) {
+	body := map[string]any{
+		"schemas": []string{errorSchema},
+		"status":  strconv.Itoa(e.status),
+		"detail":  e.detail,
+	}
+	if e.scimType != "" {
+		body["scimType"] = e.scimType
+	}
+	_ = writeResponse(w, e.status, body)
+}
+
+func writeResponse(w http.ResponseWriter, status int, v any) error {
+	w.Header().Set("Content-Type", "application/scim+json; charset=utf-8")
+	w.WriteHeader(status)
+	return json.NewEncoder(w).Encode(v)
+}
+
+func readRequest(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return badRequest("invalidSyntax", "invalid request body: %s", err)
+	}
+	return nil
+}
+
+// parseID parses the ID of a user or group. Resources are identified by their
+// database IDs.
+func parseID(s string) (int32, error) {
+	id, err := strconv.ParseInt(s, 10, 32)
+	if err != nil || id <= 0 {
+		return 0, &scimError{status: http.StatusNotFound, detail: "Resource not found."}
+	}
+	return int32(id), nil
+}
+
+// meta is the metadata of a resource.
+type meta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location"`
+}
+
+func newMeta(resourceType, endpoint string, id int32, created, lastModified time.Time) *meta {
+	return &meta{
+		ResourceType: resourceType,
+		Created:      created,
+		LastModified: lastModified,
+		Location:     strings.TrimSuffix(conf.ExternalURL(), "/") + basePath + "/" + endpoint + "/" + strconv.Itoa(int(id)),
+	}
+}
+
+// reference refers to another resource, such as the members of a group or
+// the groups of a user.
+type reference struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+type listResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+func writeList(w http.ResponseWriter, total, startIndex int, resources []any) error {
+	if resources == nil {
+		resources = []any{}
+	}
+	return writeResponse(w, http.StatusOK, &listResponse{
+		Schemas:      []string{listResponseSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+// pagination returns the 1-based start index and page size requested by the
+// startIndex and count query parameters.
+func pagination(r *http.Request) (startIndex, count int) {
+	startIndex, count = 1, maxResults
+	if v, err := strconv.Atoi(r.URL.Query().Get("startIndex")); err == nil && v > 1 {
+		startIndex = v
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("count")); err == nil && v >= 0 && v < maxResults {
+		count = v
+	}
+	return startIndex, count
+}
+
+// patchRequest is a PATCH request body (RFC 7644 section 3.5.2).
+type patchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []patchOperation `json:"Operations"`
+}
+
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+func readPatchRequest(r *http.Request) ([]patchOperation, error) {
+	var req patchRequest
+	if err := readRequest(r, &req); err != nil {
+		return nil, err
+	}
+	for i, op := range req.Operations {
+		// Some identity providers capitalize the operation.
+		req.Operations[i].Op = strings.ToLower(op.Op)
+		switch req.Operations[i].Op {
+		case "add", "replace", "remove":
+		default:
+			return nil, badRequest("invalidSyntax", "unsupported patch operation %q", op.Op)
+		}
+	}
+	return req.Operations, nil
+}
@@ -0,0 +1,306 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// newMockDB returns a mock database in which the current user (ID 1) is a site
+// admin.
+func newMockDB() *database.MockDB {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: true}, nil)
+	users.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id, Username: "alice", DisplayName: "Alice"}, nil
+	})
+
+	db := database.NewMockDB()
+	db.TransactFunc.SetDefaultReturn(db, nil)
+	db.DoneFunc.SetDefaultHook(func(err error) error { return err })
+	db.UsersFunc.SetDefaultReturn(users)
+	db.UserEmailsFunc.SetDefaultReturn(database.NewMockUserEmailsStore())
+	db.UserExternalAccountsFunc.SetDefaultReturn(database.NewMockUserExternalAccountsStore())
+	db.OrgsFunc.SetDefaultReturn(database.NewMockOrgStore())
+	db.OrgMembersFunc.SetDefaultReturn(database.NewMockOrgMemberStore())
+	db.AuthzFunc.SetDefaultReturn(database.NewMockAuthzStore())
+	return db
+}
+
+func serve(t *testing.T, handler http.HandlerFunc, method, target, body string, vars map[string]string) (int, map[string]any) {
+	t.Helper()
+
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	r = r.WithContext(actor.WithActor(r.Context(), &actor.Actor{UID: 1}))
+	r = mux.SetURLVars(r, vars)
+	w := httptest.NewRecorder()
+	handler(w, r)
+
+	var resp map[string]any
+	if w.Body.Len() > 0 {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	}
+	return w.Code, resp
+}
+
+func TestHandler_siteAdminOnly(t *testing.T) {
+	db := newMockDB()
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1}, nil)
+	db.UsersFunc.SetDefaultReturn(users)
+
+	h := NewHandler(logtest.Scoped(t), db)
+	code, resp := serve(t, h.ServeUsers, "GET", "/.api/scim/v2/Users", "", nil)
+	assert.Equal(t, http.StatusForbidden, code)
+	assert.Equal(t, "403", resp["status"])
+	mockrequire.NotCalled(t, users.ListFunc)
+}
+
+func TestCreateUser(t *testing.T) {
+	db := newMockDB()
+	users := db.Users().(*database.MockUserStore)
+	users.CreateFunc.SetDefaultHook(func(_ context.Context, info database.NewUser) (*types.User, error) {
+		return &types.User{ID: 7, Username: info.Username, DisplayName: info.DisplayName}, nil
+	})
+	emails := db.UserEmails().(*database.MockUserEmailsStore)
+	emails.ListByUserFunc.SetDefaultReturn([]*database.UserEmail{{Email: "alice@example.com", Primary: true}}, nil)
+
+	h := NewHandler(logtest.Scoped(t), db)
+	code, resp := serve(t, h.ServeUsers, "POST", "/.api/scim/v2/Users", `{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"userName": "alice@example.com",
+		"name": {"givenName": "Alice", "familyName": "Smith"},
+		"emails": [{"value": "alice@example.com", "primary": true}],
+		"active": true
+	}`, nil)
+	require.Equal(t, http.StatusCreated, code, resp)
+	assert.Equal(t, "7", resp["id"])
+
+	mockrequire.CalledOnceWith(t, users.CreateFunc, mockrequire.Values(mockrequire.Skip, database.NewUser{
+		Username:        "alice",
+		DisplayName:     "Alice Smith",
+		Email:           "alice@example.com",
+		EmailIsVerified: true,
+	}))
+	mockrequire.CalledOnceWith(t, emails.SetPrimaryEmailFunc, mockrequire.Values(mockrequire.Skip, int32(7), "alice@example.com"))
+	mockrequire.NotCalled(t, emails.AddFunc)
+}
+
+func TestPatchUser(t *testing.T) {
+	t.Run("update emails", func(t *testing.T) {
+		db := newMockDB()
+		emails := db.UserEmails().(*database.MockUserEmailsStore)
+		emails.ListByUserFunc.SetDefaultReturn([]*database.UserEmail{{Email: "alice@old.example.com", Primary: true}}, nil)
+
+		h := NewHandler(logtest.Scoped(t), db)
+		code, resp := serve(t, h.ServeUser, "PATCH", "/.api/scim/v2/Users/2", `{
+			"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+			"Operations": [{"op": "Replace", "path": "emails[type eq \"work\"].value", "value": "alice@new.example.com"}]
+		}`, map[string]string{"id": "2"})
+		require.Equal(t, http.StatusOK, code, resp)
+
+		mockrequire.CalledOnceWith(t, emails.AddFunc, mockrequire.Values(mockrequire.Skip, int32(2), "alice@new.example.com"))
+		mockrequire.CalledOnceWith(t, emails.SetVerifiedFunc, mockrequire.Values(mockrequire.Skip, int32(2), "alice@new.example.com", true))
+		mockrequire.CalledOnceWith(t, emails.SetPrimaryEmailFunc, mockrequire.Values(mockrequire.Skip, int32(2), "alice@new.example.com"))
+		mockrequire.CalledOnceWith(t, emails.RemoveFunc, mockrequire.Values(mockrequire.Skip, int32(2), "alice@old.example.com"))
+	})
+
+	t.Run("deactivate", func(t *testing.T) {
+		db := newMockDB()
+		users := db.Users().(*database.MockUserStore)
+		authz := db.Authz().(*database.MockAuthzStore)
+
+		h := NewHandler(logtest.Scoped(t), db)
+		code, resp := serve(t, h.ServeUser, "PATCH", "/.api/scim/v2/Users/2", `{
+			"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+			"Operations": [{"op": "Replace", "path": "active", "value": "False"}]
+		}`, map[string]string{"id": "2"})
+		require.Equal(t, http.StatusOK, code, resp)
+		assert.Equal(t, false, resp["active"])
+
+		mockrequire.CalledOnceWith(t, users.DeleteFunc, mockrequire.Values(mockrequire.Skip, int32(2)))
+		mockrequire.NotCalled(t, users.HardDeleteFunc)
+		mockrequire.CalledOnce(t, authz.RevokeUserPermissionsListFunc)
+	})
+
+	t.Run("current user", func(t *testing.T) {
+		db := newMockDB()
+		users := db.Users().(*database.MockUserStore)
+
+		h := NewHandler(logtest.Scoped(t), db)
+		code, _ := serve(t, h.ServeUser, "PATCH", "/.api/scim/v2/Users/1", `{
+			"Operations": [{"op": "replace", "value": {"active": false}}]
+		}`, map[string]string{"id": "1"})
+		assert.Equal(t, http.StatusBadRequest, code)
+		mockrequire.NotCalled(t, users.DeleteFunc)
+	})
+}
+
+func TestDeactivateAndReactivateUser(t *testing.T) {
+	db := newMockDB()
+	users := db.Users().(*database.MockUserStore)
+	deactivated := false
+	users.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*types.User, error) {
+		if deactivated {
+			return nil, database.NewUserNotFoundError(id)
+		}
+		return &types.User{ID: id, Username: "alice", DisplayName: "Alice"}, nil
+	})
+	users.ListFunc.SetDefaultHook(func(_ context.Context, opts *database.UsersListOptions) ([]*types.User, error) {
+		if deactivated && !opts.IncludeDeleted {
+			return nil, nil
+		}
+		return []*types.User{{ID: 2, Username: "alice", DisplayName: "Alice"}}, nil
+	})
+	users.DeleteFunc.SetDefaultHook(func(context.Context, int32) error {
+		deactivated = true
+		return nil
+	})
+	users.RecoverUsersListFunc.SetDefaultHook(func(context.Context, []int32) error {
+		deactivated = false
+		return nil
+	})
+	emails := db.UserEmails().(*database.MockUserEmailsStore)
+
+	h := NewHandler(logtest.Scoped(t), db)
+	vars := map[string]string{"id": "2"}
+	setActive := func(active string) (int, map[string]any) {
+		return serve(t, h.ServeUser, "PATCH", "/.api/scim/v2/Users/2", `{
+			"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+			"Operations": [{"op": "replace", "path": "active", "value": `+active+`}]
+		}`, vars)
+	}
+
+	code, resp := setActive("false")
+	require.Equal(t, http.StatusOK, code, resp)
+	assert.Equal(t, false, resp["active"])
+	mockrequire.CalledOnceWith(t, users.DeleteFunc, mockrequire.Values(mockrequire.Skip, int32(2)))
+
+	// Deactivated users are still returned, so that they can be reactivated.
+	code, resp = serve(t, h.ServeUser, "GET", "/.api/scim/v2/Users/2", "", vars)
+	require.Equal(t, http.StatusOK, code, resp)
+	assert.Equal(t, false, resp["active"])
+
+	// Deactivating a deactivated user does nothing.
+	code, resp = setActive("false")
+	require.Equal(t, http.StatusOK, code, resp)
+	mockrequire.CalledOnce(t, users.DeleteFunc)
+
+	code, resp = setActive("true")
+	require.Equal(t, http.StatusOK, code, resp)
+	assert.Equal(t, true, resp["active"])
+	assert.Equal(t, "alice", resp["userName"])
+	mockrequire.CalledOnceWith(t, users.RecoverUsersListFunc, mockrequire.Values(mockrequire.Skip, []int32{2}))
+	mockrequire.NotCalled(t, users.CreateFunc)
+
+	// Emails are removed on deactivation, so they are restored from a replaced
+	// resource.
+	code, resp = setActive("false")
+	require.Equal(t, http.StatusOK, code, resp)
+	code, resp = serve(t, h.ServeUser, "PUT", "/.api/scim/v2/Users/2", `{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"userName": "alice",
+		"emails": [{"value": "alice@example.com", "primary": true}],
+		"active": true
+	}`, vars)
+	require.Equal(t, http.StatusOK, code, resp)
+	assert.Equal(t, true, resp["active"])
+	mockrequire.CalledN(t, users.RecoverUsersListFunc, 2)
+	mockrequire.CalledOnceWith(t, emails.AddFunc, mockrequire.Values(mockrequire.Skip, int32(2), "alice@example.com"))
+}
+
+func TestDeleteUser(t *testing.T) {
+	db := newMockDB()
+	users := db.Users().(*database.MockUserStore)
+
+	h := NewHandler(logtest.Scoped(t), db)
+	code, _ := serve(t, h.ServeUser, "DELETE", "/.api/scim/v2/Users/2", "", map[string]string{"id": "2"})
+	assert.Equal(t, http.StatusNoContent, code)
+	mockrequire.CalledOnceWith(t, users.HardDeleteFunc, mockrequire.Values(mockrequire.Skip, int32(2)))
+}
+
+func TestListUsers_filter(t *testing.T) {
+	db := newMockDB()
+	users := db.Users().(*database.MockUserStore)
+	users.GetByUsernameFunc.SetDefaultHook(func(_ context.Context, username string) (*types.User, error) {
+		return &types.User{ID: 2, Username: username}, nil
+	})
+
+	h := NewHandler(logtest.Scoped(t), db)
+	code, resp := serve(t, h.ServeUsers, "GET", `/.api/scim/v2/Users?filter=userName+eq+%22alice%40example.com%22`, "", nil)
+	require.Equal(t, http.StatusOK, code, resp)
+	assert.Equal(t, float64(1), resp["totalResults"])
+	mockrequire.CalledOnceWith(t, users.GetByUsernameFunc, mockrequire.Values(mockrequire.Skip, "alice"))
+
+	code, resp = serve(t, h.ServeUsers, "GET", `/.api/scim/v2/Users?filter=title+eq+%22x%22`, "", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "invalidFilter", resp["scimType"])
+}
+
+func TestPatchGroup(t *testing.T) {
+	db := newMockDB()
+	orgs := db.Orgs().(*database.MockOrgStore)
+	orgs.GetByIDFunc.SetDefaultReturn(&types.Org{ID: 5, Name: "engineering"}, nil)
+	members := db.OrgMembers().(*database.MockOrgMemberStore)
+	members.GetByOrgIDFunc.PushReturn([]*types.OrgMembership{{OrgID: 5, UserID: 2}, {OrgID: 5, UserID: 3}}, nil)
+	members.GetByOrgIDFunc.PushReturn([]*types.OrgMembership{{OrgID: 5, UserID: 2}, {OrgID: 5, UserID: 3}}, nil)
+	users := db.Users().(*database.MockUserStore)
+	users.ListFunc.SetDefaultHook(func(_ context.Context, opts *database.UsersListOptions) ([]*types.User, error) {
+		var result []*types.User
+		for _, id := range opts.UserIDs {
+			result = append(result, &types.User{ID: id})
+		}
+		return result, nil
+	})
+
+	h := NewHandler(logtest.Scoped(t), db)
+	code, resp := serve(t, h.ServeGroup, "PATCH", "/.api/scim/v2/Groups/5", `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [
+			{"op": "add", "path": "members", "value": [{"value": "4"}]},
+			{"op": "remove", "path": "members[value eq \"3\"]"},
+			{"op": "replace", "path": "displayName", "value": "Engineering"}
+		]
+	}`, map[string]string{"id": "5"})
+	require.Equal(t, http.StatusOK, code, resp)
+
+	mockrequire.CalledOnceWith(t, members.CreateFunc, mockrequire.Values(mockrequire.Skip, int32(5), int32(4)))
+	mockrequire.CalledOnceWith(t, members.RemoveFunc, mockrequire.Values(mockrequire.Skip, int32(5), int32(3)))
+	mockrequire.CalledOnce(t, orgs.UpdateFunc)
+}
+
+func TestParseFilter(t *testing.T) {
+	for input, want := range map[string]*filter{
+		``:                            nil,
+		`userName eq "alice"`:         {attribute: "username", value: "alice"},
+		`emails.value EQ "a@b.com"`:   {attribute: "emails.value", value: "a@b.com"},
+		`displayName eq "a \"b\" c"`:  {attribute: "displayname", value: `a "b" c`},
+		`  userName   eq   "alice"  `: {attribute: "username", value: "alice"},
+	} {
+		got, err := parseFilter(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	for _, input := range []string{
+		`userName co "alice"`,
+		`userName eq alice`,
+		`userName eq "alice" and active eq true`,
+	} {
+		_, err := parseFilter(input)
+		assert.Error(t, err, input)
+	}
+}
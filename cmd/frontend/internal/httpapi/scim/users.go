@@ -0,0 +1,604 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	feAuth "github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// userResource is a SCIM user (RFC 7643 section 4.1).
+//
+// Users are identified by their database ID. The userName is normalized to a
+// valid Sourcegraph username, and emails provisioned through SCIM are
+// considered verified because the identity provider is authoritative for them.
+type userResource struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	ExternalID  string      `json:"externalId,omitempty"`
+	UserName    string      `json:"userName"`
+	Name        *userName   `json:"name,omitempty"`
+	DisplayName string      `json:"displayName,omitempty"`
+	Emails      []userEmail `json:"emails,omitempty"`
+	Active      *bool       `json:"active,omitempty"`
+	Groups      []reference `json:"groups,omitempty"`
+	Meta        *meta       `json:"meta,omitempty"`
+}
+
+type userName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type userEmail struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// displayName returns the display name of the user, falling back to the
+// components of their name.
+func (u *userResource) displayName() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	if u.Name == nil {
+		return ""
+	}
+	if u.Name.Formatted != "" {
+		return u.Name.Formatted
+	}
+	return strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName)
+}
+
+// primaryEmail returns the email marked as primary, or the first email if none
+// is.
+func (u *userResource) primaryEmail() string {
+	for _, e := range u.Emails {
+		if e.Primary {
+			return e.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
+
+func (u *userResource) active() bool {
+	return u.Active == nil || *u.Active
+}
+
+func (h *Handler) toUserResource(ctx context.Context, user *types.User, active bool) (*userResource, error) {
+	emails, err := h.db.UserEmails().ListByUser(ctx, database.UserEmailsListOptions{UserID: user.ID})
+	if err != nil {
+		return nil, err
+	}
+	orgs, err := h.db.Orgs().GetByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &userResource{
+		Schemas:     []string{userSchema},
+		ID:          strconv.Itoa(int(user.ID)),
+		UserName:    user.Username,
+		DisplayName: user.DisplayName,
+		Active:      &active,
+		Meta:        newMeta("User", "Users", user.ID, user.CreatedAt, user.UpdatedAt),
+	}
+	if user.DisplayName != "" {
+		res.Name = &userName{Formatted: user.DisplayName}
+	}
+	for _, e := range emails {
+		res.Emails = append(res.Emails, userEmail{Value: e.Email, Primary: e.Primary})
+	}
+	for _, org := range orgs {
+		res.Groups = append(res.Groups, reference{Value: strconv.Itoa(int(org.ID)), Display: orgDisplayName(org)})
+	}
+	return res, nil
+}
+
+func (h *Handler) writeUser(ctx context.Context, w http.ResponseWriter, status int, user *types.User, active bool) error {
+	res, err := h.toUserResource(ctx, user, active)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusCreated {
+		w.Header().Set("Location", res.Meta.Location)
+	}
+	return writeResponse(w, status, res)
+}
+
+func (h *Handler) listUsers(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	f, err := parseFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		return err
+	}
+	if f != nil {
+		var user *types.User
+		switch f.attribute {
+		case "username":
+			username, normalizeErr := feAuth.NormalizeUsername(f.value)
+			if normalizeErr != nil {
+				return writeList(w, 0, 1, nil)
+			}
+			user, err = h.db.Users().GetByUsername(ctx, username)
+		case "emails", "emails.value":
+			user, err = h.db.Users().GetByVerifiedEmail(ctx, f.value)
+		default:
+			return badRequest("invalidFilter", "filtering users by %q is not supported", f.attribute)
+		}
+		if errcode.IsNotFound(err) {
+			return writeList(w, 0, 1, nil)
+		} else if err != nil {
+			return err
+		}
+		res, err := h.toUserResource(ctx, user, true)
+		if err != nil {
+			return err
+		}
+		return writeList(w, 1, 1, []any{res})
+	}
+
+	startIndex, count := pagination(r)
+	opts := &database.UsersListOptions{
+		// Sourcegraph operator accounts are not managed by the identity provider.
+		ExcludeSourcegraphOperators: true,
+	}
+	total, err := h.db.Users().Count(ctx, opts)
+	if err != nil {
+		return err
+	}
+	opts.LimitOffset = &database.LimitOffset{Limit: count, Offset: startIndex - 1}
+	users, err := h.db.Users().List(ctx, opts)
+	if err != nil {
+		return err
+	}
+	resources := make([]any, 0, len(users))
+	for _, user := range users {
+		res, err := h.toUserResource(ctx, user, true)
+		if err != nil {
+			return err
+		}
+		resources = append(resources, res)
+	}
+	return writeList(w, total, startIndex, resources)
+}
+
+func (h *Handler) getUser(w http.ResponseWriter, r *http.Request, id int32) error {
+	user, active, err := h.userByID(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	return h.writeUser(r.Context(), w, http.StatusOK, user, active)
+}
+
+// userByID returns the user with the given ID, and whether they are active.
+// Unlike the rest of Sourcegraph, the API returns deactivated users, so that
+// identity providers can reactivate or delete them.
+func (h *Handler) userByID(ctx context.Context, id int32) (*types.User, bool, error) {
+	user, err := h.db.Users().GetByID(ctx, id)
+	if err == nil || !errcode.IsNotFound(err) {
+		return user, true, err
+	}
+	deactivated, listErr := h.db.Users().List(ctx, &database.UsersListOptions{
+		UserIDs:        []int32{id},
+		IncludeDeleted: true,
+	})
+	if listErr != nil {
+		return nil, false, listErr
+	}
+	if len(deactivated) == 0 {
+		return nil, false, err
+	}
+	return deactivated[0], false, nil
+}
+
+func (h *Handler) createUser(w http.ResponseWriter, r *http.Request) error {
+	var res userResource
+	if err := readRequest(r, &res); err != nil {
+		return err
+	}
+	if !res.active() {
+		return badRequest("invalidValue", "users must be active when they are created")
+	}
+	username, err := normalizeUsername(res.UserName)
+	if err != nil {
+		return err
+	}
+
+	user, err := h.insertUser(r.Context(), username, &res)
+	if err != nil {
+		return err
+	}
+	return h.writeUser(r.Context(), w, http.StatusCreated, user, true)
+}
+
+func (h *Handler) insertUser(ctx context.Context, username string, res *userResource) (_ *types.User, err error) {
+	tx, err := h.db.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	email := res.primaryEmail()
+	user, err := tx.Users().Create(ctx, database.NewUser{
+		Username:    username,
+		DisplayName: res.displayName(),
+		Email:       email,
+		// 🚨 SECURITY: The identity provider is authoritative for the emails of
+		// the users it provisions, and only site admins may call this API.
+		EmailIsVerified: email != "",
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := setUserEmails(ctx, tx, user.ID, res.Emails); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (h *Handler) replaceUser(w http.ResponseWriter, r *http.Request, id int32) error {
+	user, active, err := h.userByID(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	var res userResource
+	if err := readRequest(r, &res); err != nil {
+		return err
+	}
+	return h.updateUser(w, r, user, active, &res)
+}
+
+func (h *Handler) patchUser(w http.ResponseWriter, r *http.Request, id int32) error {
+	ctx := r.Context()
+
+	user, active, err := h.userByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	ops, err := readPatchRequest(r)
+	if err != nil {
+		return err
+	}
+	res, err := h.toUserResource(ctx, user, active)
+	if err != nil {
+		return err
+	}
+	for _, op := range ops {
+		if err := res.apply(op); err != nil {
+			return err
+		}
+	}
+	return h.updateUser(w, r, user, active, res)
+}
+
+// updateUser changes user to match the resource. Setting active to false
+// deactivates the user, and setting it to true reactivates a deactivated user.
+// Other changes to deactivated users are ignored.
+func (h *Handler) updateUser(w http.ResponseWriter, r *http.Request, user *types.User, active bool, res *userResource) error {
+	if !res.active() {
+		if !active {
+			return h.writeUser(r.Context(), w, http.StatusOK, user, false)
+		}
+		return h.deactivateUser(w, r, user)
+	}
+	username, err := normalizeUsername(res.UserName)
+	if err != nil {
+		return err
+	}
+	if err := h.saveUser(r.Context(), user, !active, username, res); err != nil {
+		return err
+	}
+	user, err = h.db.Users().GetByID(r.Context(), user.ID)
+	if err != nil {
+		return err
+	}
+	return h.writeUser(r.Context(), w, http.StatusOK, user, true)
+}
+
+func (h *Handler) saveUser(ctx context.Context, user *types.User, reactivate bool, username string, res *userResource) (err error) {
+	tx, err := h.db.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if reactivate {
+		if err := tx.Users().RecoverUsersList(ctx, []int32{user.ID}); err != nil {
+			return err
+		}
+	}
+
+	var update database.UserUpdate
+	if username != user.Username {
+		update.Username = username
+	}
+	if displayName := res.displayName(); displayName != user.DisplayName {
+		update.DisplayName = &displayName
+	}
+	if update.Username != "" || update.DisplayName != nil {
+		if err := tx.Users().Update(ctx, user.ID, update); err != nil {
+			return err
+		}
+	}
+	return setUserEmails(ctx, tx, user.ID, res.Emails)
+}
+
+// deactivateUser soft-deletes the user, which signs them out and prevents them
+// from signing in again. Their emails and access tokens are removed, but the
+// user can be reactivated with their username, accounts and settings.
+func (h *Handler) deactivateUser(w http.ResponseWriter, r *http.Request, user *types.User) error {
+	ctx := r.Context()
+
+	res, err := h.toUserResource(ctx, user, true)
+	if err != nil {
+		return err
+	}
+	if err := h.removeUser(ctx, user, false); err != nil {
+		return err
+	}
+
+	active := false
+	res.Active = &active
+	return writeResponse(w, http.StatusOK, res)
+}
+
+func (h *Handler) deleteUser(w http.ResponseWriter, r *http.Request, id int32) error {
+	user, _, err := h.userByID(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	if err := h.removeUser(r.Context(), user, true); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// removeUser deletes the user and revokes the permissions that were granted to
+// them through their accounts, so that a user provisioned later with the same
+// username or email does not inherit them.
+func (h *Handler) removeUser(ctx context.Context, user *types.User, hard bool) error {
+	if actor.FromContext(ctx).UID == user.ID {
+		return badRequest("mutability", "the user making the request cannot be deactivated or deleted")
+	}
+
+	extAccounts, err := h.db.UserExternalAccounts().List(ctx, database.ExternalAccountsListOptions{UserID: user.ID})
+	if err != nil {
+		return errors.Wrap(err, "list external accounts")
+	}
+	var accounts []*extsvc.Accounts
+	for _, acct := range extAccounts {
+		accounts = append(accounts, &extsvc.Accounts{
+			ServiceType: acct.ServiceType,
+			ServiceID:   acct.ServiceID,
+			AccountIDs:  []string{acct.AccountID},
+		})
+	}
+	verifiedEmails, err := h.db.UserEmails().ListByUser(ctx, database.UserEmailsListOptions{
+		UserID:       user.ID,
+		OnlyVerified: true,
+	})
+	if err != nil {
+		return err
+	}
+	accountIDs := []string{user.Username}
+	for _, e := range verifiedEmails {
+		accountIDs = append(accountIDs, e.Email)
+	}
+	accounts = append(accounts, &extsvc.Accounts{
+		ServiceType: authz.SourcegraphServiceType,
+		ServiceID:   authz.SourcegraphServiceID,
+		AccountIDs:  accountIDs,
+	})
+
+	if hard {
+		err = h.db.Users().HardDelete(ctx, user.ID)
+	} else {
+		err = h.db.Users().Delete(ctx, user.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	return h.db.Authz().RevokeUserPermissionsList(ctx, []*database.RevokeUserPermissionsArgs{{
+		UserID:   user.ID,
+		Accounts: accounts,
+	}})
+}
+
+func normalizeUsername(name string) (string, error) {
+	if name == "" {
+		return "", badRequest("invalidValue", "userName is required")
+	}
+	username, err := feAuth.NormalizeUsername(name)
+	if err != nil {
+		return "", badRequest("invalidValue", "%s", err)
+	}
+	return username, nil
+}
+
+// setUserEmails makes emails the verified emails of the user, and makes the
+// primary one their primary email. It leaves the emails of the user unchanged
+// if emails is empty, because the primary email of a user cannot be removed.
+func setUserEmails(ctx context.Context, db database.DB, userID int32, emails []userEmail) error {
+	if len(emails) == 0 {
+		return nil
+	}
+	primary := (&userResource{Emails: emails}).primaryEmail()
+
+	current, err := db.UserEmails().ListByUser(ctx, database.UserEmailsListOptions{UserID: userID})
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]*database.UserEmail, len(current))
+	for _, e := range current {
+		existing[strings.ToLower(e.Email)] = e
+	}
+
+	wanted := make(map[string]struct{}, len(emails))
+	for _, e := range emails {
+		wanted[strings.ToLower(e.Value)] = struct{}{}
+		if existing, ok := existing[strings.ToLower(e.Value)]; ok {
+			if existing.VerifiedAt == nil {
+				if err := db.UserEmails().SetVerified(ctx, userID, existing.Email, true); err != nil {
+					return err
+				}
+			}
+			if strings.EqualFold(e.Value, primary) {
+				primary = existing.Email
+			}
+			continue
+		}
+		if err := db.UserEmails().Add(ctx, userID, e.Value, nil); err != nil {
+			if database.IsEmailExists(err) {
+				return &scimError{status: http.StatusConflict, scimType: "uniqueness", detail: err.Error()}
+			}
+			return err
+		}
+		if err := db.UserEmails().SetVerified(ctx, userID, e.Value, true); err != nil {
+			return err
+		}
+	}
+
+	if err := db.UserEmails().SetPrimaryEmail(ctx, userID, primary); err != nil {
+		return err
+	}
+	for _, e := range current {
+		if _, ok := wanted[strings.ToLower(e.Email)]; !ok {
+			if err := db.UserEmails().Remove(ctx, userID, e.Email); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// emailValuePath matches the paths that identity providers such as Azure AD
+// use to change the value of an email of a given type.
+var emailValuePath = lazyregexp.New(`^emails\[type eq "(\w+)"\]\.value$`)
+
+// apply applies a PATCH operation to the resource. Attributes that are not
+// stored by Sourcegraph, such as titles and addresses, are ignored.
+func (u *userResource) apply(op patchOperation) error {
+	path := strings.ToLower(op.Path)
+	if path == "" {
+		// Without a path, the value contains the attributes to change.
+		if op.Op == "remove" {
+			return badRequest("noTarget", "remove operations require a path")
+		}
+		var attrs map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &attrs); err != nil {
+			return badRequest("invalidValue", "patch value must be an object when no path is given")
+		}
+		for attr, value := range attrs {
+			if err := u.set(op.Op, strings.ToLower(attr), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if op.Op == "remove" {
+		switch path {
+		case "displayname":
+			u.DisplayName = ""
+			u.Name = nil
+		case "name", "name.formatted", "name.givenname", "name.familyname":
+			u.Name = nil
+		case "emails":
+			u.Emails = nil
+		}
+		return nil
+	}
+	if m := emailValuePath.FindStringSubmatch(path); m != nil {
+		var value string
+		if err := json.Unmarshal(op.Value, &value); err != nil {
+			return badRequest("invalidValue", "email must be a string")
+		}
+		for i := range u.Emails {
+			if strings.EqualFold(u.Emails[i].Type, m[1]) {
+				u.Emails[i].Value = value
+				return nil
+			}
+		}
+		// Emails that were not provisioned with a type are replaced by the
+		// primary email.
+		u.Emails = []userEmail{{Value: value, Type: m[1], Primary: true}}
+		return nil
+	}
+	return u.set(op.Op, path, op.Value)
+}
+
+func (u *userResource) set(op, attr string, value json.RawMessage) error {
+	unmarshal := func(v any) error {
+		if err := json.Unmarshal(value, v); err != nil {
+			return badRequest("invalidValue", "invalid value for %s: %s", attr, err)
+		}
+		return nil
+	}
+	if u.Name == nil && strings.HasPrefix(attr, "name.") {
+		u.Name = &userName{}
+	}
+
+	switch attr {
+	case "active":
+		var active bool
+		if err := json.Unmarshal(value, &active); err != nil {
+			// Azure AD sends booleans as strings.
+			var s string
+			if err := unmarshal(&s); err != nil {
+				return err
+			}
+			if active, err = strconv.ParseBool(s); err != nil {
+				return badRequest("invalidValue", "invalid value for active: %q", s)
+			}
+		}
+		u.Active = &active
+	case "username":
+		return unmarshal(&u.UserName)
+	case "displayname":
+		return unmarshal(&u.DisplayName)
+	case "name":
+		u.Name = &userName{}
+		return unmarshal(u.Name)
+	case "name.formatted":
+		u.DisplayName = ""
+		return unmarshal(&u.Name.Formatted)
+	case "name.givenname":
+		u.DisplayName = ""
+		u.Name.Formatted = ""
+		return unmarshal(&u.Name.GivenName)
+	case "name.familyname":
+		u.DisplayName = ""
+		u.Name.Formatted = ""
+		return unmarshal(&u.Name.FamilyName)
+	case "emails":
+		var emails []userEmail
+		if err := unmarshal(&emails); err != nil {
+			return err
+		}
+		if op == "add" {
+			emails = append(u.Emails, emails...)
+		}
+		u.Emails = emails
+	}
+	return nil
+}
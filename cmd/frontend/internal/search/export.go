@@ -0,0 +1,243 @@
+package search
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/export"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	streamclient "github.com/sourcegraph/sourcegraph/internal/search/streaming/client"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// negotiateExportFormat returns the export format the client prefers
+// according to the Accept header of r. It returns false if the client prefers
+// server-sent events or did not ask for an export format, in which case we
+// stream events as usual.
+func negotiateExportFormat(r *http.Request) (export.Format, bool) {
+	var (
+		best        export.Format
+		bestQuality float64
+	)
+	for _, accept := range r.Header.Values("Accept") {
+		for _, part := range strings.Split(accept, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err != nil {
+				continue
+			}
+			quality := 1.0
+			if q, ok := params["q"]; ok {
+				if quality, err = strconv.ParseFloat(q, 64); err != nil {
+					continue
+				}
+			}
+
+			var format export.Format
+			if mediaType != "text/event-stream" {
+				var ok bool
+				if format, ok = export.FormatForContentType(mediaType); !ok {
+					continue
+				}
+			}
+			// The first of equally preferred media types wins.
+			if quality > bestQuality {
+				best, bestQuality = format, quality
+			}
+		}
+	}
+	return best, best != ""
+}
+
+// serveExport runs the search like ServeHTTP, but writes the results as
+// flattened rows in the given format instead of as events. Progress, filters
+// and alerts are not part of exports.
+func (h *streamHandler) serveExport(w http.ResponseWriter, r *http.Request, format export.Format) {
+	tr, ctx := trace.New(r.Context(), "search.ServeExport", string(format))
+	defer tr.Finish()
+	start := time.Now()
+
+	args, err := parseURLQuery(r.URL.Query())
+	if err != nil {
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	tr.TagFields(
+		otlog.String("query", args.Query),
+		otlog.String("version", args.Version),
+		otlog.String("pattern_type", args.PatternType),
+		otlog.Int("search_mode", args.SearchMode),
+	)
+
+	settings, err := graphqlbackend.DecodedViewerFinalSettings(ctx, h.db)
+	if err != nil {
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	inputs, err := h.searchClient.Plan(
+		ctx,
+		args.Version,
+		strPtr(args.PatternType),
+		args.Query,
+		search.Mode(args.SearchMode),
+		search.Streaming,
+		settings,
+		envvar.SourcegraphDotComMode(),
+	)
+	if err != nil {
+		tr.SetError(err)
+		var queryErr *client.QueryError
+		if errors.As(err, &queryErr) {
+			alert := search.AlertForQuery(queryErr.Query, queryErr.Err)
+			http.Error(w, alert.Title+": "+alert.Description, http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	displayLimit := args.Display
+	limit := inputs.MaxResults()
+	if displayLimit < 0 || displayLimit > limit {
+		displayLimit = limit
+	}
+
+	progress := &streamclient.ProgressAggregator{
+		Start:        start,
+		Limit:        limit,
+		Trace:        trace.URL(trace.ID(ctx), conf.DefaultClient()),
+		DisplayLimit: displayLimit,
+		RepoNamer:    streamclient.RepoNamer(ctx, h.db),
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	writer, err := export.NewWriter(w, format)
+	if err != nil {
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	exportStream := &exportStream{
+		ctx:              ctx,
+		db:               h.db,
+		w:                w,
+		writer:           writer,
+		progress:         progress,
+		displayRemaining: displayLimit,
+	}
+
+	alert, err := func() (*search.Alert, error) {
+		batchedStream := streaming.NewBatchingStream(50*time.Millisecond, exportStream)
+		defer batchedStream.Done()
+
+		return h.searchClient.Execute(ctx, batchedStream, inputs)
+	}()
+	err = exportStream.Done(err)
+	logSearch(ctx, h.logger, alert, err, start, inputs.OriginalQuery, progress)
+	if err == nil {
+		return
+	}
+
+	tr.SetError(err)
+	if !exportStream.started {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// We already sent the status code, so all we can do is stop writing.
+	h.logger.Warn("search export stopped early", log.String("query", args.Query), log.Error(err))
+}
+
+// exportStream writes the results of a search to an export.Writer, flushing
+// them to the client after every event.
+type exportStream struct {
+	ctx context.Context
+	db  database.DB
+	w   http.ResponseWriter
+
+	mu               sync.Mutex
+	writer           *export.Writer
+	progress         *streamclient.ProgressAggregator
+	displayRemaining int
+	// started is true once we wrote to w, after which we can no longer
+	// respond with an error.
+	started bool
+	err     error
+}
+
+func (s *exportStream) Send(event streaming.SearchEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.progress.Update(event)
+	if s.err != nil {
+		return
+	}
+
+	s.displayRemaining = event.Results.Limit(s.displayRemaining)
+	if len(event.Results) == 0 {
+		return
+	}
+
+	repoMetadata, err := getEventRepoMetadata(s.ctx, s.db, event)
+	if err != nil {
+		s.err = err
+		return
+	}
+
+	for _, match := range event.Results {
+		// Don't export matches which we cannot map to a repo the actor has
+		// access to, just like the event stream.
+		repo := match.RepoName()
+		if md, ok := repoMetadata[repo.ID]; !ok || md.Name != repo.Name {
+			continue
+		}
+		s.started = true
+		if _, err := s.writer.Write(match); err != nil {
+			s.err = err
+			return
+		}
+	}
+	s.err = s.flush()
+}
+
+// Done flushes any buffered rows, unless the search failed with searchErr or
+// writing failed. In that case it returns the errors, and nothing more is
+// sent, so that the caller can still respond with an error if nothing has
+// been sent yet.
+func (s *exportStream) Done(searchErr error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := errors.Append(searchErr, s.err); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+func (s *exportStream) flush() error {
+	s.started = true
+	if err := s.writer.Flush(); err != nil {
+		return err
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package search
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/export"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestNegotiateExportFormat(t *testing.T) {
+	cases := []struct {
+		accept string
+		want   export.Format
+	}{
+		{accept: "", want: ""},
+		{accept: "*/*", want: ""},
+		{accept: "text/event-stream", want: ""},
+		{accept: "text/csv", want: export.FormatCSV},
+		{accept: "application/x-ndjson", want: export.FormatJSONL},
+		{accept: "text/csv; charset=utf-8", want: export.FormatCSV},
+		{accept: "text/event-stream, text/csv", want: ""},
+		{accept: "text/event-stream;q=0.5, text/csv", want: export.FormatCSV},
+		{accept: "application/x-ndjson;q=0.9, text/csv;q=0.8", want: export.FormatJSONL},
+		{accept: "text/csv;q=0", want: ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.accept, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/search/stream?q=test", nil)
+			if tc.accept != "" {
+				r.Header.Set("Accept", tc.accept)
+			}
+			got, ok := negotiateExportFormat(r)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.want != "", ok)
+		})
+	}
+}
+
+func TestServeStream_export(t *testing.T) {
+	graphqlbackend.MockDecodedViewerFinalSettings = &schema.Settings{}
+	t.Cleanup(func() { graphqlbackend.MockDecodedViewerFinalSettings = nil })
+
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+	hidden := types.MinimalRepo{ID: 2, Name: "github.com/sourcegraph/hidden"}
+
+	mockRepos := database.NewMockRepoStore()
+	mockRepos.MetadataFunc.SetDefaultHook(func(_ context.Context, ids ...api.RepoID) ([]*types.SearchedRepo, error) {
+		out := make([]*types.SearchedRepo, 0, len(ids))
+		for _, id := range ids {
+			// The actor cannot see the hidden repo.
+			if id == repo.ID {
+				out = append(out, &types.SearchedRepo{ID: id, Name: repo.Name})
+			}
+		}
+		return out, nil
+	})
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(mockRepos)
+
+	newServer := func(execute func(context.Context, streaming.Sender, *search.Inputs) (*search.Alert, error)) *httptest.Server {
+		mock := client.NewMockSearchClient()
+		mock.PlanFunc.SetDefaultReturn(&search.Inputs{Query: query.Q{query.Parameter{Field: "count", Value: "1000"}}}, nil)
+		mock.ExecuteFunc.SetDefaultHook(execute)
+
+		ts := httptest.NewServer(&streamHandler{
+			logger:              logtest.Scoped(t),
+			db:                  db,
+			flushTickerInternal: 1 * time.Millisecond,
+			pingTickerInterval:  1 * time.Millisecond,
+			searchClient:        mock,
+		})
+		t.Cleanup(ts.Close)
+		return ts
+	}
+
+	get := func(t *testing.T, ts *httptest.Server, accept string) (*http.Response, string) {
+		req, err := http.NewRequest("GET", ts.URL+"?q=test", nil)
+		require.NoError(t, err)
+		req.Header.Set("Accept", accept)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		b, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return res, string(b)
+	}
+
+	ts := newServer(func(_ context.Context, s streaming.Sender, _ *search.Inputs) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{
+			Results: result.Matches{
+				&result.FileMatch{
+					File: result.File{Repo: repo, CommitID: "deadbeef", Path: "main.go"},
+					ChunkMatches: result.ChunkMatches{{
+						Content:      "func main() {",
+						ContentStart: result.Location{Line: 2},
+						Ranges:       result.Ranges{{Start: result.Location{Offset: 5, Line: 2, Column: 5}, End: result.Location{Offset: 9, Line: 2, Column: 9}}},
+					}},
+				},
+				&result.FileMatch{File: result.File{Repo: hidden, CommitID: "cafebabe", Path: "secret.go"}},
+			},
+		})
+		return nil, nil
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		res, body := get(t, ts, "text/csv")
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "text/csv", res.Header.Get("Content-Type"))
+		assert.Equal(t, `type,repository,revision,commit,path,line,preview,author,date,symbol,symbol_kind,owner
+content,github.com/sourcegraph/sourcegraph,,deadbeef,main.go,3,func main() {,,,,,
+`, body)
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		res, body := get(t, ts, "application/x-ndjson")
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/x-ndjson", res.Header.Get("Content-Type"))
+		assert.Equal(t, `{"type":"content","repository":"github.com/sourcegraph/sourcegraph","revision":"","commit":"deadbeef","path":"main.go","line":3,"preview":"func main() {","author":"","date":"","symbol":"","symbol_kind":"","owner":""}
+`, body)
+	})
+
+	t.Run("search error", func(t *testing.T) {
+		ts := newServer(func(context.Context, streaming.Sender, *search.Inputs) (*search.Alert, error) {
+			return nil, errors.New("boom")
+		})
+		res, body := get(t, ts, "text/csv")
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+		assert.Contains(t, body, "boom")
+	})
+}
@@ -0,0 +1,126 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search/exhaustive"
+	"github.com/sourcegraph/sourcegraph/internal/search/export"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+)
+
+var jobsUploadStoreConfig = &exhaustive.UploadStoreConfig{}
+
+func init() {
+	jobsUploadStoreConfig.Load()
+}
+
+var (
+	jobsUploadStoreOnce sync.Once
+	jobsUploadStore     uploadstore.Store
+	jobsUploadStoreErr  error
+)
+
+// getJobsUploadStore returns the store holding the results of search jobs. It
+// is created on first use, so that instances which never serve results do not
+// need a valid configuration.
+func getJobsUploadStore() (uploadstore.Store, error) {
+	jobsUploadStoreOnce.Do(func() {
+		if jobsUploadStoreErr = jobsUploadStoreConfig.Validate(); jobsUploadStoreErr != nil {
+			return
+		}
+		observationCtx := observation.NewContext(log.Scoped("searchJobsUploadStore", "the store holding the results of search jobs"))
+		jobsUploadStore, jobsUploadStoreErr = exhaustive.NewUploadStore(context.Background(), observationCtx, jobsUploadStoreConfig)
+	})
+	return jobsUploadStore, jobsUploadStoreErr
+}
+
+// JobResultsHandler is an http handler which serves the results of a
+// completed search job.
+func JobResultsHandler(db database.DB) http.Handler {
+	return &jobResultsHandler{
+		logger:         log.Scoped("searchJobResultsHandler", ""),
+		db:             db,
+		getUploadStore: getJobsUploadStore,
+	}
+}
+
+type jobResultsHandler struct {
+	logger         log.Logger
+	db             database.DB
+	getUploadStore func() (uploadstore.Store, error)
+}
+
+func (h *jobResultsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "invalid search job id", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.db.ExhaustiveSearchJobs().GetByID(ctx, id)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			http.Error(w, "search job not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// 🚨 SECURITY: Only the creator of the job and site admins may download
+	// its results. We don't reveal the existence of jobs to other users.
+	if err := auth.CheckSiteAdminOrSameUser(ctx, h.db, job.InitiatorID); err != nil {
+		http.Error(w, "search job not found", http.StatusNotFound)
+		return
+	}
+
+	if job.ResultKey == nil {
+		http.Error(w, fmt.Sprintf("search job is %s and has no results", job.State), http.StatusConflict)
+		return
+	}
+
+	if err := h.serveResults(ctx, w, job); err != nil {
+		h.logger.Error("failed to serve search job results", log.Int64("id", job.ID), log.Error(err))
+		http.Error(w, "failed to read search job results", http.StatusInternalServerError)
+	}
+}
+
+func (h *jobResultsHandler) serveResults(ctx context.Context, w http.ResponseWriter, job *types.ExhaustiveSearchJob) error {
+	store, err := h.getUploadStore()
+	if err != nil {
+		return err
+	}
+	rc, err := store.Get(ctx, *job.ResultKey)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	w.Header().Set("Content-Type", export.Format(job.Format).ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", exhaustive.ResultFilename(job)))
+	if job.ResultSize != nil {
+		w.Header().Set("Content-Length", strconv.FormatInt(*job.ResultSize, 10))
+	}
+
+	// Once we started writing the body we can no longer report errors with a
+	// status code, so we only log them.
+	if _, err := io.Copy(w, rc); err != nil {
+		h.logger.Warn("failed to write search job results", log.Int64("id", job.ID), log.Error(err))
+	}
+	return nil
+}
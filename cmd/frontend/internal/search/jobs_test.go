@@ -0,0 +1,86 @@
+package search
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	uploadstoremocks "github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+)
+
+func TestJobResultsHandler(t *testing.T) {
+	key := "search-jobs/1.csv"
+	size := int64(len("a,b\n"))
+	completed := &types.ExhaustiveSearchJob{ID: 1, InitiatorID: 1, Format: "csv", State: "completed", ResultKey: &key, ResultSize: &size}
+	queued := &types.ExhaustiveSearchJob{ID: 2, InitiatorID: 1, Format: "csv", State: "queued"}
+
+	jobs := database.NewMockExhaustiveSearchJobStore()
+	jobs.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int64) (*types.ExhaustiveSearchJob, error) {
+		switch id {
+		case completed.ID:
+			return completed, nil
+		case queued.ID:
+			return queued, nil
+		}
+		return nil, database.ExhaustiveSearchJobNotFoundErr{}
+	})
+
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 2}, nil)
+
+	db := database.NewMockDB()
+	db.ExhaustiveSearchJobsFunc.SetDefaultReturn(jobs)
+	db.UsersFunc.SetDefaultReturn(users)
+
+	store := uploadstoremocks.NewMockStore()
+	store.GetFunc.SetDefaultReturn(io.NopCloser(strings.NewReader("a,b\n")), nil)
+
+	h := &jobResultsHandler{
+		logger:         logtest.Scoped(t),
+		db:             db,
+		getUploadStore: func() (uploadstore.Store, error) { return store, nil },
+	}
+
+	serve := func(uid int32, id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/search/jobs/"+id+"/results", nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		req = req.WithContext(actor.WithActor(req.Context(), actor.FromUser(uid)))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("creator", func(t *testing.T) {
+		rec := serve(1, "1")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "a,b\n", rec.Body.String())
+		assert.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="search-job-1.csv"`, rec.Header().Get("Content-Disposition"))
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		rec := serve(2, "1")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("not completed", func(t *testing.T) {
+		rec := serve(1, "2")
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		rec := serve(1, "3")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -59,6 +59,11 @@ type streamHandler struct {
 }
 
 func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if format, ok := negotiateExportFormat(r); ok {
+		h.serveExport(w, r, format)
+		return
+	}
+
 	tr, ctx := trace.New(r.Context(), "search.ServeStream", "")
 	defer tr.Finish()
 	r = r.WithContext(ctx)
@@ -293,11 +298,22 @@ func fromMatch(match result.Match, repoCache map[api.RepoID]*types.SearchedRepo,
 		return fromRepository(v, repoCache)
 	case *result.CommitMatch:
 		return fromCommit(v, repoCache)
+	case *result.OwnerMatch:
+		return fromOwner(v)
 	default:
 		panic(fmt.Sprintf("unknown match type %T", v))
 	}
 }
 
+func fromOwner(om *result.OwnerMatch) *streamhttp.EventOwnerMatch {
+	return &streamhttp.EventOwnerMatch{
+		Type:       streamhttp.OwnerMatchType,
+		Handle:     om.Handle,
+		Email:      om.Email,
+		Repository: string(om.Repo.Name),
+	}
+}
+
 func fromFileMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.SearchedRepo, enableChunkMatches bool) streamhttp.EventMatch {
 	if len(fm.Symbols) > 0 {
 		return fromSymbolMatch(fm, repoCache)
@@ -59,6 +59,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -313,6 +314,9 @@ type Server struct {
 	// shared db handle
 	DB database.DB
 
+	// OutboundWebhooks, if set, is notified whenever a repository is cloned.
+	OutboundWebhooks outbound.OutboundWebhookService
+
 	// CloneQueue is a threadsafe queue used by DoBackgroundClones to process incoming clone
 	// requests asynchronously.
 	CloneQueue *cloneQueue
@@ -2268,9 +2272,33 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir GitDir, syn
 	logger.Info("repo cloned")
 	repoClonedCounter.Inc()
 
+	s.enqueueRepoClonedWebhook(ctx, logger, repo)
+
 	return nil
 }
 
+// enqueueRepoClonedWebhook notifies any outbound webhooks subscribed to repo
+// clone events. Errors are logged rather than returned, since the clone itself
+// succeeded.
+func (s *Server) enqueueRepoClonedWebhook(ctx context.Context, logger log.Logger, repo api.RepoName) {
+	if s.OutboundWebhooks == nil {
+		return
+	}
+
+	r, err := s.DB.Repos().GetByName(ctx, repo)
+	if err != nil {
+		logger.Warn("failed to get cloned repo for outbound webhook", log.Error(err))
+		return
+	}
+
+	name := string(r.Name)
+	if err := s.OutboundWebhooks.Enqueue(ctx, outbound.EventTypeRepoCloned, &name, outbound.RepoClonedData{
+		Repository: outbound.Repository{ID: int32(r.ID), Name: name},
+	}); err != nil {
+		logger.Warn("failed to enqueue outbound webhook", log.Error(err))
+	}
+}
+
 // readCloneProgress scans the reader and saves the most recent line of output
 // as the lock status.
 func readCloneProgress(logger log.Logger, redactor *urlRedactor, lock *RepositoryLock, pr io.Reader, repo api.RepoName) {
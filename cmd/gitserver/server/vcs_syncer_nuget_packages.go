@@ -0,0 +1,121 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/fs"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/nuget"
+	"github.com/sourcegraph/sourcegraph/internal/unpack"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func NewNuGetPackagesSyncer(
+	connection *schema.NuGetPackagesConnection,
+	svc *dependencies.Service,
+	client *nuget.Client,
+) VCSSyncer {
+	return &vcsPackagesSyncer{
+		logger:      log.Scoped("NuGetPackagesSyncer", "sync NuGet packages"),
+		typ:         "nuget_packages",
+		scheme:      dependencies.NuGetPackagesScheme,
+		placeholder: reposource.NewNuGetVersionedPackage("Sourcegraph.Placeholder", "0.0.0"),
+		svc:         svc,
+		configDeps:  connection.Dependencies,
+		source:      &nugetDependencySource{client: client},
+	}
+}
+
+// nugetDependencySource implements packagesSource
+type nugetDependencySource struct {
+	client *nuget.Client
+}
+
+func (nugetDependencySource) ParseVersionedPackageFromNameAndVersion(name reposource.PackageName, version string) (reposource.VersionedPackage, error) {
+	return reposource.ParseNuGetVersionedPackage(string(name) + "@" + version)
+}
+
+func (nugetDependencySource) ParseVersionedPackageFromConfiguration(dep string) (reposource.VersionedPackage, error) {
+	return reposource.ParseNuGetVersionedPackage(dep)
+}
+
+func (nugetDependencySource) ParsePackageFromName(name reposource.PackageName) (reposource.Package, error) {
+	return reposource.ParseNuGetPackageFromName(name)
+}
+
+func (nugetDependencySource) ParsePackageFromRepoName(repoName api.RepoName) (reposource.Package, error) {
+	return reposource.ParseNuGetPackageFromRepoName(repoName)
+}
+
+func (s *nugetDependencySource) Download(ctx context.Context, dir string, dep reposource.VersionedPackage) error {
+	pkgContents, packageURL, err := s.client.GetPackageContents(ctx, dep)
+	if err != nil {
+		return errors.Wrapf(err, "error downloading NuGet package with URL '%s'", packageURL)
+	}
+	defer pkgContents.Close()
+
+	if err = unpackNuGetPackage(pkgContents, dir); err != nil {
+		return errors.Wrapf(err, "failed to unzip NuGet package from URL %s", packageURL)
+	}
+
+	return nil
+}
+
+// unpackNuGetPackage unpacks the given .nupkg archive into workDir, skipping
+// the files of the Open Packaging Conventions container, files that aren't
+// valid and files that are potentially malicious. The .nuspec manifest and
+// the contents of the package are kept.
+func unpackNuGetPackage(pkg io.Reader, workDir string) error {
+	logger := log.Scoped("unpackNuGetPackage", "unpackNuGetPackage unpacks the given NuGet package archive into workDir")
+
+	// A .nupkg is a zip archive, which can't be read as a stream.
+	pkgBytes, err := io.ReadAll(pkg)
+	if err != nil {
+		return err
+	}
+
+	opts := unpack.Opts{
+		SkipInvalid:    true,
+		SkipDuplicates: true,
+		Filter: func(path string, file fs.FileInfo) bool {
+			if isNuGetPackagingFile(path) {
+				return false
+			}
+
+			size := file.Size()
+
+			const sizeLimit = 15 * 1024 * 1024
+			if size >= sizeLimit {
+				logger.With(
+					log.String("path", file.Name()),
+					log.Int64("size", size),
+					log.Float64("limit", sizeLimit),
+				).Warn("skipping large file in NuGet package")
+				return false
+			}
+
+			malicious := isPotentiallyMaliciousFilepathInArchive(path, workDir)
+			return !malicious
+		},
+	}
+
+	return unpack.Zip(bytes.NewReader(pkgBytes), int64(len(pkgBytes)), workDir, opts)
+}
+
+// isNuGetPackagingFile returns true for the files that every .nupkg contains
+// to conform to the Open Packaging Conventions and to sign the package, which
+// aren't part of the package itself.
+func isNuGetPackagingFile(path string) bool {
+	return path == "[Content_Types].xml" ||
+		path == ".signature.p7s" ||
+		strings.HasPrefix(path, "_rels/") ||
+		strings.HasPrefix(path, "package/services/")
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUnpackNuGetPackage(t *testing.T) {
+	var zipBuf bytes.Buffer
+	zw := zip.NewWriter(&zipBuf)
+	for _, f := range []fileInfo{
+		{path: "Contoso.Client.nuspec", contents: []byte("<package />")},
+		{path: "src/Client.cs", contents: []byte("class Client {}")},
+		{path: "lib/net6.0/Contoso.Client.xml", contents: []byte("<doc />")},
+		{path: "[Content_Types].xml", contents: []byte("filter me")},
+		{path: "_rels/.rels", contents: []byte("filter me")},
+		{path: "package/services/metadata/core-properties/abc.psmdcp", contents: []byte("filter me")},
+		{path: ".signature.p7s", contents: []byte("filter me")},
+		{path: "../escape.cs", contents: []byte("filter me")},
+	} {
+		fw, err := zw.Create(f.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write(f.contents); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	tmp := t.TempDir()
+	if err := unpackNuGetPackage(&zipBuf, tmp); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	if err := filepath.Walk(tmp, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			got = append(got, strings.TrimPrefix(path, tmp))
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(got)
+
+	want := []string{
+		"/Contoso.Client.nuspec",
+		"/lib/net6.0/Contoso.Client.xml",
+		"/src/Client.cs",
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Fatalf("-want,+got\n%s", d)
+	}
+}
+
+func TestUnpackNuGetPackage_InvalidZip(t *testing.T) {
+	pkg := bytes.NewReader(createTgz(t, []fileInfo{{path: "file.cs", contents: []byte("banana")}}))
+	if err := unpackNuGetPackage(pkg, t.TempDir()); err == nil {
+		t.Fatal("expected error")
+	}
+}
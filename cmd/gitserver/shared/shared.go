@@ -38,6 +38,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gomodproxy"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/npm"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/nuget"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/pypi"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/rubygems"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
@@ -55,6 +56,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/tracer"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/version"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
@@ -145,6 +147,7 @@ func Main() {
 		},
 		Hostname:                hostname.Get(),
 		DB:                      db,
+		OutboundWebhooks:        outbound.NewOutboundWebhookService(db, keyring.Default().OutboundWebhookKey),
 		CloneQueue:              server.NewCloneQueue(list.New()),
 		GlobalBatchLogSemaphore: semaphore.NewWeighted(int64(batchLogGlobalConcurrencyLimit)),
 	}
@@ -523,6 +526,18 @@ func getVCSSyncer(
 		}
 		cli := rubygems.NewClient(urn, c.Repository, httpcli.ExternalDoer)
 		return server.NewRubyPackagesSyncer(&c, depsSvc, cli), nil
+	case extsvc.TypeNuGetPackages:
+		var c schema.NuGetPackagesConnection
+		urn, err := extractOptions(&c)
+		if err != nil {
+			return nil, err
+		}
+		var username, password string
+		if c.Credentials != nil {
+			username, password = c.Credentials.Username, c.Credentials.Password
+		}
+		cli := nuget.NewClient(urn, c.Repository, username, password, httpcli.ExternalDoer)
+		return server.NewNuGetPackagesSyncer(&c, depsSvc, cli), nil
 	}
 	return &server.GitRepoSyncer{}, nil
 }
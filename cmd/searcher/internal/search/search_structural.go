@@ -1,17 +1,13 @@
 package search
 
 import (
-	"archive/tar"
 	"archive/zip"
-	"bufio"
 	"bytes"
 	"context"
-	"fmt"
 	"io"
 	"path/filepath"
 	"sort"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/RoaringBitmap/roaring"
@@ -22,7 +18,6 @@ import (
 	"github.com/sourcegraph/zoekt"
 	zoektquery "github.com/sourcegraph/zoekt/query"
 
-	"github.com/sourcegraph/log"
 	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/comby"
@@ -32,30 +27,13 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
-func toFileMatch(zipReader *zip.Reader, combyMatch *comby.FileMatch) (protocol.FileMatch, error) {
-	file, err := zipReader.Open(combyMatch.URI)
-	if err != nil {
-		return protocol.FileMatch{}, err
-	}
-	defer file.Close()
-
-	fileBuf, err := io.ReadAll(file)
-	if err != nil {
-		return protocol.FileMatch{}, err
-	}
-
-	// Convert comby matches to ranges
-	ranges := make([]protocol.Range, 0, len(combyMatch.Matches))
-	for _, r := range combyMatch.Matches {
-		// trust, but verify
-		if r.Range.Start.Offset > len(fileBuf) || r.Range.End.Offset > len(fileBuf) {
-			return protocol.FileMatch{}, errors.New("comby match range does not fit in file")
-		}
-
+func toFileMatch(path string, buf []byte, matches []comby.Match) protocol.FileMatch {
+	ranges := make([]protocol.Range, 0, len(matches))
+	for _, r := range matches {
 		ranges = append(ranges, protocol.Range{
 			Start: protocol.Location{
 				Offset: int32(r.Range.Start.Offset),
-				// Comby returns 1-based line numbers and columns
+				// Comby locations have 1-based line numbers and columns
 				Line:   int32(r.Range.Start.Line) - 1,
 				Column: int32(r.Range.Start.Column) - 1,
 			},
@@ -68,46 +46,9 @@ func toFileMatch(zipReader *zip.Reader, combyMatch *comby.FileMatch) (protocol.F
 	}
 
 	chunks := chunkRanges(ranges, 0)
-	chunkMatches := chunksToMatches(fileBuf, chunks)
+	chunkMatches := chunksToMatches(buf, chunks)
 	return protocol.FileMatch{
-		Path:         combyMatch.URI,
-		ChunkMatches: chunkMatches,
-		LimitHit:     false,
-	}, nil
-}
-
-func combyChunkMatchesToFileMatch(combyMatch *comby.FileMatchWithChunks) protocol.FileMatch {
-	chunkMatches := make([]protocol.ChunkMatch, 0, len(combyMatch.ChunkMatches))
-	for _, cm := range combyMatch.ChunkMatches {
-		ranges := make([]protocol.Range, 0, len(cm.Ranges))
-		for _, r := range cm.Ranges {
-			ranges = append(ranges, protocol.Range{
-				Start: protocol.Location{
-					Offset: int32(r.Start.Offset),
-					// comby returns 1-based line numbers and columns
-					Line:   int32(r.Start.Line) - 1,
-					Column: int32(r.Start.Column) - 1,
-				},
-				End: protocol.Location{
-					Offset: int32(r.End.Offset),
-					Line:   int32(r.End.Line) - 1,
-					Column: int32(r.End.Column) - 1,
-				},
-			})
-		}
-
-		chunkMatches = append(chunkMatches, protocol.ChunkMatch{
-			Content: cm.Content,
-			ContentStart: protocol.Location{
-				Offset: int32(cm.Start.Offset),
-				Line:   int32(cm.Start.Line) - 1,
-				Column: int32(cm.Start.Column) - 1,
-			},
-			Ranges: ranges,
-		})
-	}
-	return protocol.FileMatch{
-		Path:         combyMatch.URI,
+		Path:         path,
 		ChunkMatches: chunkMatches,
 		LimitHit:     false,
 	}
@@ -324,7 +265,8 @@ func structuralSearchWithZoekt(ctx context.Context, indexed zoekt.Streamer, p *p
 	return nil
 }
 
-// filteredStructuralSearch filters the list of files with a regex search before passing the zip to comby
+// filteredStructuralSearch filters the list of files with a regex search before
+// matching structurally in the remaining files
 func filteredStructuralSearch(ctx context.Context, zipPath string, zf *zipFile, p *protocol.PatternInfo, repo api.RepoName, sender matchSender) error {
 	// Make a copy of the pattern info to modify it to work for a regex search
 	rp := *p
@@ -402,9 +344,6 @@ func structuralSearch(ctx context.Context, inputType comby.Input, paths filePatt
 		span.Finish()
 	}()
 
-	// Cap the number of forked processes to limit the size of zip contents being mapped to memory. Resolving #7133 could help to lift this restriction.
-	numWorkers := 4
-
 	matcher := toMatcher(languages, extensionHint)
 
 	var filePatterns []string
@@ -413,135 +352,101 @@ func structuralSearch(ctx context.Context, inputType comby.Input, paths filePatt
 	}
 	span.LogFields(otlog.Int("paths", len(filePatterns)))
 
-	args := comby.Args{
-		Input:         inputType,
-		Matcher:       matcher,
-		MatchTemplate: pattern,
-		ResultKind:    comby.MatchOnly,
-		FilePatterns:  filePatterns,
-		Rule:          rule,
-		NumWorkers:    numWorkers,
-	}
+	p, compileErr := comby.Compile(pattern, rule, matcher)
 
-	switch combyInput := inputType.(type) {
+	switch input := inputType.(type) {
 	case comby.Tar:
-		return runCombyAgainstTar(ctx, args, combyInput, sender)
+		if compileErr != nil {
+			// Drain the input so that the producer does not block.
+			for range input.TarInputEventC {
+			}
+			return compileErr
+		}
+		return structuralSearchTar(ctx, p, filePatterns, input, sender)
 	case comby.ZipPath:
-		return runCombyAgainstZip(ctx, args, combyInput, sender)
+		if compileErr != nil {
+			return compileErr
+		}
+		return structuralSearchZip(ctx, p, filePatterns, input, sender)
 	}
 
-	return errors.New("comby input must be either -tar or -zip for structural search")
+	return errors.New("structural search input must be either a tar stream or a zip file")
+}
+
+// structuralSearchTar matches the files streamed on tarInput as they arrive.
+// It consumes all of tarInput, even once ctx is canceled.
+func structuralSearchTar(ctx context.Context, p *comby.Pattern, filePatterns []string, tarInput comby.Tar, sender matchSender) error {
+	for tb := range tarInput.TarInputEventC {
+		if ctx.Err() != nil || !matchesFilePatterns(tb.Header.Name, filePatterns) {
+			continue
+		}
+		sendStructuralMatches(p, tb.Header.Name, tb.Content, sender)
+	}
+	return nil
 }
 
-// runCombyAgainstTar runs comby with the flags `-tar` and `-chunk-matches 0`. `-chunk-matches 0` instructs comby to return
-// chunks as part of matches that it finds. Data is streamed into stdin from the channel on tarInput and out from stdout
-// to the result stream.
-func runCombyAgainstTar(ctx context.Context, args comby.Args, tarInput comby.Tar, sender matchSender) (err error) {
-	cmd, stdin, stdout, err := comby.SetupCmdWithPipes(ctx, args)
+// structuralSearchZip matches the files in the zip archive at zipPath.
+func structuralSearchZip(ctx context.Context, p *comby.Pattern, filePatterns []string, zipPath comby.ZipPath, sender matchSender) error {
+	zipReader, err := zip.OpenReader(string(zipPath))
 	if err != nil {
 		return err
 	}
+	defer zipReader.Close()
 
-	wg := sync.WaitGroup{}
-	defer wg.Wait()
-
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		defer stdin.Close()
-
-		tw := tar.NewWriter(stdin)
-		defer tw.Close()
-		for tb := range tarInput.TarInputEventC {
-			if err := tw.WriteHeader(&tb.Header); err != nil {
-				log.NamedError(fmt.Sprintf("failed to write tar header for file %s", tb.Header.Name), err)
-				continue
-			}
-			if _, err := tw.Write(tb.Content); err != nil {
-				log.NamedError(fmt.Sprintf("failed to write file content to tar format for file %s", tb.Header.Name), err)
-				continue
-			}
+	for _, f := range zipReader.File {
+		if ctx.Err() != nil {
+			// The search was canceled or hit its limit.
+			return nil
 		}
-	}()
-
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		defer stdout.Close()
-
-		scanner := bufio.NewScanner(stdout)
-		// increase the scanner buffer size for potentially long lines
-		scanner.Buffer(make([]byte, 100), 10*bufio.MaxScanTokenSize)
-
-		for scanner.Scan() {
-			b := scanner.Bytes()
-			if err := scanner.Err(); err != nil {
-				// warn on scanner errors and skip
-				log.NamedError("comby error: skipping scanner error line", err)
-				break
-			}
-			if r := comby.ToCombyFileMatchWithChunks(b); r != nil {
-				sender.Send(combyChunkMatchesToFileMatch(r.(*comby.FileMatchWithChunks)))
-			}
+		if f.FileInfo().IsDir() || !matchesFilePatterns(f.Name, filePatterns) {
+			continue
 		}
-	}()
 
-	err = comby.StartAndWaitForCompletion(cmd)
-	if ctx.Err() != nil {
-		// context has been canceled, ignore any "process killed" errors from the subprocess
-		return nil
+		buf, err := readZipEntry(f)
+		if err != nil {
+			return err
+		}
+		sendStructuralMatches(p, f.Name, buf, sender)
 	}
-	return err
+	return nil
 }
 
-// runCombyAgainstZip runs comby with the flag `-zip`. It reads matches from comby's stdout as they are returned and
-// attempts to convert each to a protocol.FileMatch, sending it to the result stream if successful.
-func runCombyAgainstZip(ctx context.Context, args comby.Args, zipPath comby.ZipPath, sender matchSender) (err error) {
-	cmd, stdin, stdout, err := comby.SetupCmdWithPipes(ctx, args)
+// sendStructuralMatches sends the matches of p in the file at path. If the
+// file is too complex to match completely, the matches found in it are marked
+// as incomplete, and so is the search, since the file may have had more.
+func sendStructuralMatches(p *comby.Pattern, path string, buf []byte, sender matchSender) {
+	matches, err := p.Matches(buf)
 	if err != nil {
-		return err
+		sender.SetLimitHit()
 	}
-	stdin.Close() // don't need to write to stdin when using `-zip`
+	if len(matches) > 0 {
+		fm := toFileMatch(path, buf, matches)
+		fm.LimitHit = err != nil
+		sender.Send(fm)
+	}
+}
 
-	zipReader, err := zip.OpenReader(string(zipPath))
+func readZipEntry(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer zipReader.Close()
-
-	wg := sync.WaitGroup{}
-	defer wg.Wait()
-
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		defer stdout.Close()
-
-		scanner := bufio.NewScanner(stdout)
-		// increase the scanner buffer size for potentially long lines
-		scanner.Buffer(make([]byte, 100), 10*bufio.MaxScanTokenSize)
-
-		for scanner.Scan() {
-			b := scanner.Bytes()
-			if err := scanner.Err(); err != nil {
-				// warn on scanner errors and skip
-				log.NamedError("comby error: skipping scanner error line", err)
-				break
-			}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
 
-			cfm := comby.ToFileMatch(b)
-			if cfm != nil {
-				fm, err := toFileMatch(&zipReader.Reader, cfm.(*comby.FileMatch))
-				if err != nil {
-					log.NamedError("error converting comby match to FileMatch, skipping", err)
-					continue
-				}
-				sender.Send(fm)
-			}
+// matchesFilePatterns returns true if path ends with one of the patterns, or
+// there are no patterns.
+func matchesFilePatterns(path string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if strings.HasSuffix(path, pattern) {
+			return true
 		}
-	}()
-
-	return comby.StartAndWaitForCompletion(cmd)
+	}
+	return false
 }
 
 var metricRequestTotalStructuralSearch = promauto.NewCounterVec(prometheus.CounterOpts{
@@ -3,11 +3,8 @@ package search
 import (
 	"archive/tar"
 	"context"
-	"os"
-	"os/exec"
 	"path/filepath"
 	"reflect"
-	"runtime"
 	"sort"
 	"strings"
 	"testing"
@@ -21,8 +18,6 @@ import (
 )
 
 func TestMatcherLookupByLanguage(t *testing.T) {
-	maybeSkipComby(t)
-
 	input := map[string]string{
 		"file_without_extension": `
 /* This foo(plain string) {} is in a Go comment should not match in Go, but should match in plaintext */
@@ -92,8 +87,6 @@ func foo(go string) {}
 }
 
 func TestMatcherLookupByExtension(t *testing.T) {
-	maybeSkipComby(t)
-
 	t.Parallel()
 
 	input := map[string]string{
@@ -184,8 +177,6 @@ func foo(go.txt) {}
 // Tests that structural search correctly infers the Go matcher from the .go
 // file extension.
 func TestInferredMatcher(t *testing.T) {
-	maybeSkipComby(t)
-
 	input := map[string]string{
 		"main.go": `
 /* This foo(ignore string) {} is in a Go comment should not match */
@@ -281,8 +272,6 @@ func TestRecordMetrics(t *testing.T) {
 // instead (currently) expects a list of patterns that represent a set of file
 // paths to search.
 func TestIncludePatterns(t *testing.T) {
-	maybeSkipComby(t)
-
 	input := map[string]string{
 		"a/b/c":         "",
 		"a/b/c/foo.go":  "",
@@ -330,8 +319,6 @@ func TestIncludePatterns(t *testing.T) {
 }
 
 func TestRule(t *testing.T) {
-	maybeSkipComby(t)
-
 	input := map[string]string{
 		"file.go": "func foo(success) {} func bar(fail) {}",
 	}
@@ -375,8 +362,6 @@ func TestRule(t *testing.T) {
 }
 
 func TestStructuralLimits(t *testing.T) {
-	maybeSkipComby(t)
-
 	input := map[string]string{
 		"test1.go": `
 func foo() {
@@ -429,8 +414,6 @@ func bar() {
 }
 
 func TestMatchCountForMultilineMatches(t *testing.T) {
-	maybeSkipComby(t)
-
 	input := map[string]string{
 		"main.go": `
 func foo() {
@@ -472,8 +455,6 @@ func bar() {
 }
 
 func TestMultilineMatches(t *testing.T) {
-	maybeSkipComby(t)
-
 	input := map[string]string{
 		"main.go": `
 func foo() {
@@ -666,8 +647,6 @@ func Test_chunkRanges(t *testing.T) {
 }
 
 func TestTarInput(t *testing.T) {
-	maybeSkipComby(t)
-
 	input := map[string]string{
 		"main.go": `
 func foo() {
@@ -723,16 +702,3 @@ func bar() {
 		require.Equal(t, expected, matches)
 	})
 }
-
-func maybeSkipComby(t *testing.T) {
-	t.Helper()
-	if os.Getenv("CI") != "" {
-		return
-	}
-	if runtime.GOOS == "darwin" && runtime.GOARCH == "arm64" {
-		t.Skip("Skipping due to limitations in comby and M1")
-	}
-	if _, err := exec.LookPath("comby"); err != nil {
-		t.Skipf("skipping comby test when not on CI: %v", err)
-	}
-}
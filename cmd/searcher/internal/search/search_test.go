@@ -9,8 +9,6 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
-	"os/exec"
-	"runtime"
 	"sort"
 	"strconv"
 	"strings"
@@ -301,10 +299,6 @@ abc.txt
 
 	for i, test := range cases {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
-			if test.arg.IsStructuralPat {
-				maybeSkipComby(t)
-			}
-
 			req := protocol.Request{
 				Repo:         "foo",
 				URL:          "u",
@@ -333,19 +327,6 @@ abc.txt
 	}
 }
 
-func maybeSkipComby(t *testing.T) {
-	t.Helper()
-	if os.Getenv("CI") != "" {
-		return
-	}
-	if runtime.GOOS == "darwin" && runtime.GOARCH == "arm64" {
-		t.Skip("Skipping due to limitations in comby and M1")
-	}
-	if _, err := exec.LookPath("comby"); err != nil {
-		t.Skipf("skipping comby test when not on CI: %v", err)
-	}
-}
-
 func TestSearch_badrequest(t *testing.T) {
 	cases := []protocol.Request{
 		// Bad regexp
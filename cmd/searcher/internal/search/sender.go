@@ -14,6 +14,9 @@ type matchSender interface {
 	SentCount() int
 	Remaining() int
 	LimitHit() bool
+	// SetLimitHit marks the results as incomplete without stopping the search,
+	// for example when matching a file stopped early.
+	SetLimitHit()
 }
 
 type limitedStream struct {
@@ -87,6 +90,10 @@ func (m *limitedStream) LimitHit() bool {
 	return m.limitHit.Load()
 }
 
+func (m *limitedStream) SetLimitHit() {
+	m.limitHit.Store(true)
+}
+
 type limitedStreamCollector struct {
 	collected []protocol.FileMatch
 	mux       sync.Mutex
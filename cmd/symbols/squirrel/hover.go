@@ -18,24 +18,29 @@ func findHover(node Node) string {
 		prev := cur.PrevNamedSibling()
 
 		// Skip over Java annotations and the like.
+		lastStartRow := int(cur.StartPoint().Row)
 		for ; prev != nil; prev = prev.PrevNamedSibling() {
 			if !contains(style.skipNodeTypes, prev.Type()) {
 				break
 			}
+			lastStartRow = int(prev.StartPoint().Row)
 		}
 
 		// Collect comments backwards.
 		comments := []string{}
-		lastStartRow := -1
 		for ; prev != nil && contains(style.nodeTypes, prev.Type()); prev = prev.PrevNamedSibling() {
-			if lastStartRow == -1 {
-				lastStartRow = int(prev.StartPoint().Row)
-			} else if lastStartRow != int(prev.EndPoint().Row+1) {
+			// Doc comments end on the line right above what they document.
+			if lastStartRow != int(prev.EndPoint().Row+1) {
 				break
-			} else {
-				lastStartRow = int(prev.StartPoint().Row)
 			}
 
+			// Skip trailing comments of the previous statement.
+			if before := prev.PrevSibling(); before != nil && before.EndPoint().Row == prev.StartPoint().Row {
+				break
+			}
+
+			lastStartRow = int(prev.StartPoint().Row)
+
 			comment := prev.Content(node.Contents)
 
 			// Strip line noise and delete garbage lines.
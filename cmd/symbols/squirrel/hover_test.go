@@ -30,6 +30,29 @@ func main() {
 	// comment line 2
 	var x int
 }
+`
+
+	golangDoc := `
+package p
+
+// not a doc comment
+
+// F does things.
+//
+//go:noinline
+func F() {
+	_ = 1 // not a doc comment either
+	y := 2
+}
+`
+
+	typescript := `
+/**
+ * Adds two numbers.
+ */
+export function add(a: number, b: number): number {
+	return a + b
+}
 `
 
 	csharp := `
@@ -53,6 +76,8 @@ namespace Foo {
 	}{
 		{"test.java", java, "comment line 1\ncomment line 2\n"},
 		{"test.go", golang, "comment line 1\ncomment line 2\n"},
+		{"doc.go", golangDoc, "```go\nfunc F() {\n```\n\n---\n\nF does things.\n\n"},
+		{"test.ts", typescript, "```typescript\nexport function add(a: number, b: number): number {\n```\n\n---\n\nAdds two numbers.\n"},
 		{"test.cs", csharp, "comment line 1\ncomment line 2\n"},
 	}
 
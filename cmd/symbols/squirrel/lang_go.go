@@ -0,0 +1,213 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (squirrel *SquirrelService) getDefGo(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier", "type_identifier", "package_identifier", "field_identifier":
+		ident := node.Content(node.Contents)
+
+		// Check for qualified references to other packages, e.g. fmt.Println or http.Client.
+		if parent := node.Parent(); parent != nil {
+			switch parent.Type() {
+			case "selector_expression":
+				operand := parent.ChildByFieldName("operand")
+				field := parent.ChildByFieldName("field")
+				if field != nil && nodeId(field) == nodeId(node.Node) {
+					if operand == nil || operand.Type() != "identifier" {
+						squirrel.breadcrumb(node, "getDefGo: fields of expressions require type information")
+						return nil, nil
+					}
+					return squirrel.getDefInImportedPackageGo(ctx, swapNode(node, operand), ident)
+				}
+			case "qualified_type":
+				pkg := parent.ChildByFieldName("package")
+				name := parent.ChildByFieldName("name")
+				if pkg != nil && name != nil && nodeId(name) == nodeId(node.Node) {
+					return squirrel.getDefInImportedPackageGo(ctx, swapNode(node, pkg), ident)
+				}
+			}
+		}
+
+		// Fields and methods require type information.
+		if node.Type() == "field_identifier" {
+			return nil, nil
+		}
+
+		// Check the scopes of the current file.
+		found, err := squirrel.getLocalDef(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			if spec := found.Parent(); spec != nil && spec.Type() == "import_spec" {
+				// Named imports refer to the imported package.
+				pkg, err := squirrel.getImportedPackageGo(ctx, swapNode(node, spec))
+				if err != nil {
+					return nil, err
+				}
+				if pkg != nil {
+					return pkg, nil
+				}
+			}
+			return found, nil
+		}
+
+		// Check unnamed imports.
+		spec, err := findImportSpecGo(swapNode(node, getRoot(node.Node)), ident)
+		if err != nil {
+			return nil, err
+		}
+		if spec != nil {
+			return squirrel.getImportedPackageGo(ctx, *spec)
+		}
+
+		// Check the other files of the current package.
+		return squirrel.symbolSearchOne(
+			ctx,
+			node.RepoCommitPath.Repo,
+			node.RepoCommitPath.Commit,
+			[]string{goFilesInDir(filepath.Dir(node.RepoCommitPath.Path))},
+			ident,
+		)
+
+	// No other nodes have a definition
+	default:
+		return nil, nil
+	}
+}
+
+// getDefInImportedPackageGo finds the top-level declaration ident in the package that pkg refers
+// to, e.g. Println in fmt.Println.
+func (squirrel *SquirrelService) getDefInImportedPackageGo(ctx context.Context, pkg Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(pkg, &Tuple{String(pkg.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	dir, err := squirrel.getDefGo(ctx, pkg)
+	if err != nil {
+		return nil, err
+	}
+	if dir == nil || dir.Node != nil {
+		// Not a package in this repository, or a value of which we don't know the type.
+		return nil, nil
+	}
+
+	return squirrel.symbolSearchOne(
+		ctx,
+		pkg.RepoCommitPath.Repo,
+		pkg.RepoCommitPath.Commit,
+		[]string{goFilesInDir(dir.RepoCommitPath.Path)},
+		ident,
+	)
+}
+
+// getImportedPackageGo returns the directory of the package imported by the given import_spec, or
+// nil if the package isn't in the same module.
+func (squirrel *SquirrelService) getImportedPackageGo(ctx context.Context, spec Node) (ret *Node, err error) {
+	defer squirrel.onCall(spec, String(spec.Type()), lazyNodeStringer(&ret))()
+
+	importPath := getImportPathGo(spec)
+	if importPath == "" {
+		return nil, nil
+	}
+
+	// Find the go.mod file of the current module by walking up the directory tree.
+	dir := filepath.Dir(spec.RepoCommitPath.Path)
+	for {
+		contents, err := squirrel.readFile(ctx, types.RepoCommitPath{
+			Repo:   spec.RepoCommitPath.Repo,
+			Commit: spec.RepoCommitPath.Commit,
+			Path:   filepath.Join(dir, "go.mod"),
+		})
+		if err == nil {
+			modulePath := getModulePathGo(contents)
+			if modulePath == "" {
+				return nil, nil
+			}
+			if importPath != modulePath && !strings.HasPrefix(importPath, modulePath+"/") {
+				squirrel.breadcrumb(spec, fmt.Sprintf("getImportedPackageGo: %s is not in module %s", importPath, modulePath))
+				return nil, nil
+			}
+			return &Node{
+				RepoCommitPath: types.RepoCommitPath{
+					Repo:   spec.RepoCommitPath.Repo,
+					Commit: spec.RepoCommitPath.Commit,
+					Path:   filepath.Join(dir, strings.TrimPrefix(importPath, modulePath)),
+				},
+				Node:     nil,
+				Contents: spec.Contents,
+				LangSpec: spec.LangSpec,
+			}, nil
+		}
+		if dir == "." || dir == "/" {
+			return nil, nil
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// findImportSpecGo returns the import_spec of the unnamed import whose package is called ident.
+func findImportSpecGo(root Node, ident string) (*Node, error) {
+	captures, err := allCaptures(`(import_spec) @spec`, root)
+	if err != nil {
+		return nil, err
+	}
+	for _, spec := range captures {
+		if spec.ChildByFieldName("name") != nil {
+			continue
+		}
+		if packageNameGo(getImportPathGo(spec)) == ident {
+			return &spec, nil
+		}
+	}
+	return nil, nil
+}
+
+func getImportPathGo(spec Node) string {
+	path := spec.ChildByFieldName("path")
+	if path == nil {
+		return ""
+	}
+	return strings.Trim(path.Content(spec.Contents), "\"`")
+}
+
+var majorVersionSuffixRegex = regexp.MustCompile(`^v[0-9]+$`)
+
+// packageNameGo guesses the name of a package from its import path, which is the last path
+// element by convention, skipping major version suffixes such as /v2.
+func packageNameGo(importPath string) string {
+	components := strings.Split(importPath, "/")
+	name := components[len(components)-1]
+	if len(components) > 1 && majorVersionSuffixRegex.MatchString(name) {
+		name = components[len(components)-2]
+	}
+	return name
+}
+
+var moduleDirectiveRegex = regexp.MustCompile(`(?m)^module\s+"?([^"\s]+)"?\s*$`)
+
+func getModulePathGo(goMod []byte) string {
+	match := moduleDirectiveRegex.FindSubmatch(goMod)
+	if match == nil {
+		return ""
+	}
+	return string(match[1])
+}
+
+// goFilesInDir returns an include pattern that matches the Go files directly in dir.
+func goFilesInDir(dir string) string {
+	if dir == "." {
+		return `^[^/]+\.go$`
+	}
+	return fmt.Sprintf(`^%s/[^/]+\.go$`, regexp.QuoteMeta(dir))
+}
@@ -0,0 +1,157 @@
+package squirrel
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (squirrel *SquirrelService) getDefTypeScript(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier", "type_identifier", "shorthand_property_identifier", "property_identifier":
+		ident := node.Content(node.Contents)
+
+		// Check for members of namespace imports, e.g. ns.f or ns.T.
+		if parent := node.Parent(); parent != nil {
+			var object, property string
+			switch parent.Type() {
+			case "member_expression":
+				object, property = "object", "property"
+			case "nested_type_identifier":
+				object, property = "module", "name"
+			}
+			if object != "" {
+				objectNode := parent.ChildByFieldName(object)
+				propertyNode := parent.ChildByFieldName(property)
+				if propertyNode != nil && nodeId(propertyNode) == nodeId(node.Node) {
+					if objectNode == nil || objectNode.Type() != "identifier" {
+						squirrel.breadcrumb(node, "getDefTypeScript: members of expressions require type information")
+						return nil, nil
+					}
+					return squirrel.getMemberOfNamespaceTypeScript(ctx, swapNode(node, objectNode), ident)
+				}
+			}
+		}
+
+		// Properties require type information.
+		if node.Type() == "property_identifier" {
+			return nil, nil
+		}
+
+		// Check the scopes of the current file.
+		found, err := squirrel.getLocalDef(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+
+		// Follow named imports to the exported declaration.
+		if specifier := found.Parent(); specifier != nil && specifier.Type() == "import_specifier" {
+			name := specifier.ChildByFieldName("name")
+			module := getImportSourceTypeScript(swapNode(node, specifier))
+			if name != nil && module != "" {
+				exported, err := squirrel.getExportTypeScript(ctx, found.RepoCommitPath, module, name.Content(node.Contents))
+				if err != nil {
+					return nil, err
+				}
+				if exported != nil {
+					return exported, nil
+				}
+			}
+		}
+
+		return found, nil
+
+	// No other nodes have a definition
+	default:
+		return nil, nil
+	}
+}
+
+// getMemberOfNamespaceTypeScript finds the declaration of ident in the module that namespace was
+// imported from with import * as namespace from '...'.
+func (squirrel *SquirrelService) getMemberOfNamespaceTypeScript(ctx context.Context, namespace Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(namespace, &Tuple{String(namespace.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	found, err := squirrel.getLocalDef(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, nil
+	}
+	if parent := found.Parent(); parent == nil || parent.Type() != "namespace_import" {
+		squirrel.breadcrumb(namespace, "getMemberOfNamespaceTypeScript: not a namespace import")
+		return nil, nil
+	}
+
+	module := getImportSourceTypeScript(*found)
+	if module == "" {
+		return nil, nil
+	}
+	return squirrel.getExportTypeScript(ctx, found.RepoCommitPath, module, ident)
+}
+
+// getExportTypeScript finds the top-level declaration of ident exported by the given module.
+// Only relative imports are resolved since packages aren't part of the repository.
+func (squirrel *SquirrelService) getExportTypeScript(ctx context.Context, from types.RepoCommitPath, module string, ident string) (ret *Node, err error) {
+	if !strings.HasPrefix(module, "./") && !strings.HasPrefix(module, "../") {
+		return nil, nil
+	}
+
+	// Imports can use the .js extension of the compiled file.
+	base := filepath.Join(filepath.Dir(from.Path), strings.TrimSuffix(module, ".js"))
+
+	for _, candidate := range []string{
+		base + ".ts",
+		base + ".tsx",
+		base + ".d.ts",
+		filepath.Join(base, "index.ts"),
+		filepath.Join(base, "index.tsx"),
+	} {
+		root, _ := squirrel.parse(ctx, types.RepoCommitPath{
+			Repo:   from.Repo,
+			Commit: from.Commit,
+			Path:   candidate,
+		})
+		if root == nil {
+			continue
+		}
+
+		captures, err := allCaptures(root.LangSpec.topLevelSymbolsQuery, *root)
+		if err != nil {
+			return nil, err
+		}
+		for _, capture := range captures {
+			if capture.Content(capture.Contents) == ident {
+				return &capture, nil
+			}
+		}
+		return nil, nil
+	}
+
+	return nil, nil
+}
+
+// getImportSourceTypeScript returns the module of the import statement that contains node.
+func getImportSourceTypeScript(node Node) string {
+	for cur := node.Node; cur != nil; cur = cur.Parent() {
+		if cur.Type() != "import_statement" {
+			continue
+		}
+		// Look for the string child because the source field isn't always set on import statements.
+		for _, child := range children(cur) {
+			if child.Type() == "string" {
+				return strings.Trim(child.Content(node.Contents), "\"'`")
+			}
+		}
+		return ""
+	}
+	return ""
+}
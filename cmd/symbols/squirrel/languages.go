@@ -66,6 +66,10 @@ type CommentStyle struct {
 var javaStyleStripRegex = regexp.MustCompile(`^//|^\s*\*/?|^/\*\*|\*/$`)
 var javaStyleIgnoreRegex = regexp.MustCompile(`^\s*(/\*\*|\*/)\s*$`)
 
+// Go block comments start with /* instead of /**, and directives such as //go:generate aren't
+// part of the doc comment.
+var goStyleIgnoreRegex = regexp.MustCompile(`^\s*(/\*|\*/)\s*$|^//(go:|line )`)
+
 // Mapping from language name to language specification.
 var langToLangSpec = map[string]LangSpec{
 	"java": {
@@ -105,10 +109,12 @@ var langToLangSpec = map[string]LangSpec{
 		language: golang.GetLanguage(),
 		commentStyle: CommentStyle{
 			nodeTypes:     []string{"comment"},
-			stripRegex:    regexp.MustCompile(`^//`),
+			stripRegex:    javaStyleStripRegex,
+			ignoreRegex:   goStyleIgnoreRegex,
 			codeFenceName: "go",
 		},
 		localsQuery: `
+(source_file)             @scope ; package p
 (block)                   @scope ; { ... }
 (function_declaration)    @scope ; func f() { ... }
 (method_declaration)      @scope ; func (r R) f() { ... }
@@ -116,14 +122,25 @@ var langToLangSpec = map[string]LangSpec{
 (if_statement)            @scope ; if true { ... }
 (for_statement)           @scope ; for x := range xs { ... }
 (expression_case)         @scope ; case "foo": ...
+(type_case)               @scope ; case int: ...
 (communication_case)      @scope ; case x := <-ch: ...
 
+(function_declaration  name: (identifier) @definition.parent)            ; func f() { ... }
+(type_spec             name: (type_identifier) @definition)              ; type T struct { ... }
+(import_spec           name: (package_identifier) @definition)           ; import f "fmt"
 (var_spec              name: (identifier) @definition)                   ; var x int = ...
 (const_spec            name: (identifier) @definition)                   ; const x int = ...
 (parameter_declaration name: (identifier) @definition)                   ; func(x int) { ... }
+(variadic_parameter_declaration name: (identifier) @definition)          ; func(xs ...int) { ... }
 (short_var_declaration left: (expression_list (identifier) @definition)) ; x, y := ...
 (range_clause          left: (expression_list (identifier) @definition)) ; for i := range ... { ... }
 (receive_statement     left: (expression_list (identifier) @definition)) ; case x := <-ch: ...
+`,
+		topLevelSymbolsQuery: `
+(source_file (function_declaration name: (identifier) @symbol))
+(source_file (type_declaration (type_spec name: (type_identifier) @symbol)))
+(source_file (var_declaration (var_spec name: (identifier) @symbol)))
+(source_file (const_declaration (const_spec name: (identifier) @symbol)))
 `,
 	},
 	"csharp": {
@@ -205,7 +222,10 @@ var langToLangSpec = map[string]LangSpec{
 			codeFenceName: "typescript",
 		},
 		localsQuery: `
+(program)                        @scope ; import ...
 (class_declaration)              @scope ; class C { ... }
+(interface_declaration)          @scope ; interface I<T> { ... }
+(type_alias_declaration)         @scope ; type A<T> = ...
 (method_definition)              @scope ; class ... { f() { ... } }
 (statement_block)                @scope ; { ... }
 (for_statement)                  @scope ; for (let i = 0; ...) ...
@@ -217,16 +237,36 @@ var langToLangSpec = map[string]LangSpec{
 (generator_function_declaration) @scope ; function *f(x) { ... }
 (arrow_function)                 @scope ; x => ...
 
-(variable_declarator name: (identifier) @definition)            ; const x = ...
-(function_declaration name: (identifier) @definition)           ; function f() { ... }
-(generator_function_declaration name: (identifier) @definition) ; function *f() { ... }
-(required_parameter (identifier) @definition)                   ; function(x) { ... }
-(required_parameter (rest_pattern (identifier) @definition))    ; function(...x) { ... }
-(optional_parameter (identifier) @definition)                   ; function(x?) { ... }
-(optional_parameter (rest_pattern (identifier) @definition))    ; function(...x?) { ... }
-(arrow_function parameter: (identifier) @definition)            ; x => ...
-(for_in_statement left: (identifier) @definition)               ; for (const x of xs) ...
-(catch_clause parameter: (identifier) @definition)              ; catch (e) ...
+(import_clause (identifier) @definition)                                                         ; import x from '...'
+(namespace_import (identifier) @definition)                                                      ; import * as x from '...'
+(import_specifier name: (identifier) @definition !alias)                                         ; import { x } from '...'
+(import_specifier alias: (identifier) @definition)                                               ; import { y as x } from '...'
+(variable_declarator name: (identifier) @definition)                                             ; const x = ...
+(variable_declarator name: (object_pattern (shorthand_property_identifier_pattern) @definition)) ; const { x } = ...
+(variable_declarator name: (object_pattern (pair_pattern value: (identifier) @definition)))      ; const { y: x } = ...
+(variable_declarator name: (array_pattern (identifier) @definition))                             ; const [x] = ...
+(function_declaration name: (identifier) @definition.parent)                                     ; function f() { ... }
+(generator_function_declaration name: (identifier) @definition.parent)                           ; function *f() { ... }
+(class_declaration name: (type_identifier) @definition.parent)                                   ; class C { ... }
+(interface_declaration name: (type_identifier) @definition.parent)                               ; interface I { ... }
+(type_alias_declaration name: (type_identifier) @definition.parent)                              ; type A = ...
+(enum_declaration name: (identifier) @definition)                                                ; enum E { ... }
+(type_parameter (type_identifier) @definition)                                                   ; function f<T>() { ... }
+(required_parameter (identifier) @definition)                                                    ; function(x) { ... }
+(required_parameter (rest_pattern (identifier) @definition))                                     ; function(...x) { ... }
+(optional_parameter (identifier) @definition)                                                    ; function(x?) { ... }
+(optional_parameter (rest_pattern (identifier) @definition))                                     ; function(...x?) { ... }
+(arrow_function parameter: (identifier) @definition)                                             ; x => ...
+(for_in_statement left: (identifier) @definition)                                                ; for (const x of xs) ...
+(catch_clause parameter: (identifier) @definition)                                               ; catch (e) ...
+`,
+		topLevelSymbolsQuery: `
+(program (export_statement declaration: (function_declaration   name: (identifier)      @symbol)))
+(program (export_statement declaration: (class_declaration      name: (type_identifier) @symbol)))
+(program (export_statement declaration: (interface_declaration  name: (type_identifier) @symbol)))
+(program (export_statement declaration: (type_alias_declaration name: (type_identifier) @symbol)))
+(program (export_statement declaration: (enum_declaration       name: (identifier)      @symbol)))
+(program (export_statement declaration: (lexical_declaration (variable_declarator name: (identifier) @symbol))))
 `,
 	},
 	"cpp": {
@@ -53,10 +53,22 @@ func (squirrel *SquirrelService) localCodeIntel(ctx context.Context, repoCommitP
 		for captureName, node := range nameToNode {
 			// Only collect "definition*" captures.
 			if strings.HasPrefix(captureName, "definition") {
+				// Names of functions, classes and the like are captured as "definition.parent" because
+				// they're visible in the scope that encloses the declaration, not only inside of it.
+				skipScopes := 0
+				if captureName == "definition.parent" {
+					skipScopes = 1
+				}
+
 				// Find the nearest scope (if it exists).
 				for cur := node.Node; cur != nil; cur = cur.Parent() {
 					// Found the scope.
 					if scope, ok := scopes[nodeId(cur)]; ok {
+						if skipScopes > 0 {
+							skipScopes--
+							continue
+						}
+
 						// Get the symbol name.
 						symbolName := SymbolName(node.Content(node.Contents))
 
@@ -91,6 +103,11 @@ func (squirrel *SquirrelService) localCodeIntel(ctx context.Context, repoCommitP
 			return
 		}
 
+		// Fields and properties are looked up on a value, so they never refer to a local.
+		if node.Type() == "field_identifier" || node.Type() == "property_identifier" {
+			return
+		}
+
 		// Get the symbol name.
 		symbolName := SymbolName(node.Content(root.Contents))
 
@@ -134,6 +151,34 @@ func (squirrel *SquirrelService) localCodeIntel(ctx context.Context, repoCommitP
 	return &types.LocalCodeIntelPayload{Symbols: symbols}, nil
 }
 
+// getLocalDef returns the definition of the given identifier if it's declared in one of the scopes
+// that enclose it in the same file.
+func (squirrel *SquirrelService) getLocalDef(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	payload, err := squirrel.localCodeIntel(ctx, node.RepoCommitPath)
+	if err != nil {
+		return nil, err
+	}
+
+	rnge := nodeToRange(node.Node)
+	for _, symbol := range payload.Symbols {
+		for _, ref := range symbol.Refs {
+			if ref != rnge {
+				continue
+			}
+			point := sitter.Point{Row: uint32(symbol.Def.Row), Column: uint32(symbol.Def.Column)}
+			def := getRoot(node.Node).NamedDescendantForPointRange(point, point)
+			if def == nil {
+				return nil, nil
+			}
+			return swapNodePtr(node, def), nil
+		}
+	}
+
+	return nil, nil
+}
+
 // Pretty prints the local code intel payload for debugging.
 func prettyPrintLocalCodeIntelPayload(w io.Writer, payload types.LocalCodeIntelPayload, contents string) {
 	lines := strings.Split(contents, "\n")
@@ -47,8 +47,30 @@ class Foo {
 `}, {
 		path: "test.go",
 		contents: `
+import (
+	"fmt"
+	s "strings" // < "s" file.s def < "s" file.s ref
+)
+
+//  v file.x def
+//  v file.x ref
 var x = 5
 
+//   v file.T def
+//   v file.T ref
+type T struct {
+	x int
+}
+
+//   vv file.f0 def
+//   vv file.f0 ref
+//          vv file.f1 ref
+//                 v file.T ref
+//                         v file.x ref
+func f0() { f1(0); T{}.x = x }
+
+//   vv file.f1 def
+//   vv file.f1 ref
 //      v f1.p def
 //      v f1.p ref
 func f1(p int) {
@@ -88,6 +110,15 @@ func f1(p int) {
 	//   v f1.switch2.x ref
 	case x := <-ch:
 	}
+
+	//  v f1.t def
+	//  v f1.t ref
+	//    v file.T ref
+	//        v file.T ref
+	//             v file.s ref
+	var t T = T{x: s.Repeat("x", 1)}
+
+	f0() // < "f0" file.f0 ref
 }
 `}, {
 		path: "test.cs",
@@ -219,6 +250,80 @@ const f = (p1, p2 = 3, ...p3) => {
 `}, {
 		path: "test.ts",
 		contents: `
+//     v file.D def
+//     v file.D ref
+//          v file.a def
+//          v file.a ref
+//                  v file.c def
+//                  v file.c ref
+import D, { a, b as c } from './x'
+//          vv file.ns def
+//          vv file.ns ref
+import * as ns from '../y'
+
+//        v file.I def
+//        v file.I ref
+//          v I.U def
+//          v I.U ref
+//                  v I.U ref
+interface I<U> { p: U }
+
+//   v file.A def
+//   v file.A ref
+//     v A.T def
+//     v A.T ref
+//          v file.I ref
+//            v A.T ref
+//                 v file.E ref
+type A<T> = I<T> | E
+
+//   v file.E def
+//   v file.E ref
+enum E { X }
+
+//      v file.q def
+//      v file.q ref
+//            v file.s def
+//            v file.s ref
+//                  v file.D ref
+//                      v file.t def
+//                      v file.t ref
+//                           v file.c ref
+const { q, r: s } = D, [t] = c
+
+//          v file.a ref
+//             vv file.ns ref
+//                   v file.q ref
+//                      v file.s ref
+//                         v file.t ref
+console.log(a, ns.a, q, s, t)
+
+//    v file.C def
+//    v file.C ref
+//      v C.T def
+//      v C.T ref
+//             v C.m.x def
+//             v C.m.x ref
+//                v C.T ref
+//                    v file.A ref
+//                      v C.T ref
+//                                  v file.h ref
+//                                    v C.m.x ref
+class C<T> { m(x: T): A<T> { return h(x) } }
+
+//       v file.h def
+//       v file.h ref
+//         v h.T def
+//         v h.T ref
+//            v h.x def
+//            v h.x ref
+//               v h.T ref
+//                               v file.C ref
+//                                 v h.T ref
+function h<T>(x: T) { return new C<T>() }
+
+//    v file.f def
+//    v file.f ref
 //         vv f.p1 def
 //         vv f.p1 ref
 //                      vv f.p2 def
@@ -239,10 +344,8 @@ const f = (p1?: number, p2 = 3, ...p3) => {
 	//       v f.g ref
 	function g() {}
 
-	// "g" here should be a reference to the function, but the way locals are modeled isn't sophisticated
-	// enough (yet?) to express bindings that also escape their lexical scope.
-
 	//          v f.x ref
+	//             v f.g ref
 	console.log(x, g)
 
 	//       v f.i def
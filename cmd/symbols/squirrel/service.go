@@ -148,11 +148,12 @@ func (squirrel *SquirrelService) getDef(ctx context.Context, node Node) (*Node,
 		return squirrel.getDefStarlark(ctx, node)
 	case "python":
 		return squirrel.getDefPython(ctx, node)
-	// case "go":
+	case "go":
+		return squirrel.getDefGo(ctx, node)
+	case "typescript":
+		return squirrel.getDefTypeScript(ctx, node)
 	// case "csharp":
-	// case "python":
 	// case "javascript":
-	// case "typescript":
 	// case "cpp":
 	// case "ruby":
 	default:
@@ -0,0 +1,14 @@
+package main
+
+type T struct { // < "T" go.T def
+	Name string
+}
+
+func helper() string { // < "helper" go.helper def
+	//     vvvvvvvv go.Greeting2 ref
+	return Greeting()
+}
+
+func Greeting() string { // < "Greeting" go.Greeting2 def
+	return "hello"
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+
+	u "example.com/hello/util"
+	"example.com/hello/util/strs"
+)
+
+func main() {
+	//          v util path
+	//            vvvvvvvv go.Greeting ref
+	//                      vvvv util/strs path
+	//                           vvvvv go.Upper ref
+	//                                        vvvvvv go.helper ref
+	//                                                  v go.T ref
+	fmt.Println(u.Greeting, strs.Upper("hi"), helper(), T{}) // < "fmt" go.fmt ref,nodef
+
+	//  v go.t def
+	//          vvv go.New ref
+	var t = u.New()
+
+	//vvvv go.Name ref,nodef
+	//            vvv go.Sep ref
+	t.Name = strs.Sep // < "t" go.t ref
+}
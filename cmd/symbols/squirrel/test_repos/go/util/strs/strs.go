@@ -0,0 +1,9 @@
+package strs
+
+import "strings"
+
+const Sep = "/" // < "Sep" go.Sep def
+
+func Upper(s string) string { // < "Upper" go.Upper def
+	return strings.ToUpper(s)
+}
@@ -0,0 +1,11 @@
+package util
+
+import "example.com/hello/util/strs"
+
+var Greeting = strs.Upper("hello") // < "Greeting" go.Greeting def < "Upper" go.Upper ref
+
+func New() T { // < "New" go.New def
+	return T{}
+}
+
+type T struct{ Name string }
@@ -0,0 +1,9 @@
+//       vvvvv ts.Point ref
+import { Point } from './math'
+
+//          vvvvvv ts.Circle def
+//                             vvvvv ts.Point ref
+export type Circle = { center: Point; radius: number }
+
+//           vvvvvv ts.circle def
+export const circle = (center: Point): Circle => ({ center, radius: 1 })
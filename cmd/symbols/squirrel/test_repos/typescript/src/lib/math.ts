@@ -0,0 +1,10 @@
+//              vvv ts.add def
+export function add(a: number, b: number): number {
+    return a + b
+}
+
+//               vvvvv ts.Point def
+export interface Point {
+    x: number
+    y: number
+}
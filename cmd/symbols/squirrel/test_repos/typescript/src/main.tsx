@@ -0,0 +1,20 @@
+//       vvv ts.add ref
+//                     v ts.Point ref
+import { add, Point as P } from './lib/math'
+import * as shapes from './lib'
+//     vvvvv ts.React def
+import React from 'react'
+
+//    v ts.p def
+//       v ts.Point ref
+//                vvv ts.add ref
+const p: P = { x: add(1, 2), y: 0 }
+//    v ts.c def
+//              vvvvvv ts.Circle ref
+//                              vvvvvv ts.circle ref
+//                                     v ts.p ref
+const c: shapes.Circle = shapes.circle(p)
+//          v ts.c ref
+//            vvvvvv ts.radius ref,nodef
+//                    vvvvv ts.React ref
+console.log(c.radius, React)
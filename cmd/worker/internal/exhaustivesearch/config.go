@@ -0,0 +1,51 @@
+package exhaustivesearch
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/search/exhaustive"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type config struct {
+	env.BaseConfig
+
+	WorkerPollInterval time.Duration
+	WorkerConcurrency  int
+	ProgressInterval   time.Duration
+	Retention          time.Duration
+	UploadStore        *exhaustive.UploadStoreConfig
+}
+
+var ConfigInst = &config{}
+
+func (c *config) Load() {
+	c.UploadStore = &exhaustive.UploadStoreConfig{}
+	c.UploadStore.Load()
+
+	c.WorkerPollInterval = c.GetInterval("SEARCH_JOBS_WORKER_POLL_INTERVAL", "5s", "How frequently to query the search job queue")
+	c.WorkerConcurrency = c.GetInt("SEARCH_JOBS_WORKER_CONCURRENCY", "2", "The maximum number of search jobs that can run concurrently")
+	c.ProgressInterval = c.GetInterval("SEARCH_JOBS_PROGRESS_INTERVAL", "10s", "How frequently the progress of a running search job is recorded")
+	c.Retention = c.GetInterval("SEARCH_JOBS_RETENTION", "168h", "How long to keep finished search jobs and their results")
+}
+
+func (c *config) Validate() error {
+	var errs error
+	errs = errors.Append(errs, c.BaseConfig.Validate())
+	errs = errors.Append(errs, c.UploadStore.Validate())
+	if c.WorkerPollInterval < 0 {
+		errs = errors.Append(errs, errors.New("SEARCH_JOBS_WORKER_POLL_INTERVAL must be greater than or equal to 0"))
+	}
+	if c.WorkerConcurrency < 1 {
+		errs = errors.Append(errs, errors.New("SEARCH_JOBS_WORKER_CONCURRENCY must be greater than 0"))
+	}
+	if c.ProgressInterval <= 0 {
+		errs = errors.Append(errs, errors.New("SEARCH_JOBS_PROGRESS_INTERVAL must be greater than 0"))
+	}
+	if c.Retention < time.Hour {
+		errs = errors.Append(errs, errors.New("SEARCH_JOBS_RETENTION must be at least one hour"))
+	}
+
+	return errs
+}
@@ -0,0 +1,175 @@
+package exhaustivesearch
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/exhaustive"
+	"github.com/sourcegraph/sourcegraph/internal/search/export"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// handler runs the query of an exhaustive search job and streams every result
+// into the upload store.
+type handler struct {
+	db               database.DB
+	searchClient     client.SearchClient
+	uploadStore      uploadstore.Store
+	progressInterval time.Duration
+}
+
+var _ workerutil.Handler[*types.ExhaustiveSearchJob] = &handler{}
+
+func (h *handler) Handle(ctx context.Context, logger log.Logger, job *types.ExhaustiveSearchJob) error {
+	format, err := export.ParseFormat(job.Format)
+	if err != nil {
+		return errcode.MakeNonRetryable(err)
+	}
+
+	// The search runs with the permissions and settings of the user who
+	// created the job.
+	ctx = actor.WithActor(ctx, actor.FromUser(job.InitiatorID))
+	settings, err := graphqlbackend.DecodedViewerFinalSettings(ctx, h.db)
+	if err != nil {
+		return errors.Wrap(err, "getting settings")
+	}
+
+	inputs, err := h.searchClient.Plan(
+		ctx,
+		"V3",
+		nil,
+		job.Query,
+		search.Precise,
+		search.Exhaustive,
+		settings,
+		envvar.SourcegraphDotComMode(),
+	)
+	if err != nil {
+		return errcode.MakeNonRetryable(err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pr, pw := io.Pipe()
+	w, err := export.NewWriter(pw, format)
+	if err != nil {
+		return errcode.MakeNonRetryable(err)
+	}
+
+	key := exhaustive.ResultKey(job)
+	type uploadResult struct {
+		size int64
+		err  error
+	}
+	uploaded := make(chan uploadResult, 1)
+	go func() {
+		size, err := h.uploadStore.Upload(ctx, key, pr)
+		// Unblock the search if the upload stopped before reading everything.
+		pr.CloseWithError(err)
+		uploaded <- uploadResult{size: size, err: err}
+	}()
+
+	p := &progress{repos: make(map[api.RepoID]struct{})}
+	stream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		p.Lock()
+		defer p.Unlock()
+
+		if p.err != nil {
+			return
+		}
+		for _, match := range event.Results {
+			n, err := w.Write(match)
+			p.matches += n
+			if err != nil {
+				p.err = err
+				// Stop searching, there is no point in finding results we
+				// cannot write.
+				cancel()
+				return
+			}
+		}
+		for id := range event.Stats.Repos {
+			p.repos[id] = struct{}{}
+		}
+		p.limitHit = p.limitHit || event.Stats.IsLimitHit
+	})
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(h.progressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := h.updateProgress(ctx, job.ID, p); err != nil {
+					logger.Warn("failed to record search job progress", log.Int64("id", job.ID), log.Error(err))
+				}
+			}
+		}
+	}()
+
+	alert, searchErr := h.searchClient.Execute(ctx, stream, inputs)
+
+	p.Lock()
+	searchErr = errors.Append(searchErr, p.err)
+	p.Unlock()
+	searchErr = errors.Append(searchErr, w.Flush())
+	pw.CloseWithError(searchErr)
+
+	res := <-uploaded
+	if err := errors.Append(searchErr, res.err); err != nil {
+		if res.err == nil {
+			// Don't leave partial results behind.
+			if err := h.uploadStore.Delete(context.Background(), key); err != nil {
+				logger.Warn("failed to delete partial search job results", log.String("key", key), log.Error(err))
+			}
+		}
+		return err
+	}
+
+	if alert != nil {
+		logger.Info("search job finished with an alert", log.Int64("id", job.ID), log.String("title", alert.Title))
+	}
+
+	if err := h.updateProgress(ctx, job.ID, p); err != nil {
+		return err
+	}
+	return h.db.ExhaustiveSearchJobs().SetResult(ctx, job.ID, key, res.size)
+}
+
+func (h *handler) updateProgress(ctx context.Context, id int64, p *progress) error {
+	p.Lock()
+	matches, repos, limitHit := p.matches, len(p.repos), p.limitHit
+	p.Unlock()
+
+	return h.db.ExhaustiveSearchJobs().UpdateProgress(ctx, id, matches, repos, limitHit)
+}
+
+// progress tracks what a running search has found so far.
+type progress struct {
+	sync.Mutex
+	matches  int
+	repos    map[api.RepoID]struct{}
+	limitHit bool
+	err      error
+}
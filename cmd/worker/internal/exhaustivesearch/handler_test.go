@@ -0,0 +1,115 @@
+package exhaustivesearch
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	uploadstoremocks "github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestHandler(t *testing.T) {
+	graphqlbackend.MockDecodedViewerFinalSettings = &schema.Settings{}
+	t.Cleanup(func() { graphqlbackend.MockDecodedViewerFinalSettings = nil })
+
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+
+	setup := func(execute func(context.Context, streaming.Sender, *search.Inputs) (*search.Alert, error)) (*handler, *database.MockExhaustiveSearchJobStore, *uploadstoremocks.MockStore, *bytes.Buffer) {
+		jobs := database.NewMockExhaustiveSearchJobStore()
+		db := database.NewMockDB()
+		db.ExhaustiveSearchJobsFunc.SetDefaultReturn(jobs)
+
+		searchClient := client.NewMockSearchClient()
+		searchClient.PlanFunc.SetDefaultHook(func(ctx context.Context, _ string, _ *string, query string, _ search.Mode, protocol search.Protocol, _ *schema.Settings, _ bool) (*search.Inputs, error) {
+			assert.Equal(t, search.Exhaustive, protocol)
+			assert.Equal(t, int32(7), actor.FromContext(ctx).UID)
+			return &search.Inputs{OriginalQuery: query, Protocol: protocol}, nil
+		})
+		searchClient.ExecuteFunc.SetDefaultHook(execute)
+
+		var uploaded bytes.Buffer
+		uploadStore := uploadstoremocks.NewMockStore()
+		uploadStore.UploadFunc.SetDefaultHook(func(_ context.Context, _ string, r io.Reader) (int64, error) {
+			return io.Copy(&uploaded, r)
+		})
+
+		return &handler{
+			db:               db,
+			searchClient:     searchClient,
+			uploadStore:      uploadStore,
+			progressInterval: time.Hour,
+		}, jobs, uploadStore, &uploaded
+	}
+
+	newJob := func(format string) *types.ExhaustiveSearchJob {
+		return &types.ExhaustiveSearchJob{ID: 42, InitiatorID: 7, Query: "foo", Format: format}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		h, jobs, uploadStore, uploaded := setup(func(_ context.Context, s streaming.Sender, _ *search.Inputs) (*search.Alert, error) {
+			s.Send(streaming.SearchEvent{
+				Results: result.Matches{
+					&result.FileMatch{File: result.File{Repo: repo, CommitID: "deadbeef", Path: "a.go"}},
+					&result.RepoMatch{Name: repo.Name, ID: repo.ID},
+				},
+				Stats: streaming.Stats{Repos: map[api.RepoID]struct{}{repo.ID: {}}},
+			})
+			s.Send(streaming.SearchEvent{
+				Stats: streaming.Stats{Repos: map[api.RepoID]struct{}{2: {}}, IsLimitHit: true},
+			})
+			return nil, nil
+		})
+
+		require.NoError(t, h.Handle(ctx, logger, newJob("csv")))
+
+		assert.Equal(t, `type,repository,revision,commit,path,line,preview,author,date,symbol,symbol_kind,owner
+path,github.com/sourcegraph/sourcegraph,,deadbeef,a.go,,,,,,,
+repo,github.com/sourcegraph/sourcegraph,,,,,,,,,,
+`, uploaded.String())
+
+		mockassert.CalledOnceWith(t, uploadStore.UploadFunc, mockassert.Values(mockassert.Skip, "search-jobs/42.csv"))
+		mockassert.CalledOnceWith(t, jobs.UpdateProgressFunc, mockassert.Values(mockassert.Skip, int64(42), 2, 2, true))
+		mockassert.CalledOnceWith(t, jobs.SetResultFunc, mockassert.Values(mockassert.Skip, int64(42), "search-jobs/42.csv", int64(uploaded.Len())))
+	})
+
+	t.Run("search error", func(t *testing.T) {
+		h, jobs, _, _ := setup(func(_ context.Context, s streaming.Sender, _ *search.Inputs) (*search.Alert, error) {
+			s.Send(streaming.SearchEvent{Results: result.Matches{&result.RepoMatch{Name: repo.Name, ID: repo.ID}}})
+			return nil, errors.New("boom")
+		})
+
+		err := h.Handle(ctx, logger, newJob("jsonl"))
+		require.ErrorContains(t, err, "boom")
+		mockassert.NotCalled(t, jobs.SetResultFunc)
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		h, _, uploadStore, _ := setup(nil)
+
+		err := h.Handle(ctx, logger, newJob("xml"))
+		require.Error(t, err)
+		assert.True(t, errcode.IsNonRetryable(err))
+		mockassert.NotCalled(t, uploadStore.UploadFunc)
+	})
+}
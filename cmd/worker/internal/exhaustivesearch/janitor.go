@@ -0,0 +1,44 @@
+package exhaustivesearch
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// janitor deletes finished search jobs, along with their results, once they
+// are older than the retention period.
+type janitor struct {
+	logger      log.Logger
+	store       database.ExhaustiveSearchJobStore
+	uploadStore uploadstore.Store
+	retention   time.Duration
+}
+
+var _ goroutine.Handler = &janitor{}
+var _ goroutine.ErrorHandler = &janitor{}
+
+func (j *janitor) Handle(ctx context.Context) error {
+	keys, err := j.store.DeleteFinishedBefore(ctx, time.Now().Add(-j.retention))
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, key := range keys {
+		if err := j.uploadStore.Delete(ctx, key); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "deleting %q", key))
+		}
+	}
+	return errs
+}
+
+func (j *janitor) HandleError(err error) {
+	j.logger.Error("error deleting stale search jobs", log.Error(err))
+}
@@ -0,0 +1,156 @@
+package exhaustivesearch
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/exhaustive"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+// searchJobs is a worker responsible for running queued exhaustive search
+// jobs, and for expunging finished jobs once they are no longer needed.
+type searchJobs struct{}
+
+var _ job.Job = &searchJobs{}
+
+func NewSearchJobs() job.Job {
+	return &searchJobs{}
+}
+
+func (s *searchJobs) Description() string {
+	return "Runs search jobs in the background and stores their results for download."
+}
+
+func (s *searchJobs) Config() []env.Config {
+	return []env.Config{ConfigInst}
+}
+
+func (s *searchJobs) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	uploadStore, err := exhaustive.NewUploadStore(context.Background(), observationCtx, ConfigInst.UploadStore)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := newMetrics(observationCtx)
+	rootContext := actor.WithInternalActor(context.Background())
+
+	return []goroutine.BackgroundRoutine{
+		newWorker(rootContext, observationCtx, db, uploadStore, ConfigInst, metrics),
+		newResetter(observationCtx, db, metrics),
+		goroutine.NewPeriodicGoroutine(rootContext, "search-jobs.janitor", "deletes finished search jobs and their results",
+			1*time.Hour, &janitor{
+				logger:      observationCtx.Logger.Scoped("SearchJobsJanitor", ""),
+				store:       db.ExhaustiveSearchJobs(),
+				uploadStore: uploadStore,
+				retention:   ConfigInst.Retention,
+			},
+		),
+	}, nil
+}
+
+func newWorker(ctx context.Context, observationCtx *observation.Context, db database.DB, uploadStore uploadstore.Store, cfg *config, metrics searchJobsMetrics) *workerutil.Worker[*types.ExhaustiveSearchJob] {
+	observationCtx = observation.ContextWithLogger(observationCtx.Logger.Scoped("SearchJobsWorker", ""), observationCtx)
+
+	options := workerutil.WorkerOptions{
+		Name:              "exhaustive_search_job_worker",
+		NumHandlers:       cfg.WorkerConcurrency,
+		Interval:          cfg.WorkerPollInterval,
+		HeartbeatInterval: 15 * time.Second,
+		Metrics:           metrics.workerMetrics,
+	}
+
+	return dbworker.NewWorker[*types.ExhaustiveSearchJob](ctx, createStore(observationCtx, db), &handler{
+		db:               db,
+		searchClient:     client.NewSearchClient(observationCtx.Logger, db, search.Indexed(), search.SearcherURLs()),
+		uploadStore:      uploadStore,
+		progressInterval: cfg.ProgressInterval,
+	}, options)
+}
+
+func newResetter(observationCtx *observation.Context, db database.DB, metrics searchJobsMetrics) *dbworker.Resetter[*types.ExhaustiveSearchJob] {
+	observationCtx = observation.ContextWithLogger(observationCtx.Logger.Scoped("SearchJobsResetter", ""), observationCtx)
+
+	options := dbworker.ResetterOptions{
+		Name:     "exhaustive_search_job_worker_resetter",
+		Interval: 1 * time.Minute,
+		Metrics: dbworker.ResetterMetrics{
+			Errors:              metrics.errors,
+			RecordResetFailures: metrics.resetFailures,
+			RecordResets:        metrics.resets,
+		},
+	}
+	return dbworker.NewResetter(observationCtx.Logger, createStore(observationCtx, db), options)
+}
+
+func createStore(observationCtx *observation.Context, s basestore.ShareableStore) dbworkerstore.Store[*types.ExhaustiveSearchJob] {
+	return dbworkerstore.New(observationCtx, s.Handle(), dbworkerstore.Options[*types.ExhaustiveSearchJob]{
+		Name:              "exhaustive_search_job_worker_store",
+		TableName:         "exhaustive_search_jobs",
+		ColumnExpressions: database.ExhaustiveSearchJobColumns,
+		Scan:              dbworkerstore.BuildWorkerScan(database.ScanExhaustiveSearchJob),
+		// Searches can run for a long time, but the heartbeat keeps them from
+		// being considered stalled.
+		StalledMaxAge: 60 * time.Second,
+		// Failed searches are not retried, since the error is most likely in
+		// the query and would just happen again.
+		MaxNumRetries:     0,
+		OrderByExpression: sqlf.Sprintf("exhaustive_search_jobs.id"),
+	})
+}
+
+type searchJobsMetrics struct {
+	workerMetrics workerutil.WorkerObservability
+	resets        prometheus.Counter
+	resetFailures prometheus.Counter
+	errors        prometheus.Counter
+}
+
+func newMetrics(observationCtx *observation.Context) searchJobsMetrics {
+	resetFailures := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_exhaustive_search_job_reset_failures_total",
+		Help: "The number of reset failures.",
+	})
+	observationCtx.Registerer.MustRegister(resetFailures)
+
+	resets := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_exhaustive_search_job_resets_total",
+		Help: "The number of records reset.",
+	})
+	observationCtx.Registerer.MustRegister(resets)
+
+	errors := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_exhaustive_search_job_errors_total",
+		Help: "The number of errors that occur during job.",
+	})
+	observationCtx.Registerer.MustRegister(errors)
+
+	return searchJobsMetrics{
+		workerMetrics: workerutil.NewMetrics(observationCtx, "exhaustive_search_jobs"),
+		resets:        resets,
+		resetFailures: resetFailures,
+		errors:        errors,
+	}
+}
@@ -0,0 +1,47 @@
+package outboundwebhooks
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type config struct {
+	env.BaseConfig
+
+	WorkerPollInterval  time.Duration
+	WorkerConcurrency   int
+	WorkerRetryInterval time.Duration
+	WorkerMaxRetries    int
+	Retention           time.Duration
+}
+
+var ConfigInst = &config{}
+
+func (c *config) Load() {
+	c.WorkerPollInterval = c.GetInterval("OUTBOUND_WEBHOOK_WORKER_POLL_INTERVAL", "1s", "How frequently to query the outbound webhook job queue")
+	c.WorkerConcurrency = c.GetInt("OUTBOUND_WEBHOOK_WORKER_CONCURRENCY", "4", "The maximum number of outbound webhook payloads that can be delivered concurrently")
+	c.WorkerRetryInterval = c.GetInterval("OUTBOUND_WEBHOOK_WORKER_RETRY_INTERVAL", "1m", "The minimum amount of time to wait before retrying a failed delivery")
+	c.WorkerMaxRetries = c.GetInt("OUTBOUND_WEBHOOK_WORKER_MAX_RETRIES", "5", "The maximum number of times a failed delivery is retried")
+	c.Retention = c.GetInterval("OUTBOUND_WEBHOOK_RETENTION", "72h", "How long to keep finished outbound webhook jobs and their logs")
+}
+
+func (c *config) Validate() error {
+	var errs error
+	errs = errors.Append(errs, c.BaseConfig.Validate())
+	if c.WorkerPollInterval < 0 {
+		errs = errors.Append(errs, errors.New("OUTBOUND_WEBHOOK_WORKER_POLL_INTERVAL must be greater than or equal to 0"))
+	}
+	if c.WorkerConcurrency < 1 {
+		errs = errors.Append(errs, errors.New("OUTBOUND_WEBHOOK_WORKER_CONCURRENCY must be greater than 0"))
+	}
+	if c.WorkerMaxRetries < 0 {
+		errs = errors.Append(errs, errors.New("OUTBOUND_WEBHOOK_WORKER_MAX_RETRIES must be greater than or equal to 0"))
+	}
+	if c.Retention < time.Hour {
+		errs = errors.Append(errs, errors.New("OUTBOUND_WEBHOOK_RETENTION must be at least one hour"))
+	}
+
+	return errs
+}
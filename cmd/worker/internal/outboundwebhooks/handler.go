@@ -0,0 +1,124 @@
+package outboundwebhooks
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxResponseBodySize is the maximum number of bytes of a response body that
+// are recorded in the delivery log.
+const maxResponseBodySize = 64 * 1024
+
+// handler delivers a single outbound webhook job to its webhook, recording the
+// attempt in the outbound webhook log.
+type handler struct {
+	client   httpcli.Doer
+	webhooks database.OutboundWebhookStore
+	logs     database.OutboundWebhookLogStore
+}
+
+var _ workerutil.Handler[*types.OutboundWebhookJob] = &handler{}
+
+func (h *handler) Handle(ctx context.Context, logger log.Logger, job *types.OutboundWebhookJob) error {
+	webhook, err := h.webhooks.GetByID(ctx, job.OutboundWebhookID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			// The webhook was deleted after the job was enqueued; there is
+			// nothing to deliver to, so there's no point retrying.
+			return errcode.MakeNonRetryable(err)
+		}
+		return errors.Wrap(err, "getting outbound webhook")
+	}
+
+	url, err := webhook.URL.Decrypt(ctx)
+	if err != nil {
+		return errors.Wrap(err, "decrypting webhook URL")
+	}
+	secret, err := webhook.Secret.Decrypt(ctx)
+	if err != nil {
+		return errors.Wrap(err, "decrypting webhook secret")
+	}
+	payload, err := job.Payload.Decrypt(ctx)
+	if err != nil {
+		return errors.Wrap(err, "decrypting payload")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader([]byte(payload)))
+	if err != nil {
+		return errcode.MakeNonRetryable(errors.Wrap(err, "creating request"))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(outbound.EventTypeHeader, job.EventType)
+	req.Header.Set(outbound.DeliveryHeader, strconv.FormatInt(job.ID, 10))
+	req.Header.Set(outbound.SignatureHeader, outbound.Sign(secret, []byte(payload)))
+
+	entry := &types.OutboundWebhookLog{
+		JobID:             job.ID,
+		OutboundWebhookID: webhook.ID,
+		StatusCode:        types.OutboundWebhookLogUnsentStatusCode,
+		Request: types.NewUnencryptedWebhookLogMessage(types.WebhookLogMessage{
+			Header:  req.Header,
+			Body:    []byte(payload),
+			Method:  req.Method,
+			URL:     url,
+			Version: req.Proto,
+		}),
+		Response: types.NewUnencryptedWebhookLogMessage(types.WebhookLogMessage{}),
+	}
+
+	deliveryErr := h.send(req, entry)
+	if deliveryErr != nil {
+		entry.Error = encryption.NewUnencrypted(deliveryErr.Error())
+	} else {
+		entry.Error = encryption.NewUnencrypted("")
+	}
+
+	// The delivery has already happened at this point, so failing to record
+	// it shouldn't cause the payload to be sent again.
+	if err := h.logs.Create(ctx, entry); err != nil {
+		logger.Warn("error recording outbound webhook delivery", log.Int64("jobID", job.ID), log.Error(err))
+	}
+
+	return deliveryErr
+}
+
+// send sends the request, updating the log entry with the response if one is
+// received. An error is returned if no response was received, or if the
+// response status code was not a 2XX.
+func (h *handler) send(req *http.Request, entry *types.OutboundWebhookLog) error {
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "sending request")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	if err != nil {
+		return errors.Wrap(err, "reading response body")
+	}
+
+	entry.StatusCode = resp.StatusCode
+	entry.Response = types.NewUnencryptedWebhookLogMessage(types.WebhookLogMessage{
+		Header: resp.Header,
+		Body:   body,
+	})
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Newf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
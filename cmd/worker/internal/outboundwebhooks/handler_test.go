@@ -0,0 +1,151 @@
+package outboundwebhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestHandler(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	payload := `{"eventType":"repo:cloned"}`
+
+	newJob := func() *types.OutboundWebhookJob {
+		return &types.OutboundWebhookJob{
+			ID:                42,
+			OutboundWebhookID: 1,
+			EventType:         outbound.EventTypeRepoCloned,
+			Payload:           encryption.NewUnencrypted(payload),
+		}
+	}
+
+	newStores := func(url string) (*database.MockOutboundWebhookStore, *database.MockOutboundWebhookLogStore) {
+		webhooks := database.NewMockOutboundWebhookStore()
+		webhooks.GetByIDFunc.SetDefaultReturn(&types.OutboundWebhook{
+			ID:     1,
+			URL:    encryption.NewUnencrypted(url),
+			Secret: encryption.NewUnencrypted("secret"),
+		}, nil)
+		return webhooks, database.NewMockOutboundWebhookLogStore()
+	}
+
+	t.Run("webhook not found", func(t *testing.T) {
+		webhooks, logs := newStores("")
+		webhooks.GetByIDFunc.SetDefaultReturn(nil, database.OutboundWebhookNotFoundErr{})
+
+		h := &handler{client: httpcli.ExternalDoer, webhooks: webhooks, logs: logs}
+		err := h.Handle(ctx, logger, newJob())
+		assert.Error(t, err)
+		assert.True(t, errcode.IsNonRetryable(err))
+		mockassert.NotCalled(t, logs.CreateFunc)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, payload, string(body))
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.Equal(t, outbound.EventTypeRepoCloned, r.Header.Get(outbound.EventTypeHeader))
+			assert.Equal(t, "42", r.Header.Get(outbound.DeliveryHeader))
+			assert.Equal(t, outbound.Sign("secret", body), r.Header.Get(outbound.SignatureHeader))
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		t.Cleanup(server.Close)
+
+		webhooks, logs := newStores(server.URL)
+		logs.CreateFunc.SetDefaultHook(func(ctx context.Context, log *types.OutboundWebhookLog) error {
+			assert.EqualValues(t, 42, log.JobID)
+			assert.EqualValues(t, 1, log.OutboundWebhookID)
+			assert.Equal(t, http.StatusNoContent, log.StatusCode)
+
+			message, err := log.Error.Decrypt(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, message)
+
+			request, err := log.Request.Decrypt(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, payload, string(request.Body))
+			return nil
+		})
+
+		h := &handler{client: server.Client(), webhooks: webhooks, logs: logs}
+		assert.NoError(t, h.Handle(ctx, logger, newJob()))
+		mockassert.CalledOnce(t, logs.CreateFunc)
+	})
+
+	t.Run("unexpected status code", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("oops"))
+		}))
+		t.Cleanup(server.Close)
+
+		webhooks, logs := newStores(server.URL)
+		logs.CreateFunc.SetDefaultHook(func(ctx context.Context, log *types.OutboundWebhookLog) error {
+			assert.Equal(t, http.StatusInternalServerError, log.StatusCode)
+
+			response, err := log.Response.Decrypt(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, "oops", string(response.Body))
+			return nil
+		})
+
+		h := &handler{client: server.Client(), webhooks: webhooks, logs: logs}
+		err := h.Handle(ctx, logger, newJob())
+		assert.Error(t, err)
+		assert.False(t, errcode.IsNonRetryable(err))
+		mockassert.CalledOnce(t, logs.CreateFunc)
+	})
+
+	t.Run("request error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		url := server.URL
+		server.Close()
+
+		webhooks, logs := newStores(url)
+		logs.CreateFunc.SetDefaultHook(func(ctx context.Context, log *types.OutboundWebhookLog) error {
+			assert.Equal(t, types.OutboundWebhookLogUnsentStatusCode, log.StatusCode)
+
+			message, err := log.Error.Decrypt(ctx)
+			require.NoError(t, err)
+			assert.NotEmpty(t, message)
+			return nil
+		})
+
+		h := &handler{client: http.DefaultClient, webhooks: webhooks, logs: logs}
+		assert.Error(t, h.Handle(ctx, logger, newJob()))
+		mockassert.CalledOnce(t, logs.CreateFunc)
+	})
+
+	t.Run("log error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		t.Cleanup(server.Close)
+
+		webhooks, logs := newStores(server.URL)
+		logs.CreateFunc.SetDefaultReturn(errors.New("log error"))
+
+		// The payload was delivered, so the job must not be retried.
+		h := &handler{client: server.Client(), webhooks: webhooks, logs: logs}
+		assert.NoError(t, h.Handle(ctx, logger, newJob()))
+	})
+}
@@ -0,0 +1,29 @@
+package outboundwebhooks
+
+import (
+	"context"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+// janitor deletes finished outbound webhook jobs, and with them their delivery
+// logs, once they are older than the retention period.
+type janitor struct {
+	store     database.OutboundWebhookJobStore
+	retention time.Duration
+}
+
+var _ goroutine.Handler = &janitor{}
+var _ goroutine.ErrorHandler = &janitor{}
+
+func (j *janitor) Handle(ctx context.Context) error {
+	return j.store.DeleteBefore(ctx, time.Now().Add(-j.retention))
+}
+
+func (j *janitor) HandleError(err error) {
+	log15.Error("error deleting stale outbound webhook jobs", "err", err)
+}
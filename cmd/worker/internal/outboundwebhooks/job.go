@@ -0,0 +1,145 @@
+package outboundwebhooks
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+// sender is a worker responsible for delivering queued outbound webhook jobs,
+// and for expunging finished jobs once they are no longer needed.
+type sender struct{}
+
+var _ job.Job = &sender{}
+
+func NewSender() job.Job {
+	return &sender{}
+}
+
+func (s *sender) Description() string {
+	return "Delivers outbound webhooks for events that occur on the instance."
+}
+
+func (s *sender) Config() []env.Config {
+	return []env.Config{ConfigInst}
+}
+
+func (s *sender) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	key := keyring.Default().OutboundWebhookKey
+	metrics := newMetrics(observationCtx)
+	rootContext := actor.WithInternalActor(context.Background())
+
+	return []goroutine.BackgroundRoutine{
+		newWorker(rootContext, observationCtx, db, key, ConfigInst, metrics),
+		newResetter(observationCtx, db, key, ConfigInst, metrics),
+		goroutine.NewPeriodicGoroutine(rootContext, "outbound-webhooks.janitor", "cleans up finished outbound webhook jobs",
+			1*time.Hour, &janitor{
+				store:     db.OutboundWebhookJobs(key),
+				retention: ConfigInst.Retention,
+			},
+		),
+	}, nil
+}
+
+func newWorker(ctx context.Context, observationCtx *observation.Context, db database.DB, key encryption.Key, cfg *config, metrics senderMetrics) *workerutil.Worker[*types.OutboundWebhookJob] {
+	observationCtx = observation.ContextWithLogger(observationCtx.Logger.Scoped("OutboundWebhookSender", ""), observationCtx)
+
+	options := workerutil.WorkerOptions{
+		Name:              "outbound_webhook_job_worker",
+		NumHandlers:       cfg.WorkerConcurrency,
+		Interval:          cfg.WorkerPollInterval,
+		HeartbeatInterval: 15 * time.Second,
+		Metrics:           metrics.workerMetrics,
+	}
+
+	return dbworker.NewWorker[*types.OutboundWebhookJob](ctx, createStore(observationCtx, db, key, cfg), &handler{
+		client:   httpcli.ExternalDoer,
+		webhooks: db.OutboundWebhooks(key),
+		logs:     db.OutboundWebhookLogs(key),
+	}, options)
+}
+
+func newResetter(observationCtx *observation.Context, db database.DB, key encryption.Key, cfg *config, metrics senderMetrics) *dbworker.Resetter[*types.OutboundWebhookJob] {
+	observationCtx = observation.ContextWithLogger(observationCtx.Logger.Scoped("OutboundWebhookResetter", ""), observationCtx)
+
+	options := dbworker.ResetterOptions{
+		Name:     "outbound_webhook_job_worker_resetter",
+		Interval: 1 * time.Minute,
+		Metrics: dbworker.ResetterMetrics{
+			Errors:              metrics.errors,
+			RecordResetFailures: metrics.resetFailures,
+			RecordResets:        metrics.resets,
+		},
+	}
+	return dbworker.NewResetter(observationCtx.Logger, createStore(observationCtx, db, key, cfg), options)
+}
+
+func createStore(observationCtx *observation.Context, s basestore.ShareableStore, key encryption.Key, cfg *config) dbworkerstore.Store[*types.OutboundWebhookJob] {
+	return dbworkerstore.New(observationCtx, s.Handle(), dbworkerstore.Options[*types.OutboundWebhookJob]{
+		Name:              "outbound_webhook_job_worker_store",
+		TableName:         "outbound_webhook_jobs",
+		ColumnExpressions: database.OutboundWebhookJobColumns,
+		Scan:              dbworkerstore.BuildWorkerScan(database.ScanOutboundWebhookJob(key)),
+		StalledMaxAge:     60 * time.Second,
+		RetryAfter:        cfg.WorkerRetryInterval,
+		MaxNumRetries:     cfg.WorkerMaxRetries,
+		OrderByExpression: sqlf.Sprintf("outbound_webhook_jobs.id"),
+	})
+}
+
+type senderMetrics struct {
+	workerMetrics workerutil.WorkerObservability
+	resets        prometheus.Counter
+	resetFailures prometheus.Counter
+	errors        prometheus.Counter
+}
+
+func newMetrics(observationCtx *observation.Context) senderMetrics {
+	resetFailures := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_outbound_webhook_job_reset_failures_total",
+		Help: "The number of reset failures.",
+	})
+	observationCtx.Registerer.MustRegister(resetFailures)
+
+	resets := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_outbound_webhook_job_resets_total",
+		Help: "The number of records reset.",
+	})
+	observationCtx.Registerer.MustRegister(resets)
+
+	errors := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_outbound_webhook_job_errors_total",
+		Help: "The number of errors that occur during job.",
+	})
+	observationCtx.Registerer.MustRegister(errors)
+
+	return senderMetrics{
+		workerMetrics: workerutil.NewMetrics(observationCtx, "outbound_webhook_jobs"),
+		resets:        resets,
+		resetFailures: resetFailures,
+		errors:        errors,
+	}
+}
@@ -49,11 +49,6 @@ var Mac = []category{
 				Check: checkAction(check.InPath("gfind")),
 				Fix:   cmdFix("brew install findutils"),
 			},
-			{
-				Name:  "comby",
-				Check: checkAction(check.InPath("comby")),
-				Fix:   cmdFix("brew install comby"),
-			},
 			{
 				Name:  "pcre",
 				Check: checkAction(check.InPath("pcregrep")),
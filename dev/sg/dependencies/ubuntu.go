@@ -65,12 +65,6 @@ var Ubuntu = []category{
 				Check: checkAction(check.InPath("curl")),
 				Fix:   aptGetInstall("curl"),
 			},
-			// Comby will fail systematically on linux/arm64 as there aren't binaries available for that platform.
-			{
-				Name:  "comby",
-				Check: checkAction(check.InPath("comby")),
-				Fix:   cmdFix(`bash <(curl -sL get-comby.netlify.app)`),
-			},
 			{
 				Name:  "bash",
 				Check: checkAction(check.CommandOutputContains("bash --version", "version 5")),
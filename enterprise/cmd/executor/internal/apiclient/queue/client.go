@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -95,6 +96,36 @@ func (c *Client) Dequeue(ctx context.Context, queueName string, job *executor.Jo
 	return c.client.DoAndDecode(ctx, req, &job)
 }
 
+// DequeueQueues dequeues a job from one of the given queues. The frontend tries the
+// queues in a random order weighted by their weight, falling back to the next queue
+// when one is empty. The queue the job was dequeued from is set on the job.
+func (c *Client) DequeueQueues(ctx context.Context, queues []executor.QueueWeight, job *executor.Job) (_ bool, err error) {
+	ctx, _, endObservation := c.operations.dequeueQueues.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.String("queueNames", queueWeightsToString(queues)),
+	}})
+	defer func() {
+		var fields []otlog.Field
+		if job != nil && job.Queue != "" {
+			fields = append(fields, otlog.String("dequeuedQueueName", job.Queue))
+		}
+		endObservation(1, observation.Args{LogFields: fields})
+	}()
+
+	req, err := c.client.NewJSONRequest(http.MethodPost, "dequeue", executor.DequeueRequest{
+		Version:      version.Version(),
+		ExecutorName: c.options.ExecutorName,
+		NumCPUs:      c.options.ResourceOptions.NumCPUs,
+		Memory:       c.options.ResourceOptions.Memory,
+		DiskSpace:    c.options.ResourceOptions.DiskSpace,
+		Queues:       queues,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return c.client.DoAndDecode(ctx, req, &job)
+}
+
 func (c *Client) AddExecutionLogEntry(ctx context.Context, queueName string, jobID int, entry workerutil.ExecutionLogEntry) (entryID int, err error) {
 	ctx, _, endObservation := c.operations.addExecutionLogEntry.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
 		otlog.String("queueName", queueName),
@@ -220,6 +251,24 @@ func (c *Client) Ping(ctx context.Context, queueName string, jobIDs []int) (err
 	return c.client.DoAndDrop(ctx, req)
 }
 
+// PingQueues checks that the frontend accepts heartbeats for the given queues.
+func (c *Client) PingQueues(ctx context.Context, queueNames []string) (err error) {
+	jobIDsByQueue := make(map[string][]int, len(queueNames))
+	for _, name := range queueNames {
+		jobIDsByQueue[name] = []int{}
+	}
+
+	req, err := c.client.NewJSONRequest(http.MethodPost, "heartbeat", executor.HeartbeatRequest{
+		ExecutorName:  c.options.ExecutorName,
+		JobIDsByQueue: jobIDsByQueue,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.client.DoAndDrop(ctx, req)
+}
+
 func (c *Client) Heartbeat(ctx context.Context, queueName string, jobIDs []int) (knownIDs, cancelIDs []int, err error) {
 	ctx, _, endObservation := c.operations.heartbeat.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
 		otlog.String("queueName", queueName),
@@ -227,29 +276,10 @@ func (c *Client) Heartbeat(ctx context.Context, queueName string, jobIDs []int)
 	}})
 	defer endObservation(1, observation.Args{})
 
-	metrics, err := gatherMetrics(c.logger, c.metricsGatherer)
-	if err != nil {
-		c.logger.Error("Failed to collect prometheus metrics for heartbeat", log.Error(err))
-		// Continue, no metric errors should prevent heartbeats.
-	}
-
-	req, err := c.client.NewJSONRequest(http.MethodPost, fmt.Sprintf("%s/heartbeat", queueName), executor.HeartbeatRequest{
-		// Request the new-fashioned payload.
-		Version: executor.ExecutorAPIVersion2,
-
-		ExecutorName: c.options.ExecutorName,
-		JobIDs:       jobIDs,
-
-		OS:              c.options.TelemetryOptions.OS,
-		Architecture:    c.options.TelemetryOptions.Architecture,
-		DockerVersion:   c.options.TelemetryOptions.DockerVersion,
-		ExecutorVersion: c.options.TelemetryOptions.ExecutorVersion,
-		GitVersion:      c.options.TelemetryOptions.GitVersion,
-		IgniteVersion:   c.options.TelemetryOptions.IgniteVersion,
-		SrcCliVersion:   c.options.TelemetryOptions.SrcCliVersion,
+	payload := c.newHeartbeatRequest()
+	payload.JobIDs = jobIDs
 
-		PrometheusMetrics: metrics,
-	})
+	req, err := c.client.NewJSONRequest(http.MethodPost, fmt.Sprintf("%s/heartbeat", queueName), payload)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -293,6 +323,74 @@ func (c *Client) Heartbeat(ctx context.Context, queueName string, jobIDs []int)
 	return respV1, cancelIDs, nil
 }
 
+// HeartbeatQueues sends a single heartbeat for the jobs of all given queues, and
+// returns the known and canceled job identifiers of each queue.
+func (c *Client) HeartbeatQueues(ctx context.Context, jobIDsByQueue map[string][]int) (knownIDs, cancelIDs map[string][]int, err error) {
+	queueNames := make([]string, 0, len(jobIDsByQueue))
+	for name := range jobIDsByQueue {
+		queueNames = append(queueNames, name)
+	}
+	sort.Strings(queueNames)
+
+	logFields := []otlog.Field{otlog.String("queueNames", strings.Join(queueNames, ", "))}
+	for _, name := range queueNames {
+		logFields = append(logFields, otlog.String(fmt.Sprintf("jobIDs.%s", name), intsToString(jobIDsByQueue[name])))
+	}
+	ctx, _, endObservation := c.operations.heartbeatQueues.With(ctx, &err, observation.Args{LogFields: logFields})
+	defer endObservation(1, observation.Args{})
+
+	payload := c.newHeartbeatRequest()
+	payload.JobIDsByQueue = jobIDsByQueue
+
+	req, err := c.client.NewJSONRequest(http.MethodPost, "heartbeat", payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var resp executor.HeartbeatResponse
+	if _, err := c.client.DoAndDecode(ctx, req, &resp); err != nil {
+		return nil, nil, err
+	}
+
+	return resp.KnownIDsByQueue, resp.CancelIDsByQueue, nil
+}
+
+// newHeartbeatRequest returns a heartbeat request carrying the telemetry and metrics
+// of this executor, but no job identifiers.
+func (c *Client) newHeartbeatRequest() executor.HeartbeatRequest {
+	metrics, err := gatherMetrics(c.logger, c.metricsGatherer)
+	if err != nil {
+		c.logger.Error("Failed to collect prometheus metrics for heartbeat", log.Error(err))
+		// Continue, no metric errors should prevent heartbeats.
+	}
+
+	return executor.HeartbeatRequest{
+		// Request the new-fashioned payload.
+		Version: executor.ExecutorAPIVersion2,
+
+		ExecutorName: c.options.ExecutorName,
+
+		OS:              c.options.TelemetryOptions.OS,
+		Architecture:    c.options.TelemetryOptions.Architecture,
+		DockerVersion:   c.options.TelemetryOptions.DockerVersion,
+		ExecutorVersion: c.options.TelemetryOptions.ExecutorVersion,
+		GitVersion:      c.options.TelemetryOptions.GitVersion,
+		IgniteVersion:   c.options.TelemetryOptions.IgniteVersion,
+		SrcCliVersion:   c.options.TelemetryOptions.SrcCliVersion,
+
+		PrometheusMetrics: metrics,
+	}
+}
+
+func queueWeightsToString(queues []executor.QueueWeight) string {
+	segments := make([]string, 0, len(queues))
+	for _, q := range queues {
+		segments = append(segments, fmt.Sprintf("%s:%d", q.Name, q.Weight))
+	}
+
+	return strings.Join(segments, ", ")
+}
+
 func intsToString(ints []int) string {
 	segments := make([]string, 0, len(ints))
 	for _, id := range ints {
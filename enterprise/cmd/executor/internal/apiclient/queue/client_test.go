@@ -420,6 +420,104 @@ func TestHeartbeatBadResponse(t *testing.T) {
 	})
 }
 
+func TestDequeueQueues(t *testing.T) {
+	spec := routeSpec{
+		expectedMethod:   "POST",
+		expectedPath:     "/.executors/queue/dequeue",
+		expectedUsername: "test",
+		expectedToken:    "hunter2",
+		expectedPayload: `{
+			"executorName": "deadbeef",
+			"version": "0.0.0+dev",
+			"queues": [{"name": "batches", "weight": 2}, {"name": "codeintel", "weight": 1}]
+		}`,
+		responseStatus:  http.StatusOK,
+		responsePayload: `{"id": 42, "queue": "codeintel"}`,
+	}
+
+	testRoute(t, spec, func(client *Client) {
+		var job executor.Job
+		queues := []executor.QueueWeight{{Name: "batches", Weight: 2}, {Name: "codeintel", Weight: 1}}
+		dequeued, err := client.DequeueQueues(context.Background(), queues, &job)
+		if err != nil {
+			t.Fatalf("unexpected error dequeueing record: %s", err)
+		}
+		if !dequeued {
+			t.Fatalf("expected record to be dequeued")
+		}
+		if job.ID != 42 || job.Queue != "codeintel" {
+			t.Errorf("unexpected job. want=%d/%s have=%d/%s", 42, "codeintel", job.ID, job.Queue)
+		}
+	})
+}
+
+func TestDequeueQueuesNoRecord(t *testing.T) {
+	spec := routeSpec{
+		expectedMethod:   "POST",
+		expectedPath:     "/.executors/queue/dequeue",
+		expectedUsername: "test",
+		expectedToken:    "hunter2",
+		expectedPayload:  `{"executorName": "deadbeef", "version": "0.0.0+dev", "queues": [{"name": "batches", "weight": 1}]}`,
+		responseStatus:   http.StatusNoContent,
+		responsePayload:  ``,
+	}
+
+	testRoute(t, spec, func(client *Client) {
+		dequeued, err := client.DequeueQueues(context.Background(), []executor.QueueWeight{{Name: "batches", Weight: 1}}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error dequeueing record: %s", err)
+		}
+		if dequeued {
+			t.Fatalf("did not expect a record to be dequeued")
+		}
+	})
+}
+
+func TestHeartbeatQueues(t *testing.T) {
+	spec := routeSpec{
+		expectedMethod:   "POST",
+		expectedPath:     "/.executors/queue/heartbeat",
+		expectedUsername: "test",
+		expectedToken:    "hunter2",
+		expectedPayload: `{
+			"executorName": "deadbeef",
+			"jobIds": null,
+			"jobIdsByQueue": {"batches": [1], "codeintel": [1, 2]},
+			"version": "V2",
+
+			"os": "test-os",
+			"architecture": "test-architecture",
+			"dockerVersion": "test-docker-version",
+			"executorVersion": "test-executor-version",
+			"gitVersion": "test-git-version",
+			"igniteVersion": "test-ignite-version",
+			"srcCliVersion": "test-src-cli-version",
+
+			"prometheusMetrics": ""
+		}`,
+		responseStatus:  http.StatusOK,
+		responsePayload: `{"knownIdsByQueue": {"batches": [1], "codeintel": [2]}, "cancelIdsByQueue": {"codeintel": [2]}}`,
+	}
+
+	testRoute(t, spec, func(client *Client) {
+		knownIDs, cancelIDs, err := client.HeartbeatQueues(context.Background(), map[string][]int{
+			"batches":   {1},
+			"codeintel": {1, 2},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error performing heartbeat: %s", err)
+		}
+
+		if diff := cmp.Diff(map[string][]int{"batches": {1}, "codeintel": {2}}, knownIDs); diff != "" {
+			t.Errorf("unexpected known ids (-want +got):\n%s", diff)
+		}
+
+		if diff := cmp.Diff(map[string][]int{"codeintel": {2}}, cancelIDs); diff != "" {
+			t.Errorf("unexpected cancel ids (-want +got):\n%s", diff)
+		}
+	})
+}
+
 type routeSpec struct {
 	expectedMethod   string
 	expectedPath     string
@@ -9,12 +9,14 @@ import (
 
 type operations struct {
 	dequeue                 *observation.Operation
+	dequeueQueues           *observation.Operation
 	addExecutionLogEntry    *observation.Operation
 	updateExecutionLogEntry *observation.Operation
 	markComplete            *observation.Operation
 	markErrored             *observation.Operation
 	markFailed              *observation.Operation
 	heartbeat               *observation.Operation
+	heartbeatQueues         *observation.Operation
 }
 
 func newOperations(observationCtx *observation.Context) *operations {
@@ -35,11 +37,13 @@ func newOperations(observationCtx *observation.Context) *operations {
 
 	return &operations{
 		dequeue:                 op("Dequeue"),
+		dequeueQueues:           op("DequeueQueues"),
 		addExecutionLogEntry:    op("AddExecutionLogEntry"),
 		updateExecutionLogEntry: op("UpdateExecutionLogEntry"),
 		markComplete:            op("MarkComplete"),
 		markErrored:             op("MarkErrored"),
 		markFailed:              op("MarkFailed"),
 		heartbeat:               op("Heartbeat"),
+		heartbeatQueues:         op("HeartbeatQueues"),
 	}
 }
@@ -0,0 +1,330 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/inconshreveable/log15"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// kubernetesContainerName is the name of the single container in the pod of a job.
+	kubernetesContainerName = "sg-executor-job"
+
+	// kubernetesVolumeName is the name of the volume holding the workspace in the pod
+	// of a job.
+	kubernetesVolumeName = "sg-executor-job-volume"
+
+	// kubernetesJobLabel is the label set on the jobs created by the executor. Its
+	// value is the name of the executor job the Kubernetes job belongs to.
+	kubernetesJobLabel = "executor.sourcegraph.com/name"
+
+	// defaultKubernetesPollInterval is the interval at which the status of the pod
+	// of a job is checked while waiting for it to start or finish.
+	defaultKubernetesPollInterval = time.Second
+)
+
+// kubernetesFailedWaitingReasons are the reasons for which a container can be
+// waiting that it won't recover from without changes to the job, such as an image
+// that doesn't exist. Pods with such a container are failed immediately instead of
+// waiting for them to start until the pending timeout.
+var kubernetesFailedWaitingReasons = map[string]struct{}{
+	"ErrImagePull":               {},
+	"ImagePullBackOff":           {},
+	"ErrImageNeverPull":          {},
+	"InvalidImageName":           {},
+	"CreateContainerConfigError": {},
+	"CreateContainerError":       {},
+}
+
+// newKubernetesClientset creates a client for the cluster configured in the given
+// options, or for the cluster the executor runs in if no kubeconfig is given.
+func newKubernetesClientset(options KubernetesOptions) (kubernetes.Interface, error) {
+	var (
+		config *rest.Config
+		err    error
+	)
+	if options.ConfigPath != "" {
+		config, err = clientcmd.BuildConfigFromFlags("", options.ConfigPath)
+	} else {
+		config, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return kubernetes.NewForConfig(config)
+}
+
+// newKubernetesJob constructs the Kubernetes job that runs the given spec. The pod
+// of the job mounts the workspace directory from the persistent volume claim that
+// is shared with the executor, and is subject to the resource limits specified in
+// the given options.
+func newKubernetesJob(name string, spec CommandSpec, dir string, options Options) (*batchv1.Job, error) {
+	subPath, err := filepath.Rel(options.KubernetesOptions.WorkspaceMountPath, dir)
+	if err != nil || subPath == ".." || strings.HasPrefix(subPath, "../") {
+		return nil, errors.Newf("workspace %q is not on the persistent volume mounted at %q", dir, options.KubernetesOptions.WorkspaceMountPath)
+	}
+
+	resources, err := kubernetesResources(options.ResourceOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	backoffLimit := int32(0)
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: options.KubernetesOptions.Namespace,
+			Labels:    map[string]string{kubernetesJobLabel: options.ExecutorName},
+		},
+		Spec: batchv1.JobSpec{
+			// Failed commands fail the executor job, so they are never retried.
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{kubernetesJobLabel: options.ExecutorName},
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:       kubernetesContainerName,
+							Image:      spec.Image,
+							Command:    []string{"/bin/sh", filepath.Join("/data", ScriptsPath, spec.ScriptPath)},
+							WorkingDir: filepath.Join("/data", spec.Dir),
+							Env:        kubernetesEnv(spec.Env),
+							Resources:  resources,
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      kubernetesVolumeName,
+									MountPath: "/data",
+									SubPath:   subPath,
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: kubernetesVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: options.KubernetesOptions.PersistentVolumeClaimName,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// kubernetesResources returns the resource requests and limits of the container
+// of a job. Like with docker, a value of zero sets no resource bound.
+func kubernetesResources(options ResourceOptions) (corev1.ResourceRequirements, error) {
+	resources := corev1.ResourceList{}
+	if options.NumCPUs != 0 {
+		resources[corev1.ResourceCPU] = *resource.NewQuantity(int64(options.NumCPUs), resource.DecimalSI)
+	}
+	if options.Memory != "0" && options.Memory != "" {
+		memory, err := datasize.ParseString(options.Memory)
+		if err != nil {
+			return corev1.ResourceRequirements{}, errors.Wrapf(err, "invalid memory %q", options.Memory)
+		}
+		resources[corev1.ResourceMemory] = *resource.NewQuantity(int64(memory.Bytes()), resource.BinarySI)
+	}
+
+	if len(resources) == 0 {
+		return corev1.ResourceRequirements{}, nil
+	}
+	return corev1.ResourceRequirements{Requests: resources, Limits: resources}, nil
+}
+
+func kubernetesEnv(env []string) []corev1.EnvVar {
+	vars := make([]corev1.EnvVar, 0, len(env))
+	for _, e := range env {
+		name, value, _ := strings.Cut(e, "=")
+		vars = append(vars, corev1.EnvVar{Name: name, Value: value})
+	}
+	return vars
+}
+
+// runKubernetesJob creates the given job and waits for its pod to finish. The logs
+// of the pod are written to the given logger as they are produced. The job fails if
+// its pod doesn't start within the given pending timeout, unless it is zero.
+func runKubernetesJob(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	job *batchv1.Job,
+	spec CommandSpec,
+	logger Logger,
+	pollInterval time.Duration,
+	pendingTimeout time.Duration,
+) (err error) {
+	ctx, _, endObservation := spec.Operation.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	log15.Info(fmt.Sprintf("Running kubernetes job: %s", job.Name))
+
+	if _, err := clientset.BatchV1().Jobs(job.Namespace).Create(ctx, job, metav1.CreateOptions{}); err != nil {
+		return errors.Wrap(err, "creating job")
+	}
+
+	handle := logger.Log(spec.Key, job.Spec.Template.Spec.Containers[0].Command)
+	defer handle.Close()
+
+	// The log entry is finalized as failed, unless the pod ran to completion.
+	exitCode := 1
+	defer func() { handle.Finalize(exitCode) }()
+
+	pendingCtx := ctx
+	if pendingTimeout > 0 {
+		var cancel context.CancelFunc
+		pendingCtx, cancel = context.WithTimeout(ctx, pendingTimeout)
+		defer cancel()
+	}
+	pod, err := waitForKubernetesPod(pendingCtx, clientset, job, pollInterval, func(pod *corev1.Pod) bool {
+		return pod.Status.Phase != corev1.PodPending
+	})
+	if err != nil {
+		if ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+			return errors.Newf("pod of job %q did not start within %s", job.Name, pendingTimeout)
+		}
+		return err
+	}
+
+	// Kubernetes doesn't separate the output streams of a container, so all of it is
+	// logged as stdout.
+	stream, err := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: kubernetesContainerName,
+		Follow:    true,
+	}).Stream(ctx)
+	if err != nil {
+		return errors.Wrap(err, "streaming pod logs")
+	}
+	defer stream.Close()
+	if err := readIntoBuf(handle, "stdout", stream); err != nil {
+		return errors.Wrap(err, "reading pod logs")
+	}
+
+	pod, err = waitForKubernetesPod(ctx, clientset, job, pollInterval, func(pod *corev1.Pod) bool {
+		return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+	})
+	if err != nil {
+		return err
+	}
+
+	exitCode = kubernetesExitCode(pod)
+	if exitCode != 0 {
+		return errors.New("command failed")
+	}
+	return nil
+}
+
+// waitForKubernetesPod polls the pod of the given job until the given condition
+// holds for it, or the context is canceled. An error is returned as soon as the
+// container of the pod is waiting for one of kubernetesFailedWaitingReasons.
+func waitForKubernetesPod(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	job *batchv1.Job,
+	pollInterval time.Duration,
+	condition func(pod *corev1.Pod) bool,
+) (*corev1.Pod, error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		pods, err := clientset.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: "job-name=" + job.Name,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "listing pods of job")
+		}
+		for i := range pods.Items {
+			if err := kubernetesWaitingError(&pods.Items[i]); err != nil {
+				return nil, err
+			}
+			if condition(&pods.Items[i]) {
+				return &pods.Items[i], nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// kubernetesWaitingError returns an error describing why the container of the
+// given pod can't start, if it is waiting for one of kubernetesFailedWaitingReasons.
+func kubernetesWaitingError(pod *corev1.Pod) error {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != kubernetesContainerName || status.State.Waiting == nil {
+			continue
+		}
+		waiting := status.State.Waiting
+		if _, ok := kubernetesFailedWaitingReasons[waiting.Reason]; ok {
+			if waiting.Message == "" {
+				return errors.Newf("pod %q failed to start: %s", pod.Name, waiting.Reason)
+			}
+			return errors.Newf("pod %q failed to start: %s: %s", pod.Name, waiting.Reason, waiting.Message)
+		}
+	}
+	return nil
+}
+
+// kubernetesExitCode returns the exit code of the container of the given finished
+// pod. A pod that failed without its container terminating, for example because it
+// was evicted, is treated like a command that exited with status 1.
+func kubernetesExitCode(pod *corev1.Pod) int {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == kubernetesContainerName && status.State.Terminated != nil {
+			return int(status.State.Terminated.ExitCode)
+		}
+	}
+
+	if pod.Status.Phase == corev1.PodFailed {
+		return 1
+	}
+	return 0
+}
+
+// deleteKubernetesJobs deletes the given jobs along with their pods. Jobs that no
+// longer exist are ignored.
+func deleteKubernetesJobs(ctx context.Context, clientset kubernetes.Interface, namespace string, names []string) (err error) {
+	if clientset == nil {
+		return nil
+	}
+
+	propagationPolicy := metav1.DeletePropagationBackground
+	for _, name := range names {
+		deleteErr := clientset.BatchV1().Jobs(namespace).Delete(ctx, name, metav1.DeleteOptions{
+			PropagationPolicy: &propagationPolicy,
+		})
+		if deleteErr != nil && !apierrors.IsNotFound(deleteErr) {
+			err = errors.Append(err, errors.Wrapf(deleteErr, "deleting job %q", name))
+		}
+	}
+
+	return err
+}
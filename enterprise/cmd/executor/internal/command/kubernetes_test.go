@@ -0,0 +1,313 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+)
+
+func TestNewKubernetesJob(t *testing.T) {
+	job, err := newKubernetesJob(
+		"executor-deadbeef-0",
+		CommandSpec{
+			Image:      "alpine:latest",
+			ScriptPath: "myscript.sh",
+			Dir:        "subdir",
+			Env: []string{
+				`TEST=true`,
+				`CONTAINS_WHITESPACE=yes it does`,
+			},
+			Operation: makeTestOperation(),
+		},
+		"/data/workspace-42-123",
+		Options{
+			ExecutorName: "executor-deadbeef",
+			KubernetesOptions: KubernetesOptions{
+				Enabled:                   true,
+				Namespace:                 "executors",
+				PersistentVolumeClaimName: "executor-workspaces",
+				WorkspaceMountPath:        "/data",
+			},
+			ResourceOptions: ResourceOptions{
+				NumCPUs: 4,
+				Memory:  "20G",
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if job.Name != "executor-deadbeef-0" || job.Namespace != "executors" {
+		t.Errorf("unexpected job name: %s/%s", job.Namespace, job.Name)
+	}
+	if *job.Spec.BackoffLimit != 0 {
+		t.Errorf("unexpected backoff limit: %d", *job.Spec.BackoffLimit)
+	}
+
+	podSpec := job.Spec.Template.Spec
+	if podSpec.RestartPolicy != corev1.RestartPolicyNever {
+		t.Errorf("unexpected restart policy: %s", podSpec.RestartPolicy)
+	}
+
+	resources := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("4"),
+		corev1.ResourceMemory: resource.MustParse("20Gi"),
+	}
+	expectedContainers := []corev1.Container{
+		{
+			Name:       "sg-executor-job",
+			Image:      "alpine:latest",
+			Command:    []string{"/bin/sh", "/data/.sourcegraph-executor/myscript.sh"},
+			WorkingDir: "/data/subdir",
+			Env: []corev1.EnvVar{
+				{Name: "TEST", Value: "true"},
+				{Name: "CONTAINS_WHITESPACE", Value: "yes it does"},
+			},
+			Resources: corev1.ResourceRequirements{Requests: resources, Limits: resources},
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: "sg-executor-job-volume", MountPath: "/data", SubPath: "workspace-42-123"},
+			},
+		},
+	}
+	quantityComparer := cmp.Comparer(func(x, y resource.Quantity) bool { return x.Cmp(y) == 0 })
+	if diff := cmp.Diff(expectedContainers, podSpec.Containers, quantityComparer); diff != "" {
+		t.Errorf("unexpected containers (-want +got):\n%s", diff)
+	}
+
+	expectedVolumes := []corev1.Volume{
+		{
+			Name: "sg-executor-job-volume",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "executor-workspaces"},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedVolumes, podSpec.Volumes); diff != "" {
+		t.Errorf("unexpected volumes (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewKubernetesJobWithoutResourceAllocation(t *testing.T) {
+	job, err := newKubernetesJob(
+		"executor-deadbeef-0",
+		CommandSpec{Image: "alpine:latest", ScriptPath: "myscript.sh"},
+		"/data/workspace-42-123",
+		Options{
+			KubernetesOptions: KubernetesOptions{WorkspaceMountPath: "/data"},
+			ResourceOptions:   ResourceOptions{NumCPUs: 0, Memory: "0"},
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(corev1.ResourceRequirements{}, job.Spec.Template.Spec.Containers[0].Resources); diff != "" {
+		t.Errorf("unexpected resources (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewKubernetesJobWorkspaceNotOnVolume(t *testing.T) {
+	_, err := newKubernetesJob(
+		"executor-deadbeef-0",
+		CommandSpec{Image: "alpine:latest", ScriptPath: "myscript.sh"},
+		"/tmp/workspace-42-123",
+		Options{KubernetesOptions: KubernetesOptions{WorkspaceMountPath: "/data"}},
+	)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestKubernetesRunner(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		exitCode     int32
+		phase        corev1.PodPhase
+		wantErr      bool
+		wantExitCode int
+	}{
+		{name: "success", exitCode: 0, phase: corev1.PodSucceeded, wantExitCode: 0},
+		{name: "failure", exitCode: 2, phase: corev1.PodFailed, wantErr: true, wantExitCode: 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset()
+			// Stand in for the job controller, which creates a pod for each job.
+			clientset.PrependReactor("create", "jobs", func(action ktesting.Action) (bool, runtime.Object, error) {
+				job := action.(ktesting.CreateAction).GetObject().(*batchv1.Job)
+				pod := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      job.Name + "-abcde",
+						Namespace: job.Namespace,
+						Labels:    map[string]string{"job-name": job.Name},
+					},
+					Status: corev1.PodStatus{
+						Phase: tc.phase,
+						ContainerStatuses: []corev1.ContainerStatus{
+							{
+								Name: "sg-executor-job",
+								State: corev1.ContainerState{
+									Terminated: &corev1.ContainerStateTerminated{ExitCode: tc.exitCode},
+								},
+							},
+						},
+					},
+				}
+				return false, nil, clientset.Tracker().Add(pod)
+			})
+
+			var buf bytes.Buffer
+			logEntry := NewMockLogEntry()
+			logEntry.WriteFunc.SetDefaultHook(buf.Write)
+			logger := NewMockLogger()
+			logger.LogFunc.SetDefaultReturn(logEntry)
+
+			runner := &kubernetesRunner{
+				name:   "executor-deadbeef",
+				dir:    "/data/workspace-42-123",
+				logger: logger,
+				options: Options{
+					ExecutorName: "executor-deadbeef",
+					KubernetesOptions: KubernetesOptions{
+						Enabled:            true,
+						Namespace:          "executors",
+						WorkspaceMountPath: "/data",
+					},
+				},
+				clientset:    clientset,
+				pollInterval: time.Millisecond,
+			}
+
+			ctx := context.Background()
+			if err := runner.Setup(ctx); err != nil {
+				t.Fatalf("unexpected error setting up runner: %s", err)
+			}
+
+			err := runner.Run(ctx, CommandSpec{
+				Key:        "step.docker.0",
+				Image:      "alpine:latest",
+				ScriptPath: "myscript.sh",
+				Operation:  makeTestOperation(),
+			})
+			if tc.wantErr && err == nil {
+				t.Fatal("expected an error")
+			} else if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected error running command: %s", err)
+			}
+
+			if _, err := clientset.BatchV1().Jobs("executors").Get(ctx, "executor-deadbeef-0", metav1.GetOptions{}); err != nil {
+				t.Fatalf("job not created: %s", err)
+			}
+			if have, want := buf.String(), "stdout: fake logs\n"; have != want {
+				t.Errorf("unexpected log output: want=%q have=%q", want, have)
+			}
+			if history := logEntry.FinalizeFunc.History(); len(history) != 1 || history[0].Arg0 != tc.wantExitCode {
+				t.Errorf("unexpected calls to Finalize: %+v", history)
+			}
+
+			if err := runner.Teardown(ctx); err != nil {
+				t.Fatalf("unexpected error tearing down runner: %s", err)
+			}
+			jobs, err := clientset.BatchV1().Jobs("executors").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(jobs.Items) != 0 {
+				t.Errorf("expected jobs to be deleted, have %d", len(jobs.Items))
+			}
+		})
+	}
+}
+
+func TestKubernetesRunnerPodFailsToStart(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		waiting        *corev1.ContainerStateWaiting
+		pendingTimeout time.Duration
+		wantErr        string
+	}{
+		{
+			name:    "image pull error",
+			waiting: &corev1.ContainerStateWaiting{Reason: "ErrImagePull", Message: `pull access denied for "alpine:nope"`},
+			wantErr: `pod "executor-deadbeef-0-abcde" failed to start: ErrImagePull: pull access denied for "alpine:nope"`,
+		},
+		{
+			name:    "image pull back-off",
+			waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"},
+			wantErr: `pod "executor-deadbeef-0-abcde" failed to start: ImagePullBackOff`,
+		},
+		{
+			name:           "pending timeout",
+			waiting:        &corev1.ContainerStateWaiting{Reason: "ContainerCreating"},
+			pendingTimeout: 10 * time.Millisecond,
+			wantErr:        `pod of job "executor-deadbeef-0" did not start within 10ms`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset()
+			clientset.PrependReactor("create", "jobs", func(action ktesting.Action) (bool, runtime.Object, error) {
+				job := action.(ktesting.CreateAction).GetObject().(*batchv1.Job)
+				pod := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      job.Name + "-abcde",
+						Namespace: job.Namespace,
+						Labels:    map[string]string{"job-name": job.Name},
+					},
+					Status: corev1.PodStatus{
+						Phase: corev1.PodPending,
+						ContainerStatuses: []corev1.ContainerStatus{
+							{Name: "sg-executor-job", State: corev1.ContainerState{Waiting: tc.waiting}},
+						},
+					},
+				}
+				return false, nil, clientset.Tracker().Add(pod)
+			})
+
+			logEntry := NewMockLogEntry()
+			logger := NewMockLogger()
+			logger.LogFunc.SetDefaultReturn(logEntry)
+
+			runner := &kubernetesRunner{
+				name:   "executor-deadbeef",
+				dir:    "/data/workspace-42-123",
+				logger: logger,
+				options: Options{
+					KubernetesOptions: KubernetesOptions{
+						Enabled:            true,
+						Namespace:          "executors",
+						WorkspaceMountPath: "/data",
+						PendingTimeout:     tc.pendingTimeout,
+					},
+				},
+				clientset:    clientset,
+				pollInterval: time.Millisecond,
+			}
+
+			err := runner.Run(context.Background(), CommandSpec{
+				Key:        "step.docker.0",
+				Image:      "alpine:nope",
+				ScriptPath: "myscript.sh",
+				Operation:  makeTestOperation(),
+			})
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if have := err.Error(); have != tc.wantErr {
+				t.Errorf("unexpected error: want=%q have=%q", tc.wantErr, have)
+			}
+			if history := logEntry.FinalizeFunc.History(); len(history) != 1 || history[0].Arg0 == 0 {
+				t.Errorf("unexpected calls to Finalize: %+v", history)
+			}
+		})
+	}
+}
@@ -148,32 +148,34 @@ var forwardedHostEnvVars = []string{"HOME", "PATH", "USER", "DOCKER_HOST"}
 func readProcessPipes(logWriter io.WriteCloser, stdout, stderr io.Reader) *errgroup.Group {
 	eg := &errgroup.Group{}
 
-	readIntoBuf := func(prefix string, r io.Reader) error {
-		scanner := bufio.NewScanner(r)
-		// Allocate an initial buffer of 4k.
-		buf := make([]byte, 4*1024)
-		// And set the maximum size used to buffer a token to 100M.
-		// TODO: Tweak this value as needed.
-		scanner.Buffer(buf, 100*1024*1024)
-		for scanner.Scan() {
-			_, err := fmt.Fprintf(logWriter, "%s: %s\n", prefix, scanner.Text())
-			if err != nil {
-				return err
-			}
-		}
-		return scanner.Err()
-	}
-
 	eg.Go(func() error {
-		return readIntoBuf("stdout", stdout)
+		return readIntoBuf(logWriter, "stdout", stdout)
 	})
 	eg.Go(func() error {
-		return readIntoBuf("stderr", stderr)
+		return readIntoBuf(logWriter, "stderr", stderr)
 	})
 
 	return eg
 }
 
+// readIntoBuf writes each line read from r to the given writer, prefixed with
+// the name of the stream it was read from.
+func readIntoBuf(w io.Writer, prefix string, r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	// Allocate an initial buffer of 4k.
+	buf := make([]byte, 4*1024)
+	// And set the maximum size used to buffer a token to 100M.
+	// TODO: Tweak this value as needed.
+	scanner.Buffer(buf, 100*1024*1024)
+	for scanner.Scan() {
+		_, err := fmt.Fprintf(w, "%s: %s\n", prefix, scanner.Text())
+		if err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
 // monitorCommand starts the given command and waits for the given errgroup to complete.
 // This function returns a non-nil error only if there was a system issue - commands that
 // run but fail due to a non-zero exit code will return a nil error and the exit code.
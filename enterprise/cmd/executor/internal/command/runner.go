@@ -2,7 +2,11 @@ package command
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -43,6 +47,9 @@ type Options struct {
 	// FirecrackerOptions configures the behavior of Firecracker virtual machine creation.
 	FirecrackerOptions FirecrackerOptions
 
+	// KubernetesOptions configures the behavior of Kubernetes job creation.
+	KubernetesOptions KubernetesOptions
+
 	// ResourceOptions configures the resource limits of docker container and Firecracker
 	// virtual machines running on the executor.
 	ResourceOptions ResourceOptions
@@ -73,6 +80,31 @@ type FirecrackerOptions struct {
 	DockerRegistryMirrorURLs []string
 }
 
+type KubernetesOptions struct {
+	// Enabled determines if commands will be run in Kubernetes jobs.
+	Enabled bool
+
+	// ConfigPath is the path to a kubeconfig file used to connect to the cluster.
+	// When empty, the in-cluster configuration of the executor pod is used.
+	ConfigPath string
+
+	// Namespace is the namespace in which jobs are created.
+	Namespace string
+
+	// PersistentVolumeClaimName is the name of the persistent volume claim shared
+	// by the executor and the pods of its jobs. Workspaces are created on it, and
+	// mounted into the pods of the jobs running in them.
+	PersistentVolumeClaimName string
+
+	// WorkspaceMountPath is the path at which the persistent volume claim is mounted
+	// in the executor pod.
+	WorkspaceMountPath string
+
+	// PendingTimeout is the maximum time the pod of a job may be pending before the
+	// job is failed. A value of zero waits indefinitely.
+	PendingTimeout time.Duration
+}
+
 type ResourceOptions struct {
 	// NumCPUs is the number of virtual CPUs a container or VM can use.
 	NumCPUs int
@@ -101,6 +133,16 @@ type ResourceOptions struct {
 
 // NewRunner creates a new runner with the given options.
 func NewRunner(dir string, logger Logger, options Options, operations *Operations) Runner {
+	if options.KubernetesOptions.Enabled {
+		return &kubernetesRunner{
+			name:         options.ExecutorName,
+			dir:          dir,
+			logger:       logger,
+			options:      options,
+			pollInterval: defaultKubernetesPollInterval,
+		}
+	}
+
 	if !options.FirecrackerOptions.Enabled {
 		return &dockerRunner{dir: dir, logger: logger, options: options}
 	}
@@ -164,6 +206,52 @@ func (r *firecrackerRunner) Run(ctx context.Context, command CommandSpec) error
 	return runCommand(ctx, formatFirecrackerCommand(command, r.name, r.options), r.logger)
 }
 
+type kubernetesRunner struct {
+	name    string
+	dir     string
+	logger  Logger
+	options Options
+	// clientset is created in Setup, unless it has been set already.
+	clientset kubernetes.Interface
+	// jobNames are the names of the jobs created by Run, deleted in Teardown.
+	jobNames     []string
+	pollInterval time.Duration
+}
+
+var _ Runner = &kubernetesRunner{}
+
+func (r *kubernetesRunner) Setup(ctx context.Context) error {
+	if r.clientset != nil {
+		return nil
+	}
+
+	clientset, err := newKubernetesClientset(r.options.KubernetesOptions)
+	if err != nil {
+		return errors.Wrap(err, "failed to create kubernetes client")
+	}
+	r.clientset = clientset
+	return nil
+}
+
+func (r *kubernetesRunner) Teardown(ctx context.Context) error {
+	return deleteKubernetesJobs(ctx, r.clientset, r.options.KubernetesOptions.Namespace, r.jobNames)
+}
+
+func (r *kubernetesRunner) Run(ctx context.Context, command CommandSpec) error {
+	// Commands without an image, such as src-cli steps, are run on the host.
+	if command.Image == "" {
+		return runCommand(ctx, formatRawOrDockerCommand(command, r.dir, r.options), r.logger)
+	}
+
+	job, err := newKubernetesJob(fmt.Sprintf("%s-%d", r.name, len(r.jobNames)), command, r.dir, r.options)
+	if err != nil {
+		return err
+	}
+	r.jobNames = append(r.jobNames, job.Name)
+
+	return runKubernetesJob(ctx, r.clientset, job, command, r.logger, r.pollInterval, r.options.KubernetesOptions.PendingTimeout)
+}
+
 type runnerWrapper struct{}
 
 var defaultRunner = &runnerWrapper{}
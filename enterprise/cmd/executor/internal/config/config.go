@@ -3,11 +3,13 @@ package config
 import (
 	"runtime"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/c2h5oh/datasize"
 	"github.com/google/uuid"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/hostname"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -19,6 +21,7 @@ type Config struct {
 	FrontendURL                   string
 	FrontendAuthorizationToken    string
 	QueueName                     string
+	Queues                        []executor.QueueWeight
 	QueuePollInterval             time.Duration
 	MaximumNumJobs                int
 	FirecrackerImage              string
@@ -29,6 +32,12 @@ type Config struct {
 	KeepWorkspaces                bool
 	DockerHostMountPath           string
 	UseFirecracker                bool
+	UseKubernetes                 bool
+	KubernetesConfigPath          string
+	KubernetesNamespace           string
+	KubernetesVolumeClaimName     string
+	KubernetesWorkspaceMountPath  string
+	KubernetesPodPendingTimeout   time.Duration
 	JobNumCPUs                    int
 	JobMemory                     string
 	FirecrackerDiskSpace          string
@@ -47,10 +56,23 @@ type Config struct {
 func (c *Config) Load() {
 	c.FrontendURL = c.Get("EXECUTOR_FRONTEND_URL", "", "The external URL of the sourcegraph instance.")
 	c.FrontendAuthorizationToken = c.Get("EXECUTOR_FRONTEND_PASSWORD", "", "The authorization token supplied to the frontend.")
-	c.QueueName = c.Get("EXECUTOR_QUEUE_NAME", "", "The name of the queue to listen to.")
+	c.QueueName = c.GetOptional("EXECUTOR_QUEUE_NAME", "The name of the queue to listen to.")
+	if queueNames := c.GetOptional("EXECUTOR_QUEUE_NAMES", "A comma-separated list of queues to listen to instead of EXECUTOR_QUEUE_NAME, each optionally followed by a colon and its weight (e.g. batches:2,codeintel:1)."); queueNames != "" {
+		queues, err := parseQueues(queueNames)
+		if err != nil {
+			c.AddError(errors.Wrap(err, "invalid value for EXECUTOR_QUEUE_NAMES"))
+		}
+		c.Queues = queues
+	}
 	c.QueuePollInterval = c.GetInterval("EXECUTOR_QUEUE_POLL_INTERVAL", "1s", "Interval between dequeue requests.")
 	c.MaximumNumJobs = c.GetInt("EXECUTOR_MAXIMUM_NUM_JOBS", "1", "Number of virtual machines or containers that can be running at once.")
 	c.UseFirecracker = c.GetBool("EXECUTOR_USE_FIRECRACKER", strconv.FormatBool(runtime.GOOS == "linux"), "Whether to isolate commands in virtual machines. Requires ignite and firecracker. Linux hosts only.")
+	c.UseKubernetes = c.GetBool("EXECUTOR_USE_KUBERNETES", "false", "Whether to run commands in Kubernetes jobs. Requires the executor to run in a pod with a persistent volume claim mounted, and EXECUTOR_USE_FIRECRACKER to be disabled.")
+	c.KubernetesConfigPath = c.GetOptional("EXECUTOR_KUBERNETES_CONFIG_PATH", "The path to the kubeconfig file used to create Kubernetes jobs. Defaults to the in-cluster configuration.")
+	c.KubernetesNamespace = c.Get("EXECUTOR_KUBERNETES_NAMESPACE", "default", "The namespace in which Kubernetes jobs are created.")
+	c.KubernetesVolumeClaimName = c.GetOptional("EXECUTOR_KUBERNETES_PERSISTENT_VOLUME_CLAIM_NAME", "The name of the persistent volume claim on which workspaces are created and shared with Kubernetes jobs.")
+	c.KubernetesWorkspaceMountPath = c.Get("EXECUTOR_KUBERNETES_WORKSPACE_MOUNT_PATH", "/data", "The path at which the persistent volume claim is mounted in the executor pod.")
+	c.KubernetesPodPendingTimeout = c.GetInterval("EXECUTOR_KUBERNETES_POD_PENDING_TIMEOUT", "5m", "The maximum time the pod of a Kubernetes job may be pending, for example while its image is pulled or it waits to be scheduled, before the job is failed.")
 	c.FirecrackerImage = c.Get("EXECUTOR_FIRECRACKER_IMAGE", DefaultFirecrackerImage, "The base image to use for virtual machines.")
 	c.FirecrackerKernelImage = c.Get("EXECUTOR_FIRECRACKER_KERNEL_IMAGE", DefaultFirecrackerKernelImage, "The base image containing the kernel binary to use for virtual machines.")
 	c.FirecrackerSandboxImage = c.Get("EXECUTOR_FIRECRACKER_SANDBOX_IMAGE", DefaultFirecrackerSandboxImage, "The OCI image for the ignite VM sandbox.")
@@ -77,9 +99,29 @@ func (c *Config) Load() {
 }
 
 func (c *Config) Validate() error {
-	if c.QueueName != "" && c.QueueName != "batches" && c.QueueName != "codeintel" {
+	if c.QueueName != "" && len(c.Queues) != 0 {
+		c.AddError(errors.New("EXECUTOR_QUEUE_NAME and EXECUTOR_QUEUE_NAMES cannot both be set"))
+	} else if c.QueueName == "" && len(c.Queues) == 0 {
+		c.AddError(errors.New("one of EXECUTOR_QUEUE_NAME or EXECUTOR_QUEUE_NAMES must be set"))
+	}
+
+	if c.QueueName != "" && !isValidQueueName(c.QueueName) {
 		c.AddError(errors.New("EXECUTOR_QUEUE_NAME must be set to 'batches' or 'codeintel'"))
 	}
+	for _, q := range c.Queues {
+		if !isValidQueueName(q.Name) {
+			c.AddError(errors.Newf("EXECUTOR_QUEUE_NAMES must only contain 'batches' or 'codeintel', got %q", q.Name))
+		}
+	}
+
+	if c.UseKubernetes {
+		if c.UseFirecracker {
+			c.AddError(errors.New("EXECUTOR_USE_KUBERNETES and EXECUTOR_USE_FIRECRACKER cannot both be enabled"))
+		}
+		if c.KubernetesVolumeClaimName == "" {
+			c.AddError(errors.New("EXECUTOR_KUBERNETES_PERSISTENT_VOLUME_CLAIM_NAME is required when EXECUTOR_USE_KUBERNETES is enabled"))
+		}
+	}
 
 	if c.UseFirecracker {
 		// Validate that firecracker can work on this host.
@@ -104,3 +146,32 @@ func (c *Config) Validate() error {
 
 	return c.BaseConfig.Validate()
 }
+
+func isValidQueueName(name string) bool {
+	return name == "batches" || name == "codeintel"
+}
+
+// parseQueues parses a comma-separated list of queue names, each optionally followed
+// by a colon and its weight. Queues without a weight have a weight of 1.
+func parseQueues(value string) ([]executor.QueueWeight, error) {
+	var queues []executor.QueueWeight
+	seen := map[string]struct{}{}
+	for _, part := range strings.Split(value, ",") {
+		name, weight, hasWeight := strings.Cut(strings.TrimSpace(part), ":")
+		q := executor.QueueWeight{Name: name, Weight: 1}
+		if hasWeight {
+			w, err := strconv.Atoi(weight)
+			if err != nil || w < 1 {
+				return nil, errors.Newf("weight of queue %q must be a positive integer", name)
+			}
+			q.Weight = w
+		}
+		if _, ok := seen[name]; ok {
+			return nil, errors.Newf("queue %q is listed more than once", name)
+		}
+		seen[name] = struct{}{}
+		queues = append(queues, q)
+	}
+
+	return queues, nil
+}
@@ -95,8 +95,10 @@ func apiWorkerOptions(c *config.Config, queueTelemetryOptions queue.TelemetryOpt
 		VMPrefix:           c.VMPrefix,
 		KeepWorkspaces:     c.KeepWorkspaces,
 		QueueName:          c.QueueName,
+		Queues:             c.Queues,
 		WorkerOptions:      workerOptions(c),
 		FirecrackerOptions: firecrackerOptions(c),
+		KubernetesOptions:  kubernetesOptions(c),
 		ResourceOptions:    resourceOptions(c),
 		GitServicePath:     "/.executors/git",
 		QueueOptions:       queueOptions(c, queueTelemetryOptions),
@@ -114,11 +116,11 @@ func apiWorkerOptions(c *config.Config, queueTelemetryOptions queue.TelemetryOpt
 
 func workerOptions(c *config.Config) workerutil.WorkerOptions {
 	return workerutil.WorkerOptions{
-		Name:                 fmt.Sprintf("executor_%s_worker", c.QueueName),
+		Name:                 fmt.Sprintf("executor_%s_worker", queueLabel(c)),
 		NumHandlers:          c.MaximumNumJobs,
 		Interval:             c.QueuePollInterval,
 		HeartbeatInterval:    5 * time.Second,
-		Metrics:              makeWorkerMetrics(c.QueueName),
+		Metrics:              makeWorkerMetrics(queueLabel(c)),
 		NumTotalJobs:         c.NumTotalJobs,
 		MaxActiveTime:        c.MaxActiveTime,
 		WorkerHostname:       c.WorkerHostname,
@@ -126,6 +128,20 @@ func workerOptions(c *config.Config) workerutil.WorkerOptions {
 	}
 }
 
+// queueLabel returns the name of the queue the executor processes jobs from, or the
+// names of all of its queues joined by underscores.
+func queueLabel(c *config.Config) string {
+	if len(c.Queues) == 0 {
+		return c.QueueName
+	}
+
+	names := make([]string, 0, len(c.Queues))
+	for _, q := range c.Queues {
+		names = append(names, q.Name)
+	}
+	return strings.Join(names, "_")
+}
+
 func firecrackerOptions(c *config.Config) command.FirecrackerOptions {
 	dockerMirrors := []string{}
 	if len(c.DockerRegistryMirrorURL) > 0 {
@@ -141,6 +157,17 @@ func firecrackerOptions(c *config.Config) command.FirecrackerOptions {
 	}
 }
 
+func kubernetesOptions(c *config.Config) command.KubernetesOptions {
+	return command.KubernetesOptions{
+		Enabled:                   c.UseKubernetes,
+		ConfigPath:                c.KubernetesConfigPath,
+		Namespace:                 c.KubernetesNamespace,
+		PersistentVolumeClaimName: c.KubernetesVolumeClaimName,
+		WorkspaceMountPath:        c.KubernetesWorkspaceMountPath,
+		PendingTimeout:            c.KubernetesPodPendingTimeout,
+	}
+}
+
 func resourceOptions(c *config.Config) command.ResourceOptions {
 	return command.ResourceOptions{
 		NumCPUs:             c.JobNumCPUs,
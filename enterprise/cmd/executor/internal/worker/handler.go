@@ -64,6 +64,7 @@ func (h *handler) PreDequeue(ctx context.Context, logger log.Logger) (dequeueabl
 func (h *handler) Handle(ctx context.Context, logger log.Logger, job executor.Job) (err error) {
 	logger = logger.With(
 		log.Int("jobID", job.ID),
+		log.String("queue", job.Queue),
 		log.String("repositoryName", job.RepositoryName),
 		log.String("commit", job.Commit))
 
@@ -119,6 +120,7 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, job executor.Jo
 	options := command.Options{
 		ExecutorName:       name,
 		FirecrackerOptions: h.options.FirecrackerOptions,
+		KubernetesOptions:  h.options.KubernetesOptions,
 		ResourceOptions:    h.options.ResourceOptions,
 	}
 	runner := h.runnerFactory(workspace.Path(), commandLogger, options, h.operations)
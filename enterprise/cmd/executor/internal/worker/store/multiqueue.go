@@ -0,0 +1,165 @@
+package store
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// MultiQueueShim wraps MultiQueueStore to implement workerutil.Store for jobs
+// dequeued from multiple queues.
+//
+// Jobs of different queues can share identifiers, while the worker tracks running
+// jobs by their identifier. The shim therefore hands out jobs with an identifier
+// that is unique to this executor, and translates it back to the queue and the
+// identifier of the job when talking to the queue API.
+type MultiQueueShim struct {
+	Queues []executor.QueueWeight
+	Store  MultiQueueStore
+
+	mu     sync.Mutex
+	lastID int
+	jobs   map[int]queueJob
+}
+
+type MultiQueueStore interface {
+	QueueStore
+	DequeueQueues(ctx context.Context, queues []executor.QueueWeight, payload *executor.Job) (bool, error)
+	HeartbeatQueues(ctx context.Context, jobIDsByQueue map[string][]int) (knownIDs, cancelIDs map[string][]int, err error)
+}
+
+// queueJob identifies a job by the queue it was dequeued from and its identifier
+// within that queue.
+type queueJob struct {
+	queue string
+	id    int
+}
+
+// Compile time validation.
+var _ workerutil.Store[executor.Job] = &MultiQueueShim{}
+
+func (s *MultiQueueShim) QueuedCount(ctx context.Context) (int, error) {
+	return 0, errors.New("unimplemented")
+}
+
+func (s *MultiQueueShim) Dequeue(ctx context.Context, workerHostname string, extraArguments any) (executor.Job, bool, error) {
+	var job executor.Job
+	dequeued, err := s.Store.DequeueQueues(ctx, s.Queues, &job)
+	if err != nil || !dequeued {
+		return executor.Job{}, false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.jobs == nil {
+		s.jobs = map[int]queueJob{}
+	}
+	s.lastID++
+	s.jobs[s.lastID] = queueJob{queue: job.Queue, id: job.ID}
+	job.ID = s.lastID
+
+	return job, true, nil
+}
+
+func (s *MultiQueueShim) Heartbeat(ctx context.Context, ids []int) (knownIDs, cancelIDs []int, err error) {
+	jobIDsByQueue := make(map[string][]int, len(s.Queues))
+	for _, q := range s.Queues {
+		jobIDsByQueue[q.Name] = []int{}
+	}
+	localIDs := make(map[queueJob]int, len(ids))
+
+	s.mu.Lock()
+	for _, id := range ids {
+		if j, ok := s.jobs[id]; ok {
+			jobIDsByQueue[j.queue] = append(jobIDsByQueue[j.queue], j.id)
+			localIDs[j] = id
+		}
+	}
+	s.mu.Unlock()
+
+	knownIDsByQueue, cancelIDsByQueue, err := s.Store.HeartbeatQueues(ctx, jobIDsByQueue)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	toLocalIDs := func(idsByQueue map[string][]int) []int {
+		var localIDsOut []int
+		for queue, ids := range idsByQueue {
+			for _, id := range ids {
+				if localID, ok := localIDs[queueJob{queue: queue, id: id}]; ok {
+					localIDsOut = append(localIDsOut, localID)
+				}
+			}
+		}
+		return localIDsOut
+	}
+
+	return toLocalIDs(knownIDsByQueue), toLocalIDs(cancelIDsByQueue), nil
+}
+
+func (s *MultiQueueShim) AddExecutionLogEntry(ctx context.Context, id int, entry workerutil.ExecutionLogEntry) (int, error) {
+	j, err := s.lookup(id)
+	if err != nil {
+		return 0, err
+	}
+	return s.Store.AddExecutionLogEntry(ctx, j.queue, j.id, entry)
+}
+
+func (s *MultiQueueShim) UpdateExecutionLogEntry(ctx context.Context, jobID, entryID int, entry workerutil.ExecutionLogEntry) error {
+	j, err := s.lookup(jobID)
+	if err != nil {
+		return err
+	}
+	return s.Store.UpdateExecutionLogEntry(ctx, j.queue, j.id, entryID, entry)
+}
+
+func (s *MultiQueueShim) MarkComplete(ctx context.Context, id int) (bool, error) {
+	j, err := s.lookup(id)
+	if err != nil {
+		return false, err
+	}
+	defer s.forget(id)
+	return true, s.Store.MarkComplete(ctx, j.queue, j.id)
+}
+
+func (s *MultiQueueShim) MarkErrored(ctx context.Context, id int, errorMessage string) (bool, error) {
+	j, err := s.lookup(id)
+	if err != nil {
+		return false, err
+	}
+	defer s.forget(id)
+	return true, s.Store.MarkErrored(ctx, j.queue, j.id, errorMessage)
+}
+
+func (s *MultiQueueShim) MarkFailed(ctx context.Context, id int, errorMessage string) (bool, error) {
+	j, err := s.lookup(id)
+	if err != nil {
+		return false, err
+	}
+	defer s.forget(id)
+	return true, s.Store.MarkFailed(ctx, j.queue, j.id, errorMessage)
+}
+
+// lookup returns the queue and identifier of the job with the given local identifier.
+func (s *MultiQueueShim) lookup(id int) (queueJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.jobs[id]
+	if !ok {
+		return queueJob{}, errors.Newf("unknown job %d", id)
+	}
+	return j, nil
+}
+
+// forget drops the job with the given local identifier once it has finished.
+func (s *MultiQueueShim) forget(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.jobs, id)
+}
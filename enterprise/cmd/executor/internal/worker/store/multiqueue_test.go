@@ -0,0 +1,151 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+var testQueues = []executor.QueueWeight{
+	{Name: "batches", Weight: 2},
+	{Name: "codeintel", Weight: 1},
+}
+
+func TestMultiQueueShim_Dequeue(t *testing.T) {
+	queueStore := new(multiQueueStoreMock)
+	shim := &store.MultiQueueShim{Queues: testQueues, Store: queueStore}
+
+	// Both queues hand out a job with the same identifier.
+	queueStore.On("DequeueQueues", mock.Anything, testQueues, mock.Anything).
+		Run(func(args mock.Arguments) { *args.Get(2).(*executor.Job) = executor.Job{ID: 42, Queue: "batches"} }).
+		Return(true, nil).Once()
+	queueStore.On("DequeueQueues", mock.Anything, testQueues, mock.Anything).
+		Run(func(args mock.Arguments) { *args.Get(2).(*executor.Job) = executor.Job{ID: 42, Queue: "codeintel"} }).
+		Return(true, nil).Once()
+
+	first, dequeued, err := shim.Dequeue(context.Background(), "host-name", nil)
+	require.NoError(t, err)
+	assert.True(t, dequeued)
+	second, dequeued, err := shim.Dequeue(context.Background(), "host-name", nil)
+	require.NoError(t, err)
+	assert.True(t, dequeued)
+
+	assert.Equal(t, "batches", first.Queue)
+	assert.Equal(t, "codeintel", second.Queue)
+	assert.NotEqual(t, first.ID, second.ID)
+
+	queueStore.On("MarkComplete", mock.Anything, "batches", 42).Return(nil)
+	queueStore.On("MarkFailed", mock.Anything, "codeintel", 42, "failed").Return(nil)
+
+	_, err = shim.MarkComplete(context.Background(), first.ID)
+	require.NoError(t, err)
+	_, err = shim.MarkFailed(context.Background(), second.ID, "failed")
+	require.NoError(t, err)
+
+	// Finished jobs are no longer known to the shim.
+	_, err = shim.MarkComplete(context.Background(), first.ID)
+	assert.Error(t, err)
+
+	mock.AssertExpectationsForObjects(t, queueStore)
+}
+
+func TestMultiQueueShim_DequeueNoRecord(t *testing.T) {
+	queueStore := new(multiQueueStoreMock)
+	shim := &store.MultiQueueShim{Queues: testQueues, Store: queueStore}
+
+	queueStore.On("DequeueQueues", mock.Anything, testQueues, mock.Anything).Return(false, nil)
+
+	_, dequeued, err := shim.Dequeue(context.Background(), "host-name", nil)
+	require.NoError(t, err)
+	assert.False(t, dequeued)
+
+	mock.AssertExpectationsForObjects(t, queueStore)
+}
+
+func TestMultiQueueShim_ExecutionLogEntries(t *testing.T) {
+	queueStore := new(multiQueueStoreMock)
+	shim := &store.MultiQueueShim{Queues: testQueues, Store: queueStore}
+
+	queueStore.On("DequeueQueues", mock.Anything, testQueues, mock.Anything).
+		Run(func(args mock.Arguments) { *args.Get(2).(*executor.Job) = executor.Job{ID: 42, Queue: "codeintel"} }).
+		Return(true, nil)
+	job, _, err := shim.Dequeue(context.Background(), "host-name", nil)
+	require.NoError(t, err)
+
+	entry := workerutil.ExecutionLogEntry{Key: "step.1"}
+	queueStore.On("AddExecutionLogEntry", mock.Anything, "codeintel", 42, entry).Return(7, nil)
+	queueStore.On("UpdateExecutionLogEntry", mock.Anything, "codeintel", 42, 7, entry).Return(nil)
+
+	entryID, err := shim.AddExecutionLogEntry(context.Background(), job.ID, entry)
+	require.NoError(t, err)
+	assert.Equal(t, 7, entryID)
+	require.NoError(t, shim.UpdateExecutionLogEntry(context.Background(), job.ID, entryID, entry))
+
+	_, err = shim.AddExecutionLogEntry(context.Background(), job.ID+1, entry)
+	assert.Error(t, err)
+
+	mock.AssertExpectationsForObjects(t, queueStore)
+}
+
+func TestMultiQueueShim_Heartbeat(t *testing.T) {
+	queueStore := new(multiQueueStoreMock)
+	shim := &store.MultiQueueShim{Queues: testQueues, Store: queueStore}
+
+	queueStore.On("DequeueQueues", mock.Anything, testQueues, mock.Anything).
+		Run(func(args mock.Arguments) { *args.Get(2).(*executor.Job) = executor.Job{ID: 42, Queue: "batches"} }).
+		Return(true, nil).Once()
+	queueStore.On("DequeueQueues", mock.Anything, testQueues, mock.Anything).
+		Run(func(args mock.Arguments) { *args.Get(2).(*executor.Job) = executor.Job{ID: 42, Queue: "codeintel"} }).
+		Return(true, nil).Once()
+	first, _, err := shim.Dequeue(context.Background(), "host-name", nil)
+	require.NoError(t, err)
+	second, _, err := shim.Dequeue(context.Background(), "host-name", nil)
+	require.NoError(t, err)
+
+	queueStore.On("HeartbeatQueues", mock.Anything, map[string][]int{"batches": {42}, "codeintel": {42}}).
+		Return(map[string][]int{"batches": {42}, "codeintel": {42}}, map[string][]int{"codeintel": {42}}, nil)
+
+	knownIDs, cancelIDs, err := shim.Heartbeat(context.Background(), []int{first.ID, second.ID})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int{first.ID, second.ID}, knownIDs)
+	assert.Equal(t, []int{second.ID}, cancelIDs)
+
+	mock.AssertExpectationsForObjects(t, queueStore)
+}
+
+func TestMultiQueueShim_HeartbeatNoJobs(t *testing.T) {
+	queueStore := new(multiQueueStoreMock)
+	shim := &store.MultiQueueShim{Queues: testQueues, Store: queueStore}
+
+	// Every queue is heartbeated, even without jobs.
+	queueStore.On("HeartbeatQueues", mock.Anything, map[string][]int{"batches": {}, "codeintel": {}}).
+		Return(map[string][]int{}, map[string][]int{}, nil)
+
+	knownIDs, cancelIDs, err := shim.Heartbeat(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, knownIDs)
+	assert.Empty(t, cancelIDs)
+
+	mock.AssertExpectationsForObjects(t, queueStore)
+}
+
+type multiQueueStoreMock struct {
+	queueStoreMock
+}
+
+func (m *multiQueueStoreMock) DequeueQueues(ctx context.Context, queues []executor.QueueWeight, payload *executor.Job) (bool, error) {
+	args := m.Called(ctx, queues, payload)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *multiQueueStoreMock) HeartbeatQueues(ctx context.Context, jobIDsByQueue map[string][]int) (knownIDs, cancelIDs map[string][]int, err error) {
+	args := m.Called(ctx, jobIDsByQueue)
+	return args.Get(0).(map[string][]int), args.Get(1).(map[string][]int), args.Error(2)
+}
@@ -41,6 +41,11 @@ type Options struct {
 	// horizontal scaling factors while still uniformly processing events.
 	QueueName string
 
+	// Queues are the queues to process work from along with their weights, used
+	// instead of QueueName when set. Jobs are dequeued from a queue picked at random
+	// in proportion to its weight, falling back to the other queues when it is empty.
+	Queues []executor.QueueWeight
+
 	// GitServicePath is the path to the internal git service API proxy in the frontend.
 	// This path should contain the endpoints info/refs and git-upload-pack.
 	GitServicePath string
@@ -63,6 +68,9 @@ type Options struct {
 	// FirecrackerOptions configures the behavior of Firecracker virtual machine creation.
 	FirecrackerOptions command.FirecrackerOptions
 
+	// KubernetesOptions configures the behavior of Kubernetes job creation.
+	KubernetesOptions command.KubernetesOptions
+
 	// ResourceOptions configures the resource limits of docker container and Firecracker
 	// virtual machines running on the executor.
 	ResourceOptions command.ResourceOptions
@@ -89,7 +97,13 @@ func NewWorker(observationCtx *observation.Context, nameSet *janitor.NameSet, op
 	if err != nil {
 		return nil, errors.Wrap(err, "building files store")
 	}
-	shim := &store.QueueShim{Name: options.QueueName, Store: queueStore}
+
+	var shim workerutil.Store[executor.Job]
+	if len(options.Queues) > 0 {
+		shim = &store.MultiQueueShim{Queues: options.Queues, Store: queueStore}
+	} else {
+		shim = &store.QueueShim{Name: options.QueueName, Store: queueStore}
+	}
 
 	if !connectToFrontend(observationCtx.Logger, queueStore, options) {
 		os.Exit(1)
@@ -125,7 +139,7 @@ func connectToFrontend(logger log.Logger, queueStore *queue.Client, options Opti
 	defer signal.Stop(signals)
 
 	for {
-		err := queueStore.Ping(context.Background(), options.QueueName, nil)
+		err := ping(context.Background(), queueStore, options)
 		if err == nil {
 			logger.Debug("Connected to Sourcegraph instance")
 			return true
@@ -148,3 +162,16 @@ func connectToFrontend(logger log.Logger, queueStore *queue.Client, options Opti
 		}
 	}
 }
+
+// ping sends an empty heartbeat for the queues of the executor.
+func ping(ctx context.Context, queueStore *queue.Client, options Options) error {
+	if len(options.Queues) == 0 {
+		return queueStore.Ping(ctx, options.QueueName, nil)
+	}
+
+	queueNames := make([]string, 0, len(options.Queues))
+	for _, q := range options.Queues {
+		queueNames = append(queueNames, q.Name)
+	}
+	return queueStore.PingQueues(ctx, queueNames)
+}
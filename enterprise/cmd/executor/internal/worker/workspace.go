@@ -36,6 +36,23 @@ func (h *handler) prepareWorkspace(
 		)
 	}
 
+	if h.options.KubernetesOptions.Enabled {
+		return workspace.NewKubernetesWorkspace(
+			ctx,
+			h.filesStore,
+			job,
+			h.options.KubernetesOptions.WorkspaceMountPath,
+			commandRunner,
+			commandLogger,
+			workspace.CloneOptions{
+				EndpointURL:    h.options.QueueOptions.BaseClientOptions.EndpointOptions.URL,
+				GitServicePath: h.options.GitServicePath,
+				ExecutorToken:  h.options.QueueOptions.BaseClientOptions.EndpointOptions.Token,
+			},
+			h.operations,
+		)
+	}
+
 	return workspace.NewDockerWorkspace(
 		ctx,
 		h.filesStore,
@@ -27,6 +27,21 @@ func NewDockerWorkspace(
 		return nil, err
 	}
 
+	return newHostWorkspace(ctx, workspaceDir, filesStore, job, commandRunner, logger, cloneOpts, operations)
+}
+
+// newHostWorkspace clones the repo and puts the script files into the given
+// directory on the host. The directory is removed if that fails.
+func newHostWorkspace(
+	ctx context.Context,
+	workspaceDir string,
+	filesStore store.FilesStore,
+	job executor.Job,
+	commandRunner command.Runner,
+	logger command.Logger,
+	cloneOpts CloneOptions,
+	operations *command.Operations,
+) (Workspace, error) {
 	if job.RepositoryName != "" {
 		if err := cloneRepo(ctx, workspaceDir, job, commandRunner, cloneOpts, operations); err != nil {
 			_ = os.RemoveAll(workspaceDir)
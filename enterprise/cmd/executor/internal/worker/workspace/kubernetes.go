@@ -0,0 +1,33 @@
+package workspace
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/command"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
+)
+
+// NewKubernetesWorkspace creates a new workspace for Kubernetes-based execution. A
+// directory on the persistent volume mounted at mountPath will be used to set up
+// the workspace, clone the repo and put script files, so that the pods of the jobs
+// can mount it as well.
+func NewKubernetesWorkspace(
+	ctx context.Context,
+	filesStore store.FilesStore,
+	job executor.Job,
+	mountPath string,
+	commandRunner command.Runner,
+	logger command.Logger,
+	cloneOpts CloneOptions,
+	operations *command.Operations,
+) (Workspace, error) {
+	workspaceDir, err := os.MkdirTemp(mountPath, "workspace-"+strconv.Itoa(job.ID)+"-*")
+	if err != nil {
+		return nil, err
+	}
+
+	return newHostWorkspace(ctx, workspaceDir, filesStore, job, commandRunner, logger, cloneOpts, operations)
+}
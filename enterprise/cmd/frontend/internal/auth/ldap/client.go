@@ -0,0 +1,179 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// dialTimeout bounds how long we wait for the LDAP server on each operation.
+const dialTimeout = 10 * time.Second
+
+// errInvalidCredentials is returned by authenticate when the username is
+// unknown or the password is wrong. The two cases are deliberately not
+// distinguished.
+var errInvalidCredentials = errors.New("invalid username or password")
+
+// entry is the directory entry of an authenticated user.
+type entry struct {
+	DN          string   `json:"dn"`
+	Username    string   `json:"username"`
+	Email       string   `json:"email"`
+	DisplayName string   `json:"displayName"`
+	Groups      []string `json:"groups,omitempty"`
+}
+
+// authenticate verifies the given credentials against the LDAP server by
+// binding as the user, and returns the user's directory entry along with the
+// names of the groups the user is a member of.
+//
+// 🚨 SECURITY: The caller must only treat the user as authenticated if the
+// returned error is nil.
+func (p *Provider) authenticate(username, password string) (*entry, error) {
+	// 🚨 SECURITY: Many LDAP servers treat a bind with an empty password as an
+	// unauthenticated bind that always succeeds, so it must never be attempted.
+	if username == "" || password == "" {
+		return nil, errInvalidCredentials
+	}
+
+	conn, err := p.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := p.bindService(conn); err != nil {
+		return nil, err
+	}
+
+	filter := strings.ReplaceAll(p.config.UserFilter, "{username}", goldap.EscapeFilter(username))
+	res, err := conn.Search(goldap.NewSearchRequest(
+		p.config.UserBaseDN,
+		goldap.ScopeWholeSubtree, goldap.NeverDerefAliases,
+		2, int(dialTimeout.Seconds()), false,
+		filter,
+		[]string{p.config.UsernameAttribute, p.config.EmailAttribute, p.config.DisplayNameAttribute},
+		nil,
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, "searching for user")
+	}
+	switch len(res.Entries) {
+	case 0:
+		return nil, errInvalidCredentials
+	case 1:
+	default:
+		return nil, errors.Errorf("user filter %q matched more than one entry", filter)
+	}
+	e := res.Entries[0]
+
+	if err := conn.Bind(e.DN, password); err != nil {
+		if goldap.IsErrorWithCode(err, goldap.LDAPResultInvalidCredentials) {
+			return nil, errInvalidCredentials
+		}
+		return nil, errors.Wrap(err, "binding as user")
+	}
+
+	u := &entry{
+		DN:          e.DN,
+		Username:    e.GetEqualFoldAttributeValue(p.config.UsernameAttribute),
+		Email:       e.GetEqualFoldAttributeValue(p.config.EmailAttribute),
+		DisplayName: e.GetEqualFoldAttributeValue(p.config.DisplayNameAttribute),
+	}
+	if u.Username == "" {
+		u.Username = username
+	}
+
+	if p.config.GroupBaseDN != "" {
+		// Groups are looked up with the service account, since users are often
+		// not allowed to read group entries themselves.
+		if err := p.bindService(conn); err != nil {
+			return nil, err
+		}
+		if u.Groups, err = p.searchGroups(conn, u.DN, username); err != nil {
+			return nil, err
+		}
+	}
+	return u, nil
+}
+
+// searchGroups returns the names of the groups matched by the group filter for
+// the given user.
+func (p *Provider) searchGroups(conn *goldap.Conn, dn, username string) ([]string, error) {
+	filter := strings.NewReplacer(
+		"{dn}", goldap.EscapeFilter(dn),
+		"{username}", goldap.EscapeFilter(username),
+	).Replace(p.config.GroupFilter)
+
+	res, err := conn.Search(goldap.NewSearchRequest(
+		p.config.GroupBaseDN,
+		goldap.ScopeWholeSubtree, goldap.NeverDerefAliases,
+		0, int(dialTimeout.Seconds()), false,
+		filter,
+		[]string{p.config.GroupNameAttribute},
+		nil,
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, "searching for groups")
+	}
+
+	groups := make([]string, 0, len(res.Entries))
+	for _, e := range res.Entries {
+		if name := e.GetEqualFoldAttributeValue(p.config.GroupNameAttribute); name != "" {
+			groups = append(groups, name)
+		}
+	}
+	return groups, nil
+}
+
+// bindService binds with the configured service account, if any. Otherwise
+// the connection is left anonymous.
+func (p *Provider) bindService(conn *goldap.Conn) error {
+	if p.config.BindDN == "" {
+		return nil
+	}
+	if err := conn.Bind(p.config.BindDN, p.config.BindPassword); err != nil {
+		return errors.Wrap(err, "binding with bindDN")
+	}
+	return nil
+}
+
+func (p *Provider) dial() (*goldap.Conn, error) {
+	u, err := url.Parse(p.config.Url)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing LDAP URL")
+	}
+
+	tlsConfig := &tls.Config{ServerName: u.Hostname()}
+	if p.config.Certificate != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(p.config.Certificate)) {
+			return nil, errors.New("invalid LDAP certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	conn, err := goldap.DialURL(p.config.Url,
+		goldap.DialWithDialer(&net.Dialer{Timeout: dialTimeout}),
+		goldap.DialWithTLSConfig(tlsConfig),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "connecting to LDAP server")
+	}
+	conn.SetTimeout(dialTimeout)
+
+	if p.config.StartTLS && u.Scheme == "ldap" {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, errors.Wrap(err, "starting TLS")
+		}
+	}
+	return conn, nil
+}
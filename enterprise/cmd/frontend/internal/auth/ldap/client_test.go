@@ -0,0 +1,115 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func newTestProvider(t *testing.T) *Provider {
+	url := newTestServer(t,
+		testEntry{
+			dn:       "cn=sourcegraph,dc=example,dc=com",
+			password: "service-password",
+		},
+		testEntry{
+			dn:       "uid=alice,ou=people,dc=example,dc=com",
+			password: "alice-password",
+			attrs: map[string][]string{
+				"objectClass": {"person"},
+				"uid":         {"alice"},
+				"mail":        {"alice@example.com"},
+				"cn":          {"Alice Smith"},
+			},
+		},
+		testEntry{
+			dn:       "uid=bob,ou=people,dc=example,dc=com",
+			password: "bob-password",
+			attrs: map[string][]string{
+				"objectClass": {"person"},
+				"uid":         {"bob"},
+				"mail":        {"bob@example.com"},
+			},
+		},
+		testEntry{
+			dn: "cn=engineering,ou=groups,dc=example,dc=com",
+			attrs: map[string][]string{
+				"objectClass": {"groupOfNames"},
+				"cn":          {"engineering"},
+				"member":      {"uid=alice,ou=people,dc=example,dc=com", "uid=bob,ou=people,dc=example,dc=com"},
+			},
+		},
+		testEntry{
+			dn: "cn=admins,ou=groups,dc=example,dc=com",
+			attrs: map[string][]string{
+				"objectClass": {"groupOfNames"},
+				"cn":          {"admins"},
+				"member":      {"uid=alice,ou=people,dc=example,dc=com"},
+			},
+		},
+	)
+	return NewProvider(schema.LDAPAuthProvider{
+		Type:         providerType,
+		Url:          url,
+		BindDN:       "cn=sourcegraph,dc=example,dc=com",
+		BindPassword: "service-password",
+		UserBaseDN:   "ou=people,dc=example,dc=com",
+		UserFilter:   "(&(objectClass=person)(uid={username}))",
+		GroupBaseDN:  "ou=groups,dc=example,dc=com",
+	})
+}
+
+func TestAuthenticate(t *testing.T) {
+	p := newTestProvider(t)
+
+	t.Run("success", func(t *testing.T) {
+		e, err := p.authenticate("alice", "alice-password")
+		require.NoError(t, err)
+		assert.Equal(t, &entry{
+			DN:          "uid=alice,ou=people,dc=example,dc=com",
+			Username:    "alice",
+			Email:       "alice@example.com",
+			DisplayName: "Alice Smith",
+			Groups:      []string{"engineering", "admins"},
+		}, e)
+	})
+
+	t.Run("custom attributes", func(t *testing.T) {
+		p := NewProvider(p.config)
+		p.config.UsernameAttribute = "mail"
+		p.config.DisplayNameAttribute = "uid"
+		p.config.GroupFilter = "(&(objectClass=groupOfNames)(member={dn})(!(cn=admins)))"
+
+		e, err := p.authenticate("alice", "alice-password")
+		require.NoError(t, err)
+		assert.Equal(t, "alice@example.com", e.Username)
+		assert.Equal(t, "alice", e.DisplayName)
+		assert.Equal(t, []string{"engineering"}, e.Groups)
+	})
+
+	for name, creds := range map[string][2]string{
+		"wrong password":          {"alice", "bob-password"},
+		"unknown user":            {"carol", "alice-password"},
+		"empty password":          {"alice", ""},
+		"filter injection":        {"*", "alice-password"},
+		"other user's password":   {"bob", "alice-password"},
+		"service account as user": {"sourcegraph", "service-password"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := p.authenticate(creds[0], creds[1])
+			assert.ErrorIs(t, err, errInvalidCredentials)
+		})
+	}
+
+	t.Run("wrong bind password", func(t *testing.T) {
+		p := NewProvider(p.config)
+		p.config.BindPassword = "wrong"
+
+		_, err := p.authenticate("alice", "alice-password")
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, errInvalidCredentials)
+	})
+}
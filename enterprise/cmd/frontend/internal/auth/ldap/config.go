@@ -0,0 +1,114 @@
+package ldap
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const pkgName = "ldap"
+
+func Init() {
+	conf.ContributeValidator(validateConfig)
+
+	logger := log.Scoped(pkgName, "LDAP config watch")
+	go func() {
+		conf.Watch(func() {
+			ps := getProviders()
+			if len(ps) == 0 {
+				providers.Update(pkgName, nil)
+				return
+			}
+
+			if err := licensing.Check(licensing.FeatureSSO); err != nil {
+				logger.Error("Check license for SSO (LDAP)", log.Error(err))
+				providers.Update(pkgName, nil)
+				return
+			}
+			providers.Update(pkgName, ps)
+		})
+	}()
+}
+
+func getProviders() []providers.Provider {
+	var ps []providers.Provider
+	for _, p := range conf.Get().AuthProviders {
+		if p.Ldap == nil {
+			continue
+		}
+		ps = append(ps, NewProvider(*p.Ldap))
+	}
+	return ps
+}
+
+func validateConfig(c conftypes.SiteConfigQuerier) (problems conf.Problems) {
+	for i, p := range c.SiteConfig().AuthProviders {
+		if p.Ldap == nil {
+			continue
+		}
+		pc := withDefaults(*p.Ldap)
+		if !strings.Contains(pc.UserFilter, "{username}") {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d: userFilter must contain {username}", i)))
+		}
+		if p.Ldap.BindDN != "" && p.Ldap.BindPassword == "" {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d: bindPassword must be set if bindDN is set", i)))
+		}
+		if p.Ldap.Certificate != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(p.Ldap.Certificate)) {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d: certificate is not a valid PEM-encoded certificate", i)))
+		}
+		if len(p.Ldap.GroupOrgMap) > 0 && p.Ldap.GroupBaseDN == "" {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d: groupBaseDN must be set to use groupOrgMap", i)))
+		}
+	}
+	return problems
+}
+
+// withDefaults returns the config with the default values of unset optional
+// properties.
+func withDefaults(pc schema.LDAPAuthProvider) schema.LDAPAuthProvider {
+	if pc.UserFilter == "" {
+		pc.UserFilter = "(uid={username})"
+	}
+	if pc.UsernameAttribute == "" {
+		pc.UsernameAttribute = "uid"
+	}
+	if pc.EmailAttribute == "" {
+		pc.EmailAttribute = "mail"
+	}
+	if pc.DisplayNameAttribute == "" {
+		pc.DisplayNameAttribute = "cn"
+	}
+	if pc.GroupFilter == "" {
+		pc.GroupFilter = "(member={dn})"
+	}
+	if pc.GroupNameAttribute == "" {
+		pc.GroupNameAttribute = "cn"
+	}
+	return pc
+}
+
+// providerConfigID produces a semi-stable identifier for an LDAP auth provider config object. It
+// is used to distinguish between multiple auth providers of the same type when signing in. Its
+// value is never persisted, and it must be deterministic.
+func providerConfigID(pc *schema.LDAPAuthProvider) string {
+	if pc.ConfigID != "" {
+		return pc.ConfigID
+	}
+	data, err := json.Marshal(pc)
+	if err != nil {
+		panic(err)
+	}
+	b := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(b[:16])
+}
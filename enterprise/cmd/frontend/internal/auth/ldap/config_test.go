@@ -0,0 +1,43 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := map[string]struct {
+		provider     schema.LDAPAuthProvider
+		wantProblems conf.Problems
+	}{
+		"minimal": {
+			provider: schema.LDAPAuthProvider{Type: "ldap", Url: "ldap://ldap.example.com", UserBaseDN: "dc=example,dc=com"},
+		},
+		"userFilter without username": {
+			provider:     schema.LDAPAuthProvider{Type: "ldap", Url: "ldap://ldap.example.com", UserBaseDN: "dc=example,dc=com", UserFilter: "(uid=alice)"},
+			wantProblems: conf.NewSiteProblems("userFilter must contain {username}"),
+		},
+		"bindDN without bindPassword": {
+			provider:     schema.LDAPAuthProvider{Type: "ldap", Url: "ldap://ldap.example.com", UserBaseDN: "dc=example,dc=com", BindDN: "cn=admin"},
+			wantProblems: conf.NewSiteProblems("bindPassword must be set"),
+		},
+		"invalid certificate": {
+			provider:     schema.LDAPAuthProvider{Type: "ldap", Url: "ldaps://ldap.example.com", UserBaseDN: "dc=example,dc=com", Certificate: "foo"},
+			wantProblems: conf.NewSiteProblems("not a valid PEM-encoded certificate"),
+		},
+		"groupOrgMap without groupBaseDN": {
+			provider:     schema.LDAPAuthProvider{Type: "ldap", Url: "ldap://ldap.example.com", UserBaseDN: "dc=example,dc=com", GroupOrgMap: map[string][]string{"eng": {"engineering"}}},
+			wantProblems: conf.NewSiteProblems("groupBaseDN must be set"),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			provider := test.provider
+			conf.TestValidator(t, conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				AuthProviders: []schema.AuthProviders{{Ldap: &provider}},
+			}}, validateConfig, test.wantProblems)
+		})
+	}
+}
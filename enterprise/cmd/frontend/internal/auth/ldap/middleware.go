@@ -0,0 +1,151 @@
+// Package ldap implements auth via LDAP, including Active Directory.
+package ldap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/external/session"
+	"github.com/sourcegraph/sourcegraph/internal/cookie"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// All LDAP endpoints are under this path prefix.
+const authPrefix = auth.AuthURLPrefix + "/ldap"
+
+// Middleware is middleware for LDAP authentication, adding a sign-in endpoint under the auth path
+// prefix ("/.auth") that checks a username and password against the LDAP server. All other
+// requests are passed through unchanged.
+//
+// 🚨 SECURITY
+func Middleware(db database.DB) *auth.Middleware {
+	logger := log.Scoped(pkgName, "LDAP authentication middleware")
+	return &auth.Middleware{
+		API: func(next http.Handler) http.Handler {
+			return next
+		},
+		App: func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == authPrefix+"/sign-in" {
+					signInHandler(logger, db)(w, r)
+					return
+				}
+				next.ServeHTTP(w, r)
+			})
+		},
+	}
+}
+
+type credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// getProvider returns the LDAP auth provider with the given config ID, or nil if there is none.
+func getProvider(id string) *Provider {
+	p, _ := providers.GetProviderByConfigID(providers.ConfigID{Type: providerType, ID: id}).(*Provider)
+	return p
+}
+
+// signInHandler authenticates the user with the username and password in the request body
+// against the LDAP server of the provider given by the "pc" query parameter.
+//
+// 🚨 SECURITY
+func signInHandler(logger log.Logger, db database.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, fmt.Sprintf("Unsupported method %s", r.Method), http.StatusBadRequest)
+			return
+		}
+
+		p := getProvider(r.URL.Query().Get("pc"))
+		if p == nil {
+			http.Error(w, "LDAP authentication provider not found.", http.StatusNotFound)
+			return
+		}
+
+		var creds credentials
+		if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
+			http.Error(w, "Could not decode request body", http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		logFailure := func() {
+			arg, _ := json.Marshal(struct {
+				Username string `json:"username"`
+			}{
+				Username: creds.Username,
+			})
+			db.SecurityEventLogs().LogEvent(ctx, &database.SecurityEvent{
+				Name:            database.SecurityEventLDAPLoginFailed,
+				URL:             r.URL.Path,
+				AnonymousUserID: anonymousUID(r),
+				Argument:        arg,
+				Source:          "BACKEND",
+				Timestamp:       time.Now(),
+			})
+		}
+
+		e, err := p.authenticate(creds.Username, creds.Password)
+		if err != nil {
+			logFailure()
+			if errors.Is(err, errInvalidCredentials) {
+				http.Error(w, "Authentication failed", http.StatusUnauthorized)
+				return
+			}
+			logger.Error("failed to authenticate with LDAP", log.String("username", creds.Username), log.Error(err))
+			http.Error(w, "Authentication failed: could not connect to the LDAP server.", http.StatusInternalServerError)
+			return
+		}
+
+		act, safeErrMsg, err := getOrCreateUser(ctx, db, p, e)
+		if err != nil {
+			logFailure()
+			logger.Error("failed to get or create user from LDAP entry", log.String("dn", e.DN), log.Error(err))
+			http.Error(w, safeErrMsg, http.StatusInternalServerError)
+			return
+		}
+
+		// Failing to sync the organizations should not prevent the user from signing in.
+		if err := syncOrgs(ctx, logger, db, p, act.UID, e.Groups); err != nil {
+			logger.Error("failed to sync organizations from LDAP groups", log.Int32("userID", act.UID), log.Error(err))
+		}
+
+		user, err := db.Users().GetByID(ctx, act.UID)
+		if err != nil {
+			logger.Error("failed to get user", log.Int32("userID", act.UID), log.Error(err))
+			http.Error(w, "Authentication failed: could not get user.", http.StatusInternalServerError)
+			return
+		}
+
+		if err := session.SetActor(w, r, act, 0, user.CreatedAt); err != nil {
+			logger.Error("failed to initiate session", log.Error(err))
+			http.Error(w, "Authentication failed. Try signing in again (and clearing cookies for the current site). The error was: could not initiate session.", http.StatusInternalServerError)
+			return
+		}
+
+		db.SecurityEventLogs().LogEvent(ctx, &database.SecurityEvent{
+			Name:      database.SecurityEventLDAPLoginSucceeded,
+			URL:       r.URL.Path,
+			UserID:    uint32(user.ID),
+			Source:    "BACKEND",
+			Timestamp: time.Now(),
+		})
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func anonymousUID(r *http.Request) string {
+	if uid, ok := cookie.AnonymousUID(r); ok {
+		return uid
+	}
+	return fmt.Sprintf("unknown LDAP @ %s", time.Now())
+}
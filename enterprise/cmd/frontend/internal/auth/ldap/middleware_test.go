@@ -0,0 +1,54 @@
+package ldap
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+func TestMiddleware(t *testing.T) {
+	p := newTestProvider(t)
+	providers.MockProviders = []providers.Provider{p}
+	t.Cleanup(func() { providers.MockProviders = nil })
+
+	securityEventLogs := database.NewMockSecurityEventLogsStore()
+	db := database.NewMockDB()
+	db.SecurityEventLogsFunc.SetDefaultReturn(securityEventLogs)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	handler := Middleware(db).App(next)
+
+	serve := func(method, target, body string) int {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return w.Code
+	}
+
+	signInURL := p.CachedInfo().AuthenticationURL
+
+	t.Run("other paths are passed through", func(t *testing.T) {
+		assert.Equal(t, http.StatusTeapot, serve("GET", "/search", ""))
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, serve("POST", authPrefix+"/sign-in?pc=foo", `{}`))
+	})
+
+	t.Run("wrong method", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve("GET", signInURL, ""))
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, serve("POST", signInURL, `{"username": "alice", "password": "wrong"}`))
+		mockrequire.CalledOnce(t, securityEventLogs.LogEventFunc)
+	})
+}
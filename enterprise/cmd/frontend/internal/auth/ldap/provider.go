@@ -0,0 +1,56 @@
+package ldap
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const providerType = "ldap"
+
+// Provider is an implementation of providers.Provider for LDAP authentication.
+type Provider struct {
+	// config is the site configuration of the provider, with the defaults of
+	// unset optional properties filled in.
+	config schema.LDAPAuthProvider
+}
+
+// NewProvider creates and returns a new LDAP authentication provider using the
+// given config.
+func NewProvider(config schema.LDAPAuthProvider) *Provider {
+	return &Provider{config: withDefaults(config)}
+}
+
+// ConfigID implements providers.Provider.
+func (p *Provider) ConfigID() providers.ConfigID {
+	return providers.ConfigID{
+		Type: providerType,
+		ID:   providerConfigID(&p.config),
+	}
+}
+
+// Config implements providers.Provider.
+func (p *Provider) Config() schema.AuthProviders {
+	return schema.AuthProviders{Ldap: &p.config}
+}
+
+// Refresh implements providers.Provider.
+func (p *Provider) Refresh(context.Context) error { return nil }
+
+// CachedInfo implements providers.Provider.
+func (p *Provider) CachedInfo() *providers.Info {
+	displayName := p.config.DisplayName
+	if displayName == "" {
+		displayName = "LDAP"
+		if u, err := url.Parse(p.config.Url); err == nil && u.Hostname() != "" {
+			displayName += " (" + u.Hostname() + ")"
+		}
+	}
+	return &providers.Info{
+		ServiceID:         p.config.Url,
+		DisplayName:       displayName,
+		AuthenticationURL: authPrefix + "/sign-in?pc=" + url.QueryEscape(p.ConfigID().ID),
+	}
+}
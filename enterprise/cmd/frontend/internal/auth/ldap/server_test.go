@@ -0,0 +1,192 @@
+package ldap
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+// testEntry is an entry in the directory of a testServer.
+type testEntry struct {
+	dn       string
+	password string
+	attrs    map[string][]string
+}
+
+// testServer is a minimal in-process LDAP server. It supports simple binds and
+// subtree searches with and, or, not, equality and presence filters, which is
+// everything the provider needs.
+type testServer struct {
+	t       *testing.T
+	entries []testEntry
+}
+
+// newTestServer starts an LDAP server serving the given entries and returns its
+// URL. The server is stopped when the test finishes.
+func newTestServer(t *testing.T, entries ...testEntry) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	s := &testServer{t: t, entries: entries}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return "ldap://" + l.Addr().String()
+}
+
+func (s *testServer) serve(conn net.Conn) {
+	defer conn.Close()
+	for {
+		req, err := ber.ReadPacket(conn)
+		if err != nil || len(req.Children) < 2 {
+			return
+		}
+		msgID := req.Children[0].Value.(int64)
+		op := req.Children[1]
+
+		var resps []*ber.Packet
+		switch op.Tag {
+		case goldap.ApplicationBindRequest:
+			resps = append(resps, s.bind(op))
+		case goldap.ApplicationSearchRequest:
+			resps = s.search(op)
+		case goldap.ApplicationUnbindRequest:
+			return
+		default:
+			s.t.Logf("unsupported LDAP operation %d", op.Tag)
+			return
+		}
+
+		for _, resp := range resps {
+			msg := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+			msg.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, msgID, "MessageID"))
+			msg.AppendChild(resp)
+			if _, err := conn.Write(msg.Bytes()); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (s *testServer) bind(op *ber.Packet) *ber.Packet {
+	dn := op.Children[1].Data.String()
+	password := op.Children[2].Data.String()
+
+	code := uint16(goldap.LDAPResultInvalidCredentials)
+	if dn == "" && password == "" {
+		code = goldap.LDAPResultSuccess
+	}
+	for _, e := range s.entries {
+		if strings.EqualFold(e.dn, dn) && e.password != "" && e.password == password {
+			code = goldap.LDAPResultSuccess
+		}
+	}
+	return result(goldap.ApplicationBindResponse, code)
+}
+
+func (s *testServer) search(op *ber.Packet) []*ber.Packet {
+	baseDN := strings.ToLower(op.Children[0].Data.String())
+	filter := op.Children[6]
+
+	var attributes []string
+	for _, a := range op.Children[7].Children {
+		attributes = append(attributes, a.Data.String())
+	}
+
+	var resps []*ber.Packet
+	for _, e := range s.entries {
+		if !strings.HasSuffix(strings.ToLower(e.dn), baseDN) || !e.matches(filter) {
+			continue
+		}
+
+		resp := ber.Encode(ber.ClassApplication, ber.TypeConstructed, goldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+		resp.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.dn, "DN"))
+		attrs := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+		for _, name := range attributes {
+			values, ok := e.attr(name)
+			if !ok {
+				continue
+			}
+			attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+			attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+			vals := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+			for _, v := range values {
+				vals.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "Value"))
+			}
+			attr.AppendChild(vals)
+			attrs.AppendChild(attr)
+		}
+		resp.AppendChild(attrs)
+		resps = append(resps, resp)
+	}
+	return append(resps, result(goldap.ApplicationSearchResultDone, goldap.LDAPResultSuccess))
+}
+
+func (e testEntry) attr(name string) ([]string, bool) {
+	for k, v := range e.attrs {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func (e testEntry) matches(filter *ber.Packet) bool {
+	switch filter.Tag {
+	case goldap.FilterAnd:
+		for _, f := range filter.Children {
+			if !e.matches(f) {
+				return false
+			}
+		}
+		return true
+	case goldap.FilterOr:
+		for _, f := range filter.Children {
+			if e.matches(f) {
+				return true
+			}
+		}
+		return false
+	case goldap.FilterNot:
+		return !e.matches(filter.Children[0])
+	case goldap.FilterEqualityMatch:
+		name, value := filter.Children[0].Data.String(), filter.Children[1].Data.String()
+		if strings.EqualFold(name, "dn") {
+			return strings.EqualFold(e.dn, value)
+		}
+		values, _ := e.attr(name)
+		for _, v := range values {
+			if strings.EqualFold(v, value) {
+				return true
+			}
+		}
+		return false
+	case goldap.FilterPresent:
+		_, ok := e.attr(filter.Data.String())
+		return ok
+	default:
+		return false
+	}
+}
+
+func result(tag ber.Tag, code uint16) *ber.Packet {
+	p := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "Result Code"))
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	return p
+}
@@ -0,0 +1,113 @@
+package ldap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// getOrCreateUser gets or creates a user account based on the LDAP directory entry. It returns
+// the authenticated actor if successful; otherwise it returns a friendly error message
+// (safeErrMsg) that is safe to display to users, and a non-nil err with lower-level error details.
+func getOrCreateUser(ctx context.Context, db database.DB, p *Provider, e *entry) (_ *actor.Actor, safeErrMsg string, err error) {
+	if e.Email == "" {
+		return nil, "Only users with an email address may authenticate to Sourcegraph.", errors.Errorf("no %q attribute in LDAP entry %q", p.config.EmailAttribute, e.DN)
+	}
+
+	login, err := auth.NormalizeUsername(e.Username)
+	if err != nil {
+		return nil,
+			fmt.Sprintf("Error normalizing the username %q. See https://docs.sourcegraph.com/admin/auth/#username-normalization.", e.Username),
+			errors.Wrap(err, "normalize username")
+	}
+
+	serialized, err := json.Marshal(e)
+	if err != nil {
+		return nil, "", err
+	}
+
+	pi := p.CachedInfo()
+	userID, safeErrMsg, err := auth.GetAndSaveUser(ctx, db, auth.GetAndSaveUserOp{
+		UserProps: database.NewUser{
+			Username: login,
+			Email:    e.Email,
+			// The directory is the source of truth for the email address of its users.
+			EmailIsVerified: true,
+			DisplayName:     e.DisplayName,
+		},
+		ExternalAccount: extsvc.AccountSpec{
+			ServiceType: providerType,
+			ServiceID:   pi.ServiceID,
+			ClientID:    pi.ClientID,
+			AccountID:   e.DN,
+		},
+		ExternalAccountData: extsvc.AccountData{
+			Data: extsvc.NewUnencryptedData(serialized),
+		},
+		CreateIfNotExist: p.config.AllowSignup == nil || *p.config.AllowSignup,
+	})
+	if err != nil {
+		return nil, safeErrMsg, err
+	}
+	return actor.FromUser(userID), "", nil
+}
+
+// syncOrgs makes the user a member of the organizations that their LDAP groups are mapped to in
+// the groupOrgMap, and removes them from mapped organizations that none of their groups map to
+// anymore. Memberships in organizations that are not mentioned in the groupOrgMap are left
+// untouched.
+func syncOrgs(ctx context.Context, logger log.Logger, db database.DB, p *Provider, userID int32, groups []string) error {
+	if len(p.config.GroupOrgMap) == 0 {
+		return nil
+	}
+
+	want := make(map[string]bool)
+	for _, orgNames := range p.config.GroupOrgMap {
+		for _, name := range orgNames {
+			want[name] = false
+		}
+	}
+	for _, group := range groups {
+		for _, name := range p.config.GroupOrgMap[group] {
+			want[name] = true
+		}
+	}
+
+	for name, member := range want {
+		org, err := db.Orgs().GetByName(ctx, name)
+		if err != nil {
+			if errcode.IsNotFound(err) {
+				logger.Warn("organization in LDAP groupOrgMap does not exist", log.String("org", name))
+				continue
+			}
+			return errors.Wrapf(err, "getting organization %q", name)
+		}
+
+		_, err = db.OrgMembers().GetByOrgIDAndUserID(ctx, org.ID, userID)
+		isMember := err == nil
+		if err != nil && !errcode.IsNotFound(err) {
+			return errors.Wrapf(err, "getting membership of organization %q", name)
+		}
+
+		switch {
+		case member && !isMember:
+			if _, err := db.OrgMembers().Create(ctx, org.ID, userID); err != nil {
+				return errors.Wrapf(err, "adding user to organization %q", name)
+			}
+		case !member && isMember:
+			if err := db.OrgMembers().Remove(ctx, org.ID, userID); err != nil {
+				return errors.Wrapf(err, "removing user from organization %q", name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package ldap
+
+import (
+	"context"
+	"testing"
+
+	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGetOrCreateUser(t *testing.T) {
+	disallow := false
+	p := NewProvider(schema.LDAPAuthProvider{
+		Type:        providerType,
+		Url:         "ldap://ldap.example.com",
+		UserBaseDN:  "dc=example,dc=com",
+		AllowSignup: &disallow,
+	})
+	e := &entry{
+		DN:          "uid=alice,ou=people,dc=example,dc=com",
+		Username:    "alice smith",
+		Email:       "alice@example.com",
+		DisplayName: "Alice Smith",
+	}
+
+	var got auth.GetAndSaveUserOp
+	auth.MockGetAndSaveUser = func(_ context.Context, op auth.GetAndSaveUserOp) (int32, string, error) {
+		got = op
+		return 7, "", nil
+	}
+	t.Cleanup(func() { auth.MockGetAndSaveUser = nil })
+
+	act, _, err := getOrCreateUser(context.Background(), database.NewMockDB(), p, e)
+	require.NoError(t, err)
+	assert.Equal(t, int32(7), act.UID)
+	assert.Equal(t, database.NewUser{
+		Username:        "alice-smith",
+		Email:           "alice@example.com",
+		EmailIsVerified: true,
+		DisplayName:     "Alice Smith",
+	}, got.UserProps)
+	assert.Equal(t, extsvc.AccountSpec{
+		ServiceType: "ldap",
+		ServiceID:   "ldap://ldap.example.com",
+		AccountID:   "uid=alice,ou=people,dc=example,dc=com",
+	}, got.ExternalAccount)
+	assert.False(t, got.CreateIfNotExist)
+
+	t.Run("no email", func(t *testing.T) {
+		_, safeErrMsg, err := getOrCreateUser(context.Background(), database.NewMockDB(), p, &entry{DN: e.DN, Username: "alice"})
+		assert.Error(t, err)
+		assert.Contains(t, safeErrMsg, "email address")
+	})
+}
+
+func TestSyncOrgs(t *testing.T) {
+	p := NewProvider(schema.LDAPAuthProvider{
+		GroupOrgMap: map[string][]string{
+			"engineering": {"eng", "everyone"},
+			"sales":       {"sales", "everyone"},
+			"ops":         {"missing"},
+		},
+	})
+
+	orgIDs := map[string]int32{"eng": 1, "sales": 2, "everyone": 3}
+	orgs := database.NewMockOrgStore()
+	orgs.GetByNameFunc.SetDefaultHook(func(_ context.Context, name string) (*types.Org, error) {
+		if id, ok := orgIDs[name]; ok {
+			return &types.Org{ID: id, Name: name}, nil
+		}
+		return nil, &database.OrgNotFoundError{Message: name}
+	})
+	// The user is currently a member of the "sales" and "everyone" orgs.
+	members := database.NewMockOrgMemberStore()
+	members.GetByOrgIDAndUserIDFunc.SetDefaultHook(func(_ context.Context, orgID, userID int32) (*types.OrgMembership, error) {
+		if orgID == 2 || orgID == 3 {
+			return &types.OrgMembership{OrgID: orgID, UserID: userID}, nil
+		}
+		return nil, &database.ErrOrgMemberNotFound{}
+	})
+
+	db := database.NewMockDB()
+	db.OrgsFunc.SetDefaultReturn(orgs)
+	db.OrgMembersFunc.SetDefaultReturn(members)
+
+	err := syncOrgs(context.Background(), logtest.Scoped(t), db, p, 42, []string{"engineering", "unmapped"})
+	require.NoError(t, err)
+
+	mockrequire.CalledOnceWith(t, members.CreateFunc, mockrequire.Values(mockrequire.Skip, int32(1), int32(42)))
+	mockrequire.CalledOnceWith(t, members.RemoveFunc, mockrequire.Values(mockrequire.Skip, int32(2), int32(42)))
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/graph-gophers/graphql-go"
 
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
@@ -759,12 +760,12 @@ func (r *Resolver) BatchChanges(ctx context.Context, args *graphqlbackend.ListBa
 		opts.Cursor = cursor
 	}
 
-	authErr := auth.CheckCurrentUserIsSiteAdmin(ctx, r.store.DatabaseDB())
-	if authErr != nil && authErr != auth.ErrMustBeSiteAdmin {
+	authErr := auth.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesAdmin)
+	if authErr != nil && !errors.HasType(authErr, &auth.MissingPermissionError{}) {
 		return nil, authErr
 	}
-	isSiteAdmin := authErr != auth.ErrMustBeSiteAdmin
-	if !isSiteAdmin {
+	isBatchChangesAdmin := authErr == nil
+	if !isBatchChangesAdmin {
 		actor := actor.FromContext(ctx)
 		if args.ViewerCanAdminister != nil && *args.ViewerCanAdminister {
 			opts.OnlyAdministeredByUserID = actor.UID
@@ -1184,7 +1185,9 @@ func (r *Resolver) generateAuthenticatorForCredential(ctx context.Context, exter
 			PublicKey:  keypair.PublicKey,
 			Passphrase: keypair.Passphrase,
 		}
-	} else if externalServiceType == extsvc.TypeBitbucketCloud {
+	} else if externalServiceType == extsvc.TypeBitbucketCloud ||
+		externalServiceType == extsvc.TypeAzureDevOps ||
+		externalServiceType == extsvc.TypeGerrit {
 		a = &extsvcauth.BasicAuthWithSSH{
 			BasicAuth:  extsvcauth.BasicAuth{Username: *username, Password: credential},
 			PrivateKey: keypair.PrivateKey,
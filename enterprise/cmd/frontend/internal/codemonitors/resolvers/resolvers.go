@@ -972,6 +972,7 @@ func (m *monitorTriggerEvent) ResultCount() int32 {
 	for _, cm := range m.TriggerJob.SearchResults {
 		count += cm.ResultCount()
 	}
+	count += len(m.TriggerJob.ContentResults)
 	return int32(count)
 }
 
@@ -27,6 +27,8 @@ type ExecutorHandler interface {
 	handleMarkFailed(w http.ResponseWriter, r *http.Request)
 	handleHeartbeat(w http.ResponseWriter, r *http.Request)
 	handleCanceledJobs(w http.ResponseWriter, r *http.Request)
+	dequeue(ctx context.Context, metadata executorMetadata) (apiclient.Job, bool, error)
+	heartbeatJobs(ctx context.Context, executorName string, ids []int) (knownIDs, cancelIDs []int, err error)
 }
 
 var _ ExecutorHandler = &handler[workerutil.Record]{}
@@ -238,11 +240,21 @@ func (h *handler[T]) heartbeat(ctx context.Context, executor types.Executor, ids
 		logger.Error("Failed to upsert executor heartbeat", log.Error(err))
 	}
 
+	return h.heartbeatJobs(ctx, executor.Hostname, ids)
+}
+
+// heartbeatJobs calls Heartbeat for the given jobs, without recording the heartbeat
+// of the executor itself.
+func (h *handler[T]) heartbeatJobs(ctx context.Context, executorName string, ids []int) (knownIDs, cancelIDs []int, err error) {
+	if err := validateWorkerHostname(executorName); err != nil {
+		return nil, nil, err
+	}
+
 	knownIDs, cancelIDs, err = h.Store.Heartbeat(ctx, ids, store.HeartbeatOptions{
 		// We pass the WorkerHostname, so the store enforces the record to be owned by this executor. When
 		// the previous executor didn't report heartbeats anymore, but is still alive and reporting state,
 		// both executors that ever got the job would be writing to the same record. This prevents it.
-		WorkerHostname: executor.Hostname,
+		WorkerHostname: executorName,
 	})
 	return knownIDs, cancelIDs, errors.Wrap(err, "dbworkerstore.UpsertHeartbeat")
 }
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	apiclient "github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	metricsstore "github.com/sourcegraph/sourcegraph/internal/metrics/store"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// multiQueueHandler serves the dequeue and heartbeat endpoints for executors that
+// process jobs from multiple queues at once.
+type multiQueueHandler struct {
+	handlers      map[string]ExecutorHandler
+	executorStore database.ExecutorStore
+	metricsStore  metricsstore.DistributedStore
+	logger        log.Logger
+	// intn returns a random number in [0, n). It is replaced in tests.
+	intn func(n int) int
+}
+
+func newMultiQueueHandler(executorStore database.ExecutorStore, metricsStore metricsstore.DistributedStore, handlers []ExecutorHandler) *multiQueueHandler {
+	handlersByName := make(map[string]ExecutorHandler, len(handlers))
+	for _, h := range handlers {
+		handlersByName[h.Name()] = h
+	}
+
+	return &multiQueueHandler{
+		handlers:      handlersByName,
+		executorStore: executorStore,
+		metricsStore:  metricsStore,
+		logger:        log.Scoped("executor-multi-queue-handler", "The route handler for executors processing jobs from multiple queues"),
+		intn:          rand.Intn,
+	}
+}
+
+// POST /dequeue
+func (h *multiQueueHandler) handleDequeue(w http.ResponseWriter, r *http.Request) {
+	var payload apiclient.DequeueRequest
+
+	wrapHandler(w, r, &payload, func() (int, any, error) {
+		if err := h.validateQueues(payload.Queues); err != nil {
+			return http.StatusBadRequest, errorResponse{Error: err.Error()}, nil
+		}
+
+		job, dequeued, err := h.dequeue(r.Context(), payload.Queues, executorMetadata{
+			Name:    payload.ExecutorName,
+			Version: payload.Version,
+			Resources: ResourceMetadata{
+				NumCPUs:   payload.NumCPUs,
+				Memory:    payload.Memory,
+				DiskSpace: payload.DiskSpace,
+			},
+		})
+		if !dequeued {
+			return http.StatusNoContent, nil, err
+		}
+
+		return http.StatusOK, job, err
+	})
+}
+
+// POST /heartbeat
+func (h *multiQueueHandler) handleHeartbeat(w http.ResponseWriter, r *http.Request) {
+	var payload apiclient.HeartbeatRequest
+
+	wrapHandler(w, r, &payload, func() (int, any, error) {
+		if len(payload.JobIDsByQueue) == 0 {
+			return http.StatusBadRequest, errorResponse{Error: "no queues given"}, nil
+		}
+
+		queueNames := make([]string, 0, len(payload.JobIDsByQueue))
+		for name := range payload.JobIDsByQueue {
+			queueNames = append(queueNames, name)
+		}
+		sort.Strings(queueNames)
+
+		for _, name := range queueNames {
+			if _, ok := h.handlers[name]; !ok {
+				return http.StatusBadRequest, errorResponse{Error: errors.Newf("unknown queue %q", name).Error()}, nil
+			}
+		}
+
+		executor := types.Executor{
+			Hostname:        payload.ExecutorName,
+			QueueName:       strings.Join(queueNames, ","),
+			OS:              payload.OS,
+			Architecture:    payload.Architecture,
+			DockerVersion:   payload.DockerVersion,
+			ExecutorVersion: payload.ExecutorVersion,
+			GitVersion:      payload.GitVersion,
+			IgniteVersion:   payload.IgniteVersion,
+			SrcCliVersion:   payload.SrcCliVersion,
+		}
+
+		// Handle metrics in the background, this should not delay the heartbeat response being
+		// delivered. It is critical for keeping jobs alive.
+		go ingestMetrics(h.logger, h.metricsStore, payload.ExecutorName, payload.PrometheusMetrics)
+
+		resp, err := h.heartbeat(r.Context(), executor, payload.JobIDsByQueue)
+		return http.StatusOK, resp, err
+	})
+}
+
+func (h *multiQueueHandler) validateQueues(queues []apiclient.QueueWeight) error {
+	if len(queues) == 0 {
+		return errors.New("no queues given")
+	}
+	for _, q := range queues {
+		if _, ok := h.handlers[q.Name]; !ok {
+			return errors.Newf("unknown queue %q", q.Name)
+		}
+	}
+	return nil
+}
+
+// dequeue tries to dequeue a job from each of the given queues in turn, in the
+// order given by weightedQueueOrder, until one of them has a job available. The
+// job is returned along with the name of the queue it was dequeued from.
+func (h *multiQueueHandler) dequeue(ctx context.Context, queues []apiclient.QueueWeight, metadata executorMetadata) (_ apiclient.Job, dequeued bool, _ error) {
+	for _, name := range weightedQueueOrder(queues, h.intn) {
+		job, dequeued, err := h.handlers[name].dequeue(ctx, metadata)
+		if err != nil {
+			return apiclient.Job{}, false, errors.Wrapf(err, "dequeueing from queue %q", name)
+		}
+		if dequeued {
+			job.Queue = name
+			return job, true, nil
+		}
+	}
+
+	return apiclient.Job{}, false, nil
+}
+
+// heartbeat records the heartbeat of the given executor, and calls Heartbeat for
+// the jobs of each queue.
+func (h *multiQueueHandler) heartbeat(ctx context.Context, executor types.Executor, idsByQueue map[string][]int) (apiclient.HeartbeatResponse, error) {
+	if err := validateWorkerHostname(executor.Hostname); err != nil {
+		return apiclient.HeartbeatResponse{}, err
+	}
+
+	// Write this heartbeat to the database so that we can populate the UI with recent executor activity.
+	if err := h.executorStore.UpsertHeartbeat(ctx, executor); err != nil {
+		h.logger.Error("Failed to upsert executor heartbeat", log.Error(err))
+	}
+
+	resp := apiclient.HeartbeatResponse{
+		KnownIDsByQueue:  make(map[string][]int, len(idsByQueue)),
+		CancelIDsByQueue: make(map[string][]int, len(idsByQueue)),
+	}
+	for name, ids := range idsByQueue {
+		knownIDs, cancelIDs, err := h.handlers[name].heartbeatJobs(ctx, executor.Hostname, ids)
+		if err != nil {
+			return apiclient.HeartbeatResponse{}, errors.Wrapf(err, "heartbeat for queue %q", name)
+		}
+		resp.KnownIDsByQueue[name] = knownIDs
+		resp.CancelIDsByQueue[name] = cancelIDs
+	}
+
+	return resp, nil
+}
+
+// weightedQueueOrder returns the names of the given queues in the order in which
+// to try dequeuing from them. Each position is filled by a random pick among the
+// remaining queues, weighted by their weight, so that every queue is tried once
+// and a queue with twice the weight of another is tried first twice as often.
+// Weights below 1 count as 1.
+func weightedQueueOrder(queues []apiclient.QueueWeight, intn func(n int) int) []string {
+	remaining := make([]apiclient.QueueWeight, 0, len(queues))
+	total := 0
+	for _, q := range queues {
+		if q.Weight < 1 {
+			q.Weight = 1
+		}
+		remaining = append(remaining, q)
+		total += q.Weight
+	}
+
+	names := make([]string, 0, len(queues))
+	for len(remaining) > 0 {
+		pick := intn(total)
+		for i, q := range remaining {
+			if pick < q.Weight {
+				names = append(names, q.Name)
+				total -= q.Weight
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				break
+			}
+			pick -= q.Weight
+		}
+	}
+
+	return names
+}
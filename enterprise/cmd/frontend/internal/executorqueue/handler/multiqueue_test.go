@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	apiclient "github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	metricsstore "github.com/sourcegraph/sourcegraph/internal/metrics/store"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+	workerstoremocks "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store/mocks"
+)
+
+func TestWeightedQueueOrder(t *testing.T) {
+	queues := []apiclient.QueueWeight{
+		{Name: "batches", Weight: 3},
+		{Name: "codeintel", Weight: 1},
+		{Name: "other", Weight: 0},
+	}
+
+	for _, tc := range []struct {
+		picks []int
+		want  []string
+	}{
+		// The total weight is 5: [0, 3) picks batches, 3 codeintel and 4 other.
+		{picks: []int{0, 0, 0}, want: []string{"batches", "codeintel", "other"}},
+		{picks: []int{2, 1, 0}, want: []string{"batches", "other", "codeintel"}},
+		{picks: []int{3, 0, 0}, want: []string{"codeintel", "batches", "other"}},
+		{picks: []int{4, 3, 0}, want: []string{"other", "codeintel", "batches"}},
+	} {
+		var totals []int
+		intn := func(n int) int {
+			totals = append(totals, n)
+			pick := tc.picks[0]
+			tc.picks = tc.picks[1:]
+			return pick
+		}
+
+		if diff := cmp.Diff(tc.want, weightedQueueOrder(queues, intn)); diff != "" {
+			t.Errorf("unexpected order (-want +got):\n%s", diff)
+		}
+		if totals[0] != 5 {
+			t.Errorf("unexpected total weight. want=%d have=%d", 5, totals[0])
+		}
+	}
+}
+
+func TestMultiQueueDequeue(t *testing.T) {
+	batchesStore := workerstoremocks.NewMockStore[testRecord]()
+	codeintelStore := workerstoremocks.NewMockStore[testRecord]()
+	codeintelStore.DequeueFunc.SetDefaultReturn(testRecord{ID: 42}, true, nil)
+	recordTransformer := func(ctx context.Context, _ string, record testRecord, _ ResourceMetadata) (apiclient.Job, error) {
+		return apiclient.Job{ID: record.RecordID()}, nil
+	}
+
+	executorStore := database.NewMockExecutorStore()
+	metricsStore := metricsstore.NewMockDistributedStore()
+
+	handler := newMultiQueueHandler(executorStore, metricsStore, []ExecutorHandler{
+		NewHandler(executorStore, metricsStore, QueueOptions[testRecord]{Name: "batches", Store: batchesStore, RecordTransformer: recordTransformer}),
+		NewHandler(executorStore, metricsStore, QueueOptions[testRecord]{Name: "codeintel", Store: codeintelStore, RecordTransformer: recordTransformer}),
+	})
+	// Always pick the first remaining queue.
+	handler.intn = func(n int) int { return 0 }
+
+	queues := []apiclient.QueueWeight{{Name: "batches", Weight: 1}, {Name: "codeintel", Weight: 1}}
+	job, dequeued, err := handler.dequeue(context.Background(), queues, executorMetadata{Name: "deadbeef"})
+	if err != nil {
+		t.Fatalf("unexpected error dequeueing job: %s", err)
+	}
+	if !dequeued {
+		t.Fatalf("expected job to be dequeued")
+	}
+	if diff := cmp.Diff(apiclient.Job{ID: 42, Queue: "codeintel"}, job); diff != "" {
+		t.Errorf("unexpected job (-want +got):\n%s", diff)
+	}
+
+	// The empty batches queue is tried first, and the codeintel queue as a fallback.
+	if callCount := len(batchesStore.DequeueFunc.History()); callCount != 1 {
+		t.Errorf("unexpected batches dequeue count. want=%d have=%d", 1, callCount)
+	}
+	if callCount := len(codeintelStore.DequeueFunc.History()); callCount != 1 {
+		t.Errorf("unexpected codeintel dequeue count. want=%d have=%d", 1, callCount)
+	}
+}
+
+func TestMultiQueueDequeueNoRecord(t *testing.T) {
+	executorStore := database.NewMockExecutorStore()
+	metricsStore := metricsstore.NewMockDistributedStore()
+
+	handler := newMultiQueueHandler(executorStore, metricsStore, []ExecutorHandler{
+		NewHandler(executorStore, metricsStore, QueueOptions[testRecord]{Name: "batches", Store: workerstoremocks.NewMockStore[testRecord]()}),
+		NewHandler(executorStore, metricsStore, QueueOptions[testRecord]{Name: "codeintel", Store: workerstoremocks.NewMockStore[testRecord]()}),
+	})
+
+	queues := []apiclient.QueueWeight{{Name: "batches", Weight: 1}, {Name: "codeintel", Weight: 1}}
+	_, dequeued, err := handler.dequeue(context.Background(), queues, executorMetadata{Name: "deadbeef"})
+	if err != nil {
+		t.Fatalf("unexpected error dequeueing job: %s", err)
+	}
+	if dequeued {
+		t.Fatalf("did not expect a job to be dequeued")
+	}
+}
+
+func TestMultiQueueHeartbeat(t *testing.T) {
+	batchesStore := workerstoremocks.NewMockStore[testRecord]()
+	batchesStore.HeartbeatFunc.SetDefaultHook(func(ctx context.Context, ids []int, options store.HeartbeatOptions) ([]int, []int, error) {
+		return ids, nil, nil
+	})
+	codeintelStore := workerstoremocks.NewMockStore[testRecord]()
+	codeintelStore.HeartbeatFunc.SetDefaultHook(func(ctx context.Context, ids []int, options store.HeartbeatOptions) ([]int, []int, error) {
+		return ids[:1], ids[1:], nil
+	})
+
+	executorStore := database.NewMockExecutorStore()
+	metricsStore := metricsstore.NewMockDistributedStore()
+
+	handler := newMultiQueueHandler(executorStore, metricsStore, []ExecutorHandler{
+		NewHandler(executorStore, metricsStore, QueueOptions[testRecord]{Name: "batches", Store: batchesStore}),
+		NewHandler(executorStore, metricsStore, QueueOptions[testRecord]{Name: "codeintel", Store: codeintelStore}),
+	})
+
+	executor := types.Executor{Hostname: "test-hostname", QueueName: "batches,codeintel"}
+	resp, err := handler.heartbeat(context.Background(), executor, map[string][]int{
+		"batches":   {1},
+		"codeintel": {1, 2},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error performing heartbeat: %s", err)
+	}
+
+	want := apiclient.HeartbeatResponse{
+		KnownIDsByQueue:  map[string][]int{"batches": {1}, "codeintel": {1}},
+		CancelIDsByQueue: map[string][]int{"batches": nil, "codeintel": {2}},
+	}
+	if diff := cmp.Diff(want, resp); diff != "" {
+		t.Errorf("unexpected response (-want +got):\n%s", diff)
+	}
+
+	if callCount := len(executorStore.UpsertHeartbeatFunc.History()); callCount != 1 {
+		t.Errorf("unexpected heartbeat upsert count. want=%d have=%d", 1, callCount)
+	} else if have := executorStore.UpsertHeartbeatFunc.History()[0].Arg1; have != executor {
+		t.Errorf("unexpected heartbeat executor. want=%+v have=%+v", executor, have)
+	}
+	for _, s := range []*workerstoremocks.MockStore[testRecord]{batchesStore, codeintelStore} {
+		if history := s.HeartbeatFunc.History(); len(history) != 1 || history[0].Arg2.WorkerHostname != "test-hostname" {
+			t.Errorf("unexpected heartbeats: %+v", history)
+		}
+	}
+}
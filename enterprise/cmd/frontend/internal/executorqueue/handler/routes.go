@@ -44,13 +44,20 @@ func SetupRoutes(executorStore database.ExecutorStore, metricsStore metricsstore
 			subRouter.Path(fmt.Sprintf("/%s", path)).Methods("POST").HandlerFunc(handler)
 		}
 	}
+
+	// Executors processing jobs from multiple queues dequeue and heartbeat through
+	// these routes, and use the routes of the queue a job was dequeued from for all
+	// other requests concerning it.
+	mh := newMultiQueueHandler(executorStore, metricsStore, handlers)
+	router.Path("/dequeue").Methods("POST").HandlerFunc(mh.handleDequeue)
+	router.Path("/heartbeat").Methods("POST").HandlerFunc(mh.handleHeartbeat)
 }
 
 // POST /{queueName}/dequeue
 func (h *handler[T]) handleDequeue(w http.ResponseWriter, r *http.Request) {
 	var payload apiclient.DequeueRequest
 
-	h.wrapHandler(w, r, &payload, func() (int, any, error) {
+	wrapHandler(w, r, &payload, func() (int, any, error) {
 		job, dequeued, err := h.dequeue(r.Context(), executorMetadata{
 			Name:    payload.ExecutorName,
 			Version: payload.Version,
@@ -72,7 +79,7 @@ func (h *handler[T]) handleDequeue(w http.ResponseWriter, r *http.Request) {
 func (h *handler[T]) handleAddExecutionLogEntry(w http.ResponseWriter, r *http.Request) {
 	var payload apiclient.AddExecutionLogEntryRequest
 
-	h.wrapHandler(w, r, &payload, func() (int, any, error) {
+	wrapHandler(w, r, &payload, func() (int, any, error) {
 		id, err := h.addExecutionLogEntry(r.Context(), payload.ExecutorName, payload.JobID, payload.ExecutionLogEntry)
 		return http.StatusOK, id, err
 	})
@@ -82,7 +89,7 @@ func (h *handler[T]) handleAddExecutionLogEntry(w http.ResponseWriter, r *http.R
 func (h *handler[T]) handleUpdateExecutionLogEntry(w http.ResponseWriter, r *http.Request) {
 	var payload apiclient.UpdateExecutionLogEntryRequest
 
-	h.wrapHandler(w, r, &payload, func() (int, any, error) {
+	wrapHandler(w, r, &payload, func() (int, any, error) {
 		err := h.updateExecutionLogEntry(r.Context(), payload.ExecutorName, payload.JobID, payload.EntryID, payload.ExecutionLogEntry)
 		return http.StatusNoContent, nil, err
 	})
@@ -92,7 +99,7 @@ func (h *handler[T]) handleUpdateExecutionLogEntry(w http.ResponseWriter, r *htt
 func (h *handler[T]) handleMarkComplete(w http.ResponseWriter, r *http.Request) {
 	var payload apiclient.MarkCompleteRequest
 
-	h.wrapHandler(w, r, &payload, func() (int, any, error) {
+	wrapHandler(w, r, &payload, func() (int, any, error) {
 		err := h.markComplete(r.Context(), payload.ExecutorName, payload.JobID)
 		if err == ErrUnknownJob {
 			return http.StatusNotFound, nil, nil
@@ -106,7 +113,7 @@ func (h *handler[T]) handleMarkComplete(w http.ResponseWriter, r *http.Request)
 func (h *handler[T]) handleMarkErrored(w http.ResponseWriter, r *http.Request) {
 	var payload apiclient.MarkErroredRequest
 
-	h.wrapHandler(w, r, &payload, func() (int, any, error) {
+	wrapHandler(w, r, &payload, func() (int, any, error) {
 		err := h.markErrored(r.Context(), payload.ExecutorName, payload.JobID, payload.ErrorMessage)
 		if err == ErrUnknownJob {
 			return http.StatusNotFound, nil, nil
@@ -120,7 +127,7 @@ func (h *handler[T]) handleMarkErrored(w http.ResponseWriter, r *http.Request) {
 func (h *handler[T]) handleMarkFailed(w http.ResponseWriter, r *http.Request) {
 	var payload apiclient.MarkErroredRequest
 
-	h.wrapHandler(w, r, &payload, func() (int, any, error) {
+	wrapHandler(w, r, &payload, func() (int, any, error) {
 		err := h.markFailed(r.Context(), payload.ExecutorName, payload.JobID, payload.ErrorMessage)
 		if err == ErrUnknownJob {
 			return http.StatusNotFound, nil, nil
@@ -134,7 +141,7 @@ func (h *handler[T]) handleMarkFailed(w http.ResponseWriter, r *http.Request) {
 func (h *handler[T]) handleHeartbeat(w http.ResponseWriter, r *http.Request) {
 	var payload apiclient.HeartbeatRequest
 
-	h.wrapHandler(w, r, &payload, func() (int, any, error) {
+	wrapHandler(w, r, &payload, func() (int, any, error) {
 		executor := types.Executor{
 			Hostname:        payload.ExecutorName,
 			QueueName:       h.QueueOptions.Name,
@@ -149,19 +156,7 @@ func (h *handler[T]) handleHeartbeat(w http.ResponseWriter, r *http.Request) {
 
 		// Handle metrics in the background, this should not delay the heartbeat response being
 		// delivered. It is critical for keeping jobs alive.
-		go func() {
-			metrics, err := decodeAndLabelMetrics(payload.PrometheusMetrics, payload.ExecutorName)
-			if err != nil {
-				// Just log the error but don't panic. The heartbeat is more important.
-				h.logger.Error("failed to decode metrics and apply labels for executor heartbeat", log.Error(err))
-				return
-			}
-
-			if err := h.metricsStore.Ingest(payload.ExecutorName, metrics); err != nil {
-				// Just log the error but don't panic. The heartbeat is more important.
-				h.logger.Error("failed to ingest metrics for executor heartbeat", log.Error(err))
-			}
-		}()
+		go ingestMetrics(h.logger, h.metricsStore, payload.ExecutorName, payload.PrometheusMetrics)
 
 		knownIDs, cancelIDs, err := h.heartbeat(r.Context(), executor, payload.JobIDs)
 
@@ -179,7 +174,7 @@ func (h *handler[T]) handleHeartbeat(w http.ResponseWriter, r *http.Request) {
 func (h *handler[T]) handleCanceledJobs(w http.ResponseWriter, r *http.Request) {
 	var payload apiclient.CanceledJobsRequest
 
-	h.wrapHandler(w, r, &payload, func() (int, any, error) {
+	wrapHandler(w, r, &payload, func() (int, any, error) {
 		canceledIDs, err := h.canceled(r.Context(), payload.ExecutorName, payload.KnownJobIDs)
 		return http.StatusOK, canceledIDs, err
 	})
@@ -195,7 +190,7 @@ type errorResponse struct {
 // is returned. Otherwise, the response status will match the status code value returned from the
 // handler, and the payload value returned from the handler is encoded and written to the
 // response body.
-func (h *handler[T]) wrapHandler(w http.ResponseWriter, r *http.Request, payload any, handler func() (int, any, error)) {
+func wrapHandler(w http.ResponseWriter, r *http.Request, payload any, handler func() (int, any, error)) {
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		http.Error(w, fmt.Sprintf("Failed to unmarshal payload: %s", err.Error()), http.StatusBadRequest)
 		return
@@ -223,6 +218,22 @@ func (h *handler[T]) wrapHandler(w http.ResponseWriter, r *http.Request, payload
 	}
 }
 
+// ingestMetrics stores the prometheus metrics sent along with a heartbeat of the
+// given executor. Errors are logged, as they must not fail the heartbeat.
+func ingestMetrics(logger log.Logger, metricsStore metricsstore.DistributedStore, executorName, encodedMetrics string) {
+	metrics, err := decodeAndLabelMetrics(encodedMetrics, executorName)
+	if err != nil {
+		// Just log the error but don't panic. The heartbeat is more important.
+		logger.Error("failed to decode metrics and apply labels for executor heartbeat", log.Error(err))
+		return
+	}
+
+	if err := metricsStore.Ingest(executorName, metrics); err != nil {
+		// Just log the error but don't panic. The heartbeat is more important.
+		logger.Error("failed to ingest metrics for executor heartbeat", log.Error(err))
+	}
+}
+
 // decodeAndLabelMetrics decodes the text serialized prometheus metrics dump and then
 // applies common labels.
 func decodeAndLabelMetrics(encodedMetrics, instanceName string) ([]*dto.MetricFamily, error) {
@@ -0,0 +1,51 @@
+package batches
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/merger"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type mergerJob struct{}
+
+func NewMergerJob() job.Job {
+	return &mergerJob{}
+}
+
+func (j *mergerJob) Description() string {
+	return ""
+}
+
+func (j *mergerJob) Config() []env.Config {
+	return []env.Config{}
+}
+
+func (j *mergerJob) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	observationCtx = observation.NewContext(observationCtx.Logger.Scoped("routines", "merger job routines"))
+	workCtx := actor.WithInternalActor(context.Background())
+
+	bstore, err := InitStore()
+	if err != nil {
+		return nil, err
+	}
+
+	routines := []goroutine.BackgroundRoutine{
+		merger.NewMerger(
+			workCtx,
+			observationCtx,
+			bstore,
+			sources.NewSourcer(httpcli.NewExternalClientFactory(
+				httpcli.NewLoggingMiddleware(observationCtx.Logger.Scoped("sourcer", "batches sourcer")),
+			)),
+		),
+	}
+
+	return routines, nil
+}
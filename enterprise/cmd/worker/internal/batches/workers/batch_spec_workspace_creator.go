@@ -3,9 +3,6 @@ package workers
 import (
 	"context"
 	"encoding/json"
-	"fmt"
-	"path/filepath"
-	"strings"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
@@ -18,13 +15,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
-	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution/cache"
-	"github.com/sourcegraph/sourcegraph/lib/batches/template"
-	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // batchSpecWorkspaceCreator takes in BatchSpecs, resolves them into
@@ -46,15 +40,10 @@ func (r *batchSpecWorkspaceCreator) HandlerFunc() workerutil.HandlerFunc[*btypes
 	}
 }
 
-type stepCacheKey struct {
-	index int
-	key   string
-}
-
 type workspaceCacheKey struct {
 	dbWorkspace   *btypes.BatchSpecWorkspace
 	repo          batcheslib.Repository
-	stepCacheKeys []stepCacheKey
+	stepCacheKeys []service.StepCacheKey
 	skippedSteps  map[int]struct{}
 }
 
@@ -77,30 +66,11 @@ func (r *batchSpecWorkspaceCreator) process(
 		return err
 	}
 
-	// Next, we fetch all secrets that are requested by the spec.
-	rk := spec.Spec.RequiredEnvVars()
-	var secrets []*database.ExecutorSecret
-	if len(rk) > 0 {
-		esStore := r.store.DatabaseDB().ExecutorSecrets(keyring.Default().ExecutorSecretKey)
-		secrets, _, err = esStore.List(ctx, database.ExecutorSecretScopeBatches, database.ExecutorSecretsListOpts{
-			NamespaceUserID: spec.NamespaceUserID,
-			NamespaceOrgID:  spec.NamespaceOrgID,
-			Keys:            rk,
-		})
-		if err != nil {
-			return errors.Wrap(err, "fetching secrets")
-		}
-	}
-
-	esalStore := r.store.DatabaseDB().ExecutorSecretAccessLogs()
-	envVars := make([]string, len(secrets))
-	for i, secret := range secrets {
-		// This will create an audit log event in the name of the initiating user.
-		val, err := secret.Value(ctx, esalStore)
-		if err != nil {
-			return errors.Wrap(err, "getting value for secret")
-		}
-		envVars[i] = fmt.Sprintf("%s=%s", secret.Key, val)
+	// Next, we fetch all secrets that are requested by the spec. This will
+	// create an audit log event in the name of the initiating user.
+	envVars, err := service.SecretEnvVars(ctx, r.store, spec)
+	if err != nil {
+		return err
 	}
 
 	resolver := newResolver(r.store)
@@ -117,11 +87,10 @@ func (r *batchSpecWorkspaceCreator) process(
 	cacheKeyWorkspaces := make([]workspaceCacheKey, 0, len(workspaces))
 	allStepCacheKeys := make([]string, 0, len(workspaces))
 	// load the mounts from the DB up front to avoid duplicate calls with no difference in data
-	mounts, err := listBatchSpecMounts(ctx, r.store, spec.ID)
+	retriever, err := service.NewMountMetadataRetriever(ctx, r.store, spec.ID)
 	if err != nil {
 		return err
 	}
-	retriever := &remoteFileMetadataRetriever{mounts: mounts}
 
 	// Build workspaces DB objects.
 	for _, w := range workspaces {
@@ -162,34 +131,13 @@ func (r *batchSpecWorkspaceCreator) process(
 			return err
 		}
 
-		stepCacheKeys := make([]stepCacheKey, 0, len(spec.Spec.Steps))
 		// Generate cache keys for all the steps.
-		for i := 0; i < len(spec.Spec.Steps); i++ {
-			if _, ok := skippedSteps[i]; ok {
-				continue
-			}
-
-			key := cache.KeyForWorkspace(
-				&template.BatchChangeAttributes{
-					Name:        spec.Spec.Name,
-					Description: spec.Spec.Description,
-				},
-				repo,
-				w.Path,
-				envVars,
-				w.OnlyFetchWorkspace,
-				spec.Spec.Steps,
-				i,
-				retriever,
-			)
-
-			rawStepKey, err := key.Key()
-			if err != nil {
-				return err
-			}
-
-			stepCacheKeys = append(stepCacheKeys, stepCacheKey{index: i, key: rawStepKey})
-			allStepCacheKeys = append(allStepCacheKeys, rawStepKey)
+		stepCacheKeys, err := service.StepCacheKeys(spec, repo, w.Path, w.OnlyFetchWorkspace, envVars, retriever, skippedSteps)
+		if err != nil {
+			return err
+		}
+		for _, ck := range stepCacheKeys {
+			allStepCacheKeys = append(allStepCacheKeys, ck.Key)
 		}
 
 		cacheKeyWorkspaces = append(cacheKeyWorkspaces, workspaceCacheKey{
@@ -223,8 +171,8 @@ func (r *batchSpecWorkspaceCreator) process(
 	// Check for an existing cache entry for each of the workspaces.
 	for _, workspace := range cacheKeyWorkspaces {
 		for _, ck := range workspace.stepCacheKeys {
-			key := ck.key
-			idx := ck.index
+			key := ck.Key
+			idx := ck.Index
 			if c, ok := stepEntriesByCacheKey[key]; ok {
 				var res execution.AfterStepResult
 				if err := json.Unmarshal([]byte(c.Value), &res); err != nil {
@@ -330,50 +278,6 @@ func (r *batchSpecWorkspaceCreator) process(
 	return tx.CreateBatchSpecWorkspace(ctx, ws...)
 }
 
-func listBatchSpecMounts(ctx context.Context, s *store.Store, batchSpecID int64) ([]*btypes.BatchSpecWorkspaceFile, error) {
-	mounts, _, err := s.ListBatchSpecWorkspaceFiles(ctx, store.ListBatchSpecWorkspaceFileOpts{BatchSpecID: batchSpecID})
-	if err != nil {
-		return nil, err
-	}
-	return mounts, nil
-}
-
-type remoteFileMetadataRetriever struct {
-	mounts []*btypes.BatchSpecWorkspaceFile
-}
-
-func (r *remoteFileMetadataRetriever) Get(steps []batcheslib.Step) ([]cache.MountMetadata, error) {
-	var mountsMetadata []cache.MountMetadata
-	for _, step := range steps {
-		for _, stepMount := range step.Mount {
-			metadata, err := getMountMetadata(r.mounts, stepMount.Path)
-			if err != nil {
-				return nil, err
-			}
-			mountsMetadata = append(mountsMetadata, metadata)
-		}
-	}
-	return mountsMetadata, nil
-}
-
-func getMountMetadata(mounts []*btypes.BatchSpecWorkspaceFile, path string) (metadata cache.MountMetadata, err error) {
-	dir, file := filepath.Split(path)
-	dir = strings.TrimSuffix(dir, string(filepath.Separator))
-	dir = strings.TrimPrefix(dir, fmt.Sprintf(".%s", string(filepath.Separator)))
-	mountPath := filepath.Join(dir, file)
-
-	for _, mount := range mounts {
-		if filepath.Join(mount.Path, mount.FileName) == mountPath {
-			return cache.MountMetadata{
-				Path:     mountPath,
-				Size:     mount.Size,
-				Modified: mount.ModifiedAt,
-			}, nil
-		}
-	}
-	return metadata, errors.New("could not find a matching mount entry")
-}
-
 func changesetSpecsForImports(ctx context.Context, s *store.Store, importChangesets []batcheslib.ImportChangeset, batchSpecID int64, userID int32) ([]*btypes.ChangesetSpec, error) {
 	cs := []*btypes.ChangesetSpec{}
 
@@ -266,7 +266,7 @@ func TestBatchSpecWorkspaceCreatorProcess_Caching(t *testing.T) {
 			workspace.OnlyFetchWorkspace,
 			batchSpec.Spec.Steps,
 			result.StepIndex,
-			&remoteFileMetadataRetriever{mounts: mounts},
+			&service.MountMetadataRetriever{Mounts: mounts},
 		)
 		rawKey, err := key.Key()
 		if err != nil {
@@ -31,6 +31,7 @@ var AdditionalJobs = map[string]job.Job{
 	"batches-scheduler":             batches.NewSchedulerJob(),
 	"batches-reconciler":            batches.NewReconcilerJob(),
 	"batches-bulk-processor":        batches.NewBulkOperationProcessorJob(),
+	"batches-merger":                batches.NewMergerJob(),
 	"batches-workspace-resolver":    batches.NewWorkspaceResolverJob(),
 	"executors-janitor":             executors.NewJanitorJob(),
 	"executors-metricsserver":       executors.NewMetricsServerJob(),
@@ -0,0 +1,268 @@
+// Package merger merges the changesets of batch changes in the merge waves
+// declared in their batch specs.
+package merger
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const mergeInterval = 1 * time.Minute
+
+// mergeRetryInterval is how long to wait before enqueueing the changesets of a
+// wave to be merged again, if merging them didn't succeed.
+const mergeRetryInterval = 30 * time.Minute
+
+// rebaseSettleTime is how long to wait after rebasing the changesets of a wave
+// before merging them. Code hosts rebase asynchronously, and the new check
+// states only reach us once the changesets have been synced, so without it we
+// might merge changesets based on the checks of the commits before the rebase.
+const rebaseSettleTime = 10 * time.Minute
+
+// NewMerger returns a background routine that periodically merges the changesets
+// of open batch changes whose batch spec declares merge waves.
+func NewMerger(ctx context.Context, observationCtx *observation.Context, bstore *store.Store, sourcer sources.Sourcer) goroutine.BackgroundRoutine {
+	m := &merger{
+		logger:  observationCtx.Logger.Scoped("merger", "merges the changesets of batch changes in waves"),
+		store:   bstore,
+		sourcer: sourcer,
+	}
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		"batchchanges.merger", "merges the changesets of batch changes in waves",
+		mergeInterval,
+		goroutine.HandlerFunc(m.mergeAll),
+	)
+}
+
+type merger struct {
+	logger  log.Logger
+	store   *store.Store
+	sourcer sources.Sourcer
+}
+
+func (m *merger) mergeAll(ctx context.Context) error {
+	batchChanges, _, err := m.store.ListBatchChanges(ctx, store.ListBatchChangesOpts{
+		States:             []btypes.BatchChangeState{btypes.BatchChangeStateOpen},
+		OnlyWithMergeWaves: true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing batch changes")
+	}
+
+	var errs error
+	for _, bc := range batchChanges {
+		if err := m.merge(ctx, bc); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "merging changesets of batch change %d", bc.ID))
+		}
+	}
+	return errs
+}
+
+// merge advances the merge wave of the given batch change, rebasing the
+// changesets of a wave once all previous waves are merged, and enqueueing them
+// to be merged once they are ready.
+func (m *merger) merge(ctx context.Context, bc *btypes.BatchChange) error {
+	spec, err := m.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: bc.BatchSpecID})
+	if err != nil {
+		return errors.Wrap(err, "loading batch spec")
+	}
+	waves, err := batcheslib.CompileMergeWaves(spec.Spec.MergeWaves)
+	if err != nil {
+		return errors.Wrap(err, "compiling merge waves")
+	}
+
+	changesets, _, err := m.store.ListChangesets(ctx, store.ListChangesetsOpts{
+		BatchChangeID:        bc.ID,
+		OwnedByBatchChangeID: bc.ID,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing changesets")
+	}
+	repoIDs := make([]api.RepoID, 0, len(changesets))
+	for _, c := range changesets {
+		repoIDs = append(repoIDs, c.RepoID)
+	}
+	repos, err := m.store.Repos().GetReposSetByIDs(ctx, repoIDs...)
+	if err != nil {
+		return errors.Wrap(err, "loading repos")
+	}
+
+	now := m.store.Clock()()
+	p := planMerge(waves, changesets, repos)
+
+	if p.wave != int(bc.MergeWave) {
+		// Only rebase when moving on to the next wave, not when the waves of
+		// the batch spec changed so that an earlier wave has to be merged.
+		if p.wave > int(bc.MergeWave) && p.wave < waves.Len() {
+			m.rebase(ctx, bc, p.unmerged, repos)
+			bc.MergeWaveStartedAt = now
+		} else {
+			bc.MergeWaveStartedAt = time.Time{}
+		}
+		bc.MergeWave = int32(p.wave)
+		bc.MergeWaveEnqueuedAt = time.Time{}
+		if err := m.store.UpdateBatchChangeMergeWave(ctx, bc); err != nil {
+			return errors.Wrap(err, "updating merge wave")
+		}
+		// Give the code hosts time to rebase before merging anything.
+		return nil
+	}
+
+	if p.wave >= waves.Len() || !p.ready {
+		return nil
+	}
+	if !bc.MergeWaveStartedAt.IsZero() && now.Sub(bc.MergeWaveStartedAt) < rebaseSettleTime {
+		return nil
+	}
+	if !bc.MergeWaveEnqueuedAt.IsZero() && now.Sub(bc.MergeWaveEnqueuedAt) < mergeRetryInterval {
+		return nil
+	}
+
+	if err := m.enqueueMerge(ctx, bc, p.unmerged, waves.Wave(p.wave).Squash); err != nil {
+		return err
+	}
+
+	bc.MergeWaveEnqueuedAt = now
+	return errors.Wrap(m.store.UpdateBatchChangeMergeWave(ctx, bc), "updating merge wave")
+}
+
+// enqueueMerge creates changeset jobs that merge the given changesets, which
+// are processed like a merge bulk operation of the last applier of the batch
+// change.
+func (m *merger) enqueueMerge(ctx context.Context, bc *btypes.BatchChange, changesets []*btypes.Changeset, squash bool) (err error) {
+	bulkGroupID, err := store.RandomID()
+	if err != nil {
+		return errors.Wrap(err, "creating bulkGroupID failed")
+	}
+
+	jobs := make([]*btypes.ChangesetJob, 0, len(changesets))
+	for _, c := range changesets {
+		jobs = append(jobs, &btypes.ChangesetJob{
+			BulkGroup:     bulkGroupID,
+			ChangesetID:   c.ID,
+			BatchChangeID: bc.ID,
+			UserID:        bc.LastApplierID,
+			State:         btypes.ChangesetJobStateQueued,
+			JobType:       btypes.ChangesetJobTypeMerge,
+			Payload:       &btypes.ChangesetJobMergePayload{Squash: squash},
+		})
+	}
+
+	m.logger.Info("merging merge wave",
+		log.Int64("batchChangeID", bc.ID),
+		log.Int32("wave", bc.MergeWave),
+		log.Int("changesets", len(changesets)))
+
+	return errors.Wrap(m.store.CreateChangesetJob(ctx, jobs...), "creating changeset jobs")
+}
+
+// rebase asks the code hosts to bring the open changesets of a wave up to date
+// with their base branches, which now contain the changes of the previous
+// wave. Errors are only logged, since the changesets can still be merged if
+// they don't conflict.
+func (m *merger) rebase(ctx context.Context, bc *btypes.BatchChange, changesets []*btypes.Changeset, repos map[api.RepoID]*types.Repo) {
+	// Use the last applier for the operation to enforce repository permissions.
+	ctx = actor.WithActor(ctx, actor.FromUser(bc.LastApplierID))
+
+	for _, c := range changesets {
+		if c.ExternalState != btypes.ChangesetExternalStateOpen {
+			continue
+		}
+		if err := m.rebaseChangeset(ctx, bc, c, repos[c.RepoID]); err != nil {
+			m.logger.Warn("rebasing changeset", log.Int64("changesetID", c.ID), log.Error(err))
+		}
+	}
+}
+
+func (m *merger) rebaseChangeset(ctx context.Context, bc *btypes.BatchChange, c *btypes.Changeset, repo *types.Repo) error {
+	if repo == nil {
+		return errors.New("repo not found")
+	}
+
+	css, err := m.sourcer.ForUser(ctx, m.store, bc.LastApplierID, repo)
+	if err != nil {
+		return errors.Wrap(err, "loading ChangesetSource")
+	}
+	rcss, ok := css.(sources.RebaseableChangesetSource)
+	if !ok {
+		return nil
+	}
+
+	remoteRepo, err := sources.GetRemoteRepo(ctx, css, repo, c, nil)
+	if err != nil {
+		return errors.Wrap(err, "loading remote repo")
+	}
+
+	return rcss.RebaseChangeset(ctx, &sources.Changeset{
+		Changeset:  c,
+		TargetRepo: repo,
+		RemoteRepo: remoteRepo,
+	})
+}
+
+// mergePlan is the state of the merge waves of a batch change.
+type mergePlan struct {
+	// wave is the index of the first wave with unmerged changesets, or the
+	// number of waves if all are merged.
+	wave int
+	// unmerged are the changesets of the wave that are not merged yet.
+	unmerged []*btypes.Changeset
+	// ready is true if all unmerged changesets of the wave can be merged.
+	ready bool
+}
+
+// planMerge determines the wave to merge next. Changesets in repositories that
+// belong to no wave are ignored. A wave is only merged once all of its
+// changesets are merged, so a closed changeset holds back all later waves.
+func planMerge(waves *batcheslib.MergeWaves, changesets []*btypes.Changeset, repos map[api.RepoID]*types.Repo) mergePlan {
+	unmerged := make([][]*btypes.Changeset, waves.Len())
+	for _, c := range changesets {
+		repo, ok := repos[c.RepoID]
+		if !ok || c.ExternalState == btypes.ChangesetExternalStateMerged {
+			continue
+		}
+		if i := waves.Index(string(repo.Name)); i >= 0 {
+			unmerged[i] = append(unmerged[i], c)
+		}
+	}
+
+	for i, cs := range unmerged {
+		if len(cs) == 0 {
+			continue
+		}
+		p := mergePlan{wave: i, unmerged: cs, ready: true}
+		for _, c := range cs {
+			if !mergeable(c) {
+				p.ready = false
+				break
+			}
+		}
+		return p
+	}
+
+	return mergePlan{wave: waves.Len()}
+}
+
+// mergeable returns true if the changeset is open, approved, and its checks
+// passed. Changesets without any checks are considered to have passed them.
+func mergeable(c *btypes.Changeset) bool {
+	return c.Published() &&
+		c.ReconcilerState == btypes.ReconcilerStateCompleted &&
+		c.ExternalState == btypes.ChangesetExternalStateOpen &&
+		c.ExternalReviewState == btypes.ChangesetReviewStateApproved &&
+		(c.ExternalCheckState == btypes.ChangesetCheckStatePassed || c.ExternalCheckState == btypes.ChangesetCheckStateUnknown)
+}
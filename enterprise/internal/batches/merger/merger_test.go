@@ -0,0 +1,123 @@
+package merger
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestPlanMerge(t *testing.T) {
+	waves, err := batcheslib.CompileMergeWaves([]batcheslib.MergeWave{
+		{Repositories: []string{"github.com/sourcegraph/lib"}},
+		{Repositories: []string{"github.com/sourcegraph/*"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repos := map[api.RepoID]*types.Repo{
+		1: {ID: 1, Name: "github.com/sourcegraph/lib"},
+		2: {ID: 2, Name: "github.com/sourcegraph/consumer"},
+		3: {ID: 3, Name: "github.com/other/unrelated"},
+	}
+
+	changeset := func(id int64, repoID api.RepoID, state btypes.ChangesetExternalState, review btypes.ChangesetReviewState, checks btypes.ChangesetCheckState) *btypes.Changeset {
+		return &btypes.Changeset{
+			ID:                  id,
+			RepoID:              repoID,
+			PublicationState:    btypes.ChangesetPublicationStatePublished,
+			ReconcilerState:     btypes.ReconcilerStateCompleted,
+			ExternalState:       state,
+			ExternalReviewState: review,
+			ExternalCheckState:  checks,
+		}
+	}
+	open := btypes.ChangesetExternalStateOpen
+	merged := btypes.ChangesetExternalStateMerged
+	approved := btypes.ChangesetReviewStateApproved
+	pending := btypes.ChangesetReviewStatePending
+	passed := btypes.ChangesetCheckStatePassed
+
+	ids := func(p mergePlan) []int64 {
+		var ids []int64
+		for _, c := range p.unmerged {
+			ids = append(ids, c.ID)
+		}
+		return ids
+	}
+
+	for name, tc := range map[string]struct {
+		changesets []*btypes.Changeset
+		wantWave   int
+		wantIDs    []int64
+		wantReady  bool
+	}{
+		"first wave ready": {
+			changesets: []*btypes.Changeset{
+				changeset(1, 1, open, approved, passed),
+				changeset(2, 2, open, approved, passed),
+			},
+			wantWave:  0,
+			wantIDs:   []int64{1},
+			wantReady: true,
+		},
+		"first wave not approved": {
+			changesets: []*btypes.Changeset{
+				changeset(1, 1, open, pending, passed),
+				changeset(2, 2, open, approved, passed),
+			},
+			wantWave: 0,
+			wantIDs:  []int64{1},
+		},
+		"first wave without checks": {
+			changesets: []*btypes.Changeset{
+				changeset(1, 1, open, approved, btypes.ChangesetCheckStateUnknown),
+			},
+			wantWave:  0,
+			wantIDs:   []int64{1},
+			wantReady: true,
+		},
+		"closed changeset holds back later waves": {
+			changesets: []*btypes.Changeset{
+				changeset(1, 1, btypes.ChangesetExternalStateClosed, approved, passed),
+				changeset(2, 2, open, approved, passed),
+			},
+			wantWave: 0,
+			wantIDs:  []int64{1},
+		},
+		"first wave merged": {
+			changesets: []*btypes.Changeset{
+				changeset(1, 1, merged, approved, passed),
+				changeset(2, 2, open, approved, btypes.ChangesetCheckStatePending),
+			},
+			wantWave: 1,
+			wantIDs:  []int64{2},
+		},
+		"changesets outside of waves are ignored": {
+			changesets: []*btypes.Changeset{
+				changeset(1, 1, merged, approved, passed),
+				changeset(2, 2, merged, approved, passed),
+				changeset(3, 3, open, pending, passed),
+			},
+			wantWave: 2,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p := planMerge(waves, tc.changesets, repos)
+			if p.wave != tc.wantWave {
+				t.Errorf("wrong wave. want=%d, have=%d", tc.wantWave, p.wave)
+			}
+			if diff := cmp.Diff(tc.wantIDs, ids(p)); diff != "" {
+				t.Errorf("wrong unmerged changesets (-want +got):\n%s", diff)
+			}
+			if p.ready != tc.wantReady {
+				t.Errorf("wrong ready. want=%t, have=%t", tc.wantReady, p.ready)
+			}
+		})
+	}
+}
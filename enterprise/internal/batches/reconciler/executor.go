@@ -74,6 +74,8 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
 		return nil
 	}
 
+	previousState := e.ch.ExternalState
+
 	// Load the target repo.
 	//
 	// Note that the remote repo is lazily set when a changeset source is
@@ -145,7 +147,11 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
 		return err
 	}
 
-	return e.tx.UpdateChangeset(ctx, e.ch)
+	if err := e.tx.UpdateChangeset(ctx, e.ch); err != nil {
+		return err
+	}
+
+	return state.EnqueueChangesetStateChangedWebhook(ctx, e.tx.DatabaseDB(), e.targetRepo, e.ch, previousState)
 }
 
 var errCannotPushToArchivedRepo = errcode.MakeNonRetryable(errors.New("cannot push to an archived repo"))
@@ -189,9 +195,32 @@ func (e *executor) pushChangesetPatch(ctx context.Context) (err error) {
 	}
 	opts := buildCommitOpts(e.targetRepo, e.spec, pushConf)
 
+	rcss, isReviewRef := css.(sources.ReviewRefChangesetSource)
+	if isReviewRef {
+		// The title and body of the changeset are part of the commit on
+		// these code hosts, so they need to be decorated before pushing.
+		body, err := e.decorateChangesetBody(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "decorating body for changeset %d", e.ch.ID)
+		}
+
+		rcss.PrepareCommit(&sources.Changeset{
+			Title:      e.spec.Title,
+			Body:       body,
+			BaseRef:    e.spec.BaseRef,
+			HeadRef:    e.spec.HeadRef,
+			RemoteRepo: remoteRepo,
+			TargetRepo: e.targetRepo,
+			Changeset:  e.ch,
+		}, &opts)
+	}
+
 	err = e.pushCommit(ctx, opts)
 	var pce pushCommitError
 	if errors.As(err, &pce) {
+		if isReviewRef && rcss.IsUnchangedPushError(pce.CombinedOutput) {
+			return nil
+		}
 		if acss, ok := css.(sources.ArchivableChangesetSource); ok {
 			if acss.IsArchivedPushError(pce.CombinedOutput) {
 				if err := e.handleArchivedRepo(ctx); err != nil {
@@ -225,13 +254,17 @@ func (e *executor) publishChangeset(ctx context.Context, asDraft bool) (err erro
 	}
 
 	cs := &sources.Changeset{
-		Title:      e.spec.Title,
-		Body:       body,
-		BaseRef:    e.spec.BaseRef,
-		HeadRef:    e.spec.HeadRef,
-		RemoteRepo: remoteRepo,
-		TargetRepo: e.targetRepo,
-		Changeset:  e.ch,
+		Title:         e.spec.Title,
+		Body:          body,
+		BaseRef:       e.spec.BaseRef,
+		HeadRef:       e.spec.HeadRef,
+		Reviewers:     e.spec.Reviewers,
+		TeamReviewers: e.spec.TeamReviewers,
+		Labels:        e.spec.Labels,
+		Assignees:     e.spec.Assignees,
+		RemoteRepo:    remoteRepo,
+		TargetRepo:    e.targetRepo,
+		Changeset:     e.ch,
 	}
 
 	var exists bool
@@ -269,6 +302,11 @@ func (e *executor) publishChangeset(ctx context.Context, asDraft bool) (err erro
 			}
 		}
 	}
+
+	if err := applyChangesetMetadata(ctx, css, cs); err != nil {
+		return err
+	}
+
 	// Set the changeset to published.
 	e.ch.PublicationState = btypes.ChangesetPublicationStatePublished
 	return nil
@@ -343,25 +381,45 @@ func (e *executor) updateChangeset(ctx context.Context) (err error) {
 	// We must construct the sources.Changeset after invoking changesetSource,
 	// since that may change the remoteRepo.
 	cs := sources.Changeset{
-		Title:      e.spec.Title,
-		Body:       body,
-		BaseRef:    e.spec.BaseRef,
-		HeadRef:    e.spec.HeadRef,
-		RemoteRepo: remoteRepo,
-		TargetRepo: e.targetRepo,
-		Changeset:  e.ch,
+		Title:         e.spec.Title,
+		Body:          body,
+		BaseRef:       e.spec.BaseRef,
+		HeadRef:       e.spec.HeadRef,
+		Reviewers:     e.spec.Reviewers,
+		TeamReviewers: e.spec.TeamReviewers,
+		Labels:        e.spec.Labels,
+		Assignees:     e.spec.Assignees,
+		RemoteRepo:    remoteRepo,
+		TargetRepo:    e.targetRepo,
+		Changeset:     e.ch,
 	}
 
 	if err := css.UpdateChangeset(ctx, &cs); err != nil {
 		if errcode.IsArchived(err) {
-			if err := e.handleArchivedRepo(ctx); err != nil {
-				return err
-			}
-		} else {
-			return errors.Wrap(err, "updating changeset")
+			return e.handleArchivedRepo(ctx)
 		}
+		return errors.Wrap(err, "updating changeset")
 	}
 
+	return applyChangesetMetadata(ctx, css, &cs)
+}
+
+// applyChangesetMetadata requests the reviewers and adds the labels and
+// assignees of the given changeset on its code host. Code hosts that don't
+// support any of them are skipped.
+func applyChangesetMetadata(ctx context.Context, css sources.ChangesetSource, cs *sources.Changeset) error {
+	if !cs.HasMetadata() {
+		return nil
+	}
+
+	mcss, ok := css.(sources.MetadataChangesetSource)
+	if !ok {
+		return nil
+	}
+
+	if err := mcss.ApplyChangesetMetadata(ctx, cs); err != nil {
+		return errors.Wrap(err, "applying changeset metadata")
+	}
 	return nil
 }
 
@@ -1166,6 +1166,52 @@ func TestHandleArchivedRepo(t *testing.T) {
 	})
 }
 
+func TestApplyChangesetMetadata(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no metadata", func(t *testing.T) {
+		css := &stesting.FakeChangesetSource{}
+		err := applyChangesetMetadata(ctx, css, &sources.Changeset{Changeset: &btypes.Changeset{}})
+		assert.NoError(t, err)
+		assert.False(t, css.ApplyChangesetMetadataCalled)
+	})
+
+	t.Run("unsupported source", func(t *testing.T) {
+		css := &stesting.FakeChangesetSource{}
+		// Hide ApplyChangesetMetadata, like on code hosts without a concept
+		// of reviewers, labels or assignees.
+		unsupported := struct{ sources.ChangesetSource }{css}
+		err := applyChangesetMetadata(ctx, unsupported, &sources.Changeset{
+			Labels:    []string{"automated"},
+			Changeset: &btypes.Changeset{},
+		})
+		assert.NoError(t, err)
+		assert.False(t, css.ApplyChangesetMetadataCalled)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		css := &stesting.FakeChangesetSource{}
+		cs := &sources.Changeset{
+			Reviewers: []string{"alice"},
+			Labels:    []string{"automated"},
+			Changeset: &btypes.Changeset{},
+		}
+		err := applyChangesetMetadata(ctx, css, cs)
+		assert.NoError(t, err)
+		assert.Equal(t, []*sources.Changeset{cs}, css.MetadataChangesets)
+	})
+
+	t.Run("source error", func(t *testing.T) {
+		want := errors.New("boom")
+		css := &stesting.FakeChangesetSource{Err: want}
+		have := applyChangesetMetadata(ctx, css, &sources.Changeset{
+			Assignees: []string{"carol"},
+			Changeset: &btypes.Changeset{},
+		})
+		assert.ErrorIs(t, have, want)
+	})
+}
+
 func TestBatchChangeURL(t *testing.T) {
 	ctx := context.Background()
 
@@ -176,7 +176,7 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, currentChang
 		pl.AddOp(btypes.ReconcilerOperationReattach)
 	}
 
-	delta := compareChangesetSpecs(previousSpec, currentSpec, wantedChangeset.UiPublicationState)
+	delta := compareChangesetSpecs(previousSpec, currentSpec, wantedChangeset.UiPublicationState, wantedChangeset.Conflicting())
 	pl.Delta = delta
 
 	switch wantedChangeset.PublicationState {
@@ -221,6 +221,10 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, currentChang
 		if delta.AttributesChanged() {
 			if delta.NeedCommitUpdate() {
 				pl.AddOp(btypes.ReconcilerOperationPush)
+			} else if delta.NeedDescriptionUpdate() && btypes.ExternalServiceSupports(wantedChangeset.ExternalServiceType, btypes.CodehostCapabilityCommitDescription) {
+				// The title and body are part of the commit on these code
+				// hosts, and the base ref is where the commit is pushed to.
+				pl.AddOp(btypes.ReconcilerOperationPush)
 			}
 
 			// If we only need to update the diff and we didn't change the state of the changeset,
@@ -274,7 +278,7 @@ func reopenAfterDetach(ch *btypes.Changeset) bool {
 	return ch.AttachedTo(ch.OwnedByBatchChangeID)
 }
 
-func compareChangesetSpecs(previous, current *btypes.ChangesetSpec, uiPublicationState *btypes.ChangesetUiPublicationState) *ChangesetSpecDelta {
+func compareChangesetSpecs(previous, current *btypes.ChangesetSpec, uiPublicationState *btypes.ChangesetUiPublicationState, conflicting bool) *ChangesetSpecDelta {
 	delta := &ChangesetSpecDelta{}
 
 	if previous == nil {
@@ -290,6 +294,12 @@ func compareChangesetSpecs(previous, current *btypes.ChangesetSpec, uiPublicatio
 	if previous.BaseRef != current.BaseRef {
 		delta.BaseRefChanged = true
 	}
+	if !stringsEqual(previous.Reviewers, current.Reviewers) ||
+		!stringsEqual(previous.TeamReviewers, current.TeamReviewers) ||
+		!stringsEqual(previous.Labels, current.Labels) ||
+		!stringsEqual(previous.Assignees, current.Assignees) {
+		delta.MetadataChanged = true
+	}
 
 	// If was set to "draft" and now "true", need to undraft the changeset.
 	// We currently ignore going from "true" to "draft".
@@ -306,6 +316,15 @@ func compareChangesetSpecs(previous, current *btypes.ChangesetSpec, uiPublicatio
 		delta.DiffChanged = true
 	}
 
+	// Changeset specs of the same batch spec with different base revisions
+	// come from executing a workspace again against the new head of the base
+	// branch. If the diff is the same, the commit only has to be recreated on
+	// top of the new head if the code host reports that the changeset can't
+	// be merged as it is.
+	if previous.BatchSpecID == current.BatchSpecID && previous.BaseRev != current.BaseRev && conflicting {
+		delta.RebaseNeeded = true
+	}
+
 	// CommitMessage
 	currentCommitMessage := current.CommitMessage
 	previousCommitMessage := previous.CommitMessage
@@ -330,24 +349,45 @@ func compareChangesetSpecs(previous, current *btypes.ChangesetSpec, uiPublicatio
 	return delta
 }
 
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 type ChangesetSpecDelta struct {
 	TitleChanged         bool
 	BodyChanged          bool
 	Undraft              bool
 	BaseRefChanged       bool
+	RebaseNeeded         bool
 	DiffChanged          bool
 	CommitMessageChanged bool
 	AuthorNameChanged    bool
 	AuthorEmailChanged   bool
+	MetadataChanged      bool
 }
 
 func (d *ChangesetSpecDelta) String() string { return fmt.Sprintf("%#v", d) }
 
 func (d *ChangesetSpecDelta) NeedCommitUpdate() bool {
-	return d.DiffChanged || d.CommitMessageChanged || d.AuthorNameChanged || d.AuthorEmailChanged
+	return d.DiffChanged || d.RebaseNeeded || d.CommitMessageChanged || d.AuthorNameChanged || d.AuthorEmailChanged
 }
 
 func (d *ChangesetSpecDelta) NeedCodeHostUpdate() bool {
+	return d.NeedDescriptionUpdate() || d.MetadataChanged
+}
+
+// NeedDescriptionUpdate returns true if the title, body or base ref of the
+// changeset changed, which are part of the commit on code hosts that review
+// commits.
+func (d *ChangesetSpecDelta) NeedDescriptionUpdate() bool {
 	return d.TitleChanged || d.BodyChanged || d.BaseRefChanged
 }
 
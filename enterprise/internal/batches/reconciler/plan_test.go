@@ -6,6 +6,7 @@ import (
 	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 )
 
 func TestDetermineReconcilerPlan(t *testing.T) {
@@ -218,6 +219,40 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 			},
 			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
 		},
+		{
+			name:         "title changed on published Gerrit changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true, Title: "Before"},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Title: "After"},
+			changeset: bt.TestChangesetOpts{
+				ExternalServiceType: extsvc.TypeGerrit,
+				PublicationState:    btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationUpdate,
+			},
+		},
+		{
+			name:         "labels changed on published changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true, Labels: []string{"before"}},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Labels: []string{"before", "after"}},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+		{
+			name:         "reviewers changed on published Gerrit changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Reviewers: []string{"alice"}},
+			changeset: bt.TestChangesetOpts{
+				ExternalServiceType: extsvc.TypeGerrit,
+				PublicationState:    btypes.ChangesetPublicationStatePublished,
+			},
+			// The reviewers are not part of the commit, so nothing needs to be
+			// pushed.
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
 		{
 			name:         "title changed on read-only changeset",
 			previousSpec: &bt.TestSpecOpts{Published: true, Title: "Before"},
@@ -255,6 +290,67 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 				btypes.ReconcilerOperationSync,
 			},
 		},
+		{
+			name:         "workspace of conflicting changeset executed again against new base revision",
+			previousSpec: &bt.TestSpecOpts{Published: true, BatchSpec: 1, BaseRev: "d34db33f", CommitDiff: []byte("testDiff")},
+			currentSpec:  &bt.TestSpecOpts{Published: true, BatchSpec: 1, BaseRev: "c0ffee", CommitDiff: []byte("testDiff")},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				Metadata:         &github.PullRequest{Mergeable: "CONFLICTING"},
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationSleep,
+				btypes.ReconcilerOperationSync,
+			},
+		},
+		{
+			name:         "workspace of changeset behind its base executed again against new base revision",
+			previousSpec: &bt.TestSpecOpts{Published: true, BatchSpec: 1, BaseRev: "d34db33f", CommitDiff: []byte("testDiff")},
+			currentSpec:  &bt.TestSpecOpts{Published: true, BatchSpec: 1, BaseRev: "c0ffee", CommitDiff: []byte("testDiff")},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				Metadata:         &github.PullRequest{Mergeable: "MERGEABLE", MergeStateStatus: "BEHIND"},
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationSleep,
+				btypes.ReconcilerOperationSync,
+			},
+		},
+		{
+			name:         "workspace of mergeable changeset executed again against new base revision with same diff",
+			previousSpec: &bt.TestSpecOpts{Published: true, BatchSpec: 1, BaseRev: "d34db33f", CommitDiff: []byte("testDiff")},
+			currentSpec:  &bt.TestSpecOpts{Published: true, BatchSpec: 1, BaseRev: "c0ffee", CommitDiff: []byte("testDiff")},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				Metadata:         &github.PullRequest{Mergeable: "MERGEABLE"},
+			},
+			wantOperations: Operations{},
+		},
+		{
+			name:         "workspace of mergeable changeset executed again against new base revision with new diff",
+			previousSpec: &bt.TestSpecOpts{Published: true, BatchSpec: 1, BaseRev: "d34db33f", CommitDiff: []byte("testDiff")},
+			currentSpec:  &bt.TestSpecOpts{Published: true, BatchSpec: 1, BaseRev: "c0ffee", CommitDiff: []byte("newTestDiff")},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				Metadata:         &github.PullRequest{Mergeable: "MERGEABLE"},
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationSleep,
+				btypes.ReconcilerOperationSync,
+			},
+		},
+		{
+			name:         "new batch spec with new base revision but same diff",
+			previousSpec: &bt.TestSpecOpts{Published: true, BatchSpec: 1, BaseRev: "d34db33f", CommitDiff: []byte("testDiff")},
+			currentSpec:  &bt.TestSpecOpts{Published: true, BatchSpec: 2, BaseRev: "c0ffee", CommitDiff: []byte("testDiff")},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{},
+		},
 		{
 			name:         "commit diff changed on merge changeset",
 			previousSpec: &bt.TestSpecOpts{Published: true, CommitDiff: []byte("testDiff")},
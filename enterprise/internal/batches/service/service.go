@@ -24,6 +24,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/batches/template"
@@ -845,7 +846,7 @@ func (s *Service) MoveBatchChange(ctx context.Context, opts MoveBatchChangeOpts)
 	}
 
 	// 🚨 SECURITY: Only the Author of the batch change can move it.
-	if err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
+	if err := auth.CheckSameUserOrPermission(ctx, s.store.DatabaseDB(), batchChange.CreatorID, rbac.BatchChangesAdmin); err != nil {
 		return nil, err
 	}
 	// Check if current user has access to target namespace if set.
@@ -885,7 +886,7 @@ func (s *Service) CloseBatchChange(ctx context.Context, id int64, closeChangeset
 		return batchChange, nil
 	}
 
-	if err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
+	if err := auth.CheckSameUserOrPermission(ctx, s.store.DatabaseDB(), batchChange.CreatorID, rbac.BatchChangesAdmin); err != nil {
 		return nil, err
 	}
 
@@ -927,7 +928,7 @@ func (s *Service) DeleteBatchChange(ctx context.Context, id int64) (err error) {
 		return err
 	}
 
-	if err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
+	if err := auth.CheckSameUserOrPermission(ctx, s.store.DatabaseDB(), batchChange.CreatorID, rbac.BatchChangesAdmin); err != nil {
 		return err
 	}
 
@@ -965,7 +966,7 @@ func (s *Service) EnqueueChangesetSync(ctx context.Context, id int64) (err error
 	)
 
 	for _, c := range batchChanges {
-		err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), c.CreatorID)
+		err := auth.CheckSameUserOrPermission(ctx, s.store.DatabaseDB(), c.CreatorID, rbac.BatchChangesAdmin)
 		if err != nil {
 			authErr = err
 		} else {
@@ -1016,7 +1017,7 @@ func (s *Service) ReenqueueChangeset(ctx context.Context, id int64) (changeset *
 	)
 
 	for _, c := range attachedBatchChanges {
-		err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), c.CreatorID)
+		err := auth.CheckSameUserOrPermission(ctx, s.store.DatabaseDB(), c.CreatorID, rbac.BatchChangesAdmin)
 		if err != nil {
 			authErr = err
 		} else {
@@ -1068,7 +1069,7 @@ func (s *Service) checkNamespaceAccessWithDB(ctx context.Context, db database.DB
 	if namespaceOrgID != 0 {
 		return auth.CheckOrgAccessOrSiteAdmin(ctx, db, namespaceOrgID)
 	} else if namespaceUserID != 0 {
-		return auth.CheckSiteAdminOrSameUser(ctx, db, namespaceUserID)
+		return auth.CheckSameUserOrPermission(ctx, db, namespaceUserID, rbac.BatchChangesAdmin)
 	} else {
 		return ErrNoNamespace
 	}
@@ -1167,7 +1168,7 @@ func (s *Service) CreateChangesetJobs(ctx context.Context, batchChangeID int64,
 	}
 
 	// 🚨 SECURITY: Only the author of the batch change can create jobs.
-	if err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
+	if err := auth.CheckSameUserOrPermission(ctx, s.store.DatabaseDB(), batchChange.CreatorID, rbac.BatchChangesAdmin); err != nil {
 		return bulkGroupID, err
 	}
 
@@ -10,7 +10,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/database/locker"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -64,7 +67,7 @@ func (s *Service) ApplyBatchChange(
 	}
 
 	// 🚨 SECURITY: Only site-admins or the creator of batchSpec can apply it.
-	if err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchSpec.UserID); err != nil {
+	if err := auth.CheckSameUserOrPermission(ctx, s.store.DatabaseDB(), batchSpec.UserID, rbac.BatchChangesAdmin); err != nil {
 		return nil, err
 	}
 
@@ -167,6 +170,20 @@ func (s *Service) ApplyBatchChange(
 		}
 	}
 
+	// Notify outbound webhooks within the transaction, so that they are only
+	// sent if the batch change was applied.
+	webhooks := outbound.NewOutboundWebhookService(tx.DatabaseDB(), keyring.Default().OutboundWebhookKey)
+	if err := webhooks.Enqueue(ctx, outbound.EventTypeBatchChangeApplied, nil, outbound.BatchChangeAppliedData{
+		ID:              batchChange.ID,
+		Name:            batchChange.Name,
+		NamespaceUserID: batchChange.NamespaceUserID,
+		NamespaceOrgID:  batchChange.NamespaceOrgID,
+		BatchSpecID:     batchChange.BatchSpecID,
+		LastApplierID:   batchChange.LastApplierID,
+	}); err != nil {
+		return nil, err
+	}
+
 	return batchChange, nil
 }
 
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution/cache"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// SecretEnvVars returns the values of the executor secrets required by the
+// given batch spec, formatted as environment variables. Reading the secrets
+// creates an access log entry in the name of the user in ctx.
+func SecretEnvVars(ctx context.Context, s *store.Store, spec *btypes.BatchSpec) ([]string, error) {
+	rk := spec.Spec.RequiredEnvVars()
+	if len(rk) == 0 {
+		return []string{}, nil
+	}
+
+	esStore := s.DatabaseDB().ExecutorSecrets(keyring.Default().ExecutorSecretKey)
+	secrets, _, err := esStore.List(ctx, database.ExecutorSecretScopeBatches, database.ExecutorSecretsListOpts{
+		NamespaceUserID: spec.NamespaceUserID,
+		NamespaceOrgID:  spec.NamespaceOrgID,
+		Keys:            rk,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching secrets")
+	}
+
+	esalStore := s.DatabaseDB().ExecutorSecretAccessLogs()
+	envVars := make([]string, len(secrets))
+	for i, secret := range secrets {
+		val, err := secret.Value(ctx, esalStore)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting value for secret")
+		}
+		envVars[i] = fmt.Sprintf("%s=%s", secret.Key, val)
+	}
+	return envVars, nil
+}
+
+// NewMountMetadataRetriever loads the files mounted into the steps of the given
+// batch spec, and returns a cache.MetadataRetriever for them.
+func NewMountMetadataRetriever(ctx context.Context, s *store.Store, batchSpecID int64) (*MountMetadataRetriever, error) {
+	mounts, _, err := s.ListBatchSpecWorkspaceFiles(ctx, store.ListBatchSpecWorkspaceFileOpts{BatchSpecID: batchSpecID})
+	if err != nil {
+		return nil, err
+	}
+	return &MountMetadataRetriever{Mounts: mounts}, nil
+}
+
+// MountMetadataRetriever implements cache.MetadataRetriever for the files
+// uploaded for a batch spec.
+type MountMetadataRetriever struct {
+	Mounts []*btypes.BatchSpecWorkspaceFile
+}
+
+var _ cache.MetadataRetriever = &MountMetadataRetriever{}
+
+func (r *MountMetadataRetriever) Get(steps []batcheslib.Step) ([]cache.MountMetadata, error) {
+	var mountsMetadata []cache.MountMetadata
+	for _, step := range steps {
+		for _, stepMount := range step.Mount {
+			metadata, err := getMountMetadata(r.Mounts, stepMount.Path)
+			if err != nil {
+				return nil, err
+			}
+			mountsMetadata = append(mountsMetadata, metadata)
+		}
+	}
+	return mountsMetadata, nil
+}
+
+func getMountMetadata(mounts []*btypes.BatchSpecWorkspaceFile, path string) (metadata cache.MountMetadata, err error) {
+	dir, file := filepath.Split(path)
+	dir = strings.TrimSuffix(dir, string(filepath.Separator))
+	dir = strings.TrimPrefix(dir, fmt.Sprintf(".%s", string(filepath.Separator)))
+	mountPath := filepath.Join(dir, file)
+
+	for _, mount := range mounts {
+		if filepath.Join(mount.Path, mount.FileName) == mountPath {
+			return cache.MountMetadata{
+				Path:     mountPath,
+				Size:     mount.Size,
+				Modified: mount.ModifiedAt,
+			}, nil
+		}
+	}
+	return metadata, errors.New("could not find a matching mount entry")
+}
+
+// StepCacheKey is the execution cache key of the result of a step in a
+// workspace.
+type StepCacheKey struct {
+	Index int
+	Key   string
+}
+
+// StepCacheKeys returns the execution cache keys of the steps of the batch
+// spec in the given workspace, skipping the steps in skippedSteps. The key of
+// a step covers the repository and its base revision, and all steps up to and
+// including it, so a cached result is reused for as long as none of them
+// changed.
+func StepCacheKeys(
+	spec *btypes.BatchSpec,
+	repo batcheslib.Repository,
+	path string,
+	onlyFetchWorkspace bool,
+	envVars []string,
+	retriever cache.MetadataRetriever,
+	skippedSteps map[int]struct{},
+) ([]StepCacheKey, error) {
+	keys := make([]StepCacheKey, 0, len(spec.Spec.Steps))
+	for i := 0; i < len(spec.Spec.Steps); i++ {
+		if _, ok := skippedSteps[i]; ok {
+			continue
+		}
+
+		key := cache.KeyForWorkspace(
+			&template.BatchChangeAttributes{
+				Name:        spec.Spec.Name,
+				Description: spec.Spec.Description,
+			},
+			repo,
+			path,
+			envVars,
+			onlyFetchWorkspace,
+			spec.Spec.Steps,
+			i,
+			retriever,
+		)
+
+		rawStepKey, err := key.Key()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, StepCacheKey{Index: i, Key: rawStepKey})
+	}
+	return keys, nil
+}
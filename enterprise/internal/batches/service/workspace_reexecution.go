@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/go-diff/diff"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ReexecuteBatchSpecWorkspace enqueues a new execution of the given workspace
+// against commit, the new head of its base branch. It's a no-op if the
+// workspace is still being executed.
+//
+// The changeset specs of the previous execution stay attached to the workspace
+// until the new execution completes, at which point the changesets built from
+// them are updated to the new changeset specs.
+//
+// Cached step results are reused, except for the result of the last step: that
+// step always runs, so that the execution produces the changeset specs. Results
+// cached for the previous base revision of the workspace are reused too, as long
+// as the base branch didn't change any of the files they depend on (see
+// loadStepCacheResults).
+func ReexecuteBatchSpecWorkspace(ctx context.Context, tx *store.Store, client gitserver.Client, workspace *btypes.BatchSpecWorkspace, commit api.CommitID) error {
+	jobs, err := tx.ListBatchSpecWorkspaceExecutionJobs(ctx, store.ListBatchSpecWorkspaceExecutionJobsOpts{
+		BatchSpecWorkspaceIDs: []int64{workspace.ID},
+		ExcludeRank:           true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "loading batch spec workspace execution jobs")
+	}
+	jobIDs := make([]int64, 0, len(jobs))
+	for _, j := range jobs {
+		if !j.State.Retryable() {
+			return nil
+		}
+		jobIDs = append(jobIDs, j.ID)
+	}
+
+	spec, err := tx.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: workspace.BatchSpecID})
+	if err != nil {
+		return errors.Wrap(err, "loading batch spec")
+	}
+
+	// 🚨 SECURITY: Act as the creator of the batch spec, like the initial
+	// execution did, so that repository permissions are enforced and reading
+	// secrets is logged in their name.
+	ctx = actor.WithActor(ctx, actor.FromUser(spec.UserID))
+
+	repo, err := tx.Repos().Get(ctx, workspace.RepoID)
+	if err != nil {
+		return errors.Wrap(err, "loading repo")
+	}
+
+	envVars, err := SecretEnvVars(ctx, tx, spec)
+	if err != nil {
+		return err
+	}
+	retriever, err := NewMountMetadataRetriever(ctx, tx, spec.ID)
+	if err != nil {
+		return err
+	}
+	skippedSteps, err := batcheslib.SkippedStepsForRepo(spec.Spec, string(repo.Name), workspace.FileMatches)
+	if err != nil {
+		return err
+	}
+
+	stepCacheKeys := func(rev string) ([]StepCacheKey, error) {
+		keys, err := StepCacheKeys(
+			spec,
+			batcheslib.Repository{
+				ID:          string(relay.MarshalID("Repository", repo.ID)),
+				Name:        string(repo.Name),
+				BaseRef:     workspace.Branch,
+				BaseRev:     rev,
+				FileMatches: workspace.FileMatches,
+			},
+			workspace.Path,
+			workspace.OnlyFetchWorkspace,
+			envVars,
+			retriever,
+			skippedSteps,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "computing step cache keys")
+		}
+		if len(keys) > 0 {
+			keys = keys[:len(keys)-1]
+		}
+		return keys, nil
+	}
+	keys, err := stepCacheKeys(string(commit))
+	if err != nil {
+		return err
+	}
+	prevKeys, err := stepCacheKeys(workspace.Commit)
+	if err != nil {
+		return err
+	}
+
+	prevCommit := workspace.Commit
+	workspace.Commit = string(commit)
+	workspace.CachedResultFound = false
+	workspace.StepCacheResults = map[int]btypes.StepCacheResult{}
+
+	if err := loadStepCacheResults(ctx, tx, client, repo.Name, workspace, prevCommit, keys, prevKeys, spec.UserID); err != nil {
+		return err
+	}
+
+	if err := tx.UpdateBatchSpecWorkspaceCommit(ctx, workspace); err != nil {
+		return errors.Wrap(err, "updating batch spec workspace")
+	}
+
+	if len(jobIDs) > 0 {
+		if err := tx.DeleteBatchSpecWorkspaceExecutionJobs(ctx, store.DeleteBatchSpecWorkspaceExecutionJobsOpts{IDs: jobIDs}); err != nil {
+			return errors.Wrap(err, "deleting batch spec workspace execution jobs")
+		}
+	}
+
+	return errors.Wrap(
+		tx.CreateBatchSpecWorkspaceExecutionJobsForWorkspaces(ctx, []int64{workspace.ID}),
+		"creating batch spec workspace execution job",
+	)
+}
+
+// cachedStep is the cached result of a step, found either under the cache key of
+// the step for the new base revision of a workspace, or under its key for the
+// previous base revision.
+type cachedStep struct {
+	index   int
+	key     string
+	entryID int64
+	result  *execution.AfterStepResult
+	prevRev bool
+}
+
+// loadStepCacheResults sets the cached results of the steps with the given keys
+// on the workspace, up to the first step without a reusable result.
+//
+// The keys of a step cover the base revision of the workspace, so after the base
+// branch moved, keys only match once the steps were executed against the new
+// base. For a step without such a result, the result cached under its key for
+// the previous base revision, prevKeys, is reused if none of the files it depends
+// on differ between the previous and the new base revision. Steps are assumed to
+// depend on the files changed by them and the steps before them, which is what
+// the cached diff is applied to, and on the files matched by the search query of
+// the workspace.
+func loadStepCacheResults(
+	ctx context.Context,
+	tx *store.Store,
+	client gitserver.Client,
+	repo api.RepoName,
+	workspace *btypes.BatchSpecWorkspace,
+	prevCommit string,
+	keys, prevKeys []StepCacheKey,
+	userID int32,
+) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	rawKeys := make([]string, 0, len(keys)+len(prevKeys))
+	for _, k := range keys {
+		rawKeys = append(rawKeys, k.Key)
+	}
+	for _, k := range prevKeys {
+		rawKeys = append(rawKeys, k.Key)
+	}
+	entries, err := tx.ListBatchSpecExecutionCacheEntries(ctx, store.ListBatchSpecExecutionCacheEntriesOpts{
+		UserID: userID,
+		Keys:   rawKeys,
+	})
+	if err != nil {
+		return errors.Wrap(err, "loading cache entries")
+	}
+	entriesByKey := make(map[string]*btypes.BatchSpecExecutionCacheEntry, len(entries))
+	for _, e := range entries {
+		entriesByKey[e.Key] = e
+	}
+
+	var steps []cachedStep
+	for i, k := range keys {
+		entry, ok := entriesByKey[k.Key]
+		prevRev := false
+		if !ok && i < len(prevKeys) && prevCommit != workspace.Commit {
+			entry, ok = entriesByKey[prevKeys[i].Key]
+			prevRev = true
+		}
+		if !ok {
+			break
+		}
+
+		var res execution.AfterStepResult
+		if err := json.Unmarshal([]byte(entry.Value), &res); err != nil {
+			return err
+		}
+		steps = append(steps, cachedStep{index: k.Index, key: k.Key, entryID: entry.ID, result: &res, prevRev: prevRev})
+	}
+
+	steps, err = reusableCachedSteps(ctx, client, repo, prevCommit, workspace.Commit, workspace.FileMatches, steps)
+	if err != nil {
+		return err
+	}
+
+	used := make([]int64, 0, len(steps))
+	for _, step := range steps {
+		workspace.SetStepCacheResult(step.index+1, btypes.StepCacheResult{Key: step.key, Value: step.result})
+		used = append(used, step.entryID)
+	}
+
+	return tx.MarkUsedBatchSpecExecutionCacheEntries(ctx, used)
+}
+
+// reusableCachedSteps returns the leading steps of the given cached steps whose
+// results still hold at commit. Results cached for prevCommit only hold if the
+// files changed by the cumulative diff of the step, and the given file matches,
+// are the same at both commits.
+func reusableCachedSteps(
+	ctx context.Context,
+	client gitserver.Client,
+	repo api.RepoName,
+	prevCommit, commit string,
+	fileMatches []string,
+	steps []cachedStep,
+) ([]cachedStep, error) {
+	pathsByStep := make(map[int][]string, len(steps))
+	pathSet := map[string]struct{}{}
+	for _, step := range steps {
+		if !step.prevRev {
+			continue
+		}
+		stepPaths, err := diffPaths(step.result.Diff)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing cached diff")
+		}
+		pathsByStep[step.index] = stepPaths
+		for _, path := range stepPaths {
+			pathSet[path] = struct{}{}
+		}
+	}
+	if len(pathsByStep) == 0 {
+		return steps, nil
+	}
+	for _, path := range fileMatches {
+		pathSet[path] = struct{}{}
+	}
+	paths := make([]string, 0, len(pathSet))
+	for path := range pathSet {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	changed, err := changedPaths(ctx, client, repo, prevCommit, commit, paths)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range fileMatches {
+		if _, ok := changed[path]; ok {
+			return cutAtPrevRev(steps), nil
+		}
+	}
+
+	for i, step := range steps {
+		for _, path := range pathsByStep[step.index] {
+			if _, ok := changed[path]; ok {
+				return steps[:i], nil
+			}
+		}
+	}
+	return steps, nil
+}
+
+// cutAtPrevRev returns the leading steps whose results were cached for the new
+// base revision.
+func cutAtPrevRev(steps []cachedStep) []cachedStep {
+	for i, step := range steps {
+		if step.prevRev {
+			return steps[:i]
+		}
+	}
+	return steps
+}
+
+// diffPaths returns the paths of the files changed by the given diff, including
+// both the old and the new path of renamed files.
+func diffPaths(rawDiff []byte) ([]string, error) {
+	fileDiffs, err := diff.ParseMultiFileDiff(rawDiff)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, fd := range fileDiffs {
+		for _, name := range []string{fd.OrigName, fd.NewName} {
+			if name != "/dev/null" {
+				paths = append(paths, name)
+			}
+		}
+	}
+	return paths, nil
+}
+
+// changedPaths returns the set of the given paths that differ between the two
+// given commits.
+func changedPaths(ctx context.Context, client gitserver.Client, repo api.RepoName, base, head string, paths []string) (map[string]struct{}, error) {
+	iter, err := client.Diff(ctx, gitserver.DiffOptions{
+		Repo:      repo,
+		Base:      base,
+		Head:      head,
+		RangeType: "..",
+		Paths:     paths,
+	}, authz.DefaultSubRepoPermsChecker)
+	if err != nil {
+		return nil, errors.Wrap(err, "diffing base revisions")
+	}
+	defer iter.Close()
+
+	changed := map[string]struct{}{}
+	for {
+		fd, err := iter.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Wrap(err, "diffing base revisions")
+		}
+		for _, name := range []string{fd.OrigName, fd.NewName} {
+			changed[name] = struct{}{}
+		}
+	}
+	return changed, nil
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
+)
+
+const testStepDiff = `diff --git README.md README.md
+index 1234567..89abcde 100644
+--- README.md
++++ README.md
+@@ -1 +1 @@
+-Hello
++Hello World
+`
+
+func TestReexecuteBatchSpecWorkspace(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := actor.WithInternalActor(context.Background())
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	s := store.New(db, &observation.TestContext, nil)
+
+	user := bt.CreateTestUser(t, db, false)
+	repo, _ := bt.CreateTestRepo(t, ctx, db)
+
+	spec := &btypes.BatchSpec{
+		UserID:          user.ID,
+		NamespaceUserID: user.ID,
+		Spec: &batcheslib.BatchSpec{
+			Name:       "reexecute",
+			OnConflict: batcheslib.OnConflictReexecute,
+			Steps: []batcheslib.Step{
+				{Run: "sed -i s/Hello/Hello World/ README.md", Container: "alpine:3"},
+				{Run: "echo done", Container: "alpine:3"},
+			},
+		},
+	}
+	if err := s.CreateBatchSpec(ctx, spec); err != nil {
+		t.Fatal(err)
+	}
+
+	workspace := &btypes.BatchSpecWorkspace{
+		BatchSpecID: spec.ID,
+		RepoID:      repo.ID,
+		Branch:      "refs/heads/main",
+		Commit:      "old-base",
+		Path:        "",
+	}
+	if err := s.CreateBatchSpecWorkspace(ctx, workspace); err != nil {
+		t.Fatal(err)
+	}
+
+	// Cache the result of the first step, as executed against the previous base.
+	keys, err := StepCacheKeys(spec, batcheslib.Repository{
+		ID:      string(relay.MarshalID("Repository", repo.ID)),
+		Name:    string(repo.Name),
+		BaseRef: workspace.Branch,
+		BaseRev: "old-base",
+	}, workspace.Path, workspace.OnlyFetchWorkspace, []string{}, &MountMetadataRetriever{}, map[int]struct{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := json.Marshal(execution.AfterStepResult{StepIndex: 0, Diff: []byte(testStepDiff)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := &btypes.BatchSpecExecutionCacheEntry{UserID: user.ID, Key: keys[0].Key, Value: string(value)}
+	if err := s.CreateBatchSpecExecutionCacheEntry(ctx, entry); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name          string
+		changedPaths  []string
+		wantCacheHit  bool
+		newBaseCommit api.CommitID
+	}{
+		{name: "unrelated files changed", changedPaths: []string{"main.go"}, wantCacheHit: true, newBaseCommit: "new-base-1"},
+		{name: "changed file of step changed", changedPaths: []string{"README.md"}, wantCacheHit: false, newBaseCommit: "new-base-2"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := s.UpdateBatchSpecWorkspaceCommit(ctx, &btypes.BatchSpecWorkspace{ID: workspace.ID, Commit: "old-base", StepCacheResults: map[int]btypes.StepCacheResult{}}); err != nil {
+				t.Fatal(err)
+			}
+			ws, err := s.GetBatchSpecWorkspace(ctx, store.GetBatchSpecWorkspaceOpts{ID: workspace.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := gitserver.NewMockClient()
+			client.DiffFunc.SetDefaultHook(func(ctx context.Context, opts gitserver.DiffOptions, checker authz.SubRepoPermissionChecker) (*gitserver.DiffFileIterator, error) {
+				if opts.Base != "old-base" || opts.Head != string(tc.newBaseCommit) {
+					t.Errorf("unexpected diff range: %s..%s", opts.Base, opts.Head)
+				}
+				return newTestDiffIterator(tc.changedPaths), nil
+			})
+
+			if err := ReexecuteBatchSpecWorkspace(ctx, s, client, ws, tc.newBaseCommit); err != nil {
+				t.Fatal(err)
+			}
+
+			ws, err = s.GetBatchSpecWorkspace(ctx, store.GetBatchSpecWorkspaceOpts{ID: workspace.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ws.Commit != string(tc.newBaseCommit) {
+				t.Fatalf("unexpected commit: %q", ws.Commit)
+			}
+
+			res, ok := ws.StepCacheResult(1)
+			if ok != tc.wantCacheHit {
+				t.Fatalf("unexpected cache hit. want=%t have=%t", tc.wantCacheHit, ok)
+			}
+			if ok && string(res.Value.Diff) != testStepDiff {
+				t.Errorf("unexpected cached diff: %s", res.Value.Diff)
+			}
+			if _, ok := ws.StepCacheResult(2); ok {
+				t.Error("the last step should always be executed")
+			}
+
+			jobs, err := s.ListBatchSpecWorkspaceExecutionJobs(ctx, store.ListBatchSpecWorkspaceExecutionJobsOpts{
+				BatchSpecWorkspaceIDs: []int64{workspace.ID},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(jobs) != 1 {
+				t.Fatalf("expected one execution job, have %d", len(jobs))
+			}
+		})
+	}
+}
+
+func TestReusableCachedSteps(t *testing.T) {
+	otherDiff := strings.ReplaceAll(testStepDiff, "README.md", "docs/index.md")
+	steps := []cachedStep{
+		{index: 0, key: "step-0", result: &execution.AfterStepResult{Diff: []byte(testStepDiff)}},
+		{index: 1, key: "step-1", result: &execution.AfterStepResult{Diff: []byte(testStepDiff)}, prevRev: true},
+		{index: 2, key: "step-2", result: &execution.AfterStepResult{Diff: []byte(testStepDiff + otherDiff)}, prevRev: true},
+	}
+	keysOf := func(steps []cachedStep) []string {
+		var keys []string
+		for _, s := range steps {
+			keys = append(keys, s.key)
+		}
+		return keys
+	}
+
+	for _, tc := range []struct {
+		name         string
+		fileMatches  []string
+		changedPaths []string
+		want         []string
+	}{
+		{name: "nothing changed", want: []string{"step-0", "step-1", "step-2"}},
+		{name: "unrelated file changed", changedPaths: []string{"main.go"}, want: []string{"step-0", "step-1", "step-2"}},
+		{name: "file of later step changed", changedPaths: []string{"docs/index.md"}, want: []string{"step-0", "step-1"}},
+		{name: "file of first step changed", changedPaths: []string{"README.md"}, want: []string{"step-0"}},
+		{name: "file match changed", fileMatches: []string{"main.go"}, changedPaths: []string{"main.go"}, want: []string{"step-0"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := gitserver.NewMockClient()
+			var diffedPaths []string
+			client.DiffFunc.SetDefaultHook(func(ctx context.Context, opts gitserver.DiffOptions, checker authz.SubRepoPermissionChecker) (*gitserver.DiffFileIterator, error) {
+				diffedPaths = opts.Paths
+				return newTestDiffIterator(tc.changedPaths), nil
+			})
+
+			have, err := reusableCachedSteps(context.Background(), client, "github.com/sourcegraph/test", "old-base", "new-base", tc.fileMatches, steps)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, keysOf(have)); diff != "" {
+				t.Errorf("unexpected steps (-want +got):\n%s", diff)
+			}
+
+			// Only the files the steps depend on are compared.
+			wantPaths := append([]string{"README.md", "docs/index.md"}, tc.fileMatches...)
+			sort.Strings(wantPaths)
+			if diff := cmp.Diff(wantPaths, diffedPaths); diff != "" {
+				t.Errorf("unexpected diffed paths (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("no results of the previous base", func(t *testing.T) {
+		client := gitserver.NewMockClient()
+		have, err := reusableCachedSteps(context.Background(), client, "github.com/sourcegraph/test", "old-base", "new-base", nil, steps[:1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"step-0"}, keysOf(have)); diff != "" {
+			t.Errorf("unexpected steps (-want +got):\n%s", diff)
+		}
+		if len(client.DiffFunc.History()) != 0 {
+			t.Error("unexpected call to Diff")
+		}
+	})
+}
+
+// newTestDiffIterator returns a diff iterator yielding a modification of each of
+// the given paths.
+func newTestDiffIterator(paths []string) *gitserver.DiffFileIterator {
+	var b strings.Builder
+	for _, path := range paths {
+		b.WriteString(strings.ReplaceAll(testStepDiff, "README.md", path))
+	}
+	return gitserver.NewDiffFileIterator(io.NopCloser(strings.NewReader(b.String())))
+}
@@ -0,0 +1,282 @@
+package sources
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type AzureDevOpsSource struct {
+	client azuredevops.Client
+
+	// orgs are the organizations configured in the external service, used to
+	// validate authenticators.
+	orgs []string
+}
+
+var (
+	_ DraftChangesetSource = AzureDevOpsSource{}
+)
+
+func NewAzureDevOpsSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*AzureDevOpsSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.AzureDevOpsConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	client, err := azuredevops.NewClient(svc.URN(), &c, cli)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating Azure DevOps client")
+	}
+
+	orgs := append([]string{}, c.Orgs...)
+	for _, p := range c.Projects {
+		org, _, _ := strings.Cut(p, "/")
+		orgs = append(orgs, org)
+	}
+
+	return &AzureDevOpsSource{client: client, orgs: orgs}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s AzureDevOpsSource) GitserverPushConfig(repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(repo, s.client.Authenticator())
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s AzureDevOpsSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("AzureDevOpsSource", a)
+	}
+
+	return &AzureDevOpsSource{client: s.client.WithAuthenticator(a), orgs: s.orgs}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+func (s AzureDevOpsSource) ValidateAuthenticator(ctx context.Context) error {
+	// Azure DevOps has no API that is available outside an organization, so
+	// the authenticator is validated against the configured organizations.
+	for _, org := range s.orgs {
+		if err := s.client.Ping(ctx, org); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s AzureDevOpsSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	repo := cs.TargetRepo.Metadata.(*azuredevops.Repository)
+	id, err := strconv.Atoi(cs.ExternalID)
+	if err != nil {
+		return errors.Wrapf(err, "converting external ID %q", cs.ExternalID)
+	}
+
+	pr, err := s.client.GetPullRequest(ctx, repo, id)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, repo, pr, cs)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s AzureDevOpsSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	return s.createChangeset(ctx, cs, false)
+}
+
+// CreateDraftChangeset creates the given changeset on the code host in draft
+// mode.
+func (s AzureDevOpsSource) CreateDraftChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	return s.createChangeset(ctx, cs, true)
+}
+
+func (s AzureDevOpsSource) createChangeset(ctx context.Context, cs *Changeset, draft bool) (bool, error) {
+	repo := cs.TargetRepo.Metadata.(*azuredevops.Repository)
+	input := azuredevops.PullRequestInput{
+		SourceRefName: gitdomain.EnsureRefPrefix(cs.HeadRef),
+		TargetRefName: gitdomain.EnsureRefPrefix(cs.BaseRef),
+		Title:         cs.Title,
+		Description:   cs.Body,
+		IsDraft:       draft,
+	}
+
+	exists := false
+	pr, err := s.client.CreatePullRequest(ctx, repo, input)
+	if err != nil {
+		if !azuredevops.IsConflict(err) {
+			return false, errors.Wrap(err, "creating pull request")
+		}
+
+		// An active pull request already exists for the branches, which we
+		// adopt.
+		exists = true
+		pr, err = s.client.FindActivePullRequest(ctx, repo, input.SourceRefName, input.TargetRefName)
+		if err != nil {
+			return false, errors.Wrap(err, "fetching existing pull request")
+		}
+	}
+
+	if err := s.setChangesetMetadata(ctx, repo, pr, cs); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// UndraftChangeset will update the Changeset on the source to be not in draft
+// mode anymore.
+func (s AzureDevOpsSource) UndraftChangeset(ctx context.Context, cs *Changeset) error {
+	isDraft := false
+	return s.updatePullRequest(ctx, cs, azuredevops.PullRequestUpdateInput{IsDraft: &isDraft}, "undrafting pull request")
+}
+
+// CloseChangeset will close the Changeset on the source, where "close"
+// means the appropriate final state on the codehost (e.g. "abandoned" on
+// Azure DevOps).
+func (s AzureDevOpsSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	return s.updatePullRequest(ctx, cs, azuredevops.PullRequestUpdateInput{
+		Status: azuredevops.PullRequestStatusAbandoned,
+	}, "abandoning pull request")
+}
+
+// UpdateChangeset can update Changesets.
+func (s AzureDevOpsSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	targetRefName := gitdomain.EnsureRefPrefix(cs.BaseRef)
+	return s.updatePullRequest(ctx, cs, azuredevops.PullRequestUpdateInput{
+		Title:         &cs.Title,
+		Description:   &cs.Body,
+		TargetRefName: &targetRefName,
+	}, "updating pull request")
+}
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+func (s AzureDevOpsSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	pr := cs.Metadata.(*adobatches.AnnotatedPullRequest)
+	if pr.Status == azuredevops.PullRequestStatusActive {
+		return nil
+	}
+
+	return s.updatePullRequest(ctx, cs, azuredevops.PullRequestUpdateInput{
+		Status: azuredevops.PullRequestStatusActive,
+	}, "reactivating pull request")
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s AzureDevOpsSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	repo := cs.TargetRepo.Metadata.(*azuredevops.Repository)
+	pr := cs.Metadata.(*adobatches.AnnotatedPullRequest)
+
+	return s.client.CreatePullRequestComment(ctx, repo, pr.ID, comment)
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// If squash is true, and the code host supports squash merges, the source
+// must attempt a squash merge. Otherwise, it is expected to perform a regular
+// merge. If the changeset cannot be merged, because it is in an unmergeable
+// state, ChangesetNotMergeableError must be returned.
+func (s AzureDevOpsSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	repo := cs.TargetRepo.Metadata.(*azuredevops.Repository)
+	pr := cs.Metadata.(*adobatches.AnnotatedPullRequest)
+
+	mergeStrategy := azuredevops.MergeStrategyNoFastForward
+	if squash {
+		mergeStrategy = azuredevops.MergeStrategySquash
+	}
+
+	// Azure DevOps only completes a pull request if the last merge source
+	// commit matches the head of the source branch, which guards against
+	// merging commits that weren't synced yet.
+	updated, err := s.client.UpdatePullRequest(ctx, repo, pr.ID, azuredevops.PullRequestUpdateInput{
+		Status:                azuredevops.PullRequestStatusCompleted,
+		LastMergeSourceCommit: pr.LastMergeSourceCommit,
+		CompletionOptions:     &azuredevops.CompletionOptions{MergeStrategy: mergeStrategy},
+	})
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return errors.Wrap(err, "merging pull request")
+		}
+		return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+	}
+
+	return s.setChangesetMetadata(ctx, repo, updated, cs)
+}
+
+func (s AzureDevOpsSource) updatePullRequest(ctx context.Context, cs *Changeset, input azuredevops.PullRequestUpdateInput, action string) error {
+	repo := cs.TargetRepo.Metadata.(*azuredevops.Repository)
+	pr := cs.Metadata.(*adobatches.AnnotatedPullRequest)
+
+	updated, err := s.client.UpdatePullRequest(ctx, repo, pr.ID, input)
+	if err != nil {
+		return errors.Wrap(err, action)
+	}
+
+	return s.setChangesetMetadata(ctx, repo, updated, cs)
+}
+
+func (s AzureDevOpsSource) annotatePullRequest(ctx context.Context, repo *azuredevops.Repository, pr *azuredevops.PullRequest) (*adobatches.AnnotatedPullRequest, error) {
+	statuses, err := s.client.GetPullRequestStatuses(ctx, repo, pr.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting pull request statuses")
+	}
+
+	return &adobatches.AnnotatedPullRequest{
+		PullRequest: pr,
+		Statuses:    statuses,
+		WebURL:      repo.WebURL + "/pullrequest/" + strconv.Itoa(pr.ID),
+	}, nil
+}
+
+func (s AzureDevOpsSource) setChangesetMetadata(ctx context.Context, repo *azuredevops.Repository, pr *azuredevops.PullRequest, cs *Changeset) error {
+	apr, err := s.annotatePullRequest(ctx, repo, pr)
+	if err != nil {
+		return errors.Wrap(err, "annotating pull request")
+	}
+
+	if err := cs.SetMetadata(apr); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
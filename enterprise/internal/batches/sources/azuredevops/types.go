@@ -0,0 +1,35 @@
+package azuredevops
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+)
+
+// AnnotatedPullRequest adds metadata we need that lives outside the main
+// PullRequest type returned by the Azure DevOps API alongside the pull
+// request. This type is used as the primary metadata type for Azure DevOps
+// changesets.
+type AnnotatedPullRequest struct {
+	*azuredevops.PullRequest
+	Statuses []*azuredevops.PullRequestStatus
+	// WebURL is the URL of the pull request in the Azure DevOps web UI, which
+	// the API doesn't return.
+	WebURL string
+}
+
+// UpdatedAt returns the last time the pull request or one of its statuses
+// changed, as far as the Azure DevOps API tells: pull requests have no update
+// timestamp of their own.
+func (pr *AnnotatedPullRequest) UpdatedAt() time.Time {
+	updated := pr.CreationDate
+	if pr.ClosedDate.After(updated) {
+		updated = pr.ClosedDate
+	}
+	for _, s := range pr.Statuses {
+		if s.UpdatedDate.After(updated) {
+			updated = s.UpdatedDate
+		}
+	}
+	return updated
+}
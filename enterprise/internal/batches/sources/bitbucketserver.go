@@ -24,6 +24,7 @@ type BitbucketServerSource struct {
 }
 
 var _ ForkableChangesetSource = BitbucketServerSource{}
+var _ MetadataChangesetSource = BitbucketServerSource{}
 
 // NewBitbucketServerSource returns a new BitbucketServerSource from the given external service.
 func NewBitbucketServerSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*BitbucketServerSource, error) {
@@ -239,6 +240,52 @@ func (s BitbucketServerSource) UpdateChangeset(ctx context.Context, c *Changeset
 	return c.Changeset.SetMetadata(updated)
 }
 
+// ApplyChangesetMetadata adds the reviewers of the given *Changeset to the pull
+// request. Bitbucket Server has no concept of team reviewers, labels or
+// assignees, so those are ignored.
+func (s BitbucketServerSource) ApplyChangesetMetadata(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*bitbucketserver.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Server pull request")
+	}
+
+	// Bitbucket Server rejects the author of a pull request as a reviewer.
+	skip := map[string]struct{}{}
+	if pr.Author.User != nil {
+		skip[strings.ToLower(pr.Author.User.Name)] = struct{}{}
+	}
+	for _, r := range pr.Reviewers {
+		if r.User != nil {
+			skip[strings.ToLower(r.User.Name)] = struct{}{}
+		}
+	}
+
+	added := false
+	for _, reviewer := range c.Reviewers {
+		if _, ok := skip[strings.ToLower(reviewer)]; ok {
+			continue
+		}
+		skip[strings.ToLower(reviewer)] = struct{}{}
+
+		if err := s.client.AddPullRequestReviewer(ctx, pr, reviewer); err != nil {
+			return errors.Wrapf(err, "adding reviewer %q", reviewer)
+		}
+		added = true
+	}
+	if !added {
+		return nil
+	}
+
+	if err := s.client.LoadPullRequest(ctx, pr); err != nil {
+		return errors.Wrap(err, "reloading pull request")
+	}
+	if err := s.loadPullRequestData(ctx, pr); err != nil {
+		return errors.Wrap(err, "loading pull request data")
+	}
+
+	return c.Changeset.SetMetadata(pr)
+}
+
 // ReopenChangeset reopens the *Changeset on the code host and updates the
 // Metadata column in the *batches.Changeset.
 func (s BitbucketServerSource) ReopenChangeset(ctx context.Context, c *Changeset) error {
@@ -2,7 +2,10 @@ package sources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -14,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/testutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -466,6 +470,67 @@ func TestBitbucketServerSource_UpdateChangeset(t *testing.T) {
 	}
 }
 
+func TestBitbucketServerSource_ApplyChangesetMetadata(t *testing.T) {
+	var added []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/pull-requests/42/participants"):
+			var body struct {
+				User struct{ Name string }
+				Role string
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decoding request body: %v", err)
+			}
+			assert.Equal(t, "REVIEWER", body.Role)
+			added = append(added, body.User.Name)
+			_, _ = w.Write([]byte(`{}`))
+		case strings.HasSuffix(r.URL.Path, "/pull-requests/42"):
+			_, _ = w.Write([]byte(`{"id": 42, "version": 2, "reviewers": [{"user": {"name": "alice"}}, {"user": {"name": "bob"}}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"values": [], "isLastPage": true}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	svc := &types.ExternalService{
+		Kind: extsvc.KindBitbucketServer,
+		Config: extsvc.NewUnencryptedConfig(marshalJSON(t, &schema.BitbucketServerConnection{
+			Url:   srv.URL,
+			Token: "secret",
+		})),
+	}
+
+	ctx := context.Background()
+	src, err := NewBitbucketServerSource(ctx, svc, httpcli.NewFactory(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pr := &bitbucketserver.PullRequest{
+		ID:        42,
+		Version:   1,
+		Author:    bitbucketserver.PullRequestAuthor{User: &bitbucketserver.User{Name: "author"}},
+		Reviewers: []bitbucketserver.Reviewer{{User: &bitbucketserver.User{Name: "alice"}}},
+	}
+	pr.ToRef.Repository.Slug = "repo"
+	pr.ToRef.Repository.Project.Key = "PROJ"
+
+	cs := &Changeset{
+		// Existing reviewers and the author are skipped, and labels are
+		// ignored.
+		Reviewers: []string{"author", "Alice", "bob"},
+		Labels:    []string{"automated"},
+		Changeset: &btypes.Changeset{Metadata: pr},
+	}
+	if err := src.ApplyChangesetMetadata(ctx, cs); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"bob"}, added)
+	assert.Equal(t, 2, cs.Changeset.Metadata.(*bitbucketserver.PullRequest).Version)
+}
+
 func TestBitbucketServerSource_CreateComment(t *testing.T) {
 	instanceURL := os.Getenv("BITBUCKET_SERVER_URL")
 	if instanceURL == "" {
@@ -36,6 +36,23 @@ type ArchivableChangesetSource interface {
 	IsArchivedPushError(output string) bool
 }
 
+// A ReviewRefChangesetSource is a changeset source for code hosts that don't
+// review branches, but commits pushed to a special ref, such as Gerrit's
+// refs/for/<branch>. On these code hosts, the title and body of a changeset
+// are part of its commit.
+type ReviewRefChangesetSource interface {
+	ChangesetSource
+
+	// PrepareCommit updates the options used to create and push the commit of
+	// the given changeset.
+	PrepareCommit(cs *Changeset, opts *protocol.CreateCommitFromPatchRequest)
+
+	// IsUnchangedPushError parses the given error output from `git push` to
+	// detect whether the push was rejected because the code host already
+	// has the commit.
+	IsUnchangedPushError(output string) bool
+}
+
 // A DraftChangesetSource can create draft changesets and undraft them.
 type DraftChangesetSource interface {
 	ChangesetSource
@@ -61,6 +78,31 @@ type ForkableChangesetSource interface {
 	GetUserFork(ctx context.Context, targetRepo *types.Repo) (*types.Repo, error)
 }
 
+// A MetadataChangesetSource can request reviews on changesets and add labels
+// and assignees to them. Sources that don't implement it ignore the metadata of
+// changesets, as do sources that implement it for metadata their code host has
+// no concept of.
+type MetadataChangesetSource interface {
+	ChangesetSource
+
+	// ApplyChangesetMetadata requests reviews from the reviewers and team
+	// reviewers of the given Changeset, and adds its labels and assignees on
+	// the code host. Metadata that is already present, or that was added on
+	// the code host by other means, is left untouched.
+	ApplyChangesetMetadata(context.Context, *Changeset) error
+}
+
+// A RebaseableChangesetSource can bring the head branch of a changeset up to
+// date with its base branch on the code host.
+type RebaseableChangesetSource interface {
+	ChangesetSource
+
+	// RebaseChangeset asks the code host to update the head branch of the
+	// given Changeset with the latest commits of its base branch. Code hosts
+	// perform the update asynchronously, so the Changeset is not updated.
+	RebaseChangeset(context.Context, *Changeset) error
+}
+
 // A ChangesetSource can load the latest state of a list of Changesets.
 type ChangesetSource interface {
 	// GitserverPushConfig returns an authenticated push config used for pushing
@@ -121,6 +163,11 @@ type Changeset struct {
 	HeadRef string
 	BaseRef string
 
+	Reviewers     []string
+	TeamReviewers []string
+	Labels        []string
+	Assignees     []string
+
 	// RemoteRepo is the repository the branch will be pushed to. This must be
 	// the same as TargetRepo if forking is not in use.
 	RemoteRepo *types.Repo
@@ -131,6 +178,12 @@ type Changeset struct {
 	*btypes.Changeset
 }
 
+// HasMetadata returns true when the Changeset has reviewers, team reviewers,
+// labels or assignees to apply.
+func (c *Changeset) HasMetadata() bool {
+	return len(c.Reviewers) > 0 || len(c.TeamReviewers) > 0 || len(c.Labels) > 0 || len(c.Assignees) > 0
+}
+
 // IsOutdated returns true when the attributes of the nested
 // batches.Changeset do not match the attributes (title, body, ...) set on
 // the Changeset.
@@ -0,0 +1,305 @@
+package sources
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"strings"
+
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// GerritSource publishes changesets as Gerrit changes. Instead of pushing a
+// branch, the commit of a changeset is pushed to refs/for/<base branch> with a
+// Change-Id footer derived from the changeset, so that every push creates a
+// new patch set of the same change.
+type GerritSource struct {
+	client *gerrit.Client
+}
+
+var (
+	_ DraftChangesetSource     = GerritSource{}
+	_ ReviewRefChangesetSource = GerritSource{}
+)
+
+func NewGerritSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GerritSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GerritConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	client, err := gerrit.NewClient(svc.URN(), &c, cli)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating Gerrit client")
+	}
+
+	return &GerritSource{client: client}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s GerritSource) GitserverPushConfig(repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(repo, s.client.Authenticator())
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s GerritSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("GerritSource", a)
+	}
+
+	client, err := s.client.WithAuthenticator(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GerritSource{client: client}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+func (s GerritSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.GetAuthenticatedUserAccount(ctx)
+	return err
+}
+
+// PrepareCommit sets the commit message to the title and body of the
+// changeset, followed by its Change-Id, and pushes the commit for review on
+// the base branch.
+func (s GerritSource) PrepareCommit(cs *Changeset, opts *protocol.CreateCommitFromPatchRequest) {
+	opts.CommitInfo.Message = gerritbatches.CommitMessage(cs.Title, cs.Body, gerritChangeID(cs))
+	if opts.Push != nil {
+		opts.Push.PushRef = "refs/for/" + gerritBranch(cs.BaseRef)
+	}
+}
+
+// IsUnchangedPushError returns true if Gerrit rejected the push because the
+// commit is already a patch set of the change, which happens when pushing the
+// same changeset twice.
+func (s GerritSource) IsUnchangedPushError(output string) bool {
+	return strings.Contains(output, "no new changes")
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s GerritSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	change, err := s.client.GetChange(ctx, cs.ExternalID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting change")
+	}
+
+	return s.setChangesetMetadata(change, cs)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+//
+// Gerrit creates the change when its commit is pushed, so this loads the
+// change and reports whether it existed before the last push.
+func (s GerritSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	change, err := s.getPushedChange(ctx, cs)
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.setChangesetMetadata(change, cs); err != nil {
+		return false, err
+	}
+
+	rev, _ := change.CurrentRevisionInfo()
+	return rev.Number > 1, nil
+}
+
+// CreateDraftChangeset creates the given changeset on the code host as a work
+// in progress change.
+func (s GerritSource) CreateDraftChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	change, err := s.getPushedChange(ctx, cs)
+	if err != nil {
+		return false, err
+	}
+
+	if !change.WorkInProgress {
+		if err := s.client.SetWorkInProgress(ctx, strconv.Itoa(change.Number)); err != nil {
+			return false, errors.Wrap(err, "marking change as work in progress")
+		}
+		if change, err = s.client.GetChange(ctx, strconv.Itoa(change.Number)); err != nil {
+			return false, errors.Wrap(err, "getting change")
+		}
+	}
+
+	if err := s.setChangesetMetadata(change, cs); err != nil {
+		return false, err
+	}
+
+	rev, _ := change.CurrentRevisionInfo()
+	return rev.Number > 1, nil
+}
+
+// UndraftChangeset will update the Changeset on the source to be not in draft
+// mode anymore.
+func (s GerritSource) UndraftChangeset(ctx context.Context, cs *Changeset) error {
+	if err := s.client.SetReadyForReview(ctx, cs.ExternalID); err != nil {
+		return errors.Wrap(err, "marking change as ready for review")
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// CloseChangeset will close the Changeset on the source, where "close"
+// means the appropriate final state on the codehost (e.g. "abandoned" on
+// Gerrit).
+func (s GerritSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+	if change.Status == gerrit.ChangeStatusNew {
+		if err := s.client.AbandonChange(ctx, cs.ExternalID); err != nil {
+			return errors.Wrap(err, "abandoning change")
+		}
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// UpdateChangeset can update Changesets.
+//
+// The title and body of a Gerrit change are its commit message, which is
+// updated by pushing the commit, so this only needs to load the change. If
+// the base branch changed, the push created a new change on the new branch,
+// and the change on the previous branch is abandoned.
+func (s GerritSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	change, err := s.getPushedChange(ctx, cs)
+	if err != nil {
+		return err
+	}
+
+	if previous := cs.ExternalID; previous != strconv.Itoa(change.Number) {
+		if prev, ok := cs.Metadata.(*gerritbatches.AnnotatedChange); ok && prev.Status == gerrit.ChangeStatusNew {
+			if err := s.client.AbandonChange(ctx, previous); err != nil {
+				return errors.Wrap(err, "abandoning change on previous base branch")
+			}
+		}
+	}
+
+	return s.setChangesetMetadata(change, cs)
+}
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+func (s GerritSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+	if change.Status != gerrit.ChangeStatusAbandoned {
+		return nil
+	}
+
+	if err := s.client.RestoreChange(ctx, cs.ExternalID); err != nil {
+		return errors.Wrap(err, "restoring change")
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s GerritSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	return s.client.CreateChangeComment(ctx, cs.ExternalID, comment)
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// Gerrit changes are submitted with the submit type configured for the
+// project, so squash is ignored. If the changeset cannot be merged, because
+// it is in an unmergeable state, ChangesetNotMergeableError is returned.
+func (s GerritSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	if err := s.client.SubmitChange(ctx, cs.ExternalID); err != nil {
+		if gerrit.IsConflict(err) {
+			return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+		}
+		return errors.Wrap(err, "submitting change")
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// getPushedChange returns the change created or updated by the last push of
+// the changeset's commit.
+func (s GerritSource) getPushedChange(ctx context.Context, cs *Changeset) (*gerrit.Change, error) {
+	project, err := gerritProject(cs.TargetRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := s.client.GetChange(ctx, gerrit.ChangeTriplet(project, gerritBranch(cs.BaseRef), gerritChangeID(cs)))
+	if err != nil {
+		return nil, errors.Wrap(err, "getting pushed change")
+	}
+	return change, nil
+}
+
+func (s GerritSource) setChangesetMetadata(change *gerrit.Change, cs *Changeset) error {
+	u := *s.client.URL
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/c/" + change.Project + "/+/" + strconv.Itoa(change.Number)
+
+	if err := cs.SetMetadata(&gerritbatches.AnnotatedChange{
+		Change: change,
+		WebURL: u.String(),
+	}); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
+
+// gerritChangeID returns the Change-Id of the change for the given changeset.
+// It's derived from the target repository and the head ref of the changeset
+// spec, which are stable across pushes of the changeset.
+func gerritChangeID(cs *Changeset) string {
+	sum := sha1.Sum([]byte(cs.TargetRepo.ExternalRepo.ID + "\x00" + gitdomain.EnsureRefPrefix(cs.HeadRef)))
+	return "I" + hex.EncodeToString(sum[:])
+}
+
+// gerritProject returns the name of the Gerrit project of the given repo.
+func gerritProject(repo *types.Repo) (string, error) {
+	project, ok := repo.Metadata.(*gerrit.Project)
+	if !ok {
+		return "", errors.Errorf("repo %q has no Gerrit project metadata", repo.Name)
+	}
+	// Gerrit encodes slashes in project IDs.
+	return url.PathUnescape(project.ID)
+}
+
+func gerritBranch(ref string) string {
+	return strings.TrimPrefix(gitdomain.EnsureRefPrefix(ref), "refs/heads/")
+}
@@ -0,0 +1,55 @@
+package gerrit
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+)
+
+// AnnotatedChange adds metadata we need that lives outside the main Change
+// type returned by the Gerrit API alongside the change. This type is used as
+// the primary metadata type for Gerrit changesets.
+type AnnotatedChange struct {
+	*gerrit.Change
+	// WebURL is the URL of the change in the Gerrit web UI, which the API
+	// doesn't return.
+	WebURL string
+}
+
+// Body returns the commit message of the current patch set without its
+// subject and its Change-Id footer, which is the changeset body Sourcegraph
+// used to create it.
+func (c *AnnotatedChange) Body() string {
+	rev, ok := c.CurrentRevisionInfo()
+	if !ok {
+		return ""
+	}
+
+	_, body, _ := strings.Cut(rev.Commit.Message, "\n\n")
+	body = strings.TrimSpace(body)
+
+	// Gerrit requires the Change-Id footer to be in the last paragraph of the
+	// commit message.
+	lines := strings.Split(body, "\n")
+	last := len(lines) - 1
+	if strings.HasPrefix(lines[last], "Change-Id: ") {
+		body = strings.TrimSpace(strings.Join(lines[:last], "\n"))
+	}
+	return body
+}
+
+// CommitMessage returns the commit message for a change with the given
+// subject, body and Change-Id.
+func CommitMessage(subject, body, changeID string) string {
+	var b strings.Builder
+	b.WriteString(strings.TrimSpace(subject))
+	b.WriteString("\n\n")
+	if body = strings.TrimSpace(body); body != "" {
+		b.WriteString(body)
+		b.WriteString("\n\n")
+	}
+	b.WriteString("Change-Id: ")
+	b.WriteString(changeID)
+	b.WriteString("\n")
+	return b.String()
+}
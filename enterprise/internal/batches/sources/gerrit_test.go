@@ -0,0 +1,281 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestNewGerritSource(t *testing.T) {
+	for name, input := range map[string]string{
+		"invalid JSON":   "invalid JSON",
+		"invalid schema": `{"url": ["not a string"]}`,
+		"bad URL":        `{"url": "http://[::1]:namedport"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			s, err := NewGerritSource(context.Background(), &types.ExternalService{
+				Config: extsvc.NewUnencryptedConfig(input),
+			}, nil)
+			assert.Nil(t, s)
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestGerritSource_WithAuthenticator(t *testing.T) {
+	s, _ := newFakeGerritSource(t)
+
+	t.Run("unsupported types", func(t *testing.T) {
+		for _, au := range []auth.Authenticator{
+			&auth.OAuthBearerToken{},
+			&auth.OAuthBearerTokenWithSSH{},
+			&auth.OAuthClient{},
+		} {
+			t.Run(fmt.Sprintf("%T", au), func(t *testing.T) {
+				newSource, err := s.WithAuthenticator(au)
+				assert.Nil(t, newSource)
+				assert.ErrorAs(t, err, &UnsupportedAuthenticatorError{})
+			})
+		}
+	})
+
+	t.Run("supported types", func(t *testing.T) {
+		for _, au := range []auth.Authenticator{
+			&auth.BasicAuth{Username: "user", Password: "http-password"},
+			&auth.BasicAuthWithSSH{BasicAuth: auth.BasicAuth{Username: "user", Password: "http-password"}},
+		} {
+			t.Run(fmt.Sprintf("%T", au), func(t *testing.T) {
+				newSource, err := s.WithAuthenticator(au)
+				require.NoError(t, err)
+				assert.Equal(t, au, newSource.(*GerritSource).client.Authenticator())
+			})
+		}
+	})
+}
+
+func TestGerritSource_PrepareCommit(t *testing.T) {
+	s, _ := newFakeGerritSource(t)
+
+	cs := newGerritChangeset("Fix the thing", "It was broken.")
+	opts := &protocol.CreateCommitFromPatchRequest{Push: &protocol.PushConfig{}}
+	s.PrepareCommit(cs, opts)
+
+	changeID := gerritChangeID(cs)
+	assert.Equal(t, "Fix the thing\n\nIt was broken.\n\nChange-Id: "+changeID+"\n", opts.CommitInfo.Message)
+	assert.Equal(t, "refs/for/main", opts.Push.PushRef)
+
+	t.Run("Change-Id is stable", func(t *testing.T) {
+		other := newGerritChangeset("Another title", "")
+		assert.Equal(t, changeID, gerritChangeID(other))
+
+		other.HeadRef = "refs/heads/other-branch"
+		assert.NotEqual(t, changeID, gerritChangeID(other))
+	})
+}
+
+func TestGerritSource_IsUnchangedPushError(t *testing.T) {
+	s, _ := newFakeGerritSource(t)
+
+	assert.True(t, s.IsUnchangedPushError(" ! [remote rejected] HEAD -> refs/for/main (no new changes)"))
+	assert.False(t, s.IsUnchangedPushError(" ! [remote rejected] HEAD -> refs/for/main (prohibited by Gerrit)"))
+}
+
+func TestGerritSource_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	s, srv := newFakeGerritSource(t)
+
+	cs := newGerritChangeset("Fix the thing", "It was broken.")
+	srv.push(cs)
+
+	exists, err := s.CreateDraftChangeset(ctx, cs)
+	require.NoError(t, err)
+	assert.False(t, exists)
+	assert.Equal(t, "1", cs.ExternalID)
+	assert.Equal(t, srv.URL+"/c/sourcegraph/sourcegraph/+/1", cs.Metadata.(*gerritbatches.AnnotatedChange).WebURL)
+	assert.True(t, srv.changes[1].WorkInProgress)
+
+	require.NoError(t, s.UndraftChangeset(ctx, cs))
+	assert.False(t, srv.changes[1].WorkInProgress)
+
+	require.NoError(t, s.CloseChangeset(ctx, cs))
+	assert.Equal(t, gerrit.ChangeStatusAbandoned, srv.changes[1].Status)
+
+	require.NoError(t, s.ReopenChangeset(ctx, cs))
+	assert.Equal(t, gerrit.ChangeStatusNew, srv.changes[1].Status)
+
+	t.Run("update pushes a new patch set", func(t *testing.T) {
+		cs.Title = "Fix the thing properly"
+		srv.push(cs)
+
+		require.NoError(t, s.UpdateChangeset(ctx, cs))
+		assert.Equal(t, "1", cs.ExternalID)
+
+		title, err := cs.Changeset.Title()
+		require.NoError(t, err)
+		assert.Equal(t, "Fix the thing properly", title)
+
+		exists, err := s.CreateChangeset(ctx, cs)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("changing the base branch abandons the previous change", func(t *testing.T) {
+		cs.BaseRef = "refs/heads/release"
+		srv.push(cs)
+
+		require.NoError(t, s.UpdateChangeset(ctx, cs))
+		assert.Equal(t, "2", cs.ExternalID)
+		assert.Equal(t, gerrit.ChangeStatusAbandoned, srv.changes[1].Status)
+	})
+
+	t.Run("not mergeable", func(t *testing.T) {
+		err := s.MergeChangeset(ctx, cs, false)
+		assert.ErrorAs(t, err, &ChangesetNotMergeableError{})
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		missing := newGerritChangeset("", "")
+		missing.Changeset.ExternalID = "404"
+		err := s.LoadChangeset(ctx, missing)
+		assert.ErrorAs(t, err, &ChangesetNotFoundError{})
+	})
+}
+
+// fakeGerrit is a minimal Gerrit server that keeps changes in memory.
+type fakeGerrit struct {
+	*httptest.Server
+	changes map[int]*gerrit.Change
+}
+
+// push simulates pushing the commit of the given changeset to refs/for/<base>.
+func (f *fakeGerrit) push(cs *Changeset) {
+	branch := gerritBranch(cs.BaseRef)
+	changeID := gerritChangeID(cs)
+	message := gerritbatches.CommitMessage(cs.Title, cs.Body, changeID)
+
+	for _, c := range f.changes {
+		if c.Branch == branch && c.ChangeID == changeID {
+			rev := c.Revisions[c.CurrentRevision]
+			rev.Number++
+			rev.Commit.Message = message
+			c.Subject = cs.Title
+			c.CurrentRevision = strconv.Itoa(rev.Number)
+			c.Revisions = map[string]gerrit.RevisionInfo{c.CurrentRevision: rev}
+			return
+		}
+	}
+
+	number := len(f.changes) + 1
+	f.changes[number] = &gerrit.Change{
+		ID:              gerrit.ChangeTriplet("sourcegraph%2Fsourcegraph", branch, changeID),
+		Project:         "sourcegraph/sourcegraph",
+		Branch:          branch,
+		ChangeID:        changeID,
+		Number:          number,
+		Subject:         cs.Title,
+		Status:          gerrit.ChangeStatusNew,
+		CurrentRevision: "1",
+		Revisions: map[string]gerrit.RevisionInfo{"1": {
+			Number: 1,
+			Ref:    fmt.Sprintf("refs/changes/%02d/%d/1", number, number),
+			Commit: gerrit.CommitInfo{Message: message},
+		}},
+	}
+}
+
+func (f *fakeGerrit) find(id string) *gerrit.Change {
+	for _, c := range f.changes {
+		if strconv.Itoa(c.Number) == id || gerrit.ChangeTriplet(c.Project, c.Branch, c.ChangeID) == id {
+			return c
+		}
+	}
+	return nil
+}
+
+func (f *fakeGerrit) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.EscapedPath(), "/a/changes/")
+	escapedID, action, _ := strings.Cut(path, "/")
+	id, _ := url.PathUnescape(escapedID)
+
+	change := f.find(id)
+	if change == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch action {
+	case "":
+		io.WriteString(w, ")]}'\n")
+		json.NewEncoder(w).Encode(change)
+		return
+	case "abandon":
+		change.Status = gerrit.ChangeStatusAbandoned
+	case "restore":
+		change.Status = gerrit.ChangeStatusNew
+	case "wip":
+		change.WorkInProgress = true
+	case "ready":
+		change.WorkInProgress = false
+	case "submit":
+		w.WriteHeader(http.StatusConflict)
+		io.WriteString(w, "submit requirement Code-Review not satisfied")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func newFakeGerritSource(t *testing.T) (*GerritSource, *fakeGerrit) {
+	t.Helper()
+
+	f := &fakeGerrit{changes: map[int]*gerrit.Change{}}
+	f.Server = httptest.NewServer(f)
+	t.Cleanup(f.Close)
+
+	s, err := NewGerritSource(context.Background(), &types.ExternalService{
+		Kind:   extsvc.KindGerrit,
+		Config: extsvc.NewUnencryptedConfig(fmt.Sprintf(`{"url": %q, "username": "user", "password": "pass"}`, f.URL)),
+	}, nil)
+	require.NoError(t, err)
+
+	return s, f
+}
+
+func newGerritChangeset(title, body string) *Changeset {
+	repo := &types.Repo{
+		Name: "gerrit.sgdev.org/sourcegraph/sourcegraph",
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          "sourcegraph%2Fsourcegraph",
+			ServiceType: extsvc.TypeGerrit,
+		},
+		Metadata: &gerrit.Project{ID: "sourcegraph%2Fsourcegraph", Name: "sourcegraph/sourcegraph"},
+	}
+
+	return &Changeset{
+		Title:      title,
+		Body:       body,
+		HeadRef:    "refs/heads/batch-change",
+		BaseRef:    "refs/heads/main",
+		RemoteRepo: repo,
+		TargetRepo: repo,
+		Changeset:  &btypes.Changeset{},
+	}
+}
@@ -25,6 +25,8 @@ type GithubSource struct {
 }
 
 var _ ForkableChangesetSource = GithubSource{}
+var _ MetadataChangesetSource = GithubSource{}
+var _ RebaseableChangesetSource = GithubSource{}
 
 func NewGithubSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GithubSource, error) {
 	rawConfig, err := svc.Config.Decrypt(ctx)
@@ -250,6 +252,49 @@ func (s GithubSource) UpdateChangeset(ctx context.Context, c *Changeset) error {
 	return c.Changeset.SetMetadata(updated)
 }
 
+// ApplyChangesetMetadata requests reviews from the reviewers and teams of the
+// given *Changeset, and adds its labels and assignees to the pull request.
+func (s GithubSource) ApplyChangesetMetadata(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	repo := c.TargetRepo.Metadata.(*github.Repository)
+	owner, name, err := github.SplitRepositoryNameWithOwner(repo.NameWithOwner)
+	if err != nil {
+		return errors.Wrap(err, "getting repo owner and name")
+	}
+
+	// GitHub rejects the whole request if a review is requested from the
+	// author of the pull request.
+	var reviewers []string
+	for _, r := range c.Reviewers {
+		if !strings.EqualFold(r, pr.Author.Login) {
+			reviewers = append(reviewers, r)
+		}
+	}
+	if len(reviewers) > 0 || len(c.TeamReviewers) > 0 {
+		if err := s.client.RequestPullRequestReviewers(ctx, owner, name, pr.Number, reviewers, c.TeamReviewers); err != nil {
+			return errors.Wrap(err, "requesting reviewers")
+		}
+	}
+
+	if len(c.Labels) > 0 {
+		if err := s.client.AddIssueLabels(ctx, owner, name, pr.Number, c.Labels); err != nil {
+			return errors.Wrap(err, "adding labels")
+		}
+	}
+
+	if len(c.Assignees) > 0 {
+		if err := s.client.AddIssueAssignees(ctx, owner, name, pr.Number, c.Assignees); err != nil {
+			return errors.Wrap(err, "adding assignees")
+		}
+	}
+
+	return nil
+}
+
 // ReopenChangeset reopens the given *Changeset on the code host.
 func (s GithubSource) ReopenChangeset(ctx context.Context, c *Changeset) error {
 	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
@@ -293,6 +338,23 @@ func (s GithubSource) MergeChangeset(ctx context.Context, c *Changeset, squash b
 	return c.Changeset.SetMetadata(pr)
 }
 
+// RebaseChangeset merges the base branch of the given *Changeset into its
+// head branch, since GitHub cannot rebase pull requests through its API.
+func (s GithubSource) RebaseChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	repo := c.TargetRepo.Metadata.(*github.Repository)
+	owner, name, err := github.SplitRepositoryNameWithOwner(repo.NameWithOwner)
+	if err != nil {
+		return errors.Wrap(err, "getting repo owner and name")
+	}
+
+	return errors.Wrap(s.client.UpdatePullRequestBranch(ctx, owner, name, pr.Number), "updating pull request branch")
+}
+
 // GetNamespaceFork returns a repo pointing to a fork of the given repo in
 // the given namespace, ensuring that the fork exists and is a fork of the
 // target repo.
@@ -3,9 +3,11 @@ package sources
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"strings"
@@ -20,6 +22,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/rcache"
 	"github.com/sourcegraph/sourcegraph/internal/testutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -492,6 +495,91 @@ func TestGithubSource_UpdateChangeset(t *testing.T) {
 	}
 }
 
+func TestGithubSource_ApplyChangesetMetadata(t *testing.T) {
+	type request struct {
+		Path string
+		Body map[string][]string
+	}
+	var requests []request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string][]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decoding request body: %v", err)
+		}
+		requests = append(requests, request{Path: r.Method + " " + r.URL.Path, Body: body})
+		if strings.HasSuffix(r.URL.Path, "/labels") {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	src, err := newGithubSource("GithubSource_ApplyChangesetMetadata", &schema.GitHubConnection{
+		Url:   srv.URL,
+		Token: "secret",
+	}, httpcli.NewFactory(nil), nil)
+	require.NoError(t, err)
+
+	cs := &Changeset{
+		// The author of the pull request can't be requested as a reviewer.
+		Reviewers:     []string{"alice", "Author"},
+		TeamReviewers: []string{"batchers"},
+		Labels:        []string{"automated"},
+		Assignees:     []string{"carol"},
+		TargetRepo: &types.Repo{
+			Metadata: &github.Repository{NameWithOwner: "sourcegraph/sourcegraph"},
+		},
+		Changeset: &btypes.Changeset{
+			Metadata: &github.PullRequest{Number: 42, Author: github.Actor{Login: "author"}},
+		},
+	}
+	require.NoError(t, src.ApplyChangesetMetadata(context.Background(), cs))
+
+	assert.Equal(t, []request{
+		{
+			Path: "POST /api/v3/repos/sourcegraph/sourcegraph/pulls/42/requested_reviewers",
+			Body: map[string][]string{"reviewers": {"alice"}, "team_reviewers": {"batchers"}},
+		},
+		{
+			Path: "POST /api/v3/repos/sourcegraph/sourcegraph/issues/42/labels",
+			Body: map[string][]string{"labels": {"automated"}},
+		},
+		{
+			Path: "POST /api/v3/repos/sourcegraph/sourcegraph/issues/42/assignees",
+			Body: map[string][]string{"assignees": {"carol"}},
+		},
+	}, requests)
+}
+
+func TestGithubSource_RebaseChangeset(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"message":"Updating pull request branch."}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	src, err := newGithubSource("GithubSource_RebaseChangeset", &schema.GitHubConnection{
+		Url:   srv.URL,
+		Token: "secret",
+	}, httpcli.NewFactory(nil), nil)
+	require.NoError(t, err)
+
+	cs := &Changeset{
+		TargetRepo: &types.Repo{
+			Metadata: &github.Repository{NameWithOwner: "sourcegraph/sourcegraph"},
+		},
+		Changeset: &btypes.Changeset{
+			Metadata: &github.PullRequest{Number: 42},
+		},
+	}
+	require.NoError(t, src.RebaseChangeset(context.Background(), cs))
+
+	assert.Equal(t, []string{"PUT /api/v3/repos/sourcegraph/sourcegraph/pulls/42/update-branch"}, requests)
+}
+
 func TestGithubSource_LoadChangeset(t *testing.T) {
 	testCases := []struct {
 		name string
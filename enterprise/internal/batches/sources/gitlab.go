@@ -29,6 +29,8 @@ type GitLabSource struct {
 var _ ChangesetSource = &GitLabSource{}
 var _ DraftChangesetSource = &GitLabSource{}
 var _ ForkableChangesetSource = &GitLabSource{}
+var _ MetadataChangesetSource = &GitLabSource{}
+var _ RebaseableChangesetSource = &GitLabSource{}
 
 // NewGitLabSource returns a new GitLabSource from the given external service.
 func NewGitLabSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GitLabSource, error) {
@@ -487,6 +489,84 @@ func (s *GitLabSource) UpdateChangeset(ctx context.Context, c *Changeset) error
 	return c.Changeset.SetMetadata(updated)
 }
 
+// ApplyChangesetMetadata adds the reviewers, labels and assignees of the given
+// *Changeset to the merge request. GitLab has no concept of team reviewers, so
+// those are ignored.
+func (s *GitLabSource) ApplyChangesetMetadata(ctx context.Context, c *Changeset) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+	project := c.TargetRepo.Metadata.(*gitlab.Project)
+
+	opts := gitlab.UpdateMergeRequestOpts{
+		AddLabels: strings.Join(c.Labels, ","),
+	}
+
+	// GitLab replaces the assignees and reviewers of a merge request with the
+	// given ones, so we have to add the existing ones to keep them.
+	assigneeIDs, err := s.mergeUserIDs(ctx, mr.Assignees, c.Assignees)
+	if err != nil {
+		return errors.Wrap(err, "looking up assignees")
+	}
+	if len(assigneeIDs) > len(mr.Assignees) {
+		opts.AssigneeIDs = assigneeIDs
+	}
+	reviewerIDs, err := s.mergeUserIDs(ctx, mr.Reviewers, c.Reviewers)
+	if err != nil {
+		return errors.Wrap(err, "looking up reviewers")
+	}
+	if len(reviewerIDs) > len(mr.Reviewers) {
+		opts.ReviewerIDs = reviewerIDs
+	}
+
+	if opts.AddLabels == "" && len(opts.AssigneeIDs) == 0 && len(opts.ReviewerIDs) == 0 {
+		return nil
+	}
+
+	updated, err := s.client.UpdateMergeRequest(ctx, project, mr, opts)
+	if err != nil {
+		return errors.Wrap(err, "updating GitLab merge request")
+	}
+
+	// These additional API calls can go away once we can use the GraphQL API.
+	if err := s.decorateMergeRequestData(ctx, project, updated); err != nil {
+		return errors.Wrapf(err, "retrieving additional data for merge request %d", updated.IID)
+	}
+
+	return c.Changeset.SetMetadata(updated)
+}
+
+// mergeUserIDs returns the IDs of the existing users, followed by the IDs of
+// the users with the given usernames that are not among them. Usernames that
+// don't belong to a GitLab user are ignored.
+func (s *GitLabSource) mergeUserIDs(ctx context.Context, existing []gitlab.User, usernames []string) ([]int32, error) {
+	ids := make([]int32, 0, len(existing)+len(usernames))
+	seen := make(map[string]struct{}, len(existing))
+	for _, u := range existing {
+		ids = append(ids, u.ID)
+		seen[strings.ToLower(u.Username)] = struct{}{}
+	}
+
+	for _, username := range usernames {
+		if _, ok := seen[strings.ToLower(username)]; ok {
+			continue
+		}
+		seen[strings.ToLower(username)] = struct{}{}
+
+		users, _, err := s.client.ListUsers(ctx, "users?username="+url.QueryEscape(username))
+		if err != nil {
+			return nil, err
+		}
+		if len(users) == 0 {
+			continue
+		}
+		ids = append(ids, users[0].ID)
+	}
+
+	return ids, nil
+}
+
 // UndraftChangeset marks the changeset as *not* work in progress anymore.
 func (s *GitLabSource) UndraftChangeset(ctx context.Context, c *Changeset) error {
 	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
@@ -544,6 +624,18 @@ func (s *GitLabSource) MergeChangeset(ctx context.Context, c *Changeset, squash
 	return c.Changeset.SetMetadata(updated)
 }
 
+// RebaseChangeset rebases the source branch of the given *Changeset onto its
+// target branch.
+func (s *GitLabSource) RebaseChangeset(ctx context.Context, c *Changeset) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+	project := c.TargetRepo.Metadata.(*gitlab.Project)
+
+	return errors.Wrap(s.client.RebaseMergeRequest(ctx, project, mr), "rebasing GitLab merge request")
+}
+
 // GetNamespaceFork returns a repo pointing to a fork of the given repo in
 // the given namespace, ensuring that the fork exists and is a fork of the
 // target repo.
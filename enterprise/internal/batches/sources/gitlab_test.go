@@ -863,6 +863,135 @@ func TestGitLabSource_ChangesetSource(t *testing.T) {
 	})
 }
 
+func TestGitLabSource_ApplyChangesetMetadata(t *testing.T) {
+	mockListUsers := func(t *testing.T, ids map[string]int32) {
+		oldMock := gitlab.MockListUsers
+		t.Cleanup(func() { gitlab.MockListUsers = oldMock })
+		gitlab.MockListUsers = func(c *gitlab.Client, ctx context.Context, urlStr string) ([]*gitlab.User, *string, error) {
+			u, err := url.Parse(urlStr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			username := u.Query().Get("username")
+			if id, ok := ids[username]; ok {
+				return []*gitlab.User{{ID: id, Username: username}}, nil, nil
+			}
+			return []*gitlab.User{}, nil, nil
+		}
+	}
+
+	t.Run("no changes", func(t *testing.T) {
+		p := newGitLabChangesetSourceTestProvider(t)
+		p.changeset.Changeset.Metadata = &gitlab.MergeRequest{
+			IID:       2,
+			Reviewers: []gitlab.User{{ID: 1, Username: "alice"}},
+		}
+		p.changeset.Reviewers = []string{"Alice"}
+		p.changeset.TeamReviewers = []string{"batchers"}
+
+		// UpdateMergeRequest is not mocked, so the test panics if the merge
+		// request is updated.
+		if err := p.source.ApplyChangesetMetadata(p.ctx, p.changeset); err != nil {
+			t.Errorf("unexpected non-nil error: %+v", err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		in := &gitlab.MergeRequest{
+			IID:       2,
+			Assignees: []gitlab.User{{ID: 1, Username: "alice"}},
+		}
+		out := &gitlab.MergeRequest{}
+
+		p := newGitLabChangesetSourceTestProvider(t)
+		p.changeset.Changeset.Metadata = in
+		p.changeset.Reviewers = []string{"bob", "nobody"}
+		p.changeset.Labels = []string{"automated", "batch"}
+		p.changeset.Assignees = []string{"carol"}
+
+		mockListUsers(t, map[string]int32{"bob": 2, "carol": 3})
+
+		oldMock := gitlab.MockUpdateMergeRequest
+		t.Cleanup(func() { gitlab.MockUpdateMergeRequest = oldMock })
+		gitlab.MockUpdateMergeRequest = func(c *gitlab.Client, ctx context.Context, project *gitlab.Project, mr *gitlab.MergeRequest, opts gitlab.UpdateMergeRequestOpts) (*gitlab.MergeRequest, error) {
+			assert.Equal(t, gitlab.UpdateMergeRequestOpts{
+				AddLabels:   "automated,batch",
+				AssigneeIDs: []int32{1, 3},
+				ReviewerIDs: []int32{2},
+			}, opts)
+			return out, nil
+		}
+
+		p.mockGetMergeRequestNotes(out.IID, nil, 20, nil)
+		p.mockGetMergeRequestResourceStateEvents(out.IID, nil, 20, nil)
+		p.mockGetMergeRequestPipelines(out.IID, nil, 20, nil)
+
+		if err := p.source.ApplyChangesetMetadata(p.ctx, p.changeset); err != nil {
+			t.Errorf("unexpected non-nil error: %+v", err)
+		}
+		if p.changeset.Changeset.Metadata != out {
+			t.Errorf("metadata not correctly updated: have %+v; want %+v", p.changeset.Changeset.Metadata, out)
+		}
+	})
+
+	t.Run("error looking up users", func(t *testing.T) {
+		p := newGitLabChangesetSourceTestProvider(t)
+		p.changeset.Changeset.Metadata = &gitlab.MergeRequest{IID: 2}
+		p.changeset.Assignees = []string{"carol"}
+
+		inner := errors.New("foo")
+		oldMock := gitlab.MockListUsers
+		t.Cleanup(func() { gitlab.MockListUsers = oldMock })
+		gitlab.MockListUsers = func(c *gitlab.Client, ctx context.Context, urlStr string) ([]*gitlab.User, *string, error) {
+			return nil, nil, inner
+		}
+
+		if have := p.source.ApplyChangesetMetadata(p.ctx, p.changeset); !errors.Is(have, inner) {
+			t.Errorf("error does not include inner error: have %+v; want %+v", have, inner)
+		}
+	})
+}
+
+func TestGitLabSource_RebaseChangeset(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p := newGitLabChangesetSourceTestProvider(t)
+		mr := &gitlab.MergeRequest{IID: 2}
+		p.changeset.Changeset.Metadata = mr
+
+		oldMock := gitlab.MockRebaseMergeRequest
+		t.Cleanup(func() { gitlab.MockRebaseMergeRequest = oldMock })
+		var called bool
+		gitlab.MockRebaseMergeRequest = func(c *gitlab.Client, ctx context.Context, project *gitlab.Project, have *gitlab.MergeRequest) error {
+			called = true
+			assert.Same(t, mr, have)
+			return nil
+		}
+
+		if err := p.source.RebaseChangeset(p.ctx, p.changeset); err != nil {
+			t.Errorf("unexpected non-nil error: %+v", err)
+		}
+		if !called {
+			t.Error("merge request not rebased")
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		p := newGitLabChangesetSourceTestProvider(t)
+		p.changeset.Changeset.Metadata = &gitlab.MergeRequest{IID: 2}
+
+		inner := errors.New("foo")
+		oldMock := gitlab.MockRebaseMergeRequest
+		t.Cleanup(func() { gitlab.MockRebaseMergeRequest = oldMock })
+		gitlab.MockRebaseMergeRequest = func(c *gitlab.Client, ctx context.Context, project *gitlab.Project, mr *gitlab.MergeRequest) error {
+			return inner
+		}
+
+		if have := p.source.RebaseChangeset(p.ctx, p.changeset); !errors.Is(have, inner) {
+			t.Errorf("error does not include inner error: have %+v; want %+v", have, inner)
+		}
+	})
+}
+
 func TestReadNotesUntilSeen(t *testing.T) {
 	commonNotes := []*gitlab.Note{
 		{ID: 1, System: true},
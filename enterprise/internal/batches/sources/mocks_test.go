@@ -14,6 +14,7 @@ import (
 	types1 "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	database "github.com/sourcegraph/sourcegraph/internal/database"
 	auth "github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	azuredevops "github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	bitbucketcloud "github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	protocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	types "github.com/sourcegraph/sourcegraph/internal/types"
@@ -3584,6 +3585,1313 @@ func (c SourcerStoreUserCredentialsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// MockAzureDevOpsClient is a mock implementation of the Client interface
+// (from the package
+// github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops) used for
+// unit testing.
+type MockAzureDevOpsClient struct {
+	// AuthenticatorFunc is an instance of a mock function object
+	// controlling the behavior of the method Authenticator.
+	AuthenticatorFunc *AzureDevOpsClientAuthenticatorFunc
+	// CreatePullRequestFunc is an instance of a mock function object
+	// controlling the behavior of the method CreatePullRequest.
+	CreatePullRequestFunc *AzureDevOpsClientCreatePullRequestFunc
+	// CreatePullRequestCommentFunc is an instance of a mock function object
+	// controlling the behavior of the method CreatePullRequestComment.
+	CreatePullRequestCommentFunc *AzureDevOpsClientCreatePullRequestCommentFunc
+	// FindActivePullRequestFunc is an instance of a mock function object
+	// controlling the behavior of the method FindActivePullRequest.
+	FindActivePullRequestFunc *AzureDevOpsClientFindActivePullRequestFunc
+	// GetPullRequestFunc is an instance of a mock function object
+	// controlling the behavior of the method GetPullRequest.
+	GetPullRequestFunc *AzureDevOpsClientGetPullRequestFunc
+	// GetPullRequestStatusesFunc is an instance of a mock function object
+	// controlling the behavior of the method GetPullRequestStatuses.
+	GetPullRequestStatusesFunc *AzureDevOpsClientGetPullRequestStatusesFunc
+	// ListRepositoriesFunc is an instance of a mock function object
+	// controlling the behavior of the method ListRepositories.
+	ListRepositoriesFunc *AzureDevOpsClientListRepositoriesFunc
+	// PingFunc is an instance of a mock function object controlling the
+	// behavior of the method Ping.
+	PingFunc *AzureDevOpsClientPingFunc
+	// UpdatePullRequestFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdatePullRequest.
+	UpdatePullRequestFunc *AzureDevOpsClientUpdatePullRequestFunc
+	// WithAuthenticatorFunc is an instance of a mock function object
+	// controlling the behavior of the method WithAuthenticator.
+	WithAuthenticatorFunc *AzureDevOpsClientWithAuthenticatorFunc
+}
+
+// NewMockAzureDevOpsClient creates a new mock of the Client interface. All
+// methods return zero values for all results, unless overwritten.
+func NewMockAzureDevOpsClient() *MockAzureDevOpsClient {
+	return &MockAzureDevOpsClient{
+		AuthenticatorFunc: &AzureDevOpsClientAuthenticatorFunc{
+			defaultHook: func() (r0 auth.Authenticator) {
+				return
+			},
+		},
+		CreatePullRequestFunc: &AzureDevOpsClientCreatePullRequestFunc{
+			defaultHook: func(context.Context, *azuredevops.Repository, azuredevops.PullRequestInput) (r0 *azuredevops.PullRequest, r1 error) {
+				return
+			},
+		},
+		CreatePullRequestCommentFunc: &AzureDevOpsClientCreatePullRequestCommentFunc{
+			defaultHook: func(context.Context, *azuredevops.Repository, int, string) (r0 error) {
+				return
+			},
+		},
+		FindActivePullRequestFunc: &AzureDevOpsClientFindActivePullRequestFunc{
+			defaultHook: func(context.Context, *azuredevops.Repository, string, string) (r0 *azuredevops.PullRequest, r1 error) {
+				return
+			},
+		},
+		GetPullRequestFunc: &AzureDevOpsClientGetPullRequestFunc{
+			defaultHook: func(context.Context, *azuredevops.Repository, int) (r0 *azuredevops.PullRequest, r1 error) {
+				return
+			},
+		},
+		GetPullRequestStatusesFunc: &AzureDevOpsClientGetPullRequestStatusesFunc{
+			defaultHook: func(context.Context, *azuredevops.Repository, int) (r0 []*azuredevops.PullRequestStatus, r1 error) {
+				return
+			},
+		},
+		ListRepositoriesFunc: &AzureDevOpsClientListRepositoriesFunc{
+			defaultHook: func(context.Context, string, string) (r0 []*azuredevops.Repository, r1 error) {
+				return
+			},
+		},
+		PingFunc: &AzureDevOpsClientPingFunc{
+			defaultHook: func(context.Context, string) (r0 error) {
+				return
+			},
+		},
+		UpdatePullRequestFunc: &AzureDevOpsClientUpdatePullRequestFunc{
+			defaultHook: func(context.Context, *azuredevops.Repository, int, azuredevops.PullRequestUpdateInput) (r0 *azuredevops.PullRequest, r1 error) {
+				return
+			},
+		},
+		WithAuthenticatorFunc: &AzureDevOpsClientWithAuthenticatorFunc{
+			defaultHook: func(auth.Authenticator) (r0 azuredevops.Client) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockAzureDevOpsClient creates a new mock of the Client
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockAzureDevOpsClient() *MockAzureDevOpsClient {
+	return &MockAzureDevOpsClient{
+		AuthenticatorFunc: &AzureDevOpsClientAuthenticatorFunc{
+			defaultHook: func() auth.Authenticator {
+				panic("unexpected invocation of MockAzureDevOpsClient.Authenticator")
+			},
+		},
+		CreatePullRequestFunc: &AzureDevOpsClientCreatePullRequestFunc{
+			defaultHook: func(context.Context, *azuredevops.Repository, azuredevops.PullRequestInput) (*azuredevops.PullRequest, error) {
+				panic("unexpected invocation of MockAzureDevOpsClient.CreatePullRequest")
+			},
+		},
+		CreatePullRequestCommentFunc: &AzureDevOpsClientCreatePullRequestCommentFunc{
+			defaultHook: func(context.Context, *azuredevops.Repository, int, string) error {
+				panic("unexpected invocation of MockAzureDevOpsClient.CreatePullRequestComment")
+			},
+		},
+		FindActivePullRequestFunc: &AzureDevOpsClientFindActivePullRequestFunc{
+			defaultHook: func(context.Context, *azuredevops.Repository, string, string) (*azuredevops.PullRequest, error) {
+				panic("unexpected invocation of MockAzureDevOpsClient.FindActivePullRequest")
+			},
+		},
+		GetPullRequestFunc: &AzureDevOpsClientGetPullRequestFunc{
+			defaultHook: func(context.Context, *azuredevops.Repository, int) (*azuredevops.PullRequest, error) {
+				panic("unexpected invocation of MockAzureDevOpsClient.GetPullRequest")
+			},
+		},
+		GetPullRequestStatusesFunc: &AzureDevOpsClientGetPullRequestStatusesFunc{
+			defaultHook: func(context.Context, *azuredevops.Repository, int) ([]*azuredevops.PullRequestStatus, error) {
+				panic("unexpected invocation of MockAzureDevOpsClient.GetPullRequestStatuses")
+			},
+		},
+		ListRepositoriesFunc: &AzureDevOpsClientListRepositoriesFunc{
+			defaultHook: func(context.Context, string, string) ([]*azuredevops.Repository, error) {
+				panic("unexpected invocation of MockAzureDevOpsClient.ListRepositories")
+			},
+		},
+		PingFunc: &AzureDevOpsClientPingFunc{
+			defaultHook: func(context.Context, string) error {
+				panic("unexpected invocation of MockAzureDevOpsClient.Ping")
+			},
+		},
+		UpdatePullRequestFunc: &AzureDevOpsClientUpdatePullRequestFunc{
+			defaultHook: func(context.Context, *azuredevops.Repository, int, azuredevops.PullRequestUpdateInput) (*azuredevops.PullRequest, error) {
+				panic("unexpected invocation of MockAzureDevOpsClient.UpdatePullRequest")
+			},
+		},
+		WithAuthenticatorFunc: &AzureDevOpsClientWithAuthenticatorFunc{
+			defaultHook: func(auth.Authenticator) azuredevops.Client {
+				panic("unexpected invocation of MockAzureDevOpsClient.WithAuthenticator")
+			},
+		},
+	}
+}
+
+// NewMockAzureDevOpsClientFrom creates a new mock of the
+// MockAzureDevOpsClient interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockAzureDevOpsClientFrom(i azuredevops.Client) *MockAzureDevOpsClient {
+	return &MockAzureDevOpsClient{
+		AuthenticatorFunc: &AzureDevOpsClientAuthenticatorFunc{
+			defaultHook: i.Authenticator,
+		},
+		CreatePullRequestFunc: &AzureDevOpsClientCreatePullRequestFunc{
+			defaultHook: i.CreatePullRequest,
+		},
+		CreatePullRequestCommentFunc: &AzureDevOpsClientCreatePullRequestCommentFunc{
+			defaultHook: i.CreatePullRequestComment,
+		},
+		FindActivePullRequestFunc: &AzureDevOpsClientFindActivePullRequestFunc{
+			defaultHook: i.FindActivePullRequest,
+		},
+		GetPullRequestFunc: &AzureDevOpsClientGetPullRequestFunc{
+			defaultHook: i.GetPullRequest,
+		},
+		GetPullRequestStatusesFunc: &AzureDevOpsClientGetPullRequestStatusesFunc{
+			defaultHook: i.GetPullRequestStatuses,
+		},
+		ListRepositoriesFunc: &AzureDevOpsClientListRepositoriesFunc{
+			defaultHook: i.ListRepositories,
+		},
+		PingFunc: &AzureDevOpsClientPingFunc{
+			defaultHook: i.Ping,
+		},
+		UpdatePullRequestFunc: &AzureDevOpsClientUpdatePullRequestFunc{
+			defaultHook: i.UpdatePullRequest,
+		},
+		WithAuthenticatorFunc: &AzureDevOpsClientWithAuthenticatorFunc{
+			defaultHook: i.WithAuthenticator,
+		},
+	}
+}
+
+// AzureDevOpsClientAuthenticatorFunc describes the behavior when the
+// Authenticator method of the parent MockAzureDevOpsClient instance is
+// invoked.
+type AzureDevOpsClientAuthenticatorFunc struct {
+	defaultHook func() auth.Authenticator
+	hooks       []func() auth.Authenticator
+	history     []AzureDevOpsClientAuthenticatorFuncCall
+	mutex       sync.Mutex
+}
+
+// Authenticator delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockAzureDevOpsClient) Authenticator() auth.Authenticator {
+	r0 := m.AuthenticatorFunc.nextHook()()
+	m.AuthenticatorFunc.appendCall(AzureDevOpsClientAuthenticatorFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Authenticator method
+// of the parent MockAzureDevOpsClient instance is invoked and the hook
+// queue is empty.
+func (f *AzureDevOpsClientAuthenticatorFunc) SetDefaultHook(hook func() auth.Authenticator) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Authenticator method of the parent MockAzureDevOpsClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *AzureDevOpsClientAuthenticatorFunc) PushHook(hook func() auth.Authenticator) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureDevOpsClientAuthenticatorFunc) SetDefaultReturn(r0 auth.Authenticator) {
+	f.SetDefaultHook(func() auth.Authenticator {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureDevOpsClientAuthenticatorFunc) PushReturn(r0 auth.Authenticator) {
+	f.PushHook(func() auth.Authenticator {
+		return r0
+	})
+}
+
+func (f *AzureDevOpsClientAuthenticatorFunc) nextHook() func() auth.Authenticator {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureDevOpsClientAuthenticatorFunc) appendCall(r0 AzureDevOpsClientAuthenticatorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AzureDevOpsClientAuthenticatorFuncCall
+// objects describing the invocations of this function.
+func (f *AzureDevOpsClientAuthenticatorFunc) History() []AzureDevOpsClientAuthenticatorFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureDevOpsClientAuthenticatorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureDevOpsClientAuthenticatorFuncCall is an object that describes an
+// invocation of method Authenticator on an instance of
+// MockAzureDevOpsClient.
+type AzureDevOpsClientAuthenticatorFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 auth.Authenticator
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureDevOpsClientAuthenticatorFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureDevOpsClientAuthenticatorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// AzureDevOpsClientCreatePullRequestFunc describes the behavior when the
+// CreatePullRequest method of the parent MockAzureDevOpsClient instance is
+// invoked.
+type AzureDevOpsClientCreatePullRequestFunc struct {
+	defaultHook func(context.Context, *azuredevops.Repository, azuredevops.PullRequestInput) (*azuredevops.PullRequest, error)
+	hooks       []func(context.Context, *azuredevops.Repository, azuredevops.PullRequestInput) (*azuredevops.PullRequest, error)
+	history     []AzureDevOpsClientCreatePullRequestFuncCall
+	mutex       sync.Mutex
+}
+
+// CreatePullRequest delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockAzureDevOpsClient) CreatePullRequest(v0 context.Context, v1 *azuredevops.Repository, v2 azuredevops.PullRequestInput) (*azuredevops.PullRequest, error) {
+	r0, r1 := m.CreatePullRequestFunc.nextHook()(v0, v1, v2)
+	m.CreatePullRequestFunc.appendCall(AzureDevOpsClientCreatePullRequestFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CreatePullRequest
+// method of the parent MockAzureDevOpsClient instance is invoked and the
+// hook queue is empty.
+func (f *AzureDevOpsClientCreatePullRequestFunc) SetDefaultHook(hook func(context.Context, *azuredevops.Repository, azuredevops.PullRequestInput) (*azuredevops.PullRequest, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreatePullRequest method of the parent MockAzureDevOpsClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *AzureDevOpsClientCreatePullRequestFunc) PushHook(hook func(context.Context, *azuredevops.Repository, azuredevops.PullRequestInput) (*azuredevops.PullRequest, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureDevOpsClientCreatePullRequestFunc) SetDefaultReturn(r0 *azuredevops.PullRequest, r1 error) {
+	f.SetDefaultHook(func(context.Context, *azuredevops.Repository, azuredevops.PullRequestInput) (*azuredevops.PullRequest, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureDevOpsClientCreatePullRequestFunc) PushReturn(r0 *azuredevops.PullRequest, r1 error) {
+	f.PushHook(func(context.Context, *azuredevops.Repository, azuredevops.PullRequestInput) (*azuredevops.PullRequest, error) {
+		return r0, r1
+	})
+}
+
+func (f *AzureDevOpsClientCreatePullRequestFunc) nextHook() func(context.Context, *azuredevops.Repository, azuredevops.PullRequestInput) (*azuredevops.PullRequest, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureDevOpsClientCreatePullRequestFunc) appendCall(r0 AzureDevOpsClientCreatePullRequestFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AzureDevOpsClientCreatePullRequestFuncCall
+// objects describing the invocations of this function.
+func (f *AzureDevOpsClientCreatePullRequestFunc) History() []AzureDevOpsClientCreatePullRequestFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureDevOpsClientCreatePullRequestFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureDevOpsClientCreatePullRequestFuncCall is an object that describes an
+// invocation of method CreatePullRequest on an instance of
+// MockAzureDevOpsClient.
+type AzureDevOpsClientCreatePullRequestFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *azuredevops.Repository
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 azuredevops.PullRequestInput
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *azuredevops.PullRequest
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureDevOpsClientCreatePullRequestFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureDevOpsClientCreatePullRequestFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// AzureDevOpsClientCreatePullRequestCommentFunc describes the behavior when
+// the CreatePullRequestComment method of the parent MockAzureDevOpsClient
+// instance is invoked.
+type AzureDevOpsClientCreatePullRequestCommentFunc struct {
+	defaultHook func(context.Context, *azuredevops.Repository, int, string) error
+	hooks       []func(context.Context, *azuredevops.Repository, int, string) error
+	history     []AzureDevOpsClientCreatePullRequestCommentFuncCall
+	mutex       sync.Mutex
+}
+
+// CreatePullRequestComment delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockAzureDevOpsClient) CreatePullRequestComment(v0 context.Context, v1 *azuredevops.Repository, v2 int, v3 string) error {
+	r0 := m.CreatePullRequestCommentFunc.nextHook()(v0, v1, v2, v3)
+	m.CreatePullRequestCommentFunc.appendCall(AzureDevOpsClientCreatePullRequestCommentFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// CreatePullRequestComment method of the parent MockAzureDevOpsClient
+// instance is invoked and the hook queue is empty.
+func (f *AzureDevOpsClientCreatePullRequestCommentFunc) SetDefaultHook(hook func(context.Context, *azuredevops.Repository, int, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreatePullRequestComment method of the parent MockAzureDevOpsClient
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *AzureDevOpsClientCreatePullRequestCommentFunc) PushHook(hook func(context.Context, *azuredevops.Repository, int, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureDevOpsClientCreatePullRequestCommentFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, *azuredevops.Repository, int, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureDevOpsClientCreatePullRequestCommentFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, *azuredevops.Repository, int, string) error {
+		return r0
+	})
+}
+
+func (f *AzureDevOpsClientCreatePullRequestCommentFunc) nextHook() func(context.Context, *azuredevops.Repository, int, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureDevOpsClientCreatePullRequestCommentFunc) appendCall(r0 AzureDevOpsClientCreatePullRequestCommentFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// AzureDevOpsClientCreatePullRequestCommentFuncCall objects describing the
+// invocations of this function.
+func (f *AzureDevOpsClientCreatePullRequestCommentFunc) History() []AzureDevOpsClientCreatePullRequestCommentFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureDevOpsClientCreatePullRequestCommentFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureDevOpsClientCreatePullRequestCommentFuncCall is an object that
+// describes an invocation of method CreatePullRequestComment on an instance
+// of MockAzureDevOpsClient.
+type AzureDevOpsClientCreatePullRequestCommentFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *azuredevops.Repository
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureDevOpsClientCreatePullRequestCommentFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureDevOpsClientCreatePullRequestCommentFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// AzureDevOpsClientFindActivePullRequestFunc describes the behavior when
+// the FindActivePullRequest method of the parent MockAzureDevOpsClient
+// instance is invoked.
+type AzureDevOpsClientFindActivePullRequestFunc struct {
+	defaultHook func(context.Context, *azuredevops.Repository, string, string) (*azuredevops.PullRequest, error)
+	hooks       []func(context.Context, *azuredevops.Repository, string, string) (*azuredevops.PullRequest, error)
+	history     []AzureDevOpsClientFindActivePullRequestFuncCall
+	mutex       sync.Mutex
+}
+
+// FindActivePullRequest delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockAzureDevOpsClient) FindActivePullRequest(v0 context.Context, v1 *azuredevops.Repository, v2 string, v3 string) (*azuredevops.PullRequest, error) {
+	r0, r1 := m.FindActivePullRequestFunc.nextHook()(v0, v1, v2, v3)
+	m.FindActivePullRequestFunc.appendCall(AzureDevOpsClientFindActivePullRequestFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// FindActivePullRequest method of the parent MockAzureDevOpsClient instance
+// is invoked and the hook queue is empty.
+func (f *AzureDevOpsClientFindActivePullRequestFunc) SetDefaultHook(hook func(context.Context, *azuredevops.Repository, string, string) (*azuredevops.PullRequest, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// FindActivePullRequest method of the parent MockAzureDevOpsClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *AzureDevOpsClientFindActivePullRequestFunc) PushHook(hook func(context.Context, *azuredevops.Repository, string, string) (*azuredevops.PullRequest, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureDevOpsClientFindActivePullRequestFunc) SetDefaultReturn(r0 *azuredevops.PullRequest, r1 error) {
+	f.SetDefaultHook(func(context.Context, *azuredevops.Repository, string, string) (*azuredevops.PullRequest, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureDevOpsClientFindActivePullRequestFunc) PushReturn(r0 *azuredevops.PullRequest, r1 error) {
+	f.PushHook(func(context.Context, *azuredevops.Repository, string, string) (*azuredevops.PullRequest, error) {
+		return r0, r1
+	})
+}
+
+func (f *AzureDevOpsClientFindActivePullRequestFunc) nextHook() func(context.Context, *azuredevops.Repository, string, string) (*azuredevops.PullRequest, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureDevOpsClientFindActivePullRequestFunc) appendCall(r0 AzureDevOpsClientFindActivePullRequestFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// AzureDevOpsClientFindActivePullRequestFuncCall objects describing the
+// invocations of this function.
+func (f *AzureDevOpsClientFindActivePullRequestFunc) History() []AzureDevOpsClientFindActivePullRequestFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureDevOpsClientFindActivePullRequestFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureDevOpsClientFindActivePullRequestFuncCall is an object that
+// describes an invocation of method FindActivePullRequest on an instance of
+// MockAzureDevOpsClient.
+type AzureDevOpsClientFindActivePullRequestFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *azuredevops.Repository
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *azuredevops.PullRequest
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureDevOpsClientFindActivePullRequestFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureDevOpsClientFindActivePullRequestFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// AzureDevOpsClientGetPullRequestFunc describes the behavior when the
+// GetPullRequest method of the parent MockAzureDevOpsClient instance is
+// invoked.
+type AzureDevOpsClientGetPullRequestFunc struct {
+	defaultHook func(context.Context, *azuredevops.Repository, int) (*azuredevops.PullRequest, error)
+	hooks       []func(context.Context, *azuredevops.Repository, int) (*azuredevops.PullRequest, error)
+	history     []AzureDevOpsClientGetPullRequestFuncCall
+	mutex       sync.Mutex
+}
+
+// GetPullRequest delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockAzureDevOpsClient) GetPullRequest(v0 context.Context, v1 *azuredevops.Repository, v2 int) (*azuredevops.PullRequest, error) {
+	r0, r1 := m.GetPullRequestFunc.nextHook()(v0, v1, v2)
+	m.GetPullRequestFunc.appendCall(AzureDevOpsClientGetPullRequestFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetPullRequest
+// method of the parent MockAzureDevOpsClient instance is invoked and the
+// hook queue is empty.
+func (f *AzureDevOpsClientGetPullRequestFunc) SetDefaultHook(hook func(context.Context, *azuredevops.Repository, int) (*azuredevops.PullRequest, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetPullRequest method of the parent MockAzureDevOpsClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *AzureDevOpsClientGetPullRequestFunc) PushHook(hook func(context.Context, *azuredevops.Repository, int) (*azuredevops.PullRequest, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureDevOpsClientGetPullRequestFunc) SetDefaultReturn(r0 *azuredevops.PullRequest, r1 error) {
+	f.SetDefaultHook(func(context.Context, *azuredevops.Repository, int) (*azuredevops.PullRequest, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureDevOpsClientGetPullRequestFunc) PushReturn(r0 *azuredevops.PullRequest, r1 error) {
+	f.PushHook(func(context.Context, *azuredevops.Repository, int) (*azuredevops.PullRequest, error) {
+		return r0, r1
+	})
+}
+
+func (f *AzureDevOpsClientGetPullRequestFunc) nextHook() func(context.Context, *azuredevops.Repository, int) (*azuredevops.PullRequest, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureDevOpsClientGetPullRequestFunc) appendCall(r0 AzureDevOpsClientGetPullRequestFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AzureDevOpsClientGetPullRequestFuncCall
+// objects describing the invocations of this function.
+func (f *AzureDevOpsClientGetPullRequestFunc) History() []AzureDevOpsClientGetPullRequestFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureDevOpsClientGetPullRequestFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureDevOpsClientGetPullRequestFuncCall is an object that describes an
+// invocation of method GetPullRequest on an instance of
+// MockAzureDevOpsClient.
+type AzureDevOpsClientGetPullRequestFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *azuredevops.Repository
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *azuredevops.PullRequest
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureDevOpsClientGetPullRequestFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureDevOpsClientGetPullRequestFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// AzureDevOpsClientGetPullRequestStatusesFunc describes the behavior when
+// the GetPullRequestStatuses method of the parent MockAzureDevOpsClient
+// instance is invoked.
+type AzureDevOpsClientGetPullRequestStatusesFunc struct {
+	defaultHook func(context.Context, *azuredevops.Repository, int) ([]*azuredevops.PullRequestStatus, error)
+	hooks       []func(context.Context, *azuredevops.Repository, int) ([]*azuredevops.PullRequestStatus, error)
+	history     []AzureDevOpsClientGetPullRequestStatusesFuncCall
+	mutex       sync.Mutex
+}
+
+// GetPullRequestStatuses delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockAzureDevOpsClient) GetPullRequestStatuses(v0 context.Context, v1 *azuredevops.Repository, v2 int) ([]*azuredevops.PullRequestStatus, error) {
+	r0, r1 := m.GetPullRequestStatusesFunc.nextHook()(v0, v1, v2)
+	m.GetPullRequestStatusesFunc.appendCall(AzureDevOpsClientGetPullRequestStatusesFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetPullRequestStatuses method of the parent MockAzureDevOpsClient
+// instance is invoked and the hook queue is empty.
+func (f *AzureDevOpsClientGetPullRequestStatusesFunc) SetDefaultHook(hook func(context.Context, *azuredevops.Repository, int) ([]*azuredevops.PullRequestStatus, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetPullRequestStatuses method of the parent MockAzureDevOpsClient
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *AzureDevOpsClientGetPullRequestStatusesFunc) PushHook(hook func(context.Context, *azuredevops.Repository, int) ([]*azuredevops.PullRequestStatus, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureDevOpsClientGetPullRequestStatusesFunc) SetDefaultReturn(r0 []*azuredevops.PullRequestStatus, r1 error) {
+	f.SetDefaultHook(func(context.Context, *azuredevops.Repository, int) ([]*azuredevops.PullRequestStatus, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureDevOpsClientGetPullRequestStatusesFunc) PushReturn(r0 []*azuredevops.PullRequestStatus, r1 error) {
+	f.PushHook(func(context.Context, *azuredevops.Repository, int) ([]*azuredevops.PullRequestStatus, error) {
+		return r0, r1
+	})
+}
+
+func (f *AzureDevOpsClientGetPullRequestStatusesFunc) nextHook() func(context.Context, *azuredevops.Repository, int) ([]*azuredevops.PullRequestStatus, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureDevOpsClientGetPullRequestStatusesFunc) appendCall(r0 AzureDevOpsClientGetPullRequestStatusesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// AzureDevOpsClientGetPullRequestStatusesFuncCall objects describing the
+// invocations of this function.
+func (f *AzureDevOpsClientGetPullRequestStatusesFunc) History() []AzureDevOpsClientGetPullRequestStatusesFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureDevOpsClientGetPullRequestStatusesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureDevOpsClientGetPullRequestStatusesFuncCall is an object that
+// describes an invocation of method GetPullRequestStatuses on an instance
+// of MockAzureDevOpsClient.
+type AzureDevOpsClientGetPullRequestStatusesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *azuredevops.Repository
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*azuredevops.PullRequestStatus
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureDevOpsClientGetPullRequestStatusesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureDevOpsClientGetPullRequestStatusesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// AzureDevOpsClientListRepositoriesFunc describes the behavior when the
+// ListRepositories method of the parent MockAzureDevOpsClient instance is
+// invoked.
+type AzureDevOpsClientListRepositoriesFunc struct {
+	defaultHook func(context.Context, string, string) ([]*azuredevops.Repository, error)
+	hooks       []func(context.Context, string, string) ([]*azuredevops.Repository, error)
+	history     []AzureDevOpsClientListRepositoriesFuncCall
+	mutex       sync.Mutex
+}
+
+// ListRepositories delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockAzureDevOpsClient) ListRepositories(v0 context.Context, v1 string, v2 string) ([]*azuredevops.Repository, error) {
+	r0, r1 := m.ListRepositoriesFunc.nextHook()(v0, v1, v2)
+	m.ListRepositoriesFunc.appendCall(AzureDevOpsClientListRepositoriesFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListRepositories
+// method of the parent MockAzureDevOpsClient instance is invoked and the
+// hook queue is empty.
+func (f *AzureDevOpsClientListRepositoriesFunc) SetDefaultHook(hook func(context.Context, string, string) ([]*azuredevops.Repository, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListRepositories method of the parent MockAzureDevOpsClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *AzureDevOpsClientListRepositoriesFunc) PushHook(hook func(context.Context, string, string) ([]*azuredevops.Repository, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureDevOpsClientListRepositoriesFunc) SetDefaultReturn(r0 []*azuredevops.Repository, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string) ([]*azuredevops.Repository, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureDevOpsClientListRepositoriesFunc) PushReturn(r0 []*azuredevops.Repository, r1 error) {
+	f.PushHook(func(context.Context, string, string) ([]*azuredevops.Repository, error) {
+		return r0, r1
+	})
+}
+
+func (f *AzureDevOpsClientListRepositoriesFunc) nextHook() func(context.Context, string, string) ([]*azuredevops.Repository, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureDevOpsClientListRepositoriesFunc) appendCall(r0 AzureDevOpsClientListRepositoriesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AzureDevOpsClientListRepositoriesFuncCall
+// objects describing the invocations of this function.
+func (f *AzureDevOpsClientListRepositoriesFunc) History() []AzureDevOpsClientListRepositoriesFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureDevOpsClientListRepositoriesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureDevOpsClientListRepositoriesFuncCall is an object that describes an
+// invocation of method ListRepositories on an instance of
+// MockAzureDevOpsClient.
+type AzureDevOpsClientListRepositoriesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*azuredevops.Repository
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureDevOpsClientListRepositoriesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureDevOpsClientListRepositoriesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// AzureDevOpsClientPingFunc describes the behavior when the Ping method of
+// the parent MockAzureDevOpsClient instance is invoked.
+type AzureDevOpsClientPingFunc struct {
+	defaultHook func(context.Context, string) error
+	hooks       []func(context.Context, string) error
+	history     []AzureDevOpsClientPingFuncCall
+	mutex       sync.Mutex
+}
+
+// Ping delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockAzureDevOpsClient) Ping(v0 context.Context, v1 string) error {
+	r0 := m.PingFunc.nextHook()(v0, v1)
+	m.PingFunc.appendCall(AzureDevOpsClientPingFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Ping method of the
+// parent MockAzureDevOpsClient instance is invoked and the hook queue is
+// empty.
+func (f *AzureDevOpsClientPingFunc) SetDefaultHook(hook func(context.Context, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Ping method of the parent MockAzureDevOpsClient instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *AzureDevOpsClientPingFunc) PushHook(hook func(context.Context, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureDevOpsClientPingFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureDevOpsClientPingFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string) error {
+		return r0
+	})
+}
+
+func (f *AzureDevOpsClientPingFunc) nextHook() func(context.Context, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureDevOpsClientPingFunc) appendCall(r0 AzureDevOpsClientPingFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AzureDevOpsClientPingFuncCall objects
+// describing the invocations of this function.
+func (f *AzureDevOpsClientPingFunc) History() []AzureDevOpsClientPingFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureDevOpsClientPingFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureDevOpsClientPingFuncCall is an object that describes an invocation
+// of method Ping on an instance of MockAzureDevOpsClient.
+type AzureDevOpsClientPingFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureDevOpsClientPingFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureDevOpsClientPingFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// AzureDevOpsClientUpdatePullRequestFunc describes the behavior when the
+// UpdatePullRequest method of the parent MockAzureDevOpsClient instance is
+// invoked.
+type AzureDevOpsClientUpdatePullRequestFunc struct {
+	defaultHook func(context.Context, *azuredevops.Repository, int, azuredevops.PullRequestUpdateInput) (*azuredevops.PullRequest, error)
+	hooks       []func(context.Context, *azuredevops.Repository, int, azuredevops.PullRequestUpdateInput) (*azuredevops.PullRequest, error)
+	history     []AzureDevOpsClientUpdatePullRequestFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdatePullRequest delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockAzureDevOpsClient) UpdatePullRequest(v0 context.Context, v1 *azuredevops.Repository, v2 int, v3 azuredevops.PullRequestUpdateInput) (*azuredevops.PullRequest, error) {
+	r0, r1 := m.UpdatePullRequestFunc.nextHook()(v0, v1, v2, v3)
+	m.UpdatePullRequestFunc.appendCall(AzureDevOpsClientUpdatePullRequestFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the UpdatePullRequest
+// method of the parent MockAzureDevOpsClient instance is invoked and the
+// hook queue is empty.
+func (f *AzureDevOpsClientUpdatePullRequestFunc) SetDefaultHook(hook func(context.Context, *azuredevops.Repository, int, azuredevops.PullRequestUpdateInput) (*azuredevops.PullRequest, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdatePullRequest method of the parent MockAzureDevOpsClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *AzureDevOpsClientUpdatePullRequestFunc) PushHook(hook func(context.Context, *azuredevops.Repository, int, azuredevops.PullRequestUpdateInput) (*azuredevops.PullRequest, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureDevOpsClientUpdatePullRequestFunc) SetDefaultReturn(r0 *azuredevops.PullRequest, r1 error) {
+	f.SetDefaultHook(func(context.Context, *azuredevops.Repository, int, azuredevops.PullRequestUpdateInput) (*azuredevops.PullRequest, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureDevOpsClientUpdatePullRequestFunc) PushReturn(r0 *azuredevops.PullRequest, r1 error) {
+	f.PushHook(func(context.Context, *azuredevops.Repository, int, azuredevops.PullRequestUpdateInput) (*azuredevops.PullRequest, error) {
+		return r0, r1
+	})
+}
+
+func (f *AzureDevOpsClientUpdatePullRequestFunc) nextHook() func(context.Context, *azuredevops.Repository, int, azuredevops.PullRequestUpdateInput) (*azuredevops.PullRequest, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureDevOpsClientUpdatePullRequestFunc) appendCall(r0 AzureDevOpsClientUpdatePullRequestFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AzureDevOpsClientUpdatePullRequestFuncCall
+// objects describing the invocations of this function.
+func (f *AzureDevOpsClientUpdatePullRequestFunc) History() []AzureDevOpsClientUpdatePullRequestFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureDevOpsClientUpdatePullRequestFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureDevOpsClientUpdatePullRequestFuncCall is an object that describes an
+// invocation of method UpdatePullRequest on an instance of
+// MockAzureDevOpsClient.
+type AzureDevOpsClientUpdatePullRequestFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *azuredevops.Repository
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 azuredevops.PullRequestUpdateInput
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *azuredevops.PullRequest
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureDevOpsClientUpdatePullRequestFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureDevOpsClientUpdatePullRequestFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// AzureDevOpsClientWithAuthenticatorFunc describes the behavior when the
+// WithAuthenticator method of the parent MockAzureDevOpsClient instance is
+// invoked.
+type AzureDevOpsClientWithAuthenticatorFunc struct {
+	defaultHook func(auth.Authenticator) azuredevops.Client
+	hooks       []func(auth.Authenticator) azuredevops.Client
+	history     []AzureDevOpsClientWithAuthenticatorFuncCall
+	mutex       sync.Mutex
+}
+
+// WithAuthenticator delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockAzureDevOpsClient) WithAuthenticator(v0 auth.Authenticator) azuredevops.Client {
+	r0 := m.WithAuthenticatorFunc.nextHook()(v0)
+	m.WithAuthenticatorFunc.appendCall(AzureDevOpsClientWithAuthenticatorFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the WithAuthenticator
+// method of the parent MockAzureDevOpsClient instance is invoked and the
+// hook queue is empty.
+func (f *AzureDevOpsClientWithAuthenticatorFunc) SetDefaultHook(hook func(auth.Authenticator) azuredevops.Client) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// WithAuthenticator method of the parent MockAzureDevOpsClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *AzureDevOpsClientWithAuthenticatorFunc) PushHook(hook func(auth.Authenticator) azuredevops.Client) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureDevOpsClientWithAuthenticatorFunc) SetDefaultReturn(r0 azuredevops.Client) {
+	f.SetDefaultHook(func(auth.Authenticator) azuredevops.Client {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureDevOpsClientWithAuthenticatorFunc) PushReturn(r0 azuredevops.Client) {
+	f.PushHook(func(auth.Authenticator) azuredevops.Client {
+		return r0
+	})
+}
+
+func (f *AzureDevOpsClientWithAuthenticatorFunc) nextHook() func(auth.Authenticator) azuredevops.Client {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureDevOpsClientWithAuthenticatorFunc) appendCall(r0 AzureDevOpsClientWithAuthenticatorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AzureDevOpsClientWithAuthenticatorFuncCall
+// objects describing the invocations of this function.
+func (f *AzureDevOpsClientWithAuthenticatorFunc) History() []AzureDevOpsClientWithAuthenticatorFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureDevOpsClientWithAuthenticatorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureDevOpsClientWithAuthenticatorFuncCall is an object that describes an
+// invocation of method WithAuthenticator on an instance of
+// MockAzureDevOpsClient.
+type AzureDevOpsClientWithAuthenticatorFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 auth.Authenticator
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 azuredevops.Client
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureDevOpsClientWithAuthenticatorFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureDevOpsClientWithAuthenticatorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockBitbucketCloudClient is a mock implementation of the Client interface
 // (from the package
 // github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud) used
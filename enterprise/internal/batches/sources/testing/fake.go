@@ -44,20 +44,22 @@ type FakeChangesetSource struct {
 
 	CurrentAuthenticator auth.Authenticator
 
-	CreateDraftChangesetCalled  bool
-	UndraftedChangesetsCalled   bool
-	CreateChangesetCalled       bool
-	UpdateChangesetCalled       bool
-	ListReposCalled             bool
-	ExternalServicesCalled      bool
-	LoadChangesetCalled         bool
-	CloseChangesetCalled        bool
-	ReopenChangesetCalled       bool
-	CreateCommentCalled         bool
-	AuthenticatedUsernameCalled bool
-	ValidateAuthenticatorCalled bool
-	MergeChangesetCalled        bool
-	IsArchivedPushErrorCalled   bool
+	CreateDraftChangesetCalled   bool
+	UndraftedChangesetsCalled    bool
+	CreateChangesetCalled        bool
+	UpdateChangesetCalled        bool
+	ListReposCalled              bool
+	ExternalServicesCalled       bool
+	LoadChangesetCalled          bool
+	CloseChangesetCalled         bool
+	ReopenChangesetCalled        bool
+	CreateCommentCalled          bool
+	AuthenticatedUsernameCalled  bool
+	ValidateAuthenticatorCalled  bool
+	MergeChangesetCalled         bool
+	IsArchivedPushErrorCalled    bool
+	ApplyChangesetMetadataCalled bool
+	RebaseChangesetCalled        bool
 
 	// The Changeset.HeadRef to be expected in CreateChangeset/UpdateChangeset calls.
 	WantHeadRef string
@@ -98,6 +100,10 @@ type FakeChangesetSource struct {
 	// UndraftedChangesets contains the changesets that were passed to UndraftChangeset
 	UndraftedChangesets []*sources.Changeset
 
+	// MetadataChangesets contains the changesets that were passed to
+	// ApplyChangesetMetadata
+	MetadataChangesets []*sources.Changeset
+
 	// Username is the username returned by AuthenticatedUsername
 	Username string
 
@@ -109,6 +115,8 @@ var (
 	_ sources.ChangesetSource           = &FakeChangesetSource{}
 	_ sources.ArchivableChangesetSource = &FakeChangesetSource{}
 	_ sources.DraftChangesetSource      = &FakeChangesetSource{}
+	_ sources.MetadataChangesetSource   = &FakeChangesetSource{}
+	_ sources.RebaseableChangesetSource = &FakeChangesetSource{}
 )
 
 func (s *FakeChangesetSource) CreateDraftChangeset(ctx context.Context, c *sources.Changeset) (bool, error) {
@@ -160,6 +168,17 @@ func (s *FakeChangesetSource) UndraftChangeset(ctx context.Context, c *sources.C
 	return c.SetMetadata(s.FakeMetadata)
 }
 
+func (s *FakeChangesetSource) ApplyChangesetMetadata(ctx context.Context, c *sources.Changeset) error {
+	s.ApplyChangesetMetadataCalled = true
+
+	if s.Err != nil {
+		return s.Err
+	}
+
+	s.MetadataChangesets = append(s.MetadataChangesets, c)
+	return nil
+}
+
 func (s *FakeChangesetSource) CreateChangeset(ctx context.Context, c *sources.Changeset) (bool, error) {
 	s.CreateChangesetCalled = true
 
@@ -316,6 +335,11 @@ func (s *FakeChangesetSource) MergeChangeset(ctx context.Context, c *sources.Cha
 	return s.Err
 }
 
+func (s *FakeChangesetSource) RebaseChangeset(ctx context.Context, c *sources.Changeset) error {
+	s.RebaseChangesetCalled = true
+	return s.Err
+}
+
 func (s *FakeChangesetSource) IsArchivedPushError(output string) bool {
 	s.IsArchivedPushErrorCalled = true
 	return s.IsArchivedPushErrorTrue
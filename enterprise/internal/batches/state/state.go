@@ -11,15 +11,19 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -112,6 +116,12 @@ func computeCheckState(c *btypes.Changeset, events ChangesetEvents) btypes.Chang
 
 	case *bbcs.AnnotatedPullRequest:
 		return computeBitbucketCloudBuildState(c.UpdatedAt, m, events)
+
+	case *adobatches.AnnotatedPullRequest:
+		return computeAzureDevOpsBuildState(m)
+
+	case *gerritbatches.AnnotatedChange:
+		return computeGerritCheckState(m)
 	}
 
 	return btypes.ChangesetCheckStateUnknown
@@ -251,6 +261,56 @@ func parseBitbucketCloudBuildState(s bitbucketcloud.PullRequestStatusState) btyp
 	}
 }
 
+func computeAzureDevOpsBuildState(apr *adobatches.AnnotatedPullRequest) btypes.ChangesetCheckState {
+	// Azure DevOps keeps the statuses of previous iterations of the pull
+	// request, so we only consider the most recent status of each service.
+	latest := make(map[string]*azuredevops.PullRequestStatus)
+	for _, status := range apr.Statuses {
+		if l, ok := latest[status.Key()]; !ok || l.UpdatedDate.Before(status.UpdatedDate) {
+			latest[status.Key()] = status
+		}
+	}
+
+	states := make([]btypes.ChangesetCheckState, 0, len(latest))
+	for _, status := range latest {
+		states = append(states, parseAzureDevOpsBuildState(status.State))
+	}
+
+	return combineCheckStates(states)
+}
+
+func parseAzureDevOpsBuildState(s azuredevops.PullRequestStatusState) btypes.ChangesetCheckState {
+	switch s {
+	case azuredevops.PullRequestStatusStateFailed, azuredevops.PullRequestStatusStateError:
+		return btypes.ChangesetCheckStateFailed
+	case azuredevops.PullRequestStatusStatePending:
+		return btypes.ChangesetCheckStatePending
+	case azuredevops.PullRequestStatusStateSucceeded:
+		return btypes.ChangesetCheckStatePassed
+	default:
+		return btypes.ChangesetCheckStateUnknown
+	}
+}
+
+// gerritVerifiedLabel is the Gerrit review label that CI systems vote on.
+const gerritVerifiedLabel = "Verified"
+
+func computeGerritCheckState(c *gerritbatches.AnnotatedChange) btypes.ChangesetCheckState {
+	label, ok := c.Labels[gerritVerifiedLabel]
+	if !ok {
+		return btypes.ChangesetCheckStateUnknown
+	}
+
+	switch {
+	case label.Rejected != nil, label.Disliked != nil:
+		return btypes.ChangesetCheckStateFailed
+	case label.Approved != nil:
+		return btypes.ChangesetCheckStatePassed
+	default:
+		return btypes.ChangesetCheckStatePending
+	}
+}
+
 func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, events []*btypes.ChangesetEvent) btypes.ChangesetCheckState {
 	// We should only consider the latest commit. This could be from a sync or a webhook that
 	// has occurred later
@@ -515,6 +575,36 @@ func computeSingleChangesetExternalState(c *btypes.Changeset) (s btypes.Changese
 		default:
 			return "", errors.Errorf("unknown Bitbucket Cloud pull request state: %s", m.State)
 		}
+	case *adobatches.AnnotatedPullRequest:
+		switch m.Status {
+		case azuredevops.PullRequestStatusAbandoned:
+			s = btypes.ChangesetExternalStateClosed
+		case azuredevops.PullRequestStatusCompleted:
+			s = btypes.ChangesetExternalStateMerged
+		case azuredevops.PullRequestStatusActive:
+			if m.IsDraft {
+				s = btypes.ChangesetExternalStateDraft
+			} else {
+				s = btypes.ChangesetExternalStateOpen
+			}
+		default:
+			return "", errors.Errorf("unknown Azure DevOps pull request status: %s", m.Status)
+		}
+	case *gerritbatches.AnnotatedChange:
+		switch m.Status {
+		case gerrit.ChangeStatusAbandoned:
+			s = btypes.ChangesetExternalStateClosed
+		case gerrit.ChangeStatusMerged:
+			s = btypes.ChangesetExternalStateMerged
+		case gerrit.ChangeStatusNew:
+			if m.WorkInProgress {
+				s = btypes.ChangesetExternalStateDraft
+			} else {
+				s = btypes.ChangesetExternalStateOpen
+			}
+		default:
+			return "", errors.Errorf("unknown Gerrit change status: %s", m.Status)
+		}
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -584,6 +674,36 @@ func computeSingleChangesetReviewState(c *btypes.Changeset) (s btypes.ChangesetR
 			}
 		}
 
+	case *adobatches.AnnotatedPullRequest:
+		for _, reviewer := range m.Reviewers {
+			switch {
+			case reviewer.Vote > azuredevops.VoteNone:
+				states[btypes.ChangesetReviewStateApproved] = true
+			case reviewer.Vote < azuredevops.VoteNone:
+				states[btypes.ChangesetReviewStateChangesRequested] = true
+			default:
+				states[btypes.ChangesetReviewStatePending] = true
+			}
+		}
+
+	case *gerritbatches.AnnotatedChange:
+		// Every label other than Verified is voted on by reviewers, such as
+		// Code-Review. Only the maximum vote of a label approves the change,
+		// while any negative vote blocks it.
+		for name, label := range m.Labels {
+			if name == gerritVerifiedLabel {
+				continue
+			}
+			switch {
+			case label.Rejected != nil, label.Disliked != nil:
+				states[btypes.ChangesetReviewStateChangesRequested] = true
+			case label.Approved != nil:
+				states[btypes.ChangesetReviewStateApproved] = true
+			default:
+				states[btypes.ChangesetReviewStatePending] = true
+			}
+		}
+
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -7,9 +7,13 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
@@ -407,6 +411,194 @@ func TestComputeGitLabCheckState(t *testing.T) {
 	})
 }
 
+func TestComputeAzureDevOpsState(t *testing.T) {
+	t.Parallel()
+
+	t.Run("check state", func(t *testing.T) {
+		status := func(name string, state azuredevops.PullRequestStatusState, updated int64) *azuredevops.PullRequestStatus {
+			return &azuredevops.PullRequestStatus{
+				State:       state,
+				Context:     azuredevops.StatusContext{Genre: "ci", Name: name},
+				UpdatedDate: time.Unix(updated, 0),
+			}
+		}
+
+		for name, tc := range map[string]struct {
+			statuses []*azuredevops.PullRequestStatus
+			want     btypes.ChangesetCheckState
+		}{
+			"no statuses": {
+				want: btypes.ChangesetCheckStateUnknown,
+			},
+			"passed": {
+				statuses: []*azuredevops.PullRequestStatus{
+					status("build", azuredevops.PullRequestStatusStateSucceeded, 1),
+					status("lint", azuredevops.PullRequestStatusStateSucceeded, 1),
+				},
+				want: btypes.ChangesetCheckStatePassed,
+			},
+			"one failed": {
+				statuses: []*azuredevops.PullRequestStatus{
+					status("build", azuredevops.PullRequestStatusStateSucceeded, 1),
+					status("lint", azuredevops.PullRequestStatusStateError, 1),
+				},
+				want: btypes.ChangesetCheckStateFailed,
+			},
+			"failure superseded by a later status": {
+				statuses: []*azuredevops.PullRequestStatus{
+					status("build", azuredevops.PullRequestStatusStatePending, 3),
+					status("build", azuredevops.PullRequestStatusStateFailed, 2),
+				},
+				want: btypes.ChangesetCheckStatePending,
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				c := azureDevOpsChangeset(azuredevops.PullRequestStatusActive, false)
+				c.Metadata.(*adobatches.AnnotatedPullRequest).Statuses = tc.statuses
+				if have := computeCheckState(c, nil); have != tc.want {
+					t.Errorf("wrong check state: have=%s, want=%s", have, tc.want)
+				}
+			})
+		}
+	})
+
+	t.Run("review state", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			votes []int
+			want  btypes.ChangesetReviewState
+		}{
+			"no reviewers":     {want: btypes.ChangesetReviewStatePending},
+			"no vote":          {votes: []int{azuredevops.VoteNone}, want: btypes.ChangesetReviewStatePending},
+			"approved":         {votes: []int{azuredevops.VoteApprovedWithSuggestions, azuredevops.VoteNone}, want: btypes.ChangesetReviewStateApproved},
+			"waiting":          {votes: []int{azuredevops.VoteApproved, azuredevops.VoteWaitingForAuthor}, want: btypes.ChangesetReviewStateChangesRequested},
+			"rejected":         {votes: []int{azuredevops.VoteRejected}, want: btypes.ChangesetReviewStateChangesRequested},
+			"several approved": {votes: []int{azuredevops.VoteApproved, azuredevops.VoteApproved}, want: btypes.ChangesetReviewStateApproved},
+		} {
+			t.Run(name, func(t *testing.T) {
+				c := azureDevOpsChangeset(azuredevops.PullRequestStatusActive, false)
+				for _, vote := range tc.votes {
+					pr := c.Metadata.(*adobatches.AnnotatedPullRequest)
+					pr.Reviewers = append(pr.Reviewers, azuredevops.Reviewer{Vote: vote})
+				}
+				have, err := computeReviewState(c, nil)
+				if err != nil {
+					t.Fatalf("got error: %s", err)
+				}
+				if have != tc.want {
+					t.Errorf("wrong review state: have=%s, want=%s", have, tc.want)
+				}
+			})
+		}
+	})
+
+	t.Run("external state", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			changeset *btypes.Changeset
+			want      btypes.ChangesetExternalState
+		}{
+			"active":    {changeset: azureDevOpsChangeset(azuredevops.PullRequestStatusActive, false), want: btypes.ChangesetExternalStateOpen},
+			"draft":     {changeset: azureDevOpsChangeset(azuredevops.PullRequestStatusActive, true), want: btypes.ChangesetExternalStateDraft},
+			"abandoned": {changeset: azureDevOpsChangeset(azuredevops.PullRequestStatusAbandoned, false), want: btypes.ChangesetExternalStateClosed},
+			"completed": {changeset: azureDevOpsChangeset(azuredevops.PullRequestStatusCompleted, false), want: btypes.ChangesetExternalStateMerged},
+		} {
+			t.Run(name, func(t *testing.T) {
+				have, err := computeExternalState(tc.changeset, nil, &types.Repo{})
+				if err != nil {
+					t.Fatalf("got error: %s", err)
+				}
+				if have != tc.want {
+					t.Errorf("wrong external state: have=%s, want=%s", have, tc.want)
+				}
+			})
+		}
+	})
+}
+
+func TestComputeGerritState(t *testing.T) {
+	t.Parallel()
+
+	approved := gerrit.LabelInfo{Approved: &gerrit.Account{Username: "a"}}
+	recommended := gerrit.LabelInfo{Recommended: &gerrit.Account{Username: "a"}}
+	disliked := gerrit.LabelInfo{Recommended: &gerrit.Account{Username: "a"}, Disliked: &gerrit.Account{Username: "b"}}
+	rejected := gerrit.LabelInfo{Approved: &gerrit.Account{Username: "a"}, Rejected: &gerrit.Account{Username: "b"}}
+
+	t.Run("check state", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			labels map[string]gerrit.LabelInfo
+			want   btypes.ChangesetCheckState
+		}{
+			"no Verified label": {
+				labels: map[string]gerrit.LabelInfo{"Code-Review": approved},
+				want:   btypes.ChangesetCheckStateUnknown,
+			},
+			"no votes": {labels: map[string]gerrit.LabelInfo{"Verified": {}}, want: btypes.ChangesetCheckStatePending},
+			"approved": {labels: map[string]gerrit.LabelInfo{"Verified": approved}, want: btypes.ChangesetCheckStatePassed},
+			"rejected": {labels: map[string]gerrit.LabelInfo{"Verified": rejected}, want: btypes.ChangesetCheckStateFailed},
+			"disliked": {labels: map[string]gerrit.LabelInfo{"Verified": disliked}, want: btypes.ChangesetCheckStateFailed},
+		} {
+			t.Run(name, func(t *testing.T) {
+				c := gerritChangeset(gerrit.ChangeStatusNew, false)
+				c.Metadata.(*gerritbatches.AnnotatedChange).Labels = tc.labels
+				if have := computeCheckState(c, nil); have != tc.want {
+					t.Errorf("wrong check state: have=%s, want=%s", have, tc.want)
+				}
+			})
+		}
+	})
+
+	t.Run("review state", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			labels map[string]gerrit.LabelInfo
+			want   btypes.ChangesetReviewState
+		}{
+			"no labels":   {want: btypes.ChangesetReviewStatePending},
+			"no votes":    {labels: map[string]gerrit.LabelInfo{"Code-Review": {}}, want: btypes.ChangesetReviewStatePending},
+			"recommended": {labels: map[string]gerrit.LabelInfo{"Code-Review": recommended}, want: btypes.ChangesetReviewStatePending},
+			"approved":    {labels: map[string]gerrit.LabelInfo{"Code-Review": approved}, want: btypes.ChangesetReviewStateApproved},
+			"disliked":    {labels: map[string]gerrit.LabelInfo{"Code-Review": disliked}, want: btypes.ChangesetReviewStateChangesRequested},
+			"rejected":    {labels: map[string]gerrit.LabelInfo{"Code-Review": rejected}, want: btypes.ChangesetReviewStateChangesRequested},
+			"Verified is ignored": {
+				labels: map[string]gerrit.LabelInfo{"Code-Review": approved, "Verified": rejected},
+				want:   btypes.ChangesetReviewStateApproved,
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				c := gerritChangeset(gerrit.ChangeStatusNew, false)
+				c.Metadata.(*gerritbatches.AnnotatedChange).Labels = tc.labels
+				have, err := computeReviewState(c, nil)
+				if err != nil {
+					t.Fatalf("got error: %s", err)
+				}
+				if have != tc.want {
+					t.Errorf("wrong review state: have=%s, want=%s", have, tc.want)
+				}
+			})
+		}
+	})
+
+	t.Run("external state", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			changeset *btypes.Changeset
+			want      btypes.ChangesetExternalState
+		}{
+			"new":              {changeset: gerritChangeset(gerrit.ChangeStatusNew, false), want: btypes.ChangesetExternalStateOpen},
+			"work in progress": {changeset: gerritChangeset(gerrit.ChangeStatusNew, true), want: btypes.ChangesetExternalStateDraft},
+			"abandoned":        {changeset: gerritChangeset(gerrit.ChangeStatusAbandoned, false), want: btypes.ChangesetExternalStateClosed},
+			"merged":           {changeset: gerritChangeset(gerrit.ChangeStatusMerged, false), want: btypes.ChangesetExternalStateMerged},
+		} {
+			t.Run(name, func(t *testing.T) {
+				have, err := computeExternalState(tc.changeset, nil, &types.Repo{})
+				if err != nil {
+					t.Fatalf("got error: %s", err)
+				}
+				if have != tc.want {
+					t.Errorf("wrong external state: have=%s, want=%s", have, tc.want)
+				}
+			})
+		}
+	})
+}
+
 func TestComputeReviewState(t *testing.T) {
 	t.Parallel()
 
@@ -881,6 +1073,24 @@ func bitbucketChangeset(updatedAt time.Time, state, reviewStatus string) *btypes
 	}
 }
 
+func azureDevOpsChangeset(status azuredevops.PullRequestStatusValue, draft bool) *btypes.Changeset {
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypeAzureDevOps,
+		Metadata: &adobatches.AnnotatedPullRequest{
+			PullRequest: &azuredevops.PullRequest{Status: status, IsDraft: draft},
+		},
+	}
+}
+
+func gerritChangeset(status gerrit.ChangeStatus, wip bool) *btypes.Changeset {
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypeGerrit,
+		Metadata: &gerritbatches.AnnotatedChange{
+			Change: &gerrit.Change{Status: status, WorkInProgress: wip},
+		},
+	}
+}
+
 func githubChangeset(updatedAt time.Time, state string) *btypes.Changeset {
 	return &btypes.Changeset{
 		ExternalServiceType: extsvc.TypeGitHub,
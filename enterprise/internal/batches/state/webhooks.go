@@ -0,0 +1,45 @@
+package state
+
+import (
+	"context"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
+)
+
+// EnqueueChangesetStateChangedWebhook notifies outbound webhooks if the
+// external state of the changeset differs from previous. It should be called
+// within the same transaction that persists the changeset.
+func EnqueueChangesetStateChangedWebhook(ctx context.Context, db database.DB, repo *types.Repo, c *btypes.Changeset, previous btypes.ChangesetExternalState) error {
+	if c.ExternalState == previous {
+		return nil
+	}
+
+	batchChangeIDs := make([]int64, 0, len(c.BatchChanges))
+	for _, assoc := range c.BatchChanges {
+		batchChangeIDs = append(batchChangeIDs, assoc.BatchChangeID)
+	}
+
+	// Not every changeset has a URL (for example, if it was deleted on the
+	// code host), so we don't fail if one isn't available.
+	externalURL, _ := c.URL()
+
+	name := string(repo.Name)
+	return outbound.NewOutboundWebhookService(db, keyring.Default().OutboundWebhookKey).Enqueue(
+		ctx,
+		outbound.EventTypeChangesetStateChanged,
+		&name,
+		outbound.ChangesetStateChangedData{
+			ID:             c.ID,
+			Repository:     outbound.Repository{ID: int32(repo.ID), Name: name},
+			BatchChangeIDs: batchChangeIDs,
+			ExternalID:     c.ExternalID,
+			ExternalURL:    externalURL,
+			PreviousState:  string(previous),
+			State:          string(c.ExternalState),
+		},
+	)
+}
@@ -37,6 +37,9 @@ var batchChangeColumns = []*sqlf.Query{
 	sqlf.Sprintf("batch_changes.updated_at"),
 	sqlf.Sprintf("batch_changes.closed_at"),
 	sqlf.Sprintf("batch_changes.batch_spec_id"),
+	sqlf.Sprintf("batch_changes.merge_wave"),
+	sqlf.Sprintf("batch_changes.merge_wave_started_at"),
+	sqlf.Sprintf("batch_changes.merge_wave_enqueued_at"),
 }
 
 // batchChangeInsertColumns is the list of batch changes columns that are
@@ -237,6 +240,38 @@ func (s *Store) updateBatchChangeQuery(c *btypes.BatchChange) *sqlf.Query {
 	)
 }
 
+// UpdateBatchChangeMergeWave updates only the merge wave columns of the given
+// batch change, so that the merge wave progress doesn't overwrite concurrent
+// changes to the batch change itself.
+func (s *Store) UpdateBatchChangeMergeWave(ctx context.Context, c *btypes.BatchChange) (err error) {
+	ctx, _, endObservation := s.operations.updateBatchChangeMergeWave.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(c.ID)),
+		log.Int("mergeWave", int(c.MergeWave)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		updateBatchChangeMergeWaveQueryFmtstr,
+		c.MergeWave,
+		dbutil.NullTimeColumn(c.MergeWaveStartedAt),
+		dbutil.NullTimeColumn(c.MergeWaveEnqueuedAt),
+		c.ID,
+		sqlf.Join(batchChangeColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) (err error) { return scanBatchChange(c, sc) })
+}
+
+var updateBatchChangeMergeWaveQueryFmtstr = `
+UPDATE batch_changes
+SET
+	merge_wave = %s,
+	merge_wave_started_at = %s,
+	merge_wave_enqueued_at = %s
+WHERE id = %s
+RETURNING %s
+`
+
 // DeleteBatchChange deletes the batch change with the given ID.
 func (s *Store) DeleteBatchChange(ctx context.Context, id int64) (err error) {
 	ctx, _, endObservation := s.operations.deleteBatchChange.With(ctx, &err, observation.Args{LogFields: []log.Field{
@@ -572,6 +607,10 @@ type ListBatchChangesOpts struct {
 	RepoID api.RepoID
 
 	ExcludeDraftsNotOwnedByUserID int32
+
+	// OnlyWithMergeWaves limits the results to batch changes whose applied
+	// batch spec declares merge waves.
+	OnlyWithMergeWaves bool
 }
 
 // ListBatchChanges lists batch changes with the given filters.
@@ -686,6 +725,11 @@ OR
 		)`, opts.RepoID, repoAuthzConds))
 	}
 
+	if opts.OnlyWithMergeWaves {
+		joins = append(joins, sqlf.Sprintf("INNER JOIN batch_specs ON batch_specs.id = batch_changes.batch_spec_id"))
+		preds = append(preds, sqlf.Sprintf("jsonb_array_length(COALESCE(batch_specs.spec->'mergeWaves', '[]'::jsonb)) > 0"))
+	}
+
 	if len(preds) == 0 {
 		preds = append(preds, sqlf.Sprintf("TRUE"))
 	}
@@ -712,6 +756,9 @@ func scanBatchChange(c *btypes.BatchChange, s dbutil.Scanner) error {
 		&c.UpdatedAt,
 		&dbutil.NullTime{Time: &c.ClosedAt},
 		&dbutil.NullInt64{N: &c.BatchSpecID},
+		&c.MergeWave,
+		&dbutil.NullTime{Time: &c.MergeWaveStartedAt},
+		&dbutil.NullTime{Time: &c.MergeWaveEnqueuedAt},
 	)
 }
 
@@ -703,6 +703,22 @@ func testStoreBatchChanges(t *testing.T, ctx context.Context, s *Store, clock bt
 		})
 	})
 
+	t.Run("UpdateBatchChangeMergeWave", func(t *testing.T) {
+		c := bcs[1]
+		c.MergeWave = 2
+		c.MergeWaveStartedAt = clock.Now()
+		c.MergeWaveEnqueuedAt = clock.Now().Add(time.Minute)
+
+		want := c.Clone()
+		if err := s.UpdateBatchChangeMergeWave(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(want, c); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
 	t.Run("Get", func(t *testing.T) {
 		t.Run("ByID", func(t *testing.T) {
 			want := bcs[0]
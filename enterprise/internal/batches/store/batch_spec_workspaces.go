@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"sort"
+	"strconv"
 
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
@@ -146,6 +147,11 @@ func (s *Store) CreateBatchSpecWorkspace(ctx context.Context, ws ...*btypes.Batc
 // GetBatchSpecWorkspaceOpts captures the query options needed for getting a BatchSpecWorkspace
 type GetBatchSpecWorkspaceOpts struct {
 	ID int64
+
+	// BatchSpecID and ChangesetSpecID find the workspace of the batch spec
+	// whose execution created the changeset spec.
+	BatchSpecID     int64
+	ChangesetSpecID int64
 }
 
 // GetBatchSpecWorkspace gets a BatchSpecWorkspace matching the given options.
@@ -181,7 +187,18 @@ LIMIT 1
 func getBatchSpecWorkspaceQuery(opts *GetBatchSpecWorkspaceOpts) *sqlf.Query {
 	preds := []*sqlf.Query{
 		sqlf.Sprintf("repo.deleted_at IS NULL"),
-		sqlf.Sprintf("batch_spec_workspaces.id = %s", opts.ID),
+	}
+
+	if opts.ID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_workspaces.id = %s", opts.ID))
+	}
+
+	if opts.BatchSpecID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_workspaces.batch_spec_id = %s", opts.BatchSpecID))
+	}
+
+	if opts.ChangesetSpecID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_workspaces.changeset_spec_ids ? %s", strconv.FormatInt(opts.ChangesetSpecID, 10)))
 	}
 
 	return sqlf.Sprintf(
@@ -503,6 +520,47 @@ func (s *Store) DisableBatchSpecWorkspaceExecutionCache(ctx context.Context, bat
 	return s.Exec(ctx, q)
 }
 
+const updateBatchSpecWorkspaceCommitQueryFmtstr = `
+UPDATE
+	batch_spec_workspaces
+SET
+	commit = %s,
+	cached_result_found = %s,
+	step_cache_results = %s,
+	updated_at = %s
+WHERE
+	id = %s
+RETURNING %s
+`
+
+// UpdateBatchSpecWorkspaceCommit updates the commit a workspace is executed
+// against, along with the cached step results for that commit.
+func (s *Store) UpdateBatchSpecWorkspaceCommit(ctx context.Context, ws *btypes.BatchSpecWorkspace) (err error) {
+	ctx, _, endObservation := s.operations.updateBatchSpecWorkspaceCommit.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(ws.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	marshaledStepCacheResults, err := json.Marshal(ws.StepCacheResults)
+	if err != nil {
+		return err
+	}
+
+	ws.UpdatedAt = s.now()
+	q := sqlf.Sprintf(
+		updateBatchSpecWorkspaceCommitQueryFmtstr,
+		ws.Commit,
+		ws.CachedResultFound,
+		marshaledStepCacheResults,
+		ws.UpdatedAt,
+		ws.ID,
+		sqlf.Join(BatchSpecWorkspaceColums.ToSqlf(), ", "),
+	)
+	return s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanBatchSpecWorkspace(ws, sc)
+	})
+}
+
 func scanBatchSpecWorkspace(wj *btypes.BatchSpecWorkspace, s dbutil.Scanner) error {
 	var stepCacheResults json.RawMessage
 
@@ -107,6 +107,28 @@ func testStoreBatchSpecWorkspaces(t *testing.T, ctx context.Context, s *Store, c
 			}
 		})
 
+		t.Run("GetByChangesetSpecID", func(t *testing.T) {
+			job := workspaces[0]
+			have, err := s.GetBatchSpecWorkspace(ctx, GetBatchSpecWorkspaceOpts{
+				BatchSpecID:     job.BatchSpecID,
+				ChangesetSpecID: job.ChangesetSpecIDs[1],
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(have, job); diff != "" {
+				t.Fatal(diff)
+			}
+
+			_, err = s.GetBatchSpecWorkspace(ctx, GetBatchSpecWorkspaceOpts{
+				BatchSpecID:     workspaces[1].BatchSpecID,
+				ChangesetSpecID: job.ChangesetSpecIDs[1],
+			})
+			if err != ErrNoResults {
+				t.Fatalf("wrong error: %v", err)
+			}
+		})
+
 		t.Run("NoResults", func(t *testing.T) {
 			opts := GetBatchSpecWorkspaceOpts{ID: 0xdeadbeef}
 
@@ -501,4 +523,30 @@ func testStoreBatchSpecWorkspaces(t *testing.T, ctx context.Context, s *Store, c
 		})
 		require.Error(t, err, ErrNoResults)
 	})
+
+	t.Run("UpdateBatchSpecWorkspaceCommit", func(t *testing.T) {
+		workspace := &btypes.BatchSpecWorkspace{
+			BatchSpecID:       workspaces[0].BatchSpecID,
+			RepoID:            repos[0].ID,
+			Commit:            "d34db33f",
+			CachedResultFound: true,
+			ChangesetSpecIDs:  []int64{1, 2},
+		}
+		require.NoError(t, s.CreateBatchSpecWorkspace(ctx, workspace))
+
+		workspace.Commit = "c0ffee"
+		workspace.CachedResultFound = false
+		workspace.StepCacheResults = map[int]btypes.StepCacheResult{
+			1: {Key: "asdf", Value: &execution.AfterStepResult{StepIndex: 0}},
+		}
+		require.NoError(t, s.UpdateBatchSpecWorkspaceCommit(ctx, workspace))
+
+		have, err := s.GetBatchSpecWorkspace(ctx, GetBatchSpecWorkspaceOpts{ID: workspace.ID})
+		require.NoError(t, err)
+
+		// The changeset specs of the previous execution are kept.
+		if diff := cmp.Diff(workspace, have); diff != "" {
+			t.Fatalf("invalid workspace state: %s", diff)
+		}
+	})
 }
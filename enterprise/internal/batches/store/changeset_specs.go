@@ -45,6 +45,10 @@ var changesetSpecInsertColumns = []string{
 	"commit_author_name",
 	"commit_author_email",
 	"type",
+	"reviewers",
+	"team_reviewers",
+	"labels",
+	"assignees",
 }
 
 // changesetSpecColumns are used by the changeset spec related Store methods to
@@ -72,6 +76,10 @@ var changesetSpecColumns = SQLColumns{
 	"changeset_specs.commit_author_name",
 	"changeset_specs.commit_author_email",
 	"changeset_specs.type",
+	"changeset_specs.reviewers",
+	"changeset_specs.team_reviewers",
+	"changeset_specs.labels",
+	"changeset_specs.assignees",
 }
 
 var oneGigabyte = 1000000000
@@ -137,6 +145,10 @@ func (s *Store) CreateChangesetSpec(ctx context.Context, cs ...*btypes.Changeset
 				dbutil.NewNullString(c.CommitAuthorName),
 				dbutil.NewNullString(c.CommitAuthorEmail),
 				c.Type,
+				pq.Array(c.Reviewers),
+				pq.Array(c.TeamReviewers),
+				pq.Array(c.Labels),
+				pq.Array(c.Assignees),
 			); err != nil {
 				return err
 			}
@@ -562,6 +574,10 @@ func scanChangesetSpec(c *btypes.ChangesetSpec, s dbutil.Scanner) error {
 		&dbutil.NullString{S: &c.CommitAuthorName},
 		&dbutil.NullString{S: &c.CommitAuthorEmail},
 		&typ,
+		pq.Array(&c.Reviewers),
+		pq.Array(&c.TeamReviewers),
+		pq.Array(&c.Labels),
+		pq.Array(&c.Assignees),
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset spec")
@@ -170,15 +170,16 @@ func (s *Store) queryCount(ctx context.Context, q *sqlf.Query) (int, error) {
 }
 
 type operations struct {
-	createBatchChange      *observation.Operation
-	upsertBatchChange      *observation.Operation
-	updateBatchChange      *observation.Operation
-	deleteBatchChange      *observation.Operation
-	countBatchChanges      *observation.Operation
-	getBatchChange         *observation.Operation
-	getBatchChangeDiffStat *observation.Operation
-	getRepoDiffStat        *observation.Operation
-	listBatchChanges       *observation.Operation
+	createBatchChange          *observation.Operation
+	upsertBatchChange          *observation.Operation
+	updateBatchChange          *observation.Operation
+	updateBatchChangeMergeWave *observation.Operation
+	deleteBatchChange          *observation.Operation
+	countBatchChanges          *observation.Operation
+	getBatchChange             *observation.Operation
+	getBatchChangeDiffStat     *observation.Operation
+	getRepoDiffStat            *observation.Operation
+	listBatchChanges           *observation.Operation
 
 	createBatchSpecExecution *observation.Operation
 	getBatchSpecExecution    *observation.Operation
@@ -272,6 +273,7 @@ type operations struct {
 	cancelBatchSpecWorkspaceExecutionJobs              *observation.Operation
 	retryBatchSpecWorkspaceExecutionJobs               *observation.Operation
 	disableBatchSpecWorkspaceExecutionCache            *observation.Operation
+	updateBatchSpecWorkspaceCommit                     *observation.Operation
 
 	createBatchSpecResolutionJob *observation.Operation
 	getBatchSpecResolutionJob    *observation.Operation
@@ -314,15 +316,16 @@ func newOperations(observationCtx *observation.Context) *operations {
 		}
 
 		singletonOperations = &operations{
-			createBatchChange:      op("CreateBatchChange"),
-			upsertBatchChange:      op("UpsertBatchChange"),
-			updateBatchChange:      op("UpdateBatchChange"),
-			deleteBatchChange:      op("DeleteBatchChange"),
-			countBatchChanges:      op("CountBatchChanges"),
-			listBatchChanges:       op("ListBatchChanges"),
-			getBatchChange:         op("GetBatchChange"),
-			getBatchChangeDiffStat: op("GetBatchChangeDiffStat"),
-			getRepoDiffStat:        op("GetRepoDiffStat"),
+			createBatchChange:          op("CreateBatchChange"),
+			upsertBatchChange:          op("UpsertBatchChange"),
+			updateBatchChange:          op("UpdateBatchChange"),
+			updateBatchChangeMergeWave: op("UpdateBatchChangeMergeWave"),
+			deleteBatchChange:          op("DeleteBatchChange"),
+			countBatchChanges:          op("CountBatchChanges"),
+			listBatchChanges:           op("ListBatchChanges"),
+			getBatchChange:             op("GetBatchChange"),
+			getBatchChangeDiffStat:     op("GetBatchChangeDiffStat"),
+			getRepoDiffStat:            op("GetRepoDiffStat"),
 
 			createBatchSpecExecution: op("CreateBatchSpecExecution"),
 			getBatchSpecExecution:    op("GetBatchSpecExecution"),
@@ -416,6 +419,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 			cancelBatchSpecWorkspaceExecutionJobs:              op("CancelBatchSpecWorkspaceExecutionJobs"),
 			retryBatchSpecWorkspaceExecutionJobs:               op("RetryBatchSpecWorkspaceExecutionJobs"),
 			disableBatchSpecWorkspaceExecutionCache:            op("DisableBatchSpecWorkspaceExecutionCache"),
+			updateBatchSpecWorkspaceCommit:                     op("UpdateBatchSpecWorkspaceCommit"),
 
 			createBatchSpecResolutionJob: op("CreateBatchSpecResolutionJob"),
 			getBatchSpecResolutionJob:    op("GetBatchSpecResolutionJob"),
@@ -11,6 +11,7 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -255,6 +256,10 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) MarkComplete(ctx context.Contex
 		return false, errors.Wrap(err, "setChangesetSpecIDs")
 	}
 
+	if err := updateReexecutedChangesets(ctx, tx, batchSpec.ID, workspace.ChangesetSpecIDs, specs); err != nil {
+		return false, errors.Wrap(err, "updating changesets of re-executed workspace")
+	}
+
 	return s.Store.With(tx).MarkComplete(ctx, id, options)
 }
 
@@ -295,6 +300,67 @@ SET
 WHERE id = %s
 `
 
+// updateReexecutedChangesets updates the changesets built from the changeset
+// specs of a previous execution of a workspace to the changeset specs with the
+// same head ref of the new execution, and enqueues them for the reconciler,
+// which pushes the new commit if the diff changed. For the first execution of
+// a workspace, and if the batch spec isn't applied, there are no such
+// changesets.
+func updateReexecutedChangesets(ctx context.Context, tx *Store, batchSpecID int64, previousSpecIDs []int64, specs []*btypes.ChangesetSpec) error {
+	if len(previousSpecIDs) == 0 || len(specs) == 0 {
+		return nil
+	}
+
+	batchChange, err := tx.GetBatchChange(ctx, GetBatchChangeOpts{BatchSpecID: batchSpecID})
+	if err != nil {
+		if err == ErrNoResults {
+			return nil
+		}
+		return err
+	}
+
+	previousSpecs, _, err := tx.ListChangesetSpecs(ctx, ListChangesetSpecsOpts{IDs: previousSpecIDs})
+	if err != nil {
+		return err
+	}
+	previousHeadRefs := make(map[int64]string, len(previousSpecs))
+	for _, spec := range previousSpecs {
+		previousHeadRefs[spec.ID] = spec.HeadRef
+	}
+	specsByHeadRef := make(map[string]*btypes.ChangesetSpec, len(specs))
+	for _, spec := range specs {
+		specsByHeadRef[spec.HeadRef] = spec
+	}
+
+	changesets, _, err := tx.ListChangesets(ctx, ListChangesetsOpts{OwnedByBatchChangeID: batchChange.ID})
+	if err != nil {
+		return err
+	}
+	for _, c := range changesets {
+		headRef, ok := previousHeadRefs[c.CurrentSpecID]
+		if !ok {
+			continue
+		}
+		spec, ok := specsByHeadRef[headRef]
+		if !ok {
+			// The new execution no longer produces a diff for this changeset.
+			// We leave it alone, since closing it is up to the user.
+			continue
+		}
+
+		if c.ReconcilerState == btypes.ReconcilerStateCompleted {
+			c.PreviousSpecID = c.CurrentSpecID
+		}
+		c.SetCurrentSpec(spec)
+		c.ResetReconcilerState(global.DefaultReconcilerEnqueueState())
+		if err := tx.UpdateChangeset(ctx, c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // storeCacheResults builds DB cache entries for all the results and store them using the given tx.
 func storeCacheResults(ctx context.Context, tx *Store, results []*batcheslib.CacheAfterStepResultMetadata, userID int32) error {
 	for _, result := range results {
@@ -0,0 +1,80 @@
+package syncer
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/service"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// reexecuteConflictingChangeset enqueues a new execution of the batch spec
+// workspace that produced the changeset against the current head of its base
+// branch, if the code host reports that the changeset conflicts with or is
+// behind its base branch (see Changeset.Conflicting) and the batch spec of its
+// batch change sets `onConflict: reexecute`.
+//
+// Only batch specs executed server-side have workspaces that can be executed
+// again; changesets of batch specs executed with src-cli are left alone.
+//
+// It runs in its own transaction, after the changeset has been synced, so that
+// failing to enqueue the execution doesn't roll back the sync.
+func reexecuteConflictingChangeset(ctx context.Context, syncStore SyncStore, client gitserver.Client, repo *types.Repo, c *btypes.Changeset) (err error) {
+	if !c.Conflicting() || c.OwnedByBatchChangeID == 0 || c.CurrentSpecID == 0 {
+		return nil
+	}
+	if c.ExternalState != btypes.ChangesetExternalStateOpen && c.ExternalState != btypes.ChangesetExternalStateDraft {
+		return nil
+	}
+
+	tx, err := syncStore.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	batchChange, err := tx.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: c.OwnedByBatchChangeID})
+	if err != nil {
+		return errors.Wrap(err, "loading batch change")
+	}
+	if batchChange.Closed() || batchChange.IsDraft() {
+		return nil
+	}
+
+	spec, err := tx.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: batchChange.BatchSpecID})
+	if err != nil {
+		return errors.Wrap(err, "loading batch spec")
+	}
+	if spec.Spec.OnConflict != batcheslib.OnConflictReexecute {
+		return nil
+	}
+
+	workspace, err := tx.GetBatchSpecWorkspace(ctx, store.GetBatchSpecWorkspaceOpts{
+		BatchSpecID:     spec.ID,
+		ChangesetSpecID: c.CurrentSpecID,
+	})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil
+		}
+		return errors.Wrap(err, "loading batch spec workspace")
+	}
+
+	head, err := client.ResolveRevision(ctx, repo.Name, workspace.Branch, gitserver.ResolveRevisionOptions{})
+	if err != nil {
+		return errors.Wrap(err, "resolving head of base branch")
+	}
+	// Code hosts compute whether a changeset conflicts asynchronously, so it
+	// can still be reported as conflicting after the result of an execution
+	// against the current head has been pushed. Executing it again wouldn't
+	// change anything, so we wait for the base branch to move.
+	if string(head) == workspace.Commit {
+		return nil
+	}
+
+	return service.ReexecuteBatchSpecWorkspace(ctx, tx, client, workspace, head)
+}
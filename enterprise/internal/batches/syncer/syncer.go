@@ -284,6 +284,7 @@ type syncerMetrics struct {
 	computeScheduleDuration *prometheus.HistogramVec
 	scheduleSize            *prometheus.GaugeVec
 	behindSchedule          *prometheus.GaugeVec
+	reexecutionErrors       *prometheus.CounterVec
 }
 
 func makeMetrics(observationCtx *observation.Context) *syncerMetrics {
@@ -314,6 +315,10 @@ func makeMetrics(observationCtx *observation.Context) *syncerMetrics {
 			Name: "src_repoupdater_changeset_syncer_behind_schedule",
 			Help: "The number of changesets behind schedule",
 		}, []string{"codehost"}),
+		reexecutionErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "src_repoupdater_changeset_syncer_reexecution_errors_total",
+			Help: "Total number of errors when executing the workspaces of conflicting changesets again",
+		}, []string{"codehost"}),
 	}
 	observationCtx.Registerer.MustRegister(metrics.syncs)
 	observationCtx.Registerer.MustRegister(metrics.priorityQueued)
@@ -321,6 +326,7 @@ func makeMetrics(observationCtx *observation.Context) *syncerMetrics {
 	observationCtx.Registerer.MustRegister(metrics.computeScheduleDuration)
 	observationCtx.Registerer.MustRegister(metrics.scheduleSize)
 	observationCtx.Registerer.MustRegister(metrics.behindSchedule)
+	observationCtx.Registerer.MustRegister(metrics.reexecutionErrors)
 
 	return metrics
 }
@@ -495,12 +501,23 @@ func (s *changesetSyncer) SyncChangeset(ctx context.Context, id int64) error {
 		return err
 	}
 
-	return SyncChangeset(ctx, s.syncStore, gitserver.NewClient(s.syncStore.DatabaseDB()), source, repo, cs)
+	client := gitserver.NewClient(s.syncStore.DatabaseDB())
+	if err := SyncChangeset(ctx, s.syncStore, client, source, repo, cs); err != nil {
+		return err
+	}
+
+	if err := reexecuteConflictingChangeset(ctx, s.syncStore, client, repo, cs); err != nil {
+		s.metrics.reexecutionErrors.WithLabelValues(s.codeHostURL).Inc()
+		syncLogger.Warn("Re-executing workspace of conflicting changeset", log.Error(err))
+	}
+	return nil
 }
 
 // SyncChangeset refreshes the metadata of the given changeset and
 // updates them in the database.
 func SyncChangeset(ctx context.Context, syncStore SyncStore, client gitserver.Client, source sources.ChangesetSource, repo *types.Repo, c *btypes.Changeset) (err error) {
+	previousState := c.ExternalState
+
 	repoChangeset := &sources.Changeset{TargetRepo: repo, Changeset: c}
 	if err := source.LoadChangeset(ctx, repoChangeset); err != nil {
 		if !errors.HasType(err, sources.ChangesetNotFoundError{}) {
@@ -538,5 +555,9 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, client gitserver.Cl
 		return err
 	}
 
+	if err := state.EnqueueChangesetStateChangedWebhook(ctx, tx.DatabaseDB(), repo, c, previousState); err != nil {
+		return err
+	}
+
 	return tx.UpsertChangesetEvents(ctx, events...)
 }
@@ -39,6 +39,11 @@ type TestSpecOpts struct {
 	BaseRev string
 	BaseRef string
 
+	Reviewers     []string
+	TeamReviewers []string
+	Labels        []string
+	Assignees     []string
+
 	Typ btypes.ChangesetSpecType
 }
 
@@ -72,6 +77,10 @@ func BuildChangesetSpec(t *testing.T, opts TestSpecOpts) *btypes.ChangesetSpec {
 		Diff:              opts.CommitDiff,
 		CommitAuthorEmail: opts.CommitAuthorEmail,
 		CommitAuthorName:  opts.CommitAuthorName,
+		Reviewers:         opts.Reviewers,
+		TeamReviewers:     opts.TeamReviewers,
+		Labels:            opts.Labels,
+		Assignees:         opts.Assignees,
 		DiffStatAdded:     TestChangsetSpecDiffStat.Added,
 		DiffStatDeleted:   TestChangsetSpecDiffStat.Deleted,
 		Type:              opts.Typ,
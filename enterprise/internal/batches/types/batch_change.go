@@ -33,6 +33,14 @@ type BatchChange struct {
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	// MergeWave is the index of the merge wave of the batch spec whose
+	// changesets are merged next. MergeWaveStartedAt is when the previous wave
+	// finished merging, and MergeWaveEnqueuedAt is when the changesets of the
+	// wave were last enqueued to be merged.
+	MergeWave           int32
+	MergeWaveStartedAt  time.Time
+	MergeWaveEnqueuedAt time.Time
 }
 
 // Clone returns a clone of a BatchChange.
@@ -8,7 +8,9 @@ import (
 	"github.com/inconshreveable/log15"
 	"github.com/sourcegraph/go-diff/diff"
 
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
@@ -420,6 +422,25 @@ func (c *Changeset) SetMetadata(meta any) error {
 		} else {
 			c.ExternalForkNamespace = ""
 		}
+	case *adobatches.AnnotatedPullRequest:
+		c.Metadata = pr
+		c.ExternalID = strconv.Itoa(pr.ID)
+		c.ExternalServiceType = extsvc.TypeAzureDevOps
+		c.ExternalBranch = gitdomain.EnsureRefPrefix(pr.SourceRefName)
+		c.ExternalUpdatedAt = pr.UpdatedAt()
+		// Batch changes doesn't create Azure DevOps pull requests from forks.
+		c.ExternalForkNamespace = ""
+	case *gerritbatches.AnnotatedChange:
+		c.Metadata = pr
+		c.ExternalID = strconv.Itoa(pr.Number)
+		c.ExternalServiceType = extsvc.TypeGerrit
+		// Gerrit changes have no branch: the closest thing is the ref of the
+		// current patch set.
+		if rev, ok := pr.CurrentRevisionInfo(); ok {
+			c.ExternalBranch = rev.Ref
+		}
+		c.ExternalUpdatedAt = pr.Updated.Time
+		c.ExternalForkNamespace = ""
 	default:
 		return errors.New("unknown changeset type")
 	}
@@ -447,6 +468,10 @@ func (c *Changeset) Title() (string, error) {
 		return m.Title, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Title, nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.Title, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Subject, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -466,6 +491,10 @@ func (c *Changeset) AuthorName() (string, error) {
 		return m.Author.Username, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Author.Username, nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.CreatedBy.DisplayName, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Owner.Username, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -493,6 +522,15 @@ func (c *Changeset) AuthorEmail() (string, error) {
 		// Bitbucket Cloud does not provide the e-mail of the author under any
 		// circumstances.
 		return "", nil
+	case *adobatches.AnnotatedPullRequest:
+		// The unique name of an Azure DevOps user is their e-mail address,
+		// unless the user is managed by an on-premises Active Directory.
+		if strings.Contains(m.CreatedBy.UniqueName, "@") {
+			return m.CreatedBy.UniqueName, nil
+		}
+		return "", nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Owner.Email, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -511,6 +549,10 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return m.CreatedAt.Time
 	case *bbcs.AnnotatedPullRequest:
 		return m.CreatedOn
+	case *adobatches.AnnotatedPullRequest:
+		return m.CreationDate
+	case *gerritbatches.AnnotatedChange:
+		return m.Created.Time
 	default:
 		return time.Time{}
 	}
@@ -527,6 +569,10 @@ func (c *Changeset) Body() (string, error) {
 		return m.Description, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Rendered.Description.Raw, nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.Description, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Body(), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -572,6 +618,10 @@ func (c *Changeset) URL() (s string, err error) {
 		// pull request ID, but since the link _should_ be there, we'll error
 		// instead.
 		return "", errors.New("Bitbucket Cloud pull request does not have a html link")
+	case *adobatches.AnnotatedPullRequest:
+		return m.WebURL, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.WebURL, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -761,6 +811,13 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return m.DiffRefs.HeadSHA, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Source.Commit.Hash, nil
+	case *adobatches.AnnotatedPullRequest:
+		if m.LastMergeSourceCommit == nil {
+			return "", nil
+		}
+		return m.LastMergeSourceCommit.CommitID, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.CurrentRevision, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -778,6 +835,11 @@ func (c *Changeset) HeadRef() (string, error) {
 		return "refs/heads/" + m.SourceBranch, nil
 	case *bbcs.AnnotatedPullRequest:
 		return "refs/heads/" + m.Source.Branch.Name, nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.SourceRefName, nil
+	case *gerritbatches.AnnotatedChange:
+		rev, _ := m.CurrentRevisionInfo()
+		return rev.Ref, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -796,6 +858,17 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return m.DiffRefs.BaseSHA, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Destination.Commit.Hash, nil
+	case *adobatches.AnnotatedPullRequest:
+		if m.LastMergeTargetCommit == nil {
+			return "", nil
+		}
+		return m.LastMergeTargetCommit.CommitID, nil
+	case *gerritbatches.AnnotatedChange:
+		// The base of a change is the parent of its current patch set.
+		if rev, ok := m.CurrentRevisionInfo(); ok && len(rev.Commit.Parents) > 0 {
+			return rev.Commit.Parents[0].Commit, nil
+		}
+		return "", nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -813,11 +886,31 @@ func (c *Changeset) BaseRef() (string, error) {
 		return "refs/heads/" + m.TargetBranch, nil
 	case *bbcs.AnnotatedPullRequest:
 		return "refs/heads/" + m.Destination.Branch.Name, nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.TargetRefName, nil
+	case *gerritbatches.AnnotatedChange:
+		return gitdomain.EnsureRefPrefix(m.Branch), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
 }
 
+// Conflicting returns true if the code host reports that the changeset cannot
+// be merged until it's updated, because it conflicts with its base branch or
+// because the code host requires it to be up to date with the base branch
+// first.
+// Code hosts which don't report this are never considered to conflict.
+func (c *Changeset) Conflicting() bool {
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		return m.Mergeable == "CONFLICTING" || m.MergeStateStatus == "BEHIND"
+	case *gitlab.MergeRequest:
+		return m.HasConflicts || m.DetailedMergeStatus == "need_rebase"
+	default:
+		return false
+	}
+}
+
 // AttachedTo returns true if the changeset is currently attached to the batch
 // change with the given batchChangeID.
 func (c *Changeset) AttachedTo(batchChangeID int64) bool {
@@ -72,6 +72,10 @@ func NewChangesetSpecFromSpec(spec *batcheslib.ChangesetSpec) (*ChangesetSpec, e
 		c.CommitMessage = commitMsg
 		c.CommitAuthorName = authorName
 		c.CommitAuthorEmail = authorEmail
+		c.Reviewers = spec.Reviewers
+		c.TeamReviewers = spec.TeamReviewers
+		c.Labels = spec.Labels
+		c.Assignees = spec.Assignees
 	}
 
 	c.computeForkNamespace()
@@ -112,6 +116,10 @@ type ChangesetSpec struct {
 	CommitMessage     string
 	CommitAuthorName  string
 	CommitAuthorEmail string
+	Reviewers         []string
+	TeamReviewers     []string
+	Labels            []string
+	Assignees         []string
 
 	ForkNamespace *string
 }
@@ -7,10 +7,14 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/sourcegraph/go-diff/diff"
 
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
@@ -85,6 +89,24 @@ func TestChangeset_SetMetadata(t *testing.T) {
 		meta any
 		want *Changeset
 	}{
+		"azuredevops": {
+			meta: &adobatches.AnnotatedPullRequest{
+				PullRequest: &azuredevops.PullRequest{
+					ID:            12345,
+					SourceRefName: "refs/heads/branch",
+					CreationDate:  time.Unix(5, 0),
+				},
+				Statuses: []*azuredevops.PullRequestStatus{
+					{UpdatedDate: time.Unix(10, 0)},
+				},
+			},
+			want: &Changeset{
+				ExternalID:          "12345",
+				ExternalServiceType: extsvc.TypeAzureDevOps,
+				ExternalBranch:      "refs/heads/branch",
+				ExternalUpdatedAt:   time.Unix(10, 0),
+			},
+		},
 		"bitbucketcloud with fork": {
 			meta: &bbcs.AnnotatedPullRequest{
 				PullRequest: &bitbucketcloud.PullRequest{
@@ -194,12 +216,18 @@ func TestChangeset_SetMetadata(t *testing.T) {
 func TestChangeset_Title(t *testing.T) {
 	want := "foo"
 	for name, meta := range map[string]any{
+		"azuredevops": &adobatches.AnnotatedPullRequest{
+			PullRequest: &azuredevops.PullRequest{Title: want},
+		},
 		"bitbucketcloud": &bbcs.AnnotatedPullRequest{
 			PullRequest: &bitbucketcloud.PullRequest{Title: want},
 		},
 		"bitbucketserver": &bitbucketserver.PullRequest{
 			Title: want,
 		},
+		"gerrit": &gerritbatches.AnnotatedChange{
+			Change: &gerrit.Change{Subject: want},
+		},
 		"GitHub": &github.PullRequest{
 			Title: want,
 		},
@@ -230,12 +258,18 @@ func TestChangeset_Title(t *testing.T) {
 func TestChangeset_ExternalCreatedAt(t *testing.T) {
 	want := time.Unix(10, 0)
 	for name, meta := range map[string]any{
+		"azuredevops": &adobatches.AnnotatedPullRequest{
+			PullRequest: &azuredevops.PullRequest{CreationDate: want},
+		},
 		"bitbucketcloud": &bbcs.AnnotatedPullRequest{
 			PullRequest: &bitbucketcloud.PullRequest{CreatedOn: want},
 		},
 		"bitbucketserver": &bitbucketserver.PullRequest{
 			CreatedDate: 10 * 1000,
 		},
+		"gerrit": &gerritbatches.AnnotatedChange{
+			Change: &gerrit.Change{Created: gerrit.Timestamp{Time: want}},
+		},
 		"GitHub": &github.PullRequest{
 			CreatedAt: want,
 		},
@@ -273,6 +307,16 @@ func TestChangeset_Body(t *testing.T) {
 		"bitbucketserver": &bitbucketserver.PullRequest{
 			Description: want,
 		},
+		"gerrit": &gerritbatches.AnnotatedChange{
+			Change: &gerrit.Change{
+				CurrentRevision: "deadbeef",
+				Revisions: map[string]gerrit.RevisionInfo{
+					"deadbeef": {Commit: gerrit.CommitInfo{
+						Message: gerritbatches.CommitMessage("title", want, "I0123"),
+					}},
+				},
+			},
+		},
 		"GitHub": &github.PullRequest{
 			Body: want,
 		},
@@ -552,6 +596,49 @@ func TestChangeset_BaseRef(t *testing.T) {
 	})
 }
 
+func TestChangeset_Conflicting(t *testing.T) {
+	for name, tc := range map[string]struct {
+		meta any
+		want bool
+	}{
+		"GitHub mergeable": {
+			meta: &github.PullRequest{Mergeable: "MERGEABLE"},
+			want: false,
+		},
+		"GitHub conflicting": {
+			meta: &github.PullRequest{Mergeable: "CONFLICTING"},
+			want: true,
+		},
+		"GitHub behind": {
+			meta: &github.PullRequest{Mergeable: "MERGEABLE", MergeStateStatus: "BEHIND"},
+			want: true,
+		},
+		"GitLab mergeable": {
+			meta: &gitlab.MergeRequest{DetailedMergeStatus: "mergeable"},
+			want: false,
+		},
+		"GitLab conflicting": {
+			meta: &gitlab.MergeRequest{HasConflicts: true},
+			want: true,
+		},
+		"GitLab needs rebase": {
+			meta: &gitlab.MergeRequest{DetailedMergeStatus: "need_rebase"},
+			want: true,
+		},
+		"bitbucketserver": {
+			meta: &bitbucketserver.PullRequest{},
+			want: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := &Changeset{Metadata: tc.meta}
+			if have := c.Conflicting(); have != tc.want {
+				t.Errorf("unexpected conflicting: have %t; want %t", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestChangeset_Labels(t *testing.T) {
 	for name, tc := range map[string]struct {
 		meta any
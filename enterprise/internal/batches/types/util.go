@@ -12,6 +12,10 @@ type CodehostCapability string
 const (
 	CodehostCapabilityLabels          CodehostCapability = "Labels"
 	CodehostCapabilityDraftChangesets CodehostCapability = "DraftChangesets"
+	// CodehostCapabilityCommitDescription is supported by code hosts on which
+	// the title and body of a changeset are its commit message, so that
+	// changing them requires pushing a new commit.
+	CodehostCapabilityCommitDescription CodehostCapability = "CommitDescription"
 )
 
 type CodehostCapabilities map[CodehostCapability]bool
@@ -25,6 +29,8 @@ var SupportedExternalServices = map[string]CodehostCapabilities{
 	extsvc.TypeBitbucketServer: {},
 	extsvc.TypeGitLab:          {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true},
 	extsvc.TypeBitbucketCloud:  {},
+	extsvc.TypeAzureDevOps:     {CodehostCapabilityDraftChangesets: true},
+	extsvc.TypeGerrit:          {CodehostCapabilityDraftChangesets: true, CodehostCapabilityCommitDescription: true},
 }
 
 // IsRepoSupported returns whether the given ExternalRepoSpec is supported by
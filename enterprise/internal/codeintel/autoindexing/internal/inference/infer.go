@@ -20,6 +20,7 @@ func InferRepositoryAndRevision(pkg precise.Package) (repoName api.RepoName, git
 		inferRustRepositoryAndRevision,
 		inferPythonRepositoryAndRevision,
 		inferRubyRepositoryAndRevision,
+		inferNuGetRepositoryAndRevision,
 	} {
 		if repoName, gitTagOrCommit, ok := fn(pkg); ok {
 			return repoName, gitTagOrCommit, true
@@ -116,3 +117,18 @@ func inferRubyRepositoryAndRevision(pkg precise.Package) (api.RepoName, string,
 
 	return rubyPkg.RepoName(), pkg.Version, true
 }
+
+func inferNuGetRepositoryAndRevision(pkg precise.Package) (api.RepoName, string, bool) {
+	if pkg.Scheme != dependencies.NuGetPackagesScheme {
+		return "", "", false
+	}
+
+	logger := log.Scoped("inferNuGetRepositoryAndRevision", "")
+	nugetPkg, err := reposource.ParseNuGetPackageFromName(reposource.PackageName(pkg.Name))
+	if err != nil {
+		logger.Error("invalid NuGet package name in database", log.Error(err), log.String("pkg", pkg.Name))
+		return "", "", false
+	}
+
+	return nugetPkg.RepoName(), "v" + pkg.Version, true
+}
@@ -26,16 +26,18 @@ type Config struct {
 	GCSProjectID               string
 	GCSCredentialsFile         string
 	GCSCredentialsFileContents string
+
+	FilesystemDir string
 }
 
 func (c *Config) Load() {
-	c.Backend = strings.ToLower(c.Get("PRECISE_CODE_INTEL_UPLOAD_BACKEND", "blobstore", "The target file service for code intelligence uploads. S3, GCS, and Blobstore are supported."))
+	c.Backend = strings.ToLower(c.Get("PRECISE_CODE_INTEL_UPLOAD_BACKEND", "blobstore", "The target file service for code intelligence uploads. S3, GCS, Blobstore, and Filesystem are supported."))
 	c.ManageBucket = c.GetBool("PRECISE_CODE_INTEL_UPLOAD_MANAGE_BUCKET", "false", "Whether or not the client should manage the target bucket configuration.")
 	c.Bucket = c.Get("PRECISE_CODE_INTEL_UPLOAD_BUCKET", "lsif-uploads", "The name of the bucket to store LSIF uploads in.")
 	c.TTL = c.GetInterval("PRECISE_CODE_INTEL_UPLOAD_TTL", "168h", "The maximum age of an upload before deletion.")
 
-	if c.Backend != "blobstore" && c.Backend != "s3" && c.Backend != "gcs" {
-		c.AddError(errors.Errorf("invalid backend %q for PRECISE_CODE_INTEL_UPLOAD_BACKEND: must be S3, GCS, or Blobstore", c.Backend))
+	if c.Backend != "blobstore" && c.Backend != "s3" && c.Backend != "gcs" && c.Backend != "filesystem" {
+		c.AddError(errors.Errorf("invalid backend %q for PRECISE_CODE_INTEL_UPLOAD_BACKEND: must be S3, GCS, Blobstore, or Filesystem", c.Backend))
 	}
 
 	if c.Backend == "blobstore" || c.Backend == "s3" {
@@ -53,5 +55,7 @@ func (c *Config) Load() {
 		c.GCSProjectID = c.Get("PRECISE_CODE_INTEL_UPLOAD_GCP_PROJECT_ID", "", "The project containing the GCS bucket.")
 		c.GCSCredentialsFile = c.GetOptional("PRECISE_CODE_INTEL_UPLOAD_GOOGLE_APPLICATION_CREDENTIALS_FILE", "The path to a service account key file with access to GCS.")
 		c.GCSCredentialsFileContents = c.GetOptional("PRECISE_CODE_INTEL_UPLOAD_GOOGLE_APPLICATION_CREDENTIALS_FILE_CONTENT", "The contents of a service account key file with access to GCS.")
+	} else if c.Backend == "filesystem" {
+		c.FilesystemDir = c.Get("PRECISE_CODE_INTEL_UPLOAD_FILESYSTEM_DIR", "", "The local directory in which to store uploads. It must be shared by all services reading or writing uploads.")
 	}
 }
@@ -106,6 +106,25 @@ func TestConfigGCS(t *testing.T) {
 	}
 }
 
+func TestConfigFilesystem(t *testing.T) {
+	env := map[string]string{
+		"PRECISE_CODE_INTEL_UPLOAD_BACKEND":        "Filesystem",
+		"PRECISE_CODE_INTEL_UPLOAD_FILESYSTEM_DIR": "/data/uploads",
+	}
+
+	config := Config{}
+	config.SetMockGetter(mapGetter(env))
+	config.Load()
+
+	if err := config.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %s", err)
+	}
+
+	if config.FilesystemDir != "/data/uploads" {
+		t.Errorf("unexpected value for Filesystem.Dir. want=%s have=%s", "/data/uploads", config.FilesystemDir)
+	}
+}
+
 func mapGetter(env map[string]string) func(name, defaultValue, description string) string {
 	return func(name, defaultValue, description string) string {
 		if v, ok := env[name]; ok {
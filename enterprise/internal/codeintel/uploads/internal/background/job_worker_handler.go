@@ -23,6 +23,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
@@ -39,6 +40,7 @@ func NewUploadProcessorWorker(
 	repoStore RepoStore,
 	workerStore dbworkerstore.Store[codeinteltypes.Upload],
 	uploadStore uploadstore.Store,
+	outboundWebhooks outbound.OutboundWebhookService,
 	workerConcurrency int,
 	workerBudget int64,
 	workerPollInterval time.Duration,
@@ -54,6 +56,7 @@ func NewUploadProcessorWorker(
 		repoStore,
 		workerStore,
 		uploadStore,
+		outboundWebhooks,
 		workerConcurrency,
 		workerBudget,
 	)
@@ -77,6 +80,7 @@ type handler struct {
 	repoStore       RepoStore
 	workerStore     dbworkerstore.Store[codeinteltypes.Upload]
 	uploadStore     uploadstore.Store
+	webhooks        outbound.OutboundWebhookService
 	handleOp        *observation.Operation
 	budgetRemaining int64
 	enableBudget    bool
@@ -97,6 +101,7 @@ func NewUploadProcessorHandler(
 	repoStore RepoStore,
 	workerStore dbworkerstore.Store[codeinteltypes.Upload],
 	uploadStore uploadstore.Store,
+	outboundWebhooks outbound.OutboundWebhookService,
 	numProcessorRoutines int,
 	budgetMax int64,
 ) workerutil.Handler[codeinteltypes.Upload] {
@@ -109,6 +114,7 @@ func NewUploadProcessorHandler(
 		repoStore:       repoStore,
 		workerStore:     workerStore,
 		uploadStore:     uploadStore,
+		webhooks:        outboundWebhooks,
 		handleOp:        operations.uploadProcessor,
 		budgetRemaining: budgetMax,
 		enableBudget:    budgetMax > 0,
@@ -130,10 +136,42 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, upload codeinte
 	}()
 
 	requeued, err = h.HandleRawUpload(ctx, logger, upload, h.uploadStore, otLogger)
+	if !requeued {
+		h.enqueueWebhook(ctx, logger, upload, err)
+	}
 
 	return err
 }
 
+// enqueueWebhook notifies outbound webhooks that the given upload has finished
+// processing. Uploads are not retried, so a non-nil err means the upload has
+// failed.
+func (h *handler) enqueueWebhook(ctx context.Context, logger log.Logger, upload codeinteltypes.Upload, err error) {
+	if h.webhooks == nil {
+		return
+	}
+
+	eventType := outbound.EventTypePreciseIndexCompleted
+	data := outbound.PreciseIndexData{
+		UploadID: upload.ID,
+		Repository: outbound.Repository{
+			ID:   int32(upload.RepositoryID),
+			Name: upload.RepositoryName,
+		},
+		Commit:  upload.Commit,
+		Root:    upload.Root,
+		Indexer: upload.Indexer,
+	}
+	if err != nil {
+		eventType = outbound.EventTypePreciseIndexFailed
+		data.FailureMessage = err.Error()
+	}
+
+	if err := h.webhooks.Enqueue(ctx, eventType, &upload.RepositoryName, data); err != nil {
+		logger.Warn("failed to enqueue outbound webhook", log.Int("uploadID", upload.ID), log.Error(err))
+	}
+}
+
 func (h *handler) PreDequeue(ctx context.Context, logger log.Logger) (bool, any, error) {
 	if !h.enableBudget {
 		return true, nil, nil
@@ -1,8 +1,10 @@
 package background
 
 import (
+	"fmt"
 	"net/url"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 )
 
@@ -18,4 +20,42 @@ type actionArgs struct {
 	Query          string
 	Results        []*result.CommitMatch
 	IncludeResults bool
+
+	// ContentResults is set instead of Results for monitors whose query does
+	// not search commits.
+	ContentResults []*edb.ContentResult
+}
+
+func truncateContentResults(results []*edb.ContentResult, maxResults int) (_ []*edb.ContentResult, totalCount, truncatedCount int) {
+	if len(results) <= maxResults {
+		return results, len(results), 0
+	}
+	return results[:maxResults], len(results), len(results) - maxResults
+}
+
+func contentResultType(r *edb.ContentResult) string {
+	if r.Removed {
+		return "Removed"
+	}
+	return "Added"
+}
+
+// getContentResultURL returns the URL of the file, or of the repository for
+// repository matches, that a content result belongs to. Removed results link
+// to the default branch, since the match no longer exists there.
+func getContentResultURL(externalURL *url.URL, r *edb.ContentResult, utmSource string) string {
+	path := string(r.RepoName)
+	if r.Path == "" {
+		return sourcegraphURL(externalURL, path, "", utmSource)
+	}
+	if r.Commit != "" && !r.Removed {
+		path += "@" + string(r.Commit)
+	}
+	path += "/-/blob/" + r.Path
+
+	u := sourcegraphURL(externalURL, path, "", utmSource)
+	if r.LineNumber > 0 && !r.Removed {
+		u += fmt.Sprintf("#L%d", r.LineNumber)
+	}
+	return u
 }
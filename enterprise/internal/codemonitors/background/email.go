@@ -78,11 +78,22 @@ func NewTemplateDataForNewSearchResults(args actionArgs, email *edb.EmailAction)
 		priority = ""
 	}
 
-	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
-
-	displayResults := make([]*DisplayResult, len(truncatedResults))
-	for i, result := range truncatedResults {
-		displayResults[i] = toDisplayResult(result, args.ExternalURL)
+	var (
+		displayResults             []*DisplayResult
+		totalCount, truncatedCount int
+	)
+	if len(args.ContentResults) > 0 {
+		var truncatedResults []*edb.ContentResult
+		truncatedResults, totalCount, truncatedCount = truncateContentResults(args.ContentResults, 5)
+		for _, result := range truncatedResults {
+			displayResults = append(displayResults, contentToDisplayResult(result, args.ExternalURL))
+		}
+	} else {
+		var truncatedResults []*result.CommitMatch
+		truncatedResults, totalCount, truncatedCount = truncateResults(args.Results, 5)
+		for _, result := range truncatedResults {
+			displayResults = append(displayResults, toDisplayResult(result, args.ExternalURL))
+		}
 	}
 
 	return &TemplateDataNewSearchResults{
@@ -198,6 +209,7 @@ type DisplayResult struct {
 	CommitURL  string
 	RepoName   string
 	CommitID   string
+	Path       string
 	Content    string
 }
 
@@ -222,3 +234,19 @@ func toDisplayResult(result *result.CommitMatch, externalURL *url.URL) *DisplayR
 		Content:    content,
 	}
 }
+
+func contentToDisplayResult(result *edb.ContentResult, externalURL *url.URL) *DisplayResult {
+	var commitID string
+	if !result.Removed {
+		commitID = result.Commit.Short()
+	}
+
+	return &DisplayResult{
+		ResultType: contentResultType(result),
+		CommitURL:  getContentResultURL(externalURL, result, utmSourceEmail),
+		RepoName:   string(result.RepoName),
+		CommitID:   commitID,
+		Path:       result.Path,
+		Content:    result.Preview,
+	}
+}
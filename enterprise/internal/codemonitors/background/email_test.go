@@ -8,6 +8,7 @@ import (
 	"github.com/hexops/autogold"
 	"github.com/stretchr/testify/require"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/txemail"
 )
 
@@ -185,4 +186,35 @@ func TestEmail(t *testing.T) {
 		})
 	})
 
+	t.Run("content results", func(t *testing.T) {
+		templateData, err := NewTemplateDataForNewSearchResults(actionArgs{
+			MonitorDescription: "My test monitor",
+			ExternalURL:        externalURLMock,
+			Query:              "TODO(security)",
+			ContentResults:     []*edb.ContentResult{&addedContentResultMock, &removedContentResultMock},
+			IncludeResults:     true,
+		}, &edb.EmailAction{Monitor: 42})
+		require.NoError(t, err)
+
+		t.Run("html", func(t *testing.T) {
+			var buf bytes.Buffer
+			err := template.Html.Execute(&buf, templateData)
+			require.NoError(t, err)
+			autogold.Equal(t, autogold.Raw(buf.String()))
+		})
+
+		t.Run("text", func(t *testing.T) {
+			var buf bytes.Buffer
+			err := template.Text.Execute(&buf, templateData)
+			require.NoError(t, err)
+			autogold.Equal(t, autogold.Raw(buf.String()))
+		})
+
+		t.Run("subject", func(t *testing.T) {
+			var buf bytes.Buffer
+			err := template.Subj.Execute(&buf, templateData)
+			require.NoError(t, err)
+			require.Equal(t, "Sourcegraph code monitor My test monitor detected 2 new results", buf.String())
+		})
+	})
 }
@@ -26,6 +26,9 @@ func slackPayload(args actionArgs) *slack.WebhookMessage {
 	}
 
 	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+	truncatedContentResults, contentTotalCount, contentTruncatedCount := truncateContentResults(args.ContentResults, 5)
+	totalCount += contentTotalCount
+	truncatedCount += contentTruncatedCount
 
 	blocks := []slack.Block{
 		newMarkdownSection(fmt.Sprintf(
@@ -57,6 +60,21 @@ func slackPayload(args actionArgs) *slack.WebhookMessage {
 			}
 			blocks = append(blocks, newMarkdownSection(formatCodeBlock(contentRaw)))
 		}
+		for _, result := range truncatedContentResults {
+			location := string(result.RepoName)
+			if result.Path != "" {
+				location += ": " + result.Path
+			}
+			blocks = append(blocks, newMarkdownSection(fmt.Sprintf(
+				"%s match: <%s|%s>",
+				contentResultType(result),
+				getContentResultURL(args.ExternalURL, result, args.UTMSource),
+				location,
+			)))
+			if result.Preview != "" {
+				blocks = append(blocks, newMarkdownSection(formatCodeBlock(result.Preview)))
+			}
+		}
 		if truncatedCount > 0 {
 			blocks = append(blocks, newMarkdownSection(fmt.Sprintf(
 				"...and <%s|%d more matches>.",
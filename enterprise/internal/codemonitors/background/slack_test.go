@@ -12,6 +12,7 @@ import (
 	"github.com/hexops/autogold"
 	"github.com/stretchr/testify/require"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 )
 
@@ -80,6 +81,14 @@ func TestSlackWebhook(t *testing.T) {
 		autogold.Equal(t, jsonSlackPayload(actionCopy))
 	})
 
+	t.Run("golden with content results", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.Results = nil
+		actionCopy.ContentResults = []*edb.ContentResult{&addedContentResultMock, &removedContentResultMock}
+		actionCopy.IncludeResults = true
+		autogold.Equal(t, jsonSlackPayload(actionCopy))
+	})
+
 	t.Run("golden without results", func(t *testing.T) {
 		autogold.Equal(t, jsonSlackPayload(action))
 	})
@@ -3,6 +3,7 @@ package background
 import (
 	"net/url"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
@@ -50,3 +51,22 @@ var commitResultMock = result.CommitMatch{
 }
 
 var commitDisplayResultMock = toDisplayResult(&commitResultMock, externalURLMock)
+
+var addedContentResultMock = edb.ContentResult{
+	Fingerprint: "b5bb9d8014a0f9b1d61e21e796d78dccdf1352f23cd32812f4850b878ae4944c",
+	RepoID:      1,
+	RepoName:    api.RepoName("github.com/test/test"),
+	Commit:      api.CommitID("7815187511872asbasdfgasd"),
+	Path:        "internal/auth/session.go",
+	Preview:     "// TODO(security): validate the session token",
+	LineNumber:  42,
+}
+
+var removedContentResultMock = edb.ContentResult{
+	Fingerprint: "7d865e959b2466918c9863afca942d0fb89d7c9ac0c99bafc3749504ded97730",
+	RepoID:      1,
+	RepoName:    api.RepoName("github.com/test/test"),
+	Path:        "internal/auth/cookie.go",
+	Preview:     "// TODO(security): set the secure flag",
+	Removed:     true,
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -72,6 +73,7 @@ func generateWebhookPayload(args actionArgs) webhookPayload {
 
 	if args.IncludeResults {
 		p.Results = generateResults(args.Results)
+		p.Results = append(p.Results, generateContentResults(args.ContentResults)...)
 	}
 
 	return p
@@ -84,6 +86,13 @@ type webhookResult struct {
 	MatchedMessageRanges [][2]int `json:"matchedMessageRanges,omitempty"`
 	Diff                 string   `json:"diff,omitempty"`
 	MatchedDiffRanges    [][2]int `json:"matchedDiffRanges,omitempty"`
+
+	// The fields below are only set for monitors whose query does not search
+	// commits.
+	Path       string `json:"path,omitempty"`
+	LineNumber int    `json:"lineNumber,omitempty"`
+	Content    string `json:"content,omitempty"`
+	Removed    bool   `json:"removed,omitempty"`
 }
 
 func generateResults(in []*result.CommitMatch) []webhookResult {
@@ -106,6 +115,21 @@ func generateResults(in []*result.CommitMatch) []webhookResult {
 	return out
 }
 
+func generateContentResults(in []*edb.ContentResult) []webhookResult {
+	out := make([]webhookResult, len(in))
+	for i, match := range in {
+		out[i] = webhookResult{
+			Repository: string(match.RepoName),
+			Commit:     string(match.Commit),
+			Path:       match.Path,
+			LineNumber: match.LineNumber,
+			Content:    match.Preview,
+			Removed:    match.Removed,
+		}
+	}
+	return out
+}
+
 func rangesToInts(ranges result.Ranges) [][2]int {
 	out := make([][2]int, len(ranges))
 	for i, r := range ranges {
@@ -12,6 +12,7 @@ import (
 	"github.com/hexops/autogold"
 	"github.com/stretchr/testify/require"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 )
 
@@ -54,6 +55,18 @@ func TestWebhook(t *testing.T) {
 		autogold.Equal(t, autogold.Raw(j))
 	})
 
+	t.Run("golden with content results", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.Results = nil
+		actionCopy.ContentResults = []*edb.ContentResult{&addedContentResultMock, &removedContentResultMock}
+		actionCopy.IncludeResults = true
+
+		j, err := json.Marshal(generateWebhookPayload(actionCopy))
+		require.NoError(t, err)
+
+		autogold.Equal(t, autogold.Raw(j))
+	})
+
 	t.Run("error is returned", func(t *testing.T) {
 		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			b, err := io.ReadAll(r.Body)
@@ -23,6 +23,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 const (
@@ -179,6 +180,14 @@ func (r *queryRunner) Handle(ctx context.Context, logger log.Logger, triggerJob
 		return errors.Wrap(err, "query settings")
 	}
 
+	searchesCommits, err := codemonitors.SearchesCommits(q.QueryString)
+	if err != nil {
+		return errors.Wrap(err, "parse query")
+	}
+	if !searchesCommits {
+		return r.handleContent(ctx, logger, s, triggerJob, q, m, settings)
+	}
+
 	query := q.QueryString
 	if !featureflag.FromContext(ctx).GetBoolOr("cc-repo-aware-monitors", true) {
 		// Only add an after filter when repo-aware monitors is disabled
@@ -213,6 +222,63 @@ func (r *queryRunner) Handle(ctx context.Context, logger log.Logger, triggerJob
 	return nil
 }
 
+// handleContent runs a trigger job for a monitor whose query does not search
+// commits. The result set is compared to the one stored by the previous run,
+// and actions are triggered if any matches were added or removed.
+func (r *queryRunner) handleContent(ctx context.Context, logger log.Logger, s edb.CodeMonitorStore, triggerJob *edb.TriggerJob, q *edb.QueryTrigger, m *edb.Monitor, settings *schema.Settings) error {
+	results, searchErr := codemonitors.SearchContent(ctx, logger, r.db, q.QueryString, settings)
+
+	latestResult := s.Clock()()
+	if q.LatestResult != nil {
+		latestResult = *q.LatestResult
+	}
+
+	var diff []*edb.ContentResult
+	if searchErr == nil {
+		previous, stored, err := s.GetResultFingerprints(ctx, m.ID)
+		if err != nil {
+			return errors.Wrap(err, "GetResultFingerprints")
+		}
+		// If no result set was ever stored, for example because the monitor
+		// was created without a snapshot, every current match would be
+		// reported as new. The first run only stores the result set instead.
+		if stored {
+			diff = codemonitors.DiffContentResults(previous, results)
+		}
+		if len(diff) > 0 {
+			latestResult = s.Clock()()
+		}
+	}
+
+	// Log next_run and latest_result to table cm_queries.
+	err := s.SetQueryTriggerNextRun(ctx, q.ID, s.Clock()().Add(5*time.Minute), latestResult.UTC())
+	if err != nil {
+		return err
+	}
+
+	// After setting the next run, check the error value
+	if searchErr != nil {
+		return errors.Wrap(searchErr, "execute search")
+	}
+
+	if err := s.ReplaceResultFingerprints(ctx, m.ID, results); err != nil {
+		return errors.Wrap(err, "ReplaceResultFingerprints")
+	}
+
+	err = s.UpdateTriggerJobWithContentResults(ctx, triggerJob.ID, q.QueryString, diff)
+	if err != nil {
+		return errors.Wrap(err, "UpdateTriggerJobWithContentResults")
+	}
+
+	if len(diff) > 0 {
+		_, err := s.EnqueueActionJobsForMonitor(ctx, m.ID, triggerJob.ID)
+		if err != nil {
+			return errors.Wrap(err, "store.EnqueueActionJobsForQuery")
+		}
+	}
+	return nil
+}
+
 type actionRunner struct {
 	edb.CodeMonitorStore
 }
@@ -272,6 +338,7 @@ func (r *actionRunner) handleEmail(ctx context.Context, j *edb.ActionJob) error
 		Query:              m.Query,
 		MonitorOwnerName:   m.OwnerName,
 		Results:            m.Results,
+		ContentResults:     m.ContentResults,
 		IncludeResults:     e.IncludeResults,
 	}
 
@@ -325,6 +392,7 @@ func (r *actionRunner) handleWebhook(ctx context.Context, j *edb.ActionJob) erro
 		Query:              m.Query,
 		MonitorOwnerName:   m.OwnerName,
 		Results:            m.Results,
+		ContentResults:     m.ContentResults,
 		IncludeResults:     w.IncludeResults,
 	}
 
@@ -361,6 +429,7 @@ func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *edb.ActionJob)
 		Query:              m.Query,
 		MonitorOwnerName:   m.OwnerName,
 		Results:            m.Results,
+		ContentResults:     m.ContentResults,
 		IncludeResults:     w.IncludeResults,
 	}
 
@@ -0,0 +1,206 @@
+package codemonitors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// ErrIncompleteContentResults is returned when the result set of a monitor
+// that does not search commits is truncated. Diffing a truncated result set
+// would report spurious added and removed matches.
+var ErrIncompleteContentResults = errors.New("code monitor query matched too many results to be diffed; narrow the query or increase its count: filter")
+
+// contentMonitorMaxResults is the number of results that a monitor that does
+// not search commits searches for, unless its query sets count:. It is much
+// higher than the default of streaming search, since the complete result set
+// is required to be diffed.
+const contentMonitorMaxResults = 10000
+
+// SearchesCommits returns whether the given code monitor query searches commits
+// or diffs. Monitors whose query searches anything else (file contents,
+// symbols, paths or repositories) are triggered by changes to their result
+// set instead.
+func SearchesCommits(q string) (bool, error) {
+	plan, err := query.Pipeline(query.Init(q, query.SearchTypeStandard))
+	if err != nil {
+		return false, err
+	}
+
+	for _, basic := range plan {
+		types, _ := basic.Parameters.IncludeExcludeValues(query.FieldType)
+		for _, t := range types {
+			if t == "commit" || t == "diff" {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// SearchContent runs a query that does not search commits and returns its
+// complete result set on the default branch of each searched repository.
+func SearchContent(ctx context.Context, logger log.Logger, db database.DB, query string, settings *schema.Settings) (_ []*edb.ContentResult, err error) {
+	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs())
+	inputs, err := searchClient.Plan(
+		ctx,
+		"V3",
+		nil,
+		query,
+		search.Precise,
+		search.Streaming,
+		settings,
+		envvar.SourcegraphDotComMode(),
+	)
+	if err != nil {
+		return nil, errcode.MakeNonRetryable(err)
+	}
+
+	inputs.Plan = withDefaultCount(inputs.Plan, contentMonitorMaxResults)
+	inputs.Query = inputs.Plan.ToQ()
+	planJob, err := jobutil.NewPlanJob(inputs, inputs.Plan)
+	if err != nil {
+		return nil, errcode.MakeNonRetryable(err)
+	}
+
+	agg := streaming.NewAggregatingStream()
+	_, err = planJob.Run(ctx, searchClient.JobClients(), agg)
+	if err != nil {
+		return nil, err
+	}
+	if agg.Stats.IsLimitHit {
+		return nil, errcode.MakeNonRetryable(ErrIncompleteContentResults)
+	}
+
+	return toContentResults(agg.Results), nil
+}
+
+// withDefaultCount adds count:<count> to each query of plan that does not set
+// count: itself.
+func withDefaultCount(plan query.Plan, count int) query.Plan {
+	return query.MapPlan(plan, func(b query.Basic) query.Basic {
+		if b.Count() != nil {
+			return b
+		}
+		parameters := make([]query.Parameter, 0, len(b.Parameters)+1)
+		parameters = append(parameters, b.Parameters...)
+		parameters = append(parameters, query.Parameter{Field: query.FieldCount, Value: strconv.Itoa(count)})
+		return b.MapParameters(parameters)
+	})
+}
+
+// snapshotContent stores the current result set of a monitor that does not
+// search commits, so that its next run only reports changes.
+func snapshotContent(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64, settings *schema.Settings) error {
+	results, err := SearchContent(ctx, logger, db, query, settings)
+	if err != nil {
+		return err
+	}
+	return edb.NewEnterpriseDB(db).CodeMonitors().ReplaceResultFingerprints(ctx, monitorID, results)
+}
+
+// toContentResults flattens search matches into one result per matched line,
+// symbol, path or repository.
+func toContentResults(matches result.Matches) []*edb.ContentResult {
+	var (
+		results     []*edb.ContentResult
+		occurrences = make(map[string]int)
+	)
+
+	add := func(repo api.RepoID, repoName api.RepoName, commit api.CommitID, path, kind, preview string, lineNumber int) {
+		preview = strings.TrimSpace(preview)
+
+		// Identical lines in the same file are told apart by their order of
+		// occurrence rather than their line number, so that a match is not
+		// reported as new when lines above it are added or removed.
+		key := fmt.Sprintf("%d\x00%s\x00%s\x00%s", repo, path, kind, preview)
+		occurrence := occurrences[key]
+		occurrences[key]++
+
+		results = append(results, &edb.ContentResult{
+			Fingerprint: fingerprint(key, occurrence),
+			RepoID:      repo,
+			RepoName:    repoName,
+			Commit:      commit,
+			Path:        path,
+			Preview:     preview,
+			LineNumber:  lineNumber,
+		})
+	}
+
+	for _, match := range matches {
+		switch m := match.(type) {
+		case *result.FileMatch:
+			repo, commit, path := m.Repo, m.CommitID, m.Path
+			for _, sym := range m.Symbols {
+				add(repo.ID, repo.Name, commit, path, "symbol", sym.Symbol.Kind+" "+sym.Symbol.Name, sym.Symbol.Line)
+			}
+			for _, lm := range m.ChunkMatches.AsLineMatches() {
+				if len(lm.OffsetAndLengths) == 0 {
+					// Context lines of a chunk are not matches themselves.
+					continue
+				}
+				add(repo.ID, repo.Name, commit, path, "content", lm.Preview, int(lm.LineNumber)+1)
+			}
+			if len(m.Symbols) == 0 && len(m.ChunkMatches) == 0 {
+				add(repo.ID, repo.Name, commit, path, "path", "", 0)
+			}
+		case *result.RepoMatch:
+			add(m.ID, m.Name, "", "", "repo", "", 0)
+		}
+	}
+	return results
+}
+
+func fingerprint(key string, occurrence int) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d", key, occurrence)))
+	return hex.EncodeToString(sum[:])
+}
+
+// DiffContentResults returns the results in current that are not in previous,
+// followed by the results in previous that are not in current, which are
+// marked as removed.
+func DiffContentResults(previous, current []*edb.ContentResult) []*edb.ContentResult {
+	previousSet := make(map[string]struct{}, len(previous))
+	for _, r := range previous {
+		previousSet[r.Fingerprint] = struct{}{}
+	}
+	currentSet := make(map[string]struct{}, len(current))
+	for _, r := range current {
+		currentSet[r.Fingerprint] = struct{}{}
+	}
+
+	var diff []*edb.ContentResult
+	for _, r := range current {
+		if _, ok := previousSet[r.Fingerprint]; !ok {
+			diff = append(diff, r)
+		}
+	}
+	for _, r := range previous {
+		if _, ok := currentSet[r.Fingerprint]; !ok {
+			removed := *r
+			removed.Removed = true
+			diff = append(diff, &removed)
+		}
+	}
+	return diff
+}
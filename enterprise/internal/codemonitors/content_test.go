@@ -0,0 +1,143 @@
+package codemonitors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSearchesCommits(t *testing.T) {
+	cases := []struct {
+		query string
+		want  bool
+	}{
+		{query: "type:commit TODO", want: true},
+		{query: "type:diff repo:foo TODO", want: true},
+		{query: "(type:diff TODO) or (type:commit FIXME)", want: true},
+		{query: "TODO(security)", want: false},
+		{query: "type:symbol HandleRequest", want: false},
+		{query: "type:path file:\\.env$", want: false},
+		{query: "repo:foo select:repo", want: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.query, func(t *testing.T) {
+			got, err := SearchesCommits(tc.query)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestWithDefaultCount(t *testing.T) {
+	cases := []struct {
+		query string
+		want  []int
+	}{
+		{query: "TODO(security)", want: []int{10000}},
+		{query: "TODO(security) count:20", want: []int{20}},
+		{query: "(TODO count:20) or FIXME", want: []int{20, 10000}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.query, func(t *testing.T) {
+			plan, err := query.Pipeline(query.Init(tc.query, query.SearchTypeStandard))
+			require.NoError(t, err)
+
+			var got []int
+			for _, b := range withDefaultCount(plan, contentMonitorMaxResults) {
+				got = append(got, *b.Count())
+			}
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestToContentResults(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+	file := func(path string) result.File {
+		return result.File{Repo: repo, CommitID: "deadbeef", Path: path}
+	}
+	chunk := func(line int, content string, matched bool) result.ChunkMatch {
+		c := result.ChunkMatch{
+			Content:      content,
+			ContentStart: result.Location{Line: line},
+		}
+		if matched {
+			c.Ranges = result.Ranges{{
+				Start: result.Location{Line: line, Column: 0},
+				End:   result.Location{Line: line, Column: 4},
+			}}
+		}
+		return c
+	}
+
+	matches := result.Matches{
+		&result.FileMatch{
+			File: file("a.go"),
+			ChunkMatches: result.ChunkMatches{
+				chunk(3, "\t// TODO(security): check input", true),
+				chunk(9, "// unrelated context", false),
+				chunk(20, "// TODO(security): check input", true),
+			},
+		},
+		&result.FileMatch{
+			File: file("b.go"),
+			Symbols: []*result.SymbolMatch{{
+				Symbol: result.Symbol{Name: "HandleRequest", Kind: "function", Line: 12},
+			}},
+		},
+		&result.FileMatch{File: file("c.go")},
+		&result.RepoMatch{ID: repo.ID, Name: repo.Name},
+	}
+
+	results := toContentResults(matches)
+	require.Len(t, results, 5)
+
+	require.Equal(t, "a.go", results[0].Path)
+	require.Equal(t, "// TODO(security): check input", results[0].Preview)
+	require.Equal(t, 4, results[0].LineNumber)
+	require.Equal(t, 21, results[1].LineNumber)
+	require.NotEqual(t, results[0].Fingerprint, results[1].Fingerprint, "identical lines must have distinct fingerprints")
+
+	require.Equal(t, "function HandleRequest", results[2].Preview)
+	require.Equal(t, "c.go", results[3].Path)
+	require.Equal(t, "", results[4].Path)
+
+	// Moving a match to another line must not change its fingerprint.
+	moved := toContentResults(result.Matches{
+		&result.FileMatch{
+			File:         file("a.go"),
+			ChunkMatches: result.ChunkMatches{chunk(7, "// TODO(security): check input", true)},
+		},
+	})
+	require.Equal(t, results[0].Fingerprint, moved[0].Fingerprint)
+}
+
+func TestDiffContentResults(t *testing.T) {
+	a := &edb.ContentResult{Fingerprint: "a", Path: "a.go"}
+	b := &edb.ContentResult{Fingerprint: "b", Path: "b.go"}
+	c := &edb.ContentResult{Fingerprint: "c", Path: "c.go"}
+
+	t.Run("unchanged", func(t *testing.T) {
+		require.Empty(t, DiffContentResults([]*edb.ContentResult{a, b}, []*edb.ContentResult{b, a}))
+	})
+
+	t.Run("added and removed", func(t *testing.T) {
+		diff := DiffContentResults([]*edb.ContentResult{a, b}, []*edb.ContentResult{b, c})
+		require.Equal(t, []*edb.ContentResult{
+			c,
+			{Fingerprint: "a", Path: "a.go", Removed: true},
+		}, diff)
+		require.False(t, a.Removed, "previous results must not be modified")
+	})
+
+	t.Run("first run", func(t *testing.T) {
+		require.Equal(t, []*edb.ContentResult{a}, DiffContentResults(nil, []*edb.ContentResult{a}))
+	})
+}
@@ -186,8 +186,17 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 
 // Snapshot runs a dummy search that just saves the current state of the searched repos in the database.
 // On subsequent runs, this allows us to treat all new repos or sets of args as something new that should
-// be searched from the beginning.
+// be searched from the beginning. For queries that do not search commits, the current result set is
+// saved instead, so that subsequent runs only report matches that were added or removed.
 func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64, settings *schema.Settings) error {
+	searchesCommits, err := SearchesCommits(query)
+	if err != nil {
+		return err
+	}
+	if !searchesCommits {
+		return snapshotContent(ctx, logger, db, query, monitorID, settings)
+	}
+
 	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs())
 	inputs, err := searchClient.Plan(
 		ctx,
@@ -40,23 +40,19 @@ func substituteRegexp(content string, match *regexp.Regexp, replacePattern, sepa
 
 func output(ctx context.Context, fragment string, matchPattern MatchPattern, replacePattern string, separator string) (string, error) {
 	var newContent string
-	var err error
 	switch match := matchPattern.(type) {
 	case *Regexp:
 		newContent = substituteRegexp(fragment, match.Value, replacePattern, separator)
 	case *Comby:
-		newContent, err = comby.Outputs(ctx, comby.Args{
-			Input:           comby.FileContent(fragment),
-			MatchTemplate:   match.Value,
-			RewriteTemplate: replacePattern,
-			Matcher:         ".generic", // TODO(rvantoner): use language or file filter
-			ResultKind:      comby.NewlineSeparatedOutput,
-			NumWorkers:      0,
-		})
+		p, err := comby.Compile(match.Value, "", ".generic") // TODO(rvantoner): use language or file filter
 		if err != nil {
 			return "", err
 		}
-
+		outputs, err := p.Outputs([]byte(fragment), replacePattern)
+		if err != nil {
+			return "", err
+		}
+		newContent = strings.Join(outputs, "\n")
 	}
 	return newContent, nil
 }
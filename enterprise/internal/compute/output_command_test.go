@@ -3,13 +3,11 @@ package compute
 import (
 	"context"
 	"encoding/json"
-	"os"
 	"testing"
 
 	"github.com/grafana/regexp"
 	"github.com/hexops/autogold"
 
-	"github.com/sourcegraph/sourcegraph/internal/comby"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
@@ -34,11 +32,6 @@ func Test_output(t *testing.T) {
 			Separator:     "~",
 		}))
 
-	// If we are not on CI skip the test if comby is not installed.
-	if os.Getenv("CI") == "" && !comby.Exists() {
-		t.Skip("comby is not installed on the PATH. Try running 'bash <(curl -sL get.comby.dev)'.")
-	}
-
 	autogold.Want(
 		"structural search output",
 		`train(regional, intercity)
@@ -124,11 +117,6 @@ func TestRun(t *testing.T) {
 		"test\nstring\n").
 		Equal(t, test(`content:output((\b\w+\b) -> $1)`, fileMatch("test", "string")))
 
-	// If we are not on CI skip the test if comby is not installed.
-	if os.Getenv("CI") == "" && !comby.Exists() {
-		t.Skip("comby is not installed on the PATH. Try running 'bash <(curl -sL get.comby.dev)'.")
-	}
-
 	autogold.Want(
 		"template substitution structural",
 		">bar<").
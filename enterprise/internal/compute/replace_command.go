@@ -31,19 +31,14 @@ func replace(ctx context.Context, content []byte, matchPattern MatchPattern, rep
 	case *Regexp:
 		newContent = match.Value.ReplaceAllString(string(content), replacePattern)
 	case *Comby:
-		replacements, err := comby.Replacements(ctx, comby.Args{
-			Input:           comby.FileContent(content),
-			MatchTemplate:   match.Value,
-			RewriteTemplate: replacePattern,
-			Matcher:         ".generic", // TODO(rvantonder): use language or file filter
-			ResultKind:      comby.Replacement,
-			NumWorkers:      0, // Just a single file's content.
-		})
+		p, err := comby.Compile(match.Value, "", ".generic") // TODO(rvantonder): use language or file filter
+		if err != nil {
+			return nil, err
+		}
+		newContent, err = p.Replace(content, replacePattern)
 		if err != nil {
 			return nil, err
 		}
-		// There is only one replacement value since we passed in comby.FileContent.
-		newContent = replacements[0].Content
 	default:
 		return nil, errors.Errorf("unsupported replacement operation for match pattern %T", match)
 	}
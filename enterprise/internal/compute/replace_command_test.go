@@ -2,13 +2,10 @@ package compute
 
 import (
 	"context"
-	"os"
 	"testing"
 
 	"github.com/grafana/regexp"
 	"github.com/hexops/autogold"
-
-	"github.com/sourcegraph/sourcegraph/internal/comby"
 )
 
 func Test_replace(t *testing.T) {
@@ -28,11 +25,6 @@ func Test_replace(t *testing.T) {
 			ReplacePattern: "a bit more $1",
 		}))
 
-	// If we are not on CI skip the test if comby is not installed.
-	if os.Getenv("CI") == "" && !comby.Exists() {
-		t.Skip("comby is not installed on the PATH. Try running 'bash <(curl -sL get.comby.dev)'.")
-	}
-
 	autogold.Want(
 		"structural search replace",
 		"foo(baz, bar)").
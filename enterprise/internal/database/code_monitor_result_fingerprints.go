@@ -0,0 +1,127 @@
+package database
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// ContentResult is a single content, symbol, path or repository match of a
+// code monitor whose query does not search commits.
+type ContentResult struct {
+	// Fingerprint identifies the match independently of its line number, so
+	// that a match that only moves within a file is not reported as new.
+	Fingerprint string       `json:"fingerprint"`
+	RepoID      api.RepoID   `json:"repoID"`
+	RepoName    api.RepoName `json:"repoName"`
+	Commit      api.CommitID `json:"commit,omitempty"`
+	Path        string       `json:"path,omitempty"`
+	Preview     string       `json:"preview,omitempty"`
+	LineNumber  int          `json:"lineNumber,omitempty"`
+
+	// Removed is set if the match existed on the previous run of the monitor,
+	// but no longer exists.
+	Removed bool `json:"removed,omitempty"`
+}
+
+// GetResultFingerprints returns the result set that was stored for the given
+// monitor by the previous call to ReplaceResultFingerprints. stored is false if
+// no result set was ever stored for the monitor.
+func (s *codeMonitorStore) GetResultFingerprints(ctx context.Context, monitorID int64) (results []*ContentResult, stored bool, err error) {
+	stored, _, err = basestore.ScanFirstBool(s.Query(ctx, sqlf.Sprintf(resultFingerprintsStoredFmtStr, monitorID)))
+	if err != nil || !stored {
+		return nil, false, err
+	}
+
+	results, err = basestore.NewSliceScanner(scanContentResult)(s.Query(ctx, sqlf.Sprintf(getResultFingerprintsFmtStr, monitorID)))
+	return results, true, err
+}
+
+const resultFingerprintsStoredFmtStr = `
+SELECT result_fingerprints_stored_at IS NOT NULL
+FROM cm_monitors
+WHERE id = %s
+`
+
+const getResultFingerprintsFmtStr = `
+SELECT
+	crf.fingerprint,
+	crf.repo_id,
+	repo.name,
+	crf.path,
+	crf.preview
+FROM cm_result_fingerprints crf
+INNER JOIN repo ON repo.id = crf.repo_id
+WHERE crf.monitor_id = %s
+ORDER BY repo.name, crf.path, crf.fingerprint
+`
+
+func scanContentResult(sc dbutil.Scanner) (*ContentResult, error) {
+	var r ContentResult
+	return &r, sc.Scan(&r.Fingerprint, &r.RepoID, &r.RepoName, &r.Path, &r.Preview)
+}
+
+// ReplaceResultFingerprints replaces the stored result set of the given monitor
+// with the given results.
+func (s *codeMonitorStore) ReplaceResultFingerprints(ctx context.Context, monitorID int64, results []*ContentResult) (err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(markResultFingerprintsStoredFmtStr, monitorID)); err != nil {
+		return err
+	}
+	if err := tx.Exec(ctx, sqlf.Sprintf(deleteResultFingerprintsFmtStr, monitorID)); err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		return nil
+	}
+
+	var (
+		repoIDs      = make([]int32, 0, len(results))
+		fingerprints = make([]string, 0, len(results))
+		paths        = make([]string, 0, len(results))
+		previews     = make([]string, 0, len(results))
+	)
+	for _, r := range results {
+		repoIDs = append(repoIDs, int32(r.RepoID))
+		fingerprints = append(fingerprints, r.Fingerprint)
+		paths = append(paths, r.Path)
+		previews = append(previews, r.Preview)
+	}
+
+	return tx.Exec(ctx, sqlf.Sprintf(
+		insertResultFingerprintsFmtStr,
+		monitorID,
+		pq.Array(repoIDs),
+		pq.Array(fingerprints),
+		pq.Array(paths),
+		pq.Array(previews),
+	))
+}
+
+const markResultFingerprintsStoredFmtStr = `
+UPDATE cm_monitors
+SET result_fingerprints_stored_at = NOW()
+WHERE id = %s
+`
+
+const deleteResultFingerprintsFmtStr = `
+DELETE FROM cm_result_fingerprints
+WHERE monitor_id = %s
+`
+
+const insertResultFingerprintsFmtStr = `
+INSERT INTO cm_result_fingerprints (monitor_id, repo_id, fingerprint, path, preview)
+SELECT %s, repo_id, fingerprint, path, preview
+FROM unnest(%s::integer[], %s::text[], %s::text[], %s::text[]) AS r(repo_id, fingerprint, path, preview)
+ON CONFLICT DO NOTHING
+`
@@ -0,0 +1,92 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestCodeMonitorStoreResultFingerprints(t *testing.T) {
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+	fixtures := populateCodeMonitorFixtures(t, db)
+	cm := db.CodeMonitors()
+
+	// Nothing is stored before the first run.
+	results, stored, err := cm.GetResultFingerprints(ctx, fixtures.Monitor.ID)
+	require.NoError(t, err)
+	require.False(t, stored)
+	require.Empty(t, results)
+
+	first := []*ContentResult{
+		{Fingerprint: "a", RepoID: fixtures.Repo.ID, Path: "a.go", Preview: "// TODO(security)", LineNumber: 4},
+		{Fingerprint: "b", RepoID: fixtures.Repo.ID, Path: "b.go", Preview: "// TODO(security)", LineNumber: 8},
+	}
+	require.NoError(t, cm.ReplaceResultFingerprints(ctx, fixtures.Monitor.ID, first))
+
+	results, stored, err = cm.GetResultFingerprints(ctx, fixtures.Monitor.ID)
+	require.NoError(t, err)
+	require.True(t, stored)
+	require.Equal(t, []*ContentResult{
+		{Fingerprint: "a", RepoID: fixtures.Repo.ID, RepoName: fixtures.Repo.Name, Path: "a.go", Preview: "// TODO(security)"},
+		{Fingerprint: "b", RepoID: fixtures.Repo.ID, RepoName: fixtures.Repo.Name, Path: "b.go", Preview: "// TODO(security)"},
+	}, results)
+
+	// Replacing removes results that are no longer present.
+	second := []*ContentResult{
+		{Fingerprint: "c", RepoID: fixtures.Repo.ID, Path: "c.go"},
+	}
+	require.NoError(t, cm.ReplaceResultFingerprints(ctx, fixtures.Monitor.ID, second))
+
+	results, stored, err = cm.GetResultFingerprints(ctx, fixtures.Monitor.ID)
+	require.NoError(t, err)
+	require.True(t, stored)
+	require.Equal(t, []*ContentResult{
+		{Fingerprint: "c", RepoID: fixtures.Repo.ID, RepoName: fixtures.Repo.Name, Path: "c.go"},
+	}, results)
+
+	// Replacing with an empty result set clears it, but the result set is
+	// still known to be stored.
+	require.NoError(t, cm.ReplaceResultFingerprints(ctx, fixtures.Monitor.ID, nil))
+
+	results, stored, err = cm.GetResultFingerprints(ctx, fixtures.Monitor.ID)
+	require.NoError(t, err)
+	require.True(t, stored)
+	require.Empty(t, results)
+}
+
+func TestUpdateTriggerJobWithContentResults(t *testing.T) {
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+	fixtures := populateCodeMonitorFixtures(t, db)
+	cm := db.CodeMonitors()
+
+	triggerJobs, err := cm.EnqueueQueryTriggerJobs(ctx)
+	require.NoError(t, err)
+	require.Len(t, triggerJobs, 1)
+
+	diff := []*ContentResult{
+		{Fingerprint: "a", RepoID: fixtures.Repo.ID, RepoName: fixtures.Repo.Name, Path: "a.go", Preview: "// TODO(security)", LineNumber: 4},
+		{Fingerprint: "b", RepoID: fixtures.Repo.ID, RepoName: fixtures.Repo.Name, Path: "b.go", Preview: "// TODO(security)", Removed: true},
+	}
+	err = cm.UpdateTriggerJobWithContentResults(ctx, triggerJobs[0].ID, "TODO(security)", diff)
+	require.NoError(t, err)
+
+	jobs, err := cm.ListQueryTriggerJobs(ctx, ListTriggerJobsOpts{QueryID: &fixtures.Query.ID})
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	require.Equal(t, diff, jobs[0].ContentResults)
+	require.Empty(t, jobs[0].SearchResults)
+}
@@ -21,6 +21,10 @@ type TriggerJob struct {
 
 	SearchResults []*result.CommitMatch
 
+	// ContentResults are the matches that were added or removed since the
+	// previous run, for queries that do not search commits.
+	ContentResults []*ContentResult
+
 	// Fields demanded for any dbworker.
 	State          string
 	FailureMessage *string
@@ -82,6 +86,29 @@ func (s *codeMonitorStore) UpdateTriggerJobWithResults(ctx context.Context, trig
 	return s.Store.Exec(ctx, sqlf.Sprintf(logSearchFmtStr, queryString, resultsJSON, triggerJobID))
 }
 
+const logContentSearchFmtStr = `
+UPDATE cm_trigger_jobs
+SET query_string = %s,
+    search_results = '[]'::jsonb,
+    content_results = %s
+WHERE id = %s
+`
+
+// UpdateTriggerJobWithContentResults records the query that was run by a
+// trigger job that does not search commits, along with the matches that were
+// added or removed since the previous run.
+func (s *codeMonitorStore) UpdateTriggerJobWithContentResults(ctx context.Context, triggerJobID int32, queryString string, results []*ContentResult) error {
+	if results == nil {
+		results = []*ContentResult{}
+	}
+
+	resultsJSON, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+	return s.Store.Exec(ctx, sqlf.Sprintf(logContentSearchFmtStr, queryString, resultsJSON, triggerJobID))
+}
+
 const deleteOldJobLogsFmtStr = `
 DELETE FROM cm_trigger_jobs
 WHERE finished_at < (NOW() - (%s * '1 day'::interval));
@@ -143,7 +170,7 @@ func (s *codeMonitorStore) ListQueryTriggerJobs(ctx context.Context, opts ListTr
 const totalCountEventsForQueryIDInt64FmtStr = `
 SELECT COUNT(*)
 FROM cm_trigger_jobs
-WHERE ((state = 'completed' AND jsonb_array_length(search_results) > 0) OR (state != 'completed'))
+WHERE ((state = 'completed' AND (jsonb_array_length(search_results) > 0 OR jsonb_array_length(content_results) > 0)) OR (state != 'completed'))
 AND query = %s
 `
 
@@ -170,7 +197,7 @@ func scanTriggerJobs(rows *sql.Rows) ([]*TriggerJob, error) {
 }
 
 func ScanTriggerJob(scanner dbutil.Scanner) (*TriggerJob, error) {
-	var resultsJSON []byte
+	var resultsJSON, contentResultsJSON []byte
 	m := &TriggerJob{}
 	err := scanner.Scan(
 		&m.ID,
@@ -185,6 +212,7 @@ func ScanTriggerJob(scanner dbutil.Scanner) (*TriggerJob, error) {
 		&m.NumResets,
 		&m.NumFailures,
 		&m.LogContents,
+		&contentResultsJSON,
 	)
 	if err != nil {
 		return nil, err
@@ -195,6 +223,11 @@ func ScanTriggerJob(scanner dbutil.Scanner) (*TriggerJob, error) {
 			return nil, err
 		}
 	}
+	if len(contentResultsJSON) > 0 {
+		if err := json.Unmarshal(contentResultsJSON, &m.ContentResults); err != nil {
+			return nil, err
+		}
+	}
 
 	return m, nil
 }
@@ -212,4 +245,5 @@ var TriggerJobsColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_trigger_jobs.num_resets"),
 	sqlf.Sprintf("cm_trigger_jobs.num_failures"),
 	sqlf.Sprintf("cm_trigger_jobs.log_contents"),
+	sqlf.Sprintf("cm_trigger_jobs.content_results"),
 }
@@ -50,6 +50,7 @@ type CodeMonitorStore interface {
 	CountQueryTriggerJobs(ctx context.Context, queryID int64) (int32, error)
 
 	UpdateTriggerJobWithResults(ctx context.Context, triggerJobID int32, queryString string, results []*result.CommitMatch) error
+	UpdateTriggerJobWithContentResults(ctx context.Context, triggerJobID int32, queryString string, results []*ContentResult) error
 	DeleteOldTriggerJobs(ctx context.Context, retentionInDays int) error
 
 	UpdateEmailAction(_ context.Context, id int64, _ *EmailActionArgs) (*EmailAction, error)
@@ -90,6 +91,12 @@ type CodeMonitorStore interface {
 	HasAnyLastSearched(ctx context.Context, monitorID int64) (bool, error)
 	UpsertLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID, lastSearched []string) error
 	GetLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID) ([]string, error)
+
+	// GetResultFingerprints and ReplaceResultFingerprints store the result set
+	// of the previous run of a monitor whose query does not search commits, so
+	// that the next run can report which matches were added or removed.
+	GetResultFingerprints(ctx context.Context, monitorID int64) (results []*ContentResult, stored bool, err error)
+	ReplaceResultFingerprints(ctx context.Context, monitorID int64, results []*ContentResult) error
 }
 
 // codeMonitorStore exposes methods to read and write codemonitors domain models
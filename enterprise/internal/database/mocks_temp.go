@@ -121,6 +121,9 @@ type MockCodeMonitorStore struct {
 	// object controlling the behavior of the method
 	// GetQueryTriggerForMonitor.
 	GetQueryTriggerForMonitorFunc *CodeMonitorStoreGetQueryTriggerForMonitorFunc
+	// GetResultFingerprintsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetResultFingerprints.
+	GetResultFingerprintsFunc *CodeMonitorStoreGetResultFingerprintsFunc
 	// GetSlackWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method GetSlackWebhookAction.
 	GetSlackWebhookActionFunc *CodeMonitorStoreGetSlackWebhookActionFunc
@@ -157,6 +160,10 @@ type MockCodeMonitorStore struct {
 	// NowFunc is an instance of a mock function object controlling the
 	// behavior of the method Now.
 	NowFunc *CodeMonitorStoreNowFunc
+	// ReplaceResultFingerprintsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// ReplaceResultFingerprints.
+	ReplaceResultFingerprintsFunc *CodeMonitorStoreReplaceResultFingerprintsFunc
 	// ResetQueryTriggerTimestampsFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// ResetQueryTriggerTimestamps.
@@ -182,6 +189,10 @@ type MockCodeMonitorStore struct {
 	// UpdateSlackWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateSlackWebhookAction.
 	UpdateSlackWebhookActionFunc *CodeMonitorStoreUpdateSlackWebhookActionFunc
+	// UpdateTriggerJobWithContentResultsFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// UpdateTriggerJobWithContentResults.
+	UpdateTriggerJobWithContentResultsFunc *CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc
 	// UpdateTriggerJobWithResultsFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// UpdateTriggerJobWithResults.
@@ -349,6 +360,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		GetResultFingerprintsFunc: &CodeMonitorStoreGetResultFingerprintsFunc{
+			defaultHook: func(context.Context, int64) (r0 []*ContentResult, r1 bool, r2 error) {
+				return
+			},
+		},
 		GetSlackWebhookActionFunc: &CodeMonitorStoreGetSlackWebhookActionFunc{
 			defaultHook: func(context.Context, int64) (r0 *SlackWebhookAction, r1 error) {
 				return
@@ -409,6 +425,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		ReplaceResultFingerprintsFunc: &CodeMonitorStoreReplaceResultFingerprintsFunc{
+			defaultHook: func(context.Context, int64, []*ContentResult) (r0 error) {
+				return
+			},
+		},
 		ResetQueryTriggerTimestampsFunc: &CodeMonitorStoreResetQueryTriggerTimestampsFunc{
 			defaultHook: func(context.Context, int64) (r0 error) {
 				return
@@ -449,6 +470,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		UpdateTriggerJobWithContentResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc{
+			defaultHook: func(context.Context, int32, string, []*ContentResult) (r0 error) {
+				return
+			},
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
 			defaultHook: func(context.Context, int32, string, []*result.CommitMatch) (r0 error) {
 				return
@@ -621,6 +647,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.GetQueryTriggerForMonitor")
 			},
 		},
+		GetResultFingerprintsFunc: &CodeMonitorStoreGetResultFingerprintsFunc{
+			defaultHook: func(context.Context, int64) ([]*ContentResult, bool, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.GetResultFingerprints")
+			},
+		},
 		GetSlackWebhookActionFunc: &CodeMonitorStoreGetSlackWebhookActionFunc{
 			defaultHook: func(context.Context, int64) (*SlackWebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.GetSlackWebhookAction")
@@ -681,6 +712,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.Now")
 			},
 		},
+		ReplaceResultFingerprintsFunc: &CodeMonitorStoreReplaceResultFingerprintsFunc{
+			defaultHook: func(context.Context, int64, []*ContentResult) error {
+				panic("unexpected invocation of MockCodeMonitorStore.ReplaceResultFingerprints")
+			},
+		},
 		ResetQueryTriggerTimestampsFunc: &CodeMonitorStoreResetQueryTriggerTimestampsFunc{
 			defaultHook: func(context.Context, int64) error {
 				panic("unexpected invocation of MockCodeMonitorStore.ResetQueryTriggerTimestamps")
@@ -721,6 +757,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateSlackWebhookAction")
 			},
 		},
+		UpdateTriggerJobWithContentResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc{
+			defaultHook: func(context.Context, int32, string, []*ContentResult) error {
+				panic("unexpected invocation of MockCodeMonitorStore.UpdateTriggerJobWithContentResults")
+			},
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
 			defaultHook: func(context.Context, int32, string, []*result.CommitMatch) error {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateTriggerJobWithResults")
@@ -834,6 +875,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		GetQueryTriggerForMonitorFunc: &CodeMonitorStoreGetQueryTriggerForMonitorFunc{
 			defaultHook: i.GetQueryTriggerForMonitor,
 		},
+		GetResultFingerprintsFunc: &CodeMonitorStoreGetResultFingerprintsFunc{
+			defaultHook: i.GetResultFingerprints,
+		},
 		GetSlackWebhookActionFunc: &CodeMonitorStoreGetSlackWebhookActionFunc{
 			defaultHook: i.GetSlackWebhookAction,
 		},
@@ -870,6 +914,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		NowFunc: &CodeMonitorStoreNowFunc{
 			defaultHook: i.Now,
 		},
+		ReplaceResultFingerprintsFunc: &CodeMonitorStoreReplaceResultFingerprintsFunc{
+			defaultHook: i.ReplaceResultFingerprints,
+		},
 		ResetQueryTriggerTimestampsFunc: &CodeMonitorStoreResetQueryTriggerTimestampsFunc{
 			defaultHook: i.ResetQueryTriggerTimestamps,
 		},
@@ -894,6 +941,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		UpdateSlackWebhookActionFunc: &CodeMonitorStoreUpdateSlackWebhookActionFunc{
 			defaultHook: i.UpdateSlackWebhookAction,
 		},
+		UpdateTriggerJobWithContentResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc{
+			defaultHook: i.UpdateTriggerJobWithContentResults,
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
 			defaultHook: i.UpdateTriggerJobWithResults,
 		},
@@ -4249,6 +4299,121 @@ func (c CodeMonitorStoreGetQueryTriggerForMonitorFuncCall) Results() []interface
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreGetResultFingerprintsFunc describes the behavior when the
+// GetResultFingerprints method of the parent MockCodeMonitorStore instance
+// is invoked.
+type CodeMonitorStoreGetResultFingerprintsFunc struct {
+	defaultHook func(context.Context, int64) ([]*ContentResult, bool, error)
+	hooks       []func(context.Context, int64) ([]*ContentResult, bool, error)
+	history     []CodeMonitorStoreGetResultFingerprintsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetResultFingerprints delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetResultFingerprints(v0 context.Context, v1 int64) ([]*ContentResult, bool, error) {
+	r0, r1, r2 := m.GetResultFingerprintsFunc.nextHook()(v0, v1)
+	m.GetResultFingerprintsFunc.appendCall(CodeMonitorStoreGetResultFingerprintsFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetResultFingerprints method of the parent MockCodeMonitorStore instance
+// is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreGetResultFingerprintsFunc) SetDefaultHook(hook func(context.Context, int64) ([]*ContentResult, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetResultFingerprints method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreGetResultFingerprintsFunc) PushHook(hook func(context.Context, int64) ([]*ContentResult, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreGetResultFingerprintsFunc) SetDefaultReturn(r0 []*ContentResult, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int64) ([]*ContentResult, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreGetResultFingerprintsFunc) PushReturn(r0 []*ContentResult, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int64) ([]*ContentResult, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *CodeMonitorStoreGetResultFingerprintsFunc) nextHook() func(context.Context, int64) ([]*ContentResult, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreGetResultFingerprintsFunc) appendCall(r0 CodeMonitorStoreGetResultFingerprintsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreGetResultFingerprintsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreGetResultFingerprintsFunc) History() []CodeMonitorStoreGetResultFingerprintsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreGetResultFingerprintsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreGetResultFingerprintsFuncCall is an object that describes
+// an invocation of method GetResultFingerprints on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreGetResultFingerprintsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*ContentResult
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreGetResultFingerprintsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreGetResultFingerprintsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // CodeMonitorStoreGetSlackWebhookActionFunc describes the behavior when the
 // GetSlackWebhookAction method of the parent MockCodeMonitorStore instance
 // is invoked.
@@ -5556,6 +5721,118 @@ func (c CodeMonitorStoreNowFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// CodeMonitorStoreReplaceResultFingerprintsFunc describes the behavior when
+// the ReplaceResultFingerprints method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreReplaceResultFingerprintsFunc struct {
+	defaultHook func(context.Context, int64, []*ContentResult) error
+	hooks       []func(context.Context, int64, []*ContentResult) error
+	history     []CodeMonitorStoreReplaceResultFingerprintsFuncCall
+	mutex       sync.Mutex
+}
+
+// ReplaceResultFingerprints delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ReplaceResultFingerprints(v0 context.Context, v1 int64, v2 []*ContentResult) error {
+	r0 := m.ReplaceResultFingerprintsFunc.nextHook()(v0, v1, v2)
+	m.ReplaceResultFingerprintsFunc.appendCall(CodeMonitorStoreReplaceResultFingerprintsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// ReplaceResultFingerprints method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreReplaceResultFingerprintsFunc) SetDefaultHook(hook func(context.Context, int64, []*ContentResult) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ReplaceResultFingerprints method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreReplaceResultFingerprintsFunc) PushHook(hook func(context.Context, int64, []*ContentResult) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreReplaceResultFingerprintsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, []*ContentResult) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreReplaceResultFingerprintsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, []*ContentResult) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreReplaceResultFingerprintsFunc) nextHook() func(context.Context, int64, []*ContentResult) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreReplaceResultFingerprintsFunc) appendCall(r0 CodeMonitorStoreReplaceResultFingerprintsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreReplaceResultFingerprintsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreReplaceResultFingerprintsFunc) History() []CodeMonitorStoreReplaceResultFingerprintsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreReplaceResultFingerprintsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreReplaceResultFingerprintsFuncCall is an object that
+// describes an invocation of method ReplaceResultFingerprints on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreReplaceResultFingerprintsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []*ContentResult
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreReplaceResultFingerprintsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreReplaceResultFingerprintsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CodeMonitorStoreResetQueryTriggerTimestampsFunc describes the behavior
 // when the ResetQueryTriggerTimestamps method of the parent
 // MockCodeMonitorStore instance is invoked.
@@ -6459,37 +6736,37 @@ func (c CodeMonitorStoreUpdateSlackWebhookActionFuncCall) Results() []interface{
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreUpdateTriggerJobWithResultsFunc describes the behavior
-// when the UpdateTriggerJobWithResults method of the parent
+// CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc describes the
+// behavior when the UpdateTriggerJobWithContentResults method of the parent
 // MockCodeMonitorStore instance is invoked.
-type CodeMonitorStoreUpdateTriggerJobWithResultsFunc struct {
-	defaultHook func(context.Context, int32, string, []*result.CommitMatch) error
-	hooks       []func(context.Context, int32, string, []*result.CommitMatch) error
-	history     []CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall
+type CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc struct {
+	defaultHook func(context.Context, int32, string, []*ContentResult) error
+	hooks       []func(context.Context, int32, string, []*ContentResult) error
+	history     []CodeMonitorStoreUpdateTriggerJobWithContentResultsFuncCall
 	mutex       sync.Mutex
 }
 
-// UpdateTriggerJobWithResults delegates to the next hook function in the
-// queue and stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) UpdateTriggerJobWithResults(v0 context.Context, v1 int32, v2 string, v3 []*result.CommitMatch) error {
-	r0 := m.UpdateTriggerJobWithResultsFunc.nextHook()(v0, v1, v2, v3)
-	m.UpdateTriggerJobWithResultsFunc.appendCall(CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall{v0, v1, v2, v3, r0})
+// UpdateTriggerJobWithContentResults delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateTriggerJobWithContentResults(v0 context.Context, v1 int32, v2 string, v3 []*ContentResult) error {
+	r0 := m.UpdateTriggerJobWithContentResultsFunc.nextHook()(v0, v1, v2, v3)
+	m.UpdateTriggerJobWithContentResultsFunc.appendCall(CodeMonitorStoreUpdateTriggerJobWithContentResultsFuncCall{v0, v1, v2, v3, r0})
 	return r0
 }
 
 // SetDefaultHook sets function that is called when the
-// UpdateTriggerJobWithResults method of the parent MockCodeMonitorStore
-// instance is invoked and the hook queue is empty.
-func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) SetDefaultHook(hook func(context.Context, int32, string, []*result.CommitMatch) error) {
+// UpdateTriggerJobWithContentResults method of the parent
+// MockCodeMonitorStore instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc) SetDefaultHook(hook func(context.Context, int32, string, []*ContentResult) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// UpdateTriggerJobWithResults method of the parent MockCodeMonitorStore
-// instance invokes the hook at the front of the queue and discards it.
-// After the queue is empty, the default hook function is invoked for any
-// future action.
-func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) PushHook(hook func(context.Context, int32, string, []*result.CommitMatch) error) {
+// UpdateTriggerJobWithContentResults method of the parent
+// MockCodeMonitorStore instance invokes the hook at the front of the queue
+// and discards it. After the queue is empty, the default hook function is
+// invoked for any future action.
+func (f *CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc) PushHook(hook func(context.Context, int32, string, []*ContentResult) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -6497,20 +6774,20 @@ func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) PushHook(hook func(con
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int32, string, []*result.CommitMatch) error {
+func (f *CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, string, []*ContentResult) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int32, string, []*result.CommitMatch) error {
+func (f *CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, string, []*ContentResult) error {
 		return r0
 	})
 }
 
-func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) nextHook() func(context.Context, int32, string, []*result.CommitMatch) error {
+func (f *CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc) nextHook() func(context.Context, int32, string, []*ContentResult) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -6523,28 +6800,28 @@ func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) nextHook() func(contex
 	return hook
 }
 
-func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) appendCall(r0 CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall) {
+func (f *CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc) appendCall(r0 CodeMonitorStoreUpdateTriggerJobWithContentResultsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
 // History returns a sequence of
-// CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall objects describing
-// the invocations of this function.
-func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) History() []CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall {
+// CodeMonitorStoreUpdateTriggerJobWithContentResultsFuncCall objects
+// describing the invocations of this function.
+func (f *CodeMonitorStoreUpdateTriggerJobWithContentResultsFunc) History() []CodeMonitorStoreUpdateTriggerJobWithContentResultsFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreUpdateTriggerJobWithContentResultsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall is an object that
-// describes an invocation of method UpdateTriggerJobWithResults on an
-// instance of MockCodeMonitorStore.
-type CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall struct {
+// CodeMonitorStoreUpdateTriggerJobWithContentResultsFuncCall is an object
+// that describes an invocation of method UpdateTriggerJobWithContentResults
+// on an instance of MockCodeMonitorStore.
+type CodeMonitorStoreUpdateTriggerJobWithContentResultsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -6556,7 +6833,7 @@ type CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall struct {
 	Arg2 string
 	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
-	Arg3 []*result.CommitMatch
+	Arg3 []*ContentResult
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -6564,44 +6841,159 @@ type CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreUpdateTriggerJobWithContentResultsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreUpdateTriggerJobWithContentResultsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// CodeMonitorStoreUpdateWebhookActionFunc describes the behavior when the
-// UpdateWebhookAction method of the parent MockCodeMonitorStore instance is
-// invoked.
-type CodeMonitorStoreUpdateWebhookActionFunc struct {
-	defaultHook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)
-	hooks       []func(context.Context, int64, bool, bool, string) (*WebhookAction, error)
-	history     []CodeMonitorStoreUpdateWebhookActionFuncCall
+// CodeMonitorStoreUpdateTriggerJobWithResultsFunc describes the behavior
+// when the UpdateTriggerJobWithResults method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreUpdateTriggerJobWithResultsFunc struct {
+	defaultHook func(context.Context, int32, string, []*result.CommitMatch) error
+	hooks       []func(context.Context, int32, string, []*result.CommitMatch) error
+	history     []CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall
 	mutex       sync.Mutex
 }
 
-// UpdateWebhookAction delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) UpdateWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*WebhookAction, error) {
-	r0, r1 := m.UpdateWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.UpdateWebhookActionFunc.appendCall(CodeMonitorStoreUpdateWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
-	return r0, r1
+// UpdateTriggerJobWithResults delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateTriggerJobWithResults(v0 context.Context, v1 int32, v2 string, v3 []*result.CommitMatch) error {
+	r0 := m.UpdateTriggerJobWithResultsFunc.nextHook()(v0, v1, v2, v3)
+	m.UpdateTriggerJobWithResultsFunc.appendCall(CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall{v0, v1, v2, v3, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the UpdateWebhookAction
-// method of the parent MockCodeMonitorStore instance is invoked and the
-// hook queue is empty.
-func (f *CodeMonitorStoreUpdateWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)) {
+// SetDefaultHook sets function that is called when the
+// UpdateTriggerJobWithResults method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) SetDefaultHook(hook func(context.Context, int32, string, []*result.CommitMatch) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// UpdateWebhookAction method of the parent MockCodeMonitorStore instance
-// invokes the hook at the front of the queue and discards it. After the
+// UpdateTriggerJobWithResults method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) PushHook(hook func(context.Context, int32, string, []*result.CommitMatch) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, string, []*result.CommitMatch) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, string, []*result.CommitMatch) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) nextHook() func(context.Context, int32, string, []*result.CommitMatch) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) appendCall(r0 CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultsFunc) History() []CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall is an object that
+// describes an invocation of method UpdateTriggerJobWithResults on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []*result.CommitMatch
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// CodeMonitorStoreUpdateWebhookActionFunc describes the behavior when the
+// UpdateWebhookAction method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreUpdateWebhookActionFunc struct {
+	defaultHook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string) (*WebhookAction, error)
+	history     []CodeMonitorStoreUpdateWebhookActionFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateWebhookAction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*WebhookAction, error) {
+	r0, r1 := m.UpdateWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.UpdateWebhookActionFunc.appendCall(CodeMonitorStoreUpdateWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the UpdateWebhookAction
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreUpdateWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateWebhookAction method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
 func (f *CodeMonitorStoreUpdateWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)) {
@@ -6847,6 +7239,9 @@ type MockEnterpriseDB struct {
 	// ExecutorsFunc is an instance of a mock function object controlling
 	// the behavior of the method Executors.
 	ExecutorsFunc *EnterpriseDBExecutorsFunc
+	// ExhaustiveSearchJobsFunc is an instance of a mock function object
+	// controlling the behavior of the method ExhaustiveSearchJobs.
+	ExhaustiveSearchJobsFunc *EnterpriseDBExhaustiveSearchJobsFunc
 	// ExternalServicesFunc is an instance of a mock function object
 	// controlling the behavior of the method ExternalServices.
 	ExternalServicesFunc *EnterpriseDBExternalServicesFunc
@@ -6880,6 +7275,18 @@ type MockEnterpriseDB struct {
 	// OrgsFunc is an instance of a mock function object controlling the
 	// behavior of the method Orgs.
 	OrgsFunc *EnterpriseDBOrgsFunc
+	// OutboundWebhookJobsFunc is an instance of a mock function object
+	// controlling the behavior of the method OutboundWebhookJobs.
+	OutboundWebhookJobsFunc *EnterpriseDBOutboundWebhookJobsFunc
+	// OutboundWebhookLogsFunc is an instance of a mock function object
+	// controlling the behavior of the method OutboundWebhookLogs.
+	OutboundWebhookLogsFunc *EnterpriseDBOutboundWebhookLogsFunc
+	// OutboundWebhooksFunc is an instance of a mock function object
+	// controlling the behavior of the method OutboundWebhooks.
+	OutboundWebhooksFunc *EnterpriseDBOutboundWebhooksFunc
+	// PathRanksFunc is an instance of a mock function object controlling
+	// the behavior of the method PathRanks.
+	PathRanksFunc *EnterpriseDBPathRanksFunc
 	// PermsFunc is an instance of a mock function object controlling the
 	// behavior of the method Perms.
 	PermsFunc *EnterpriseDBPermsFunc
@@ -6901,6 +7308,9 @@ type MockEnterpriseDB struct {
 	// ReposFunc is an instance of a mock function object controlling the
 	// behavior of the method Repos.
 	ReposFunc *EnterpriseDBReposFunc
+	// RolesFunc is an instance of a mock function object controlling the
+	// behavior of the method Roles.
+	RolesFunc *EnterpriseDBRolesFunc
 	// SavedSearchesFunc is an instance of a mock function object
 	// controlling the behavior of the method SavedSearches.
 	SavedSearchesFunc *EnterpriseDBSavedSearchesFunc
@@ -7004,6 +7414,11 @@ func NewMockEnterpriseDB() *MockEnterpriseDB {
 				return
 			},
 		},
+		ExhaustiveSearchJobsFunc: &EnterpriseDBExhaustiveSearchJobsFunc{
+			defaultHook: func() (r0 database.ExhaustiveSearchJobStore) {
+				return
+			},
+		},
 		ExternalServicesFunc: &EnterpriseDBExternalServicesFunc{
 			defaultHook: func() (r0 database.ExternalServiceStore) {
 				return
@@ -7059,6 +7474,26 @@ func NewMockEnterpriseDB() *MockEnterpriseDB {
 				return
 			},
 		},
+		OutboundWebhookJobsFunc: &EnterpriseDBOutboundWebhookJobsFunc{
+			defaultHook: func(encryption.Key) (r0 database.OutboundWebhookJobStore) {
+				return
+			},
+		},
+		OutboundWebhookLogsFunc: &EnterpriseDBOutboundWebhookLogsFunc{
+			defaultHook: func(encryption.Key) (r0 database.OutboundWebhookLogStore) {
+				return
+			},
+		},
+		OutboundWebhooksFunc: &EnterpriseDBOutboundWebhooksFunc{
+			defaultHook: func(encryption.Key) (r0 database.OutboundWebhookStore) {
+				return
+			},
+		},
+		PathRanksFunc: &EnterpriseDBPathRanksFunc{
+			defaultHook: func() (r0 database.PathRankStore) {
+				return
+			},
+		},
 		PermsFunc: &EnterpriseDBPermsFunc{
 			defaultHook: func() (r0 PermsStore) {
 				return
@@ -7094,6 +7529,11 @@ func NewMockEnterpriseDB() *MockEnterpriseDB {
 				return
 			},
 		},
+		RolesFunc: &EnterpriseDBRolesFunc{
+			defaultHook: func() (r0 database.RoleStore) {
+				return
+			},
+		},
 		SavedSearchesFunc: &EnterpriseDBSavedSearchesFunc{
 			defaultHook: func() (r0 database.SavedSearchStore) {
 				return
@@ -7226,6 +7666,11 @@ func NewStrictMockEnterpriseDB() *MockEnterpriseDB {
 				panic("unexpected invocation of MockEnterpriseDB.Executors")
 			},
 		},
+		ExhaustiveSearchJobsFunc: &EnterpriseDBExhaustiveSearchJobsFunc{
+			defaultHook: func() database.ExhaustiveSearchJobStore {
+				panic("unexpected invocation of MockEnterpriseDB.ExhaustiveSearchJobs")
+			},
+		},
 		ExternalServicesFunc: &EnterpriseDBExternalServicesFunc{
 			defaultHook: func() database.ExternalServiceStore {
 				panic("unexpected invocation of MockEnterpriseDB.ExternalServices")
@@ -7281,6 +7726,26 @@ func NewStrictMockEnterpriseDB() *MockEnterpriseDB {
 				panic("unexpected invocation of MockEnterpriseDB.Orgs")
 			},
 		},
+		OutboundWebhookJobsFunc: &EnterpriseDBOutboundWebhookJobsFunc{
+			defaultHook: func(encryption.Key) database.OutboundWebhookJobStore {
+				panic("unexpected invocation of MockEnterpriseDB.OutboundWebhookJobs")
+			},
+		},
+		OutboundWebhookLogsFunc: &EnterpriseDBOutboundWebhookLogsFunc{
+			defaultHook: func(encryption.Key) database.OutboundWebhookLogStore {
+				panic("unexpected invocation of MockEnterpriseDB.OutboundWebhookLogs")
+			},
+		},
+		OutboundWebhooksFunc: &EnterpriseDBOutboundWebhooksFunc{
+			defaultHook: func(encryption.Key) database.OutboundWebhookStore {
+				panic("unexpected invocation of MockEnterpriseDB.OutboundWebhooks")
+			},
+		},
+		PathRanksFunc: &EnterpriseDBPathRanksFunc{
+			defaultHook: func() database.PathRankStore {
+				panic("unexpected invocation of MockEnterpriseDB.PathRanks")
+			},
+		},
 		PermsFunc: &EnterpriseDBPermsFunc{
 			defaultHook: func() PermsStore {
 				panic("unexpected invocation of MockEnterpriseDB.Perms")
@@ -7316,6 +7781,11 @@ func NewStrictMockEnterpriseDB() *MockEnterpriseDB {
 				panic("unexpected invocation of MockEnterpriseDB.Repos")
 			},
 		},
+		RolesFunc: &EnterpriseDBRolesFunc{
+			defaultHook: func() database.RoleStore {
+				panic("unexpected invocation of MockEnterpriseDB.Roles")
+			},
+		},
 		SavedSearchesFunc: &EnterpriseDBSavedSearchesFunc{
 			defaultHook: func() database.SavedSearchStore {
 				panic("unexpected invocation of MockEnterpriseDB.SavedSearches")
@@ -7427,6 +7897,9 @@ func NewMockEnterpriseDBFrom(i EnterpriseDB) *MockEnterpriseDB {
 		ExecutorsFunc: &EnterpriseDBExecutorsFunc{
 			defaultHook: i.Executors,
 		},
+		ExhaustiveSearchJobsFunc: &EnterpriseDBExhaustiveSearchJobsFunc{
+			defaultHook: i.ExhaustiveSearchJobs,
+		},
 		ExternalServicesFunc: &EnterpriseDBExternalServicesFunc{
 			defaultHook: i.ExternalServices,
 		},
@@ -7460,6 +7933,18 @@ func NewMockEnterpriseDBFrom(i EnterpriseDB) *MockEnterpriseDB {
 		OrgsFunc: &EnterpriseDBOrgsFunc{
 			defaultHook: i.Orgs,
 		},
+		OutboundWebhookJobsFunc: &EnterpriseDBOutboundWebhookJobsFunc{
+			defaultHook: i.OutboundWebhookJobs,
+		},
+		OutboundWebhookLogsFunc: &EnterpriseDBOutboundWebhookLogsFunc{
+			defaultHook: i.OutboundWebhookLogs,
+		},
+		OutboundWebhooksFunc: &EnterpriseDBOutboundWebhooksFunc{
+			defaultHook: i.OutboundWebhooks,
+		},
+		PathRanksFunc: &EnterpriseDBPathRanksFunc{
+			defaultHook: i.PathRanks,
+		},
 		PermsFunc: &EnterpriseDBPermsFunc{
 			defaultHook: i.Perms,
 		},
@@ -7481,6 +7966,9 @@ func NewMockEnterpriseDBFrom(i EnterpriseDB) *MockEnterpriseDB {
 		ReposFunc: &EnterpriseDBReposFunc{
 			defaultHook: i.Repos,
 		},
+		RolesFunc: &EnterpriseDBRolesFunc{
+			defaultHook: i.Roles,
+		},
 		SavedSearchesFunc: &EnterpriseDBSavedSearchesFunc{
 			defaultHook: i.SavedSearches,
 		},
@@ -8646,6 +9134,108 @@ func (c EnterpriseDBExecutorsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// EnterpriseDBExhaustiveSearchJobsFunc describes the behavior when the
+// ExhaustiveSearchJobs method of the parent MockEnterpriseDB instance is
+// invoked.
+type EnterpriseDBExhaustiveSearchJobsFunc struct {
+	defaultHook func() database.ExhaustiveSearchJobStore
+	hooks       []func() database.ExhaustiveSearchJobStore
+	history     []EnterpriseDBExhaustiveSearchJobsFuncCall
+	mutex       sync.Mutex
+}
+
+// ExhaustiveSearchJobs delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockEnterpriseDB) ExhaustiveSearchJobs() database.ExhaustiveSearchJobStore {
+	r0 := m.ExhaustiveSearchJobsFunc.nextHook()()
+	m.ExhaustiveSearchJobsFunc.appendCall(EnterpriseDBExhaustiveSearchJobsFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ExhaustiveSearchJobs
+// method of the parent MockEnterpriseDB instance is invoked and the hook
+// queue is empty.
+func (f *EnterpriseDBExhaustiveSearchJobsFunc) SetDefaultHook(hook func() database.ExhaustiveSearchJobStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ExhaustiveSearchJobs method of the parent MockEnterpriseDB instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *EnterpriseDBExhaustiveSearchJobsFunc) PushHook(hook func() database.ExhaustiveSearchJobStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *EnterpriseDBExhaustiveSearchJobsFunc) SetDefaultReturn(r0 database.ExhaustiveSearchJobStore) {
+	f.SetDefaultHook(func() database.ExhaustiveSearchJobStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *EnterpriseDBExhaustiveSearchJobsFunc) PushReturn(r0 database.ExhaustiveSearchJobStore) {
+	f.PushHook(func() database.ExhaustiveSearchJobStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBExhaustiveSearchJobsFunc) nextHook() func() database.ExhaustiveSearchJobStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBExhaustiveSearchJobsFunc) appendCall(r0 EnterpriseDBExhaustiveSearchJobsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnterpriseDBExhaustiveSearchJobsFuncCall
+// objects describing the invocations of this function.
+func (f *EnterpriseDBExhaustiveSearchJobsFunc) History() []EnterpriseDBExhaustiveSearchJobsFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBExhaustiveSearchJobsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBExhaustiveSearchJobsFuncCall is an object that describes an
+// invocation of method ExhaustiveSearchJobs on an instance of
+// MockEnterpriseDB.
+type EnterpriseDBExhaustiveSearchJobsFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.ExhaustiveSearchJobStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBExhaustiveSearchJobsFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBExhaustiveSearchJobsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // EnterpriseDBExternalServicesFunc describes the behavior when the
 // ExternalServices method of the parent MockEnterpriseDB instance is
 // invoked.
@@ -9737,34 +10327,37 @@ func (c EnterpriseDBOrgsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// EnterpriseDBPermsFunc describes the behavior when the Perms method of the
-// parent MockEnterpriseDB instance is invoked.
-type EnterpriseDBPermsFunc struct {
-	defaultHook func() PermsStore
-	hooks       []func() PermsStore
-	history     []EnterpriseDBPermsFuncCall
+// EnterpriseDBOutboundWebhookJobsFunc describes the behavior when the
+// OutboundWebhookJobs method of the parent MockEnterpriseDB instance is
+// invoked.
+type EnterpriseDBOutboundWebhookJobsFunc struct {
+	defaultHook func(encryption.Key) database.OutboundWebhookJobStore
+	hooks       []func(encryption.Key) database.OutboundWebhookJobStore
+	history     []EnterpriseDBOutboundWebhookJobsFuncCall
 	mutex       sync.Mutex
 }
 
-// Perms delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockEnterpriseDB) Perms() PermsStore {
-	r0 := m.PermsFunc.nextHook()()
-	m.PermsFunc.appendCall(EnterpriseDBPermsFuncCall{r0})
+// OutboundWebhookJobs delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockEnterpriseDB) OutboundWebhookJobs(v0 encryption.Key) database.OutboundWebhookJobStore {
+	r0 := m.OutboundWebhookJobsFunc.nextHook()(v0)
+	m.OutboundWebhookJobsFunc.appendCall(EnterpriseDBOutboundWebhookJobsFuncCall{v0, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the Perms method of the
-// parent MockEnterpriseDB instance is invoked and the hook queue is empty.
-func (f *EnterpriseDBPermsFunc) SetDefaultHook(hook func() PermsStore) {
+// SetDefaultHook sets function that is called when the OutboundWebhookJobs
+// method of the parent MockEnterpriseDB instance is invoked and the hook
+// queue is empty.
+func (f *EnterpriseDBOutboundWebhookJobsFunc) SetDefaultHook(hook func(encryption.Key) database.OutboundWebhookJobStore) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Perms method of the parent MockEnterpriseDB instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *EnterpriseDBPermsFunc) PushHook(hook func() PermsStore) {
+// OutboundWebhookJobs method of the parent MockEnterpriseDB instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *EnterpriseDBOutboundWebhookJobsFunc) PushHook(hook func(encryption.Key) database.OutboundWebhookJobStore) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -9772,20 +10365,429 @@ func (f *EnterpriseDBPermsFunc) PushHook(hook func() PermsStore) {
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *EnterpriseDBPermsFunc) SetDefaultReturn(r0 PermsStore) {
-	f.SetDefaultHook(func() PermsStore {
+func (f *EnterpriseDBOutboundWebhookJobsFunc) SetDefaultReturn(r0 database.OutboundWebhookJobStore) {
+	f.SetDefaultHook(func(encryption.Key) database.OutboundWebhookJobStore {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *EnterpriseDBPermsFunc) PushReturn(r0 PermsStore) {
-	f.PushHook(func() PermsStore {
+func (f *EnterpriseDBOutboundWebhookJobsFunc) PushReturn(r0 database.OutboundWebhookJobStore) {
+	f.PushHook(func(encryption.Key) database.OutboundWebhookJobStore {
 		return r0
 	})
 }
 
-func (f *EnterpriseDBPermsFunc) nextHook() func() PermsStore {
+func (f *EnterpriseDBOutboundWebhookJobsFunc) nextHook() func(encryption.Key) database.OutboundWebhookJobStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBOutboundWebhookJobsFunc) appendCall(r0 EnterpriseDBOutboundWebhookJobsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnterpriseDBOutboundWebhookJobsFuncCall
+// objects describing the invocations of this function.
+func (f *EnterpriseDBOutboundWebhookJobsFunc) History() []EnterpriseDBOutboundWebhookJobsFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBOutboundWebhookJobsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBOutboundWebhookJobsFuncCall is an object that describes an
+// invocation of method OutboundWebhookJobs on an instance of
+// MockEnterpriseDB.
+type EnterpriseDBOutboundWebhookJobsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 encryption.Key
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.OutboundWebhookJobStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBOutboundWebhookJobsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBOutboundWebhookJobsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// EnterpriseDBOutboundWebhookLogsFunc describes the behavior when the
+// OutboundWebhookLogs method of the parent MockEnterpriseDB instance is
+// invoked.
+type EnterpriseDBOutboundWebhookLogsFunc struct {
+	defaultHook func(encryption.Key) database.OutboundWebhookLogStore
+	hooks       []func(encryption.Key) database.OutboundWebhookLogStore
+	history     []EnterpriseDBOutboundWebhookLogsFuncCall
+	mutex       sync.Mutex
+}
+
+// OutboundWebhookLogs delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockEnterpriseDB) OutboundWebhookLogs(v0 encryption.Key) database.OutboundWebhookLogStore {
+	r0 := m.OutboundWebhookLogsFunc.nextHook()(v0)
+	m.OutboundWebhookLogsFunc.appendCall(EnterpriseDBOutboundWebhookLogsFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the OutboundWebhookLogs
+// method of the parent MockEnterpriseDB instance is invoked and the hook
+// queue is empty.
+func (f *EnterpriseDBOutboundWebhookLogsFunc) SetDefaultHook(hook func(encryption.Key) database.OutboundWebhookLogStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// OutboundWebhookLogs method of the parent MockEnterpriseDB instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *EnterpriseDBOutboundWebhookLogsFunc) PushHook(hook func(encryption.Key) database.OutboundWebhookLogStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *EnterpriseDBOutboundWebhookLogsFunc) SetDefaultReturn(r0 database.OutboundWebhookLogStore) {
+	f.SetDefaultHook(func(encryption.Key) database.OutboundWebhookLogStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *EnterpriseDBOutboundWebhookLogsFunc) PushReturn(r0 database.OutboundWebhookLogStore) {
+	f.PushHook(func(encryption.Key) database.OutboundWebhookLogStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBOutboundWebhookLogsFunc) nextHook() func(encryption.Key) database.OutboundWebhookLogStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBOutboundWebhookLogsFunc) appendCall(r0 EnterpriseDBOutboundWebhookLogsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnterpriseDBOutboundWebhookLogsFuncCall
+// objects describing the invocations of this function.
+func (f *EnterpriseDBOutboundWebhookLogsFunc) History() []EnterpriseDBOutboundWebhookLogsFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBOutboundWebhookLogsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBOutboundWebhookLogsFuncCall is an object that describes an
+// invocation of method OutboundWebhookLogs on an instance of
+// MockEnterpriseDB.
+type EnterpriseDBOutboundWebhookLogsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 encryption.Key
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.OutboundWebhookLogStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBOutboundWebhookLogsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBOutboundWebhookLogsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// EnterpriseDBOutboundWebhooksFunc describes the behavior when the
+// OutboundWebhooks method of the parent MockEnterpriseDB instance is
+// invoked.
+type EnterpriseDBOutboundWebhooksFunc struct {
+	defaultHook func(encryption.Key) database.OutboundWebhookStore
+	hooks       []func(encryption.Key) database.OutboundWebhookStore
+	history     []EnterpriseDBOutboundWebhooksFuncCall
+	mutex       sync.Mutex
+}
+
+// OutboundWebhooks delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockEnterpriseDB) OutboundWebhooks(v0 encryption.Key) database.OutboundWebhookStore {
+	r0 := m.OutboundWebhooksFunc.nextHook()(v0)
+	m.OutboundWebhooksFunc.appendCall(EnterpriseDBOutboundWebhooksFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the OutboundWebhooks
+// method of the parent MockEnterpriseDB instance is invoked and the hook
+// queue is empty.
+func (f *EnterpriseDBOutboundWebhooksFunc) SetDefaultHook(hook func(encryption.Key) database.OutboundWebhookStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// OutboundWebhooks method of the parent MockEnterpriseDB instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *EnterpriseDBOutboundWebhooksFunc) PushHook(hook func(encryption.Key) database.OutboundWebhookStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *EnterpriseDBOutboundWebhooksFunc) SetDefaultReturn(r0 database.OutboundWebhookStore) {
+	f.SetDefaultHook(func(encryption.Key) database.OutboundWebhookStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *EnterpriseDBOutboundWebhooksFunc) PushReturn(r0 database.OutboundWebhookStore) {
+	f.PushHook(func(encryption.Key) database.OutboundWebhookStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBOutboundWebhooksFunc) nextHook() func(encryption.Key) database.OutboundWebhookStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBOutboundWebhooksFunc) appendCall(r0 EnterpriseDBOutboundWebhooksFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnterpriseDBOutboundWebhooksFuncCall
+// objects describing the invocations of this function.
+func (f *EnterpriseDBOutboundWebhooksFunc) History() []EnterpriseDBOutboundWebhooksFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBOutboundWebhooksFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBOutboundWebhooksFuncCall is an object that describes an
+// invocation of method OutboundWebhooks on an instance of MockEnterpriseDB.
+type EnterpriseDBOutboundWebhooksFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 encryption.Key
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.OutboundWebhookStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBOutboundWebhooksFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBOutboundWebhooksFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// EnterpriseDBPathRanksFunc describes the behavior when the PathRanks
+// method of the parent MockEnterpriseDB instance is invoked.
+type EnterpriseDBPathRanksFunc struct {
+	defaultHook func() database.PathRankStore
+	hooks       []func() database.PathRankStore
+	history     []EnterpriseDBPathRanksFuncCall
+	mutex       sync.Mutex
+}
+
+// PathRanks delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockEnterpriseDB) PathRanks() database.PathRankStore {
+	r0 := m.PathRanksFunc.nextHook()()
+	m.PathRanksFunc.appendCall(EnterpriseDBPathRanksFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the PathRanks method of
+// the parent MockEnterpriseDB instance is invoked and the hook queue is
+// empty.
+func (f *EnterpriseDBPathRanksFunc) SetDefaultHook(hook func() database.PathRankStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// PathRanks method of the parent MockEnterpriseDB instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *EnterpriseDBPathRanksFunc) PushHook(hook func() database.PathRankStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *EnterpriseDBPathRanksFunc) SetDefaultReturn(r0 database.PathRankStore) {
+	f.SetDefaultHook(func() database.PathRankStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *EnterpriseDBPathRanksFunc) PushReturn(r0 database.PathRankStore) {
+	f.PushHook(func() database.PathRankStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBPathRanksFunc) nextHook() func() database.PathRankStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBPathRanksFunc) appendCall(r0 EnterpriseDBPathRanksFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnterpriseDBPathRanksFuncCall objects
+// describing the invocations of this function.
+func (f *EnterpriseDBPathRanksFunc) History() []EnterpriseDBPathRanksFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBPathRanksFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBPathRanksFuncCall is an object that describes an invocation
+// of method PathRanks on an instance of MockEnterpriseDB.
+type EnterpriseDBPathRanksFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.PathRankStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBPathRanksFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBPathRanksFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// EnterpriseDBPermsFunc describes the behavior when the Perms method of the
+// parent MockEnterpriseDB instance is invoked.
+type EnterpriseDBPermsFunc struct {
+	defaultHook func() PermsStore
+	hooks       []func() PermsStore
+	history     []EnterpriseDBPermsFuncCall
+	mutex       sync.Mutex
+}
+
+// Perms delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockEnterpriseDB) Perms() PermsStore {
+	r0 := m.PermsFunc.nextHook()()
+	m.PermsFunc.appendCall(EnterpriseDBPermsFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Perms method of the
+// parent MockEnterpriseDB instance is invoked and the hook queue is empty.
+func (f *EnterpriseDBPermsFunc) SetDefaultHook(hook func() PermsStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Perms method of the parent MockEnterpriseDB instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *EnterpriseDBPermsFunc) PushHook(hook func() PermsStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *EnterpriseDBPermsFunc) SetDefaultReturn(r0 PermsStore) {
+	f.SetDefaultHook(func() PermsStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *EnterpriseDBPermsFunc) PushReturn(r0 PermsStore) {
+	f.PushHook(func() PermsStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBPermsFunc) nextHook() func() PermsStore {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -10464,6 +11466,104 @@ func (c EnterpriseDBReposFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// EnterpriseDBRolesFunc describes the behavior when the Roles method of the
+// parent MockEnterpriseDB instance is invoked.
+type EnterpriseDBRolesFunc struct {
+	defaultHook func() database.RoleStore
+	hooks       []func() database.RoleStore
+	history     []EnterpriseDBRolesFuncCall
+	mutex       sync.Mutex
+}
+
+// Roles delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockEnterpriseDB) Roles() database.RoleStore {
+	r0 := m.RolesFunc.nextHook()()
+	m.RolesFunc.appendCall(EnterpriseDBRolesFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Roles method of the
+// parent MockEnterpriseDB instance is invoked and the hook queue is empty.
+func (f *EnterpriseDBRolesFunc) SetDefaultHook(hook func() database.RoleStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Roles method of the parent MockEnterpriseDB instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *EnterpriseDBRolesFunc) PushHook(hook func() database.RoleStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *EnterpriseDBRolesFunc) SetDefaultReturn(r0 database.RoleStore) {
+	f.SetDefaultHook(func() database.RoleStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *EnterpriseDBRolesFunc) PushReturn(r0 database.RoleStore) {
+	f.PushHook(func() database.RoleStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBRolesFunc) nextHook() func() database.RoleStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBRolesFunc) appendCall(r0 EnterpriseDBRolesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnterpriseDBRolesFuncCall objects
+// describing the invocations of this function.
+func (f *EnterpriseDBRolesFunc) History() []EnterpriseDBRolesFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBRolesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBRolesFuncCall is an object that describes an invocation of
+// method Roles on an instance of MockEnterpriseDB.
+type EnterpriseDBRolesFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.RoleStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBRolesFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBRolesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // EnterpriseDBSavedSearchesFunc describes the behavior when the
 // SavedSearches method of the parent MockEnterpriseDB instance is invoked.
 type EnterpriseDBSavedSearchesFunc struct {
@@ -18,6 +18,10 @@ type Job struct {
 	// that different queues can share identifiers.
 	ID int `json:"id"`
 
+	// Queue is the name of the queue the job was dequeued from. It is only set
+	// for jobs dequeued from multiple queues at once.
+	Queue string `json:"queue,omitempty"`
+
 	// RepositoryName is the name of the repository to be cloned into the
 	// workspace prior to job execution.
 	RepositoryName string `json:"repositoryName"`
@@ -67,6 +71,7 @@ func (j Job) MarshalJSON() ([]byte, error) {
 		v2 := v2Job{
 			Version:             j.Version,
 			ID:                  j.ID,
+			Queue:               j.Queue,
 			RepositoryName:      j.RepositoryName,
 			RepositoryDirectory: j.RepositoryDirectory,
 			Commit:              j.Commit,
@@ -85,6 +90,7 @@ func (j Job) MarshalJSON() ([]byte, error) {
 	}
 	v1 := v1Job{
 		ID:                  j.ID,
+		Queue:               j.Queue,
 		RepositoryName:      j.RepositoryName,
 		RepositoryDirectory: j.RepositoryDirectory,
 		Commit:              j.Commit,
@@ -119,6 +125,7 @@ func (j *Job) UnmarshalJSON(data []byte) error {
 		}
 		j.Version = v2.Version
 		j.ID = v2.ID
+		j.Queue = v2.Queue
 		j.RepositoryName = v2.RepositoryName
 		j.RepositoryDirectory = v2.RepositoryDirectory
 		j.Commit = v2.Commit
@@ -139,6 +146,7 @@ func (j *Job) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	j.ID = v1.ID
+	j.Queue = v1.Queue
 	j.RepositoryName = v1.RepositoryName
 	j.RepositoryDirectory = v1.RepositoryDirectory
 	j.Commit = v1.Commit
@@ -167,6 +175,7 @@ type versionJob struct {
 type v2Job struct {
 	Version             int                             `json:"version,omitempty"`
 	ID                  int                             `json:"id"`
+	Queue               string                          `json:"queue,omitempty"`
 	RepositoryName      string                          `json:"repositoryName"`
 	RepositoryDirectory string                          `json:"repositoryDirectory"`
 	Commit              string                          `json:"commit"`
@@ -181,6 +190,7 @@ type v2Job struct {
 
 type v1Job struct {
 	ID                  int                             `json:"id"`
+	Queue               string                          `json:"queue,omitempty"`
 	RepositoryName      string                          `json:"repositoryName"`
 	RepositoryDirectory string                          `json:"repositoryDirectory"`
 	Commit              string                          `json:"commit"`
@@ -268,6 +278,18 @@ type DequeueRequest struct {
 	NumCPUs      int    `json:"numCPUs,omitempty"`
 	Memory       string `json:"memory,omitempty"`
 	DiskSpace    string `json:"diskSpace,omitempty"`
+
+	// Queues are the queues to dequeue a job from, when dequeuing from multiple
+	// queues at once.
+	Queues []QueueWeight `json:"queues,omitempty"`
+}
+
+// QueueWeight is a queue an executor dequeues jobs from, along with its weight
+// relative to the other queues the executor dequeues jobs from. A queue with twice
+// the weight of another queue is tried first twice as often.
+type QueueWeight struct {
+	Name   string `json:"name"`
+	Weight int    `json:"weight"`
 }
 
 type AddExecutionLogEntryRequest struct {
@@ -307,6 +329,11 @@ type HeartbeatRequest struct {
 	ExecutorName string `json:"executorName"`
 	JobIDs       []int  `json:"jobIds"`
 
+	// JobIDsByQueue are the IDs of the jobs the executor is processing, by the
+	// name of the queue they were dequeued from, when heartbeating for multiple
+	// queues at once. All queues served by the executor must be present.
+	JobIDsByQueue map[string][]int `json:"jobIdsByQueue,omitempty"`
+
 	// Telemetry data.
 
 	OS              string `json:"os"`
@@ -323,6 +350,11 @@ type HeartbeatRequest struct {
 type HeartbeatResponse struct {
 	KnownIDs  []int `json:"knownIds"`
 	CancelIDs []int `json:"cancelIds"`
+
+	// KnownIDsByQueue and CancelIDsByQueue are set instead of KnownIDs and
+	// CancelIDs in response to heartbeats for multiple queues at once.
+	KnownIDsByQueue  map[string][]int `json:"knownIdsByQueue,omitempty"`
+	CancelIDsByQueue map[string][]int `json:"cancelIdsByQueue,omitempty"`
 }
 
 // TODO: Deprecated. Can be removed in Sourcegraph 4.4.
@@ -44,6 +44,11 @@ type Actor struct {
 	// cookie, logout would be ineffective.)
 	FromSessionCookie bool `json:"-"`
 
+	// Scopes are the scopes of the access token that was used to authenticate the actor, if
+	// the token does not have the user:all scope. It is nil if the actor is not restricted to
+	// a subset of the user's privileges. See authz.ActorHasScope.
+	Scopes []string `json:",omitempty"`
+
 	// user is populated lazily by (*Actor).User()
 	user     *types.User
 	userErr  error
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
+)
+
+// MissingPermissionError is returned when the current user is not granted a
+// permission by any of their roles.
+type MissingPermissionError struct {
+	Permission rbac.Permission
+}
+
+func (e *MissingPermissionError) Error() string {
+	return fmt.Sprintf("must be site admin or have permission %s", e.Permission)
+}
+
+func (e *MissingPermissionError) Unauthorized() bool { return true }
+
+// CheckCurrentUserHasPermission returns an error if the current user is not
+// granted the permission by a role assigned to them or to one of their
+// organizations. Site admins are members of the built-in site administrator
+// role, which grants every permission.
+func CheckCurrentUserHasPermission(ctx context.Context, db database.DB, permission rbac.Permission) error {
+	if actor.FromContext(ctx).IsInternal() {
+		return nil
+	}
+	user, err := CurrentUser(ctx, db)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrNotAuthenticated
+	}
+	return checkUserHasPermission(ctx, db, user.ID, user.SiteAdmin, permission)
+}
+
+// CheckUserHasPermission returns an error if the user is not granted the
+// permission. See CheckCurrentUserHasPermission.
+func CheckUserHasPermission(ctx context.Context, db database.DB, userID int32, permission rbac.Permission) error {
+	if actor.FromContext(ctx).IsInternal() {
+		return nil
+	}
+	user, err := db.Users().GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrNotAuthenticated
+	}
+	return checkUserHasPermission(ctx, db, user.ID, user.SiteAdmin, permission)
+}
+
+func checkUserHasPermission(ctx context.Context, db database.DB, userID int32, siteAdmin bool, permission rbac.Permission) error {
+	if siteAdmin {
+		return nil
+	}
+	ok, err := db.Roles().UserHasPermission(ctx, userID, permission)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return &MissingPermissionError{Permission: permission}
+	}
+	return nil
+}
+
+// CheckSameUserOrPermission returns an error if the current user is NEITHER
+// (1) the user specified by subjectUserID NOR (2) granted the permission.
+//
+// It is used when an action on a user's resources can be performed by the
+// user themselves and by users with an administrative permission, such as
+// site admins.
+func CheckSameUserOrPermission(ctx context.Context, db database.DB, subjectUserID int32, permission rbac.Permission) error {
+	a := actor.FromContext(ctx)
+	if a.IsInternal() || (a.IsAuthenticated() && a.UID == subjectUserID) {
+		return nil
+	}
+	if err := CheckCurrentUserHasPermission(ctx, db, permission); err == nil {
+		return nil
+	}
+	return &InsufficientAuthorizationError{fmt.Sprintf("must be authenticated as the authorized user, site admin, or have permission %s", permission)}
+}
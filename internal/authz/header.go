@@ -11,6 +11,7 @@ import (
 const (
 	SchemeToken     = "token"      // Scheme for Authorization header with only an access token
 	SchemeTokenSudo = "token-sudo" // Scheme for Authorization header with access token and sudo user
+	SchemeBearer    = "Bearer"     // Scheme for Authorization header with only an access token, only accepted by the SCIM API
 )
 
 // errUnrecognizedScheme occurs when the Authorization header scheme (the first token) is not
@@ -33,6 +34,8 @@ func IsUnrecognizedScheme(err error) bool {
 //   - With a token as params:
 //     "token" 1*SP "token" BWS "=" BWS quoted-string
 //
+// Bearer tokens are not supported, see ParseBearerAuthorizationHeader.
+//
 // The returned values are derived directly from user input and have not been validated or
 // authenticated.
 func ParseAuthorizationHeader(headerValue string) (token, sudoUser string, err error) {
@@ -66,6 +69,28 @@ func ParseAuthorizationHeader(headerValue string) (token, sudoUser string, err e
 	return token, sudoUser, nil
 }
 
+// ParseBearerAuthorizationHeader parses an HTTP Authorization request header with an access
+// token given as a bearer token ("Bearer" 1*SP token68), which is how SCIM clients send it.
+//
+// It must only be used for the SCIM API: elsewhere, bearer tokens are left to other auth
+// middlewares and proxies, which may set them for their own purposes.
+//
+// The returned token is derived directly from user input and has not been validated or
+// authenticated.
+func ParseBearerAuthorizationHeader(headerValue string) (token string, err error) {
+	scheme, token68, _, err := parseHTTPCredentials(headerValue)
+	if err != nil {
+		return "", err
+	}
+	if scheme != SchemeBearer {
+		return "", errUnrecognizedScheme
+	}
+	if token68 == "" {
+		return "", errors.New(`HTTP Authorization request header value must be of the following form: Bearer TOKEN`)
+	}
+	return token68, nil
+}
+
 // parseHTTPCredentials parses the "credentials" token as defined in [RFC 7235 Appendix
 // C](https://tools.ietf.org/html/rfc7235#appendix-C).
 func parseHTTPCredentials(credentials string) (scheme, token68 string, params map[string]string, err error) {
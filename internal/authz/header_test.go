@@ -23,6 +23,7 @@ func TestParseAuthorizationHeader(t *testing.T) {
 		`token-sudo token="tok==", user="alice"`: {token: "tok==", sudoUser: "alice"},
 		`token-sudo token=tok, user="alice"`:     {token: "tok", sudoUser: "alice"},
 		`token-sudo token="tok==", user=alice`:   {token: "tok==", sudoUser: "alice"},
+		"Bearer tok==":                           {err: true},
 		"xyz tok":                                {err: true},
 		`token-sudo user="alice"`:                {err: true},
 		`token-sudo token="",user="alice"`:       {err: true},
@@ -59,6 +60,30 @@ func TestParseAuthorizationHeader(t *testing.T) {
 	})
 }
 
+func TestParseBearerAuthorizationHeader(t *testing.T) {
+	tests := map[string]struct {
+		token string
+		err   bool
+	}{
+		"Bearer tok==":         {token: "tok=="},
+		"Bearer ":              {err: true},
+		`Bearer token="tok=="`: {err: true},
+		"token tok":            {err: true},
+		"xyz tok":              {err: true},
+	}
+	for input, test := range tests {
+		t.Run(input, func(t *testing.T) {
+			token, err := ParseBearerAuthorizationHeader(input)
+			if (err != nil) != test.err {
+				t.Errorf("got error %v, want error? %v", err, test.err)
+			}
+			if token != test.token {
+				t.Errorf("got token %q, want %q", token, test.token)
+			}
+		})
+	}
+}
+
 func TestParseHTTPCredentials(t *testing.T) {
 	tests := map[string]struct {
 		scheme  string
@@ -1,13 +1,80 @@
 package authz
 
+import "github.com/sourcegraph/sourcegraph/internal/actor"
+
 const (
 	// Access token scopes.
 	ScopeUserAll       = "user:all"        // Full control of all resources accessible to the user account.
 	ScopeSiteAdminSudo = "site-admin:sudo" // Ability to perform any action as any other user.
+
+	// Narrow access token scopes. Tokens with only these scopes can perform a
+	// subset of the actions of the user account.
+	ScopeSearchRead        = "search:read"         // Read-only access to search.
+	ScopeRepoRead          = "repo:read"           // Read-only access to repository contents.
+	ScopeCodeIntelUpload   = "codeintel:upload"    // Ability to upload precise code intelligence indexes.
+	ScopeBatchChangesWrite = "batch-changes:write" // Ability to create, apply and manage batch changes.
+	ScopeExecutor          = "executor"            // All narrow scopes, as needed by executor jobs.
 )
 
 // AllScopes is a list of all known access token scopes.
 var AllScopes = []string{
 	ScopeUserAll,
 	ScopeSiteAdminSudo,
+	ScopeSearchRead,
+	ScopeRepoRead,
+	ScopeCodeIntelUpload,
+	ScopeBatchChangesWrite,
+	ScopeExecutor,
+}
+
+// NarrowScopes is a list of the access token scopes that grant a subset of
+// ScopeUserAll.
+var NarrowScopes = []string{
+	ScopeSearchRead,
+	ScopeRepoRead,
+	ScopeCodeIntelUpload,
+	ScopeBatchChangesWrite,
+	ScopeExecutor,
+}
+
+// impliedScopes maps a scope to the narrow scopes that it implies.
+var impliedScopes = map[string][]string{
+	ScopeUserAll:  NarrowScopes,
+	ScopeExecutor: {ScopeSearchRead, ScopeRepoRead, ScopeCodeIntelUpload, ScopeBatchChangesWrite},
+}
+
+// ScopesAllow returns true if a token with the granted scopes may perform
+// actions that require the given scope.
+func ScopesAllow(granted []string, required string) bool {
+	for _, scope := range granted {
+		if scope == required {
+			return true
+		}
+		for _, implied := range impliedScopes[scope] {
+			if implied == required {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsNarrowScope returns true if the scope grants a subset of ScopeUserAll.
+func IsNarrowScope(scope string) bool {
+	for _, s := range NarrowScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// ActorHasScope returns true if the actor may perform actions that require
+// the given scope. Actors that are not restricted to access token scopes, such
+// as users authenticated with a session cookie, have every scope.
+func ActorHasScope(a *actor.Actor, scope string) bool {
+	if a == nil || a.Scopes == nil {
+		return true
+	}
+	return ScopesAllow(a.Scopes, scope)
 }
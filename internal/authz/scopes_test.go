@@ -0,0 +1,42 @@
+package authz
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+func TestScopesAllow(t *testing.T) {
+	tests := []struct {
+		granted  []string
+		required string
+		want     bool
+	}{
+		{granted: []string{ScopeUserAll}, required: ScopeUserAll, want: true},
+		{granted: []string{ScopeUserAll}, required: ScopeSearchRead, want: true},
+		{granted: []string{ScopeUserAll}, required: ScopeSiteAdminSudo, want: false},
+		{granted: []string{ScopeSearchRead}, required: ScopeSearchRead, want: true},
+		{granted: []string{ScopeSearchRead}, required: ScopeRepoRead, want: false},
+		{granted: []string{ScopeSearchRead}, required: ScopeUserAll, want: false},
+		{granted: []string{ScopeExecutor}, required: ScopeBatchChangesWrite, want: true},
+		{granted: []string{ScopeExecutor}, required: ScopeUserAll, want: false},
+		{granted: nil, required: ScopeSearchRead, want: false},
+	}
+	for _, tc := range tests {
+		if got := ScopesAllow(tc.granted, tc.required); got != tc.want {
+			t.Errorf("ScopesAllow(%q, %q) = %v, want %v", tc.granted, tc.required, got, tc.want)
+		}
+	}
+}
+
+func TestActorHasScope(t *testing.T) {
+	if !ActorHasScope(&actor.Actor{UID: 1}, ScopeRepoRead) {
+		t.Error("unrestricted actor should have every scope")
+	}
+	if ActorHasScope(&actor.Actor{UID: 1, Scopes: []string{ScopeSearchRead}}, ScopeRepoRead) {
+		t.Error("restricted actor should not have scope it was not granted")
+	}
+	if ActorHasScope(&actor.Actor{UID: 1, Scopes: []string{}}, ScopeRepoRead) {
+		t.Error("actor restricted to no scopes should not have any scope")
+	}
+}
@@ -7,4 +7,5 @@ const (
 	PythonPackagesScheme = "python"
 	RustPackagesScheme   = "rust-analyzer"
 	RubyPackagesScheme   = "scip-ruby"
+	NuGetPackagesScheme  = "scip-dotnet"
 )
@@ -0,0 +1,9 @@
+// Package ranking holds the configuration of code intelligence ranking that is
+// shared by the ranking service, which loads ranks, and search, which reads them.
+package ranking
+
+import "github.com/sourcegraph/sourcegraph/internal/env"
+
+// ResultsGraphKey identifies the graph export whose ranks are loaded into
+// codeintel_path_ranks. Ranks stored under another graph key are stale.
+var ResultsGraphKey = env.Get("CODEINTEL_RANKING_RESULTS_GRAPH_KEY", "dev", "An identifier of the graph export. Change to start a new import from the configured bucket.")
@@ -0,0 +1,330 @@
+package comby
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxStepsPerFile bounds the work spent backtracking in a single file, like
+// the per-file timeout of comby. Matching stops early in pathological files.
+// It is a variable so that tests can lower it.
+var maxStepsPerFile = 10_000_000
+
+// ErrStepLimitExceeded is returned when matching stopped early in a file,
+// because it took more than maxStepsPerFile steps. Matches after the point at
+// which matching stopped are missing.
+var ErrStepLimitExceeded = errors.New("structural matching stopped early because the file is too complex")
+
+// Pattern is a compiled match template and rule. It matches in-process with
+// the semantics of comby: holes match within balanced delimiters, and
+// comments and string literals are opaque according to the language.
+type Pattern struct {
+	elements []element
+	rule     rule
+	syntax   *syntax
+}
+
+// Compile compiles a match template, constrained by an optional rule, for
+// the language denoted by matcher. The matcher is a representative file
+// extension, like ".go", as accepted by comby's -matcher flag. Unknown
+// matchers use the generic language.
+func Compile(matchTemplate, rule, matcher string) (*Pattern, error) {
+	// Like comby, leading and trailing whitespace is not significant.
+	elements, err := compileTemplate(strings.TrimSpace(matchTemplate))
+	if err != nil {
+		return nil, err
+	}
+	r, err := parseRule(rule)
+	if err != nil {
+		return nil, err
+	}
+	return &Pattern{elements: elements, rule: r, syntax: syntaxForMatcher(matcher)}, nil
+}
+
+// Matches returns the non-overlapping matches of p in content, in order. Like
+// comby, locations have 1-based lines and columns, where columns count
+// characters. If matching stopped early, the matches found until then are
+// returned along with ErrStepLimitExceeded.
+func (p *Pattern) Matches(content []byte) ([]Match, error) {
+	found, err := p.findAll(content)
+	if len(found) == 0 {
+		return nil, err
+	}
+
+	lineStarts := []int{0}
+	for i, c := range content {
+		if c == '\n' {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+	location := func(offset int) Location {
+		line := sort.Search(len(lineStarts), func(i int) bool { return lineStarts[i] > offset }) - 1
+		return Location{
+			Offset: offset,
+			Line:   line + 1,
+			Column: utf8.RuneCount(content[lineStarts[line]:offset]) + 1,
+		}
+	}
+
+	matches := make([]Match, 0, len(found))
+	for _, m := range found {
+		matches = append(matches, Match{
+			Range:   Range{Start: location(m.start), End: location(m.end)},
+			Matched: string(content[m.start:m.end]),
+		})
+	}
+	return matches, err
+}
+
+// Replace returns content where every match of p is replaced by the rewrite
+// template, with the holes bound by the match substituted. Nothing is replaced
+// if matching stopped early.
+func (p *Pattern) Replace(content []byte, rewriteTemplate string) (string, error) {
+	found, err := p.findAll(content)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range found {
+		b.Write(content[last:m.start])
+		b.WriteString(substitute(rewriteTemplate, m.env))
+		last = m.end
+	}
+	b.Write(content[last:])
+	return b.String(), nil
+}
+
+// Outputs returns the rewrite template with the holes bound by each match of
+// p substituted. Like Replace, it returns no outputs if matching stopped
+// early.
+func (p *Pattern) Outputs(content []byte, rewriteTemplate string) ([]string, error) {
+	found, err := p.findAll(content)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make([]string, 0, len(found))
+	for _, m := range found {
+		outputs = append(outputs, substitute(rewriteTemplate, m.env))
+	}
+	return outputs, nil
+}
+
+type match struct {
+	start, end int
+	env        environment
+}
+
+// findAll returns the non-overlapping matches of p in buf, and
+// ErrStepLimitExceeded if it stopped early.
+func (p *Pattern) findAll(buf []byte) ([]match, error) {
+	if len(p.elements) == 0 {
+		// Like comby, the empty template matches every file once.
+		return []match{{env: environment{buf: buf}}}, nil
+	}
+
+	m := &matcher{elements: p.elements, src: newSource(buf, p.syntax)}
+
+	var prefix []byte
+	if first := p.elements[0]; first.kind == literalElement {
+		prefix = []byte(first.literal)
+	}
+
+	var found []match
+	for pos := 0; pos < len(buf) && m.steps <= maxStepsPerFile; {
+		if prefix != nil {
+			// Skip ahead to the next candidate.
+			n := bytes.Index(buf[pos:], prefix)
+			if n < 0 {
+				break
+			}
+			pos += n
+		}
+		if m.src.regions[pos] != code {
+			pos++
+			continue
+		}
+
+		end, env, ok := m.match(0, pos, environment{buf: buf})
+		if ok && end > pos && p.rule.satisfied(env, p.syntax) {
+			found = append(found, match{start: pos, end: end, env: env})
+			pos = end
+			continue
+		}
+		pos++
+	}
+	if m.steps > maxStepsPerFile {
+		return found, ErrStepLimitExceeded
+	}
+	return found, nil
+}
+
+type matcher struct {
+	elements []element
+	src      *source
+	// anchored requires matches to extend to the end of the source.
+	anchored bool
+	steps    int
+}
+
+// match matches the elements from i onwards at pos, and returns the end of
+// the match. Holes backtrack, trying the shortest match first.
+func (m *matcher) match(i, pos int, env environment) (int, environment, bool) {
+	m.steps++
+	if m.steps > maxStepsPerFile {
+		return 0, env, false
+	}
+	if i == len(m.elements) {
+		return pos, env, !m.anchored || pos == len(m.src.buf)
+	}
+
+	buf := m.src.buf
+	e := m.elements[i]
+	switch e.kind {
+	case literalElement:
+		if !hasPrefixAt(buf, pos, e.literal) {
+			return 0, env, false
+		}
+		return m.match(i+1, pos+len(e.literal), env)
+
+	case spaceElement:
+		end := pos
+		for end < len(buf) && isSpace(buf[end]) {
+			end++
+		}
+		if end == pos {
+			return 0, env, false
+		}
+		return m.match(i+1, end, env)
+	}
+
+	switch e.hole {
+	case holeEverything:
+		if i == len(m.elements)-1 {
+			// Nothing follows, so a trailing hole extends as far as the
+			// enclosing delimiters allow.
+			end := pos
+			for next, ok := m.src.next(end); ok; next, ok = m.src.next(end) {
+				end = next
+			}
+			return m.bind(i, pos, end, env)
+		}
+		for end := pos; ; {
+			if end, env, ok := m.bind(i, pos, end, env); ok {
+				return end, env, true
+			}
+			next, ok := m.src.next(end)
+			if !ok {
+				return 0, env, false
+			}
+			end = next
+		}
+
+	case holeAlphanum, holeNonSpace, holeSpace:
+		end := pos
+		for end < len(buf) && accepts(e.hole, buf[end]) {
+			end++
+		}
+		for ; end > pos; end-- {
+			if end, env, ok := m.bind(i, pos, end, env); ok {
+				return end, env, true
+			}
+		}
+		return 0, env, false
+
+	case holeLine:
+		end := indexByte(buf, pos, '\n')
+		if end < 0 {
+			end = len(buf)
+		} else {
+			end++
+		}
+		return m.bind(i, pos, end, env)
+
+	case holeRegexp:
+		loc := e.re.FindIndex(buf[pos:])
+		if loc == nil {
+			return 0, env, false
+		}
+		return m.bind(i, pos, pos+loc[1], env)
+	}
+	return 0, env, false
+}
+
+// bind binds the hole at i to [start, end) and matches the remaining
+// elements.
+func (m *matcher) bind(i, start, end int, env environment) (int, environment, bool) {
+	if e := m.elements[i]; !e.anonymous() {
+		var ok bool
+		if env, ok = env.bind(e.name, start, end); !ok {
+			return 0, env, false
+		}
+	}
+	return m.match(i+1, end, env)
+}
+
+func accepts(h holeKind, c byte) bool {
+	switch h {
+	case holeAlphanum:
+		return c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+	case holeNonSpace:
+		if _, ok := opening(c); ok {
+			return false
+		}
+		if _, ok := closing(c); ok {
+			return false
+		}
+		return !isSpace(c)
+	case holeSpace:
+		return c != '\n' && c != '\r' && isSpace(c)
+	}
+	return false
+}
+
+func isSpace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r', '\v', '\f':
+		return true
+	}
+	return false
+}
+
+// environment holds the values bound to the holes of a match.
+type environment struct {
+	buf      []byte
+	bindings []binding
+}
+
+type binding struct {
+	name       string
+	start, end int
+}
+
+func (env environment) lookup(name string) (string, bool) {
+	for _, b := range env.bindings {
+		if b.name == name {
+			return string(env.buf[b.start:b.end]), true
+		}
+	}
+	return "", false
+}
+
+// bind returns env with name bound to [start, end). A name which is already
+// bound must match the same content again.
+func (env environment) bind(name string, start, end int) (environment, bool) {
+	for _, b := range env.bindings {
+		if b.name == name {
+			return env, bytes.Equal(env.buf[b.start:b.end], env.buf[start:end])
+		}
+	}
+	// Backtracking may reuse the backing array past len(env.bindings), which
+	// only holds bindings of abandoned matches.
+	env.bindings = append(env.bindings, binding{name: name, start: start, end: end})
+	return env, true
+}
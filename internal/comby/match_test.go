@@ -0,0 +1,286 @@
+package comby
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatternMatches(t *testing.T) {
+	cases := []struct {
+		name     string
+		template string
+		rule     string
+		matcher  string
+		content  string
+		want     []string
+	}{
+		{
+			name:     "balanced delimiters",
+			template: "foo(:[args])",
+			content:  "foo(bar(1, 2), baz) foo()",
+			want:     []string{"foo(bar(1, 2), baz)", "foo()"},
+		},
+		{
+			name:     "holes do not cross unbalanced delimiters",
+			template: "(:[x])",
+			content:  "(a]b) (c)",
+			want:     []string{"(c)"},
+		},
+		{
+			name:     "nested matches are found outside of outer matches",
+			template: "(:[_])",
+			content:  `f() { g("x") }`,
+			want:     []string{"()", `("x")`},
+		},
+		{
+			name:     "whitespace matches any whitespace",
+			template: "if err != nil {",
+			content:  "if  err !=\n\tnil {",
+			want:     []string{"if  err !=\n\tnil {"},
+		},
+		{
+			name:     "comments are skipped in code",
+			template: "foo(:[x])",
+			matcher:  ".go",
+			content:  "// foo(a)\n/* foo(b) */ foo(c)",
+			want:     []string{"foo(c)"},
+		},
+		{
+			name:     "comments are text in the generic language",
+			template: "foo(:[x])",
+			content:  "// foo(a)\n/* foo(b) */",
+			want:     []string{"foo(a)", "foo(b)"},
+		},
+		{
+			name:     "delimiters in strings are not balanced",
+			template: "foo(:[x])",
+			matcher:  ".go",
+			content:  `foo(")") foo(` + "`(`" + `)`,
+			want:     []string{`foo(")")`, "foo(`(`)"},
+		},
+		{
+			name:     "alphanumeric hole",
+			template: "func :[[fn]](",
+			content:  "func foo_1(",
+			want:     []string{"func foo_1("},
+		},
+		{
+			name:     "ellipsis",
+			template: "foo(...)",
+			content:  "foo(a, b)",
+			want:     []string{"foo(a, b)"},
+		},
+		{
+			name:     "repeated holes match the same content",
+			template: ":[[x]] == :[[x]]",
+			content:  "a == b; c == c",
+			want:     []string{"c == c"},
+		},
+		{
+			name:     "keyword",
+			template: "func",
+			matcher:  ".go",
+			content:  "package main\n\n// func is a keyword\nfunc main() {\n\tfmt.Println(\"func\")\n}\n",
+			want:     []string{"func"},
+		},
+		{
+			name:     "regexp hole",
+			template: "foo(:[x~[0-9]+])",
+			content:  "foo(a) foo(42)",
+			want:     []string{"foo(42)"},
+		},
+		{
+			name:     "line hole",
+			template: "// :[x\\n]",
+			content:  "a // b c\nd",
+			want:     []string{"// b c\n"},
+		},
+		{
+			name:     "rule",
+			template: "foo(:[x])",
+			rule:     `where :[x] != "a", :[x] != "b"`,
+			content:  "foo(a) foo(b) foo(c)",
+			want:     []string{"foo(c)"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := Compile(tc.template, tc.rule, tc.matcher)
+			require.NoError(t, err)
+
+			matches, err := p.Matches([]byte(tc.content))
+			require.NoError(t, err)
+
+			var got []string
+			for _, m := range matches {
+				got = append(got, m.Matched)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestPatternMatchesLocations(t *testing.T) {
+	p, err := Compile("{:[body]}", "", ".go")
+	require.NoError(t, err)
+
+	got, err := p.Matches([]byte("func ü() {\n\treturn\n}"))
+	require.NoError(t, err)
+	assert.Equal(t, []Match{{
+		Range: Range{
+			Start: Location{Offset: 10, Line: 1, Column: 10},
+			End:   Location{Offset: 21, Line: 3, Column: 2},
+		},
+		Matched: "{\n\treturn\n}",
+	}}, got)
+}
+
+func TestPatternRewrite(t *testing.T) {
+	p, err := Compile("foo(:[x], :[y])", "", "")
+	require.NoError(t, err)
+
+	content := []byte("foo(a, b); foo(c, d)")
+	replaced, err := p.Replace(content, "foo(:[y], :[x])")
+	require.NoError(t, err)
+	assert.Equal(t, "foo(b, a); foo(d, c)", replaced)
+	outputs, err := p.Outputs(content, ":[x]-:[y] :[z]")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a-b :[z]", "c-d :[z]"}, outputs)
+}
+
+func TestPatternReplace(t *testing.T) {
+	const mainGo = `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("Hello foo")
+}
+`
+	for _, tc := range []struct {
+		name            string
+		matchTemplate   string
+		rewriteTemplate string
+		matcher         string
+		content         string
+		want            string
+	}{
+		{
+			name:            "keyword",
+			matchTemplate:   "func",
+			rewriteTemplate: "derp",
+			matcher:         ".go",
+			content:         mainGo,
+			want:            strings.Replace(mainGo, "func main", "derp main", 1),
+		},
+		{
+			name:            "whole file",
+			matchTemplate:   "yes",
+			rewriteTemplate: "no",
+			matcher:         ".go",
+			content:         "yes\n",
+			want:            "no\n",
+		},
+		{
+			name:            "identifier",
+			matchTemplate:   "tuesday",
+			rewriteTemplate: "wednesday",
+			matcher:         ".go",
+			content:         "package tuesday",
+			want:            "package wednesday",
+		},
+		{
+			name:            "no matches",
+			matchTemplate:   "tuesday",
+			rewriteTemplate: "wednesday",
+			content:         "package monday",
+			want:            "package monday",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := Compile(tc.matchTemplate, "", tc.matcher)
+			require.NoError(t, err)
+
+			got, err := p.Replace([]byte(tc.content), tc.rewriteTemplate)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestPatternStepLimit(t *testing.T) {
+	old := maxStepsPerFile
+	maxStepsPerFile = 100
+	t.Cleanup(func() { maxStepsPerFile = old })
+
+	p, err := Compile("foo(:[x])", "", "")
+	require.NoError(t, err)
+
+	// Every candidate takes a few steps, so matching stops before the end.
+	content := []byte(strings.Repeat("foo(a) ", 50))
+	matches, err := p.Matches(content)
+	assert.ErrorIs(t, err, ErrStepLimitExceeded)
+	assert.NotEmpty(t, matches)
+	assert.Less(t, len(matches), 50)
+
+	_, err = p.Replace(content, "bar(:[x])")
+	assert.ErrorIs(t, err, ErrStepLimitExceeded)
+	_, err = p.Outputs(content, ":[x]")
+	assert.ErrorIs(t, err, ErrStepLimitExceeded)
+
+	// Files that are simple enough are matched completely.
+	matches, err = p.Matches([]byte("foo(a) foo(b)"))
+	require.NoError(t, err)
+	assert.Len(t, matches, 2)
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, tc := range []struct {
+		template, rule string
+	}{
+		{template: ":[x~*]"},
+		{template: "foo", rule: "match :[x] { | _ -> true }"},
+		{template: "foo", rule: "where :[x] < 1"},
+		{template: "foo", rule: "where foo == :[x]"},
+	} {
+		_, err := Compile(tc.template, tc.rule, "")
+		assert.Error(t, err, "template %q, rule %q", tc.template, tc.rule)
+	}
+}
+
+func TestPatternMatchRules(t *testing.T) {
+	content := []byte(`f(" query: string") f(other) f(bar(1))`)
+	for _, tc := range []struct {
+		rule string
+		want []string
+	}{
+		{
+			rule: `where match :[x] { | "\" query: string\"" -> true }`,
+			want: []string{`f(" query: string")`},
+		},
+		{
+			rule: `where match :[x] { | "bar(:[_])" -> false | _ -> true }`,
+			want: []string{`f(" query: string")`, "f(other)"},
+		},
+		{
+			rule: `where match :[x] { | "other" -> true }, :[x] != "other"`,
+			want: nil,
+		},
+	} {
+		p, err := Compile("f(:[x])", tc.rule, ".go")
+		require.NoError(t, err)
+
+		matches, err := p.Matches(content)
+		require.NoError(t, err)
+
+		var got []string
+		for _, m := range matches {
+			got = append(got, m.Matched)
+		}
+		assert.Equal(t, tc.want, got, tc.rule)
+	}
+}
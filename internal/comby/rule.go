@@ -0,0 +1,221 @@
+package comby
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// rule is a conjunction of constraints on the holes of a match, like
+//
+//	where :[x] == "foo", :[y] != :[x], match :[z] { | "bar(:[_])" -> true }
+//
+// It supports the subset of comby's rule language which compares and matches
+// values, but not rewrite expressions.
+type rule []constraint
+
+type constraint interface {
+	satisfied(env environment, syn *syntax) bool
+}
+
+func (r rule) satisfied(env environment, syn *syntax) bool {
+	for _, c := range r {
+		if !c.satisfied(env, syn) {
+			return false
+		}
+	}
+	return true
+}
+
+type constantConstraint bool
+
+func (c constantConstraint) satisfied(environment, *syntax) bool { return bool(c) }
+
+// compareConstraint compares two templates, substituted with the holes of a
+// match.
+type compareConstraint struct {
+	left, right string
+	negated     bool
+}
+
+func (c compareConstraint) satisfied(env environment, _ *syntax) bool {
+	return (substitute(c.left, env) == substitute(c.right, env)) != c.negated
+}
+
+// matchConstraint matches the substituted template against the patterns of
+// its cases in order. The first case whose pattern matches all of the value
+// decides. It is not satisfied if no case matches.
+type matchConstraint struct {
+	template string
+	cases    []matchCase
+}
+
+type matchCase struct {
+	// elements is the pattern of the case. A nil pattern is the wildcard _.
+	elements []element
+	result   bool
+}
+
+func (c matchConstraint) satisfied(env environment, syn *syntax) bool {
+	value := []byte(substitute(c.template, env))
+	for _, mc := range c.cases {
+		if mc.elements == nil {
+			return mc.result
+		}
+		m := &matcher{elements: mc.elements, src: newSource(value, syn), anchored: true}
+		if _, _, ok := m.match(0, 0, environment{buf: value}); ok {
+			return mc.result
+		}
+	}
+	return false
+}
+
+func parseRule(s string) (rule, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(s, "where") {
+		return nil, errors.Errorf("unsupported rule %q: rules must start with 'where'", s)
+	}
+
+	var r rule
+	for _, expr := range splitOutsideQuotes(strings.TrimPrefix(s, "where"), ",") {
+		c, err := parseConstraint(strings.TrimSpace(expr))
+		if err != nil {
+			return nil, err
+		}
+		r = append(r, c)
+	}
+	return r, nil
+}
+
+func parseConstraint(expr string) (constraint, error) {
+	switch {
+	case expr == "true":
+		return constantConstraint(true), nil
+	case expr == "false":
+		return constantConstraint(false), nil
+	case strings.HasPrefix(expr, "match "):
+		return parseMatchConstraint(expr)
+	}
+
+	c := compareConstraint{}
+	operands := splitOutsideQuotes(expr, "==")
+	if len(operands) != 2 {
+		operands = splitOutsideQuotes(expr, "!=")
+		c.negated = true
+	}
+	if len(operands) != 2 {
+		return nil, errors.Errorf("unsupported rule expression %q: expected a comparison with == or !=, or a match expression", expr)
+	}
+	var err error
+	if c.left, err = parseOperand(operands[0]); err != nil {
+		return nil, err
+	}
+	if c.right, err = parseOperand(operands[1]); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// parseMatchConstraint parses expressions like
+//
+//	match :[x] { | "foo" -> true | _ -> false }
+func parseMatchConstraint(expr string) (constraint, error) {
+	parts := splitOutsideQuotes(strings.TrimPrefix(expr, "match "), "{")
+	if len(parts) != 2 || !strings.HasSuffix(strings.TrimSpace(parts[1]), "}") {
+		return nil, errors.Errorf("invalid match expression %q", expr)
+	}
+	template, err := parseOperand(parts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	c := matchConstraint{template: template}
+	body := strings.TrimSuffix(strings.TrimSpace(parts[1]), "}")
+	for i, arm := range splitOutsideQuotes(body, "|") {
+		if i == 0 {
+			if strings.TrimSpace(arm) != "" {
+				return nil, errors.Errorf("invalid match expression %q: cases must start with |", expr)
+			}
+			continue
+		}
+		caseParts := splitOutsideQuotes(arm, "->")
+		if len(caseParts) != 2 {
+			return nil, errors.Errorf("invalid match case %q: expected pattern -> result", arm)
+		}
+
+		var mc matchCase
+		switch result := strings.TrimSpace(caseParts[1]); result {
+		case "true", "false":
+			mc.result = result == "true"
+		default:
+			return nil, errors.Errorf("unsupported match case result %q: only true and false are supported", result)
+		}
+
+		if pattern := strings.TrimSpace(caseParts[0]); pattern != "_" {
+			pattern, err := parseOperand(pattern)
+			if err != nil {
+				return nil, err
+			}
+			if mc.elements, err = compileTemplate(pattern); err != nil {
+				return nil, err
+			}
+			if mc.elements == nil {
+				// The empty pattern only matches the empty value.
+				mc.elements = []element{}
+			}
+		}
+		c.cases = append(c.cases, mc)
+	}
+	return c, nil
+}
+
+// parseOperand returns the template of an operand, which is either a hole or
+// a quoted string which may contain holes.
+func parseOperand(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '`') && s[len(s)-1] == s[0] {
+		if s[0] == '`' {
+			return s[1 : len(s)-1], nil
+		}
+		return strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(s[1 : len(s)-1]), nil
+	}
+	if _, ok, _ := compileHole(s); ok {
+		return s, nil
+	}
+	return "", errors.Errorf("unsupported rule operand %q: expected a hole or a quoted string", s)
+}
+
+// splitOutsideQuotes splits s around each sep which is neither part of a
+// quoted string nor nested in braces.
+func splitOutsideQuotes(s, sep string) []string {
+	var (
+		parts []string
+		quote byte
+		depth int
+		last  int
+	)
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '`':
+			quote = c
+		case depth == 0 && strings.HasPrefix(s[i:], sep):
+			parts = append(parts, s[last:i])
+			last = i + len(sep)
+			i += len(sep) - 1
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+		}
+	}
+	return append(parts, s[last:])
+}
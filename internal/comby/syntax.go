@@ -0,0 +1,260 @@
+package comby
+
+import (
+	"bytes"
+	"strings"
+)
+
+// syntax describes the parts of a language the matcher has to understand to
+// match structurally: which delimiters must be balanced, and which comments
+// and string literals are opaque to matching.
+type syntax struct {
+	lineComments  []string
+	blockComments [][2]string
+	// quotes are string literal delimiters with backslash escapes.
+	quotes []byte
+	// rawQuotes are string literal delimiters without escapes.
+	rawQuotes []byte
+}
+
+// delimiters are the balanced delimiters of every language.
+var delimiters = [...][2]byte{{'(', ')'}, {'[', ']'}, {'{', '}'}}
+
+var (
+	cStyle  = []string{"//"}
+	cBlock  = [][2]string{{"/*", "*/"}}
+	mlBlock = [][2]string{{"(*", "*)"}}
+)
+
+// syntaxes maps comby's -matcher values, a representative file extension of a
+// language, to the syntax of the language.
+var syntaxes = map[string]*syntax{
+	".generic": {quotes: []byte{'"'}},
+	".txt":     {},
+	".md":      {},
+	".org":     {},
+	".rst":     {},
+
+	".c":     {lineComments: cStyle, blockComments: cBlock, quotes: []byte{'"', '\''}},
+	".cs":    {lineComments: cStyle, blockComments: cBlock, quotes: []byte{'"', '\''}},
+	".css":   {blockComments: cBlock, quotes: []byte{'"', '\''}},
+	".dart":  {lineComments: cStyle, blockComments: cBlock, quotes: []byte{'"', '\''}},
+	".go":    {lineComments: cStyle, blockComments: cBlock, quotes: []byte{'"', '\''}, rawQuotes: []byte{'`'}},
+	".java":  {lineComments: cStyle, blockComments: cBlock, quotes: []byte{'"', '\''}},
+	".js":    {lineComments: cStyle, blockComments: cBlock, quotes: []byte{'"', '\''}, rawQuotes: []byte{'`'}},
+	".json":  {quotes: []byte{'"'}},
+	".kt":    {lineComments: cStyle, blockComments: cBlock, quotes: []byte{'"', '\''}},
+	".php":   {lineComments: []string{"//", "#"}, blockComments: cBlock, quotes: []byte{'"', '\''}},
+	".re":    {lineComments: cStyle, blockComments: cBlock, quotes: []byte{'"'}},
+	".rs":    {lineComments: cStyle, blockComments: cBlock, quotes: []byte{'"'}},
+	".scala": {lineComments: cStyle, blockComments: cBlock, quotes: []byte{'"'}},
+	".swift": {lineComments: cStyle, blockComments: cBlock, quotes: []byte{'"'}},
+	".ts":    {lineComments: cStyle, blockComments: cBlock, quotes: []byte{'"', '\''}, rawQuotes: []byte{'`'}},
+
+	".ex":  {lineComments: []string{"#"}, quotes: []byte{'"', '\''}},
+	".jl":  {lineComments: []string{"#"}, quotes: []byte{'"'}},
+	".nim": {lineComments: []string{"#"}, quotes: []byte{'"'}},
+	".py":  {lineComments: []string{"#"}, quotes: []byte{'"', '\''}},
+	".rb":  {lineComments: []string{"#"}, quotes: []byte{'"', '\''}},
+	".sh":  {lineComments: []string{"#"}, quotes: []byte{'"'}, rawQuotes: []byte{'\''}},
+
+	".elm": {lineComments: []string{"--"}, blockComments: [][2]string{{"{-", "-}"}}, quotes: []byte{'"'}},
+	".hs":  {lineComments: []string{"--"}, blockComments: [][2]string{{"{-", "-}"}}, quotes: []byte{'"'}},
+	".sql": {lineComments: []string{"--"}, blockComments: cBlock, quotes: []byte{'"', '\''}},
+
+	".fsx": {lineComments: cStyle, blockComments: mlBlock, quotes: []byte{'"'}},
+	".ml":  {blockComments: mlBlock, quotes: []byte{'"'}},
+	".pas": {lineComments: cStyle, blockComments: mlBlock, quotes: []byte{'\''}},
+
+	".clj":  {lineComments: []string{";"}, quotes: []byte{'"'}},
+	".lisp": {lineComments: []string{";"}, quotes: []byte{'"'}},
+	".s":    {lineComments: []string{";", "#"}, quotes: []byte{'"'}},
+
+	".bib": {lineComments: []string{"%"}},
+	".erl": {lineComments: []string{"%"}, quotes: []byte{'"'}},
+	".tex": {lineComments: []string{"%"}},
+
+	".f": {lineComments: []string{"!"}, quotes: []byte{'"', '\''}},
+
+	".html": {blockComments: [][2]string{{"<!--", "-->"}}, quotes: []byte{'"'}},
+	".xml":  {blockComments: [][2]string{{"<!--", "-->"}}, quotes: []byte{'"'}},
+}
+
+// syntaxForMatcher returns the syntax for a -matcher value like ".go". It
+// falls back to the generic syntax for unknown matchers, like comby does.
+func syntaxForMatcher(matcher string) *syntax {
+	if s, ok := syntaxes[strings.ToLower(matcher)]; ok {
+		return s
+	}
+	return syntaxes[".generic"]
+}
+
+// region is the lexical context of a byte in the source.
+type region uint8
+
+const (
+	code region = iota
+	comment
+	stringLiteral
+)
+
+// source is a file prepared for matching. For every offset it records the
+// region, where comments and string literals starting there end, and where
+// delimiters opening there are closed.
+type source struct {
+	buf     []byte
+	regions []region
+	// skip is the end offset of the comment or string literal starting at
+	// an offset, or 0.
+	skip []int32
+	// closing is the offset of the delimiter closing the delimiter opened at
+	// an offset, -1 if the delimiter is unbalanced, or 0 if the offset does
+	// not open a delimiter.
+	closing []int32
+}
+
+func newSource(buf []byte, syn *syntax) *source {
+	s := &source{
+		buf:     buf,
+		regions: make([]region, len(buf)),
+		skip:    make([]int32, len(buf)),
+		closing: make([]int32, len(buf)),
+	}
+
+	var open []int
+	for i := 0; i < len(buf); {
+		if end, ok := syn.commentAt(buf, i); ok {
+			s.mark(i, end, comment)
+			i = end
+			continue
+		}
+		if end, ok := syn.stringAt(buf, i); ok {
+			s.mark(i, end, stringLiteral)
+			i = end
+			continue
+		}
+
+		if _, ok := opening(buf[i]); ok {
+			s.closing[i] = -1
+			open = append(open, i)
+		} else if o, ok := closing(buf[i]); ok && len(open) > 0 && buf[open[len(open)-1]] == o {
+			s.closing[open[len(open)-1]] = int32(i)
+			open = open[:len(open)-1]
+		}
+		i++
+	}
+	return s
+}
+
+// mark records a comment or string literal spanning [start, end).
+func (s *source) mark(start, end int, r region) {
+	s.skip[start] = int32(end)
+	for i := start + 1; i < end; i++ {
+		s.regions[i] = r
+	}
+}
+
+// next returns the end of the syntactic unit starting at i: a comment or
+// string literal, a balanced group of delimiters, or a single byte. It returns
+// false at the end of the input, at an unbalanced delimiter, and at a
+// closing delimiter, which holes must not consume.
+func (s *source) next(i int) (int, bool) {
+	if i >= len(s.buf) {
+		return 0, false
+	}
+	if s.regions[i] != code {
+		return i + 1, true
+	}
+	if end := s.skip[i]; end > 0 {
+		return int(end), true
+	}
+	if c := s.closing[i]; c != 0 {
+		return int(c) + 1, c > 0
+	}
+	if _, ok := closing(s.buf[i]); ok {
+		return 0, false
+	}
+	return i + 1, true
+}
+
+func (syn *syntax) commentAt(buf []byte, i int) (int, bool) {
+	for _, prefix := range syn.lineComments {
+		if hasPrefixAt(buf, i, prefix) {
+			if n := indexByte(buf, i, '\n'); n >= 0 {
+				return n, true
+			}
+			return len(buf), true
+		}
+	}
+	for _, block := range syn.blockComments {
+		if hasPrefixAt(buf, i, block[0]) {
+			if n := bytes.Index(buf[i+len(block[0]):], []byte(block[1])); n >= 0 {
+				return i + len(block[0]) + n + len(block[1]), true
+			}
+			return len(buf), true
+		}
+	}
+	return 0, false
+}
+
+// stringAt returns the end of the string literal starting at i. Unterminated
+// quotes, like apostrophes in prose, are not string literals.
+func (syn *syntax) stringAt(buf []byte, i int) (int, bool) {
+	c := buf[i]
+	for _, q := range syn.quotes {
+		if c != q {
+			continue
+		}
+		for j := i + 1; j < len(buf); j++ {
+			switch buf[j] {
+			case '\\':
+				j++
+			case '\n':
+				return 0, false
+			case q:
+				return j + 1, true
+			}
+		}
+		return 0, false
+	}
+	for _, q := range syn.rawQuotes {
+		if c != q {
+			continue
+		}
+		if n := indexByte(buf, i+1, q); n >= 0 {
+			return n + 1, true
+		}
+		return 0, false
+	}
+	return 0, false
+}
+
+func opening(c byte) (byte, bool) {
+	for _, d := range delimiters {
+		if d[0] == c {
+			return d[1], true
+		}
+	}
+	return 0, false
+}
+
+func closing(c byte) (byte, bool) {
+	for _, d := range delimiters {
+		if d[1] == c {
+			return d[0], true
+		}
+	}
+	return 0, false
+}
+
+func hasPrefixAt(buf []byte, i int, prefix string) bool {
+	return len(buf)-i >= len(prefix) && string(buf[i:i+len(prefix)]) == prefix
+}
+
+// indexByte returns the offset of the first c in buf at or after from, or -1.
+func indexByte(buf []byte, from int, c byte) int {
+	if n := bytes.IndexByte(buf[from:], c); n >= 0 {
+		return from + n
+	}
+	return -1
+}
@@ -0,0 +1,162 @@
+package comby
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type elementKind int
+
+const (
+	// literalElement matches its text exactly.
+	literalElement elementKind = iota
+	// spaceElement matches one or more whitespace characters.
+	spaceElement
+	// holeElement matches according to its holeKind and binds the match to
+	// its name.
+	holeElement
+)
+
+type holeKind int
+
+const (
+	// holeEverything is :[x] or ..., which lazily matches anything within
+	// balanced delimiters.
+	holeEverything holeKind = iota
+	// holeAlphanum is :[[x]], which matches one or more word characters.
+	holeAlphanum
+	// holeNonSpace is :[x.], which matches one or more characters that are
+	// neither whitespace nor delimiters.
+	holeNonSpace
+	// holeLine is :[x\n], which matches up to and including a newline.
+	holeLine
+	// holeSpace is :[ x], which matches one or more whitespace characters
+	// other than newlines.
+	holeSpace
+	// holeRegexp is :[x~regexp], which matches a regular expression.
+	holeRegexp
+)
+
+type element struct {
+	kind    elementKind
+	literal string
+
+	hole holeKind
+	name string
+	re   *regexp.Regexp
+}
+
+// anonymous returns true for holes which do not bind their match, and thus
+// may match different content in the same template.
+func (e element) anonymous() bool {
+	return e.name == "" || e.name == "_"
+}
+
+var (
+	alphanumHole = regexp.MustCompile(`^:\[\[(\w+)\]\]$`)
+	regexpHole   = regexp.MustCompile(`(?s)^:\[(\w*)~(.*)\]$`)
+	spaceHole    = regexp.MustCompile(`^:\[ +(\w*)\]$`)
+	nonSpaceHole = regexp.MustCompile(`^:\[(\w+)\.\]$`)
+	lineHole     = regexp.MustCompile(`^:\[(\w+)\\n\]$`)
+	anythingHole = regexp.MustCompile(`^:\[(\w+)\]$`)
+)
+
+// compileTemplate compiles a match template into the sequence of elements
+// the matcher walks.
+func compileTemplate(template string) ([]element, error) {
+	var elements []element
+	for _, term := range parseTemplate([]byte(template)) {
+		switch t := term.(type) {
+		case Hole:
+			e, ok, err := compileHole(string(t))
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				elements = append(elements, e)
+				continue
+			}
+			// Not a hole after all, comby matches it literally.
+			elements = appendLiterals(elements, string(t))
+		case Literal:
+			for i, part := range strings.Split(string(t), "...") {
+				if i > 0 {
+					elements = append(elements, element{kind: holeElement, hole: holeEverything})
+				}
+				elements = appendLiterals(elements, part)
+			}
+		}
+	}
+	return elements, nil
+}
+
+func compileHole(s string) (element, bool, error) {
+	e := element{kind: holeElement}
+	if m := alphanumHole.FindStringSubmatch(s); m != nil {
+		e.hole, e.name = holeAlphanum, m[1]
+	} else if m := regexpHole.FindStringSubmatch(s); m != nil {
+		re, err := regexp.Compile(`^(?:` + m[2] + `)`)
+		if err != nil {
+			return e, false, errors.Wrapf(err, "invalid regular expression in hole %s", s)
+		}
+		e.hole, e.name, e.re = holeRegexp, m[1], re
+	} else if m := spaceHole.FindStringSubmatch(s); m != nil {
+		e.hole, e.name = holeSpace, m[1]
+	} else if m := nonSpaceHole.FindStringSubmatch(s); m != nil {
+		e.hole, e.name = holeNonSpace, m[1]
+	} else if m := lineHole.FindStringSubmatch(s); m != nil {
+		e.hole, e.name = holeLine, m[1]
+	} else if m := anythingHole.FindStringSubmatch(s); m != nil {
+		e.hole, e.name = holeEverything, m[1]
+	} else {
+		return e, false, nil
+	}
+	return e, true, nil
+}
+
+// appendLiterals appends the literal text s, where runs of whitespace become
+// space elements.
+func appendLiterals(elements []element, s string) []element {
+	for len(s) > 0 {
+		i := strings.IndexFunc(s, unicode.IsSpace)
+		if i < 0 {
+			i = len(s)
+		}
+		if i > 0 {
+			if n := len(elements); n > 0 && elements[n-1].kind == literalElement {
+				elements[n-1].literal += s[:i]
+			} else {
+				elements = append(elements, element{kind: literalElement, literal: s[:i]})
+			}
+			s = s[i:]
+			continue
+		}
+		s = strings.TrimLeftFunc(s, unicode.IsSpace)
+		if n := len(elements); n == 0 || elements[n-1].kind != spaceElement {
+			elements = append(elements, element{kind: spaceElement})
+		}
+	}
+	return elements
+}
+
+// substitute replaces the holes in a rewrite template with the values bound
+// in env. Holes without a binding are kept as is.
+func substitute(template string, env environment) string {
+	var b strings.Builder
+	for _, term := range parseTemplate([]byte(template)) {
+		if h, ok := term.(Hole); ok {
+			if e, ok, _ := compileHole(string(h)); ok {
+				if v, ok := env.lookup(e.name); ok {
+					b.WriteString(v)
+					continue
+				}
+			}
+		}
+		b.WriteString(term.String())
+	}
+	return b.String()
+}
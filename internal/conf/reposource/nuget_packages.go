@@ -0,0 +1,93 @@
+package reposource
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const nugetPackagesPrefix = "nuget/"
+
+type NuGetVersionedPackage struct {
+	Name    PackageName
+	Version string
+}
+
+func NewNuGetVersionedPackage(name PackageName, version string) *NuGetVersionedPackage {
+	return &NuGetVersionedPackage{
+		Name:    name,
+		Version: version,
+	}
+}
+
+// ParseNuGetVersionedPackage parses a string in a '<id>(@version>)?' format into an
+// NuGetVersionedPackage.
+func ParseNuGetVersionedPackage(dependency string) (*NuGetVersionedPackage, error) {
+	var dep NuGetVersionedPackage
+	if i := strings.LastIndex(dependency, "@"); i == -1 {
+		dep.Name = PackageName(strings.TrimSpace(dependency))
+	} else {
+		dep.Name = PackageName(strings.TrimSpace(dependency[:i]))
+		dep.Version = strings.TrimSpace(dependency[i+1:])
+	}
+	if dep.Name == "" {
+		return nil, errors.Newf("invalid NuGet dependency %q, missing package ID", dependency)
+	}
+	return &dep, nil
+}
+
+func ParseNuGetPackageFromName(name PackageName) (*NuGetVersionedPackage, error) {
+	return ParseNuGetVersionedPackage(string(name))
+}
+
+// ParseNuGetPackageFromRepoName is a convenience function to parse a repo name in a
+// 'nuget/<id>(@<version>)?' format into a NuGetVersionedPackage.
+func ParseNuGetPackageFromRepoName(name api.RepoName) (*NuGetVersionedPackage, error) {
+	dependency := strings.TrimPrefix(string(name), nugetPackagesPrefix)
+	if len(dependency) == len(name) {
+		return nil, errors.Newf("invalid NuGet dependency repo name, missing %s prefix '%s'", nugetPackagesPrefix, name)
+	}
+	return ParseNuGetVersionedPackage(dependency)
+}
+
+func (p *NuGetVersionedPackage) Scheme() string {
+	return "scip-dotnet"
+}
+
+func (p *NuGetVersionedPackage) PackageSyntax() PackageName {
+	return p.Name
+}
+
+func (p *NuGetVersionedPackage) VersionedPackageSyntax() string {
+	if p.Version == "" {
+		return string(p.Name)
+	}
+	return string(p.Name) + "@" + p.Version
+}
+
+func (p *NuGetVersionedPackage) PackageVersion() string {
+	return p.Version
+}
+
+func (p *NuGetVersionedPackage) Description() string { return "" }
+
+func (p *NuGetVersionedPackage) RepoName() api.RepoName {
+	return api.RepoName(nugetPackagesPrefix + p.Name)
+}
+
+func (p *NuGetVersionedPackage) GitTagFromVersion() string {
+	version := strings.TrimPrefix(p.Version, "v")
+	return "v" + version
+}
+
+func (p *NuGetVersionedPackage) Less(other VersionedPackage) bool {
+	o := other.(*NuGetVersionedPackage)
+
+	// NuGet package IDs are case-insensitive.
+	if name, otherName := strings.ToLower(string(p.Name)), strings.ToLower(string(o.Name)); name != otherName {
+		return name > otherName
+	}
+
+	return versionGreaterThan(p.Version, o.Version)
+}
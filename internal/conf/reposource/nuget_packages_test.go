@@ -0,0 +1,63 @@
+package reposource
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestParseNuGetVersionedPackage(t *testing.T) {
+	for input, want := range map[string]*NuGetVersionedPackage{
+		"Newtonsoft.Json@13.0.1":       {Name: "Newtonsoft.Json", Version: "13.0.1"},
+		"Serilog@3.0.0-dev-01998":      {Name: "Serilog", Version: "3.0.0-dev-01998"},
+		" Contoso.Internal.Client@2.1": {Name: "Contoso.Internal.Client", Version: "2.1"},
+		"Dapper":                       {Name: "Dapper"},
+	} {
+		t.Run(input, func(t *testing.T) {
+			have, err := ParseNuGetVersionedPackage(input)
+			require.NoError(t, err)
+			assert.Equal(t, want, have)
+		})
+	}
+
+	_, err := ParseNuGetVersionedPackage("@1.0.0")
+	assert.Error(t, err)
+}
+
+func TestParseNuGetPackageFromRepoName(t *testing.T) {
+	pkg, err := ParseNuGetPackageFromRepoName("nuget/Newtonsoft.Json")
+	require.NoError(t, err)
+	assert.Equal(t, PackageName("Newtonsoft.Json"), pkg.Name)
+	assert.Equal(t, api.RepoName("nuget/Newtonsoft.Json"), pkg.RepoName())
+
+	_, err = ParseNuGetPackageFromRepoName("rubygems/rails")
+	assert.Error(t, err)
+}
+
+func TestNuGetVersionedPackage_Less(t *testing.T) {
+	parse := func(s string) VersionedPackage {
+		pkg, err := ParseNuGetVersionedPackage(s)
+		require.NoError(t, err)
+		return pkg
+	}
+
+	have := []VersionedPackage{
+		parse("Dapper@2.0.123"),
+		parse("newtonsoft.json@12.0.3"),
+		parse("Newtonsoft.Json@13.0.1"),
+		parse("Dapper@2.0.90"),
+	}
+	sort.Slice(have, func(i, j int) bool { return have[i].Less(have[j]) })
+
+	want := []VersionedPackage{
+		parse("Newtonsoft.Json@13.0.1"),
+		parse("newtonsoft.json@12.0.3"),
+		parse("Dapper@2.0.123"),
+		parse("Dapper@2.0.90"),
+	}
+	assert.Equal(t, want, have)
+}
@@ -240,6 +240,28 @@ func TestRedactSecrets_AuthProvidersSectionNotAdded(t *testing.T) {
 	assert.Equal(t, want, redacted.Site)
 }
 
+func TestRedactSecrets_LDAPBindPassword(t *testing.T) {
+	const site = `{
+  "auth.providers": [
+    {
+      "type": "ldap",
+      "url": "ldap://ldap.example.com",
+      "bindDN": "cn=sourcegraph,dc=example,dc=com",
+      "bindPassword": "s3cret",
+      "userBaseDN": "ou=people,dc=example,dc=com"
+    }
+  ]
+}`
+	redacted, err := RedactSecrets(conftypes.RawUnified{Site: site})
+	require.NoError(t, err)
+	assert.NotContains(t, redacted.Site, "s3cret")
+	assert.Contains(t, redacted.Site, `"bindPassword": "REDACTED"`)
+
+	unredacted, err := UnredactSecrets(redacted.Site, conftypes.RawUnified{Site: site})
+	require.NoError(t, err)
+	assert.Contains(t, unredacted, `"bindPassword": "s3cret"`)
+}
+
 func TestUnredactSecrets(t *testing.T) {
 	previousSite := getTestSiteWithSecrets(
 		executorsAccessToken,
@@ -32,6 +32,9 @@ type AccessToken struct {
 	Internal   bool
 	CreatedAt  time.Time
 	LastUsedAt *time.Time
+	// ExpiresAt is the time after which the token can no longer be used. It
+	// is nil if the token never expires.
+	ExpiresAt *time.Time
 }
 
 // ErrAccessTokenNotFound occurs when a database operation expects a specific access token to exist
@@ -68,9 +71,11 @@ type AccessTokenStore interface {
 	// space; also bcrypt is slow and would add noticeable latency to each request that supplied a
 	// token.
 	//
+	// If expiresAt is non-nil, the token can no longer be used after that time.
+	//
 	// 🚨 SECURITY: The caller must ensure that the actor is permitted to create tokens for the
 	// specified user (i.e., that the actor is either the user or a site admin).
-	Create(ctx context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32) (id int64, token string, err error)
+	Create(ctx context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32, expiresAt *time.Time) (id int64, token string, err error)
 
 	// CreateInternal creates an *internal* access token for the specified user. An
 	// internal access token will be used by Sourcegraph to talk to its API from
@@ -118,9 +123,17 @@ type AccessTokenStore interface {
 	// Calling Lookup also updates the access token's last-used-at date.
 	//
 	// 🚨 SECURITY: This returns a user ID if and only if the tokenHexEncoded corresponds to a valid,
-	// non-deleted access token.
+	// non-deleted, non-expired access token.
 	Lookup(ctx context.Context, tokenHexEncoded, requiredScope string) (subjectUserID int32, err error)
 
+	// LookupAnyScope looks up the access token like Lookup, but does not require a particular
+	// scope. It returns the subject's user ID and the scopes of the token.
+	//
+	// Calling LookupAnyScope also updates the access token's last-used-at date.
+	//
+	// 🚨 SECURITY: The caller must ensure that the returned scopes permit the requested action.
+	LookupAnyScope(ctx context.Context, tokenHexEncoded string) (subjectUserID int32, scopes []string, err error)
+
 	Transact(context.Context) (AccessTokenStore, error)
 	With(basestore.ShareableStore) AccessTokenStore
 	basestore.ShareableStore
@@ -147,15 +160,15 @@ func (s *accessTokenStore) Transact(ctx context.Context) (AccessTokenStore, erro
 	return &accessTokenStore{Store: txBase, logger: s.logger}, err
 }
 
-func (s *accessTokenStore) Create(ctx context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32) (id int64, token string, err error) {
-	return s.createToken(ctx, subjectUserID, scopes, note, creatorUserID, false)
+func (s *accessTokenStore) Create(ctx context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32, expiresAt *time.Time) (id int64, token string, err error) {
+	return s.createToken(ctx, subjectUserID, scopes, note, creatorUserID, false, expiresAt)
 }
 
 func (s *accessTokenStore) CreateInternal(ctx context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32) (id int64, token string, err error) {
-	return s.createToken(ctx, subjectUserID, scopes, note, creatorUserID, true)
+	return s.createToken(ctx, subjectUserID, scopes, note, creatorUserID, true, nil)
 }
 
-func (s *accessTokenStore) createToken(ctx context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32, internal bool) (id int64, token string, err error) {
+func (s *accessTokenStore) createToken(ctx context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32, internal bool, expiresAt *time.Time) (id int64, token string, err error) {
 	var b [20]byte
 	if _, err := rand.Read(b[:]); err != nil {
 		return 0, "", err
@@ -179,12 +192,12 @@ creator_user AS (
   SELECT id FROM users WHERE id=$5 AND deleted_at IS NULL FOR UPDATE
 ),
 insert_values AS (
-  SELECT subject_user.id AS subject_user_id, $2::text[] AS scopes, $3::bytea AS value_sha256, $4::text AS note, creator_user.id AS creator_user_id, $6::boolean AS internal
+  SELECT subject_user.id AS subject_user_id, $2::text[] AS scopes, $3::bytea AS value_sha256, $4::text AS note, creator_user.id AS creator_user_id, $6::boolean AS internal, $7::timestamp with time zone AS expires_at
   FROM subject_user, creator_user
 )
-INSERT INTO access_tokens(subject_user_id, scopes, value_sha256, note, creator_user_id, internal) SELECT * FROM insert_values RETURNING id
+INSERT INTO access_tokens(subject_user_id, scopes, value_sha256, note, creator_user_id, internal, expires_at) SELECT * FROM insert_values RETURNING id
 `,
-		subjectUserID, pq.Array(scopes), toSHA256Bytes(b[:]), note, creatorUserID, internal,
+		subjectUserID, pq.Array(scopes), toSHA256Bytes(b[:]), note, creatorUserID, internal, expiresAt,
 	).Scan(&id); err != nil {
 		return 0, "", err
 	}
@@ -192,15 +205,17 @@ INSERT INTO access_tokens(subject_user_id, scopes, value_sha256, note, creator_u
 	// only log access tokens created by users
 	if !internal {
 		arg, err := json.Marshal(struct {
-			SubjectUserId int32    `json:"subject_user_id"`
-			CreatorUserId int32    `json:"creator_user_id"`
-			Scopes        []string `json:"scopes"`
-			Note          string   `json:"note"`
+			SubjectUserId int32      `json:"subject_user_id"`
+			CreatorUserId int32      `json:"creator_user_id"`
+			Scopes        []string   `json:"scopes"`
+			Note          string     `json:"note"`
+			ExpiresAt     *time.Time `json:"expires_at,omitempty"`
 		}{
 			SubjectUserId: subjectUserID,
 			CreatorUserId: creatorUserID,
 			Scopes:        scopes,
 			Note:          note,
+			ExpiresAt:     expiresAt,
 		})
 		if err != nil {
 			s.logger.Error("failed to marshall the access token log argument")
@@ -229,7 +244,23 @@ func (s *accessTokenStore) Lookup(ctx context.Context, tokenHexEncoded, required
 		return 0, errors.Wrap(err, "AccessTokens.Lookup")
 	}
 
-	if err := s.Handle().QueryRowContext(ctx,
+	subjectUserID, _, err = s.lookup(ctx, token, sqlf.Sprintf("%s = ANY (t2.scopes)", requiredScope))
+	return subjectUserID, err
+}
+
+func (s *accessTokenStore) LookupAnyScope(ctx context.Context, tokenHexEncoded string) (subjectUserID int32, scopes []string, err error) {
+	token, err := decodeToken(tokenHexEncoded)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "AccessTokens.LookupAnyScope")
+	}
+
+	return s.lookup(ctx, token, sqlf.Sprintf("TRUE"))
+}
+
+// lookup returns the subject user ID and scopes of the valid access token that
+// matches scopeCond.
+func (s *accessTokenStore) lookup(ctx context.Context, token []byte, scopeCond *sqlf.Query) (subjectUserID int32, scopes []string, err error) {
+	q := sqlf.Sprintf(
 		// Ensure that subject and creator users still exist.
 		`
 UPDATE access_tokens t SET last_used_at=now()
@@ -237,19 +268,21 @@ WHERE t.id IN (
 	SELECT t2.id FROM access_tokens t2
 	JOIN users subject_user ON t2.subject_user_id=subject_user.id AND subject_user.deleted_at IS NULL
 	JOIN users creator_user ON t2.creator_user_id=creator_user.id AND creator_user.deleted_at IS NULL
-	WHERE t2.value_sha256=$1 AND t2.deleted_at IS NULL AND
-	$2 = ANY (t2.scopes)
+	WHERE t2.value_sha256=%s AND t2.deleted_at IS NULL AND
+	(t2.expires_at IS NULL OR t2.expires_at > now()) AND
+	%s
 )
-RETURNING t.subject_user_id
+RETURNING t.subject_user_id, t.scopes
 `,
-		toSHA256Bytes(token), requiredScope,
-	).Scan(&subjectUserID); err != nil {
+		toSHA256Bytes(token), scopeCond,
+	)
+	if err := s.QueryRow(ctx, q).Scan(&subjectUserID, pq.Array(&scopes)); err != nil {
 		if err == sql.ErrNoRows {
-			return 0, ErrAccessTokenNotFound
+			return 0, nil, ErrAccessTokenNotFound
 		}
-		return 0, err
+		return 0, nil, err
 	}
-	return subjectUserID, nil
+	return subjectUserID, scopes, nil
 }
 
 func (s *accessTokenStore) GetByID(ctx context.Context, id int64) (*AccessToken, error) {
@@ -308,7 +341,7 @@ func (s *accessTokenStore) List(ctx context.Context, opt AccessTokensListOptions
 
 func (s *accessTokenStore) list(ctx context.Context, conds []*sqlf.Query, limitOffset *LimitOffset) ([]*AccessToken, error) {
 	q := sqlf.Sprintf(`
-SELECT id, subject_user_id, scopes, note, creator_user_id, internal, created_at, last_used_at FROM access_tokens
+SELECT id, subject_user_id, scopes, note, creator_user_id, internal, created_at, last_used_at, expires_at FROM access_tokens
 WHERE (%s)
 ORDER BY now() - created_at < interval '5 minutes' DESC, -- show recently created tokens first
 last_used_at DESC NULLS FIRST, -- ensure newly created tokens show first
@@ -327,7 +360,7 @@ created_at DESC
 	var results []*AccessToken
 	for rows.Next() {
 		var t AccessToken
-		if err := rows.Scan(&t.ID, &t.SubjectUserID, pq.Array(&t.Scopes), &t.Note, &t.CreatorUserID, &t.Internal, &t.CreatedAt, &t.LastUsedAt); err != nil {
+		if err := rows.Scan(&t.ID, &t.SubjectUserID, pq.Array(&t.Scopes), &t.Note, &t.CreatorUserID, &t.Internal, &t.CreatedAt, &t.LastUsedAt, &t.ExpiresAt); err != nil {
 			return nil, err
 		}
 		results = append(results, &t)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/sourcegraph/log/logtest"
 	"github.com/stretchr/testify/assert"
@@ -41,7 +42,7 @@ func TestAccessTokens_Create(t *testing.T) {
 	}
 
 	assertSecurityEventCount(t, db, SecurityEventAccessTokenCreated, 0)
-	tid0, tv0, err := db.AccessTokens().Create(ctx, subject.ID, []string{"a", "b"}, "n0", creator.ID)
+	tid0, tv0, err := db.AccessTokens().Create(ctx, subject.ID, []string{"a", "b"}, "n0", creator.ID, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -121,15 +122,15 @@ func TestAccessTokens_Delete(t *testing.T) {
 	subjectActor := actor.FromUser(subject.ID)
 	ctxWithActor := actor.WithActor(context.Background(), subjectActor)
 
-	tid0, _, err := db.AccessTokens().Create(ctxWithActor, subject.ID, []string{"a", "b"}, "n0", creator.ID)
+	tid0, _, err := db.AccessTokens().Create(ctxWithActor, subject.ID, []string{"a", "b"}, "n0", creator.ID, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, tv1, err := db.AccessTokens().Create(ctxWithActor, subject.ID, []string{"a", "b"}, "n0", creator.ID)
+	_, tv1, err := db.AccessTokens().Create(ctxWithActor, subject.ID, []string{"a", "b"}, "n0", creator.ID, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
-	tid2, _, err := db.AccessTokens().Create(ctxWithActor, subject.ID, []string{"a", "b"}, "n0", creator.ID)
+	tid2, _, err := db.AccessTokens().Create(ctxWithActor, subject.ID, []string{"a", "b"}, "n0", creator.ID, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -228,11 +229,11 @@ func TestAccessTokens_List(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	_, _, err = db.AccessTokens().Create(ctx, subject1.ID, []string{"a", "b"}, "n0", subject1.ID)
+	_, _, err = db.AccessTokens().Create(ctx, subject1.ID, []string{"a", "b"}, "n0", subject1.ID, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, _, err = db.AccessTokens().Create(ctx, subject1.ID, []string{"a", "b"}, "n1", subject1.ID)
+	_, _, err = db.AccessTokens().Create(ctx, subject1.ID, []string{"a", "b"}, "n1", subject1.ID, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -309,7 +310,7 @@ func TestAccessTokens_Lookup(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	tid0, tv0, err := db.AccessTokens().Create(ctx, subject.ID, []string{"a", "b"}, "n0", creator.ID)
+	tid0, tv0, err := db.AccessTokens().Create(ctx, subject.ID, []string{"a", "b"}, "n0", creator.ID, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -348,6 +349,61 @@ func TestAccessTokens_Lookup(t *testing.T) {
 	}
 }
 
+// 🚨 SECURITY: This tests that expired access tokens can no longer be used.
+func TestAccessTokens_Lookup_expired(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	logger := logtest.Scoped(t)
+	t.Parallel()
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	subject, err := db.Users().Create(ctx, NewUser{
+		Email:                 "u1@example.com",
+		Username:              "u1",
+		Password:              "p1",
+		EmailVerificationCode: "c1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	past := time.Now().Add(-time.Hour)
+	_, expired, err := db.AccessTokens().Create(ctx, subject.ID, []string{"a"}, "expired", subject.ID, &past)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.AccessTokens().Lookup(ctx, expired, "a"); err != ErrAccessTokenNotFound {
+		t.Fatalf("got err %v, want %v", err, ErrAccessTokenNotFound)
+	}
+	if _, _, err := db.AccessTokens().LookupAnyScope(ctx, expired); err != ErrAccessTokenNotFound {
+		t.Fatalf("got err %v, want %v", err, ErrAccessTokenNotFound)
+	}
+
+	future := time.Now().Add(time.Hour)
+	tid, valid, err := db.AccessTokens().Create(ctx, subject.ID, []string{"a", "b"}, "valid", subject.ID, &future)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotSubjectUserID, gotScopes, err := db.AccessTokens().LookupAnyScope(ctx, valid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotSubjectUserID != subject.ID {
+		t.Errorf("got subject %d, want %d", gotSubjectUserID, subject.ID)
+	}
+	assert.Equal(t, []string{"a", "b"}, gotScopes)
+
+	token, err := db.AccessTokens().GetByID(ctx, tid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.ExpiresAt == nil || !token.ExpiresAt.Equal(future.Truncate(time.Microsecond)) {
+		t.Errorf("got expiration %v, want %v", token.ExpiresAt, future)
+	}
+}
+
 // 🚨 SECURITY: This tests that deleting the subject or creator user of an access token invalidates
 // the token, and that no new access tokens may be created for deleted users.
 func TestAccessTokens_Lookup_deletedUser(t *testing.T) {
@@ -379,7 +435,7 @@ func TestAccessTokens_Lookup_deletedUser(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		_, tv0, err := db.AccessTokens().Create(ctx, subject.ID, []string{"a"}, "n0", creator.ID)
+		_, tv0, err := db.AccessTokens().Create(ctx, subject.ID, []string{"a"}, "n0", creator.ID, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -390,7 +446,7 @@ func TestAccessTokens_Lookup_deletedUser(t *testing.T) {
 			t.Fatal("Lookup: want error looking up token for deleted subject user")
 		}
 
-		if _, _, err := db.AccessTokens().Create(ctx, subject.ID, nil, "n0", creator.ID); err == nil {
+		if _, _, err := db.AccessTokens().Create(ctx, subject.ID, nil, "n0", creator.ID, nil); err == nil {
 			t.Fatal("Create: want error creating token for deleted subject user")
 		}
 	})
@@ -415,7 +471,7 @@ func TestAccessTokens_Lookup_deletedUser(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		_, tv0, err := db.AccessTokens().Create(ctx, subject.ID, []string{"a"}, "n0", creator.ID)
+		_, tv0, err := db.AccessTokens().Create(ctx, subject.ID, []string{"a"}, "n0", creator.ID, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -426,7 +482,7 @@ func TestAccessTokens_Lookup_deletedUser(t *testing.T) {
 			t.Fatal("Lookup: want error looking up token for deleted creator user")
 		}
 
-		if _, _, err := db.AccessTokens().Create(ctx, subject.ID, nil, "n0", creator.ID); err == nil {
+		if _, _, err := db.AccessTokens().Create(ctx, subject.ID, nil, "n0", creator.ID, nil); err == nil {
 			t.Fatal("Create: want error creating token for deleted creator user")
 		}
 	})
@@ -26,6 +26,7 @@ type DB interface {
 	Conf() ConfStore
 	EventLogs() EventLogStore
 	SecurityEventLogs() SecurityEventLogsStore
+	ExhaustiveSearchJobs() ExhaustiveSearchJobStore
 	ExternalServices() ExternalServiceStore
 	FeatureFlags() FeatureFlagStore
 	GitserverRepos() GitserverRepoStore
@@ -36,9 +37,14 @@ type DB interface {
 	OrgMembers() OrgMemberStore
 	Orgs() OrgStore
 	OrgStats() OrgStatsStore
+	OutboundWebhooks(encryption.Key) OutboundWebhookStore
+	OutboundWebhookJobs(encryption.Key) OutboundWebhookJobStore
+	OutboundWebhookLogs(encryption.Key) OutboundWebhookLogStore
+	PathRanks() PathRankStore
 	Phabricator() PhabricatorStore
 	Repos() RepoStore
 	RepoKVPs() RepoKVPStore
+	Roles() RoleStore
 	SavedSearches() SavedSearchStore
 	SearchContexts() SearchContextsStore
 	Settings() SettingsStore
@@ -125,6 +131,10 @@ func (d *db) SecurityEventLogs() SecurityEventLogsStore {
 	return SecurityEventLogsWith(d.logger, d.Store)
 }
 
+func (d *db) ExhaustiveSearchJobs() ExhaustiveSearchJobStore {
+	return ExhaustiveSearchJobsWith(d.Store)
+}
+
 func (d *db) ExternalServices() ExternalServiceStore {
 	return ExternalServicesWith(d.logger, d.Store)
 }
@@ -165,6 +175,22 @@ func (d *db) OrgStats() OrgStatsStore {
 	return OrgStatsWith(d.Store)
 }
 
+func (d *db) OutboundWebhooks(key encryption.Key) OutboundWebhookStore {
+	return OutboundWebhooksWith(d.Store, key)
+}
+
+func (d *db) OutboundWebhookJobs(key encryption.Key) OutboundWebhookJobStore {
+	return OutboundWebhookJobsWith(d.Store, key)
+}
+
+func (d *db) OutboundWebhookLogs(key encryption.Key) OutboundWebhookLogStore {
+	return OutboundWebhookLogsWith(d.Store, key)
+}
+
+func (d *db) PathRanks() PathRankStore {
+	return PathRanksWith(d.Store)
+}
+
 func (d *db) Phabricator() PhabricatorStore {
 	return PhabricatorWith(d.Store)
 }
@@ -177,6 +203,10 @@ func (d *db) RepoKVPs() RepoKVPStore {
 	return &repoKVPStore{d.Store}
 }
 
+func (d *db) Roles() RoleStore {
+	return RolesWith(d.Store)
+}
+
 func (d *db) SavedSearches() SavedSearchStore {
 	return SavedSearchesWith(d.Store)
 }
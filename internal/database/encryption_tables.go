@@ -27,6 +27,7 @@ var EncryptionConfigs = []EncryptionConfig{
 	batchChangesSiteCredentialsEncryptionConfig,
 	webhooklogsEncryptionConfig,
 	executorSecretsEncryptionConfig,
+	outboundWebhooksEncryptionConfig,
 }
 
 var externalServicesEncryptionConfig = EncryptionConfig{
@@ -92,6 +93,17 @@ var executorSecretsEncryptionConfig = EncryptionConfig{
 	Limit:               5,
 }
 
+var outboundWebhooksEncryptionConfig = EncryptionConfig{
+	TableName:           "outbound_webhooks",
+	IDFieldName:         "id",
+	KeyIDFieldName:      "encryption_key_id",
+	EncryptedFieldNames: []string{"url", "secret"},
+	UpdateAsBytes:       true,
+	Scan:                basestore.NewMapScanner(scanEncryptedByteaPair),
+	Key:                 func() encryption.Key { return keyring.Default().OutboundWebhookKey },
+	Limit:               5,
+}
+
 func scanEncryptedString(scanner dbutil.Scanner) (id int, e Encrypted, err error) {
 	e.Values = make([]string, 1)
 	err = scanner.Scan(&id, &e.KeyID, &e.Values[0])
@@ -110,3 +122,10 @@ func scanEncryptedBytea(scanner dbutil.Scanner) (id int, e Encrypted, err error)
 	e.Values = []string{string(bs)}
 	return
 }
+
+func scanEncryptedByteaPair(scanner dbutil.Scanner) (id int, e Encrypted, err error) {
+	var first, second []byte
+	err = scanner.Scan(&id, &e.KeyID, &first, &second)
+	e.Values = []string{string(first), string(second)}
+	return
+}
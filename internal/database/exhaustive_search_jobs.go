@@ -0,0 +1,308 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+// ExhaustiveSearchJobNotFoundErr is returned when an exhaustive search job
+// cannot be found.
+type ExhaustiveSearchJobNotFoundErr struct {
+	id int64
+}
+
+func (err ExhaustiveSearchJobNotFoundErr) Error() string {
+	return fmt.Sprintf("exhaustive search job not found: id=%d", err.id)
+}
+
+func (ExhaustiveSearchJobNotFoundErr) NotFound() bool {
+	return true
+}
+
+// ExhaustiveSearchJobStore provides access to the `exhaustive_search_jobs`
+// table, which is the queue of search queries to be run in the background.
+type ExhaustiveSearchJobStore interface {
+	basestore.ShareableStore
+	With(basestore.ShareableStore) ExhaustiveSearchJobStore
+	Transact(context.Context) (ExhaustiveSearchJobStore, error)
+	Done(error) error
+
+	// Create queues a new job for the given query on behalf of the initiator.
+	Create(ctx context.Context, initiatorID int32, query, format string) (*types.ExhaustiveSearchJob, error)
+	// GetByID returns the job matching the given ID, or
+	// ExhaustiveSearchJobNotFoundErr if no such job exists.
+	GetByID(ctx context.Context, id int64) (*types.ExhaustiveSearchJob, error)
+	// List returns the jobs matching the given options, most recent first.
+	List(ctx context.Context, opts ExhaustiveSearchJobsListOptions) ([]*types.ExhaustiveSearchJob, error)
+	// Count returns the number of jobs matching the given options.
+	Count(ctx context.Context, opts ExhaustiveSearchJobsListOptions) (int, error)
+	// Cancel cancels the job with the given ID. Queued jobs are canceled
+	// straight away, while processing jobs are stopped by the worker. It is a
+	// no-op if the job has already finished.
+	Cancel(ctx context.Context, id int64) error
+	// Delete deletes the job with the given ID, or returns
+	// ExhaustiveSearchJobNotFoundErr if no such job exists.
+	Delete(ctx context.Context, id int64) error
+	// DeleteFinishedBefore deletes all jobs which finished before the given
+	// time, and returns the result keys of the deleted jobs.
+	DeleteFinishedBefore(ctx context.Context, before time.Time) ([]string, error)
+
+	// UpdateProgress records the progress of a running job.
+	UpdateProgress(ctx context.Context, id int64, matchCount, repositoriesSearched int, limitHit bool) error
+	// SetResult records the location and size of the results of a job.
+	SetResult(ctx context.Context, id int64, key string, size int64) error
+}
+
+// ExhaustiveSearchJobsListOptions provide the options when listing or counting
+// exhaustive search jobs.
+type ExhaustiveSearchJobsListOptions struct {
+	// InitiatorID, if set, limits the returned jobs to those created by the
+	// user.
+	InitiatorID int32
+	*LimitOffset
+}
+
+func (opts ExhaustiveSearchJobsListOptions) sqlConds() *sqlf.Query {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.InitiatorID != 0 {
+		preds = append(preds, sqlf.Sprintf("initiator_id = %s", opts.InitiatorID))
+	}
+	return sqlf.Join(preds, " AND ")
+}
+
+type exhaustiveSearchJobStore struct {
+	*basestore.Store
+}
+
+var _ ExhaustiveSearchJobStore = &exhaustiveSearchJobStore{}
+
+// ExhaustiveSearchJobsWith instantiates and returns a new ExhaustiveSearchJobStore using the other store handle.
+func ExhaustiveSearchJobsWith(other basestore.ShareableStore) ExhaustiveSearchJobStore {
+	return &exhaustiveSearchJobStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (s *exhaustiveSearchJobStore) With(other basestore.ShareableStore) ExhaustiveSearchJobStore {
+	return &exhaustiveSearchJobStore{Store: s.Store.With(other)}
+}
+
+func (s *exhaustiveSearchJobStore) Transact(ctx context.Context) (ExhaustiveSearchJobStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &exhaustiveSearchJobStore{Store: txBase}, err
+}
+
+func (s *exhaustiveSearchJobStore) Create(ctx context.Context, initiatorID int32, query, format string) (*types.ExhaustiveSearchJob, error) {
+	q := sqlf.Sprintf(
+		exhaustiveSearchJobCreateQueryFmtstr,
+		initiatorID,
+		query,
+		format,
+		sqlf.Join(ExhaustiveSearchJobColumns, ", "),
+	)
+	return ScanExhaustiveSearchJob(s.QueryRow(ctx, q))
+}
+
+const exhaustiveSearchJobCreateQueryFmtstr = `
+INSERT INTO exhaustive_search_jobs (initiator_id, query, format)
+VALUES (%s, %s, %s)
+RETURNING %s
+`
+
+func (s *exhaustiveSearchJobStore) GetByID(ctx context.Context, id int64) (*types.ExhaustiveSearchJob, error) {
+	q := sqlf.Sprintf(
+		"SELECT %s FROM exhaustive_search_jobs WHERE id = %s",
+		sqlf.Join(ExhaustiveSearchJobColumns, ", "),
+		id,
+	)
+
+	job, err := ScanExhaustiveSearchJob(s.QueryRow(ctx, q))
+	if err == sql.ErrNoRows {
+		return nil, ExhaustiveSearchJobNotFoundErr{id: id}
+	} else if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+var scanExhaustiveSearchJobs = basestore.NewSliceScanner(ScanExhaustiveSearchJob)
+
+func (s *exhaustiveSearchJobStore) List(ctx context.Context, opts ExhaustiveSearchJobsListOptions) ([]*types.ExhaustiveSearchJob, error) {
+	return scanExhaustiveSearchJobs(s.Query(ctx, sqlf.Sprintf(
+		exhaustiveSearchJobListQueryFmtstr,
+		sqlf.Join(ExhaustiveSearchJobColumns, ", "),
+		opts.sqlConds(),
+		opts.LimitOffset.SQL(),
+	)))
+}
+
+const exhaustiveSearchJobListQueryFmtstr = `
+SELECT %s
+FROM exhaustive_search_jobs
+WHERE %s
+ORDER BY id DESC
+%s
+`
+
+func (s *exhaustiveSearchJobStore) Count(ctx context.Context, opts ExhaustiveSearchJobsListOptions) (int, error) {
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(
+		"SELECT COUNT(*) FROM exhaustive_search_jobs WHERE %s",
+		opts.sqlConds(),
+	)))
+	return count, err
+}
+
+func (s *exhaustiveSearchJobStore) Cancel(ctx context.Context, id int64) error {
+	return s.Exec(ctx, sqlf.Sprintf(exhaustiveSearchJobCancelQueryFmtstr, time.Now(), id))
+}
+
+const exhaustiveSearchJobCancelQueryFmtstr = `
+UPDATE
+	exhaustive_search_jobs
+SET
+	cancel = TRUE,
+	-- If the job is still queued, we directly abort, otherwise we keep the
+	-- state, so the worker can stop the search and mark it failed itself.
+	state = CASE WHEN exhaustive_search_jobs.state = 'processing' THEN exhaustive_search_jobs.state ELSE 'canceled' END,
+	finished_at = CASE WHEN exhaustive_search_jobs.state = 'processing' THEN exhaustive_search_jobs.finished_at ELSE %s END,
+	updated_at = NOW()
+WHERE
+	id = %s
+	AND state IN ('queued', 'processing', 'errored')
+	AND cancel IS FALSE
+`
+
+func (s *exhaustiveSearchJobStore) Delete(ctx context.Context, id int64) error {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf("DELETE FROM exhaustive_search_jobs WHERE id = %s", id))
+	if err != nil {
+		return err
+	}
+	if rows, err := res.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		return ExhaustiveSearchJobNotFoundErr{id: id}
+	}
+	return nil
+}
+
+func (s *exhaustiveSearchJobStore) DeleteFinishedBefore(ctx context.Context, before time.Time) ([]string, error) {
+	return basestore.ScanStrings(s.Query(ctx, sqlf.Sprintf(exhaustiveSearchJobDeleteFinishedBeforeQueryFmtstr, before)))
+}
+
+const exhaustiveSearchJobDeleteFinishedBeforeQueryFmtstr = `
+WITH deleted AS (
+	DELETE FROM
+		exhaustive_search_jobs
+	WHERE
+		state IN ('completed', 'failed', 'canceled')
+		AND finished_at <= %s
+	RETURNING result_key
+)
+SELECT result_key FROM deleted WHERE result_key IS NOT NULL
+`
+
+func (s *exhaustiveSearchJobStore) UpdateProgress(ctx context.Context, id int64, matchCount, repositoriesSearched int, limitHit bool) error {
+	return s.Exec(ctx, sqlf.Sprintf(
+		exhaustiveSearchJobUpdateProgressQueryFmtstr,
+		matchCount,
+		repositoriesSearched,
+		limitHit,
+		id,
+	))
+}
+
+const exhaustiveSearchJobUpdateProgressQueryFmtstr = `
+UPDATE exhaustive_search_jobs
+SET match_count = %s, repositories_searched = %s, limit_hit = %s, updated_at = NOW()
+WHERE id = %s
+`
+
+func (s *exhaustiveSearchJobStore) SetResult(ctx context.Context, id int64, key string, size int64) error {
+	return s.Exec(ctx, sqlf.Sprintf(
+		"UPDATE exhaustive_search_jobs SET result_key = %s, result_size = %s, updated_at = NOW() WHERE id = %s",
+		key,
+		size,
+		id,
+	))
+}
+
+// ExhaustiveSearchJobColumns are the columns of the `exhaustive_search_jobs`
+// table, in the order expected by ScanExhaustiveSearchJob.
+var ExhaustiveSearchJobColumns = []*sqlf.Query{
+	sqlf.Sprintf("exhaustive_search_jobs.id"),
+	sqlf.Sprintf("exhaustive_search_jobs.initiator_id"),
+	sqlf.Sprintf("exhaustive_search_jobs.query"),
+	sqlf.Sprintf("exhaustive_search_jobs.format"),
+	sqlf.Sprintf("exhaustive_search_jobs.created_at"),
+	sqlf.Sprintf("exhaustive_search_jobs.updated_at"),
+	sqlf.Sprintf("exhaustive_search_jobs.match_count"),
+	sqlf.Sprintf("exhaustive_search_jobs.repositories_searched"),
+	sqlf.Sprintf("exhaustive_search_jobs.limit_hit"),
+	sqlf.Sprintf("exhaustive_search_jobs.result_key"),
+	sqlf.Sprintf("exhaustive_search_jobs.result_size"),
+	sqlf.Sprintf("exhaustive_search_jobs.state"),
+	sqlf.Sprintf("exhaustive_search_jobs.failure_message"),
+	sqlf.Sprintf("exhaustive_search_jobs.queued_at"),
+	sqlf.Sprintf("exhaustive_search_jobs.started_at"),
+	sqlf.Sprintf("exhaustive_search_jobs.finished_at"),
+	sqlf.Sprintf("exhaustive_search_jobs.process_after"),
+	sqlf.Sprintf("exhaustive_search_jobs.num_resets"),
+	sqlf.Sprintf("exhaustive_search_jobs.num_failures"),
+	sqlf.Sprintf("exhaustive_search_jobs.last_heartbeat_at"),
+	sqlf.Sprintf("exhaustive_search_jobs.execution_logs"),
+	sqlf.Sprintf("exhaustive_search_jobs.worker_hostname"),
+	sqlf.Sprintf("exhaustive_search_jobs.cancel"),
+}
+
+// ScanExhaustiveSearchJob scans a row selected with ExhaustiveSearchJobColumns
+// into a job.
+func ScanExhaustiveSearchJob(sc dbutil.Scanner) (*types.ExhaustiveSearchJob, error) {
+	var (
+		job           types.ExhaustiveSearchJob
+		executionLogs []dbworkerstore.ExecutionLogEntry
+	)
+
+	if err := sc.Scan(
+		&job.ID,
+		&job.InitiatorID,
+		&job.Query,
+		&job.Format,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+		&job.MatchCount,
+		&job.RepositoriesSearched,
+		&job.LimitHit,
+		&job.ResultKey,
+		&job.ResultSize,
+		&job.State,
+		&job.FailureMessage,
+		&job.QueuedAt,
+		&job.StartedAt,
+		&job.FinishedAt,
+		&job.ProcessAfter,
+		&job.NumResets,
+		&job.NumFailures,
+		&job.LastHeartbeatAt,
+		pq.Array(&executionLogs),
+		&job.WorkerHostname,
+		&job.Cancel,
+	); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range executionLogs {
+		job.ExecutionLogs = append(job.ExecutionLogs, workerutil.ExecutionLogEntry(entry))
+	}
+
+	return &job, nil
+}
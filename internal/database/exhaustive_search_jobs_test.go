@@ -0,0 +1,105 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func TestExhaustiveSearchJobs(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+	store := db.ExhaustiveSearchJobs()
+
+	alice, err := db.Users().Create(ctx, NewUser{Username: "alice"})
+	require.NoError(t, err)
+	bob, err := db.Users().Create(ctx, NewUser{Username: "bob"})
+	require.NoError(t, err)
+
+	first, err := store.Create(ctx, alice.ID, "foo count:all", "csv")
+	require.NoError(t, err)
+	assert.Equal(t, "queued", first.State)
+	assert.Equal(t, alice.ID, first.InitiatorID)
+	assert.Equal(t, "foo count:all", first.Query)
+	assert.Equal(t, "csv", first.Format)
+	assert.Nil(t, first.ResultKey)
+
+	second, err := store.Create(ctx, alice.ID, "bar", "jsonl")
+	require.NoError(t, err)
+	third, err := store.Create(ctx, bob.ID, "baz", "jsonl")
+	require.NoError(t, err)
+
+	t.Run("list and count", func(t *testing.T) {
+		got, err := store.List(ctx, ExhaustiveSearchJobsListOptions{InitiatorID: alice.ID})
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, second.ID, got[0].ID)
+		assert.Equal(t, first.ID, got[1].ID)
+
+		got, err = store.List(ctx, ExhaustiveSearchJobsListOptions{LimitOffset: &LimitOffset{Limit: 1}})
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, third.ID, got[0].ID)
+
+		count, err := store.Count(ctx, ExhaustiveSearchJobsListOptions{InitiatorID: alice.ID})
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("progress and result", func(t *testing.T) {
+		require.NoError(t, store.UpdateProgress(ctx, first.ID, 42, 7, true))
+		require.NoError(t, store.SetResult(ctx, first.ID, "search-jobs/1.csv", 1024))
+
+		got, err := store.GetByID(ctx, first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 42, got.MatchCount)
+		assert.Equal(t, 7, got.RepositoriesSearched)
+		assert.True(t, got.LimitHit)
+		require.NotNil(t, got.ResultKey)
+		assert.Equal(t, "search-jobs/1.csv", *got.ResultKey)
+		require.NotNil(t, got.ResultSize)
+		assert.EqualValues(t, 1024, *got.ResultSize)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		require.NoError(t, store.Cancel(ctx, second.ID))
+		got, err := store.GetByID(ctx, second.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "canceled", got.State)
+		assert.True(t, got.Cancel)
+		assert.NotNil(t, got.FinishedAt)
+	})
+
+	t.Run("delete finished before", func(t *testing.T) {
+		finishedAt := time.Now().Add(-48 * time.Hour)
+		_, err := db.ExecContext(ctx,
+			"UPDATE exhaustive_search_jobs SET state = 'completed', finished_at = $1 WHERE id = $2",
+			finishedAt, first.ID,
+		)
+		require.NoError(t, err)
+
+		keys, err := store.DeleteFinishedBefore(ctx, time.Now().Add(-24*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"search-jobs/1.csv"}, keys)
+
+		_, err = store.GetByID(ctx, first.ID)
+		assert.True(t, errcode.IsNotFound(err))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, store.Delete(ctx, third.ID))
+		assert.True(t, errcode.IsNotFound(store.Delete(ctx, third.ID)))
+	})
+}
@@ -0,0 +1,236 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// OutboundWebhookJobNotFoundErr is returned when an outbound webhook job cannot
+// be found.
+type OutboundWebhookJobNotFoundErr struct {
+	id int64
+}
+
+func (err OutboundWebhookJobNotFoundErr) Error() string {
+	return fmt.Sprintf("outbound webhook job not found: id=%d", err.id)
+}
+
+func (OutboundWebhookJobNotFoundErr) NotFound() bool {
+	return true
+}
+
+// OutboundWebhookJobStore provides access to the `outbound_webhook_jobs` table,
+// which is the queue of payloads to be delivered to outbound webhooks.
+type OutboundWebhookJobStore interface {
+	basestore.ShareableStore
+	With(basestore.ShareableStore) OutboundWebhookJobStore
+	Transact(context.Context) (OutboundWebhookJobStore, error)
+	Done(error) error
+
+	// Enqueue creates a job to deliver the given payload to each outbound
+	// webhook subscribed to the event type, either unscoped or with the given
+	// scope. The number of jobs created is returned.
+	Enqueue(ctx context.Context, eventType string, scope *string, payload []byte) (int, error)
+	// GetByID returns the job matching the given ID, or
+	// OutboundWebhookJobNotFoundErr if no such job exists.
+	GetByID(ctx context.Context, id int64) (*types.OutboundWebhookJob, error)
+	// DeleteBefore deletes all completed or failed jobs, along with their
+	// logs, that finished before the given time.
+	DeleteBefore(ctx context.Context, before time.Time) error
+}
+
+type outboundWebhookJobStore struct {
+	*basestore.Store
+	key encryption.Key
+}
+
+var _ OutboundWebhookJobStore = &outboundWebhookJobStore{}
+
+// OutboundWebhookJobsWith instantiates and returns a new OutboundWebhookJobStore using the other store handle.
+func OutboundWebhookJobsWith(other basestore.ShareableStore, key encryption.Key) OutboundWebhookJobStore {
+	return &outboundWebhookJobStore{
+		Store: basestore.NewWithHandle(other.Handle()),
+		key:   key,
+	}
+}
+
+func (s *outboundWebhookJobStore) With(other basestore.ShareableStore) OutboundWebhookJobStore {
+	return &outboundWebhookJobStore{
+		Store: s.Store.With(other),
+		key:   s.key,
+	}
+}
+
+func (s *outboundWebhookJobStore) Transact(ctx context.Context) (OutboundWebhookJobStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &outboundWebhookJobStore{
+		Store: txBase,
+		key:   s.key,
+	}, err
+}
+
+func (s *outboundWebhookJobStore) Enqueue(ctx context.Context, eventType string, scope *string, payload []byte) (int, error) {
+	// Most events will not have any subscribers, so check that there is at
+	// least one before encrypting the payload, since that may involve a call
+	// to an external KMS.
+	exists, _, err := basestore.ScanFirstBool(s.Query(ctx, sqlf.Sprintf(
+		outboundWebhookJobSubscribersExistQueryFmtstr,
+		outboundWebhookJobSubscriptionConds(eventType, scope),
+	)))
+	if err != nil {
+		return 0, errors.Wrap(err, "checking for subscribers")
+	}
+	if !exists {
+		return 0, nil
+	}
+
+	encrypted, keyID, err := encryption.MaybeEncrypt(ctx, s.key, string(payload))
+	if err != nil {
+		return 0, errors.Wrap(err, "encrypting payload")
+	}
+
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(
+		outboundWebhookJobEnqueueQueryFmtstr,
+		eventType,
+		scope,
+		keyID,
+		[]byte(encrypted),
+		outboundWebhookJobSubscriptionConds(eventType, scope),
+	)))
+	if err != nil {
+		return 0, errors.Wrap(err, "enqueuing jobs")
+	}
+
+	return count, nil
+}
+
+func outboundWebhookJobSubscriptionConds(eventType string, scope *string) *sqlf.Query {
+	if scope == nil {
+		return sqlf.Sprintf("event_type = %s AND scope IS NULL", eventType)
+	}
+	return sqlf.Sprintf("event_type = %s AND (scope IS NULL OR scope = %s)", eventType, *scope)
+}
+
+const outboundWebhookJobSubscribersExistQueryFmtstr = `
+SELECT EXISTS (SELECT 1 FROM outbound_webhook_event_types WHERE %s)
+`
+
+const outboundWebhookJobEnqueueQueryFmtstr = `
+WITH inserted AS (
+	INSERT INTO outbound_webhook_jobs (outbound_webhook_id, event_type, scope, encryption_key_id, payload)
+	SELECT DISTINCT outbound_webhook_id, %s, %s, %s, %s
+	FROM outbound_webhook_event_types
+	WHERE %s
+	RETURNING 1
+)
+SELECT COUNT(*) FROM inserted
+`
+
+func (s *outboundWebhookJobStore) GetByID(ctx context.Context, id int64) (*types.OutboundWebhookJob, error) {
+	q := sqlf.Sprintf(
+		"SELECT %s FROM outbound_webhook_jobs WHERE id = %s",
+		sqlf.Join(OutboundWebhookJobColumns, ", "),
+		id,
+	)
+
+	job, err := ScanOutboundWebhookJob(s.key)(s.QueryRow(ctx, q))
+	if err == sql.ErrNoRows {
+		return nil, OutboundWebhookJobNotFoundErr{id: id}
+	} else if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (s *outboundWebhookJobStore) DeleteBefore(ctx context.Context, before time.Time) error {
+	return s.Exec(ctx, sqlf.Sprintf(outboundWebhookJobDeleteBeforeQueryFmtstr, before))
+}
+
+const outboundWebhookJobDeleteBeforeQueryFmtstr = `
+DELETE FROM
+	outbound_webhook_jobs
+WHERE
+	state IN ('completed', 'failed')
+	AND finished_at <= %s
+`
+
+// OutboundWebhookJobColumns are the columns of the `outbound_webhook_jobs`
+// table, in the order expected by ScanOutboundWebhookJob.
+var OutboundWebhookJobColumns = []*sqlf.Query{
+	sqlf.Sprintf("outbound_webhook_jobs.id"),
+	sqlf.Sprintf("outbound_webhook_jobs.outbound_webhook_id"),
+	sqlf.Sprintf("outbound_webhook_jobs.event_type"),
+	sqlf.Sprintf("outbound_webhook_jobs.scope"),
+	sqlf.Sprintf("outbound_webhook_jobs.encryption_key_id"),
+	sqlf.Sprintf("outbound_webhook_jobs.payload"),
+	sqlf.Sprintf("outbound_webhook_jobs.state"),
+	sqlf.Sprintf("outbound_webhook_jobs.failure_message"),
+	sqlf.Sprintf("outbound_webhook_jobs.queued_at"),
+	sqlf.Sprintf("outbound_webhook_jobs.started_at"),
+	sqlf.Sprintf("outbound_webhook_jobs.finished_at"),
+	sqlf.Sprintf("outbound_webhook_jobs.process_after"),
+	sqlf.Sprintf("outbound_webhook_jobs.num_resets"),
+	sqlf.Sprintf("outbound_webhook_jobs.num_failures"),
+	sqlf.Sprintf("outbound_webhook_jobs.last_heartbeat_at"),
+	sqlf.Sprintf("outbound_webhook_jobs.execution_logs"),
+	sqlf.Sprintf("outbound_webhook_jobs.worker_hostname"),
+	sqlf.Sprintf("outbound_webhook_jobs.cancel"),
+}
+
+// ScanOutboundWebhookJob returns a function that scans a row selected with
+// OutboundWebhookJobColumns into a job. The payload of the job is decrypted
+// with the given key.
+func ScanOutboundWebhookJob(key encryption.Key) func(dbutil.Scanner) (*types.OutboundWebhookJob, error) {
+	return func(sc dbutil.Scanner) (*types.OutboundWebhookJob, error) {
+		var (
+			job           types.OutboundWebhookJob
+			keyID         string
+			payload       []byte
+			executionLogs []dbworkerstore.ExecutionLogEntry
+		)
+
+		if err := sc.Scan(
+			&job.ID,
+			&job.OutboundWebhookID,
+			&job.EventType,
+			&job.Scope,
+			&dbutil.NullString{S: &keyID},
+			&payload,
+			&job.State,
+			&job.FailureMessage,
+			&job.QueuedAt,
+			&job.StartedAt,
+			&job.FinishedAt,
+			&job.ProcessAfter,
+			&job.NumResets,
+			&job.NumFailures,
+			&job.LastHeartbeatAt,
+			pq.Array(&executionLogs),
+			&job.WorkerHostname,
+			&job.Cancel,
+		); err != nil {
+			return nil, err
+		}
+
+		job.Payload = encryption.NewEncrypted(string(payload), keyID, key)
+		for _, entry := range executionLogs {
+			job.ExecutionLogs = append(job.ExecutionLogs, workerutil.ExecutionLogEntry(entry))
+		}
+
+		return &job, nil
+	}
+}
@@ -0,0 +1,131 @@
+package database
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	et "github.com/sourcegraph/sourcegraph/internal/encryption/testing"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestOutboundWebhookJobStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	key := et.ByteaTestKey{}
+
+	user, err := db.Users().Create(ctx, NewUser{Username: "admin"})
+	require.NoError(t, err)
+
+	scope := "github.com/sourcegraph/sourcegraph"
+	unscoped := newTestOutboundWebhook(user.ID, "https://example.com/unscoped", types.OutboundWebhookEventType{
+		EventType: "repo:cloned",
+	})
+	require.NoError(t, db.OutboundWebhooks(key).Create(ctx, unscoped))
+	scoped := newTestOutboundWebhook(user.ID, "https://example.com/scoped",
+		types.OutboundWebhookEventType{EventType: "repo:cloned", Scope: &scope},
+		types.OutboundWebhookEventType{EventType: "batch_change:applied"},
+	)
+	require.NoError(t, db.OutboundWebhooks(key).Create(ctx, scoped))
+
+	store := db.OutboundWebhookJobs(key)
+
+	t.Run("Enqueue", func(t *testing.T) {
+		other := "github.com/sourcegraph/other"
+		for name, tc := range map[string]struct {
+			eventType string
+			scope     *string
+			want      int
+		}{
+			"no subscribers":      {eventType: "repo:deleted", want: 0},
+			"unscoped event":      {eventType: "repo:cloned", want: 1},
+			"matching scope":      {eventType: "repo:cloned", scope: &scope, want: 2},
+			"non-matching scope":  {eventType: "repo:cloned", scope: &other, want: 1},
+			"unscoped event type": {eventType: "batch_change:applied", want: 1},
+		} {
+			t.Run(name, func(t *testing.T) {
+				tx, err := store.Transact(ctx)
+				require.NoError(t, err)
+				defer func() { _ = tx.Done(errors.New("rollback")) }()
+
+				have, err := tx.Enqueue(ctx, tc.eventType, tc.scope, []byte(`{}`))
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, have)
+			})
+		}
+	})
+
+	t.Run("GetByID", func(t *testing.T) {
+		_, err := store.GetByID(ctx, 0)
+		assert.True(t, errcode.IsNotFound(err))
+
+		n, err := store.Enqueue(ctx, "batch_change:applied", nil, []byte(`{"eventType":"batch_change:applied"}`))
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+
+		var id int64
+		require.NoError(t, db.QueryRowContext(ctx, "SELECT id FROM outbound_webhook_jobs WHERE outbound_webhook_id = $1", scoped.ID).Scan(&id))
+
+		job, err := store.GetByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, scoped.ID, job.OutboundWebhookID)
+		assert.Equal(t, "queued", job.State)
+
+		payload, err := job.Payload.Decrypt(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, `{"eventType":"batch_change:applied"}`, payload)
+
+		t.Run("logs", func(t *testing.T) {
+			logs := db.OutboundWebhookLogs(key)
+			for _, code := range []int{types.OutboundWebhookLogUnsentStatusCode, http.StatusInternalServerError, http.StatusOK} {
+				require.NoError(t, logs.Create(ctx, &types.OutboundWebhookLog{
+					JobID:             job.ID,
+					OutboundWebhookID: scoped.ID,
+					StatusCode:        code,
+					Request:           types.NewUnencryptedWebhookLogMessage(types.WebhookLogMessage{}),
+					Response:          types.NewUnencryptedWebhookLogMessage(types.WebhookLogMessage{}),
+					Error:             encryption.NewUnencrypted(""),
+				}))
+			}
+
+			total, errored, err := logs.CountsForOutboundWebhook(ctx, scoped.ID)
+			require.NoError(t, err)
+			assert.Equal(t, 3, total)
+			assert.Equal(t, 2, errored)
+
+			have, err := logs.ListForOutboundWebhook(ctx, OutboundWebhookLogListOpts{
+				OutboundWebhookID: scoped.ID,
+				OnlyErrors:        true,
+			})
+			require.NoError(t, err)
+			require.Len(t, have, 2)
+			assert.Equal(t, http.StatusInternalServerError, have[0].StatusCode)
+		})
+
+		t.Run("DeleteBefore", func(t *testing.T) {
+			_, err := db.ExecContext(ctx, "UPDATE outbound_webhook_jobs SET state = 'completed', finished_at = $1 WHERE id = $2", time.Now().Add(-2*time.Hour), id)
+			require.NoError(t, err)
+
+			require.NoError(t, store.DeleteBefore(ctx, time.Now().Add(-1*time.Hour)))
+
+			_, err = store.GetByID(ctx, id)
+			assert.True(t, errcode.IsNotFound(err))
+
+			total, _, err := db.OutboundWebhookLogs(key).CountsForOutboundWebhook(ctx, scoped.ID)
+			require.NoError(t, err)
+			assert.Zero(t, total)
+		})
+	})
+}
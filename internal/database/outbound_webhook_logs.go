@@ -0,0 +1,202 @@
+package database
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// OutboundWebhookLogStore provides access to the `outbound_webhook_logs` table,
+// which records every attempt to deliver an outbound webhook job.
+type OutboundWebhookLogStore interface {
+	basestore.ShareableStore
+
+	// Create inserts the given log entry into the database.
+	Create(context.Context, *types.OutboundWebhookLog) error
+	// ListForOutboundWebhook returns the log entries of the given webhook,
+	// most recent first.
+	ListForOutboundWebhook(context.Context, OutboundWebhookLogListOpts) ([]*types.OutboundWebhookLog, error)
+	// CountsForOutboundWebhook returns the total number of log entries and the
+	// number of those entries that are errors for the given webhook.
+	CountsForOutboundWebhook(ctx context.Context, outboundWebhookID int64) (total, errored int, err error)
+}
+
+// OutboundWebhookLogListOpts provide the options when listing outbound webhook
+// logs.
+type OutboundWebhookLogListOpts struct {
+	*LimitOffset
+
+	OutboundWebhookID int64
+
+	// If set, only log entries for failed deliveries will be returned.
+	OnlyErrors bool
+}
+
+func (opts OutboundWebhookLogListOpts) sqlConds() *sqlf.Query {
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("outbound_webhook_id = %s", opts.OutboundWebhookID),
+	}
+	if opts.OnlyErrors {
+		preds = append(preds, outboundWebhookLogErrorCond)
+	}
+
+	return sqlf.Join(preds, " AND ")
+}
+
+// A delivery is considered failed if no response was received, or if the
+// response was not a 2XX.
+var outboundWebhookLogErrorCond = sqlf.Sprintf("status_code NOT BETWEEN 200 AND 299")
+
+type outboundWebhookLogStore struct {
+	*basestore.Store
+	key encryption.Key
+}
+
+var _ OutboundWebhookLogStore = &outboundWebhookLogStore{}
+
+// OutboundWebhookLogsWith instantiates and returns a new OutboundWebhookLogStore using the other store handle.
+func OutboundWebhookLogsWith(other basestore.ShareableStore, key encryption.Key) OutboundWebhookLogStore {
+	return &outboundWebhookLogStore{
+		Store: basestore.NewWithHandle(other.Handle()),
+		key:   key,
+	}
+}
+
+func (s *outboundWebhookLogStore) Create(ctx context.Context, log *types.OutboundWebhookLog) error {
+	rawRequest, _, err := log.Request.Encrypt(ctx, s.key)
+	if err != nil {
+		return errors.Wrap(err, "encrypting request")
+	}
+	rawResponse, _, err := log.Response.Encrypt(ctx, s.key)
+	if err != nil {
+		return errors.Wrap(err, "encrypting response")
+	}
+	rawError, keyID, err := log.Error.Encrypt(ctx, s.key)
+	if err != nil {
+		return errors.Wrap(err, "encrypting error")
+	}
+
+	q := sqlf.Sprintf(
+		outboundWebhookLogCreateQueryFmtstr,
+		log.JobID,
+		log.OutboundWebhookID,
+		log.StatusCode,
+		keyID,
+		[]byte(rawRequest),
+		[]byte(rawResponse),
+		[]byte(rawError),
+		sqlf.Join(outboundWebhookLogColumns, ", "),
+	)
+
+	if err := s.scanOutboundWebhookLog(log, s.QueryRow(ctx, q)); err != nil {
+		return errors.Wrap(err, "scanning outbound webhook log")
+	}
+
+	return nil
+}
+
+const outboundWebhookLogCreateQueryFmtstr = `
+INSERT INTO outbound_webhook_logs (
+	job_id,
+	outbound_webhook_id,
+	status_code,
+	encryption_key_id,
+	request,
+	response,
+	error
+)
+VALUES (%s, %s, %s, %s, %s, %s, %s)
+RETURNING %s
+`
+
+func (s *outboundWebhookLogStore) ListForOutboundWebhook(ctx context.Context, opts OutboundWebhookLogListOpts) ([]*types.OutboundWebhookLog, error) {
+	q := sqlf.Sprintf(
+		outboundWebhookLogListQueryFmtstr,
+		sqlf.Join(outboundWebhookLogColumns, ", "),
+		opts.sqlConds(),
+		opts.LimitOffset.SQL(),
+	)
+
+	return basestore.NewSliceScanner(func(sc dbutil.Scanner) (*types.OutboundWebhookLog, error) {
+		log := types.OutboundWebhookLog{}
+		err := s.scanOutboundWebhookLog(&log, sc)
+		return &log, err
+	})(s.Query(ctx, q))
+}
+
+const outboundWebhookLogListQueryFmtstr = `
+SELECT
+	%s
+FROM
+	outbound_webhook_logs
+WHERE
+	%s
+ORDER BY
+	id DESC
+%s -- LIMIT
+`
+
+func (s *outboundWebhookLogStore) CountsForOutboundWebhook(ctx context.Context, outboundWebhookID int64) (total, errored int, err error) {
+	q := sqlf.Sprintf(
+		outboundWebhookLogCountsQueryFmtstr,
+		outboundWebhookLogErrorCond,
+		outboundWebhookID,
+	)
+
+	err = s.QueryRow(ctx, q).Scan(&total, &errored)
+	return total, errored, err
+}
+
+const outboundWebhookLogCountsQueryFmtstr = `
+SELECT
+	COUNT(*) AS total,
+	COUNT(*) FILTER (WHERE %s) AS errored
+FROM
+	outbound_webhook_logs
+WHERE
+	outbound_webhook_id = %s
+`
+
+var outboundWebhookLogColumns = []*sqlf.Query{
+	sqlf.Sprintf("id"),
+	sqlf.Sprintf("job_id"),
+	sqlf.Sprintf("outbound_webhook_id"),
+	sqlf.Sprintf("sent_at"),
+	sqlf.Sprintf("status_code"),
+	sqlf.Sprintf("encryption_key_id"),
+	sqlf.Sprintf("request"),
+	sqlf.Sprintf("response"),
+	sqlf.Sprintf("error"),
+}
+
+func (s *outboundWebhookLogStore) scanOutboundWebhookLog(log *types.OutboundWebhookLog, sc dbutil.Scanner) error {
+	var (
+		keyID                      string
+		request, response, message []byte
+	)
+
+	if err := sc.Scan(
+		&log.ID,
+		&log.JobID,
+		&log.OutboundWebhookID,
+		&log.SentAt,
+		&log.StatusCode,
+		&dbutil.NullString{S: &keyID},
+		&request,
+		&response,
+		&message,
+	); err != nil {
+		return err
+	}
+
+	log.Request = types.NewEncryptedWebhookLogMessage(string(request), keyID, s.key)
+	log.Response = types.NewEncryptedWebhookLogMessage(string(response), keyID, s.key)
+	log.Error = encryption.NewEncrypted(string(message), keyID, s.key)
+	return nil
+}
@@ -0,0 +1,419 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// OutboundWebhookNotFoundErr is returned when an outbound webhook cannot be found.
+type OutboundWebhookNotFoundErr struct {
+	id int64
+}
+
+func (err OutboundWebhookNotFoundErr) Error() string {
+	return fmt.Sprintf("outbound webhook not found: id=%d", err.id)
+}
+
+func (OutboundWebhookNotFoundErr) NotFound() bool {
+	return true
+}
+
+// OutboundWebhookStore provides access to the `outbound_webhooks` and
+// `outbound_webhook_event_types` tables.
+type OutboundWebhookStore interface {
+	basestore.ShareableStore
+	With(basestore.ShareableStore) OutboundWebhookStore
+	Transact(context.Context) (OutboundWebhookStore, error)
+	Done(error) error
+
+	// Create inserts the given outbound webhook and its event types into the
+	// database.
+	Create(context.Context, *types.OutboundWebhook) error
+	// GetByID returns the outbound webhook matching the given ID, or
+	// OutboundWebhookNotFoundErr if no such webhook exists.
+	GetByID(context.Context, int64) (*types.OutboundWebhook, error)
+	// List returns all outbound webhooks matching the given options.
+	List(context.Context, OutboundWebhookListOpts) ([]*types.OutboundWebhook, error)
+	// Count counts all outbound webhooks matching the given options.
+	Count(context.Context, OutboundWebhookListOpts) (int, error)
+	// Update updates the given outbound webhook, replacing its event types.
+	Update(context.Context, *types.OutboundWebhook) error
+	// Delete deletes the outbound webhook with the given ID, along with any
+	// pending jobs and logs.
+	Delete(context.Context, int64) error
+}
+
+// OutboundWebhookListOpts provide the options when listing outbound webhooks.
+type OutboundWebhookListOpts struct {
+	*LimitOffset
+
+	// EventTypes, if set, limits the returned webhooks to those subscribed to
+	// at least one of the given event types.
+	EventTypes []string
+}
+
+func (opts OutboundWebhookListOpts) sqlConds() *sqlf.Query {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if len(opts.EventTypes) > 0 {
+		preds = append(preds, sqlf.Sprintf(
+			"EXISTS (SELECT 1 FROM outbound_webhook_event_types WHERE outbound_webhook_id = outbound_webhooks.id AND event_type = ANY(%s))",
+			pq.Array(opts.EventTypes),
+		))
+	}
+
+	return sqlf.Join(preds, " AND ")
+}
+
+type outboundWebhookStore struct {
+	*basestore.Store
+	key encryption.Key
+}
+
+var _ OutboundWebhookStore = &outboundWebhookStore{}
+
+// OutboundWebhooksWith instantiates and returns a new OutboundWebhookStore using the other store handle.
+func OutboundWebhooksWith(other basestore.ShareableStore, key encryption.Key) OutboundWebhookStore {
+	return &outboundWebhookStore{
+		Store: basestore.NewWithHandle(other.Handle()),
+		key:   key,
+	}
+}
+
+func (s *outboundWebhookStore) With(other basestore.ShareableStore) OutboundWebhookStore {
+	return &outboundWebhookStore{
+		Store: s.Store.With(other),
+		key:   s.key,
+	}
+}
+
+func (s *outboundWebhookStore) Transact(ctx context.Context) (OutboundWebhookStore, error) {
+	return s.transact(ctx)
+}
+
+func (s *outboundWebhookStore) transact(ctx context.Context) (*outboundWebhookStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &outboundWebhookStore{
+		Store: txBase,
+		key:   s.key,
+	}, err
+}
+
+var (
+	ErrEmptyOutboundWebhookURL        = errors.New("empty outbound webhook URL is not allowed")
+	ErrEmptyOutboundWebhookSecret     = errors.New("empty outbound webhook secret is not allowed")
+	ErrEmptyOutboundWebhookEventTypes = errors.New("outbound webhooks must subscribe to at least one event type")
+)
+
+func (s *outboundWebhookStore) Create(ctx context.Context, webhook *types.OutboundWebhook) (err error) {
+	url, secret, keyID, err := s.encryptWebhook(ctx, webhook)
+	if err != nil {
+		return err
+	}
+
+	// Set the current actor as the webhook creator if not set.
+	if webhook.CreatedBy == 0 {
+		webhook.CreatedBy = actor.FromContext(ctx).UID
+	}
+	webhook.UpdatedBy = webhook.CreatedBy
+
+	tx, err := s.transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	q := sqlf.Sprintf(
+		outboundWebhookCreateQueryFmtstr,
+		dbutil.NullInt32Column(webhook.CreatedBy),
+		dbutil.NullInt32Column(webhook.UpdatedBy),
+		keyID,
+		[]byte(url),
+		[]byte(secret),
+		sqlf.Join(outboundWebhookColumns, ", "),
+	)
+
+	if err := s.scanOutboundWebhook(webhook, tx.QueryRow(ctx, q)); err != nil {
+		return errors.Wrap(err, "scanning outbound webhook")
+	}
+
+	return tx.replaceEventTypes(ctx, webhook)
+}
+
+const outboundWebhookCreateQueryFmtstr = `
+INSERT INTO outbound_webhooks (
+	created_by,
+	updated_by,
+	encryption_key_id,
+	url,
+	secret
+)
+VALUES (%s, %s, %s, %s, %s)
+RETURNING %s
+`
+
+func (s *outboundWebhookStore) GetByID(ctx context.Context, id int64) (*types.OutboundWebhook, error) {
+	q := sqlf.Sprintf(
+		"SELECT %s FROM outbound_webhooks WHERE id = %s",
+		sqlf.Join(outboundWebhookColumns, ", "),
+		id,
+	)
+
+	webhook := types.OutboundWebhook{}
+	if err := s.scanOutboundWebhook(&webhook, s.QueryRow(ctx, q)); err == sql.ErrNoRows {
+		return nil, OutboundWebhookNotFoundErr{id: id}
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := s.loadEventTypes(ctx, []*types.OutboundWebhook{&webhook}); err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (s *outboundWebhookStore) List(ctx context.Context, opts OutboundWebhookListOpts) ([]*types.OutboundWebhook, error) {
+	q := sqlf.Sprintf(
+		outboundWebhookListQueryFmtstr,
+		sqlf.Join(outboundWebhookColumns, ", "),
+		opts.sqlConds(),
+		opts.LimitOffset.SQL(),
+	)
+
+	webhooks, err := basestore.NewSliceScanner(func(sc dbutil.Scanner) (*types.OutboundWebhook, error) {
+		webhook := types.OutboundWebhook{}
+		err := s.scanOutboundWebhook(&webhook, sc)
+		return &webhook, err
+	})(s.Query(ctx, q))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.loadEventTypes(ctx, webhooks); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+const outboundWebhookListQueryFmtstr = `
+SELECT
+	%s
+FROM
+	outbound_webhooks
+WHERE
+	%s
+ORDER BY
+	id ASC
+%s -- LIMIT
+`
+
+func (s *outboundWebhookStore) Count(ctx context.Context, opts OutboundWebhookListOpts) (int, error) {
+	q := sqlf.Sprintf("SELECT COUNT(*) FROM outbound_webhooks WHERE %s", opts.sqlConds())
+
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, q))
+	return count, err
+}
+
+func (s *outboundWebhookStore) Update(ctx context.Context, webhook *types.OutboundWebhook) (err error) {
+	url, secret, keyID, err := s.encryptWebhook(ctx, webhook)
+	if err != nil {
+		return err
+	}
+
+	if uid := actor.FromContext(ctx).UID; uid != 0 {
+		webhook.UpdatedBy = uid
+	}
+
+	tx, err := s.transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	q := sqlf.Sprintf(
+		outboundWebhookUpdateQueryFmtstr,
+		dbutil.NullInt32Column(webhook.UpdatedBy),
+		keyID,
+		[]byte(url),
+		[]byte(secret),
+		webhook.ID,
+		sqlf.Join(outboundWebhookColumns, ", "),
+	)
+
+	if err := s.scanOutboundWebhook(webhook, tx.QueryRow(ctx, q)); err == sql.ErrNoRows {
+		return OutboundWebhookNotFoundErr{id: webhook.ID}
+	} else if err != nil {
+		return errors.Wrap(err, "scanning outbound webhook")
+	}
+
+	return tx.replaceEventTypes(ctx, webhook)
+}
+
+const outboundWebhookUpdateQueryFmtstr = `
+UPDATE
+	outbound_webhooks
+SET
+	updated_by = %s,
+	updated_at = NOW(),
+	encryption_key_id = %s,
+	url = %s,
+	secret = %s
+WHERE
+	id = %s
+RETURNING %s
+`
+
+func (s *outboundWebhookStore) Delete(ctx context.Context, id int64) error {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf("DELETE FROM outbound_webhooks WHERE id = %s", id))
+	if err != nil {
+		return err
+	}
+
+	if rows, err := res.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		return OutboundWebhookNotFoundErr{id: id}
+	}
+
+	return nil
+}
+
+// replaceEventTypes replaces the event types stored for the given webhook with
+// the event types set on the webhook. It must be called within a transaction.
+func (s *outboundWebhookStore) replaceEventTypes(ctx context.Context, webhook *types.OutboundWebhook) error {
+	if err := s.Exec(ctx, sqlf.Sprintf("DELETE FROM outbound_webhook_event_types WHERE outbound_webhook_id = %s", webhook.ID)); err != nil {
+		return errors.Wrap(err, "deleting event types")
+	}
+
+	values := make([]*sqlf.Query, 0, len(webhook.EventTypes))
+	for _, eventType := range webhook.EventTypes {
+		values = append(values, sqlf.Sprintf("(%s, %s, %s)", webhook.ID, eventType.EventType, eventType.Scope))
+	}
+
+	rows, err := s.Query(ctx, sqlf.Sprintf(outboundWebhookEventTypesInsertQueryFmtstr, sqlf.Join(values, ", ")))
+	if err != nil {
+		return errors.Wrap(err, "inserting event types")
+	}
+
+	eventTypes, err := scanOutboundWebhookEventTypes(rows, nil)
+	if err != nil {
+		return errors.Wrap(err, "scanning event types")
+	}
+	webhook.EventTypes = eventTypes
+
+	return nil
+}
+
+const outboundWebhookEventTypesInsertQueryFmtstr = `
+INSERT INTO outbound_webhook_event_types (outbound_webhook_id, event_type, scope)
+VALUES %s
+RETURNING id, outbound_webhook_id, event_type, scope
+`
+
+// loadEventTypes populates the event types of the given webhooks.
+func (s *outboundWebhookStore) loadEventTypes(ctx context.Context, webhooks []*types.OutboundWebhook) error {
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(webhooks))
+	byID := make(map[int64]*types.OutboundWebhook, len(webhooks))
+	for _, webhook := range webhooks {
+		ids = append(ids, webhook.ID)
+		byID[webhook.ID] = webhook
+		webhook.EventTypes = []types.OutboundWebhookEventType{}
+	}
+
+	eventTypes, err := scanOutboundWebhookEventTypes(s.Query(ctx, sqlf.Sprintf(
+		"SELECT id, outbound_webhook_id, event_type, scope FROM outbound_webhook_event_types WHERE outbound_webhook_id = ANY(%s) ORDER BY id ASC",
+		pq.Array(ids),
+	)))
+	if err != nil {
+		return err
+	}
+
+	for _, eventType := range eventTypes {
+		webhook := byID[eventType.OutboundWebhookID]
+		webhook.EventTypes = append(webhook.EventTypes, eventType)
+	}
+
+	return nil
+}
+
+func (s *outboundWebhookStore) encryptWebhook(ctx context.Context, webhook *types.OutboundWebhook) (url, secret, keyID string, err error) {
+	if webhook.URL == nil {
+		return "", "", "", ErrEmptyOutboundWebhookURL
+	}
+	if webhook.Secret == nil {
+		return "", "", "", ErrEmptyOutboundWebhookSecret
+	}
+	if len(webhook.EventTypes) == 0 {
+		return "", "", "", ErrEmptyOutboundWebhookEventTypes
+	}
+
+	if url, _, err = webhook.URL.Encrypt(ctx, s.key); err != nil {
+		return "", "", "", errors.Wrap(err, "encrypting URL")
+	}
+	if secret, keyID, err = webhook.Secret.Encrypt(ctx, s.key); err != nil {
+		return "", "", "", errors.Wrap(err, "encrypting secret")
+	}
+
+	return url, secret, keyID, nil
+}
+
+var outboundWebhookColumns = []*sqlf.Query{
+	sqlf.Sprintf("id"),
+	sqlf.Sprintf("created_by"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("updated_by"),
+	sqlf.Sprintf("updated_at"),
+	sqlf.Sprintf("encryption_key_id"),
+	sqlf.Sprintf("url"),
+	sqlf.Sprintf("secret"),
+}
+
+func (s *outboundWebhookStore) scanOutboundWebhook(webhook *types.OutboundWebhook, sc dbutil.Scanner) error {
+	var (
+		keyID       string
+		url, secret []byte
+	)
+
+	if err := sc.Scan(
+		&webhook.ID,
+		&dbutil.NullInt32{N: &webhook.CreatedBy},
+		&webhook.CreatedAt,
+		&dbutil.NullInt32{N: &webhook.UpdatedBy},
+		&webhook.UpdatedAt,
+		&dbutil.NullString{S: &keyID},
+		&url,
+		&secret,
+	); err != nil {
+		return err
+	}
+
+	webhook.URL = encryption.NewEncrypted(string(url), keyID, s.key)
+	webhook.Secret = encryption.NewEncrypted(string(secret), keyID, s.key)
+	return nil
+}
+
+var scanOutboundWebhookEventTypes = basestore.NewSliceScanner(func(sc dbutil.Scanner) (eventType types.OutboundWebhookEventType, _ error) {
+	err := sc.Scan(
+		&eventType.ID,
+		&eventType.OutboundWebhookID,
+		&eventType.EventType,
+		&eventType.Scope,
+	)
+	return eventType, err
+})
@@ -0,0 +1,176 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	et "github.com/sourcegraph/sourcegraph/internal/encryption/testing"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestOutboundWebhookStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+
+	user, err := db.Users().Create(ctx, NewUser{Username: "admin"})
+	require.NoError(t, err)
+
+	for name, key := range map[string]encryption.Key{
+		"unencrypted": nil,
+		"encrypted":   et.ByteaTestKey{},
+	} {
+		key := key
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tx, err := db.Transact(ctx)
+			require.NoError(t, err)
+			defer func() { _ = tx.Done(errors.New("rollback")) }()
+
+			store := tx.OutboundWebhooks(key)
+
+			t.Run("Create", func(t *testing.T) {
+				for name, tc := range map[string]struct {
+					webhook *types.OutboundWebhook
+					want    error
+				}{
+					"no URL": {
+						webhook: &types.OutboundWebhook{
+							CreatedBy:  user.ID,
+							Secret:     encryption.NewUnencrypted("secret"),
+							EventTypes: []types.OutboundWebhookEventType{{EventType: "repo:cloned"}},
+						},
+						want: ErrEmptyOutboundWebhookURL,
+					},
+					"no event types": {
+						webhook: &types.OutboundWebhook{
+							CreatedBy: user.ID,
+							URL:       encryption.NewUnencrypted("https://example.com/"),
+							Secret:    encryption.NewUnencrypted("secret"),
+						},
+						want: ErrEmptyOutboundWebhookEventTypes,
+					},
+				} {
+					t.Run(name, func(t *testing.T) {
+						assert.ErrorIs(t, store.Create(ctx, tc.webhook), tc.want)
+					})
+				}
+			})
+
+			cloned := newTestOutboundWebhook(user.ID, "https://example.com/cloned", types.OutboundWebhookEventType{
+				EventType: "repo:cloned",
+			})
+			require.NoError(t, store.Create(ctx, cloned))
+			assert.NotZero(t, cloned.ID)
+			assert.NotZero(t, cloned.CreatedAt)
+			assert.Equal(t, user.ID, cloned.UpdatedBy)
+			require.Len(t, cloned.EventTypes, 1)
+			assert.Equal(t, cloned.ID, cloned.EventTypes[0].OutboundWebhookID)
+
+			scope := "github.com/sourcegraph/sourcegraph"
+			deleted := newTestOutboundWebhook(user.ID, "https://example.com/deleted", types.OutboundWebhookEventType{
+				EventType: "repo:deleted",
+				Scope:     &scope,
+			})
+			require.NoError(t, store.Create(ctx, deleted))
+
+			if key != nil {
+				// Check that the URL and secret aren't stored in plain text.
+				var url, secret []byte
+				require.NoError(t, tx.QueryRowContext(ctx, "SELECT url, secret FROM outbound_webhooks WHERE id = $1", cloned.ID).Scan(&url, &secret))
+				assert.NotEqual(t, "https://example.com/cloned", string(url))
+				assert.NotEqual(t, "secret", string(secret))
+			}
+
+			t.Run("GetByID", func(t *testing.T) {
+				t.Run("not found", func(t *testing.T) {
+					_, err := store.GetByID(ctx, 0)
+					assert.True(t, errcode.IsNotFound(err))
+				})
+
+				t.Run("found", func(t *testing.T) {
+					have, err := store.GetByID(ctx, deleted.ID)
+					require.NoError(t, err)
+					assertOutboundWebhookURL(t, "https://example.com/deleted", have)
+					assert.Equal(t, deleted.EventTypes, have.EventTypes)
+				})
+			})
+
+			t.Run("List", func(t *testing.T) {
+				all, err := store.List(ctx, OutboundWebhookListOpts{})
+				require.NoError(t, err)
+				require.Len(t, all, 2)
+
+				filtered, err := store.List(ctx, OutboundWebhookListOpts{EventTypes: []string{"repo:cloned"}})
+				require.NoError(t, err)
+				require.Len(t, filtered, 1)
+				assert.Equal(t, cloned.ID, filtered[0].ID)
+				assert.Equal(t, cloned.EventTypes, filtered[0].EventTypes)
+
+				paginated, err := store.List(ctx, OutboundWebhookListOpts{LimitOffset: &LimitOffset{Limit: 1, Offset: 1}})
+				require.NoError(t, err)
+				require.Len(t, paginated, 1)
+				assert.Equal(t, all[1].ID, paginated[0].ID)
+			})
+
+			t.Run("Count", func(t *testing.T) {
+				count, err := store.Count(ctx, OutboundWebhookListOpts{})
+				require.NoError(t, err)
+				assert.Equal(t, 2, count)
+
+				count, err = store.Count(ctx, OutboundWebhookListOpts{EventTypes: []string{"repo:deleted"}})
+				require.NoError(t, err)
+				assert.Equal(t, 1, count)
+			})
+
+			t.Run("Update", func(t *testing.T) {
+				cloned.URL = encryption.NewUnencrypted("https://example.com/updated")
+				cloned.EventTypes = []types.OutboundWebhookEventType{
+					{EventType: "repo:cloned"},
+					{EventType: "repo:deleted"},
+				}
+				require.NoError(t, store.Update(ctx, cloned))
+
+				have, err := store.GetByID(ctx, cloned.ID)
+				require.NoError(t, err)
+				assertOutboundWebhookURL(t, "https://example.com/updated", have)
+				assert.Len(t, have.EventTypes, 2)
+			})
+
+			t.Run("Delete", func(t *testing.T) {
+				require.NoError(t, store.Delete(ctx, deleted.ID))
+
+				_, err := store.GetByID(ctx, deleted.ID)
+				assert.True(t, errcode.IsNotFound(err))
+			})
+		})
+	}
+}
+
+func newTestOutboundWebhook(userID int32, url string, eventTypes ...types.OutboundWebhookEventType) *types.OutboundWebhook {
+	return &types.OutboundWebhook{
+		CreatedBy:  userID,
+		URL:        encryption.NewUnencrypted(url),
+		Secret:     encryption.NewUnencrypted("secret"),
+		EventTypes: eventTypes,
+	}
+}
+
+func assertOutboundWebhookURL(t *testing.T, want string, webhook *types.OutboundWebhook) {
+	t.Helper()
+
+	url, err := webhook.URL.Decrypt(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, want, url)
+}
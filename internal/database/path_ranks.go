@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// PathRankStore reads the document ranks of paths, which the code intelligence
+// ranking jobs compute from precise indexes and store in codeintel_path_ranks.
+type PathRankStore interface {
+	basestore.ShareableStore
+
+	With(other basestore.ShareableStore) PathRankStore
+
+	// GetPathRanks returns the ranks of the given paths, keyed by repository
+	// and path. Only ranks of the given graph key are read, and paths without
+	// a rank are omitted. Where ranks of different precision exist for a path,
+	// the rank with the lowest precision wins, like in the ranks served to
+	// Zoekt.
+	GetPathRanks(ctx context.Context, graphKey string, paths map[api.RepoID][]string) (map[api.RepoID]map[string]float64, error)
+}
+
+var _ PathRankStore = (*pathRankStore)(nil)
+
+// pathRankStore is responsible for data stored in the codeintel_path_ranks
+// table.
+type pathRankStore struct {
+	*basestore.Store
+}
+
+// PathRanksWith instantiates and returns a new pathRankStore using the other
+// store handle.
+func PathRanksWith(other basestore.ShareableStore) PathRankStore {
+	return &pathRankStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (s *pathRankStore) With(other basestore.ShareableStore) PathRankStore {
+	return &pathRankStore{Store: s.Store.With(other)}
+}
+
+func (s *pathRankStore) GetPathRanks(ctx context.Context, graphKey string, paths map[api.RepoID][]string) (map[api.RepoID]map[string]float64, error) {
+	var (
+		repoIDs   []int32
+		pathNames []string
+	)
+	for repoID, repoPaths := range paths {
+		for _, path := range repoPaths {
+			repoIDs = append(repoIDs, int32(repoID))
+			pathNames = append(pathNames, path)
+		}
+	}
+	if len(repoIDs) == 0 {
+		return map[api.RepoID]map[string]float64{}, nil
+	}
+
+	ranks := map[api.RepoID]map[string]float64{}
+	scanner := func(sc dbutil.Scanner) error {
+		var (
+			repoID api.RepoID
+			path   string
+			rank   float64
+		)
+		if err := sc.Scan(&repoID, &path, &rank); err != nil {
+			return err
+		}
+
+		repoRanks, ok := ranks[repoID]
+		if !ok {
+			repoRanks = map[string]float64{}
+			ranks[repoID] = repoRanks
+		}
+		repoRanks[path] = rank
+		return nil
+	}
+
+	query := sqlf.Sprintf(getPathRanksQueryFmtstr, pq.Array(repoIDs), pq.Array(pathNames), graphKey)
+	if err := basestore.NewCallbackScanner(scanner)(s.Query(ctx, query)); err != nil {
+		return nil, err
+	}
+	return ranks, nil
+}
+
+const getPathRanksQueryFmtstr = `
+SELECT DISTINCT ON (p.repository_id, p.path)
+	p.repository_id,
+	p.path,
+	(pr.payload->>p.path)::float8
+FROM unnest(%s::integer[], %s::text[]) AS p(repository_id, path)
+JOIN codeintel_path_ranks pr ON pr.repository_id = p.repository_id
+JOIN repo ON repo.id = pr.repository_id
+WHERE
+	repo.deleted_at IS NULL
+AND
+	repo.blocked IS NULL
+AND
+	pr.graph_key = %s
+AND
+	pr.payload->>p.path IS NOT NULL
+ORDER BY p.repository_id, p.path, pr.precision
+`
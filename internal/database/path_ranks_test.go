@@ -0,0 +1,50 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestPathRanks_GetPathRanks(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	repos := []*types.Repo{{Name: "github.com/foo/bar"}, {Name: "github.com/foo/baz"}}
+	require.NoError(t, db.Repos().Create(ctx, repos...))
+
+	for _, q := range []*sqlf.Query{
+		sqlf.Sprintf(`INSERT INTO codeintel_path_ranks (repository_id, precision, graph_key, payload) VALUES (%s, 1, 'current', '{"a.go": 0.5, "b.go": 0.25, "c.go": 0.125}')`, repos[0].ID),
+		sqlf.Sprintf(`INSERT INTO codeintel_path_ranks (repository_id, precision, graph_key, payload) VALUES (%s, 0.5, 'current', '{"a.go": 0.75}')`, repos[0].ID),
+		sqlf.Sprintf(`INSERT INTO codeintel_path_ranks (repository_id, precision, graph_key, payload) VALUES (%s, 1, 'stale', '{"a.go": 0.5}')`, repos[1].ID),
+	} {
+		_, err := db.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+		require.NoError(t, err)
+	}
+
+	got, err := db.PathRanks().GetPathRanks(ctx, "current", map[api.RepoID][]string{
+		repos[0].ID: {"a.go", "b.go", "unranked.go"},
+		repos[1].ID: {"a.go"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[api.RepoID]map[string]float64{
+		repos[0].ID: {"a.go": 0.75, "b.go": 0.25},
+	}, got)
+
+	got, err = db.PathRanks().GetPathRanks(ctx, "current", nil)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
@@ -0,0 +1,319 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// RoleNotFoundErr is returned when a role cannot be found.
+type RoleNotFoundErr struct {
+	id   int32
+	name string
+}
+
+func (err *RoleNotFoundErr) Error() string {
+	if err.name != "" {
+		return fmt.Sprintf("role not found: name=%q", err.name)
+	}
+	return fmt.Sprintf("role not found: id=%d", err.id)
+}
+
+func (*RoleNotFoundErr) NotFound() bool {
+	return true
+}
+
+// ErrRoleReadOnly is returned when attempting to modify or delete a built-in
+// role.
+var ErrRoleReadOnly = errors.New("built-in roles cannot be modified")
+
+// RoleStore provides access to the `roles`, `role_permissions`, `user_roles`
+// and `org_roles` tables.
+type RoleStore interface {
+	basestore.ShareableStore
+	With(basestore.ShareableStore) RoleStore
+	Transact(context.Context) (RoleStore, error)
+	Done(error) error
+
+	// Create creates a new role with the given name and no permissions.
+	Create(ctx context.Context, name string) (*types.Role, error)
+	// GetByID returns the role with the given ID, or RoleNotFoundErr if no
+	// such role exists.
+	GetByID(ctx context.Context, id int32) (*types.Role, error)
+	// GetByName returns the role with the given name, or RoleNotFoundErr if
+	// no such role exists.
+	GetByName(ctx context.Context, name string) (*types.Role, error)
+	// List returns all roles matching the given options, ordered by name.
+	List(ctx context.Context, opts RolesListOptions) ([]*types.Role, error)
+	// Delete deletes the role with the given ID along with its assignments.
+	// Built-in roles cannot be deleted.
+	Delete(ctx context.Context, id int32) error
+
+	// SetPermissions replaces the permissions granted by the role with the
+	// given ID. Built-in roles cannot be modified.
+	SetPermissions(ctx context.Context, id int32, permissions []rbac.Permission) error
+	// ListPermissions returns the permissions granted by the role with the
+	// given ID.
+	ListPermissions(ctx context.Context, id int32) ([]rbac.Permission, error)
+
+	// AssignToUser assigns the role to the user. It is a no-op if the user
+	// already has the role.
+	AssignToUser(ctx context.Context, id, userID int32) error
+	// RevokeFromUser removes the role from the user.
+	RevokeFromUser(ctx context.Context, id, userID int32) error
+	// AssignToOrg assigns the role to the organization, which grants its
+	// permissions to all members of the organization. It is a no-op if the
+	// organization already has the role.
+	AssignToOrg(ctx context.Context, id, orgID int32) error
+	// RevokeFromOrg removes the role from the organization.
+	RevokeFromOrg(ctx context.Context, id, orgID int32) error
+
+	// UserHasPermission returns true if the user is granted the permission
+	// by a role assigned to them or to an organization they are a member of.
+	UserHasPermission(ctx context.Context, userID int32, permission rbac.Permission) (bool, error)
+}
+
+// RolesListOptions provide the options when listing roles.
+type RolesListOptions struct {
+	// UserID, if set, limits the returned roles to those assigned directly to
+	// the user.
+	UserID int32
+	// OrgID, if set, limits the returned roles to those assigned to the
+	// organization.
+	OrgID int32
+}
+
+func (opts RolesListOptions) sqlConds() *sqlf.Query {
+	preds := []*sqlf.Query{sqlf.Sprintf("roles.deleted_at IS NULL")}
+	if opts.UserID != 0 {
+		preds = append(preds, sqlf.Sprintf("EXISTS (SELECT 1 FROM user_roles WHERE user_roles.role_id = roles.id AND user_roles.user_id = %s)", opts.UserID))
+	}
+	if opts.OrgID != 0 {
+		preds = append(preds, sqlf.Sprintf("EXISTS (SELECT 1 FROM org_roles WHERE org_roles.role_id = roles.id AND org_roles.org_id = %s)", opts.OrgID))
+	}
+	return sqlf.Join(preds, " AND ")
+}
+
+type roleStore struct {
+	*basestore.Store
+}
+
+var _ RoleStore = (*roleStore)(nil)
+
+// RolesWith instantiates and returns a new RoleStore using the other store handle.
+func RolesWith(other basestore.ShareableStore) RoleStore {
+	return &roleStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (s *roleStore) With(other basestore.ShareableStore) RoleStore {
+	return &roleStore{Store: s.Store.With(other)}
+}
+
+func (s *roleStore) Transact(ctx context.Context) (RoleStore, error) {
+	return s.transact(ctx)
+}
+
+func (s *roleStore) transact(ctx context.Context) (*roleStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &roleStore{Store: txBase}, err
+}
+
+const roleColumns = "roles.id, roles.name, roles.readonly, roles.created_at"
+
+func scanRole(sc dbutil.Scanner) (*types.Role, error) {
+	var r types.Role
+	return &r, sc.Scan(&r.ID, &r.Name, &r.ReadOnly, &r.CreatedAt)
+}
+
+var (
+	scanRoles     = basestore.NewSliceScanner(scanRole)
+	scanFirstRole = basestore.NewFirstScanner(scanRole)
+)
+
+func (s *roleStore) Create(ctx context.Context, name string) (*types.Role, error) {
+	q := sqlf.Sprintf(
+		"INSERT INTO roles (name) VALUES (%s) RETURNING "+roleColumns,
+		name,
+	)
+	role, _, err := scanFirstRole(s.Query(ctx, q))
+	return role, err
+}
+
+func (s *roleStore) GetByID(ctx context.Context, id int32) (*types.Role, error) {
+	q := sqlf.Sprintf(
+		"SELECT "+roleColumns+" FROM roles WHERE id = %s AND deleted_at IS NULL",
+		id,
+	)
+	role, ok, err := scanFirstRole(s.Query(ctx, q))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, &RoleNotFoundErr{id: id}
+	}
+	return role, nil
+}
+
+func (s *roleStore) GetByName(ctx context.Context, name string) (*types.Role, error) {
+	q := sqlf.Sprintf(
+		"SELECT "+roleColumns+" FROM roles WHERE name = %s AND deleted_at IS NULL",
+		name,
+	)
+	role, ok, err := scanFirstRole(s.Query(ctx, q))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, &RoleNotFoundErr{name: name}
+	}
+	return role, nil
+}
+
+func (s *roleStore) List(ctx context.Context, opts RolesListOptions) ([]*types.Role, error) {
+	q := sqlf.Sprintf(
+		"SELECT "+roleColumns+" FROM roles WHERE %s ORDER BY roles.name ASC",
+		opts.sqlConds(),
+	)
+	return scanRoles(s.Query(ctx, q))
+}
+
+func (s *roleStore) Delete(ctx context.Context, id int32) (err error) {
+	tx, err := s.transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.checkWritable(ctx, id); err != nil {
+		return err
+	}
+	return tx.Exec(ctx, sqlf.Sprintf("DELETE FROM roles WHERE id = %s", id))
+}
+
+// checkWritable returns an error if the role does not exist or is a built-in
+// role.
+func (s *roleStore) checkWritable(ctx context.Context, id int32) error {
+	role, err := s.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if role.ReadOnly {
+		return ErrRoleReadOnly
+	}
+	return nil
+}
+
+func (s *roleStore) SetPermissions(ctx context.Context, id int32, permissions []rbac.Permission) (err error) {
+	tx, err := s.transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.checkWritable(ctx, id); err != nil {
+		return err
+	}
+	if err := tx.Exec(ctx, sqlf.Sprintf("DELETE FROM role_permissions WHERE role_id = %s", id)); err != nil {
+		return err
+	}
+	if len(permissions) == 0 {
+		return nil
+	}
+
+	conds := make([]*sqlf.Query, 0, len(permissions))
+	for _, p := range permissions {
+		conds = append(conds, sqlf.Sprintf("(namespace = %s AND action = %s)", p.Namespace, p.Action))
+	}
+	q := sqlf.Sprintf(
+		"INSERT INTO role_permissions (role_id, permission_id) SELECT %s, id FROM permissions WHERE %s",
+		id,
+		sqlf.Join(conds, " OR "),
+	)
+	res, err := tx.ExecResult(ctx, q)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if int(n) != len(permissions) {
+		return errors.Errorf("some of the permissions %v do not exist", permissions)
+	}
+	return nil
+}
+
+func (s *roleStore) ListPermissions(ctx context.Context, id int32) ([]rbac.Permission, error) {
+	q := sqlf.Sprintf(`
+SELECT permissions.namespace, permissions.action
+FROM permissions
+JOIN role_permissions ON role_permissions.permission_id = permissions.id
+WHERE role_permissions.role_id = %s
+ORDER BY permissions.namespace, permissions.action
+`, id)
+	return basestore.NewSliceScanner(func(sc dbutil.Scanner) (rbac.Permission, error) {
+		var p rbac.Permission
+		return p, sc.Scan(&p.Namespace, &p.Action)
+	})(s.Query(ctx, q))
+}
+
+func (s *roleStore) AssignToUser(ctx context.Context, id, userID int32) error {
+	return s.Exec(ctx, sqlf.Sprintf(
+		"INSERT INTO user_roles (role_id, user_id) VALUES (%s, %s) ON CONFLICT DO NOTHING",
+		id, userID,
+	))
+}
+
+func (s *roleStore) RevokeFromUser(ctx context.Context, id, userID int32) error {
+	return s.Exec(ctx, sqlf.Sprintf(
+		"DELETE FROM user_roles WHERE role_id = %s AND user_id = %s",
+		id, userID,
+	))
+}
+
+func (s *roleStore) AssignToOrg(ctx context.Context, id, orgID int32) error {
+	return s.Exec(ctx, sqlf.Sprintf(
+		"INSERT INTO org_roles (role_id, org_id) VALUES (%s, %s) ON CONFLICT DO NOTHING",
+		id, orgID,
+	))
+}
+
+func (s *roleStore) RevokeFromOrg(ctx context.Context, id, orgID int32) error {
+	return s.Exec(ctx, sqlf.Sprintf(
+		"DELETE FROM org_roles WHERE role_id = %s AND org_id = %s",
+		id, orgID,
+	))
+}
+
+const userHasPermissionQueryFmtstr = `
+SELECT EXISTS (
+	SELECT 1
+	FROM role_permissions
+	JOIN permissions ON permissions.id = role_permissions.permission_id
+	JOIN roles ON roles.id = role_permissions.role_id
+	WHERE
+		permissions.namespace = %s AND permissions.action = %s
+		AND roles.deleted_at IS NULL
+		AND (
+			role_permissions.role_id IN (SELECT role_id FROM user_roles WHERE user_id = %s)
+			OR role_permissions.role_id IN (
+				SELECT org_roles.role_id
+				FROM org_roles
+				JOIN org_members ON org_members.org_id = org_roles.org_id
+				JOIN orgs ON orgs.id = org_roles.org_id
+				WHERE org_members.user_id = %s AND orgs.deleted_at IS NULL
+			)
+		)
+)
+`
+
+func (s *roleStore) UserHasPermission(ctx context.Context, userID int32, permission rbac.Permission) (bool, error) {
+	q := sqlf.Sprintf(userHasPermissionQueryFmtstr, permission.Namespace, permission.Action, userID, userID)
+	ok, _, err := basestore.ScanFirstBool(s.Query(ctx, q))
+	return ok, err
+}
@@ -0,0 +1,122 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
+)
+
+func TestRoles(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+	roles := db.Roles()
+
+	user, err := db.Users().Create(ctx, NewUser{Username: "u1"})
+	require.NoError(t, err)
+	member, err := db.Users().Create(ctx, NewUser{Username: "u2"})
+	require.NoError(t, err)
+	org, err := db.Orgs().Create(ctx, "o", nil)
+	require.NoError(t, err)
+	_, err = db.OrgMembers().Create(ctx, org.ID, member.ID)
+	require.NoError(t, err)
+
+	t.Run("built-in site administrator role", func(t *testing.T) {
+		admin, err := roles.GetByName(ctx, rbac.SiteAdministratorRole)
+		require.NoError(t, err)
+		assert.True(t, admin.ReadOnly)
+
+		assert.ErrorIs(t, roles.Delete(ctx, admin.ID), ErrRoleReadOnly)
+		assert.ErrorIs(t, roles.SetPermissions(ctx, admin.ID, nil), ErrRoleReadOnly)
+
+		// Membership follows the site admin flag.
+		require.NoError(t, db.Users().SetIsSiteAdmin(ctx, user.ID, true))
+		got, err := roles.List(ctx, RolesListOptions{UserID: user.ID})
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, rbac.SiteAdministratorRole, got[0].Name)
+
+		require.NoError(t, db.Users().SetIsSiteAdmin(ctx, user.ID, false))
+		got, err = roles.List(ctx, RolesListOptions{UserID: user.ID})
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	role, err := roles.Create(ctx, "CODE_HOST_ADMIN")
+	require.NoError(t, err)
+	assert.False(t, role.ReadOnly)
+
+	t.Run("permissions", func(t *testing.T) {
+		require.NoError(t, roles.SetPermissions(ctx, role.ID, []rbac.Permission{rbac.ExternalServicesManage, rbac.BatchChangesAdmin}))
+		got, err := roles.ListPermissions(ctx, role.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []rbac.Permission{rbac.BatchChangesAdmin, rbac.ExternalServicesManage}, got)
+
+		require.NoError(t, roles.SetPermissions(ctx, role.ID, []rbac.Permission{rbac.ExternalServicesManage}))
+		got, err = roles.ListPermissions(ctx, role.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []rbac.Permission{rbac.ExternalServicesManage}, got)
+
+		err = roles.SetPermissions(ctx, role.ID, []rbac.Permission{{Namespace: "UNKNOWN", Action: "UNKNOWN"}})
+		assert.Error(t, err)
+	})
+
+	t.Run("user assignment", func(t *testing.T) {
+		ok, err := roles.UserHasPermission(ctx, user.ID, rbac.ExternalServicesManage)
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		require.NoError(t, roles.AssignToUser(ctx, role.ID, user.ID))
+		require.NoError(t, roles.AssignToUser(ctx, role.ID, user.ID))
+
+		ok, err = roles.UserHasPermission(ctx, user.ID, rbac.ExternalServicesManage)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		ok, err = roles.UserHasPermission(ctx, user.ID, rbac.CodeInsightsAdmin)
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		require.NoError(t, roles.RevokeFromUser(ctx, role.ID, user.ID))
+		ok, err = roles.UserHasPermission(ctx, user.ID, rbac.ExternalServicesManage)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("org assignment", func(t *testing.T) {
+		require.NoError(t, roles.AssignToOrg(ctx, role.ID, org.ID))
+
+		got, err := roles.List(ctx, RolesListOptions{OrgID: org.ID})
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, role.ID, got[0].ID)
+
+		ok, err := roles.UserHasPermission(ctx, member.ID, rbac.ExternalServicesManage)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		ok, err = roles.UserHasPermission(ctx, user.ID, rbac.ExternalServicesManage)
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		require.NoError(t, roles.RevokeFromOrg(ctx, role.ID, org.ID))
+		ok, err = roles.UserHasPermission(ctx, member.ID, rbac.ExternalServicesManage)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, roles.Delete(ctx, role.ID))
+		_, err := roles.GetByID(ctx, role.ID)
+		assert.True(t, errcode.IsNotFound(err))
+	})
+}
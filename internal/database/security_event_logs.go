@@ -59,6 +59,9 @@ const (
 
 	SecurityEventOIDCLoginSucceeded SecurityEventName = "SecurityEventOIDCLoginSucceeded"
 	SecurityEventOIDCLoginFailed    SecurityEventName = "SecurityEventOIDCLoginFailed"
+
+	SecurityEventLDAPLoginSucceeded SecurityEventName = "LDAPLoginSucceeded"
+	SecurityEventLDAPLoginFailed    SecurityEventName = "LDAPLoginFailed"
 )
 
 // SecurityEvent contains information needed for logging a security-relevant event.
@@ -78,6 +78,7 @@ type UserStore interface {
 	List(context.Context, *UsersListOptions) (_ []*types.User, err error)
 	ListDates(context.Context) ([]types.UserDates, error)
 	RandomizePasswordAndClearPasswordResetRateLimit(context.Context, int32) error
+	RecoverUsersList(context.Context, []int32) error
 	RenewPasswordResetCode(context.Context, int32) (string, error)
 	SetIsSiteAdmin(ctx context.Context, id int32, isSiteAdmin bool) error
 	SetPassword(ctx context.Context, id int32, resetCode, newPassword string) (bool, error)
@@ -559,6 +560,57 @@ func (u *userStore) DeleteList(ctx context.Context, ids []int32) (err error) {
 	return nil
 }
 
+// RecoverUsersList restores the given soft-deleted users, along with their
+// usernames and the external accounts that were deleted with them. Access
+// tokens and emails are not restored.
+func (u *userStore) RecoverUsersList(ctx context.Context, ids []int32) (err error) {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	tx, err := u.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	userIDs := make([]*sqlf.Query, len(ids))
+	for i := range ids {
+		userIDs[i] = sqlf.Sprintf("%d", ids[i])
+	}
+
+	idsCond := sqlf.Join(userIDs, ",")
+
+	// External accounts are soft-deleted in the same transaction as their user,
+	// so they share its deletion timestamp.
+	if err := tx.Exec(ctx, sqlf.Sprintf("UPDATE user_external_accounts a SET deleted_at=NULL FROM users u WHERE a.user_id=u.id AND u.id IN (%s) AND a.deleted_at=u.deleted_at", idsCond)); err != nil {
+		return err
+	}
+
+	res, err := tx.ExecResult(ctx, sqlf.Sprintf("UPDATE users SET deleted_at=NULL, updated_at=now() WHERE id IN (%s) AND deleted_at IS NOT NULL", idsCond))
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows != int64(len(ids)) {
+		return userNotFoundErr{args: []any{fmt.Sprintf("Some users were not found. Expected to recover %d users, but recovered only %d", +len(ids), rows)}}
+	}
+
+	// Claim the usernames again, which may have been taken in the meantime.
+	if err := tx.Exec(ctx, sqlf.Sprintf("INSERT INTO names (name, user_id) SELECT username, id FROM users WHERE id IN (%s)", idsCond)); err != nil {
+		var e *pgconn.PgError
+		if errors.As(err, &e) && e.ConstraintName == "names_pkey" {
+			return errCannotCreateUser{errorCodeUsernameExists}
+		}
+		return err
+	}
+
+	return nil
+}
+
 // HardDelete removes the user and all resources associated with this user.
 func (u *userStore) HardDelete(ctx context.Context, id int32) (err error) {
 	return u.HardDeleteList(ctx, []int32{id})
@@ -822,6 +874,9 @@ type UsersListOptions struct {
 	// user accounts.
 	ExcludeSourcegraphOperators bool
 
+	// IncludeDeleted indicates whether to include soft-deleted users.
+	IncludeDeleted bool
+
 	*LimitOffset
 }
 
@@ -894,7 +949,9 @@ EXISTS (
 
 func (*userStore) listSQL(opt UsersListOptions) (conds []*sqlf.Query) {
 	conds = []*sqlf.Query{sqlf.Sprintf("TRUE")}
-	conds = append(conds, sqlf.Sprintf("deleted_at IS NULL"))
+	if !opt.IncludeDeleted {
+		conds = append(conds, sqlf.Sprintf("deleted_at IS NULL"))
+	}
 	if opt.Query != "" {
 		query := "%" + opt.Query + "%"
 		items := []*sqlf.Query{
@@ -805,6 +805,45 @@ func TestUsers_Delete(t *testing.T) {
 	}
 }
 
+func TestUsers_RecoverUsersList(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	spec := extsvc.AccountSpec{
+		ServiceType: "xa",
+		ServiceID:   "xb",
+		ClientID:    "xc",
+		AccountID:   "xd",
+	}
+	userID, err := db.UserExternalAccounts().CreateUserAndSave(ctx, NewUser{Username: "u"}, spec, extsvc.AccountData{})
+	require.NoError(t, err)
+	require.NoError(t, db.Users().Delete(ctx, userID))
+
+	require.NoError(t, db.Users().RecoverUsersList(ctx, []int32{userID}))
+	user, err := db.Users().GetByUsername(ctx, "u")
+	require.NoError(t, err)
+	assert.Equal(t, userID, user.ID)
+	accounts, err := db.UserExternalAccounts().List(ctx, ExternalAccountsListOptions{UserID: userID})
+	require.NoError(t, err)
+	assert.Len(t, accounts, 1)
+
+	// Users that are not deleted cannot be recovered.
+	err = db.Users().RecoverUsersList(ctx, []int32{userID})
+	assert.True(t, errcode.IsNotFound(err), "unexpected error: %v", err)
+
+	// Usernames that were taken in the meantime cannot be claimed again.
+	require.NoError(t, db.Users().Delete(ctx, userID))
+	_, err = db.Users().Create(ctx, NewUser{Username: "u"})
+	require.NoError(t, err)
+	err = db.Users().RecoverUsersList(ctx, []int32{userID})
+	assert.True(t, IsUsernameExists(err), "unexpected error: %v", err)
+}
+
 func TestUsers_HasTag(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -127,6 +127,13 @@ func NewRing(ctx context.Context, keyConfig *schema.EncryptionKeys) (*Ring, erro
 		}
 	}
 
+	if keyConfig.OutboundWebhookKey != nil {
+		r.OutboundWebhookKey, err = NewKey(ctx, keyConfig.OutboundWebhookKey, keyConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &r, nil
 }
 
@@ -137,6 +144,7 @@ type Ring struct {
 	WebhookKey                encryption.Key
 	WebhookLogKey             encryption.Key
 	ExecutorSecretKey         encryption.Key
+	OutboundWebhookKey        encryption.Key
 }
 
 func NewKey(ctx context.Context, k *schema.EncryptionKey, config *schema.EncryptionKeys) (encryption.Key, error) {
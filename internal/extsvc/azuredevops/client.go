@@ -0,0 +1,206 @@
+package azuredevops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// apiVersion is the version of the Azure DevOps REST API used by the client.
+// It is supported by Azure DevOps Services and Azure DevOps Server 2022.
+const apiVersion = "7.0"
+
+type Client interface {
+	Authenticator() auth.Authenticator
+	WithAuthenticator(a auth.Authenticator) Client
+
+	Ping(ctx context.Context, org string) error
+
+	ListRepositories(ctx context.Context, org, project string) ([]*Repository, error)
+
+	CreatePullRequest(ctx context.Context, repo *Repository, input PullRequestInput) (*PullRequest, error)
+	FindActivePullRequest(ctx context.Context, repo *Repository, sourceRefName, targetRefName string) (*PullRequest, error)
+	GetPullRequest(ctx context.Context, repo *Repository, id int) (*PullRequest, error)
+	GetPullRequestStatuses(ctx context.Context, repo *Repository, id int) ([]*PullRequestStatus, error)
+	UpdatePullRequest(ctx context.Context, repo *Repository, id int, input PullRequestUpdateInput) (*PullRequest, error)
+	CreatePullRequestComment(ctx context.Context, repo *Repository, id int, content string) error
+}
+
+// client accesses Azure DevOps Services or Azure DevOps Server via the REST
+// API.
+type client struct {
+	// HTTP Client used to communicate with the API
+	httpClient httpcli.Doer
+
+	// URL is the base URL of Azure DevOps, without an organization.
+	URL *url.URL
+
+	// Auth is the authentication method used when accessing the server. Only
+	// auth.BasicAuth, where the password is a personal access token, is
+	// currently supported.
+	Auth auth.Authenticator
+
+	// RateLimit is the self-imposed rate limiter. Azure DevOps delays requests
+	// instead of rejecting them when its own limits are exceeded.
+	rateLimit *ratelimit.InstrumentedLimiter
+}
+
+// NewClient returns an Azure DevOps API client authenticated with the username
+// and personal access token of the given configuration. If a nil httpClient is
+// provided, httpcli.ExternalDoer will be used.
+func NewClient(urn string, config *schema.AzureDevOpsConnection, httpClient httpcli.Doer) (Client, error) {
+	u, err := url.Parse(config.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpClient == nil {
+		httpClient = httpcli.ExternalDoer
+	}
+
+	return &client{
+		httpClient: httpClient,
+		URL:        extsvc.NormalizeBaseURL(u),
+		Auth: &auth.BasicAuth{
+			Username: config.Username,
+			Password: config.Token,
+		},
+		rateLimit: ratelimit.DefaultRegistry.Get(urn),
+	}, nil
+}
+
+func (c *client) Authenticator() auth.Authenticator {
+	return c.Auth
+}
+
+// WithAuthenticator returns a new Client that uses the same configuration,
+// HTTPClient, and RateLimiter as the current Client, except authenticated with
+// the given authenticator instance.
+func (c *client) WithAuthenticator(a auth.Authenticator) Client {
+	return &client{
+		httpClient: c.httpClient,
+		URL:        c.URL,
+		Auth:       a,
+		rateLimit:  c.rateLimit,
+	}
+}
+
+// Ping lists a single project of the given organization, thereby validating
+// that the current authenticator can access it.
+func (c *client) Ping(ctx context.Context, org string) error {
+	qs := url.Values{"$top": []string{"1"}}
+	req, err := http.NewRequest("GET", c.orgPath(org, "_apis/projects", qs), nil)
+	if err != nil {
+		return errors.Wrap(err, "creating request")
+	}
+	return c.do(ctx, req, nil)
+}
+
+// orgPath returns the URL, relative to the base URL, of the given API path in
+// the given organization.
+func (c *client) orgPath(org, path string, qs url.Values) string {
+	return (&url.URL{Path: org + "/" + path, RawQuery: withAPIVersion(qs).Encode()}).String()
+}
+
+func withAPIVersion(qs url.Values) url.Values {
+	if qs == nil {
+		qs = make(url.Values)
+	}
+	qs.Set("api-version", apiVersion)
+	return qs
+}
+
+// newJSONRequest returns a request with the given value marshalled as its body.
+func newJSONRequest(method, url string, v any) (*http.Request, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling request")
+	}
+	return http.NewRequest(method, url, bytes.NewReader(data))
+}
+
+func (c *client) do(ctx context.Context, req *http.Request, result any) error {
+	req.URL = c.URL.ResolveReference(req.URL)
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+	if req.Body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if err := c.Auth.Authenticate(req); err != nil {
+		return err
+	}
+
+	if err := c.rateLimit.Wait(ctx); err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// Azure DevOps answers requests with invalid credentials with a sign-in
+	// page and 203 Non-Authoritative Information instead of 401 Unauthorized.
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 || resp.StatusCode == http.StatusNonAuthoritativeInfo {
+		return errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	if result != nil {
+		return json.Unmarshal(bs, result)
+	}
+
+	return nil
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Azure DevOps API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusNonAuthoritativeInfo
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+func (e *httpError) Conflict() bool {
+	return e.StatusCode == http.StatusConflict
+}
+
+// IsConflict reports whether err is an HTTP 409 Conflict error, which Azure
+// DevOps returns when an active pull request already exists for the same
+// source and target branches.
+func IsConflict(err error) bool {
+	var e interface{ Conflict() bool }
+	return errors.As(err, &e) && e.Conflict()
+}
@@ -0,0 +1,154 @@
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestClient_ListRepositories(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RequestURI())
+		username, password, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "admin", username)
+		assert.Equal(t, "secret", password)
+
+		_, _ = w.Write([]byte(`{"count":1,"value":[{"id":"b3d7","name":"api","project":{"id":"p1","name":"Fabrikam Fiber","visibility":"private"}}]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	cli := newTestClient(t, srv.URL)
+	ctx := context.Background()
+
+	repos, err := cli.ListRepositories(ctx, "fabrikam", "")
+	require.NoError(t, err)
+	assert.Equal(t, []*Repository{{
+		ID:      "b3d7",
+		Name:    "api",
+		Project: Project{ID: "p1", Name: "Fabrikam Fiber", Visibility: "private"},
+	}}, repos)
+	assert.True(t, repos[0].IsPrivate())
+
+	_, err = cli.ListRepositories(ctx, "fabrikam", "Fabrikam Fiber")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"/fabrikam/_apis/git/repositories?api-version=7.0",
+		"/fabrikam/Fabrikam%20Fiber/_apis/git/repositories?api-version=7.0",
+	}, requests)
+}
+
+func TestClient_Unauthorized(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNonAuthoritativeInfo)
+		_, _ = w.Write([]byte(`<html>Sign In</html>`))
+	}))
+	t.Cleanup(srv.Close)
+
+	err := newTestClient(t, srv.URL).Ping(context.Background(), "fabrikam")
+	var e *httpError
+	require.True(t, errors.As(err, &e))
+	assert.True(t, e.Unauthorized())
+}
+
+func TestClient_PullRequests(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "7.0", r.URL.Query().Get("api-version"))
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /fabrikam/_apis/git/repositories/b3d7/pullrequests":
+			var input PullRequestInput
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+			if input.SourceRefName == "refs/heads/exists" {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"message":"TF401179: An active pull request for the source and target branch already exists."}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(&PullRequest{ID: 1, Title: input.Title, SourceRefName: input.SourceRefName, IsDraft: input.IsDraft})
+
+		case "GET /fabrikam/_apis/git/repositories/b3d7/pullrequests":
+			q := r.URL.Query()
+			assert.Equal(t, "active", q.Get("searchCriteria.status"))
+			if q.Get("searchCriteria.sourceRefName") == "refs/heads/exists" {
+				_, _ = w.Write([]byte(`{"value":[{"pullRequestId":2}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"value":[]}`))
+
+		case "PATCH /fabrikam/_apis/git/repositories/b3d7/pullrequests/2":
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"status":"completed","lastMergeSourceCommit":{"commitId":"abc"},"completionOptions":{"mergeStrategy":"squash"}}`, string(body))
+			_, _ = w.Write([]byte(`{"pullRequestId":2,"status":"completed"}`))
+
+		case "GET /fabrikam/_apis/git/repositories/b3d7/pullrequests/2/statuses":
+			_, _ = w.Write([]byte(`{"value":[{"id":1,"state":"succeeded","context":{"genre":"continuous-integration","name":"build"}}]}`))
+
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	cli := newTestClient(t, srv.URL)
+	ctx := context.Background()
+	repo := &Repository{ID: "b3d7", URL: srv.URL + "/fabrikam/_apis/git/repositories/b3d7"}
+
+	pr, err := cli.CreatePullRequest(ctx, repo, PullRequestInput{Title: "Fix", SourceRefName: "refs/heads/fix", IsDraft: true})
+	require.NoError(t, err)
+	assert.Equal(t, &PullRequest{ID: 1, Title: "Fix", SourceRefName: "refs/heads/fix", IsDraft: true}, pr)
+
+	_, err = cli.CreatePullRequest(ctx, repo, PullRequestInput{SourceRefName: "refs/heads/exists"})
+	assert.True(t, IsConflict(err))
+
+	pr, err = cli.FindActivePullRequest(ctx, repo, "refs/heads/exists", "refs/heads/main")
+	require.NoError(t, err)
+	assert.Equal(t, 2, pr.ID)
+
+	_, err = cli.FindActivePullRequest(ctx, repo, "refs/heads/missing", "refs/heads/main")
+	assert.True(t, errcode.IsNotFound(err))
+
+	pr, err = cli.UpdatePullRequest(ctx, repo, 2, PullRequestUpdateInput{
+		Status:                PullRequestStatusCompleted,
+		LastMergeSourceCommit: &GitCommitRef{CommitID: "abc"},
+		CompletionOptions:     &CompletionOptions{MergeStrategy: MergeStrategySquash},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, PullRequestStatusCompleted, pr.Status)
+
+	statuses, err := cli.GetPullRequestStatuses(ctx, repo, 2)
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "continuous-integration/build", statuses[0].Key())
+}
+
+func TestClient_WithAuthenticator(t *testing.T) {
+	cli := newTestClient(t, "https://dev.azure.com")
+	assert.Equal(t, &auth.BasicAuth{Username: "admin", Password: "secret"}, cli.Authenticator())
+
+	a := &auth.BasicAuth{Username: "other", Password: "token"}
+	assert.Same(t, a, cli.WithAuthenticator(a).Authenticator())
+}
+
+func newTestClient(t *testing.T, url string) Client {
+	t.Helper()
+
+	cli, err := NewClient("urn", &schema.AzureDevOpsConnection{Url: url, Username: "admin", Token: "secret"}, nil)
+	require.NoError(t, err)
+	return cli
+}
@@ -0,0 +1,298 @@
+package azuredevops
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type PullRequestStatusValue string
+
+const (
+	PullRequestStatusActive    PullRequestStatusValue = "active"
+	PullRequestStatusAbandoned PullRequestStatusValue = "abandoned"
+	PullRequestStatusCompleted PullRequestStatusValue = "completed"
+)
+
+// PullRequest is an Azure DevOps pull request.
+type PullRequest struct {
+	ID                    int                    `json:"pullRequestId"`
+	Repository            *Repository            `json:"repository,omitempty"`
+	Status                PullRequestStatusValue `json:"status"`
+	CreatedBy             IdentityRef            `json:"createdBy"`
+	CreationDate          time.Time              `json:"creationDate"`
+	ClosedDate            time.Time              `json:"closedDate"`
+	Title                 string                 `json:"title"`
+	Description           string                 `json:"description"`
+	SourceRefName         string                 `json:"sourceRefName"`
+	TargetRefName         string                 `json:"targetRefName"`
+	MergeStatus           string                 `json:"mergeStatus,omitempty"`
+	IsDraft               bool                   `json:"isDraft"`
+	LastMergeSourceCommit *GitCommitRef          `json:"lastMergeSourceCommit,omitempty"`
+	LastMergeTargetCommit *GitCommitRef          `json:"lastMergeTargetCommit,omitempty"`
+	Reviewers             []Reviewer             `json:"reviewers"`
+	// URL is the API URL of the pull request.
+	URL string `json:"url"`
+}
+
+// IdentityRef is a user or group on Azure DevOps.
+type IdentityRef struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	// UniqueName is usually the e-mail address of a user, or the principal
+	// name of a group.
+	UniqueName string `json:"uniqueName"`
+}
+
+// Reviewer is a reviewer of a pull request, and their vote.
+type Reviewer struct {
+	IdentityRef
+	Vote       int  `json:"vote"`
+	IsRequired bool `json:"isRequired,omitempty"`
+}
+
+// The votes a reviewer can cast on a pull request.
+const (
+	VoteApproved                = 10
+	VoteApprovedWithSuggestions = 5
+	VoteNone                    = 0
+	VoteWaitingForAuthor        = -5
+	VoteRejected                = -10
+)
+
+type GitCommitRef struct {
+	CommitID string `json:"commitId"`
+}
+
+type PullRequestStatusState string
+
+const (
+	PullRequestStatusStateNotSet        PullRequestStatusState = "notSet"
+	PullRequestStatusStatePending       PullRequestStatusState = "pending"
+	PullRequestStatusStateSucceeded     PullRequestStatusState = "succeeded"
+	PullRequestStatusStateFailed        PullRequestStatusState = "failed"
+	PullRequestStatusStateError         PullRequestStatusState = "error"
+	PullRequestStatusStateNotApplicable PullRequestStatusState = "notApplicable"
+)
+
+// PullRequestStatus is a status posted to a pull request, usually by a build
+// pipeline or another service.
+type PullRequestStatus struct {
+	ID           int                    `json:"id"`
+	State        PullRequestStatusState `json:"state"`
+	Description  string                 `json:"description"`
+	Context      StatusContext          `json:"context"`
+	TargetURL    string                 `json:"targetUrl,omitempty"`
+	CreationDate time.Time              `json:"creationDate"`
+	UpdatedDate  time.Time              `json:"updatedDate"`
+}
+
+// StatusContext identifies the service that posted a status.
+type StatusContext struct {
+	Genre string `json:"genre,omitempty"`
+	Name  string `json:"name"`
+}
+
+// Key returns a key identifying the service that posted the status. A service
+// posting a new status for the same pull request replaces its previous
+// status.
+func (s *PullRequestStatus) Key() string {
+	return s.Context.Genre + "/" + s.Context.Name
+}
+
+// PullRequestInput is the payload used to create a pull request.
+type PullRequestInput struct {
+	SourceRefName string `json:"sourceRefName"`
+	TargetRefName string `json:"targetRefName"`
+	Title         string `json:"title"`
+	Description   string `json:"description"`
+	IsDraft       bool   `json:"isDraft"`
+}
+
+// PullRequestUpdateInput is the payload used to update a pull request. Only
+// the fields that are set are updated.
+type PullRequestUpdateInput struct {
+	Title         *string                `json:"title,omitempty"`
+	Description   *string                `json:"description,omitempty"`
+	TargetRefName *string                `json:"targetRefName,omitempty"`
+	Status        PullRequestStatusValue `json:"status,omitempty"`
+	IsDraft       *bool                  `json:"isDraft,omitempty"`
+
+	// LastMergeSourceCommit and CompletionOptions are required to complete,
+	// that is merge, a pull request.
+	LastMergeSourceCommit *GitCommitRef      `json:"lastMergeSourceCommit,omitempty"`
+	CompletionOptions     *CompletionOptions `json:"completionOptions,omitempty"`
+}
+
+type MergeStrategy string
+
+const (
+	MergeStrategyNoFastForward MergeStrategy = "noFastForward"
+	MergeStrategySquash        MergeStrategy = "squash"
+)
+
+type CompletionOptions struct {
+	MergeStrategy MergeStrategy `json:"mergeStrategy"`
+}
+
+// CreatePullRequest opens a new pull request. If an active pull request
+// already exists for the same source and target branches, an error for which
+// IsConflict returns true is returned.
+func (c *client) CreatePullRequest(ctx context.Context, repo *Repository, input PullRequestInput) (*PullRequest, error) {
+	u, err := repoPath(repo, nil, "pullrequests")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := newJSONRequest("POST", u, &input)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request")
+	}
+
+	var pr PullRequest
+	if err := c.do(ctx, req, &pr); err != nil {
+		return nil, errors.Wrap(err, "sending request")
+	}
+
+	return &pr, nil
+}
+
+// FindActivePullRequest returns the active pull request from the given source
+// branch into the given target branch. If there is none, an error for which
+// errcode.IsNotFound returns true is returned.
+func (c *client) FindActivePullRequest(ctx context.Context, repo *Repository, sourceRefName, targetRefName string) (*PullRequest, error) {
+	u, err := repoPath(repo, url.Values{
+		"searchCriteria.sourceRefName": []string{sourceRefName},
+		"searchCriteria.targetRefName": []string{targetRefName},
+		"searchCriteria.status":        []string{string(PullRequestStatusActive)},
+	}, "pullrequests")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request")
+	}
+
+	var resp struct {
+		Value []*PullRequest `json:"value"`
+	}
+	if err := c.do(ctx, req, &resp); err != nil {
+		return nil, errors.Wrap(err, "sending request")
+	}
+
+	if len(resp.Value) == 0 {
+		return nil, &notFoundError{what: "active pull request from " + sourceRefName + " into " + targetRefName}
+	}
+	return resp.Value[0], nil
+}
+
+// GetPullRequest retrieves a single pull request.
+func (c *client) GetPullRequest(ctx context.Context, repo *Repository, id int) (*PullRequest, error) {
+	u, err := repoPath(repo, nil, "pullrequests", strconv.Itoa(id))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request")
+	}
+
+	var pr PullRequest
+	if err := c.do(ctx, req, &pr); err != nil {
+		return nil, errors.Wrap(err, "sending request")
+	}
+
+	return &pr, nil
+}
+
+// GetPullRequestStatuses retrieves the statuses posted to a pull request.
+func (c *client) GetPullRequestStatuses(ctx context.Context, repo *Repository, id int) ([]*PullRequestStatus, error) {
+	u, err := repoPath(repo, nil, "pullrequests", strconv.Itoa(id), "statuses")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request")
+	}
+
+	var resp struct {
+		Value []*PullRequestStatus `json:"value"`
+	}
+	if err := c.do(ctx, req, &resp); err != nil {
+		return nil, errors.Wrap(err, "sending request")
+	}
+
+	return resp.Value, nil
+}
+
+// UpdatePullRequest updates a pull request. Abandoning, reactivating and
+// completing a pull request are all updates of its status.
+func (c *client) UpdatePullRequest(ctx context.Context, repo *Repository, id int, input PullRequestUpdateInput) (*PullRequest, error) {
+	u, err := repoPath(repo, nil, "pullrequests", strconv.Itoa(id))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := newJSONRequest("PATCH", u, &input)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request")
+	}
+
+	var pr PullRequest
+	if err := c.do(ctx, req, &pr); err != nil {
+		return nil, errors.Wrap(err, "sending request")
+	}
+
+	return &pr, nil
+}
+
+// CreatePullRequestComment starts a new comment thread on a pull request.
+func (c *client) CreatePullRequestComment(ctx context.Context, repo *Repository, id int, content string) error {
+	u, err := repoPath(repo, nil, "pullrequests", strconv.Itoa(id), "threads")
+	if err != nil {
+		return err
+	}
+
+	type comment struct {
+		Content     string `json:"content"`
+		CommentType string `json:"commentType"`
+	}
+	req, err := newJSONRequest("POST", u, &struct {
+		Comments []comment `json:"comments"`
+	}{
+		Comments: []comment{{Content: content, CommentType: "text"}},
+	})
+	if err != nil {
+		return errors.Wrap(err, "creating request")
+	}
+
+	if err := c.do(ctx, req, nil); err != nil {
+		return errors.Wrap(err, "sending request")
+	}
+	return nil
+}
+
+// repoPath returns the API URL of the given path below the repository.
+func repoPath(repo *Repository, qs url.Values, elem ...string) (string, error) {
+	u, err := url.Parse(repo.URL)
+	if err != nil {
+		return "", errors.Wrap(err, "parsing repository URL")
+	}
+	u = u.JoinPath(elem...)
+	u.RawQuery = withAPIVersion(qs).Encode()
+	return u.String(), nil
+}
+
+type notFoundError struct{ what string }
+
+func (e *notFoundError) Error() string  { return "Azure DevOps " + e.what + " not found" }
+func (e *notFoundError) NotFound() bool { return true }
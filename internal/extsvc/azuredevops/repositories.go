@@ -0,0 +1,63 @@
+package azuredevops
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Project is an Azure DevOps project, which groups repositories within an
+// organization.
+type Project struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	State      string `json:"state,omitempty"`
+	Visibility string `json:"visibility,omitempty"`
+}
+
+// Repository is an Azure DevOps Git repository.
+type Repository struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	Project       Project `json:"project"`
+	DefaultBranch string  `json:"defaultBranch,omitempty"`
+	IsDisabled    bool    `json:"isDisabled,omitempty"`
+	IsFork        bool    `json:"isFork,omitempty"`
+	// URL is the API URL of the repository.
+	URL       string `json:"url"`
+	RemoteURL string `json:"remoteUrl"`
+	SSHURL    string `json:"sshUrl"`
+	WebURL    string `json:"webUrl"`
+}
+
+// IsPrivate reports whether the repository is in a private project. Projects
+// are private unless explicitly made public.
+func (r *Repository) IsPrivate() bool {
+	return r.Project.Visibility != "public"
+}
+
+// ListRepositories returns the repositories of the given project in the given
+// organization. If project is empty, the repositories of all projects in the
+// organization are returned.
+//
+// The API returns all repositories in a single response.
+func (c *client) ListRepositories(ctx context.Context, org, project string) ([]*Repository, error) {
+	path := "_apis/git/repositories"
+	if project != "" {
+		path = project + "/" + path
+	}
+
+	req, err := http.NewRequest("GET", c.orgPath(org, path, nil), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request")
+	}
+
+	var resp struct {
+		Value []*Repository `json:"value"`
+	}
+	if err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Value, nil
+}
@@ -15,7 +15,7 @@ type CodeHost struct {
 
 func (c *CodeHost) IsPackageHost() bool {
 	switch c.ServiceType {
-	case TypeNpmPackages, TypeJVMPackages, TypeGoModules, TypePythonPackages, TypeRustPackages, TypeRubyPackages:
+	case TypeNpmPackages, TypeJVMPackages, TypeGoModules, TypePythonPackages, TypeRustPackages, TypeRubyPackages, TypeNuGetPackages:
 		return true
 	}
 	return false
@@ -49,6 +49,9 @@ var (
 	RubyURL      = &url.URL{Host: "rubygems"}
 	RubyPackages = NewCodeHost(RubyURL, TypeRubyPackages)
 
+	NuGetURL      = &url.URL{Host: "nuget"}
+	NuGetPackages = NewCodeHost(NuGetURL, TypeNuGetPackages)
+
 	PublicCodeHosts = []*CodeHost{
 		GitHubDotCom,
 		GitLabDotCom,
@@ -58,6 +61,7 @@ var (
 		PythonPackages,
 		RustPackages,
 		RubyPackages,
+		NuGetPackages,
 	}
 )
 
@@ -0,0 +1,224 @@
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ChangeStatus is the status of a Gerrit change.
+type ChangeStatus string
+
+const (
+	ChangeStatusNew       ChangeStatus = "NEW"
+	ChangeStatusMerged    ChangeStatus = "MERGED"
+	ChangeStatusAbandoned ChangeStatus = "ABANDONED"
+)
+
+// Change is a Gerrit change, as returned by the changes endpoints with the
+// options requested by GetChange.
+//
+// See https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#change-info.
+type Change struct {
+	// ID is the triplet "project~branch~Change-Id" identifying the change,
+	// with the project name URL encoded.
+	ID       string       `json:"id"`
+	Project  string       `json:"project"`
+	Branch   string       `json:"branch"`
+	ChangeID string       `json:"change_id"`
+	Number   int          `json:"_number"`
+	Subject  string       `json:"subject"`
+	Status   ChangeStatus `json:"status"`
+	Created  Timestamp    `json:"created"`
+	Updated  Timestamp    `json:"updated"`
+	// Submitted is only set for merged changes.
+	Submitted      *Timestamp           `json:"submitted,omitempty"`
+	Owner          Account              `json:"owner"`
+	WorkInProgress bool                 `json:"work_in_progress,omitempty"`
+	Submittable    bool                 `json:"submittable,omitempty"`
+	Labels         map[string]LabelInfo `json:"labels,omitempty"`
+	// CurrentRevision is the commit ID of the current patch set.
+	CurrentRevision string                  `json:"current_revision,omitempty"`
+	Revisions       map[string]RevisionInfo `json:"revisions,omitempty"`
+}
+
+// CurrentRevisionInfo returns the current patch set of the change, if it was
+// requested.
+func (c *Change) CurrentRevisionInfo() (RevisionInfo, bool) {
+	r, ok := c.Revisions[c.CurrentRevision]
+	return r, ok
+}
+
+// RevisionInfo is a patch set of a change.
+type RevisionInfo struct {
+	Number int        `json:"_number"`
+	Ref    string     `json:"ref"`
+	Commit CommitInfo `json:"commit"`
+}
+
+// CommitInfo is the commit of a patch set.
+type CommitInfo struct {
+	Parents []CommitInfo `json:"parents,omitempty"`
+	// Commit is only set for parents.
+	Commit  string `json:"commit,omitempty"`
+	Subject string `json:"subject"`
+	Message string `json:"message,omitempty"`
+}
+
+// LabelInfo is the state of a review label, such as Code-Review or Verified,
+// on a change.
+type LabelInfo struct {
+	// Approved, Rejected, Recommended and Disliked are set to one of the
+	// accounts that voted with the maximum, minimum, positive and negative
+	// value of the label, respectively.
+	Approved    *Account       `json:"approved,omitempty"`
+	Rejected    *Account       `json:"rejected,omitempty"`
+	Recommended *Account       `json:"recommended,omitempty"`
+	Disliked    *Account       `json:"disliked,omitempty"`
+	Optional    bool           `json:"optional,omitempty"`
+	All         []ApprovalInfo `json:"all,omitempty"`
+}
+
+// ApprovalInfo is the vote of a reviewer on a label.
+type ApprovalInfo struct {
+	Account
+	Value int       `json:"value"`
+	Date  Timestamp `json:"date,omitempty"`
+}
+
+// timestampLayout is the format of timestamps in the Gerrit API, which are
+// always in UTC.
+const timestampLayout = "2006-01-02 15:04:05.000000000"
+
+// Timestamp is a timestamp in the format used by the Gerrit API.
+type Timestamp struct{ time.Time }
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+	parsed, err := time.Parse(timestampLayout, s)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte(`""`), nil
+	}
+	return json.Marshal(t.UTC().Format(timestampLayout))
+}
+
+// ChangeTriplet returns the ID of the change with the given Change-Id on the
+// given branch of the given project, which is unique unlike the Change-Id.
+func ChangeTriplet(project, branch, changeID string) string {
+	return project + "~" + strings.TrimPrefix(branch, "refs/heads/") + "~" + changeID
+}
+
+// GetChange returns the change with the given ID, which can be its number,
+// its Change-Id or its triplet, including its current revision, its review
+// labels and whether it can be submitted.
+func (c *Client) GetChange(ctx context.Context, id string) (*Change, error) {
+	qs := url.Values{"o": []string{
+		"CURRENT_REVISION",
+		"CURRENT_COMMIT",
+		"DETAILED_LABELS",
+		"DETAILED_ACCOUNTS",
+		"SUBMITTABLE",
+	}}
+	req, err := http.NewRequest("GET", changePath(id, "", qs), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var change Change
+	if _, err := c.do(ctx, req, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// AbandonChange abandons the change with the given ID.
+func (c *Client) AbandonChange(ctx context.Context, id string) error {
+	return c.postChangeAction(ctx, id, "abandon", nil)
+}
+
+// RestoreChange restores the abandoned change with the given ID.
+func (c *Client) RestoreChange(ctx context.Context, id string) error {
+	return c.postChangeAction(ctx, id, "restore", nil)
+}
+
+// SubmitChange submits the change with the given ID, merging it into its
+// branch. If the change doesn't satisfy the submit requirements of its
+// project, an error for which IsConflict returns true is returned.
+func (c *Client) SubmitChange(ctx context.Context, id string) error {
+	return c.postChangeAction(ctx, id, "submit", nil)
+}
+
+// SetWorkInProgress marks the change with the given ID as work in progress.
+func (c *Client) SetWorkInProgress(ctx context.Context, id string) error {
+	return c.postChangeAction(ctx, id, "wip", nil)
+}
+
+// SetReadyForReview marks the work in progress change with the given ID as
+// ready for review.
+func (c *Client) SetReadyForReview(ctx context.Context, id string) error {
+	return c.postChangeAction(ctx, id, "ready", nil)
+}
+
+// CreateChangeComment posts a message on the current revision of the change
+// with the given ID.
+func (c *Client) CreateChangeComment(ctx context.Context, id, message string) error {
+	return c.postChangeAction(ctx, id, "revisions/current/review", map[string]string{
+		"message": message,
+	})
+}
+
+func (c *Client) postChangeAction(ctx context.Context, id, action string, payload any) error {
+	var body []byte
+	if payload != nil {
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest("POST", changePath(id, action, nil), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	_, err = c.do(ctx, req, nil)
+	return err
+}
+
+// changePath returns the relative URL of the given endpoint of a change. The
+// ID is escaped, since triplets contain the project name, which can contain
+// slashes.
+func changePath(id, endpoint string, qs url.Values) string {
+	u := url.URL{
+		Path:    "a/changes/" + id,
+		RawPath: "a/changes/" + url.PathEscape(id),
+	}
+	if endpoint != "" {
+		u.Path += "/" + endpoint
+		u.RawPath += "/" + endpoint
+	}
+	u.RawQuery = qs.Encode()
+	return u.String()
+}
@@ -0,0 +1,120 @@
+package gerrit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestClient_GetChange(t *testing.T) {
+	var requestURI string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestURI = r.RequestURI
+		if r.URL.Path == "/a/changes/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		io.WriteString(w, `)]}'
+{
+  "id": "sourcegraph%2Fsourcegraph~main~I0123",
+  "project": "sourcegraph/sourcegraph",
+  "branch": "main",
+  "change_id": "I0123",
+  "_number": 42,
+  "subject": "Fix the thing",
+  "status": "NEW",
+  "created": "2022-11-23 09:30:15.123000000",
+  "updated": "2022-11-24 10:00:00.000000000",
+  "work_in_progress": true,
+  "owner": {"_account_id": 1, "username": "alice"},
+  "labels": {"Code-Review": {"approved": {"_account_id": 2}}},
+  "current_revision": "deadbeef",
+  "revisions": {"deadbeef": {"_number": 2, "ref": "refs/changes/42/42/2"}}
+}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	cli, err := NewClient("urn", &schema.GerritConnection{Url: srv.URL}, nil)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	t.Run("triplet", func(t *testing.T) {
+		change, err := cli.GetChange(ctx, ChangeTriplet("sourcegraph/sourcegraph", "refs/heads/main", "I0123"))
+		require.NoError(t, err)
+
+		assert.Contains(t, requestURI, "/a/changes/sourcegraph%2Fsourcegraph~main~I0123?")
+		assert.Equal(t, 42, change.Number)
+		assert.True(t, change.WorkInProgress)
+		assert.Equal(t, time.Date(2022, 11, 23, 9, 30, 15, 123000000, time.UTC), change.Created.Time)
+		assert.NotNil(t, change.Labels["Code-Review"].Approved)
+
+		rev, ok := change.CurrentRevisionInfo()
+		assert.True(t, ok)
+		assert.Equal(t, 2, rev.Number)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := cli.GetChange(ctx, "missing")
+		assert.True(t, errcode.IsNotFound(err))
+	})
+}
+
+func TestClient_ChangeActions(t *testing.T) {
+	var method, path, body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.EscapedPath()
+		bs, _ := io.ReadAll(r.Body)
+		body = string(bs)
+		if r.URL.Path == "/a/changes/42/submit" {
+			w.WriteHeader(http.StatusConflict)
+			io.WriteString(w, "change 42: needs Code-Review")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+
+	cli, err := NewClient("urn", &schema.GerritConnection{Url: srv.URL}, nil)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	for name, tc := range map[string]struct {
+		action   func(id string) error
+		wantPath string
+		wantBody string
+	}{
+		"abandon": {action: func(id string) error { return cli.AbandonChange(ctx, id) }, wantPath: "/a/changes/p%2Fq~main~I0/abandon"},
+		"restore": {action: func(id string) error { return cli.RestoreChange(ctx, id) }, wantPath: "/a/changes/p%2Fq~main~I0/restore"},
+		"wip":     {action: func(id string) error { return cli.SetWorkInProgress(ctx, id) }, wantPath: "/a/changes/p%2Fq~main~I0/wip"},
+		"ready":   {action: func(id string) error { return cli.SetReadyForReview(ctx, id) }, wantPath: "/a/changes/p%2Fq~main~I0/ready"},
+		"comment": {
+			action:   func(id string) error { return cli.CreateChangeComment(ctx, id, "hello") },
+			wantPath: "/a/changes/p%2Fq~main~I0/revisions/current/review",
+			wantBody: `{"message":"hello"}`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, tc.action(ChangeTriplet("p/q", "main", "I0")))
+			assert.Equal(t, "POST", method)
+			assert.Equal(t, tc.wantPath, path)
+			assert.Equal(t, tc.wantBody, body)
+		})
+	}
+
+	t.Run("submit conflict", func(t *testing.T) {
+		err := cli.SubmitChange(ctx, "42")
+		assert.Error(t, err)
+		assert.True(t, IsConflict(err))
+	})
+}
@@ -3,15 +3,16 @@ package gerrit
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -26,6 +27,10 @@ type Client struct {
 	// URL is the base URL of Gerrit.
 	URL *url.URL
 
+	// auth is used to authenticate requests. It defaults to the username and
+	// password of the code host connection.
+	auth auth.Authenticator
+
 	// RateLimit is the self-imposed rate limiter (since Gerrit does not have a concept
 	// of rate limiting in HTTP response headers).
 	rateLimit *ratelimit.InstrumentedLimiter
@@ -48,10 +53,45 @@ func NewClient(urn string, config *schema.GerritConnection, httpClient httpcli.D
 		httpClient: httpClient,
 		Config:     config,
 		URL:        u,
+		auth:       &auth.BasicAuth{Username: config.Username, Password: config.Password},
 		rateLimit:  ratelimit.DefaultRegistry.Get(urn),
 	}, nil
 }
 
+// Authenticator returns the authenticator used by the client.
+func (c *Client) Authenticator() auth.Authenticator {
+	return c.auth
+}
+
+// WithAuthenticator returns a copy of the client that uses the given
+// authenticator. Gerrit only supports HTTP basic authentication with the
+// HTTP password of an account.
+func (c *Client) WithAuthenticator(a auth.Authenticator) (*Client, error) {
+	switch a.(type) {
+	case *auth.BasicAuth, *auth.BasicAuthWithSSH:
+	default:
+		return nil, errors.Errorf("authenticator type unsupported for Gerrit clients: %T", a)
+	}
+
+	cc := *c
+	cc.auth = a
+	return &cc, nil
+}
+
+// GetAuthenticatedUserAccount returns the account of the authenticated user.
+func (c *Client) GetAuthenticatedUserAccount(ctx context.Context) (*Account, error) {
+	req, err := http.NewRequest("GET", "a/accounts/self", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var account Account
+	if _, err = c.do(ctx, req, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
 type ListAccountsResponse []Account
 
 func (c *Client) ListAccountsByEmail(ctx context.Context, email string) (ListAccountsResponse, error) {
@@ -171,7 +211,9 @@ func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.R
 	req.URL = c.URL.ResolveReference(req.URL)
 
 	// Add Basic Auth headers for authenticated requests.
-	req.Header.Add("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(c.Config.Username+":"+c.Config.Password)))
+	if err := c.auth.Authenticate(req); err != nil {
+		return nil, err
+	}
 
 	if err := c.rateLimit.Wait(ctx); err != nil {
 		return nil, err
@@ -199,6 +241,12 @@ func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.R
 		}
 	}
 
+	// Some endpoints, such as the one to set the work in progress state of a
+	// change, respond without a body.
+	if result == nil {
+		return resp, nil
+	}
+
 	// The first 4 characters of the Gerrit API responses need to be stripped, see: https://gerrit-review.googlesource.com/Documentation/rest-api.html#output .
 	if len(bs) < 4 {
 		return nil, &httpError{
@@ -265,3 +313,12 @@ func (e *httpError) Unauthorized() bool {
 func (e *httpError) NotFound() bool {
 	return e.StatusCode == http.StatusNotFound
 }
+
+// IsConflict reports whether err is an HTTP 409 Conflict error, which Gerrit
+// returns when an operation isn't possible in the current state of a change,
+// for example when submitting a change that doesn't satisfy the submit
+// requirements.
+func IsConflict(err error) bool {
+	var e *httpError
+	return errors.As(err, &e) && e.StatusCode == http.StatusConflict
+}
@@ -0,0 +1,230 @@
+//nolint:bodyclose // Body is closed in Client.do, but the response is still returned to provide access to the headers
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/peterhellberg/link"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/iterator"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// Client accesses a Gitea or Forgejo instance via the REST API.
+type Client struct {
+	// Config is the code host connection config for this client
+	Config *schema.GiteaConnection
+
+	// URL is the base URL of Gitea.
+	URL *url.URL
+
+	// HTTP Client used to communicate with the API
+	httpClient httpcli.Doer
+
+	// RateLimit is the self-imposed rate limiter (since Gitea does not have a concept
+	// of rate limiting in HTTP response headers).
+	rateLimit *ratelimit.InstrumentedLimiter
+
+	// perPage is the number of repositories requested per page.
+	perPage int
+}
+
+// NewClient returns an authenticated Gitea API client with the provided
+// configuration. If a nil httpClient is provided, httpcli.ExternalDoer will be
+// used.
+func NewClient(urn string, config *schema.GiteaConnection, httpClient httpcli.Doer) (*Client, error) {
+	u, err := url.Parse(config.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpClient == nil {
+		httpClient = httpcli.ExternalDoer
+	}
+
+	return &Client{
+		Config:     config,
+		URL:        u,
+		httpClient: httpClient,
+		rateLimit:  ratelimit.DefaultRegistry.Get(urn),
+		perPage:    50, // The default maximum page size of Gitea.
+	}, nil
+}
+
+// WithPerPage returns a copy of the client that requests perPage repositories
+// per page.
+func (c *Client) WithPerPage(perPage int) *Client {
+	cc := *c
+	cc.perPage = perPage
+	return &cc
+}
+
+// ListOrgRepos returns an iterator over the repositories of the given
+// organization.
+func (c *Client) ListOrgRepos(ctx context.Context, org string) *iterator.Iterator[*Repository] {
+	return c.listRepos(ctx, "api/v1/orgs/"+url.PathEscape(org)+"/repos", nil, false)
+}
+
+// ListUserRepos returns an iterator over the repositories owned by the given
+// user.
+func (c *Client) ListUserRepos(ctx context.Context, user string) *iterator.Iterator[*Repository] {
+	return c.listRepos(ctx, "api/v1/users/"+url.PathEscape(user)+"/repos", nil, false)
+}
+
+// SearchRepos returns an iterator over the repositories matching the given
+// keyword. An empty keyword matches all repositories visible to the client.
+func (c *Client) SearchRepos(ctx context.Context, keyword string) *iterator.Iterator[*Repository] {
+	qs := make(url.Values)
+	if keyword != "" {
+		qs.Set("q", keyword)
+	}
+	return c.listRepos(ctx, "api/v1/repos/search", qs, true)
+}
+
+// searchReposResponse is the envelope returned by the repository search
+// endpoint.
+type searchReposResponse struct {
+	OK   bool          `json:"ok"`
+	Data []*Repository `json:"data"`
+}
+
+// listRepos pages through the repositories returned by path. If search is true,
+// the repositories are wrapped in a searchReposResponse.
+func (c *Client) listRepos(ctx context.Context, path string, qs url.Values, search bool) *iterator.Iterator[*Repository] {
+	page := 1
+	done := false
+
+	return iterator.New(func() ([]*Repository, error) {
+		if done {
+			return nil, nil
+		}
+
+		q := make(url.Values, len(qs)+2)
+		for k, v := range qs {
+			q[k] = v
+		}
+		q.Set("page", strconv.Itoa(page))
+		q.Set("limit", strconv.Itoa(c.perPage))
+
+		u := url.URL{Path: path, RawQuery: q.Encode()}
+		req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var repos []*Repository
+		var resp *http.Response
+		if search {
+			var sr searchReposResponse
+			if resp, err = c.do(req, &sr); err != nil {
+				return nil, err
+			}
+			if !sr.OK {
+				return nil, errors.Errorf("Gitea repository search failed: url=%q", req.URL)
+			}
+			repos = sr.Data
+		} else if resp, err = c.do(req, &repos); err != nil {
+			return nil, err
+		}
+
+		// Gitea links to the next page for as long as there is one. See
+		// https://docs.gitea.io/en-us/api-usage/#pagination.
+		if link.ParseResponse(resp)["next"] == nil || len(repos) == 0 {
+			done = true
+		}
+		page++
+
+		return repos, nil
+	})
+}
+
+func (c *Client) do(req *http.Request, result any) (*http.Response, error) {
+	req.URL = c.URL.ResolveReference(req.URL)
+	req.Header.Set("Accept", "application/json")
+	if c.Config.Token != "" {
+		req.Header.Set("Authorization", "token "+c.Config.Token)
+	}
+
+	if err := c.rateLimit.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	return resp, json.Unmarshal(bs, result)
+}
+
+// User is a Gitea user or organization.
+type User struct {
+	ID       int64  `json:"id"`
+	Login    string `json:"login"`
+	FullName string `json:"full_name,omitempty"`
+}
+
+// Repository is a Gitea repository.
+type Repository struct {
+	ID            int64       `json:"id"`
+	Owner         *User       `json:"owner,omitempty"`
+	Name          string      `json:"name"`
+	FullName      string      `json:"full_name"`
+	Description   string      `json:"description"`
+	Empty         bool        `json:"empty"`
+	Private       bool        `json:"private"`
+	Fork          bool        `json:"fork"`
+	Parent        *Repository `json:"parent,omitempty"`
+	Mirror        bool        `json:"mirror"`
+	Archived      bool        `json:"archived"`
+	HTMLURL       string      `json:"html_url"`
+	SSHURL        string      `json:"ssh_url"`
+	CloneURL      string      `json:"clone_url"`
+	DefaultBranch string      `json:"default_branch"`
+	StarsCount    int         `json:"stars_count"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Gitea API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
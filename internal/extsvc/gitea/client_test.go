@@ -0,0 +1,81 @@
+package gitea
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/iterator"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestClient_ListOrgRepos(t *testing.T) {
+	var requests []string
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RequestURI())
+		assert.Equal(t, "token secret", r.Header.Get("Authorization"))
+
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Header().Set("Link", `<`+srv.URL+`/api/v1/orgs/forgejo/repos?limit=1&page=2>; rel="next",<`+srv.URL+`/api/v1/orgs/forgejo/repos?limit=1&page=2>; rel="last"`)
+			_, _ = w.Write([]byte(`[{"id":1,"full_name":"forgejo/forgejo","clone_url":"https://codeberg.org/forgejo/forgejo.git"}]`))
+		default:
+			w.Header().Set("Link", `<`+srv.URL+`/api/v1/orgs/forgejo/repos?limit=1&page=1>; rel="first"`)
+			_, _ = w.Write([]byte(`[{"id":2,"full_name":"forgejo/runner","archived":true}]`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	cli, err := NewClient("urn", &schema.GiteaConnection{Url: srv.URL, Token: "secret"}, nil)
+	require.NoError(t, err)
+
+	repos, err := iterator.Collect(cli.WithPerPage(1).ListOrgRepos(context.Background(), "forgejo"))
+	require.NoError(t, err)
+	assert.Equal(t, []*Repository{
+		{ID: 1, FullName: "forgejo/forgejo", CloneURL: "https://codeberg.org/forgejo/forgejo.git"},
+		{ID: 2, FullName: "forgejo/runner", Archived: true},
+	}, repos)
+	assert.Equal(t, []string{
+		"/api/v1/orgs/forgejo/repos?limit=1&page=1",
+		"/api/v1/orgs/forgejo/repos?limit=1&page=2",
+	}, requests)
+}
+
+func TestClient_SearchRepos(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/repos/search", r.URL.Path)
+		assert.Empty(t, r.Header.Get("Authorization"))
+
+		switch r.URL.Query().Get("q") {
+		case "runner":
+			_, _ = w.Write([]byte(`{"ok":true,"data":[{"id":2,"full_name":"forgejo/runner"}]}`))
+		case "broken":
+			_, _ = w.Write([]byte(`{"ok":false,"data":[]}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	cli, err := NewClient("urn", &schema.GiteaConnection{Url: srv.URL}, nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	repos, err := iterator.Collect(cli.SearchRepos(ctx, "runner"))
+	require.NoError(t, err)
+	assert.Equal(t, []*Repository{{ID: 2, FullName: "forgejo/runner"}}, repos)
+
+	_, err = iterator.Collect(cli.SearchRepos(ctx, "broken"))
+	assert.Error(t, err)
+
+	_, err = iterator.Collect(cli.SearchRepos(ctx, ""))
+	var e *httpError
+	require.True(t, errors.As(err, &e))
+	assert.True(t, e.Unauthorized())
+}
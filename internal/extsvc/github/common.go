@@ -174,6 +174,7 @@ type PullRequest struct {
 	BaseRefOid     string
 	HeadRefName    string
 	BaseRefName    string
+	Mergeable      string
 	Number         int64
 	Author         Actor
 	BaseRepository PullRequestRepo
@@ -183,8 +184,11 @@ type PullRequest struct {
 	TimelineItems  []TimelineItem
 	Commits        struct{ Nodes []CommitWithChecks }
 	IsDraft        bool
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	// MergeStateStatus is BEHIND when the base branch is protected by status
+	// checks that require the pull request to be up to date with it.
+	MergeStateStatus string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
 }
 
 // AssignedEvent represents an 'assigned' event on a PullRequest.
@@ -1397,6 +1401,7 @@ fragment pr on PullRequest {
   baseRefOid
   headRefName
   baseRefName
+  mergeable
   %s
   author {
     ...actor
@@ -1449,7 +1454,7 @@ func pullRequestFragments(version *semver.Version) (string, error) {
 		return fmt.Sprintf(timelineItemsFragment+pullRequestFragmentsFmtstr, "", timelineItemTypes), nil
 	}
 	if ghe221PlusOrDotComSemver.Check(version) {
-		return fmt.Sprintf(timelineItemsFragment+pullRequestFragmentsFmtstr, "isDraft", timelineItemTypes), nil
+		return fmt.Sprintf(timelineItemsFragment+pullRequestFragmentsFmtstr, "isDraft\n  mergeStateStatus", timelineItemTypes), nil
 	}
 	return "", errors.Errorf("unsupported version of GitHub: %s", version)
 }
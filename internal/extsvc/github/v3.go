@@ -721,6 +721,63 @@ func (c *V3Client) Fork(ctx context.Context, owner, repo string, org *string, fo
 	return convertRestRepo(restRepo), nil
 }
 
+// RequestPullRequestReviewers requests reviews from the given users and teams
+// on the pull request with the given number. Teams are given by their slug.
+//
+// API docs: https://docs.github.com/en/rest/pulls/review-requests#request-reviewers-for-a-pull-request
+func (c *V3Client) RequestPullRequestReviewers(ctx context.Context, owner, repo string, number int64, reviewers, teamReviewers []string) error {
+	payload := struct {
+		Reviewers     []string `json:"reviewers,omitempty"`
+		TeamReviewers []string `json:"team_reviewers,omitempty"`
+	}{Reviewers: reviewers, TeamReviewers: teamReviewers}
+
+	_, err := c.post(ctx, fmt.Sprintf("repos/%s/%s/pulls/%d/requested_reviewers", owner, repo, number), payload, &struct{}{})
+	return err
+}
+
+// AddIssueLabels adds the given labels to the issue or pull request with the
+// given number. Labels that don't exist yet are created.
+//
+// API docs: https://docs.github.com/en/rest/issues/labels#add-labels-to-an-issue
+func (c *V3Client) AddIssueLabels(ctx context.Context, owner, repo string, number int64, labels []string) error {
+	payload := struct {
+		Labels []string `json:"labels"`
+	}{Labels: labels}
+
+	_, err := c.post(ctx, fmt.Sprintf("repos/%s/%s/issues/%d/labels", owner, repo, number), payload, &[]struct{}{})
+	return err
+}
+
+// AddIssueAssignees assigns the given users to the issue or pull request with
+// the given number. Users that cannot be assigned are ignored by GitHub.
+//
+// API docs: https://docs.github.com/en/rest/issues/assignees#add-assignees-to-an-issue
+func (c *V3Client) AddIssueAssignees(ctx context.Context, owner, repo string, number int64, assignees []string) error {
+	payload := struct {
+		Assignees []string `json:"assignees"`
+	}{Assignees: assignees}
+
+	_, err := c.post(ctx, fmt.Sprintf("repos/%s/%s/issues/%d/assignees", owner, repo, number), payload, &struct{}{})
+	return err
+}
+
+// UpdatePullRequestBranch brings the head branch of the pull request with the
+// given number up to date with its base branch, by merging the base branch
+// into it. GitHub performs the update asynchronously.
+//
+// API docs: https://docs.github.com/en/rest/pulls/pulls#update-a-pull-request-branch
+func (c *V3Client) UpdatePullRequestBranch(ctx context.Context, owner, repo string, number int64) error {
+	req, err := http.NewRequest("PUT", fmt.Sprintf("repos/%s/%s/pulls/%d/update-branch", owner, repo, number), bytes.NewReader([]byte("{}")))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+
+	_, err = c.request(ctx, req, &struct{}{})
+	return err
+}
+
 // GetAppInstallation gets information of a GitHub App installation.
 //
 // API docs: https://docs.github.com/en/rest/reference/apps#get-an-installation-for-the-authenticated-app
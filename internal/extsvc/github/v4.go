@@ -138,9 +138,12 @@ func (c *V4Client) requestGraphQL(ctx context.Context, query string, vars map[st
 		return err
 	}
 
-	// Enable Checks API
+	// Enable Checks API, and the merge state status of pull requests on GitHub
+	// Enterprise versions that still have it in preview.
 	// https://developer.github.com/v4/previews/#checks
+	// https://developer.github.com/v4/previews/#merge-info-preview
 	req.Header.Add("Accept", "application/vnd.github.antiope-preview+json")
+	req.Header.Add("Accept", "application/vnd.github.merge-info-preview+json")
 	var respBody struct {
 		Data   json.RawMessage `json:"data"`
 		Errors graphqlErrors   `json:"errors"`
@@ -603,6 +606,42 @@ func (c *V4Client) Fork(ctx context.Context, owner, repo string, org *string, fo
 	return NewV3Client(logger, c.urn, c.apiURL, c.auth, c.httpClient).Fork(ctx, owner, repo, org, forkName)
 }
 
+// RequestPullRequestReviewers requests reviews from the given users and teams
+// on the pull request with the given number.
+func (c *V4Client) RequestPullRequestReviewers(ctx context.Context, owner, repo string, number int64, reviewers, teamReviewers []string) error {
+	// The GraphQL API requires node IDs of users and teams to request reviews,
+	// whereas the REST API accepts their logins and slugs.
+	logger := c.log.Scoped("RequestPullRequestReviewers", "temporary client for requesting GitHub pull request reviewers")
+	return NewV3Client(logger, c.urn, c.apiURL, c.auth, c.httpClient).RequestPullRequestReviewers(ctx, owner, repo, number, reviewers, teamReviewers)
+}
+
+// AddIssueLabels adds the given labels to the issue or pull request with the
+// given number.
+func (c *V4Client) AddIssueLabels(ctx context.Context, owner, repo string, number int64, labels []string) error {
+	// The GraphQL API requires node IDs of existing labels, whereas the REST
+	// API accepts label names and creates missing labels.
+	logger := c.log.Scoped("AddIssueLabels", "temporary client for labelling GitHub issues")
+	return NewV3Client(logger, c.urn, c.apiURL, c.auth, c.httpClient).AddIssueLabels(ctx, owner, repo, number, labels)
+}
+
+// AddIssueAssignees assigns the given users to the issue or pull request with
+// the given number.
+func (c *V4Client) AddIssueAssignees(ctx context.Context, owner, repo string, number int64, assignees []string) error {
+	// The GraphQL API requires node IDs of users, whereas the REST API accepts
+	// their logins.
+	logger := c.log.Scoped("AddIssueAssignees", "temporary client for assigning GitHub issues")
+	return NewV3Client(logger, c.urn, c.apiURL, c.auth, c.httpClient).AddIssueAssignees(ctx, owner, repo, number, assignees)
+}
+
+// UpdatePullRequestBranch brings the head branch of the pull request with the
+// given number up to date with its base branch.
+func (c *V4Client) UpdatePullRequestBranch(ctx context.Context, owner, repo string, number int64) error {
+	// The GraphQL mutation is not available on all GitHub Enterprise versions
+	// we support, whereas the REST endpoint is.
+	logger := c.log.Scoped("UpdatePullRequestBranch", "temporary client for updating GitHub pull request branches")
+	return NewV3Client(logger, c.urn, c.apiURL, c.auth, c.httpClient).UpdatePullRequestBranch(ctx, owner, repo, number)
+}
+
 type RecentCommittersParams struct {
 	// Repository name
 	Name string
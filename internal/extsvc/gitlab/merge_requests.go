@@ -46,7 +46,11 @@ type MergeRequest struct {
 	WebURL                 string            `json:"web_url"`
 	WorkInProgress         bool              `json:"work_in_progress"`
 	Draft                  bool              `json:"draft"`
+	HasConflicts           bool              `json:"has_conflicts"`
+	DetailedMergeStatus    string            `json:"detailed_merge_status"`
 	Author                 User              `json:"author"`
+	Assignees              []User            `json:"assignees"`
+	Reviewers              []User            `json:"reviewers"`
 
 	DiffRefs DiffRefs `json:"diff_refs"`
 
@@ -221,6 +225,13 @@ type UpdateMergeRequestOpts struct {
 	Title        string                       `json:"title,omitempty"`
 	Description  string                       `json:"description,omitempty"`
 	StateEvent   UpdateMergeRequestStateEvent `json:"state_event,omitempty"`
+	// AddLabels is a comma separated list of labels to add to the merge
+	// request, in addition to its existing labels.
+	AddLabels string `json:"add_labels,omitempty"`
+	// AssigneeIDs and ReviewerIDs replace the assignees and reviewers of the
+	// merge request.
+	AssigneeIDs []int32 `json:"assignee_ids,omitempty"`
+	ReviewerIDs []int32 `json:"reviewer_ids,omitempty"`
 }
 
 type UpdateMergeRequestStateEvent string
@@ -308,6 +319,33 @@ func (c *Client) MergeMergeRequest(ctx context.Context, project *Project, mr *Me
 	return resp, nil
 }
 
+// RebaseMergeRequest rebases the source branch of the merge request onto its
+// target branch. GitLab performs the rebase asynchronously.
+func (c *Client) RebaseMergeRequest(ctx context.Context, project *Project, mr *MergeRequest) error {
+	if MockRebaseMergeRequest != nil {
+		return MockRebaseMergeRequest(c, ctx, project, mr)
+	}
+
+	time.Sleep(c.rateLimitMonitor.RecommendedWaitForBackgroundOp(1))
+
+	req, err := http.NewRequest("PUT", fmt.Sprintf("projects/%d/merge_requests/%d/rebase", project.ID, mr.IID), nil)
+	if err != nil {
+		return errors.Wrap(err, "creating request to rebase a merge request")
+	}
+
+	var resp struct {
+		RebaseInProgress bool `json:"rebase_in_progress"`
+	}
+	if _, _, err := c.do(ctx, req, &resp); err != nil {
+		if aerr := c.convertToArchivedError(ctx, err, project); aerr != nil {
+			return aerr
+		}
+		return errors.Wrap(err, "sending request to rebase a merge request")
+	}
+
+	return nil
+}
+
 func (c *Client) CreateMergeRequestNote(ctx context.Context, project *Project, mr *MergeRequest, body string) error {
 	if MockCreateMergeRequestNote != nil {
 		return MockCreateMergeRequestNote(c, ctx, project, mr, body)
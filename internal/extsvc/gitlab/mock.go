@@ -49,6 +49,10 @@ var MockUpdateMergeRequest func(c *Client, ctx context.Context, project *Project
 // Client.MergeMergeRequest
 var MockMergeMergeRequest func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, squash bool) (*MergeRequest, error)
 
+// MockRebaseMergeRequest, if non-nil, will be called instead of
+// Client.RebaseMergeRequest
+var MockRebaseMergeRequest func(c *Client, ctx context.Context, project *Project, mr *MergeRequest) error
+
 // MockCreateMergeRequestNote, if non-nil, will be called instead of
 // Client.CreateMergeRequestNote
 var MockCreateMergeRequestNote func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, body string) error
@@ -0,0 +1,191 @@
+// Package nuget
+//
+// A client for the NuGet v3 server API as described in
+// https://learn.microsoft.com/en-us/nuget/api/overview.
+//
+// A NuGet feed is described by its service index, which lists the resources
+// the feed provides. Package archives (.nupkg files) are downloaded from the
+// PackageBaseAddress resource, also called the flat container, which is
+// implemented by every v3 feed, including nuget.org, Azure Artifacts and
+// Artifactory.
+package nuget
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// DefaultServiceIndexURL is the service index of nuget.org.
+const DefaultServiceIndexURL = "https://api.nuget.org/v3/index.json"
+
+// packageBaseAddressType is the type of the flat container resource in the
+// service index.
+const packageBaseAddressType = "PackageBaseAddress/3.0.0"
+
+type Client struct {
+	serviceIndexURL string
+	username        string
+	password        string
+
+	cli httpcli.Doer
+
+	// Self-imposed rate-limiter.
+	limiter *ratelimit.InstrumentedLimiter
+
+	mu                 sync.Mutex
+	packageBaseAddress string
+}
+
+// NewClient returns a client for the feed with the given service index URL.
+// If password is not empty, requests to the host of the service index are
+// authenticated with HTTP basic auth.
+func NewClient(urn, serviceIndexURL, username, password string, cli httpcli.Doer) *Client {
+	if serviceIndexURL == "" {
+		serviceIndexURL = DefaultServiceIndexURL
+	}
+	return &Client{
+		serviceIndexURL: serviceIndexURL,
+		username:        username,
+		password:        password,
+		cli:             cli,
+		limiter:         ratelimit.DefaultRegistry.Get(urn),
+	}
+}
+
+// GetPackageContents returns the .nupkg archive of the given package version,
+// and the URL it was downloaded from.
+func (c *Client) GetPackageContents(ctx context.Context, dep reposource.VersionedPackage) (body io.ReadCloser, url string, err error) {
+	base, err := c.getPackageBaseAddress(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// The flat container expects lowercase IDs and versions.
+	id := strings.ToLower(string(dep.PackageSyntax()))
+	version := strings.ToLower(dep.PackageVersion())
+	url = fmt.Sprintf("%s/%s/%s/%s.%s.nupkg", base, id, version, id, version)
+
+	body, err = c.get(ctx, url)
+	if err != nil {
+		return nil, url, err
+	}
+	return body, url, nil
+}
+
+type serviceIndex struct {
+	Resources []struct {
+		ID   string `json:"@id"`
+		Type string `json:"@type"`
+	} `json:"resources"`
+}
+
+// getPackageBaseAddress returns the URL of the flat container of the feed,
+// without a trailing slash. The service index is only fetched once.
+func (c *Client) getPackageBaseAddress(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.packageBaseAddress != "" {
+		return c.packageBaseAddress, nil
+	}
+
+	body, err := c.get(ctx, c.serviceIndexURL)
+	if err != nil {
+		return "", errors.Wrap(err, "fetching NuGet service index")
+	}
+	defer body.Close()
+
+	var index serviceIndex
+	if err := json.NewDecoder(body).Decode(&index); err != nil {
+		return "", errors.Wrap(err, "decoding NuGet service index")
+	}
+
+	for _, r := range index.Resources {
+		if r.Type == packageBaseAddressType {
+			c.packageBaseAddress = strings.TrimSuffix(r.ID, "/")
+			return c.packageBaseAddress, nil
+		}
+	}
+	return "", errors.Newf("NuGet service index %s has no %s resource", c.serviceIndexURL, packageBaseAddressType)
+}
+
+func (c *Client) get(ctx context.Context, u string) (io.ReadCloser, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("User-Agent", "sourcegraph-nuget-syncer (sourcegraph.com)")
+
+	// Resources of a feed are usually served from the host of its service
+	// index, but nuget.org serves them from CDNs. Credentials are only sent to
+	// the host they were configured for.
+	if c.password != "" && sameHost(u, c.serviceIndexURL) {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	return c.do(req)
+}
+
+func sameHost(a, b string) bool {
+	ua, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+	ub, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(ua.Host, ub.Host)
+}
+
+type Error struct {
+	path    string
+	code    int
+	message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("bad response with status code %d for %s: %s", e.code, e.path, e.message)
+}
+
+func (e *Error) NotFound() bool {
+	return e.code == http.StatusNotFound
+}
+
+func (e *Error) Unauthorized() bool {
+	return e.code == http.StatusUnauthorized
+}
+
+func (c *Client) do(req *http.Request) (io.ReadCloser, error) {
+	resp, err := c.cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+
+		bs, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, &Error{path: req.URL.Path, code: resp.StatusCode, message: fmt.Sprintf("failed to read non-200 body: %v", err)}
+		}
+		return nil, &Error{path: req.URL.Path, code: resp.StatusCode, message: string(bs)}
+	}
+
+	return resp.Body, nil
+}
@@ -0,0 +1,80 @@
+package nuget
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func TestGetPackageContents(t *testing.T) {
+	var indexRequests int
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pat" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v3/index.json":
+			indexRequests++
+			fmt.Fprintf(w, `{
+  "version": "3.0.0",
+  "resources": [
+    {"@id": "%[1]s/v3/query", "@type": "SearchQueryService"},
+    {"@id": "%[1]s/v3/flatcontainer/", "@type": "PackageBaseAddress/3.0.0"}
+  ]
+}`, srv.URL)
+		case "/v3/flatcontainer/contoso.client/2.1.0-beta/contoso.client.2.1.0-beta.nupkg":
+			io.WriteString(w, "nupkg")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := context.Background()
+	client := NewClient("nuget_urn", srv.URL+"/v3/index.json", "user", "pat", http.DefaultClient)
+
+	dep := reposource.NewNuGetVersionedPackage("Contoso.Client", "2.1.0-Beta")
+	body, url, err := client.GetPackageContents(ctx, dep)
+	require.NoError(t, err)
+	defer body.Close()
+
+	assert.Equal(t, srv.URL+"/v3/flatcontainer/contoso.client/2.1.0-beta/contoso.client.2.1.0-beta.nupkg", url)
+	contents, err := io.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, "nupkg", string(contents))
+
+	t.Run("not found", func(t *testing.T) {
+		_, _, err := client.GetPackageContents(ctx, reposource.NewNuGetVersionedPackage("Contoso.Client", "9.9.9"))
+		assert.True(t, errcode.IsNotFound(err))
+		assert.Equal(t, 1, indexRequests, "service index should be cached")
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		client := NewClient("nuget_urn", srv.URL+"/v3/index.json", "user", "wrong", http.DefaultClient)
+		_, _, err := client.GetPackageContents(ctx, dep)
+		assert.True(t, errcode.IsUnauthorized(err))
+	})
+}
+
+func TestGetPackageContents_NoPackageBaseAddress(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"version": "3.0.0", "resources": []}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewClient("nuget_urn", srv.URL+"/v3/index.json", "", "", http.DefaultClient)
+	_, _, err := client.GetPackageContents(context.Background(), reposource.NewNuGetVersionedPackage("Dapper", "2.0.123"))
+	assert.ErrorContains(t, err, "has no PackageBaseAddress/3.0.0 resource")
+}
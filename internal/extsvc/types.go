@@ -113,9 +113,11 @@ const (
 	// in preference to the Type values below.
 
 	KindAWSCodeCommit   = "AWSCODECOMMIT"
+	KindAzureDevOps     = "AZUREDEVOPS"
 	KindBitbucketServer = "BITBUCKETSERVER"
 	KindBitbucketCloud  = "BITBUCKETCLOUD"
 	KindGerrit          = "GERRIT"
+	KindGitea           = "GITEA"
 	KindGitHub          = "GITHUB"
 	KindGitLab          = "GITLAB"
 	KindGitolite        = "GITOLITE"
@@ -126,6 +128,7 @@ const (
 	KindPythonPackages  = "PYTHONPACKAGES"
 	KindRustPackages    = "RUSTPACKAGES"
 	KindRubyPackages    = "RUBYPACKAGES"
+	KindNuGetPackages   = "NUGETPACKAGES"
 	KindNpmPackages     = "NPMPACKAGES"
 	KindPagure          = "PAGURE"
 	KindOther           = "OTHER"
@@ -147,9 +150,18 @@ const (
 	// ServiceID value is the base URL to the Bitbucket Cloud.
 	TypeBitbucketCloud = "bitbucketCloud"
 
+	// TypeAzureDevOps is the (api.ExternalRepoSpec).ServiceType value for Azure DevOps repositories. The
+	// ServiceID value is the base URL to Azure DevOps (https://dev.azure.com or the Azure DevOps Server
+	// collection URL).
+	TypeAzureDevOps = "azuredevops"
+
 	// TypeGerrit is the (api.ExternalRepoSpec).ServiceType value for Gerrit projects.
 	TypeGerrit = "gerrit"
 
+	// TypeGitea is the (api.ExternalRepoSpec).ServiceType value for Gitea and Forgejo repositories. The
+	// ServiceID value is the base URL to the Gitea instance.
+	TypeGitea = "gitea"
+
 	// TypeGitHub is the (api.ExternalRepoSpec).ServiceType value for GitHub repositories. The ServiceID value
 	// is the base URL to the GitHub instance (https://github.com or the GitHub Enterprise URL).
 	TypeGitHub = "github"
@@ -188,6 +200,9 @@ const (
 	// TypeRubyPackages is the (api.ExternalRepoSpec).ServiceType value for Ruby packages.
 	TypeRubyPackages = "rubyPackages"
 
+	// TypeNuGetPackages is the (api.ExternalRepoSpec).ServiceType value for NuGet packages.
+	TypeNuGetPackages = "nugetPackages"
+
 	// TypeOther is the (api.ExternalRepoSpec).ServiceType value for other projects.
 	TypeOther = "other"
 )
@@ -202,8 +217,12 @@ func KindToType(kind string) string {
 		return TypeBitbucketServer
 	case KindBitbucketCloud:
 		return TypeBitbucketCloud
+	case KindAzureDevOps:
+		return TypeAzureDevOps
 	case KindGerrit:
 		return TypeGerrit
+	case KindGitea:
+		return TypeGitea
 	case KindGitHub:
 		return TypeGitHub
 	case KindGitLab:
@@ -222,6 +241,8 @@ func KindToType(kind string) string {
 		return TypeRustPackages
 	case KindRubyPackages:
 		return TypeRubyPackages
+	case KindNuGetPackages:
+		return TypeNuGetPackages
 	case KindNpmPackages:
 		return TypeNpmPackages
 	case KindGoPackages:
@@ -245,8 +266,12 @@ func TypeToKind(t string) string {
 		return KindBitbucketServer
 	case TypeBitbucketCloud:
 		return KindBitbucketCloud
+	case TypeAzureDevOps:
+		return KindAzureDevOps
 	case TypeGerrit:
 		return KindGerrit
+	case TypeGitea:
+		return KindGitea
 	case TypeGitHub:
 		return KindGitHub
 	case TypeGitLab:
@@ -267,6 +292,8 @@ func TypeToKind(t string) string {
 		return KindRustPackages
 	case TypeRubyPackages:
 		return KindRubyPackages
+	case TypeNuGetPackages:
+		return KindNuGetPackages
 	case TypeGoModules:
 		return KindGoPackages
 	case TypePagure:
@@ -288,6 +315,7 @@ var (
 	pythonLower = strings.ToLower(TypePythonPackages)
 	rustLower   = strings.ToLower(TypeRustPackages)
 	rubyLower   = strings.ToLower(TypeRubyPackages)
+	nugetLower  = strings.ToLower(TypeNuGetPackages)
 )
 
 // ParseServiceType will return a ServiceType constant after doing a case insensitive match on s.
@@ -300,8 +328,12 @@ func ParseServiceType(s string) (string, bool) {
 		return TypeBitbucketServer, true
 	case bbcLower:
 		return TypeBitbucketCloud, true
+	case TypeAzureDevOps:
+		return TypeAzureDevOps, true
 	case TypeGerrit:
 		return TypeGerrit, true
+	case TypeGitea:
+		return TypeGitea, true
 	case TypeGitHub:
 		return TypeGitHub, true
 	case TypeGitLab:
@@ -324,6 +356,8 @@ func ParseServiceType(s string) (string, bool) {
 		return TypeRustPackages, true
 	case rubyLower:
 		return TypeRubyPackages, true
+	case nugetLower:
+		return TypeNuGetPackages, true
 	case TypePagure:
 		return TypePagure, true
 	case TypeOther:
@@ -343,8 +377,12 @@ func ParseServiceKind(s string) (string, bool) {
 		return KindBitbucketServer, true
 	case KindBitbucketCloud:
 		return KindBitbucketCloud, true
+	case KindAzureDevOps:
+		return KindAzureDevOps, true
 	case KindGerrit:
 		return KindGerrit, true
+	case KindGitea:
+		return KindGitea, true
 	case KindGitHub:
 		return KindGitHub, true
 	case KindGitLab:
@@ -365,6 +403,8 @@ func ParseServiceKind(s string) (string, bool) {
 		return KindRustPackages, true
 	case KindRubyPackages:
 		return KindRubyPackages, true
+	case KindNuGetPackages:
+		return KindNuGetPackages, true
 	case KindPagure:
 		return KindPagure, true
 	case KindOther:
@@ -422,8 +462,12 @@ func getConfigPrototype(kind string) (any, error) {
 		return &schema.BitbucketServerConnection{}, nil
 	case KindBitbucketCloud:
 		return &schema.BitbucketCloudConnection{}, nil
+	case KindAzureDevOps:
+		return &schema.AzureDevOpsConnection{}, nil
 	case KindGerrit:
 		return &schema.GerritConnection{}, nil
+	case KindGitea:
+		return &schema.GiteaConnection{}, nil
 	case KindGitHub:
 		return &schema.GitHubConnection{}, nil
 	case KindGitLab:
@@ -448,6 +492,8 @@ func getConfigPrototype(kind string) (any, error) {
 		return &schema.RustPackagesConnection{}, nil
 	case KindRubyPackages:
 		return &schema.RubyPackagesConnection{}, nil
+	case KindNuGetPackages:
+		return &schema.NuGetPackagesConnection{}, nil
 	case KindOther:
 		return &schema.OtherExternalServiceConnection{}, nil
 	default:
@@ -519,6 +565,10 @@ func extractToken(parsed any, kind string) (string, error) {
 		return c.Token, nil
 	case *schema.PagureConnection:
 		return c.Token, nil
+	case *schema.GiteaConnection:
+		return c.Token, nil
+	case *schema.AzureDevOpsConnection:
+		return c.Token, nil
 	default:
 		return "", errors.Errorf("unable to extract token for service kind %q", kind)
 	}
@@ -602,6 +652,12 @@ func GetLimitFromConfig(kind string, config any) (rate.Limit, error) {
 		if c != nil && c.RateLimit != nil {
 			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
 		}
+	case *schema.GiteaConnection:
+		// 8/s is the default limit we enforce
+		limit = rate.Limit(8)
+		if c != nil && c.RateLimit != nil {
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
 	case *schema.NpmPackagesConnection:
 		limit = rate.Limit(6000 / 3600.0) // Same as the default in npm-packages.schema.json
 		if c != nil && c.RateLimit != nil {
@@ -634,6 +690,13 @@ func GetLimitFromConfig(kind string, config any) (rate.Limit, error) {
 		if c != nil && c.RateLimit != nil {
 			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
 		}
+	case *schema.NuGetPackagesConnection:
+		// nuget.org doesn't document a rate limit for its package content API,
+		// which is served from a CDN.
+		limit = rate.Limit(20)
+		if c != nil && c.RateLimit != nil {
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
 	default:
 		return limit, ErrRateLimitUnsupported{codehostKind: kind}
 	}
@@ -731,6 +794,10 @@ func uniqueCodeHostIdentifier(kind string, cfg any) (string, error) {
 		rawURL = c.Url
 	case *schema.GerritConnection:
 		rawURL = c.Url
+	case *schema.GiteaConnection:
+		rawURL = c.Url
+	case *schema.AzureDevOpsConnection:
+		rawURL = c.Url
 	case *schema.PhabricatorConnection:
 		rawURL = c.Url
 	case *schema.OtherExternalServiceConnection:
@@ -756,6 +823,8 @@ func uniqueCodeHostIdentifier(kind string, cfg any) (string, error) {
 		return KindRustPackages, nil
 	case *schema.RubyPackagesConnection:
 		return KindRubyPackages, nil
+	case *schema.NuGetPackagesConnection:
+		return KindNuGetPackages, nil
 	case *schema.PagureConnection:
 		rawURL = c.Url
 	default:
@@ -37,6 +37,16 @@ func TestExtractToken(t *testing.T) {
 			kind:   KindPhabricator,
 			want:   "deadbeef",
 		},
+		{
+			config: `{"token": "deadbeef"}`,
+			kind:   KindGitea,
+			want:   "deadbeef",
+		},
+		{
+			config: `{"url": "https://dev.azure.com", "username": "admin", "token": "deadbeef"}`,
+			kind:   KindAzureDevOps,
+			want:   "deadbeef",
+		},
 	} {
 		t.Run(tc.kind, func(t *testing.T) {
 			have, err := ExtractToken(tc.config, tc.kind)
@@ -289,6 +299,16 @@ func TestUniqueCodeHostIdentifier(t *testing.T) {
 			config: `{"url": "https://example.com"}`,
 			want:   "https://example.com/",
 		},
+		{
+			kind:   KindGitea,
+			config: `{"url": "https://codeberg.org"}`,
+			want:   "https://codeberg.org/",
+		},
+		{
+			kind:   KindAzureDevOps,
+			config: `{"url": "https://dev.azure.com", "username": "admin", "token": "deadbeef"}`,
+			want:   "https://dev.azure.com/",
+		},
 		{
 			kind:   KindBitbucketServer,
 			config: `{"url": "https://bitbucket.sgdev.org/"}`,
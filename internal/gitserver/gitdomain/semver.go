@@ -0,0 +1,95 @@
+package gitdomain
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// SemverRange is a compiled range of semantic versions, like ">=3.0.0 <4.2.0",
+// which matches tags whose names are semantic versions. Use CompileSemverRange
+// to create it.
+type SemverRange struct {
+	constraints *semver.Constraints
+}
+
+// CompileSemverRange compiles a range of semantic versions. Comparisons are
+// separated by whitespace or commas, which must all be satisfied, and
+// alternatives are separated by "||". Hyphen ranges like "1.2 - 1.4", tilde
+// ranges and caret ranges are supported as well.
+func CompileSemverRange(r string) (*SemverRange, error) {
+	if strings.TrimSpace(r) == "" {
+		return nil, errors.New("empty semver range")
+	}
+	c, err := semver.NewConstraint(normalizeSemverRange(r))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid semver range %q", r)
+	}
+	return &SemverRange{constraints: c}, nil
+}
+
+// normalizeSemverRange rewrites whitespace separated comparisons like
+// ">= 3.0.0 <4.2.0" to the comma separated ">=3.0.0,<4.2.0" which the semver
+// library expects.
+func normalizeSemverRange(r string) string {
+	ors := strings.Split(r, "||")
+	for i, or := range ors {
+		fields := strings.Fields(strings.ReplaceAll(or, ",", " "))
+		var ands []string
+		for j := 0; j < len(fields); j++ {
+			f := fields[j]
+			switch {
+			case f == "-" && len(ands) > 0 && j+1 < len(fields):
+				// Keep hyphen ranges intact, the library rewrites them itself.
+				j++
+				ands[len(ands)-1] += " - " + fields[j]
+			case strings.Trim(f, "<>=!~^") == "" && j+1 < len(fields):
+				// An operator separated from its version.
+				j++
+				ands = append(ands, f+fields[j])
+			default:
+				ands = append(ands, f)
+			}
+		}
+		ors[i] = strings.Join(ands, ",")
+	}
+	return strings.Join(ors, "||")
+}
+
+// MatchTags returns the full names of the tags in refs whose short names, with
+// an optional "v" prefix, are semantic versions in the range. They are ordered
+// from the highest version to the lowest, and at most limit names are
+// returned if limit is positive.
+func (r *SemverRange) MatchTags(refs []Ref, limit int) []string {
+	type tag struct {
+		name    string
+		version *semver.Version
+	}
+	var tags []tag
+	for _, ref := range refs {
+		if !strings.HasPrefix(ref.Name, "refs/tags/") {
+			continue
+		}
+		v, err := semver.NewVersion(strings.TrimPrefix(strings.TrimPrefix(ref.Name, "refs/tags/"), "v"))
+		if err != nil || !r.constraints.Check(v) {
+			continue
+		}
+		tags = append(tags, tag{name: ref.Name, version: v})
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].version.GreaterThan(tags[j].version)
+	})
+	if limit > 0 && len(tags) > limit {
+		tags = tags[:limit]
+	}
+
+	names := make([]string, 0, len(tags))
+	for _, t := range tags {
+		names = append(names, t.name)
+	}
+	return names
+}
@@ -0,0 +1,66 @@
+package gitdomain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSemverRangeMatchTags(t *testing.T) {
+	refs := []Ref{
+		{Name: "refs/heads/v3.5.0"},
+		{Name: "refs/tags/v2.9.9"},
+		{Name: "refs/tags/v3.0.0"},
+		{Name: "refs/tags/v3.10.1"},
+		{Name: "refs/tags/3.2.0"},
+		{Name: "refs/tags/v3.3.0-beta.1"},
+		{Name: "refs/tags/v4.2.0"},
+		{Name: "refs/tags/nightly"},
+	}
+
+	tests := []struct {
+		semverRange string
+		limit       int
+		want        []string
+	}{
+		{
+			semverRange: ">=3.0.0 <4.2.0",
+			want:        []string{"refs/tags/v3.10.1", "refs/tags/3.2.0", "refs/tags/v3.0.0"},
+		},
+		{
+			semverRange: ">= 3.0.0, < 4.2.0",
+			limit:       2,
+			want:        []string{"refs/tags/v3.10.1", "refs/tags/3.2.0"},
+		},
+		{
+			semverRange: "3.0 - 3.2 || ^4",
+			want:        []string{"refs/tags/v4.2.0", "refs/tags/3.2.0", "refs/tags/v3.0.0"},
+		},
+		{
+			semverRange: "~3.3.0-0",
+			want:        []string{"refs/tags/v3.3.0-beta.1"},
+		},
+		{
+			semverRange: ">5",
+			want:        []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.semverRange, func(t *testing.T) {
+			r, err := CompileSemverRange(tt.semverRange)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := r.MatchTags(refs, tt.limit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileSemverRangeErrors(t *testing.T) {
+	for _, r := range []string{"", " ", ">=", "foo", ">=3.0.0 <"} {
+		if _, err := CompileSemverRange(r); err == nil {
+			t.Errorf("expected error for %q", r)
+		}
+	}
+}
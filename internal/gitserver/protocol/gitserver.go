@@ -318,6 +318,12 @@ type PushConfig struct {
 	// Passphrase is the passphrase to decrypt the private key. It is required
 	// when passing PrivateKey.
 	Passphrase string
+
+	// PushRef is the ref on the remote to which the commit is pushed. If
+	// empty, the commit is pushed to the target ref of the request. Code hosts
+	// such as Gerrit review commits pushed to special refs, rather than
+	// branches.
+	PushRef string
 }
 
 // CreateCommitFromPatchResponse is the response type returned after creating
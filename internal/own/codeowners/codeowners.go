@@ -0,0 +1,273 @@
+// Package codeowners parses CODEOWNERS files and finds the owners of paths.
+//
+// The GitHub, GitLab and Bitbucket flavors of the format are supported:
+//
+//   - Each rule is a gitignore-style path pattern followed by owners, which
+//     are @user or @org/team handles, @@group handles or email addresses.
+//   - When several rules match a path, the last one takes precedence.
+//   - GitLab sections, such as [Backend] @backend-team, group rules. Each
+//     section contributes the owners of its last matching rule, and rules
+//     without owners fall back to the default owners of their section.
+package codeowners
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Owner is a user, team or group handle, or an email address.
+type Owner struct {
+	// Handle is the handle without leading @, e.g. alice or org/team.
+	Handle string
+	Email  string
+}
+
+func (o Owner) String() string {
+	if o.Handle != "" {
+		return "@" + o.Handle
+	}
+	return o.Email
+}
+
+// Matches returns true if the owner is identified by the given handle or
+// email, ignoring case and leading @.
+func (o Owner) Matches(handleOrEmail string) bool {
+	handleOrEmail = strings.TrimLeft(handleOrEmail, "@")
+	if o.Handle != "" {
+		return strings.EqualFold(o.Handle, handleOrEmail)
+	}
+	return strings.EqualFold(o.Email, handleOrEmail)
+}
+
+// Rule assigns owners to the paths matched by a pattern.
+type Rule struct {
+	Pattern string
+	Owners  []Owner
+	// LineNumber is the 1-based line of the rule in the CODEOWNERS file.
+	LineNumber int
+
+	matcher *regexp.Regexp
+}
+
+// Section is a GitLab section of rules. Rules outside of any section belong
+// to an unnamed section.
+type Section struct {
+	Name          string
+	DefaultOwners []Owner
+	Rules         []*Rule
+}
+
+// Ruleset is a parsed CODEOWNERS file.
+type Ruleset struct {
+	// Path is the path of the CODEOWNERS file in the repository.
+	Path     string
+	Sections []*Section
+}
+
+// Parse parses the CODEOWNERS file at the given path in the repository.
+func Parse(path string, r io.Reader) (*Ruleset, error) {
+	rs := &Ruleset{Path: path}
+	section := &Section{}
+	rs.Sections = append(rs.Sections, section)
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if name, owners, ok := parseSectionHeader(line); ok {
+			section = &Section{Name: name, DefaultOwners: owners}
+			rs.Sections = append(rs.Sections, section)
+			continue
+		}
+
+		pattern, rest := splitPattern(line)
+		matcher, err := compilePattern(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s:%d: invalid pattern %q", path, lineNumber, pattern)
+		}
+		section.Rules = append(section.Rules, &Rule{
+			Pattern:    pattern,
+			Owners:     parseOwners(rest),
+			LineNumber: lineNumber,
+			matcher:    matcher,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rs, nil
+}
+
+// FindOwners returns the owners of the given path, which is relative to the
+// root of the repository. It returns nil if the path has no owners.
+func (rs *Ruleset) FindOwners(path string) []Owner {
+	if rs == nil {
+		return nil
+	}
+
+	path = strings.TrimPrefix(path, "/")
+
+	var owners []Owner
+	seen := map[Owner]struct{}{}
+	for _, section := range rs.Sections {
+		rule := section.match(path)
+		if rule == nil {
+			continue
+		}
+		sectionOwners := rule.Owners
+		if len(sectionOwners) == 0 {
+			sectionOwners = section.DefaultOwners
+		}
+		for _, owner := range sectionOwners {
+			if _, ok := seen[owner]; ok {
+				continue
+			}
+			seen[owner] = struct{}{}
+			owners = append(owners, owner)
+		}
+	}
+	return owners
+}
+
+// match returns the last rule of the section that matches path.
+func (s *Section) match(path string) *Rule {
+	for i := len(s.Rules) - 1; i >= 0; i-- {
+		if s.Rules[i].Match(path) {
+			return s.Rules[i]
+		}
+	}
+	return nil
+}
+
+// Match returns true if the rule applies to the given path.
+func (r *Rule) Match(path string) bool {
+	pattern := r.Pattern
+	dirOnly := strings.HasSuffix(pattern, "/")
+
+	if !dirOnly && r.matcher.MatchString(path) {
+		return true
+	}
+
+	// A pattern that matches a directory applies to everything inside of it,
+	// except for patterns such as docs/* which only match direct children.
+	if strings.HasSuffix(pattern, "/*") {
+		return false
+	}
+	for i := strings.LastIndex(path, "/"); i > 0; i = strings.LastIndex(path[:i], "/") {
+		if r.matcher.MatchString(path[:i]) {
+			return true
+		}
+	}
+	return false
+}
+
+var sectionHeaderRegex = regexp.MustCompile(`^\^?\[([^\]]+)\](?:\[[0-9]+\])?(.*)$`)
+
+// parseSectionHeader parses a GitLab section header such as
+// ^[Documentation][2] @docs-team.
+func parseSectionHeader(line string) (name string, owners []Owner, ok bool) {
+	match := sectionHeaderRegex.FindStringSubmatch(line)
+	if match == nil {
+		return "", nil, false
+	}
+	return strings.TrimSpace(match[1]), parseOwners(match[2]), true
+}
+
+// splitPattern splits a rule into its pattern and the rest of the line.
+// Spaces in patterns are escaped with a backslash.
+func splitPattern(line string) (pattern, rest string) {
+	escaped := false
+	for i, c := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == ' ' || c == '\t':
+			return line[:i], line[i+1:]
+		}
+	}
+	return line, ""
+}
+
+func parseOwners(s string) []Owner {
+	var owners []Owner
+	for _, field := range strings.Fields(s) {
+		// The rest of the line is a comment.
+		if strings.HasPrefix(field, "#") {
+			break
+		}
+		if strings.HasPrefix(field, "@") {
+			owners = append(owners, Owner{Handle: strings.TrimLeft(field, "@")})
+		} else if strings.Contains(field, "@") {
+			owners = append(owners, Owner{Email: field})
+		}
+	}
+	return owners
+}
+
+// compilePattern translates a gitignore-style pattern into a regular
+// expression that matches whole paths.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	pattern = strings.TrimSuffix(pattern, "/")
+
+	// Patterns are relative to the root of the repository if they contain a
+	// slash, and match at any depth otherwise.
+	var b strings.Builder
+	if strings.Contains(pattern, "/") {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch c {
+		case '*':
+			if strings.HasPrefix(pattern[i:], "**/") {
+				b.WriteString("(?:.*/)?")
+				i += 2
+			} else if strings.HasPrefix(pattern[i:], "**") {
+				b.WriteString(".*")
+				i++
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				return nil, errors.New("unterminated character class")
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				b.WriteString(regexp.QuoteMeta(string(pattern[i])))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+
+	return regexp.Compile(b.String())
+}
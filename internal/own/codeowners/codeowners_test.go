@@ -0,0 +1,162 @@
+package codeowners
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	rs, err := Parse("CODEOWNERS", strings.NewReader(`
+# Global owners
+*                   @global-owner   # trailing comment
+/docs/              docs@example.com
+path\ with\ spaces  @@bitbucket-group
+
+[Backend] @backend-team
+/internal/
+^[Frontend][2] @org/frontend
+*.ts                @alice @bob
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type rule struct {
+		Pattern string
+		Owners  []Owner
+	}
+	type section struct {
+		Name          string
+		DefaultOwners []Owner
+		Rules         []rule
+	}
+	var got []section
+	for _, s := range rs.Sections {
+		sec := section{Name: s.Name, DefaultOwners: s.DefaultOwners}
+		for _, r := range s.Rules {
+			sec.Rules = append(sec.Rules, rule{Pattern: r.Pattern, Owners: r.Owners})
+		}
+		got = append(got, sec)
+	}
+
+	want := []section{
+		{Rules: []rule{
+			{Pattern: "*", Owners: []Owner{{Handle: "global-owner"}}},
+			{Pattern: "/docs/", Owners: []Owner{{Email: "docs@example.com"}}},
+			{Pattern: `path\ with\ spaces`, Owners: []Owner{{Handle: "bitbucket-group"}}},
+		}},
+		{Name: "Backend", DefaultOwners: []Owner{{Handle: "backend-team"}}, Rules: []rule{
+			{Pattern: "/internal/"},
+		}},
+		{Name: "Frontend", DefaultOwners: []Owner{{Handle: "org/frontend"}}, Rules: []rule{
+			{Pattern: "*.ts", Owners: []Owner{{Handle: "alice"}, {Handle: "bob"}}},
+		}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected ruleset (-want +got):\n%s", diff)
+	}
+}
+
+func TestParse_InvalidPattern(t *testing.T) {
+	_, err := Parse("CODEOWNERS", strings.NewReader("[abc @alice\nfoo[ @bob\n"))
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestRuleMatch(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"*", "a/b/c.go", true},
+		{"*.js", "main.js", true},
+		{"*.js", "web/src/main.js", true},
+		{"*.js", "main.ts", false},
+		{"/build/", "build/out.txt", true},
+		{"/build/", "build", false},
+		{"/build/", "src/build/out.txt", false},
+		{"build/", "src/build/out.txt", true},
+		{"logs", "src/logs/today.log", true},
+		{"docs/*", "docs/getting-started.md", true},
+		{"docs/*", "docs/build/index.md", false},
+		{"apps/", "apps/web/main.go", true},
+		{"**/logs", "deep/down/logs/a.log", true},
+		{"**/logs", "logs/a.log", true},
+		{"/scripts/**", "scripts/a/b.sh", true},
+		{"a/**/b", "a/x/y/b", true},
+		{"a/**/b", "a/b", true},
+		{"file?.txt", "file1.txt", true},
+		{"file?.txt", "file10.txt", false},
+		{"file[0-9].txt", "file5.txt", true},
+		{"file[!0-9].txt", "file5.txt", false},
+		{`path\ with\ spaces`, "path with spaces", true},
+		{"/README.md", "README.md", true},
+		{"/README.md", "docs/README.md", false},
+	} {
+		t.Run(tc.pattern+" "+tc.path, func(t *testing.T) {
+			matcher, err := compilePattern(tc.pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := &Rule{Pattern: tc.pattern, matcher: matcher}
+			if got := r.Match(tc.path); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFindOwners(t *testing.T) {
+	rs, err := Parse("CODEOWNERS", strings.NewReader(`
+*          @global
+*.go       @gophers
+/internal/ @internal @gophers
+
+[Docs] @docs-team
+*.md
+/internal/README.md @alice
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string][]Owner{
+		"main.go":             {{Handle: "gophers"}},
+		"/cmd/main.ts":        {{Handle: "global"}},
+		"internal/foo.go":     {{Handle: "internal"}, {Handle: "gophers"}},
+		"README.md":           {{Handle: "global"}, {Handle: "docs-team"}},
+		"internal/README.md":  {{Handle: "internal"}, {Handle: "gophers"}, {Handle: "alice"}},
+		"internal/a/b/CHANGE": {{Handle: "internal"}, {Handle: "gophers"}},
+	} {
+		if diff := cmp.Diff(want, rs.FindOwners(path)); diff != "" {
+			t.Errorf("unexpected owners of %s (-want +got):\n%s", path, diff)
+		}
+	}
+
+	var empty *Ruleset
+	if owners := empty.FindOwners("main.go"); owners != nil {
+		t.Errorf("expected no owners, got %v", owners)
+	}
+}
+
+func TestOwnerMatches(t *testing.T) {
+	for _, tc := range []struct {
+		owner Owner
+		query string
+		want  bool
+	}{
+		{Owner{Handle: "org/Team"}, "@org/team", true},
+		{Owner{Handle: "alice"}, "alice", true},
+		{Owner{Handle: "alice"}, "@bob", false},
+		{Owner{Email: "Alice@example.com"}, "alice@example.com", true},
+		{Owner{Email: "alice@example.com"}, "alice", false},
+	} {
+		if got := tc.owner.Matches(tc.query); got != tc.want {
+			t.Errorf("%v.Matches(%q) = %v, want %v", tc.owner, tc.query, got, tc.want)
+		}
+	}
+}
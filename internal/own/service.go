@@ -0,0 +1,106 @@
+// Package own determines the owners of files in repositories.
+package own
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync"
+
+	"github.com/golang/groupcache/lru"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/own/codeowners"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// codeownersPaths are the locations of CODEOWNERS files that are understood by
+// GitHub, GitLab and Bitbucket, in order of precedence.
+var codeownersPaths = []string{
+	"CODEOWNERS",
+	".github/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+	".bitbucket/CODEOWNERS",
+	"docs/CODEOWNERS",
+}
+
+// Service gives access to the ownership rules of repositories.
+type Service interface {
+	// RulesetForRepo returns the CODEOWNERS rules of the repository at the
+	// given commit, or nil if the repository has no CODEOWNERS file.
+	RulesetForRepo(ctx context.Context, repo api.RepoName, commit api.CommitID) (*codeowners.Ruleset, error)
+}
+
+// NewService returns a Service that reads CODEOWNERS files from gitserver.
+func NewService(gitserverClient gitserver.Client) Service {
+	return &service{
+		gitserverClient: gitserverClient,
+		checker:         authz.DefaultSubRepoPermsChecker,
+	}
+}
+
+type service struct {
+	gitserverClient gitserver.Client
+	checker         authz.SubRepoPermissionChecker
+}
+
+// rulesetCache caches parsed rulesets by repository and commit. Commits are
+// immutable, so entries never need to be invalidated. The cache is shared by
+// all actors, so repositories with sub-repo permissions are never cached.
+var (
+	rulesetCacheMu sync.Mutex
+	rulesetCache   = lru.New(1000)
+)
+
+type rulesetCacheKey struct {
+	repo   api.RepoName
+	commit api.CommitID
+}
+
+func (s *service) RulesetForRepo(ctx context.Context, repo api.RepoName, commit api.CommitID) (*codeowners.Ruleset, error) {
+	// Whether the actor may read the CODEOWNERS file depends on their
+	// sub-repo permissions, so the result cannot be shared with other actors.
+	subRepoEnabled, err := authz.SubRepoEnabledForRepo(ctx, s.checker, repo)
+	if err != nil {
+		return nil, errors.Wrap(err, "checking sub-repo permissions")
+	}
+	if subRepoEnabled {
+		return s.readRuleset(ctx, repo, commit)
+	}
+
+	key := rulesetCacheKey{repo: repo, commit: commit}
+
+	rulesetCacheMu.Lock()
+	cached, ok := rulesetCache.Get(key)
+	rulesetCacheMu.Unlock()
+	if ok {
+		return cached.(*codeowners.Ruleset), nil
+	}
+
+	ruleset, err := s.readRuleset(ctx, repo, commit)
+	if err != nil {
+		return nil, err
+	}
+
+	rulesetCacheMu.Lock()
+	rulesetCache.Add(key, ruleset)
+	rulesetCacheMu.Unlock()
+
+	return ruleset, nil
+}
+
+func (s *service) readRuleset(ctx context.Context, repo api.RepoName, commit api.CommitID) (*codeowners.Ruleset, error) {
+	for _, path := range codeownersPaths {
+		content, err := s.gitserverClient.ReadFile(ctx, repo, commit, path, s.checker)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "reading %s", path)
+		}
+		return codeowners.Parse(path, bytes.NewReader(content))
+	}
+	return nil, nil
+}
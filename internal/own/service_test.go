@@ -0,0 +1,93 @@
+package own
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/own/codeowners"
+)
+
+func TestRulesetForRepo(t *testing.T) {
+	files := map[string]string{
+		"github.com/sourcegraph/a": "",
+		"github.com/sourcegraph/b": ".github/CODEOWNERS",
+	}
+
+	var reads int
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ReadFileFunc.SetDefaultHook(func(_ context.Context, repo api.RepoName, _ api.CommitID, name string, _ authz.SubRepoPermissionChecker) ([]byte, error) {
+		reads++
+		if files[string(repo)] != name {
+			return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+		}
+		return []byte("*.go @gophers\n"), nil
+	})
+
+	svc := NewService(gitserverClient)
+	ctx := context.Background()
+
+	ruleset, err := svc.RulesetForRepo(ctx, "github.com/sourcegraph/a", "deadbeef")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ruleset != nil {
+		t.Fatalf("expected no ruleset, got %+v", ruleset)
+	}
+
+	for i := 0; i < 2; i++ {
+		ruleset, err = svc.RulesetForRepo(ctx, "github.com/sourcegraph/b", "deadbeef")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ruleset == nil || ruleset.Path != ".github/CODEOWNERS" {
+			t.Fatalf("unexpected ruleset %+v", ruleset)
+		}
+		if owners := ruleset.FindOwners("main.go"); len(owners) != 1 || owners[0] != (codeowners.Owner{Handle: "gophers"}) {
+			t.Fatalf("unexpected owners %v", owners)
+		}
+	}
+
+	// All candidate paths are read for repository a, and the first two for
+	// repository b. The second lookup for b is cached.
+	if want := len(codeownersPaths) + 2; reads != want {
+		t.Errorf("got %d reads, want %d", reads, want)
+	}
+}
+
+func TestRulesetForRepoSubRepoPermissions(t *testing.T) {
+	var reads int
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ReadFileFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, _ api.CommitID, name string, _ authz.SubRepoPermissionChecker) ([]byte, error) {
+		reads++
+		if name != "CODEOWNERS" {
+			return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+		}
+		return []byte("*.go @gophers\n"), nil
+	})
+
+	checker := authz.NewMockSubRepoPermissionChecker()
+	checker.EnabledFunc.SetDefaultReturn(true)
+	checker.EnabledForRepoFunc.SetDefaultReturn(true, nil)
+
+	svc := &service{gitserverClient: gitserverClient, checker: checker}
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		ruleset, err := svc.RulesetForRepo(ctx, "github.com/sourcegraph/subrepo", "deadbeef")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ruleset == nil || ruleset.Path != "CODEOWNERS" {
+			t.Fatalf("unexpected ruleset %+v", ruleset)
+		}
+	}
+
+	// The file is read with the permissions of each caller and never cached.
+	if reads != 2 {
+		t.Errorf("got %d reads, want 2", reads)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/gomodule/redigo/redis"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"golang.org/x/time/rate"
@@ -15,16 +16,26 @@ import (
 )
 
 // DefaultRegistry is the default global rate limit registry, which holds rate
-// limit mappings for each instance of our services.
-var DefaultRegistry = NewRegistry()
+// limit mappings for each instance of our services. If SRC_SHARED_RATE_LIMITS
+// is set, its rate limiters share their budget with all other replicas through
+// Redis.
+var DefaultRegistry = newRegistry(defaultSharedPool())
 
 const defaultBurst = 10
 
 // NewRegistry creates and returns an empty rate limit registry. If a global default rate limit is specified a fallback
 // rate limiter will be added.
 func NewRegistry() *Registry {
+	return newRegistry(nil)
+}
+
+// newRegistry creates and returns an empty rate limit registry. If pool is not
+// nil, the token buckets of the rate limiters created by the registry are
+// stored in Redis.
+func newRegistry(pool *redis.Pool) *Registry {
 	return &Registry{
 		rateLimiters: make(map[string]*InstrumentedLimiter),
+		pool:         pool,
 	}
 }
 
@@ -34,6 +45,8 @@ type Registry struct {
 	// rateLimiters contains mappings of external service to its *rate.Limiter. The
 	// key should be the URN of the external service.
 	rateLimiters map[string]*InstrumentedLimiter
+	// pool is the Redis pool that stores shared token buckets, if any.
+	pool *redis.Pool
 }
 
 // Get returns the rate limiter configured for the given URN of an external
@@ -67,6 +80,9 @@ func (r *Registry) getOrSet(urn string, fallback *InstrumentedLimiter) *Instrume
 			fallbackRateLimit = rate.Inf
 		}
 		fallback = NewInstrumentedLimiter(urn, rate.NewLimiter(fallbackRateLimit, defaultBurst))
+		if r.pool != nil {
+			fallback.shared = newSharedBucket(r.pool, urn)
+		}
 	}
 	r.rateLimiters[urn] = fallback
 	return fallback
@@ -88,6 +104,12 @@ type LimitInfo struct {
 	// Infinite is true if Limit is infinite. This is required since infinity cannot
 	// be marshalled in JSON.
 	Infinite bool
+	// Shared is true if the budget of the limiter is shared with all replicas
+	// through Redis, and Redis is currently reachable.
+	Shared bool `json:",omitempty"`
+	// Available is the number of requests that can currently be made without
+	// waiting, across all replicas. It is only set if Shared is true.
+	Available float64 `json:",omitempty"`
 }
 
 // LimitInfo reports how all the existing rate limiters are configured, keyed by
@@ -106,6 +128,13 @@ func (r *Registry) LimitInfo() map[string]LimitInfo {
 		if math.IsInf(info.Limit, 0) || limit == rate.Inf {
 			info.Limit = 0
 			info.Infinite = true
+		} else if rl.shared != nil && rl.shared.available() {
+			if available, err := rl.shared.tokens(limit, info.Burst); err == nil {
+				info.Shared = true
+				info.Available = available
+			} else {
+				rl.shared.markUnavailable()
+			}
 		}
 		m[urn] = info
 	}
@@ -116,6 +145,11 @@ func (r *Registry) LimitInfo() map[string]LimitInfo {
 type InstrumentedLimiter struct {
 	urn string
 	*rate.Limiter
+
+	// shared is set if the token bucket of the limiter is stored in Redis. The
+	// wrapped *rate.Limiter then only holds the configured rate and burst, and
+	// is used as a fallback when Redis cannot be reached.
+	shared *sharedBucket
 }
 
 // NewInstrumentedLimiter creates new InstrumentedLimiter with given URN and rate.Limiter
@@ -143,7 +177,7 @@ func (i *InstrumentedLimiter) WaitN(ctx context.Context, n int) error {
 	}
 
 	start := time.Now()
-	err := i.Limiter.WaitN(ctx, n)
+	err := i.waitN(ctx, n)
 	d := time.Since(start)
 	failedLabel := "false"
 	if err != nil {
@@ -154,6 +188,15 @@ func (i *InstrumentedLimiter) WaitN(ctx context.Context, n int) error {
 	return err
 }
 
+func (i *InstrumentedLimiter) waitN(ctx context.Context, n int) error {
+	if limit := i.Limit(); i.shared != nil && limit != rate.Inf && i.shared.available() {
+		if ok, err := i.shared.waitN(ctx, limit, i.Burst(), n); ok {
+			return err
+		}
+	}
+	return i.Limiter.WaitN(ctx, n)
+}
+
 // SetBurst is calling SetBurstAt(time.Now(), newBurst) method of the wrapped *rate.Limiter.
 func (i *InstrumentedLimiter) SetBurst(newBurst int) {
 	i.Limiter.SetBurstAt(time.Now(), newBurst)
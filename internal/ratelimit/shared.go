@@ -0,0 +1,235 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var sharedRateLimitsEnabled = env.MustGetBool("SRC_SHARED_RATE_LIMITS", false, "Store the token buckets of code host rate limiters in Redis, so that all replicas of a service share the configured rate limit.")
+
+// sharedKeyPrefix is the prefix of the Redis keys holding shared token buckets.
+// It is followed by the URN of the external service.
+const sharedKeyPrefix = "ratelimit:"
+
+// sharedUnavailableBackoff is how long a shared bucket uses its local limiter
+// after Redis could not be reached, before trying Redis again.
+const sharedUnavailableBackoff = 30 * time.Second
+
+// sharedBucket is a token bucket whose state is stored in Redis, so that every
+// process using the same key draws from the same budget. The rate and burst of
+// the bucket are not stored, and are instead passed in on each call: every
+// process derives them from the same external service configuration.
+type sharedBucket struct {
+	pool *redis.Pool
+	key  string
+
+	mu               sync.Mutex
+	unavailableUntil time.Time
+}
+
+func newSharedBucket(pool *redis.Pool, urn string) *sharedBucket {
+	return &sharedBucket{pool: pool, key: sharedKeyPrefix + urn}
+}
+
+// available returns false if Redis could not be reached recently, in which
+// case the caller should use its local limiter.
+func (b *sharedBucket) available() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return time.Now().After(b.unavailableUntil)
+}
+
+func (b *sharedBucket) markUnavailable() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.unavailableUntil = time.Now().Add(sharedUnavailableBackoff)
+}
+
+// waitN blocks until the shared bucket permits n events to happen. If Redis
+// cannot be reached, ok is false and the caller must fall back to its local
+// limiter.
+//
+// Unlike rate.Limiter, tokens taken for a wait that is cut short by the
+// context are not returned to the bucket.
+func (b *sharedBucket) waitN(ctx context.Context, limit rate.Limit, burst, n int) (ok bool, err error) {
+	if n > burst {
+		return true, errors.Errorf("rate: Wait(n=%d) exceeds limiter's burst %d", n, burst)
+	}
+	if err := ctx.Err(); err != nil {
+		return true, err
+	}
+
+	maxWait := time.Duration(-1)
+	if deadline, ok := ctx.Deadline(); ok {
+		maxWait = time.Until(deadline)
+	}
+
+	wait, reserved, err := b.reserveN(ctx, limit, burst, n, maxWait)
+	if err != nil {
+		b.markUnavailable()
+		metricSharedFallbacks.Inc()
+		return false, nil
+	}
+	if !reserved {
+		return true, errors.Errorf("rate: Wait(n=%d) would exceed context deadline", n)
+	}
+	if wait <= 0 {
+		return true, nil
+	}
+
+	t := time.NewTimer(wait)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true, nil
+	case <-ctx.Done():
+		return true, ctx.Err()
+	}
+}
+
+// reserveNScript atomically refills the bucket stored at KEYS[1] and takes n
+// tokens from it. The number of tokens may become negative, in which case the
+// caller has to wait for the returned number of milliseconds before acting. If
+// that wait is longer than the maximum wait, no tokens are taken and -1 is
+// returned.
+//
+// ARGV: rate in tokens per second, burst, n, maximum wait in milliseconds (or
+// a negative number for no maximum).
+//
+// The time of the Redis server is used so that the clocks of the callers do
+// not need to agree.
+var reserveNScript = redis.NewScript(1, `
+redis.replicate_commands()
+local key = KEYS[1]
+local limit = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+local max_wait = tonumber(ARGV[4])
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local state = redis.call('HMGET', key, 'tokens', 'updated_at')
+local tokens = tonumber(state[1])
+local updated_at = tonumber(state[2])
+if tokens == nil or updated_at == nil then
+	tokens = burst
+	updated_at = now
+end
+if now > updated_at then
+	tokens = math.min(burst, tokens + (now - updated_at) * limit / 1000)
+	updated_at = now
+end
+
+local remaining = tokens - n
+local wait = 0
+if remaining < 0 then
+	if limit <= 0 then
+		return -1
+	end
+	wait = math.ceil(-remaining * 1000 / limit)
+end
+if max_wait >= 0 and wait > max_wait then
+	return -1
+end
+
+redis.call('HMSET', key, 'tokens', tostring(remaining), 'updated_at', tostring(updated_at))
+if limit > 0 then
+	-- Once the bucket is full again, its state is the same as a missing key.
+	redis.call('PEXPIRE', key, math.ceil((burst - remaining) * 1000 / limit) + 1000)
+end
+return wait
+`)
+
+func (b *sharedBucket) reserveN(ctx context.Context, limit rate.Limit, burst, n int, maxWait time.Duration) (wait time.Duration, reserved bool, err error) {
+	c, err := b.pool.GetContext(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	defer c.Close()
+
+	maxWaitMillis := int64(-1)
+	if maxWait >= 0 {
+		maxWaitMillis = maxWait.Milliseconds()
+	}
+
+	ms, err := redis.Int64(reserveNScript.Do(c, b.key, formatLimit(limit), burst, n, maxWaitMillis))
+	if err != nil {
+		return 0, false, err
+	}
+	if ms < 0 {
+		return 0, false, nil
+	}
+	return time.Duration(ms) * time.Millisecond, true, nil
+}
+
+// tokens returns the number of tokens currently available in the bucket.
+func (b *sharedBucket) tokens(limit rate.Limit, burst int) (float64, error) {
+	c := b.pool.Get()
+	defer c.Close()
+
+	values, err := redis.Values(c.Do("HMGET", b.key, "tokens", "updated_at"))
+	if err != nil {
+		return 0, err
+	}
+	var tokensStr, updatedAtStr string
+	if _, err := redis.Scan(values, &tokensStr, &updatedAtStr); err != nil {
+		return 0, err
+	}
+	if tokensStr == "" || updatedAtStr == "" {
+		// A missing bucket is full.
+		return float64(burst), nil
+	}
+
+	tokens, err := strconv.ParseFloat(tokensStr, 64)
+	if err != nil {
+		return 0, err
+	}
+	updatedAt, err := strconv.ParseFloat(updatedAtStr, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	// Like reserveNScript, use the time of the Redis server.
+	now, err := redis.Int64s(c.Do("TIME"))
+	if err != nil {
+		return 0, err
+	}
+	if len(now) != 2 {
+		return 0, errors.Errorf("unexpected TIME reply %v", now)
+	}
+	nowMillis := float64(now[0]*1000 + now[1]/1000)
+
+	elapsed := math.Max(0, nowMillis-updatedAt)
+	return math.Min(float64(burst), tokens+elapsed*float64(limit)/1000), nil
+}
+
+func formatLimit(limit rate.Limit) string {
+	return strconv.FormatFloat(float64(limit), 'f', -1, 64)
+}
+
+// defaultSharedPool returns the Redis pool used for shared token buckets, or
+// nil if shared rate limits are disabled.
+func defaultSharedPool() *redis.Pool {
+	if !sharedRateLimitsEnabled {
+		return nil
+	}
+	return redispool.Cache
+}
+
+var metricSharedFallbacks = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "src_internal_rate_limit_shared_fallbacks_total",
+	Help: "Number of times a shared rate limiter fell back to its local limiter because Redis could not be reached",
+})
@@ -0,0 +1,103 @@
+package ratelimit
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestSharedLimiterFallback(t *testing.T) {
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return nil, errors.New("redis is down")
+		},
+	}
+	r := newRegistry(pool)
+
+	l := r.Get("extsvc:github:1")
+	l.SetLimit(10)
+	l.SetBurst(1)
+
+	// Redis is not reachable, so the local limiter is used instead.
+	require.NoError(t, l.Wait(context.Background()))
+	assert.False(t, l.shared.available())
+
+	assert.Equal(t, LimitInfo{Limit: 10, Burst: 1}, r.LimitInfo()["extsvc:github:1"])
+}
+
+func TestSharedLimiter(t *testing.T) {
+	pool := setupRedisForTest(t)
+
+	// Two registries stand in for two replicas of a service.
+	urn := "extsvc:github:" + t.Name()
+	first := newRegistry(pool).Get(urn)
+	second := newRegistry(pool).Get(urn)
+	for _, l := range []*InstrumentedLimiter{first, second} {
+		l.SetLimit(0.001)
+		l.SetBurst(2)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// The first replica uses up the shared burst ...
+	require.NoError(t, first.WaitN(ctx, 2))
+
+	// ... so the second replica would have to wait far longer than its
+	// deadline, even though its own local limiter is untouched.
+	err := second.Wait(ctx)
+	require.Error(t, err)
+	assert.Equal(t, 2, int(second.Limiter.Tokens()))
+
+	registry := newRegistry(pool)
+	registry.Get(urn).SetLimit(0.001)
+	registry.Get(urn).SetBurst(2)
+	info := registry.LimitInfo()[urn]
+	assert.True(t, info.Shared)
+	assert.Less(t, info.Available, 1.0)
+
+	t.Run("infinite limit skips redis", func(t *testing.T) {
+		l := newRegistry(pool).Get(urn + ":inf")
+		l.SetLimit(rate.Inf)
+		require.NoError(t, l.WaitN(ctx, 5))
+	})
+}
+
+func setupRedisForTest(t *testing.T) *redis.Pool {
+	t.Helper()
+
+	pool := &redis.Pool{
+		MaxIdle:     3,
+		IdleTimeout: 240 * time.Second,
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", "127.0.0.1:6379")
+		},
+	}
+
+	c := pool.Get()
+	defer c.Close()
+
+	// If we are not on CI, skip the test if our redis connection fails.
+	if _, err := c.Do("PING"); err != nil {
+		if os.Getenv("CI") == "" {
+			t.Skip("could not connect to redis", err)
+		}
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		c := pool.Get()
+		defer c.Close()
+		_, _ = c.Do("DEL", sharedKeyPrefix+"extsvc:github:"+t.Name())
+		_ = pool.Close()
+	})
+	return pool
+}
@@ -0,0 +1,62 @@
+// Package rbac defines the permissions that roles can grant to users and
+// organizations.
+package rbac
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// SiteAdministratorRole is the name of the built-in role whose members are
+// the site admins. Membership is kept in sync with the site admin flag of
+// users, and the role implicitly grants every permission.
+const SiteAdministratorRole = "SITE_ADMINISTRATOR"
+
+// Permission is an action in a namespace that a role can grant.
+type Permission struct {
+	Namespace string
+	Action    string
+}
+
+// String returns the permission in the "NAMESPACE#ACTION" form that is used
+// in the API.
+func (p Permission) String() string {
+	return p.Namespace + "#" + p.Action
+}
+
+var (
+	// BatchChangesAdmin grants managing batch changes, batch specs and
+	// changesets created by other users.
+	BatchChangesAdmin = Permission{Namespace: "BATCH_CHANGES", Action: "ADMIN"}
+	// CodeInsightsAdmin grants viewing and managing the code insights admin
+	// and debugging information of all insights.
+	CodeInsightsAdmin = Permission{Namespace: "CODE_INSIGHTS", Action: "ADMIN"}
+	// ExternalServicesManage grants adding, updating, deleting and syncing
+	// code host connections.
+	ExternalServicesManage = Permission{Namespace: "EXTERNAL_SERVICES", Action: "MANAGE"}
+)
+
+// AllPermissions is the registry of all permissions. Every permission in it
+// must also exist in the permissions table.
+var AllPermissions = []Permission{
+	BatchChangesAdmin,
+	CodeInsightsAdmin,
+	ExternalServicesManage,
+}
+
+// ParsePermission parses a permission in the form returned by
+// Permission.String and returns an error if it is not a known permission.
+func ParsePermission(s string) (Permission, error) {
+	namespace, action, ok := strings.Cut(s, "#")
+	if !ok {
+		return Permission{}, errors.Errorf("invalid permission %q: must be of the form NAMESPACE#ACTION", s)
+	}
+	p := Permission{Namespace: namespace, Action: action}
+	for _, known := range AllPermissions {
+		if p == known {
+			return p, nil
+		}
+	}
+	return Permission{}, errors.Errorf("unknown permission %q", s)
+}
@@ -0,0 +1,21 @@
+package rbac
+
+import "testing"
+
+func TestParsePermission(t *testing.T) {
+	for _, p := range AllPermissions {
+		got, err := ParsePermission(p.String())
+		if err != nil {
+			t.Fatalf("ParsePermission(%q): %s", p, err)
+		}
+		if got != p {
+			t.Errorf("ParsePermission(%q) = %v, want %v", p, got, p)
+		}
+	}
+
+	for _, invalid := range []string{"", "BATCH_CHANGES", "BATCH_CHANGES#READ", "batch_changes#admin"} {
+		if _, err := ParsePermission(invalid); err == nil {
+			t.Errorf("ParsePermission(%q): expected error", invalid)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// An AzureDevOpsSource yields repositories from a single Azure DevOps
+// connection configured in Sourcegraph via the external services
+// configuration.
+type AzureDevOpsSource struct {
+	svc       *types.ExternalService
+	config    *schema.AzureDevOpsConnection
+	cli       azuredevops.Client
+	baseURL   *url.URL
+	serviceID string
+	exclude   excludeFunc
+}
+
+// NewAzureDevOpsSource returns a new AzureDevOpsSource from the given external
+// service.
+func NewAzureDevOpsSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*AzureDevOpsSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.AzureDevOpsConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d config error", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	httpCli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := azuredevops.NewClient(svc.URN(), &c, httpCli)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, err
+	}
+	baseURL = extsvc.NormalizeBaseURL(baseURL)
+
+	var eb excludeBuilder
+	for _, r := range c.Exclude {
+		eb.Exact(r.Name)
+		eb.Pattern(r.Pattern)
+	}
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &AzureDevOpsSource{
+		svc:       svc,
+		config:    &c,
+		cli:       cli,
+		baseURL:   baseURL,
+		serviceID: baseURL.String(),
+		exclude:   exclude,
+	}, nil
+}
+
+// CheckConnection at this point assumes availability and relies on errors returned
+// from the subsequent calls. This is going to be expanded as part of issue #44683
+// to actually only return true if the source can serve requests.
+func (s *AzureDevOpsSource) CheckConnection(ctx context.Context) error {
+	return nil
+}
+
+// ListRepos returns all Azure DevOps repositories configured with this
+// AzureDevOpsSource's config: the repositories of all projects of each
+// configured organization, and the repositories of each configured project.
+func (s *AzureDevOpsSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	type listing struct{ org, project string }
+
+	var listings []listing
+	for _, org := range s.config.Orgs {
+		listings = append(listings, listing{org: org})
+	}
+	for _, p := range s.config.Projects {
+		org, project, ok := strings.Cut(p, "/")
+		if !ok {
+			results <- SourceResult{Source: s, Err: errors.Errorf("invalid Azure DevOps project %q: expected \"org/project\"", p)}
+			continue
+		}
+		listings = append(listings, listing{org: org, project: project})
+	}
+
+	// A project's repositories can be listed both through its organization
+	// and through the project itself, but are only yielded once.
+	seen := make(map[string]struct{})
+	for _, l := range listings {
+		repos, err := s.cli.ListRepositories(ctx, l.org, l.project)
+		if err != nil {
+			// An error listing one organization or project doesn't prevent
+			// listing the others.
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "listing repositories of %q", path.Join(l.org, l.project))}
+			continue
+		}
+
+		for _, r := range repos {
+			if _, ok := seen[r.ID]; ok {
+				continue
+			}
+			seen[r.ID] = struct{}{}
+
+			// Disabled repositories can neither be cloned nor fetched.
+			if r.IsDisabled || s.exclude(path.Join(l.org, r.Project.Name, r.Name)) {
+				continue
+			}
+
+			repo, err := s.makeRepo(l.org, r)
+			if err != nil {
+				results <- SourceResult{Source: s, Err: err}
+				return
+			}
+			results <- SourceResult{Source: s, Repo: repo}
+		}
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *AzureDevOpsSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s *AzureDevOpsSource) makeRepo(org string, r *azuredevops.Repository) (*types.Repo, error) {
+	urn := s.svc.URN()
+
+	remoteURL, err := url.Parse(r.RemoteURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing remote URL of Azure DevOps repository %q", r.Name)
+	}
+	// Azure DevOps includes the organization as the user of the remote URL,
+	// which is not a credential.
+	remoteURL.User = nil
+
+	name := path.Join(s.baseURL.Host, s.baseURL.Path, org, r.Project.Name, r.Name)
+
+	return &types.Repo{
+		Name:    api.RepoName(name),
+		URI:     name,
+		Fork:    r.IsFork,
+		Private: r.IsPrivate(),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          r.ID,
+			ServiceType: extsvc.TypeAzureDevOps,
+			ServiceID:   s.serviceID,
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: remoteURL.String(),
+			},
+		},
+		Metadata: r,
+	}, nil
+}
@@ -0,0 +1,155 @@
+package repos
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestAzureDevOpsSource_ListRepos(t *testing.T) {
+	// Repositories of the fabrikam organization, by project.
+	server := newFakeAzureDevOpsServer(t, "fabrikam", map[string][]*azuredevops.Repository{
+		"Fiber": {
+			{ID: "1", Name: "api"},
+			{ID: "2", Name: "web"},
+			{ID: "3", Name: "legacy", IsDisabled: true},
+		},
+		"Tools": {
+			{ID: "4", Name: "cli", IsFork: true},
+		},
+	})
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	list := func(t *testing.T, c *schema.AzureDevOpsConnection) []*types.Repo {
+		t.Helper()
+
+		c.Url = server.URL
+		c.Username = "admin"
+		c.Token = "secret"
+		svc := &types.ExternalService{
+			ID:     1,
+			Kind:   extsvc.KindAzureDevOps,
+			Config: extsvc.NewUnencryptedConfig(marshalJSON(t, c)),
+		}
+
+		src, err := NewAzureDevOpsSource(context.Background(), svc, nil)
+		require.NoError(t, err)
+
+		repos, err := listAll(context.Background(), src)
+		require.NoError(t, err)
+		return repos
+	}
+
+	names := func(repos []*types.Repo) []string {
+		var names []string
+		for _, r := range repos {
+			names = append(names, strings.TrimPrefix(string(r.Name), host+"/"))
+		}
+		return names
+	}
+
+	t.Run("orgs", func(t *testing.T) {
+		repos := list(t, &schema.AzureDevOpsConnection{Orgs: []string{"fabrikam"}})
+		assert.ElementsMatch(t, []string{
+			"fabrikam/Fiber/api",
+			"fabrikam/Fiber/web",
+			"fabrikam/Tools/cli",
+		}, names(repos))
+	})
+
+	t.Run("projects", func(t *testing.T) {
+		repos := list(t, &schema.AzureDevOpsConnection{
+			Orgs:     []string{"fabrikam"},
+			Projects: []string{"fabrikam/Tools"},
+		})
+		// The repositories of the Tools project are listed through both the
+		// organization and the project, but are only yielded once.
+		assert.Len(t, repos, 3)
+
+		repos = list(t, &schema.AzureDevOpsConnection{Projects: []string{"fabrikam/Tools"}})
+		assert.Equal(t, []string{"fabrikam/Tools/cli"}, names(repos))
+	})
+
+	t.Run("exclude", func(t *testing.T) {
+		repos := list(t, &schema.AzureDevOpsConnection{
+			Orgs: []string{"fabrikam"},
+			Exclude: []*schema.ExcludedAzureDevOpsRepo{
+				{Name: "fabrikam/Fiber/web"},
+				{Pattern: "^fabrikam/Tools/"},
+			},
+		})
+		assert.Equal(t, []string{"fabrikam/Fiber/api"}, names(repos))
+	})
+
+	t.Run("repo", func(t *testing.T) {
+		repos := list(t, &schema.AzureDevOpsConnection{Projects: []string{"fabrikam/Tools"}})
+		require.Len(t, repos, 1)
+
+		r := repos[0]
+		assert.Equal(t, api.RepoName(host+"/fabrikam/Tools/cli"), r.Name)
+		assert.True(t, r.Fork)
+		assert.True(t, r.Private)
+		assert.Equal(t, api.ExternalRepoSpec{
+			ID:          "4",
+			ServiceType: extsvc.TypeAzureDevOps,
+			ServiceID:   server.URL + "/",
+		}, r.ExternalRepo)
+		assert.Equal(t, server.URL+"/fabrikam/Tools/_git/cli", r.Sources["extsvc:azuredevops:1"].CloneURL)
+	})
+}
+
+// newFakeAzureDevOpsServer returns a server that implements the Azure DevOps
+// API endpoint listing the repositories of an organization or a project.
+func newFakeAzureDevOpsServer(t *testing.T, org string, projects map[string][]*azuredevops.Repository) *httptest.Server {
+	t.Helper()
+
+	var server *httptest.Server
+	withProject := func(project string, r *azuredevops.Repository) *azuredevops.Repository {
+		rr := *r
+		rr.Project = azuredevops.Project{ID: project, Name: project, Visibility: "private"}
+		rr.URL = server.URL + "/" + org + "/_apis/git/repositories/" + r.ID
+		rr.WebURL = server.URL + "/" + org + "/" + project + "/_git/" + r.Name
+		rr.RemoteURL = strings.Replace(rr.WebURL, "://", "://"+org+"@", 1)
+		return &rr
+	}
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, password, _ := r.BasicAuth(); password != "secret" {
+			w.WriteHeader(http.StatusNonAuthoritativeInfo)
+			return
+		}
+
+		// Paths are /{org}/_apis/git/repositories or
+		// /{org}/{project}/_apis/git/repositories.
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		if parts[0] != org || !strings.HasSuffix(r.URL.Path, "/_apis/git/repositories") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var value []*azuredevops.Repository
+		for project, repos := range projects {
+			if len(parts) == 5 && parts[1] != project {
+				continue
+			}
+			for _, repo := range repos {
+				value = append(value, withProject(project, repo))
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"count": len(value), "value": value})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
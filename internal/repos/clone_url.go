@@ -11,9 +11,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitolite"
@@ -64,6 +66,14 @@ func cloneURL(parsed any, logger log.Logger, kind string, repo *types.Repo) (str
 		if r, ok := repo.Metadata.(*gerrit.Project); ok {
 			return gerritCloneURL(logger, r, t), nil
 		}
+	case *schema.AzureDevOpsConnection:
+		if r, ok := repo.Metadata.(*azuredevops.Repository); ok {
+			return azureDevOpsCloneURL(logger, r, t), nil
+		}
+	case *schema.GiteaConnection:
+		if r, ok := repo.Metadata.(*gitea.Repository); ok {
+			return giteaCloneURL(logger, r, t), nil
+		}
 	case *schema.GitHubConnection:
 		if r, ok := repo.Metadata.(*github.Repository); ok {
 			return githubCloneURL(logger, r, t)
@@ -100,6 +110,8 @@ func cloneURL(parsed any, logger log.Logger, kind string, repo *types.Repo) (str
 		return string(repo.Name), nil
 	case *schema.RubyPackagesConnection:
 		return string(repo.Name), nil
+	case *schema.NuGetPackagesConnection:
+		return string(repo.Name), nil
 	case *schema.JVMPackagesConnection:
 		if r, ok := repo.Metadata.(*reposource.MavenMetadata); ok {
 			return r.Module.CloneURL(), nil
@@ -233,6 +245,34 @@ func gitlabCloneURL(logger log.Logger, repo *gitlab.Project, cfg *schema.GitLabC
 	return u.String()
 }
 
+func giteaCloneURL(logger log.Logger, repo *gitea.Repository, cfg *schema.GiteaConnection) string {
+	if cfg.GitURLType == "ssh" {
+		return repo.SSHURL // SSH authentication must be provided out-of-band
+	}
+	if cfg.Token == "" {
+		return repo.CloneURL
+	}
+	u, err := url.Parse(repo.CloneURL)
+	if err != nil {
+		logger.Warn("Error adding authentication to Gitea repository Git remote URL.", log.String("url", repo.CloneURL), log.Error(err))
+		return repo.CloneURL
+	}
+	// Gitea treats the username as an access token if the password is
+	// "x-oauth-basic", which is supported by all versions of Gitea and Forgejo.
+	u.User = url.UserPassword(cfg.Token, "x-oauth-basic")
+	return u.String()
+}
+
+func azureDevOpsCloneURL(logger log.Logger, repo *azuredevops.Repository, cfg *schema.AzureDevOpsConnection) string {
+	u, err := url.Parse(repo.RemoteURL)
+	if err != nil {
+		logger.Warn("Error adding authentication to Azure DevOps repository Git remote URL.", log.String("url", repo.RemoteURL), log.Error(err))
+		return repo.RemoteURL
+	}
+	u.User = url.UserPassword(cfg.Username, cfg.Token)
+	return u.String()
+}
+
 func gerritCloneURL(logger log.Logger, project *gerrit.Project, cfg *schema.GerritConnection) string {
 	u, err := url.Parse(cfg.Url)
 	if err != nil {
@@ -0,0 +1,213 @@
+package repos
+
+import (
+	"context"
+	"path"
+	"strconv"
+
+	"github.com/goware/urlx"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/iterator"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A GiteaSource yields repositories from a single Gitea or Forgejo connection
+// configured in Sourcegraph via the external services configuration.
+type GiteaSource struct {
+	svc       *types.ExternalService
+	config    *schema.GiteaConnection
+	cli       *gitea.Client
+	serviceID string
+
+	exclude         excludeFunc
+	excludeArchived bool
+	excludeForks    bool
+}
+
+// NewGiteaSource returns a new GiteaSource from the given external service.
+func NewGiteaSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GiteaSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GiteaConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d config error", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	httpCli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := gitea.NewClient(svc.URN(), &c, httpCli)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		eb              excludeBuilder
+		excludeArchived bool
+		excludeForks    bool
+	)
+
+	for _, r := range c.Exclude {
+		eb.Exact(r.Name)
+		if r.Id != 0 {
+			eb.Exact(strconv.Itoa(r.Id))
+		}
+		eb.Pattern(r.Pattern)
+
+		if r.Archived {
+			excludeArchived = true
+		}
+
+		if r.Forks {
+			excludeForks = true
+		}
+	}
+
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &GiteaSource{
+		svc:             svc,
+		config:          &c,
+		cli:             cli,
+		serviceID:       extsvc.NormalizeBaseURL(cli.URL).String(),
+		exclude:         exclude,
+		excludeArchived: excludeArchived,
+		excludeForks:    excludeForks,
+	}, nil
+}
+
+// CheckConnection at this point assumes availability and relies on errors returned
+// from the subsequent calls. This is going to be expanded as part of issue #44683
+// to actually only return true if the source can serve requests.
+func (s *GiteaSource) CheckConnection(ctx context.Context) error {
+	return nil
+}
+
+// ListRepos returns all Gitea repositories configured with this GiteaSource's
+// config: the repositories of each configured organization and user, and the
+// repositories matching each repository query.
+func (s *GiteaSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	var its []*iterator.Iterator[*gitea.Repository]
+	for _, org := range s.config.Orgs {
+		its = append(its, s.cli.ListOrgRepos(ctx, org))
+	}
+	for _, user := range s.config.Users {
+		its = append(its, s.cli.ListUserRepos(ctx, user))
+	}
+	for _, query := range s.config.RepositoryQuery {
+		if query == "all" {
+			query = ""
+		}
+		its = append(its, s.cli.SearchRepos(ctx, query))
+	}
+
+	// A repository can be matched by more than one of the above, but is only
+	// yielded once.
+	seen := make(map[int64]struct{})
+	for _, it := range its {
+		for it.Next() {
+			r := it.Current()
+			if _, ok := seen[r.ID]; ok {
+				continue
+			}
+			seen[r.ID] = struct{}{}
+
+			if s.excludes(r) {
+				continue
+			}
+
+			repo, err := s.makeRepo(r)
+			if err != nil {
+				results <- SourceResult{Source: s, Err: err}
+				return
+			}
+			results <- SourceResult{Source: s, Repo: repo}
+		}
+
+		// An error listing one organization, user or query doesn't prevent
+		// listing the others.
+		if err := it.Err(); err != nil {
+			results <- SourceResult{Source: s, Err: err}
+		}
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *GiteaSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s *GiteaSource) excludes(r *gitea.Repository) bool {
+	if s.exclude(r.FullName) || s.exclude(strconv.FormatInt(r.ID, 10)) {
+		return true
+	}
+
+	if s.excludeArchived && r.Archived {
+		return true
+	}
+
+	if s.excludeForks && r.Fork {
+		return true
+	}
+
+	return false
+}
+
+func (s *GiteaSource) makeRepo(r *gitea.Repository) (*types.Repo, error) {
+	urn := s.svc.URN()
+
+	htmlURL, err := urlx.Parse(r.HTMLURL)
+	if err != nil {
+		return nil, err
+	}
+
+	name := path.Join(htmlURL.Host, htmlURL.Path)
+
+	return &types.Repo{
+		Name:        api.RepoName(name),
+		URI:         name,
+		Description: r.Description,
+		Fork:        r.Fork,
+		Archived:    r.Archived,
+		Private:     r.Private,
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          strconv.FormatInt(r.ID, 10),
+			ServiceType: extsvc.TypeGitea,
+			ServiceID:   s.serviceID,
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: s.remoteURL(r),
+			},
+		},
+		Metadata: r,
+	}, nil
+}
+
+// remoteURL returns the repository's Git remote URL without credentials. Use
+// repos.CloneURL to get an authenticated clone URL.
+func (s *GiteaSource) remoteURL(r *gitea.Repository) string {
+	if s.config.GitURLType == "ssh" {
+		return r.SSHURL // SSH authentication must be provided out-of-band
+	}
+	return r.CloneURL
+}
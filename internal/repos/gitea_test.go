@@ -0,0 +1,201 @@
+package repos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGiteaSource_ListRepos(t *testing.T) {
+	server := newFakeGiteaServer(t, []*gitea.Repository{
+		{ID: 1, FullName: "forgejo/forgejo", Owner: &gitea.User{Login: "forgejo"}},
+		{ID: 2, FullName: "forgejo/runner", Owner: &gitea.User{Login: "forgejo"}, Archived: true},
+		{ID: 3, FullName: "forgejo/website", Owner: &gitea.User{Login: "forgejo"}},
+		{ID: 4, FullName: "alice/forgejo", Owner: &gitea.User{Login: "alice"}, Fork: true},
+		{ID: 5, FullName: "alice/dotfiles", Owner: &gitea.User{Login: "alice"}, Private: true},
+		{ID: 6, FullName: "bob/notes", Owner: &gitea.User{Login: "bob"}},
+	})
+
+	list := func(t *testing.T, c *schema.GiteaConnection) []string {
+		t.Helper()
+
+		c.Url = server.URL
+		svc := &types.ExternalService{
+			Kind:   extsvc.KindGitea,
+			Config: extsvc.NewUnencryptedConfig(marshalJSON(t, c)),
+		}
+
+		src, err := NewGiteaSource(context.Background(), svc, nil)
+		require.NoError(t, err)
+		src.cli = src.cli.WithPerPage(2)
+
+		repos, err := listAll(context.Background(), src)
+		require.NoError(t, err)
+
+		var names []string
+		for _, r := range repos {
+			names = append(names, strings.TrimPrefix(string(r.Name), strings.TrimPrefix(server.URL, "http://")+"/"))
+		}
+		return names
+	}
+
+	t.Run("orgs and users", func(t *testing.T) {
+		names := list(t, &schema.GiteaConnection{
+			Orgs:  []string{"forgejo"},
+			Users: []string{"alice"},
+		})
+		assert.ElementsMatch(t, []string{
+			"forgejo/forgejo",
+			"forgejo/runner",
+			"forgejo/website",
+			"alice/forgejo",
+			"alice/dotfiles",
+		}, names)
+	})
+
+	t.Run("repository query", func(t *testing.T) {
+		names := list(t, &schema.GiteaConnection{
+			Orgs:            []string{"forgejo"},
+			RepositoryQuery: []string{"forgejo"},
+		})
+		// forgejo/forgejo is matched by the org and the query, but is only
+		// listed once.
+		assert.ElementsMatch(t, []string{
+			"forgejo/forgejo",
+			"forgejo/runner",
+			"forgejo/website",
+			"alice/forgejo",
+		}, names)
+	})
+
+	t.Run("all", func(t *testing.T) {
+		names := list(t, &schema.GiteaConnection{RepositoryQuery: []string{"all"}})
+		assert.Len(t, names, 6)
+	})
+
+	t.Run("exclude", func(t *testing.T) {
+		names := list(t, &schema.GiteaConnection{
+			RepositoryQuery: []string{"all"},
+			Exclude: []*schema.ExcludedGiteaRepo{
+				{Name: "bob/notes"},
+				{Id: 3},
+				{Pattern: "^alice/dot"},
+				{Forks: true},
+				{Archived: true},
+			},
+		})
+		assert.Equal(t, []string{"forgejo/forgejo"}, names)
+	})
+
+	t.Run("repo", func(t *testing.T) {
+		c := &schema.GiteaConnection{Url: server.URL, Users: []string{"alice"}}
+		svc := &types.ExternalService{
+			ID:     7,
+			Kind:   extsvc.KindGitea,
+			Config: extsvc.NewUnencryptedConfig(marshalJSON(t, c)),
+		}
+		src, err := NewGiteaSource(context.Background(), svc, nil)
+		require.NoError(t, err)
+
+		repos, err := listAll(context.Background(), src)
+		require.NoError(t, err)
+		require.Len(t, repos, 2)
+
+		host := strings.TrimPrefix(server.URL, "http://")
+		r := repos[0]
+		assert.Equal(t, api.RepoName(host+"/alice/forgejo"), r.Name)
+		assert.True(t, r.Fork)
+		assert.Equal(t, api.ExternalRepoSpec{
+			ID:          "4",
+			ServiceType: extsvc.TypeGitea,
+			ServiceID:   server.URL + "/",
+		}, r.ExternalRepo)
+		assert.Equal(t, server.URL+"/alice/forgejo.git", r.Sources[svc.URN()].CloneURL)
+		assert.True(t, repos[1].Private)
+	})
+}
+
+// newFakeGiteaServer returns a server that implements the Gitea API endpoints
+// listing repositories, paginated like Gitea.
+func newFakeGiteaServer(t *testing.T, repos []*gitea.Repository) *httptest.Server {
+	t.Helper()
+
+	var server *httptest.Server
+	withURLs := func(r *gitea.Repository) *gitea.Repository {
+		rr := *r
+		rr.Name = r.FullName[strings.Index(r.FullName, "/")+1:]
+		rr.HTMLURL = server.URL + "/" + r.FullName
+		rr.CloneURL = rr.HTMLURL + ".git"
+		return &rr
+	}
+
+	paginate := func(w http.ResponseWriter, r *http.Request, matches []*gitea.Repository) []*gitea.Repository {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		start, end := (page-1)*limit, page*limit
+		if start > len(matches) {
+			start = len(matches)
+		}
+		if end < len(matches) {
+			next := *r.URL
+			q := next.Query()
+			q.Set("page", strconv.Itoa(page+1))
+			next.RawQuery = q.Encode()
+			w.Header().Set("Link", fmt.Sprintf("<%s%s>; rel=\"next\"", server.URL, next.String()))
+		} else {
+			end = len(matches)
+		}
+
+		var out []*gitea.Repository
+		for _, m := range matches[start:end] {
+			out = append(out, withURLs(m))
+		}
+		return out
+	}
+
+	byOwner := func(w http.ResponseWriter, r *http.Request) {
+		owner := strings.Split(r.URL.Path, "/")[4]
+		var matches []*gitea.Repository
+		for _, repo := range repos {
+			if repo.Owner.Login == owner {
+				matches = append(matches, repo)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(paginate(w, r, matches))
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/orgs/", byOwner)
+	mux.HandleFunc("/api/v1/users/", byOwner)
+	mux.HandleFunc("/api/v1/repos/search", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		var matches []*gitea.Repository
+		for _, repo := range repos {
+			if strings.Contains(repo.FullName[strings.Index(repo.FullName, "/")+1:], q) {
+				matches = append(matches, repo)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"ok":   true,
+			"data": paginate(w, r, matches),
+		})
+	})
+
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
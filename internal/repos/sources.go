@@ -70,6 +70,10 @@ func NewSource(ctx context.Context, logger log.Logger, db database.DB, svc *type
 		return NewJVMPackagesSource(ctx, svc)
 	case extsvc.KindPagure:
 		return NewPagureSource(ctx, svc, cf)
+	case extsvc.KindGitea:
+		return NewGiteaSource(ctx, svc, cf)
+	case extsvc.KindAzureDevOps:
+		return NewAzureDevOpsSource(ctx, svc, cf)
 	case extsvc.KindNpmPackages:
 		return NewNpmPackagesSource(ctx, svc, cf)
 	case extsvc.KindPythonPackages:
@@ -78,6 +82,8 @@ func NewSource(ctx context.Context, logger log.Logger, db database.DB, svc *type
 		return NewRustPackagesSource(ctx, svc, cf)
 	case extsvc.KindRubyPackages:
 		return NewRubyPackagesSource(ctx, svc, cf)
+	case extsvc.KindNuGetPackages:
+		return NewNuGetPackagesSource(ctx, svc, cf)
 	case extsvc.KindOther:
 		return NewOtherSource(ctx, svc, cf, logger.Scoped("OtherSource", ""))
 	default:
@@ -24,6 +24,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -38,6 +39,10 @@ type Syncer struct {
 	// Synced is sent a collection of Repos that were synced by Sync (only if Synced is non-nil)
 	Synced chan Diff
 
+	// OutboundWebhooks is notified of repos that were deleted by Sync (only if
+	// OutboundWebhooks is non-nil)
+	OutboundWebhooks outbound.OutboundWebhookService
+
 	ObsvCtx *observation.Context
 
 	// Now is time.Now. Can be set by tests to get deterministic output.
@@ -456,6 +461,39 @@ func (s *Syncer) notifyDeleted(ctx context.Context, deleted ...api.RepoID) {
 		case s.Synced <- d:
 		}
 	}
+
+	if s.OutboundWebhooks != nil && len(deleted) > 0 {
+		s.enqueueRepoDeletedWebhooks(ctx, deleted)
+	}
+}
+
+// enqueueRepoDeletedWebhooks notifies outbound webhooks of the given repos that
+// were soft deleted. Repos that were only removed from an external service but
+// are still synced by another are skipped.
+func (s *Syncer) enqueueRepoDeletedWebhooks(ctx context.Context, deleted []api.RepoID) {
+	logger := s.ObsvCtx.Logger.Scoped("enqueueRepoDeletedWebhooks", "")
+
+	rs, err := s.Store.RepoStore().List(ctx, database.ReposListOptions{
+		IDs:            deleted,
+		IncludeDeleted: true,
+	})
+	if err != nil {
+		logger.Warn("failed to list deleted repos for outbound webhooks", log.Error(err))
+		return
+	}
+
+	for _, r := range rs {
+		if r.DeletedAt.IsZero() {
+			continue
+		}
+
+		name := string(api.UndeletedRepoName(r.Name))
+		if err := s.OutboundWebhooks.Enqueue(ctx, outbound.EventTypeRepoDeleted, &name, outbound.RepoDeletedData{
+			Repository: outbound.Repository{ID: int32(r.ID), Name: name},
+		}); err != nil {
+			logger.Warn("failed to enqueue outbound webhook", log.String("repo", name), log.Error(err))
+		}
+	}
 }
 
 // ErrCloudDefaultSync is returned by SyncExternalService if an attempt to
@@ -0,0 +1,261 @@
+// Package export flattens search results into rows with a stable set of
+// columns, so that they can be written out as JSON lines or CSV.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Format is a file format search results can be exported to.
+type Format string
+
+const (
+	FormatJSONL Format = "jsonl"
+	FormatCSV   Format = "csv"
+)
+
+// ParseFormat returns the format with the given name. Names are case
+// insensitive.
+func ParseFormat(name string) (Format, error) {
+	switch f := Format(strings.ToLower(name)); f {
+	case FormatJSONL, FormatCSV:
+		return f, nil
+	}
+	return "", errors.Errorf("unsupported export format %q, expected %q or %q", name, FormatJSONL, FormatCSV)
+}
+
+// ContentType is the MIME type of files in this format.
+func (f Format) ContentType() string {
+	if f == FormatCSV {
+		return "text/csv"
+	}
+	return "application/x-ndjson"
+}
+
+// FormatForContentType returns the format with the given MIME type, if any.
+func FormatForContentType(mediaType string) (Format, bool) {
+	for _, f := range []Format{FormatJSONL, FormatCSV} {
+		if strings.EqualFold(mediaType, f.ContentType()) {
+			return f, true
+		}
+	}
+	return "", false
+}
+
+// Extension is the file extension of files in this format, including the
+// leading dot.
+func (f Format) Extension() string {
+	return "." + string(f)
+}
+
+// Columns are the names of the fields of a Row, in the order they are written.
+// Columns are never removed or reordered, so that consumers of exported files
+// can rely on them.
+var Columns = []string{
+	"type",
+	"repository",
+	"revision",
+	"commit",
+	"path",
+	"line",
+	"preview",
+	"author",
+	"date",
+	"symbol",
+	"symbol_kind",
+	"owner",
+}
+
+// Row is a single flattened search result. Fields which don't apply to the
+// type of the result are left empty.
+type Row struct {
+	// Type is one of "content", "path", "symbol", "repo", "commit", "diff"
+	// or "owner".
+	Type       string `json:"type"`
+	Repository string `json:"repository"`
+	// Revision is the revision specifier the result was found at, if it
+	// was searched at a revision other than the default branch.
+	Revision string `json:"revision"`
+	Commit   string `json:"commit"`
+	Path     string `json:"path"`
+	// Line is the 1-based line number of content and symbol results.
+	Line       int    `json:"line"`
+	Preview    string `json:"preview"`
+	Author     string `json:"author"`
+	Date       string `json:"date"`
+	Symbol     string `json:"symbol"`
+	SymbolKind string `json:"symbol_kind"`
+	Owner      string `json:"owner"`
+}
+
+func (r *Row) record() []string {
+	line := ""
+	if r.Line > 0 {
+		line = strconv.Itoa(r.Line)
+	}
+	return []string{
+		r.Type,
+		r.Repository,
+		r.Revision,
+		r.Commit,
+		r.Path,
+		line,
+		r.Preview,
+		r.Author,
+		r.Date,
+		r.Symbol,
+		r.SymbolKind,
+		r.Owner,
+	}
+}
+
+// Rows flattens a match into rows. File matches produce a row per matched line
+// or symbol, or a single row if only the path matched. All other matches
+// produce a single row.
+func Rows(match result.Match) ([]Row, error) {
+	switch m := match.(type) {
+	case *result.FileMatch:
+		return fileRows(m), nil
+	case *result.RepoMatch:
+		return []Row{{
+			Type:       "repo",
+			Repository: string(m.Name),
+			Revision:   m.Rev,
+		}}, nil
+	case *result.CommitMatch:
+		return []Row{commitRow(m)}, nil
+	case *result.OwnerMatch:
+		return []Row{{
+			Type:       "owner",
+			Repository: string(m.Repo.Name),
+			Owner:      m.Identifier(),
+		}}, nil
+	}
+	return nil, errors.Errorf("unsupported match type %T", match)
+}
+
+func fileRows(fm *result.FileMatch) []Row {
+	file := Row{
+		Repository: string(fm.Repo.Name),
+		Commit:     string(fm.CommitID),
+		Path:       fm.Path,
+	}
+	if fm.InputRev != nil {
+		file.Revision = *fm.InputRev
+	}
+
+	if len(fm.Symbols) > 0 {
+		rows := make([]Row, 0, len(fm.Symbols))
+		for _, sym := range fm.Symbols {
+			row := file
+			row.Type = "symbol"
+			row.Line = sym.Symbol.Line
+			row.Symbol = sym.Symbol.Name
+			row.SymbolKind = "UNKNOWN"
+			if kind := sym.Symbol.LSPKind(); kind != 0 {
+				row.SymbolKind = strings.ToUpper(kind.String())
+			}
+			rows = append(rows, row)
+		}
+		return rows
+	}
+
+	if fm.ChunkMatches.MatchCount() > 0 {
+		lineMatches := fm.ChunkMatches.AsLineMatches()
+		rows := make([]Row, 0, len(lineMatches))
+		for _, lm := range lineMatches {
+			row := file
+			row.Type = "content"
+			row.Line = int(lm.LineNumber) + 1
+			row.Preview = lm.Preview
+			rows = append(rows, row)
+		}
+		return rows
+	}
+
+	file.Type = "path"
+	return []Row{file}
+}
+
+func commitRow(cm *result.CommitMatch) Row {
+	row := Row{
+		Type:       "commit",
+		Repository: string(cm.Repo.Name),
+		Commit:     string(cm.Commit.ID),
+		Preview:    cm.Commit.Message.Subject(),
+		Author:     cm.Commit.Author.Name,
+		Date:       cm.Commit.Author.Date.UTC().Format(time.RFC3339),
+	}
+	if cm.DiffPreview != nil {
+		row.Type = "diff"
+	}
+	if len(cm.SourceRefs) > 0 {
+		row.Revision = cm.SourceRefs[0]
+	}
+	return row
+}
+
+// Writer writes flattened search results to an underlying writer.
+type Writer struct {
+	csv  *csv.Writer
+	json *json.Encoder
+}
+
+// NewWriter returns a Writer for the given format. For CSV the header row is
+// written straight away, so that an export without results is still a valid
+// file. Callers must call Flush once they are done writing.
+func NewWriter(w io.Writer, format Format) (*Writer, error) {
+	switch format {
+	case FormatJSONL:
+		return &Writer{json: json.NewEncoder(w)}, nil
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(Columns); err != nil {
+			return nil, err
+		}
+		return &Writer{csv: cw}, nil
+	}
+	return nil, errors.Errorf("unsupported export format %q", format)
+}
+
+// Write writes the rows of match and returns how many rows were written.
+func (w *Writer) Write(match result.Match) (int, error) {
+	rows, err := Rows(match)
+	if err != nil {
+		return 0, err
+	}
+	for i := range rows {
+		if err := w.WriteRow(&rows[i]); err != nil {
+			return i, err
+		}
+	}
+	return len(rows), nil
+}
+
+// WriteRow writes a single row.
+func (w *Writer) WriteRow(row *Row) error {
+	if w.csv != nil {
+		return w.csv.Write(row.record())
+	}
+	if err := w.json.Encode(row); err != nil {
+		return errors.Wrap(err, "encoding row")
+	}
+	return nil
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (w *Writer) Flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		return w.csv.Error()
+	}
+	return nil
+}
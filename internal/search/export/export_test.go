@@ -0,0 +1,135 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func testMatches() []result.Match {
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+	rev := "main"
+	file := result.File{Repo: repo, CommitID: "deadbeef", Path: "cmd/main.go", InputRev: &rev}
+
+	return []result.Match{
+		&result.FileMatch{
+			File: file,
+			ChunkMatches: result.ChunkMatches{{
+				Content:      "func main() {\n\tfmt.Println(\"hello, world\")",
+				ContentStart: result.Location{Line: 4},
+				Ranges: result.Ranges{{
+					Start: result.Location{Offset: 50, Line: 4, Column: 5},
+					End:   result.Location{Offset: 70, Line: 5, Column: 5},
+				}},
+			}},
+		},
+		&result.FileMatch{
+			File: file,
+			Symbols: []*result.SymbolMatch{
+				result.NewSymbolMatch(&file, 5, -1, "main", "func", "", "", "Go", "func main() {", false),
+			},
+		},
+		&result.FileMatch{File: result.File{Repo: repo, CommitID: "deadbeef", Path: "README.md"}},
+		&result.RepoMatch{Name: repo.Name, ID: repo.ID},
+		&result.CommitMatch{
+			Repo: repo,
+			Commit: gitdomain.Commit{
+				ID:      "cafe",
+				Author:  gitdomain.Signature{Name: "Alice", Date: time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC)},
+				Message: "Fix the thing\n\nWith details, too.",
+			},
+			MessagePreview: &result.MatchedString{Content: "Fix the thing"},
+		},
+		&result.OwnerMatch{Repo: repo, Handle: "alice"},
+	}
+}
+
+func TestWriter(t *testing.T) {
+	for _, tc := range []struct {
+		format Format
+		want   string
+	}{{
+		format: FormatCSV,
+		want: `type,repository,revision,commit,path,line,preview,author,date,symbol,symbol_kind,owner
+content,github.com/sourcegraph/sourcegraph,main,deadbeef,cmd/main.go,5,func main() {,,,,,
+content,github.com/sourcegraph/sourcegraph,main,deadbeef,cmd/main.go,6,"	fmt.Println(""hello, world"")",,,,,
+symbol,github.com/sourcegraph/sourcegraph,main,deadbeef,cmd/main.go,5,,,,main,FUNCTION,
+path,github.com/sourcegraph/sourcegraph,,deadbeef,README.md,,,,,,,
+repo,github.com/sourcegraph/sourcegraph,,,,,,,,,,
+commit,github.com/sourcegraph/sourcegraph,,cafe,,,Fix the thing,Alice,2022-12-01T10:00:00Z,,,
+owner,github.com/sourcegraph/sourcegraph,,,,,,,,,,@alice
+`,
+	}, {
+		format: FormatJSONL,
+		want: `{"type":"content","repository":"github.com/sourcegraph/sourcegraph","revision":"main","commit":"deadbeef","path":"cmd/main.go","line":5,"preview":"func main() {","author":"","date":"","symbol":"","symbol_kind":"","owner":""}
+{"type":"content","repository":"github.com/sourcegraph/sourcegraph","revision":"main","commit":"deadbeef","path":"cmd/main.go","line":6,"preview":"\tfmt.Println(\"hello, world\")","author":"","date":"","symbol":"","symbol_kind":"","owner":""}
+{"type":"symbol","repository":"github.com/sourcegraph/sourcegraph","revision":"main","commit":"deadbeef","path":"cmd/main.go","line":5,"preview":"","author":"","date":"","symbol":"main","symbol_kind":"FUNCTION","owner":""}
+{"type":"path","repository":"github.com/sourcegraph/sourcegraph","revision":"","commit":"deadbeef","path":"README.md","line":0,"preview":"","author":"","date":"","symbol":"","symbol_kind":"","owner":""}
+{"type":"repo","repository":"github.com/sourcegraph/sourcegraph","revision":"","commit":"","path":"","line":0,"preview":"","author":"","date":"","symbol":"","symbol_kind":"","owner":""}
+{"type":"commit","repository":"github.com/sourcegraph/sourcegraph","revision":"","commit":"cafe","path":"","line":0,"preview":"Fix the thing","author":"Alice","date":"2022-12-01T10:00:00Z","symbol":"","symbol_kind":"","owner":""}
+{"type":"owner","repository":"github.com/sourcegraph/sourcegraph","revision":"","commit":"","path":"","line":0,"preview":"","author":"","date":"","symbol":"","symbol_kind":"","owner":"@alice"}
+`,
+	}} {
+		t.Run(string(tc.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := NewWriter(&buf, tc.format)
+			require.NoError(t, err)
+
+			rows := 0
+			for _, m := range testMatches() {
+				n, err := w.Write(m)
+				require.NoError(t, err)
+				rows += n
+			}
+			require.NoError(t, w.Flush())
+
+			require.Equal(t, 7, rows)
+			require.Equal(t, tc.want, buf.String())
+		})
+	}
+}
+
+func TestWriter_EmptyCSVHasHeader(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, FormatCSV)
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+	require.Equal(t, "type,repository,revision,commit,path,line,preview,author,date,symbol,symbol_kind,owner\n", buf.String())
+}
+
+func TestParseFormat(t *testing.T) {
+	f, err := ParseFormat("CSV")
+	require.NoError(t, err)
+	require.Equal(t, FormatCSV, f)
+
+	f, err = ParseFormat("jsonl")
+	require.NoError(t, err)
+	require.Equal(t, FormatJSONL, f)
+
+	_, err = ParseFormat("xml")
+	require.Error(t, err)
+}
+
+func TestFormatForContentType(t *testing.T) {
+	f, ok := FormatForContentType("text/csv")
+	require.True(t, ok)
+	require.Equal(t, FormatCSV, f)
+
+	f, ok = FormatForContentType("Application/X-NDJSON")
+	require.True(t, ok)
+	require.Equal(t, FormatJSONL, f)
+
+	_, ok = FormatForContentType("text/event-stream")
+	require.False(t, ok)
+}
+
+func TestRows_UnsupportedMatch(t *testing.T) {
+	_, err := Rows(&result.CommitDiffMatch{})
+	require.Error(t, err)
+}
@@ -0,0 +1,215 @@
+package jobutil
+
+import (
+	"context"
+	"sync"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/own"
+	"github.com/sourcegraph/sourcegraph/internal/own/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewFileHasOwnerFilterJob creates a filter job to post-filter results for
+// the file:has.owner() predicate. Only file matches are kept whose owners,
+// according to the CODEOWNERS file of the repository at the matched commit,
+// include all of includeOwners and none of excludeOwners.
+func NewFileHasOwnerFilterJob(includeOwners, excludeOwners []string, child job.Job) job.Job {
+	return &fileHasOwnerFilterJob{
+		includeOwners: includeOwners,
+		excludeOwners: excludeOwners,
+		child:         child,
+	}
+}
+
+type fileHasOwnerFilterJob struct {
+	includeOwners []string
+	excludeOwners []string
+	child         job.Job
+}
+
+func (j *fileHasOwnerFilterJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	ownService := own.NewService(clients.Gitserver)
+
+	var (
+		mu   sync.Mutex
+		errs error
+	)
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		filtered := event.Results[:0]
+		for _, m := range event.Results {
+			fm, ok := m.(*result.FileMatch)
+			if !ok {
+				// Only files have owners.
+				continue
+			}
+			owners, err := fileOwners(ctx, ownService, fm)
+			if err != nil {
+				mu.Lock()
+				errs = errors.Append(errs, err)
+				mu.Unlock()
+				continue
+			}
+			if j.matches(owners) {
+				filtered = append(filtered, fm)
+			}
+		}
+		event.Results = filtered
+		stream.Send(event)
+	})
+
+	alert, err = j.child.Run(ctx, clients, filteredStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+func (j *fileHasOwnerFilterJob) matches(owners []codeowners.Owner) bool {
+	for _, want := range j.includeOwners {
+		if !containsOwner(owners, want) {
+			return false
+		}
+	}
+	for _, notWant := range j.excludeOwners {
+		if containsOwner(owners, notWant) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsOwner(owners []codeowners.Owner, handleOrEmail string) bool {
+	for _, owner := range owners {
+		if owner.Matches(handleOrEmail) {
+			return true
+		}
+	}
+	return false
+}
+
+func (j *fileHasOwnerFilterJob) Name() string {
+	return "FileHasOwnerFilterJob"
+}
+
+func (j *fileHasOwnerFilterJob) Fields(v job.Verbosity) (res []otlog.Field) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			trace.Strings("includeOwners", j.includeOwners),
+			trace.Strings("excludeOwners", j.excludeOwners),
+		)
+	}
+	return res
+}
+
+func (j *fileHasOwnerFilterJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *fileHasOwnerFilterJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
+
+// NewSelectOwnersJob creates a job that replaces the file matches streamed by
+// child with the deduplicated owners of those files, for `select:file.owners`.
+func NewSelectOwnersJob(child job.Job) job.Job {
+	return &selectOwnersJob{child: child}
+}
+
+type selectOwnersJob struct {
+	child job.Job
+}
+
+func (j *selectOwnersJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	ownService := own.NewService(clients.Gitserver)
+
+	var (
+		mu    sync.Mutex
+		errs  error
+		dedup = result.NewDeduper()
+	)
+
+	selectingStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		var selected result.Matches
+		for _, m := range event.Results {
+			fm, ok := m.(*result.FileMatch)
+			if !ok {
+				continue
+			}
+			owners, err := fileOwners(ctx, ownService, fm)
+
+			mu.Lock()
+			if err != nil {
+				errs = errors.Append(errs, err)
+			}
+			for _, owner := range owners {
+				om := &result.OwnerMatch{
+					Handle: owner.Handle,
+					Email:  owner.Email,
+					Repo:   fm.Repo,
+				}
+				if dedup.Seen(om) {
+					continue
+				}
+				dedup.Add(om)
+				selected = append(selected, om)
+			}
+			mu.Unlock()
+		}
+		event.Results = selected
+		stream.Send(event)
+	})
+
+	alert, err = j.child.Run(ctx, clients, selectingStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+func (j *selectOwnersJob) Name() string {
+	return "SelectOwnersJob"
+}
+
+func (j *selectOwnersJob) Fields(job.Verbosity) []otlog.Field { return nil }
+
+func (j *selectOwnersJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *selectOwnersJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
+
+// fileOwners returns the owners of the file of fm at the commit it was
+// matched at.
+func fileOwners(ctx context.Context, ownService own.Service, fm *result.FileMatch) ([]codeowners.Owner, error) {
+	if fm.CommitID == "" {
+		return nil, nil
+	}
+	ruleset, err := ownService.RulesetForRepo(ctx, fm.Repo.Name, fm.CommitID)
+	if err != nil {
+		return nil, err
+	}
+	return ruleset.FindOwners(fm.Path), nil
+}
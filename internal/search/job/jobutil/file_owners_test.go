@@ -0,0 +1,122 @@
+package jobutil
+
+import (
+	"context"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestFileOwnersJobs(t *testing.T) {
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ReadFileFunc.SetDefaultHook(func(_ context.Context, repo api.RepoName, _ api.CommitID, name string, _ authz.SubRepoPermissionChecker) ([]byte, error) {
+		if repo == "github.com/sourcegraph/owned" && name == ".github/CODEOWNERS" {
+			return []byte("*.go @sourcegraph/gophers\n/docs/ docs@sourcegraph.com @alice\n"), nil
+		}
+		if repo == "github.com/sourcegraph/fork" && name == "CODEOWNERS" {
+			return []byte("*.go @sourcegraph/gophers\n"), nil
+		}
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	})
+	clients := job.RuntimeClients{Gitserver: gitserverClient}
+
+	fileMatch := func(repo api.RepoName, path string) *result.FileMatch {
+		return &result.FileMatch{
+			File: result.File{
+				Repo:     types.MinimalRepo{Name: repo},
+				CommitID: "deadbeef",
+				Path:     path,
+			},
+		}
+	}
+
+	mockJob := mockjob.NewMockJob()
+	mockJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{
+			Results: []result.Match{
+				fileMatch("github.com/sourcegraph/owned", "main.go"),
+				fileMatch("github.com/sourcegraph/owned", "cmd/server/server.go"),
+				fileMatch("github.com/sourcegraph/owned", "docs/index.md"),
+				fileMatch("github.com/sourcegraph/owned", "README.md"),
+				fileMatch("github.com/sourcegraph/unowned", "main.go"),
+				fileMatch("github.com/sourcegraph/fork", "main.go"),
+				&result.RepoMatch{Name: "github.com/sourcegraph/owned"},
+			},
+		})
+		return nil, nil
+	})
+
+	run := func(t *testing.T, j job.Job) []result.Match {
+		t.Helper()
+		agg := streaming.NewAggregatingStream()
+		if _, err := j.Run(context.Background(), clients, agg); err != nil {
+			t.Fatal(err)
+		}
+		return agg.Results
+	}
+
+	paths := func(matches []result.Match) []string {
+		var paths []string
+		for _, m := range matches {
+			fm := m.(*result.FileMatch)
+			paths = append(paths, string(fm.Repo.Name)+"/"+fm.Path)
+		}
+		return paths
+	}
+
+	t.Run("file:has.owner", func(t *testing.T) {
+		got := paths(run(t, NewFileHasOwnerFilterJob([]string{"@SourceGraph/Gophers"}, nil, mockJob)))
+		want := []string{
+			"github.com/sourcegraph/owned/main.go",
+			"github.com/sourcegraph/owned/cmd/server/server.go",
+			"github.com/sourcegraph/fork/main.go",
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("unexpected matches (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("-file:has.owner", func(t *testing.T) {
+		got := paths(run(t, NewFileHasOwnerFilterJob(nil, []string{"docs@sourcegraph.com"}, mockJob)))
+		want := []string{
+			"github.com/sourcegraph/owned/main.go",
+			"github.com/sourcegraph/owned/cmd/server/server.go",
+			"github.com/sourcegraph/owned/README.md",
+			"github.com/sourcegraph/unowned/main.go",
+			"github.com/sourcegraph/fork/main.go",
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("unexpected matches (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("select:file.owners", func(t *testing.T) {
+		var got []string
+		for _, m := range run(t, NewSelectOwnersJob(mockJob)) {
+			om := m.(*result.OwnerMatch)
+			got = append(got, string(om.Repo.Name)+" "+om.Identifier())
+		}
+		sort.Strings(got)
+		want := []string{
+			"github.com/sourcegraph/fork @sourcegraph/gophers",
+			"github.com/sourcegraph/owned @alice",
+			"github.com/sourcegraph/owned @sourcegraph/gophers",
+			"github.com/sourcegraph/owned docs@sourcegraph.com",
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("unexpected owners (-want +got):\n%s", diff)
+		}
+	})
+}
@@ -177,16 +177,33 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 
 	basicJob := NewParallelJob(children...)
 
+	{ // Apply ranking
+		if inputs.Features.Ranking && inputs.Protocol != search.Exhaustive {
+			basicJob = NewRankingJob(rankingFlushWallTime, computeFileMatchLimit(b, inputs.Protocol), basicJob)
+		}
+	}
+
 	{ // Apply file:contains.content() post-filter
 		if len(fileContainsPatterns) > 0 {
 			basicJob = NewFileContainsFilterJob(fileContainsPatterns, originalQuery.Pattern, b.IsCaseSensitive(), basicJob)
 		}
 	}
 
+	{ // Apply file:has.owner() post-filter
+		includeOwners, excludeOwners := b.FileHasOwner()
+		if len(includeOwners) > 0 || len(excludeOwners) > 0 {
+			basicJob = NewFileHasOwnerFilterJob(includeOwners, excludeOwners, basicJob)
+		}
+	}
+
 	{ // Apply selectors
 		if v, _ := b.ToParseTree().StringValue(query.FieldSelect); v != "" {
 			sp, _ := filter.SelectPathFromString(v) // Invariant: select already validated
-			basicJob = NewSelectJob(sp, basicJob)
+			if sp.Root() == filter.File && len(sp) > 1 && sp[1] == "owners" {
+				basicJob = NewSelectOwnersJob(basicJob)
+			} else {
+				basicJob = NewSelectJob(sp, basicJob)
+			}
 		}
 	}
 
@@ -209,8 +226,12 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 	}
 
 	{ // Apply timeout
-		timeout := timeoutDuration(b)
-		basicJob = NewTimeoutJob(timeout, basicJob)
+		// Exhaustive searches run in the background until they are done,
+		// unless the query explicitly asks for a timeout.
+		if inputs.Protocol != search.Exhaustive || b.GetTimeout() != nil {
+			timeout := timeoutDuration(b)
+			basicJob = NewTimeoutJob(timeout, basicJob)
+		}
 	}
 
 	{
@@ -293,7 +314,7 @@ func NewFlatJob(searchInputs *search.Inputs, f query.Flat) (job.Job, error) {
 	patternInfo := toTextPatternInfo(f.ToBasic(), resultTypes, searchInputs.Protocol)
 
 	// searcher to use full deadline if timeout: set or we are streaming.
-	useFullDeadline := f.GetTimeout() != nil || f.Count() != nil || searchInputs.Protocol == search.Streaming
+	useFullDeadline := f.GetTimeout() != nil || f.Count() != nil || searchInputs.Protocol == search.Streaming || searchInputs.Protocol == search.Exhaustive
 
 	repoOptions := toRepoOptions(f.ToBasic(), searchInputs.UserSettings)
 
@@ -517,6 +538,8 @@ func computeFileMatchLimit(b query.Basic, p search.Protocol) int {
 		return limits.DefaultMaxSearchResults
 	case search.Streaming:
 		return limits.DefaultMaxSearchResultsStreaming
+	case search.Exhaustive:
+		return limits.DefaultMaxSearchResultsExhaustive
 	}
 	panic("unreachable")
 }
@@ -555,6 +578,8 @@ func count(b query.Basic, p search.Protocol) int {
 		return limits.DefaultMaxSearchResults
 	case search.Streaming:
 		return limits.DefaultMaxSearchResultsStreaming
+	case search.Exhaustive:
+		return limits.DefaultMaxSearchResultsExhaustive
 	}
 	panic("unreachable")
 }
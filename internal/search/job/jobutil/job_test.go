@@ -46,6 +46,7 @@ func TestNewPlanJob(t *testing.T) {
 		query      string
 		protocol   search.Protocol
 		searchType query.SearchType
+		features   search.Features
 		want       autogold.Value
 	}{{
 		query:      `foo context:@userA`,
@@ -777,6 +778,98 @@ func TestNewPlanJob(t *testing.T) {
           (STRUCTURALSEARCH
             (patternInfo.pattern . (:[_]))(patternInfo.isStructural . true)(patternInfo.fileMatchLimit . 500)
             ))))))`),
+		}, {
+			query:      `foo context:global`,
+			protocol:   search.Exhaustive,
+			searchType: query.SearchTypeLiteral,
+			want: autogold.Want("exhaustive search has no timeout", `
+(LOG
+  (ALERT
+    (query . )
+    (originalQuery . )
+    (patternType . literal)
+    (LIMIT
+      (limit . 99999999)
+      (PARALLEL
+        (SEQUENTIAL
+          (ensureUnique . false)
+          (ZOEKTGLOBALTEXTSEARCH
+            (query . substr:"foo")
+            (type . text)
+            (repoOpts.searchContextSpec . global))
+          (REPOSEARCH
+            (repoOpts.repoFilters.0 . foo)(repoOpts.searchContextSpec . global)
+            (repoNamePatterns . [(?i)foo])))
+        (REPOSCOMPUTEEXCLUDED
+          (repoOpts.searchContextSpec . global))
+        NoopJob))))`),
+		}, {
+			query:      `foo context:global timeout:30s`,
+			protocol:   search.Exhaustive,
+			searchType: query.SearchTypeLiteral,
+			want: autogold.Want("exhaustive search with explicit timeout", `
+(LOG
+  (ALERT
+    (query . )
+    (originalQuery . )
+    (patternType . literal)
+    (TIMEOUT
+      (timeout . 30s)
+      (LIMIT
+        (limit . 99999999)
+        (PARALLEL
+          (SEQUENTIAL
+            (ensureUnique . false)
+            (ZOEKTGLOBALTEXTSEARCH
+              (query . substr:"foo")
+              (type . text)
+              (repoOpts.searchContextSpec . global))
+            (REPOSEARCH
+              (repoOpts.repoFilters.0 . foo)(repoOpts.searchContextSpec . global)
+              (repoNamePatterns . [(?i)foo])))
+          (REPOSCOMPUTEEXCLUDED
+            (repoOpts.searchContextSpec . global))
+          NoopJob)))))`),
+		}, {
+			query:      `foo repo:sourcegraph/sourcegraph`,
+			protocol:   search.Streaming,
+			searchType: query.SearchTypeLiteral,
+			features:   search.Features{Ranking: true},
+			want: autogold.Want("ranking reorders results of all backends", `
+(LOG
+  (ALERT
+    (query . )
+    (originalQuery . )
+    (patternType . literal)
+    (TIMEOUT
+      (timeout . 20s)
+      (LIMIT
+        (limit . 500)
+        (RANKING
+          (flushWallTime . 500ms)
+          (maxBuffered . 500)
+          (PARALLEL
+            (SEQUENTIAL
+              (ensureUnique . false)
+              (REPOPAGER
+                (repoOpts.repoFilters.0 . sourcegraph/sourcegraph)
+                (PARTIALREPOS
+                  (ZOEKTREPOSUBSETTEXTSEARCH
+                    (query . substr:"foo")
+                    (type . text))))
+              (REPOPAGER
+                (repoOpts.repoFilters.0 . sourcegraph/sourcegraph)
+                (PARTIALREPOS
+                  (SEARCHERTEXTSEARCH
+                    (indexed . false))))
+              (REPOSEARCH
+                (repoOpts.repoFilters.0 . sourcegraph/sourcegraph)(repoOpts.repoFilters.1 . foo)
+                (repoNamePatterns . [(?i)sourcegraph/sourcegraph (?i)foo])))
+            (REPOSCOMPUTEEXCLUDED
+              (repoOpts.repoFilters.0 . sourcegraph/sourcegraph))
+            (PARALLEL
+              NoopJob
+              NoopJob)))))))`),
 		},
 	}
 
@@ -789,7 +882,7 @@ func TestNewPlanJob(t *testing.T) {
 				UserSettings:        &schema.Settings{},
 				PatternType:         tc.searchType,
 				Protocol:            tc.protocol,
-				Features:            &search.Features{},
+				Features:            &tc.features,
 				OnSourcegraphDotCom: true,
 			}
 
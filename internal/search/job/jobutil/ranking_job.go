@@ -0,0 +1,222 @@
+package jobutil
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// rankingFlushWallTime is how long the ranking job buffers file matches. It is
+// the same budget Zoekt gets to collect results before ranking them.
+const rankingFlushWallTime = 500 * time.Millisecond
+
+// maxPathRanksLookupDuration bounds the time spent loading the ranks of paths
+// when flushing, so that the ranking budget is not exceeded by a slow
+// database.
+const maxPathRanksLookupDuration = 250 * time.Millisecond
+
+// NewRankingJob creates a job that reorders the file matches of child, no
+// matter which backend produced them. File matches are buffered until
+// flushWallTime passed, maxBuffered file matches arrived, or child finished.
+// They are then sent ordered by the star count of their repository, and
+// within a repository by the stored rank of their path. File matches which
+// arrive after the flush are sent as they arrive, so ranking never delays
+// results by more than flushWallTime. Other results and stats are not
+// delayed.
+func NewRankingJob(flushWallTime time.Duration, maxBuffered int, child job.Job) job.Job {
+	if _, ok := child.(*NoopJob); ok {
+		return child
+	}
+	return &rankingJob{
+		flushWallTime: flushWallTime,
+		maxBuffered:   maxBuffered,
+		child:         child,
+	}
+}
+
+type rankingJob struct {
+	flushWallTime time.Duration
+	maxBuffered   int
+	child         job.Job
+}
+
+func (j *rankingJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	tr, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	rs := &rankingStream{
+		ctx:         ctx,
+		tr:          tr,
+		pathRanks:   clients.DB.PathRanks(),
+		graphKey:    ranking.ResultsGraphKey,
+		maxBuffered: j.maxBuffered,
+		parent:      stream,
+	}
+
+	timer := time.AfterFunc(j.flushWallTime, rs.flush)
+	defer timer.Stop()
+
+	alert, err = j.child.Run(ctx, clients, rs)
+	rs.flush()
+	return alert, err
+}
+
+func (j *rankingJob) Name() string {
+	return "RankingJob"
+}
+
+func (j *rankingJob) Fields(v job.Verbosity) (res []otlog.Field) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			trace.Stringer("flushWallTime", j.flushWallTime),
+			otlog.Int("maxBuffered", j.maxBuffered),
+		)
+	}
+	return res
+}
+
+func (j *rankingJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *rankingJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
+
+// rankingStream buffers file matches until it is flushed, and passes
+// everything else on to parent.
+type rankingStream struct {
+	ctx         context.Context
+	tr          *trace.Trace
+	pathRanks   database.PathRankStore
+	graphKey    string
+	maxBuffered int
+	parent      streaming.Sender
+
+	// flushOnce ensures that the buffered file matches are flushed once, and
+	// that callers of flush wait for it to finish.
+	flushOnce sync.Once
+
+	// mu protects the fields below. It is held while sending to parent, so
+	// that file matches sent after the flush cannot overtake it.
+	mu       sync.Mutex
+	flushed  bool
+	buffered []*result.FileMatch
+}
+
+func (s *rankingStream) Send(event streaming.SearchEvent) {
+	s.mu.Lock()
+	if s.flushed {
+		s.parent.Send(event)
+		s.mu.Unlock()
+		return
+	}
+
+	rest := event.Results[:0]
+	for _, m := range event.Results {
+		if fm, ok := m.(*result.FileMatch); ok {
+			s.buffered = append(s.buffered, fm)
+			continue
+		}
+		rest = append(rest, m)
+	}
+	event.Results = rest
+	if len(event.Results) > 0 || !event.Stats.Zero() {
+		s.parent.Send(event)
+	}
+
+	full := s.maxBuffered > 0 && len(s.buffered) >= s.maxBuffered
+	s.mu.Unlock()
+
+	if full {
+		s.flush()
+	}
+}
+
+// flush sends the ranked buffered file matches to parent. Once flushed, file
+// matches are no longer buffered.
+func (s *rankingStream) flush() {
+	s.flushOnce.Do(s.doFlush)
+}
+
+func (s *rankingStream) doFlush() {
+	// The ranks of paths are loaded without holding mu, so that file matches
+	// can still be buffered in the meantime.
+	s.mu.Lock()
+	fms := s.buffered
+	s.buffered = nil
+	s.mu.Unlock()
+
+	s.rank(fms)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.flushed = true
+
+	// File matches which arrived while ranking are sent after the ranked ones,
+	// in the order in which they arrived.
+	fms = append(fms, s.buffered...)
+	s.buffered = nil
+	if len(fms) == 0 {
+		return
+	}
+
+	results := make(result.Matches, 0, len(fms))
+	for _, fm := range fms {
+		results = append(results, fm)
+	}
+	s.parent.Send(streaming.SearchEvent{Results: results})
+}
+
+// rank orders file matches by the star count of their repository, then by
+// the rank of their path. Paths without a rank come last, and otherwise the
+// order in which file matches arrived is kept. If the ranks of paths cannot be
+// loaded, file matches are only ordered by star count.
+func (s *rankingStream) rank(fms []*result.FileMatch) {
+	if len(fms) == 0 {
+		return
+	}
+
+	paths := map[api.RepoID][]string{}
+	for _, fm := range fms {
+		paths[fm.Repo.ID] = append(paths[fm.Repo.ID], fm.Path)
+	}
+
+	ctx, cancel := context.WithTimeout(s.ctx, maxPathRanksLookupDuration)
+	defer cancel()
+	pathRanks, err := s.pathRanks.GetPathRanks(ctx, s.graphKey, paths)
+	if err != nil {
+		s.tr.LazyPrintf("failed to load path ranks: %s", err)
+		pathRanks = nil
+	}
+	pathRank := func(fm *result.FileMatch) float64 {
+		if rank, ok := pathRanks[fm.Repo.ID][fm.Path]; ok {
+			return rank
+		}
+		return -1
+	}
+
+	sort.SliceStable(fms, func(i, j int) bool {
+		if fms[i].Repo.Stars != fms[j].Repo.Stars {
+			return fms[i].Repo.Stars > fms[j].Repo.Stars
+		}
+		return pathRank(fms[i]) > pathRank(fms[j])
+	})
+}
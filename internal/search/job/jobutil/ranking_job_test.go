@@ -0,0 +1,168 @@
+package jobutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestRankingJob(t *testing.T) {
+	small := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/small", Stars: 10}
+	popular := types.MinimalRepo{ID: 2, Name: "github.com/sourcegraph/popular", Stars: 1000}
+
+	fileMatch := func(repo types.MinimalRepo, path string) *result.FileMatch {
+		return &result.FileMatch{File: result.File{Repo: repo, Path: path}}
+	}
+
+	clientsWithRanks := func(ranks map[api.RepoID]map[string]float64, err error) job.RuntimeClients {
+		pathRanks := database.NewMockPathRankStore()
+		pathRanks.GetPathRanksFunc.SetDefaultHook(func(_ context.Context, graphKey string, paths map[api.RepoID][]string) (map[api.RepoID]map[string]float64, error) {
+			if graphKey != ranking.ResultsGraphKey {
+				t.Errorf("unexpected graph key %q", graphKey)
+			}
+			// Only the ranks of the paths of file matches are loaded.
+			res := map[api.RepoID]map[string]float64{}
+			for repoID, repoPaths := range paths {
+				for _, path := range repoPaths {
+					if rank, ok := ranks[repoID][path]; ok {
+						if res[repoID] == nil {
+							res[repoID] = map[string]float64{}
+						}
+						res[repoID][path] = rank
+					}
+				}
+			}
+			return res, err
+		})
+		db := database.NewMockDB()
+		db.PathRanksFunc.SetDefaultReturn(pathRanks)
+		return job.RuntimeClients{DB: db}
+	}
+
+	paths := func(events []streaming.SearchEvent) (paths [][]string) {
+		for _, event := range events {
+			var batch []string
+			for _, m := range event.Results {
+				switch v := m.(type) {
+				case *result.FileMatch:
+					batch = append(batch, string(v.Repo.Name)+"/"+v.Path)
+				case *result.RepoMatch:
+					batch = append(batch, string(v.Name))
+				}
+			}
+			paths = append(paths, batch)
+		}
+		return paths
+	}
+
+	run := func(t *testing.T, j job.Job, clients job.RuntimeClients) []streaming.SearchEvent {
+		t.Helper()
+		var events []streaming.SearchEvent
+		stream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+			events = append(events, event)
+		})
+		if _, err := j.Run(context.Background(), clients, stream); err != nil {
+			t.Fatal(err)
+		}
+		return events
+	}
+
+	mockJob := mockjob.NewMockJob()
+	mockJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{Results: []result.Match{
+			fileMatch(small, "a.go"),
+			&result.RepoMatch{Name: "github.com/sourcegraph/repo"},
+		}})
+		s.Send(streaming.SearchEvent{Results: []result.Match{
+			fileMatch(popular, "unranked.go"),
+			fileMatch(small, "b.go"),
+			fileMatch(popular, "ranked.go"),
+		}})
+		return nil, nil
+	})
+
+	t.Run("ranks by stars then path rank", func(t *testing.T) {
+		clients := clientsWithRanks(map[api.RepoID]map[string]float64{
+			small.ID:   {"b.go": 0.5, "a.go": 0.1},
+			popular.ID: {"ranked.go": 0.01},
+		}, nil)
+		got := paths(run(t, NewRankingJob(time.Minute, 100, mockJob), clients))
+		want := [][]string{
+			{"github.com/sourcegraph/repo"},
+			{
+				"github.com/sourcegraph/popular/ranked.go",
+				"github.com/sourcegraph/popular/unranked.go",
+				"github.com/sourcegraph/small/b.go",
+				"github.com/sourcegraph/small/a.go",
+			},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("unexpected events (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("falls back to stars if ranks are unavailable", func(t *testing.T) {
+		clients := clientsWithRanks(nil, errors.New("boom"))
+		got := paths(run(t, NewRankingJob(time.Minute, 100, mockJob), clients))
+		want := [][]string{
+			{"github.com/sourcegraph/repo"},
+			{
+				"github.com/sourcegraph/popular/unranked.go",
+				"github.com/sourcegraph/popular/ranked.go",
+				"github.com/sourcegraph/small/a.go",
+				"github.com/sourcegraph/small/b.go",
+			},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("unexpected events (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("flushes when the buffer is full", func(t *testing.T) {
+		clients := clientsWithRanks(nil, nil)
+		got := paths(run(t, NewRankingJob(time.Minute, 1, mockJob), clients))
+		want := [][]string{
+			{"github.com/sourcegraph/repo"},
+			{"github.com/sourcegraph/small/a.go"},
+			{
+				"github.com/sourcegraph/popular/unranked.go",
+				"github.com/sourcegraph/small/b.go",
+				"github.com/sourcegraph/popular/ranked.go",
+			},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("unexpected events (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("flushes after the wall time", func(t *testing.T) {
+		slowJob := mockjob.NewMockJob()
+		slowJob.RunFunc.SetDefaultHook(func(ctx context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+			s.Send(streaming.SearchEvent{Results: []result.Match{fileMatch(small, "a.go")}})
+			time.Sleep(50 * time.Millisecond)
+			s.Send(streaming.SearchEvent{Results: []result.Match{fileMatch(popular, "late.go")}})
+			return nil, nil
+		})
+		got := paths(run(t, NewRankingJob(time.Millisecond, 100, slowJob), clientsWithRanks(nil, nil)))
+		want := [][]string{
+			{"github.com/sourcegraph/small/a.go"},
+			{"github.com/sourcegraph/popular/late.go"},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("unexpected events (-want +got):\n%s", diff)
+		}
+	})
+}
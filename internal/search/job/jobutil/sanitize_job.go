@@ -67,7 +67,7 @@ func (j *sanitizeJob) sanitizeEvent(event streaming.SearchEvent) streaming.Searc
 			if sanitizedCommitMatch := j.sanitizeCommitMatch(v); sanitizedCommitMatch != nil {
 				sanitized = append(sanitized, sanitizedCommitMatch)
 			}
-		case *result.RepoMatch:
+		case *result.RepoMatch, *result.OwnerMatch:
 			sanitized = append(sanitized, v)
 		default:
 			// default to dropping this result
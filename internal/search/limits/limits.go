@@ -12,6 +12,10 @@ const (
 	DefaultMaxSearchResults          = 30
 	DefaultMaxSearchResultsStreaming = 500
 
+	// DefaultMaxSearchResultsExhaustive is the limit used by exhaustive
+	// search jobs. It is the same value count:all expands to.
+	DefaultMaxSearchResultsExhaustive = 99999999
+
 	// The default timeout to use for queries.
 	DefaultTimeout = 20 * time.Second
 )
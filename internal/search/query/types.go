@@ -393,6 +393,19 @@ func (p Parameters) FileContainsContent() (include []string) {
 	return include
 }
 
+// FileHasOwner returns the owners specified by file:has.owner(...)
+// predicates. Negated predicates are returned as exclude.
+func (p Parameters) FileHasOwner() (include, exclude []string) {
+	VisitTypedPredicate(toNodes(p), func(pred *FileHasOwnerPredicate) {
+		if pred.Negated {
+			exclude = append(exclude, pred.Owner)
+		} else {
+			include = append(include, pred.Owner)
+		}
+	})
+	return include, exclude
+}
+
 type RepoHasCommitAfterArgs struct {
 	TimeRef string
 	Negated bool
@@ -56,3 +56,23 @@ func TestRepoHasKVPs(t *testing.T) {
 
 	require.Equal(t, want, ps.RepoHasKVPs())
 }
+
+func TestFileHasOwner(t *testing.T) {
+	ps := Parameters{
+		Parameter{
+			Field:      FieldFile,
+			Value:      "has.owner(@sourcegraph/search)",
+			Annotation: Annotation{Labels: IsPredicate},
+		},
+		Parameter{
+			Field:      FieldFile,
+			Value:      "has.owner(alice@example.com)",
+			Negated:    true,
+			Annotation: Annotation{Labels: IsPredicate},
+		},
+	}
+
+	include, exclude := ps.FileHasOwner()
+	require.Equal(t, []string{"@sourcegraph/search"}, include)
+	require.Equal(t, []string{"alice@example.com"}, exclude)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/go-enry/go-enry/v2"
 	"github.com/grafana/regexp"
 
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/search/filter"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -221,6 +222,18 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		return err
 	}
 
+	isValidSemverRanges := func() error {
+		for _, rev := range strings.Split(value, ":") {
+			if !strings.HasPrefix(rev, "*semver(") || !strings.HasSuffix(rev, ")") {
+				continue
+			}
+			if _, err := gitdomain.CompileSemverRange(strings.TrimSuffix(strings.TrimPrefix(rev, "*semver("), ")")); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	satisfies := func(fns ...func() error) error {
 		for _, fn := range fns {
 			if err := fn(); err != nil {
@@ -288,7 +301,7 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		return satisfies(isSingular, isNotNegated, isDuration)
 	case
 		FieldRev:
-		return satisfies(isSingular, isNotNegated)
+		return satisfies(isSingular, isNotNegated, isValidSemverRanges)
 	case
 		FieldSelect:
 		return satisfies(isSingular, isNotNegated, isValidSelect)
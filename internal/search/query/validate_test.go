@@ -79,6 +79,10 @@ func TestValidation(t *testing.T) {
 			input: `repo:'' rev:bedge`,
 			want:  "invalid syntax. The query contains `rev:` without `repo:`. Add a `repo:` filter and try again",
 		},
+		{
+			input: "repo:foo rev:*semver(>=3.0.0 <)",
+			want:  `invalid semver range ">=3.0.0 <": improper constraint: <`,
+		},
 		{
 			input: "repo:foo author:rob@saucegraph.com",
 			want:  `your query contains the field 'author', which requires type:commit or type:diff in the query`,
@@ -8,8 +8,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
-// RevisionSpecifier represents either a revspec or a ref glob. At most one
-// field is set. The default branch is represented by all fields being empty.
+// RevisionSpecifier represents either a revspec, a ref glob or a semver range.
+// At most one field is set. The default branch is represented by all fields being empty.
 type RevisionSpecifier struct {
 	// RevSpec is a revision range specifier suitable for passing to git. See
 	// the manpage gitrevisions(7).
@@ -22,9 +22,17 @@ type RevisionSpecifier struct {
 	// ExcludeRefGlob is a glob for references to exclude. See the
 	// documentation for "--exclude" in git-log.
 	ExcludeRefGlob string
+
+	// SemverRange is a range of semantic versions, like ">=3.0.0 <4.2.0",
+	// which selects the tags whose names are versions in the range. See
+	// gitdomain.CompileSemverRange for the syntax.
+	SemverRange string
 }
 
 func (r1 RevisionSpecifier) String() string {
+	if r1.SemverRange != "" {
+		return "*semver(" + r1.SemverRange + ")"
+	}
 	if r1.ExcludeRefGlob != "" {
 		return "*!" + r1.ExcludeRefGlob
 	}
@@ -46,7 +54,10 @@ func (r1 RevisionSpecifier) Less(r2 RevisionSpecifier) bool {
 	if r1.RefGlob != r2.RefGlob {
 		return r1.RefGlob < r2.RefGlob
 	}
-	return r1.ExcludeRefGlob < r2.ExcludeRefGlob
+	if r1.ExcludeRefGlob != r2.ExcludeRefGlob {
+		return r1.ExcludeRefGlob < r2.ExcludeRefGlob
+	}
+	return r1.SemverRange < r2.SemverRange
 }
 
 // RepositoryRevisions specifies a repository and 0 or more revspecs and ref
@@ -90,6 +101,8 @@ func (r *RepositoryRevisions) Equal(other *RepositoryRevisions) bool {
 //   - 'foo@*bar' refers to the 'foo' repo and all refs matching the glob 'bar/*',
 //     because git interprets the ref glob 'bar' as being 'bar/*' (see `man git-log`
 //     section on the --glob flag)
+//   - 'foo@*semver(>=3.0.0 <4.2.0)' refers to the 'foo' repo and all tags which
+//     are semantic versions in the range '>=3.0.0 <4.2.0'
 func ParseRepositoryRevisions(repoAndOptionalRev string) (string, []RevisionSpecifier) {
 	i := strings.Index(repoAndOptionalRev, "@")
 	if i == -1 {
@@ -116,7 +129,9 @@ func ParseRepositoryRevisions(repoAndOptionalRev string) (string, []RevisionSpec
 }
 
 func parseRev(spec string) RevisionSpecifier {
-	if strings.HasPrefix(spec, "*!") {
+	if strings.HasPrefix(spec, "*semver(") && strings.HasSuffix(spec, ")") {
+		return RevisionSpecifier{SemverRange: strings.TrimSuffix(strings.TrimPrefix(spec, "*semver("), ")")}
+	} else if strings.HasPrefix(spec, "*!") {
 		return RevisionSpecifier{ExcludeRefGlob: spec[2:]}
 	} else if strings.HasPrefix(spec, "*") {
 		return RevisionSpecifier{RefGlob: spec[1:]}
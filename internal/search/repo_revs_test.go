@@ -16,6 +16,14 @@ func TestParseRepositoryRevisions(t *testing.T) {
 		"repo@rev1:rev2": {repo: "repo", revs: []RevisionSpecifier{{RevSpec: "rev1"}, {RevSpec: "rev2"}}},
 		"repo@:rev1:":    {repo: "repo", revs: []RevisionSpecifier{{RevSpec: "rev1"}}},
 		"repo@*glob":     {repo: "repo", revs: []RevisionSpecifier{{RefGlob: "glob"}}},
+		"repo@*semver(>=3.0.0 <4.2.0)": {
+			repo: "repo",
+			revs: []RevisionSpecifier{{SemverRange: ">=3.0.0 <4.2.0"}},
+		},
+		"repo@*semver(^3.1):*glob": {
+			repo: "repo",
+			revs: []RevisionSpecifier{{SemverRange: "^3.1"}, {RefGlob: "glob"}},
+		},
 		"repo@rev1:*glob1:^rev2": {
 			repo: "repo",
 			revs: []RevisionSpecifier{{RevSpec: "rev1"}, {RefGlob: "glob1"}, {RevSpec: "^rev2"}},
@@ -401,6 +401,10 @@ func (r *Resolver) normalizeRefs(ctx context.Context, repoRevSpecs []RepoRevSpec
 	return filteredResults, missing, nil
 }
 
+// maxSemverRangeRevs is the maximum number of tags a semver range in a
+// revision specifier expands to per repository. The highest versions are kept.
+const maxSemverRangeRevs = 100
+
 func (r *Resolver) normalizeRepoRefs(
 	ctx context.Context,
 	repo types.MinimalRepo,
@@ -409,12 +413,19 @@ func (r *Resolver) normalizeRepoRefs(
 ) ([]string, error) {
 	revs := make([]string, 0, len(revSpecs))
 	var globs []gitdomain.RefGlob
+	var semverRanges []*gitdomain.SemverRange
 	for _, rev := range revSpecs {
 		switch {
 		case rev.RefGlob != "":
 			globs = append(globs, gitdomain.RefGlob{Include: rev.RefGlob})
 		case rev.ExcludeRefGlob != "":
 			globs = append(globs, gitdomain.RefGlob{Exclude: rev.ExcludeRefGlob})
+		case rev.SemverRange != "":
+			sr, err := gitdomain.CompileSemverRange(rev.SemverRange)
+			if err != nil {
+				return nil, err
+			}
+			semverRanges = append(semverRanges, sr)
 		case rev.RevSpec == "" || rev.RevSpec == "HEAD":
 			// NOTE: HEAD is the only case here that we don't resolve to a
 			// commit ID. We should consider building []gitdomain.Ref here
@@ -435,8 +446,8 @@ func (r *Resolver) normalizeRepoRefs(
 		}
 	}
 
-	if len(globs) == 0 {
-		// Happy path with no globs to expand
+	if len(globs) == 0 && len(semverRanges) == 0 {
+		// Happy path with no globs or semver ranges to expand
 		return revs, nil
 	}
 
@@ -456,6 +467,10 @@ func (r *Resolver) normalizeRepoRefs(
 		}
 	}
 
+	for _, sr := range semverRanges {
+		revs = append(revs, sr.MatchTags(allRefs, maxSemverRangeRevs)...)
+	}
+
 	return revs, nil
 
 }
@@ -1009,6 +1024,7 @@ func (r *RepoRevSpecs) RevSpecs() []string {
 		switch {
 		case rev.RefGlob != "":
 		case rev.ExcludeRefGlob != "":
+		case rev.SemverRange != "":
 		default:
 			res = append(res, rev.RevSpec)
 		}
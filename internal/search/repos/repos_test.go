@@ -64,6 +64,18 @@ func TestRevisionValidation(t *testing.T) {
 			Name: "refs/heads/revBar",
 		}, {
 			Name: "refs/heads/revBas",
+		}, {
+			Name: "refs/tags/v2.9.0",
+		}, {
+			Name: "refs/tags/v3.0.0",
+		}, {
+			Name: "refs/tags/v3.1.0-rc.1",
+		}, {
+			Name: "refs/tags/v4.1.2",
+		}, {
+			Name: "refs/tags/4.2.0",
+		}, {
+			Name: "refs/tags/release-candidate",
 		}}, nil
 	})
 
@@ -89,6 +101,22 @@ func TestRevisionValidation(t *testing.T) {
 			}},
 			wantMissingRepoRevisions: []RepoRevSpecs{},
 		},
+		{
+			repoFilters: []string{"repoFoo@*semver(>=3.0.0 <4.2.0)"},
+			wantRepoRevs: []*search.RepositoryRevisions{{
+				Repo: types.MinimalRepo{Name: "repoFoo"},
+				Revs: []string{"refs/tags/v4.1.2", "refs/tags/v3.0.0"},
+			}},
+			wantMissingRepoRevisions: []RepoRevSpecs{},
+		},
+		{
+			repoFilters: []string{"repoFoo@revBar:*semver(>= 4)"},
+			wantRepoRevs: []*search.RepositoryRevisions{{
+				Repo: types.MinimalRepo{Name: "repoFoo"},
+				Revs: []string{"revBar", "refs/tags/4.2.0", "refs/tags/v4.1.2"},
+			}},
+			wantMissingRepoRevisions: []RepoRevSpecs{},
+		},
 		{
 			repoFilters: []string{"repoFoo@revBar:^revQux"},
 			wantRepoRevs: []*search.RepositoryRevisions{{
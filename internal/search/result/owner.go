@@ -0,0 +1,55 @@
+package result
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// OwnerMatch is an owner of files matched by a search, as defined by a
+// CODEOWNERS file. It is produced by `select:file.owners`.
+type OwnerMatch struct {
+	// Handle is the handle of the owner without leading @. It is empty if the
+	// owner is identified by Email.
+	Handle string
+	Email  string
+
+	// Repo is the repository whose CODEOWNERS file names the owner.
+	Repo types.MinimalRepo
+}
+
+func (o *OwnerMatch) RepoName() types.MinimalRepo {
+	return o.Repo
+}
+
+func (o *OwnerMatch) Limit(limit int) int {
+	// Always represents one result and limit > 0 so we just return limit - 1.
+	return limit - 1
+}
+
+func (o *OwnerMatch) ResultCount() int {
+	return 1
+}
+
+func (o *OwnerMatch) Select(path filter.SelectPath) Match {
+	return nil
+}
+
+// Identifier returns the handle of the owner prefixed with @, or the email.
+func (o *OwnerMatch) Identifier() string {
+	if o.Handle != "" {
+		return "@" + o.Handle
+	}
+	return o.Email
+}
+
+// Key deduplicates owners within a repository. An owner of files in several
+// repositories is a separate match for each of them.
+func (o *OwnerMatch) Key() Key {
+	return Key{
+		Repo:     o.Repo.Name,
+		TypeRank: rankOwnerMatch,
+		Owner:    o.Identifier(),
+	}
+}
+
+func (o *OwnerMatch) searchResultMarker() {}
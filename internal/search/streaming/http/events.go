@@ -166,6 +166,22 @@ type EventCommitMatch struct {
 
 func (e *EventCommitMatch) eventMatch() {}
 
+// EventOwnerMatch is an owner of files, as returned by select:file.owners.
+type EventOwnerMatch struct {
+	// Type is always OwnerMatchType. Included here for marshalling.
+	Type MatchType `json:"type"`
+
+	// Handle is the handle of the owner without leading @, or empty if the
+	// owner is identified by Email.
+	Handle string `json:"handle,omitempty"`
+	Email  string `json:"email,omitempty"`
+
+	// Repository is the repository whose CODEOWNERS file names the owner.
+	Repository string `json:"repository"`
+}
+
+func (e *EventOwnerMatch) eventMatch() {}
+
 // EventFilter is a suggestion for a search filter. Currently has a 1-1
 // correspondance with the SearchFilter graphql type.
 type EventFilter struct {
@@ -211,6 +227,7 @@ const (
 	SymbolMatchType
 	CommitMatchType
 	PathMatchType
+	OwnerMatchType
 )
 
 func (t MatchType) MarshalJSON() ([]byte, error) {
@@ -225,6 +242,8 @@ func (t MatchType) MarshalJSON() ([]byte, error) {
 		return []byte(`"commit"`), nil
 	case PathMatchType:
 		return []byte(`"path"`), nil
+	case OwnerMatchType:
+		return []byte(`"owner"`), nil
 	default:
 		return nil, errors.Errorf("unknown MatchType: %d", t)
 	}
@@ -241,6 +260,8 @@ func (t *MatchType) UnmarshalJSON(b []byte) error {
 		*t = CommitMatchType
 	} else if bytes.Equal(b, []byte(`"path"`)) {
 		*t = PathMatchType
+	} else if bytes.Equal(b, []byte(`"owner"`)) {
+		*t = OwnerMatchType
 	} else {
 		return errors.Errorf("unknown MatchType: %s", b)
 	}
@@ -41,8 +41,11 @@ func (inputs Inputs) MaxResults() int {
 
 // DefaultLimit is the default limit to use if not specified in query.
 func (inputs Inputs) DefaultLimit() int {
-	if inputs.Protocol == Batch {
+	switch inputs.Protocol {
+	case Batch:
 		return limits.DefaultMaxSearchResults
+	case Exhaustive:
+		return limits.DefaultMaxSearchResultsExhaustive
 	}
 	return limits.DefaultMaxSearchResultsStreaming
 }
@@ -59,6 +62,9 @@ type Protocol int
 const (
 	Streaming Protocol = iota
 	Batch
+	// Exhaustive is used by search jobs that run in the background and
+	// collect every result rather than the first page of them.
+	Exhaustive
 )
 
 func (p Protocol) String() string {
@@ -67,6 +73,8 @@ func (p Protocol) String() string {
 		return "Streaming"
 	case Batch:
 		return "Batch"
+	case Exhaustive:
+		return "Exhaustive"
 	default:
 		return fmt.Sprintf("unknown{%d}", p)
 	}
@@ -0,0 +1,50 @@
+package types
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+// ExhaustiveSearchJob is a search query that is run in the background over all
+// matching repositories, without the result count and time limits of
+// interactive searches. Jobs are processed by a dbworker, which writes all
+// results to the upload store for later download.
+type ExhaustiveSearchJob struct {
+	ID          int64
+	InitiatorID int32
+	Query       string
+	// Format is the format the results are written in, see the export package.
+	Format    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// Progress of the job, updated while it is running.
+	MatchCount           int
+	RepositoriesSearched int
+	LimitHit             bool
+
+	// ResultKey is the key of the object in the upload store which holds the
+	// results. It is only set once the job has completed.
+	ResultKey  *string
+	ResultSize *int64
+
+	// Standard dbworker fields
+	State           string
+	FailureMessage  *string
+	QueuedAt        time.Time
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+	ProcessAfter    *time.Time
+	NumResets       int
+	NumFailures     int
+	LastHeartbeatAt *time.Time
+	ExecutionLogs   []workerutil.ExecutionLogEntry
+	WorkerHostname  string
+	Cancel          bool
+}
+
+// RecordID implements workerutil.Record.
+func (j *ExhaustiveSearchJob) RecordID() int {
+	return int(j.ID)
+}
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+// OutboundWebhook is a subscriber that is sent an HTTP POST request whenever an
+// event of one of its event types occurs on the instance.
+type OutboundWebhook struct {
+	ID        int64
+	CreatedBy int32
+	CreatedAt time.Time
+	UpdatedBy int32
+	UpdatedAt time.Time
+
+	// URL and Secret are encrypted at rest. The secret is used to sign each
+	// payload sent to the webhook.
+	URL    *encryption.Encryptable
+	Secret *encryption.Encryptable
+
+	EventTypes []OutboundWebhookEventType
+}
+
+// OutboundWebhookEventType subscribes an outbound webhook to an event type. If
+// Scope is set, only events with the same scope (for example, a repository
+// name) are sent to the webhook.
+type OutboundWebhookEventType struct {
+	ID                int64
+	OutboundWebhookID int64
+	EventType         string
+	Scope             *string
+}
+
+// OutboundWebhookJob is a single delivery of an event payload to an outbound
+// webhook. Jobs are processed by a dbworker, so failed deliveries are retried.
+type OutboundWebhookJob struct {
+	ID                int64
+	OutboundWebhookID int64
+	EventType         string
+	Scope             *string
+	Payload           *encryption.Encryptable
+
+	// Standard dbworker fields
+	State           string
+	FailureMessage  *string
+	QueuedAt        time.Time
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+	ProcessAfter    *time.Time
+	NumResets       int
+	NumFailures     int
+	LastHeartbeatAt *time.Time
+	ExecutionLogs   []workerutil.ExecutionLogEntry
+	WorkerHostname  string
+	Cancel          bool
+}
+
+// RecordID implements workerutil.Record.
+func (j *OutboundWebhookJob) RecordID() int {
+	return int(j.ID)
+}
+
+// OutboundWebhookLog records a single attempt to deliver an OutboundWebhookJob.
+type OutboundWebhookLog struct {
+	ID                int64
+	JobID             int64
+	OutboundWebhookID int64
+	SentAt            time.Time
+
+	// StatusCode is the HTTP status code returned by the receiver, or
+	// OutboundWebhookLogUnsentStatusCode if no response was received.
+	StatusCode int
+	Request    *EncryptableWebhookLogMessage
+	Response   *EncryptableWebhookLogMessage
+	Error      *encryption.Encryptable
+}
+
+// OutboundWebhookLogUnsentStatusCode is the status code recorded when a request
+// could not be sent, or no response was received.
+const OutboundWebhookLogUnsentStatusCode = 0
@@ -0,0 +1,12 @@
+package types
+
+import "time"
+
+// Role is a named set of permissions that can be assigned to users and
+// organizations.
+type Role struct {
+	ID        int32
+	Name      string
+	ReadOnly  bool
+	CreatedAt time.Time
+}
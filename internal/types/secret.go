@@ -79,12 +79,20 @@ func (e *ExternalService) RedactedConfig(ctx context.Context) (string, error) {
 		// Nothing to redact
 	case *schema.RubyPackagesConnection:
 		es.redactString(c.Repository, "repository")
+	case *schema.NuGetPackagesConnection:
+		if c.Credentials != nil {
+			es.redactString(c.Credentials.Password, "credentials", "password")
+		}
 	case *schema.JVMPackagesConnection:
 		if c.Maven != nil {
 			es.redactString(c.Maven.Credentials, "maven", "credentials")
 		}
 	case *schema.PagureConnection:
 		es.redactString(c.Token, "token")
+	case *schema.GiteaConnection:
+		es.redactString(c.Token, "token")
+	case *schema.AzureDevOpsConnection:
+		es.redactString(c.Token, "token")
 	case *schema.NpmPackagesConnection:
 		es.redactString(c.Credentials, "credentials")
 	case *schema.OtherExternalServiceConnection:
@@ -179,6 +187,11 @@ func (e *ExternalService) UnredactConfig(ctx context.Context, old *ExternalServi
 	case *schema.RubyPackagesConnection:
 		o := oldCfg.(*schema.RubyPackagesConnection)
 		es.unredactString(c.Repository, o.Repository, "repository")
+	case *schema.NuGetPackagesConnection:
+		o := oldCfg.(*schema.NuGetPackagesConnection)
+		if c.Credentials != nil && o.Credentials != nil {
+			es.unredactString(c.Credentials.Password, o.Credentials.Password, "credentials", "password")
+		}
 	case *schema.JVMPackagesConnection:
 		o := oldCfg.(*schema.JVMPackagesConnection)
 		if c.Maven != nil && o.Maven != nil {
@@ -187,6 +200,12 @@ func (e *ExternalService) UnredactConfig(ctx context.Context, old *ExternalServi
 	case *schema.PagureConnection:
 		o := oldCfg.(*schema.PagureConnection)
 		es.unredactString(c.Token, o.Token, "token")
+	case *schema.GiteaConnection:
+		o := oldCfg.(*schema.GiteaConnection)
+		es.unredactString(c.Token, o.Token, "token")
+	case *schema.AzureDevOpsConnection:
+		o := oldCfg.(*schema.AzureDevOpsConnection)
+		es.unredactString(c.Token, o.Token, "token")
 	case *schema.NpmPackagesConnection:
 		o := oldCfg.(*schema.NpmPackagesConnection)
 		es.unredactString(c.Credentials, o.Credentials, "credentials")
@@ -85,6 +85,21 @@ func TestExternalService_RedactedConfig(t *testing.T) {
 			in:   schema.PagureConnection{Url: "https://src.fedoraproject.org", Token: "bar"},
 			out:  schema.PagureConnection{Url: "https://src.fedoraproject.org", Token: RedactedSecret},
 		},
+		{
+			kind: extsvc.KindGitea,
+			in:   schema.GiteaConnection{Url: "https://codeberg.org", Token: "bar"},
+			out:  schema.GiteaConnection{Url: "https://codeberg.org", Token: RedactedSecret},
+		},
+		{
+			kind: extsvc.KindAzureDevOps,
+			in:   schema.AzureDevOpsConnection{Url: "https://dev.azure.com", Username: "admin", Token: "bar"},
+			out:  schema.AzureDevOpsConnection{Url: "https://dev.azure.com", Username: "admin", Token: RedactedSecret},
+		},
+		{
+			kind: extsvc.KindNuGetPackages,
+			in:   schema.NuGetPackagesConnection{Repository: "https://nuget.example.com/v3/index.json", Credentials: &schema.NuGetCredentials{Username: "user", Password: "bar"}},
+			out:  schema.NuGetPackagesConnection{Repository: "https://nuget.example.com/v3/index.json", Credentials: &schema.NuGetCredentials{Username: "user", Password: RedactedSecret}},
+		},
 		{
 			kind: extsvc.KindJVMPackages,
 			in:   schema.JVMPackagesConnection{Maven: &schema.Maven{Credentials: "foobar", Dependencies: []string{"baz"}}},
@@ -265,6 +280,24 @@ func TestExternalService_UnredactConfig(t *testing.T) {
 			in:   schema.PagureConnection{Url: "https://src.fedoraproject.org", Token: RedactedSecret},
 			out:  schema.PagureConnection{Url: "https://src.fedoraproject.org", Token: "bar"},
 		},
+		{
+			kind: extsvc.KindGitea,
+			old:  schema.GiteaConnection{Url: "https://codeberg.org", Token: "bar"},
+			in:   schema.GiteaConnection{Url: "https://codeberg.org", Token: RedactedSecret},
+			out:  schema.GiteaConnection{Url: "https://codeberg.org", Token: "bar"},
+		},
+		{
+			kind: extsvc.KindAzureDevOps,
+			old:  schema.AzureDevOpsConnection{Url: "https://dev.azure.com", Username: "admin", Token: "bar"},
+			in:   schema.AzureDevOpsConnection{Url: "https://dev.azure.com", Username: "admin", Token: RedactedSecret},
+			out:  schema.AzureDevOpsConnection{Url: "https://dev.azure.com", Username: "admin", Token: "bar"},
+		},
+		{
+			kind: extsvc.KindNuGetPackages,
+			old:  schema.NuGetPackagesConnection{Credentials: &schema.NuGetCredentials{Username: "user", Password: "bar"}},
+			in:   schema.NuGetPackagesConnection{Credentials: &schema.NuGetCredentials{Username: "user", Password: RedactedSecret}},
+			out:  schema.NuGetPackagesConnection{Credentials: &schema.NuGetCredentials{Username: "user", Password: "bar"}},
+		},
 		{
 			kind: extsvc.KindJVMPackages,
 			old:  schema.JVMPackagesConnection{Maven: &schema.Maven{Credentials: "foobar", Dependencies: []string{"baz"}}},
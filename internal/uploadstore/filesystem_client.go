@@ -0,0 +1,334 @@
+package uploadstore
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing/opentracing-go/log"
+	sglog "github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type filesystemStore struct {
+	dir          string
+	tmpDir       string
+	manageBucket bool
+	operations   *Operations
+}
+
+var _ Store = &filesystemStore{}
+
+type FilesystemConfig struct {
+	// Dir is the root directory of the store. Each bucket is a subdirectory of Dir
+	// and each object is a file within its bucket directory.
+	Dir string
+}
+
+// newFilesystemFromConfig creates a new store backed by a directory on the local disk.
+func newFilesystemFromConfig(ctx context.Context, config Config, operations *Operations) (Store, error) {
+	if config.Filesystem.Dir == "" {
+		return nil, errors.New("no directory configured for filesystem upload store")
+	}
+	if config.Bucket == "" || strings.ContainsAny(config.Bucket, `/\`) || strings.HasPrefix(config.Bucket, ".") {
+		return nil, errors.Errorf("invalid bucket name %q for filesystem upload store", config.Bucket)
+	}
+
+	return newFilesystemWithDir(config.Filesystem.Dir, config.Bucket, config.ManageBucket, operations), nil
+}
+
+func newFilesystemWithDir(dir, bucket string, manageBucket bool, operations *Operations) *filesystemStore {
+	return &filesystemStore{
+		dir: filepath.Join(dir, bucket),
+		// Temporary files are written next to (but not inside) the bucket directory so
+		// that they are on the same filesystem and can be renamed into place atomically.
+		tmpDir:       filepath.Join(dir, ".tmp"),
+		manageBucket: manageBucket,
+		operations:   operations,
+	}
+}
+
+func (s *filesystemStore) Init(ctx context.Context) error {
+	if s.manageBucket {
+		if err := os.MkdirAll(s.dir, 0o755); err != nil {
+			return errors.Wrap(err, "failed to create bucket directory")
+		}
+	} else if _, err := os.Stat(s.dir); err != nil {
+		return errors.Wrap(err, "failed to stat bucket directory")
+	}
+
+	if err := os.MkdirAll(s.tmpDir, 0o755); err != nil {
+		return errors.Wrap(err, "failed to create temporary directory")
+	}
+
+	return nil
+}
+
+// Get returns the file backing the object at the given key. The returned reader is an
+// *os.File and so also supports seeking to, and reading at, arbitrary byte offsets.
+func (s *filesystemStore) Get(ctx context.Context, key string) (_ io.ReadCloser, err error) {
+	ctx, _, endObservation := s.operations.Get.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get object")
+	}
+
+	return f, nil
+}
+
+func (s *filesystemStore) Upload(ctx context.Context, key string, r io.Reader) (_ int64, err error) {
+	ctx, _, endObservation := s.operations.Upload.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := s.writeFile(ctx, path, func(w io.Writer) (int64, error) {
+		return io.Copy(w, r)
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to upload object")
+	}
+
+	return n, nil
+}
+
+func (s *filesystemStore) Compose(ctx context.Context, destination string, sources ...string) (_ int64, err error) {
+	ctx, _, endObservation := s.operations.Compose.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("destination", destination),
+		log.String("sources", strings.Join(sources, ", ")),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	path, err := s.path(destination)
+	if err != nil {
+		return 0, err
+	}
+
+	sourcePaths := make([]string, 0, len(sources))
+	for _, source := range sources {
+		sourcePath, err := s.path(source)
+		if err != nil {
+			return 0, err
+		}
+		sourcePaths = append(sourcePaths, sourcePath)
+	}
+
+	n, err := s.writeFile(ctx, path, func(w io.Writer) (int64, error) {
+		var total int64
+		for _, sourcePath := range sourcePaths {
+			n, err := copyFile(w, sourcePath)
+			total += n
+			if err != nil {
+				return total, err
+			}
+		}
+
+		return total, nil
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to compose object")
+	}
+
+	// Delete sources on success
+	for _, sourcePath := range sourcePaths {
+		if err := s.remove(sourcePath); err != nil {
+			log15.Error("Failed to delete source object", "error", err)
+		}
+	}
+
+	return n, nil
+}
+
+func (s *filesystemStore) Delete(ctx context.Context, key string) (err error) {
+	ctx, _, endObservation := s.operations.Delete.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrap(s.remove(path), "failed to delete object")
+}
+
+func (s *filesystemStore) ExpireObjects(ctx context.Context, prefix string, maxAge time.Duration) (err error) {
+	ctx, _, endObservation := s.operations.ExpireObjects.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("prefix", prefix),
+		log.String("maxAge", maxAge.String()),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return filepath.WalkDir(s.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				// Removed concurrently
+				return nil
+			}
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(s.dir, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+
+		if entry.IsDir() {
+			if key == "." {
+				return nil
+			}
+			// Skip directories which cannot contain keys with the given prefix
+			if dirKey := key + "/"; !strings.HasPrefix(dirKey, prefix) && !strings.HasPrefix(prefix, dirKey) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if time.Since(info.ModTime()) < maxAge {
+			return nil
+		}
+
+		if err := s.remove(path); err != nil {
+			s.operations.ExpireObjects.Logger.Error("Failed to delete expired object",
+				sglog.Error(err),
+				sglog.String("key", key))
+		}
+
+		return nil
+	})
+}
+
+// path returns the path of the file backing the object with the given key. Keys are
+// slash-separated paths relative to the bucket directory which may not escape it.
+func (s *filesystemStore) path(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return "", errors.Errorf("invalid key %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return "", errors.Errorf("invalid key %q", key)
+		}
+	}
+
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+// writeFile invokes the given function with a temporary file and atomically moves
+// that file to the given path once the function returns successfully.
+func (s *filesystemStore) writeFile(ctx context.Context, path string, fn func(w io.Writer) (int64, error)) (_ int64, err error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return 0, err
+	}
+
+	f, err := os.CreateTemp(s.tmpDir, "upload-*")
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	n, err := fn(&contextWriter{ctx: ctx, w: f})
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		if !os.IsNotExist(err) {
+			return 0, err
+		}
+
+		// The parent directory may have been removed by a concurrent delete
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return 0, err
+		}
+		if err := os.Rename(f.Name(), path); err != nil {
+			return 0, err
+		}
+	}
+
+	return n, nil
+}
+
+// remove deletes the file at the given path along with any parent directories within
+// the bucket that become empty. Removing a file that does not exist is not an error.
+func (s *filesystemStore) remove(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	for dir := filepath.Dir(path); dir != s.dir && strings.HasPrefix(dir, s.dir); dir = filepath.Dir(dir) {
+		// Fails if the directory is not empty
+		if err := os.Remove(dir); err != nil {
+			break
+		}
+	}
+
+	return nil
+}
+
+func copyFile(w io.Writer, path string) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return io.Copy(w, f)
+}
+
+// contextWriter is an io.Writer that stops accepting writes once the given context
+// is canceled.
+type contextWriter struct {
+	ctx context.Context
+	w   io.Writer
+}
+
+func (w *contextWriter) Write(p []byte) (int, error) {
+	if err := w.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return w.w.Write(p)
+}
@@ -0,0 +1,216 @@
+package uploadstore
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestFilesystemInit(t *testing.T) {
+	dir := t.TempDir()
+
+	client := testFilesystemClient(dir, true)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	if info, err := os.Stat(filepath.Join(dir, "test-bucket")); err != nil {
+		t.Fatalf("unexpected error statting bucket directory: %s", err)
+	} else if !info.IsDir() {
+		t.Errorf("expected bucket to be a directory")
+	}
+}
+
+func TestFilesystemUnmanagedInit(t *testing.T) {
+	client := testFilesystemClient(t.TempDir(), false)
+	if err := client.Init(context.Background()); err == nil {
+		t.Fatalf("expected error initializing client with a missing bucket directory")
+	}
+}
+
+func TestFilesystemUploadAndGet(t *testing.T) {
+	client := testFilesystemClient(t.TempDir(), true)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	size, err := client.Upload(context.Background(), "nested/test-key", strings.NewReader("TEST PAYLOAD"))
+	if err != nil {
+		t.Fatalf("unexpected error uploading: %s", err)
+	} else if size != 12 {
+		t.Errorf("unexpected size. want=%d have=%d", 12, size)
+	}
+
+	rc, err := client.Get(context.Background(), "nested/test-key")
+	if err != nil {
+		t.Fatalf("unexpected error getting key: %s", err)
+	}
+	defer rc.Close()
+
+	// The returned reader supports byte offsets
+	if _, err := rc.(io.Seeker).Seek(5, io.SeekStart); err != nil {
+		t.Fatalf("unexpected error seeking: %s", err)
+	}
+	contents, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("unexpected error reading object: %s", err)
+	}
+	if string(contents) != "PAYLOAD" {
+		t.Fatalf("unexpected contents. want=%s have=%s", "PAYLOAD", contents)
+	}
+
+	if _, err := client.Get(context.Background(), "missing-key"); err == nil {
+		t.Fatalf("expected error getting missing key")
+	}
+}
+
+func TestFilesystemInvalidKey(t *testing.T) {
+	client := testFilesystemClient(t.TempDir(), true)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	for _, key := range []string{"", "/abs", "../escape", "a/../../escape", "a//b"} {
+		if _, err := client.Upload(context.Background(), key, strings.NewReader("")); err == nil {
+			t.Errorf("expected error uploading to key %q", key)
+		}
+	}
+}
+
+func TestFilesystemCompose(t *testing.T) {
+	client := testFilesystemClient(t.TempDir(), true)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	for key, payload := range map[string]string{"test-src1": "foo", "test-src2": "bar", "test-src3": "baz"} {
+		if _, err := client.Upload(context.Background(), key, strings.NewReader(payload)); err != nil {
+			t.Fatalf("unexpected error uploading: %s", err)
+		}
+	}
+
+	size, err := client.Compose(context.Background(), "test-key", "test-src1", "test-src2", "test-src3")
+	if err != nil {
+		t.Fatalf("unexpected error composing: %s", err)
+	} else if size != 9 {
+		t.Errorf("unexpected size. want=%d have=%d", 9, size)
+	}
+
+	if contents := readFilesystemObject(t, client, "test-key"); contents != "foobarbaz" {
+		t.Errorf("unexpected contents. want=%s have=%s", "foobarbaz", contents)
+	}
+
+	for _, key := range []string{"test-src1", "test-src2", "test-src3"} {
+		if _, err := client.Get(context.Background(), key); err == nil {
+			t.Errorf("expected source %q to be deleted", key)
+		}
+	}
+}
+
+func TestFilesystemComposeMissingSource(t *testing.T) {
+	client := testFilesystemClient(t.TempDir(), true)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	if _, err := client.Upload(context.Background(), "test-src1", strings.NewReader("foo")); err != nil {
+		t.Fatalf("unexpected error uploading: %s", err)
+	}
+
+	if _, err := client.Compose(context.Background(), "test-key", "test-src1", "test-src2"); err == nil {
+		t.Fatalf("expected error composing")
+	}
+
+	// Sources are not deleted on failure
+	if contents := readFilesystemObject(t, client, "test-src1"); contents != "foo" {
+		t.Errorf("unexpected contents. want=%s have=%s", "foo", contents)
+	}
+	if _, err := client.Get(context.Background(), "test-key"); err == nil {
+		t.Errorf("expected destination to not exist")
+	}
+}
+
+func TestFilesystemDelete(t *testing.T) {
+	dir := t.TempDir()
+	client := testFilesystemClient(dir, true)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	if _, err := client.Upload(context.Background(), "nested/test-key", strings.NewReader("foo")); err != nil {
+		t.Fatalf("unexpected error uploading: %s", err)
+	}
+
+	if err := client.Delete(context.Background(), "nested/test-key"); err != nil {
+		t.Fatalf("unexpected error deleting: %s", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "test-bucket", "nested")); !os.IsNotExist(err) {
+		t.Errorf("expected empty parent directory to be removed")
+	}
+
+	// Deleting a missing key is not an error
+	if err := client.Delete(context.Background(), "nested/test-key"); err != nil {
+		t.Fatalf("unexpected error deleting missing key: %s", err)
+	}
+}
+
+func TestFilesystemExpireObjects(t *testing.T) {
+	dir := t.TempDir()
+	client := testFilesystemClient(dir, true)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	old := time.Now().Add(-2 * time.Hour)
+	for _, key := range []string{"uploads/old", "uploads/new", "other/old"} {
+		if _, err := client.Upload(context.Background(), key, strings.NewReader(key)); err != nil {
+			t.Fatalf("unexpected error uploading: %s", err)
+		}
+		if strings.HasSuffix(key, "old") {
+			if err := os.Chtimes(filepath.Join(dir, "test-bucket", key), old, old); err != nil {
+				t.Fatalf("unexpected error changing times: %s", err)
+			}
+		}
+	}
+
+	expirer := &expirer{store: client, prefix: "uploads/", maxAge: time.Hour}
+	if err := expirer.Handle(context.Background()); err != nil {
+		t.Fatalf("unexpected error expiring objects: %s", err)
+	}
+
+	if _, err := client.Get(context.Background(), "uploads/old"); err == nil {
+		t.Errorf("expected expired object to be deleted")
+	}
+	for _, key := range []string{"uploads/new", "other/old"} {
+		if contents := readFilesystemObject(t, client, key); contents != key {
+			t.Errorf("unexpected contents. want=%s have=%s", key, contents)
+		}
+	}
+}
+
+func testFilesystemClient(dir string, manageBucket bool) Store {
+	return newFilesystemWithDir(dir, "test-bucket", manageBucket, NewOperations(&observation.TestContext, "test", "brittleStore"))
+}
+
+func readFilesystemObject(t *testing.T, client Store, key string) string {
+	t.Helper()
+
+	rc, err := client.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("unexpected error getting key %q: %s", key, err)
+	}
+	defer rc.Close()
+
+	contents, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("unexpected error reading object: %s", err)
+	}
+
+	return string(contents)
+}
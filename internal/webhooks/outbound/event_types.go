@@ -0,0 +1,39 @@
+package outbound
+
+// Event types that outbound webhooks can subscribe to. Event types are
+// namespaced by the kind of resource they relate to.
+const (
+	EventTypeRepoCloned  = "repo:cloned"
+	EventTypeRepoDeleted = "repo:deleted"
+
+	EventTypeBatchChangeApplied    = "batch_change:applied"
+	EventTypeChangesetStateChanged = "changeset:state_changed"
+	EventTypePreciseIndexCompleted = "precise_index:completed"
+	EventTypePreciseIndexFailed    = "precise_index:failed"
+)
+
+// EventType describes an event type that outbound webhooks can subscribe to.
+type EventType struct {
+	Key         string
+	Description string
+}
+
+// EventTypes is the list of all event types that can be emitted.
+var EventTypes = []EventType{
+	{Key: EventTypeRepoCloned, Description: "sent when a repository has been cloned"},
+	{Key: EventTypeRepoDeleted, Description: "sent when a repository is deleted"},
+	{Key: EventTypeBatchChangeApplied, Description: "sent when a batch spec is applied to a batch change"},
+	{Key: EventTypeChangesetStateChanged, Description: "sent when the state of a changeset on its code host changes"},
+	{Key: EventTypePreciseIndexCompleted, Description: "sent when a precise code intelligence upload has been processed"},
+	{Key: EventTypePreciseIndexFailed, Description: "sent when a precise code intelligence upload fails to process"},
+}
+
+// IsValidEventType returns true if the given key is a known event type.
+func IsValidEventType(key string) bool {
+	for _, eventType := range EventTypes {
+		if eventType.Key == key {
+			return true
+		}
+	}
+	return false
+}
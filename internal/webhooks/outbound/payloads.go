@@ -0,0 +1,60 @@
+package outbound
+
+import "time"
+
+// Payload is the body of every request sent to an outbound webhook. The shape of
+// Data depends on the event type.
+type Payload struct {
+	EventType string    `json:"eventType"`
+	Timestamp time.Time `json:"timestamp"`
+	Data      any       `json:"data"`
+}
+
+// Repository identifies a repository in event payloads.
+type Repository struct {
+	ID   int32  `json:"id"`
+	Name string `json:"name"`
+}
+
+// RepoClonedData is the payload data of EventTypeRepoCloned.
+type RepoClonedData struct {
+	Repository Repository `json:"repository"`
+}
+
+// RepoDeletedData is the payload data of EventTypeRepoDeleted.
+type RepoDeletedData struct {
+	Repository Repository `json:"repository"`
+}
+
+// BatchChangeAppliedData is the payload data of EventTypeBatchChangeApplied.
+type BatchChangeAppliedData struct {
+	ID              int64  `json:"id"`
+	Name            string `json:"name"`
+	NamespaceUserID int32  `json:"namespaceUserID,omitempty"`
+	NamespaceOrgID  int32  `json:"namespaceOrgID,omitempty"`
+	BatchSpecID     int64  `json:"batchSpecID"`
+	LastApplierID   int32  `json:"lastApplierID"`
+}
+
+// ChangesetStateChangedData is the payload data of
+// EventTypeChangesetStateChanged.
+type ChangesetStateChangedData struct {
+	ID             int64      `json:"id"`
+	Repository     Repository `json:"repository"`
+	BatchChangeIDs []int64    `json:"batchChangeIDs"`
+	ExternalID     string     `json:"externalID,omitempty"`
+	ExternalURL    string     `json:"externalURL,omitempty"`
+	PreviousState  string     `json:"previousState"`
+	State          string     `json:"state"`
+}
+
+// PreciseIndexData is the payload data of EventTypePreciseIndexCompleted and
+// EventTypePreciseIndexFailed.
+type PreciseIndexData struct {
+	UploadID       int        `json:"uploadID"`
+	Repository     Repository `json:"repository"`
+	Commit         string     `json:"commit"`
+	Root           string     `json:"root"`
+	Indexer        string     `json:"indexer"`
+	FailureMessage string     `json:"failureMessage,omitempty"`
+}
@@ -0,0 +1,56 @@
+package outbound
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// OutboundWebhookService enqueues events for delivery to outbound webhooks.
+type OutboundWebhookService interface {
+	// Enqueue creates delivery jobs for the given event for every outbound
+	// webhook subscribed to the event type. If scope is non-nil, webhooks
+	// that subscribed to the event type with a different scope are skipped.
+	//
+	// data is serialised to JSON as the data field of a Payload.
+	Enqueue(ctx context.Context, eventType string, scope *string, data any) error
+}
+
+type outboundWebhookService struct {
+	store database.OutboundWebhookJobStore
+	now   func() time.Time
+}
+
+// NewOutboundWebhookService instantiates a new outbound webhook service. Payloads
+// are encrypted at rest with the given key.
+func NewOutboundWebhookService(db database.DB, key encryption.Key) OutboundWebhookService {
+	return &outboundWebhookService{
+		store: db.OutboundWebhookJobs(key),
+		now:   time.Now,
+	}
+}
+
+func (s *outboundWebhookService) Enqueue(ctx context.Context, eventType string, scope *string, data any) error {
+	if !IsValidEventType(eventType) {
+		return errors.Newf("unknown outbound webhook event type %q", eventType)
+	}
+
+	payload, err := json.Marshal(Payload{
+		EventType: eventType,
+		Timestamp: s.now().UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshalling payload")
+	}
+
+	if _, err := s.store.Enqueue(ctx, eventType, scope, payload); err != nil {
+		return errors.Wrap(err, "enqueuing outbound webhook jobs")
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package outbound
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+func TestOutboundWebhookService(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 12, 13, 12, 0, 0, 0, time.UTC)
+
+	newService := func() (*outboundWebhookService, *database.MockOutboundWebhookJobStore) {
+		store := database.NewMockOutboundWebhookJobStore()
+		return &outboundWebhookService{store: store, now: func() time.Time { return now }}, store
+	}
+
+	t.Run("unknown event type", func(t *testing.T) {
+		svc, store := newService()
+		if err := svc.Enqueue(ctx, "unknown", nil, nil); err == nil {
+			t.Fatal("expected error")
+		}
+		if calls := store.EnqueueFunc.History(); len(calls) != 0 {
+			t.Errorf("unexpected calls to Enqueue: %d", len(calls))
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		svc, store := newService()
+		scope := "github.com/sourcegraph/sourcegraph"
+		data := RepoClonedData{Repository: Repository{ID: 1, Name: scope}}
+
+		if err := svc.Enqueue(ctx, EventTypeRepoCloned, &scope, data); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		calls := store.EnqueueFunc.History()
+		if len(calls) != 1 {
+			t.Fatalf("unexpected number of calls to Enqueue. want=%d have=%d", 1, len(calls))
+		}
+		call := calls[0]
+		if call.Arg1 != EventTypeRepoCloned {
+			t.Errorf("unexpected event type. want=%q have=%q", EventTypeRepoCloned, call.Arg1)
+		}
+		if call.Arg2 == nil || *call.Arg2 != scope {
+			t.Errorf("unexpected scope. want=%q have=%v", scope, call.Arg2)
+		}
+
+		var have map[string]any
+		if err := json.Unmarshal(call.Arg3, &have); err != nil {
+			t.Fatalf("unexpected error unmarshalling payload: %s", err)
+		}
+		want := map[string]any{
+			"eventType": EventTypeRepoCloned,
+			"timestamp": "2022-12-13T12:00:00Z",
+			"data": map[string]any{
+				"repository": map[string]any{
+					"id":   float64(1),
+					"name": scope,
+				},
+			},
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Errorf("unexpected payload (-want +have):\n%s", diff)
+		}
+	})
+}
+
+func TestSign(t *testing.T) {
+	// Computed with: printf 'payload' | openssl dgst -sha256 -hmac secret
+	want := "sha256=b82fcb791acec57859b989b430a826488ce2e479fdf92326bd0a2e8375a42ba4"
+	if have := Sign("secret", []byte("payload")); have != want {
+		t.Errorf("unexpected signature. want=%q have=%q", want, have)
+	}
+}
@@ -0,0 +1,30 @@
+package outbound
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Headers set on every request sent to an outbound webhook.
+const (
+	// EventTypeHeader contains the event type of the payload.
+	EventTypeHeader = "X-Sourcegraph-Webhook-Event-Type"
+	// DeliveryHeader contains an identifier that is unique to the delivery, but
+	// identical across retries of the same delivery.
+	DeliveryHeader = "X-Sourcegraph-Webhook-Delivery"
+	// SignatureHeader contains the signature of the payload, as computed by
+	// Sign.
+	SignatureHeader = "X-Sourcegraph-Webhook-Signature"
+)
+
+// Sign returns the signature of the given payload, which is sent in the
+// SignatureHeader of each request. The signature is the hex-encoded HMAC-SHA256
+// of the request body keyed by the webhook secret, prefixed with "sha256=".
+// Receivers should compute the same value and compare it using a constant time
+// comparison.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -37,14 +37,32 @@ type BatchSpec struct {
 	TransformChanges  *TransformChanges        `json:"transformChanges,omitempty" yaml:"transformChanges,omitempty"`
 	ImportChangesets  []ImportChangeset        `json:"importChangesets,omitempty" yaml:"importChangesets"`
 	ChangesetTemplate *ChangesetTemplate       `json:"changesetTemplate,omitempty" yaml:"changesetTemplate"`
+	MergeWaves        []MergeWave              `json:"mergeWaves,omitempty" yaml:"mergeWaves"`
+	OnConflict        OnConflict               `json:"onConflict,omitempty" yaml:"onConflict"`
 }
 
+// OnConflict is the policy that applies to published changesets which can no
+// longer be merged into their base branch.
+type OnConflict string
+
+const (
+	// OnConflictIgnore leaves conflicting changesets alone. It is the default.
+	OnConflictIgnore OnConflict = "ignore"
+	// OnConflictReexecute executes the steps again against the new head of
+	// the base branch, and pushes the result if the diff changed.
+	OnConflictReexecute OnConflict = "reexecute"
+)
+
 type ChangesetTemplate struct {
-	Title     string                       `json:"title,omitempty" yaml:"title"`
-	Body      string                       `json:"body,omitempty" yaml:"body"`
-	Branch    string                       `json:"branch,omitempty" yaml:"branch"`
-	Commit    ExpandedGitCommitDescription `json:"commit,omitempty" yaml:"commit"`
-	Published *overridable.BoolOrString    `json:"published" yaml:"published"`
+	Title         string                       `json:"title,omitempty" yaml:"title"`
+	Body          string                       `json:"body,omitempty" yaml:"body"`
+	Branch        string                       `json:"branch,omitempty" yaml:"branch"`
+	Commit        ExpandedGitCommitDescription `json:"commit,omitempty" yaml:"commit"`
+	Published     *overridable.BoolOrString    `json:"published" yaml:"published"`
+	Reviewers     []string                     `json:"reviewers,omitempty" yaml:"reviewers"`
+	TeamReviewers []string                     `json:"teamReviewers,omitempty" yaml:"teamReviewers"`
+	Labels        []string                     `json:"labels,omitempty" yaml:"labels"`
+	Assignees     []string                     `json:"assignees,omitempty" yaml:"assignees"`
 }
 
 type GitCommitAuthor struct {
@@ -165,6 +183,10 @@ func parseBatchSpec(schema string, data []byte) (*BatchSpec, error) {
 		errs = errors.Append(errs, NewValidationError(errors.New("batch spec includes steps but no changesetTemplate")))
 	}
 
+	if _, err := CompileMergeWaves(spec.MergeWaves); err != nil {
+		errs = errors.Append(errs, NewValidationError(err))
+	}
+
 	for i, step := range spec.Steps {
 		for _, mount := range step.Mount {
 			if strings.Contains(mount.Path, invalidMountCharacters) {
@@ -210,6 +210,39 @@ changesetTemplate:
 		_, err := ParseBatchSpec([]byte(spec))
 		assert.Equal(t, "step 1 mount mountpoint contains invalid characters", err.Error())
 	})
+
+	t.Run("invalid merge wave pattern", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+mergeWaves:
+  - repositories:
+      - github.com/sourcegraph/[lib
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.ErrorContains(t, err, `merge wave 1: invalid repository pattern "github.com/sourcegraph/[lib"`)
+	})
+
+	t.Run("onConflict", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+onConflict: reexecute
+`
+		have, err := ParseBatchSpec([]byte(spec))
+		assert.Nil(t, err)
+		assert.Equal(t, OnConflictReexecute, have.OnConflict)
+	})
+
+	t.Run("invalid onConflict", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+onConflict: rebase
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.ErrorContains(t, err, "onConflict")
+	})
 }
 
 func TestOnQueryOrRepository_Branches(t *testing.T) {
@@ -80,6 +80,13 @@ type ChangesetSpec struct {
 	Commits []GitCommitDescription `json:"commits,omitempty"`
 
 	Published PublishedValue `json:"published,omitempty"`
+
+	// Reviewers, TeamReviewers, Labels and Assignees are applied to the
+	// changeset on code hosts that support them, and ignored elsewhere.
+	Reviewers     []string `json:"reviewers,omitempty"`
+	TeamReviewers []string `json:"teamReviewers,omitempty"`
+	Labels        []string `json:"labels,omitempty"`
+	Assignees     []string `json:"assignees,omitempty"`
 }
 
 // MarshalJSON overwrites the default behavior of the json lib while unmarshalling
@@ -102,6 +109,10 @@ func (c *ChangesetSpec) MarshalJSON() ([]byte, error) {
 		Body           string                 `json:"body,omitempty"`
 		Commits        []GitCommitDescription `json:"commits,omitempty"`
 		Published      *PublishedValue        `json:"published,omitempty"`
+		Reviewers      []string               `json:"reviewers,omitempty"`
+		TeamReviewers  []string               `json:"teamReviewers,omitempty"`
+		Labels         []string               `json:"labels,omitempty"`
+		Assignees      []string               `json:"assignees,omitempty"`
 	}{
 		BaseRepository: c.BaseRepository,
 		ExternalID:     c.ExternalID,
@@ -112,6 +123,10 @@ func (c *ChangesetSpec) MarshalJSON() ([]byte, error) {
 		Title:          c.Title,
 		Body:           c.Body,
 		Commits:        c.Commits,
+		Reviewers:      c.Reviewers,
+		TeamReviewers:  c.TeamReviewers,
+		Labels:         c.Labels,
+		Assignees:      c.Assignees,
 	}
 	if !c.Published.Nil() {
 		v.Published = &c.Published
@@ -86,6 +86,26 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, binaryDiffs bool) ([]*Change
 		return nil, err
 	}
 
+	reviewers, err := renderChangesetTemplateList("reviewers", input.Template.Reviewers, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	teamReviewers, err := renderChangesetTemplateList("teamReviewers", input.Template.TeamReviewers, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := renderChangesetTemplateList("labels", input.Template.Labels, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	assignees, err := renderChangesetTemplateList("assignees", input.Template.Assignees, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+
 	// TODO: As a next step, we should extend the ChangesetTemplateContext to also include
 	// TransformChanges.Group and then change validateGroups and groupFileDiffs to, for each group,
 	// render the branch name *before* grouping the diffs.
@@ -123,7 +143,11 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, binaryDiffs bool) ([]*Change
 					Diff:        diff,
 				},
 			},
-			Published: PublishedValue{Val: published},
+			Published:     PublishedValue{Val: published},
+			Reviewers:     reviewers,
+			TeamReviewers: teamReviewers,
+			Labels:        labels,
+			Assignees:     assignees,
 		}
 	}
 
@@ -154,6 +178,24 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, binaryDiffs bool) ([]*Change
 	return specs, nil
 }
 
+// renderChangesetTemplateList renders each entry of a list field of the
+// changeset template. Entries that render to an empty string are dropped, so
+// that templates can conditionally add entries per workspace.
+func renderChangesetTemplateList(name string, tmpls []string, tmplCtx *template.ChangesetTemplateContext) ([]string, error) {
+	var rendered []string
+	for _, tmpl := range tmpls {
+		v, err := template.RenderChangesetTemplateField(name, tmpl, tmplCtx)
+		if err != nil {
+			return nil, err
+		}
+		if v == "" {
+			continue
+		}
+		rendered = append(rendered, v)
+	}
+	return rendered, nil
+}
+
 type RepoFetcher func(context.Context, []string) (map[string]string, error)
 
 func BuildImportChangesetSpecs(ctx context.Context, importChangesets []ImportChangeset, repoFetcher RepoFetcher) (specs []*ChangesetSpec, errs error) {
@@ -133,6 +133,25 @@ func TestCreateChangesetSpecs(t *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			name: "reviewers, labels and assignees",
+			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
+				input.Template.Reviewers = []string{"alice", `${{ if eq repository.name "github.com/sourcegraph/src-cli" }}bob${{ end }}`}
+				input.Template.TeamReviewers = []string{"batchers"}
+				input.Template.Labels = []string{"automated", "${{ repository.branch }}", `${{ if eq repository.name "github.com/sourcegraph/other" }}other${{ end }}`}
+				input.Template.Assignees = []string{"carol"}
+				input.Template.Published = parsePublishedFieldString(t, "false")
+			}),
+			want: []*ChangesetSpec{
+				specWith(defaultChangesetSpec, func(s *ChangesetSpec) {
+					s.Reviewers = []string{"alice", "bob"}
+					s.TeamReviewers = []string{"batchers"}
+					s.Labels = []string{"automated", "my-cool-base-ref"}
+					s.Assignees = []string{"carol"}
+				}),
+			},
+			wantErr: "",
+		},
 		{
 			name: "publish in UI",
 			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
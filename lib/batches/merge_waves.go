@@ -0,0 +1,64 @@
+package batches
+
+import (
+	"github.com/gobwas/glob"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// MergeWave is a group of repositories whose changesets are merged together,
+// after the changesets of all previous waves have been merged.
+type MergeWave struct {
+	Repositories []string `json:"repositories,omitempty" yaml:"repositories"`
+	Squash       bool     `json:"squash,omitempty" yaml:"squash"`
+}
+
+// MergeWaves are the compiled merge waves of a batch spec. Use
+// CompileMergeWaves to create them.
+type MergeWaves struct {
+	waves    []MergeWave
+	patterns [][]glob.Glob
+}
+
+// CompileMergeWaves compiles the repository patterns of the given merge waves.
+func CompileMergeWaves(waves []MergeWave) (*MergeWaves, error) {
+	mw := &MergeWaves{waves: waves, patterns: make([][]glob.Glob, len(waves))}
+	var errs error
+	for i, wave := range waves {
+		for _, pattern := range wave.Repositories {
+			compiled, err := glob.Compile(pattern)
+			if err != nil {
+				errs = errors.Append(errs, errors.Wrapf(err, "merge wave %d: invalid repository pattern %q", i+1, pattern))
+				continue
+			}
+			mw.patterns[i] = append(mw.patterns[i], compiled)
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return mw, nil
+}
+
+// Len returns the number of merge waves.
+func (mw *MergeWaves) Len() int {
+	return len(mw.waves)
+}
+
+// Wave returns the merge wave with the given index.
+func (mw *MergeWaves) Wave(i int) MergeWave {
+	return mw.waves[i]
+}
+
+// Index returns the index of the first merge wave with a pattern matching the
+// given repository name, or -1 if the repository belongs to no wave.
+func (mw *MergeWaves) Index(repoName string) int {
+	for i, patterns := range mw.patterns {
+		for _, p := range patterns {
+			if p.Match(repoName) {
+				return i
+			}
+		}
+	}
+	return -1
+}
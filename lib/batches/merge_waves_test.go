@@ -0,0 +1,31 @@
+package batches
+
+import "testing"
+
+func TestMergeWaves_Index(t *testing.T) {
+	mw, err := CompileMergeWaves([]MergeWave{
+		{Repositories: []string{"github.com/sourcegraph/lib"}},
+		{Repositories: []string{"github.com/sourcegraph/*", "gitlab.com/sourcegraph/*"}, Squash: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if have, want := mw.Len(), 2; have != want {
+		t.Fatalf("wrong number of waves. want=%d, have=%d", want, have)
+	}
+	if !mw.Wave(1).Squash {
+		t.Fatal("second wave should squash")
+	}
+
+	for repo, want := range map[string]int{
+		"github.com/sourcegraph/lib":      0,
+		"github.com/sourcegraph/consumer": 1,
+		"gitlab.com/sourcegraph/consumer": 1,
+		"github.com/other/consumer":       -1,
+	} {
+		if have := mw.Index(repo); have != want {
+			t.Errorf("wrong wave for %s. want=%d, have=%d", repo, want, have)
+		}
+	}
+}
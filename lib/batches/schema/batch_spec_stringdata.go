@@ -395,8 +395,66 @@ const BatchSpecJSON = `{
               }
             }
           ]
+        },
+        "reviewers": {
+          "type": "array",
+          "description": "The usernames of the users to request a review from on the changeset. Ignored on code hosts that don't support requesting reviewers.",
+          "items": {
+            "type": "string"
+          }
+        },
+        "teamReviewers": {
+          "type": "array",
+          "description": "The slugs of the teams to request a review from on the changeset. Only supported on GitHub, and ignored on other code hosts.",
+          "items": {
+            "type": "string"
+          }
+        },
+        "labels": {
+          "type": "array",
+          "description": "The labels to add to the changeset. Ignored on code hosts that don't support labels.",
+          "items": {
+            "type": "string"
+          }
+        },
+        "assignees": {
+          "type": "array",
+          "description": "The usernames of the users to assign to the changeset. Ignored on code hosts that don't support assignees.",
+          "items": {
+            "type": "string"
+          }
         }
       }
+    },
+    "mergeWaves": {
+      "type": "array",
+      "description": "An ordered list of waves in which the changesets are merged automatically. A wave is merged once all of its changesets are open, approved and have passing checks, and only after all changesets in the previous waves are merged. Changesets in repositories that match no wave are not merged automatically.",
+      "items": {
+        "title": "MergeWave",
+        "type": "object",
+        "additionalProperties": false,
+        "required": ["repositories"],
+        "properties": {
+          "repositories": {
+            "type": "array",
+            "description": "Glob patterns matching the names of the repositories whose changesets belong to this wave. A repository that matches several waves belongs to the first of them.",
+            "items": {
+              "type": "string"
+            },
+            "minItems": 1
+          },
+          "squash": {
+            "type": "boolean",
+            "description": "Whether to squash the commits of the changesets when merging them."
+          }
+        }
+      }
+    },
+    "onConflict": {
+      "type": "string",
+      "description": "What to do when a published changeset can no longer be merged, because it conflicts with its base branch or the code host requires it to be updated first. With ` + "`" + `reexecute` + "`" + `, the steps of the workspace that produced the changeset are executed again against the new head of the base branch, and the changeset is updated if the diff changed. Only applies to batch changes executed server-side.",
+      "enum": ["ignore", "reexecute"],
+      "default": "ignore"
     }
   }
 }
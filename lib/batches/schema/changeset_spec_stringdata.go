@@ -107,6 +107,26 @@ const ChangesetSpecJSON = `{
         "published": {
           "oneOf": [{ "type": "boolean" }, { "type": "string", "pattern": "^draft$" }, { "type": "null" }],
           "description": "Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host."
+        },
+        "reviewers": {
+          "type": "array",
+          "description": "The usernames of the users to request a review from on the changeset.",
+          "items": { "type": "string" }
+        },
+        "teamReviewers": {
+          "type": "array",
+          "description": "The slugs of the teams to request a review from on the changeset.",
+          "items": { "type": "string" }
+        },
+        "labels": {
+          "type": "array",
+          "description": "The labels to add to the changeset.",
+          "items": { "type": "string" }
+        },
+        "assignees": {
+          "type": "array",
+          "description": "The usernames of the users to assign to the changeset.",
+          "items": { "type": "string" }
         }
       },
       "required": ["baseRepository", "baseRef", "baseRev", "headRepository", "headRef", "title", "body", "commits"],
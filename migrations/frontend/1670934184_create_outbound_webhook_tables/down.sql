@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS outbound_webhook_logs;
+DROP TABLE IF EXISTS outbound_webhook_jobs;
+DROP TABLE IF EXISTS outbound_webhook_event_types;
+DROP TABLE IF EXISTS outbound_webhooks;
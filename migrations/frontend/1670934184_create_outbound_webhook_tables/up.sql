@@ -0,0 +1,62 @@
+CREATE TABLE IF NOT EXISTS outbound_webhooks (
+    id bigserial PRIMARY KEY,
+    created_by integer REFERENCES users (id) ON DELETE SET NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT NOW(),
+    updated_by integer REFERENCES users (id) ON DELETE SET NULL,
+    updated_at timestamp with time zone NOT NULL DEFAULT NOW(),
+    encryption_key_id text,
+    url bytea NOT NULL,
+    secret bytea NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS outbound_webhook_event_types (
+    id bigserial PRIMARY KEY,
+    outbound_webhook_id bigint NOT NULL REFERENCES outbound_webhooks (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    event_type text NOT NULL,
+    scope text
+);
+
+CREATE INDEX IF NOT EXISTS outbound_webhook_event_types_event_type_idx ON outbound_webhook_event_types (event_type, scope);
+CREATE INDEX IF NOT EXISTS outbound_webhook_event_types_outbound_webhook_id_idx ON outbound_webhook_event_types (outbound_webhook_id);
+
+CREATE TABLE IF NOT EXISTS outbound_webhook_jobs (
+    id bigserial PRIMARY KEY,
+    outbound_webhook_id bigint NOT NULL REFERENCES outbound_webhooks (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    event_type text NOT NULL,
+    scope text,
+    encryption_key_id text,
+    payload bytea NOT NULL,
+    state text NOT NULL DEFAULT 'queued',
+    failure_message text,
+    queued_at timestamp with time zone NOT NULL DEFAULT NOW(),
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    process_after timestamp with time zone,
+    num_resets integer NOT NULL DEFAULT 0,
+    num_failures integer NOT NULL DEFAULT 0,
+    last_heartbeat_at timestamp with time zone,
+    execution_logs json[],
+    worker_hostname text NOT NULL DEFAULT '',
+    cancel boolean NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX IF NOT EXISTS outbound_webhook_jobs_state_idx ON outbound_webhook_jobs (state);
+CREATE INDEX IF NOT EXISTS outbound_webhook_jobs_outbound_webhook_id_idx ON outbound_webhook_jobs (outbound_webhook_id);
+
+CREATE TABLE IF NOT EXISTS outbound_webhook_logs (
+    id bigserial PRIMARY KEY,
+    job_id bigint NOT NULL REFERENCES outbound_webhook_jobs (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    outbound_webhook_id bigint NOT NULL REFERENCES outbound_webhooks (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    sent_at timestamp with time zone NOT NULL DEFAULT NOW(),
+    status_code integer NOT NULL,
+    encryption_key_id text,
+    request bytea NOT NULL,
+    response bytea NOT NULL,
+    error bytea NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS outbound_webhook_logs_outbound_webhook_id_idx ON outbound_webhook_logs (outbound_webhook_id);
+CREATE INDEX IF NOT EXISTS outbound_webhook_logs_status_code_idx ON outbound_webhook_logs (status_code);
+
+COMMENT ON COLUMN outbound_webhook_event_types.scope IS 'If set, only events with a matching scope (for example, a repository name) are delivered to the webhook.';
+COMMENT ON COLUMN outbound_webhook_logs.status_code IS 'The HTTP status code returned by the receiver, or 0 if no response was received.';
@@ -0,0 +1,3 @@
+ALTER TABLE cm_trigger_jobs DROP COLUMN IF EXISTS content_results;
+
+DROP TABLE IF EXISTS cm_result_fingerprints;
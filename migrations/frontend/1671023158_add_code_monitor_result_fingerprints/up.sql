@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS cm_result_fingerprints (
+    monitor_id bigint NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    fingerprint text NOT NULL,
+    path text NOT NULL,
+    preview text NOT NULL DEFAULT '',
+    PRIMARY KEY (monitor_id, fingerprint)
+);
+
+COMMENT ON TABLE cm_result_fingerprints IS 'The result set of the most recent run of a code monitor whose query searches file contents or symbols rather than commits.';
+COMMENT ON COLUMN cm_result_fingerprints.fingerprint IS 'Identifies a single content or symbol match, independently of its line number.';
+
+ALTER TABLE cm_trigger_jobs ADD COLUMN IF NOT EXISTS content_results jsonb;
+
+COMMENT ON COLUMN cm_trigger_jobs.content_results IS 'Content or symbol matches that were added or removed since the previous run, for triggers that do not search commits.';
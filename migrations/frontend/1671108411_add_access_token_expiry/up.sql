@@ -0,0 +1,3 @@
+ALTER TABLE access_tokens ADD COLUMN IF NOT EXISTS expires_at timestamp with time zone;
+
+COMMENT ON COLUMN access_tokens.expires_at IS 'The time after which the access token can no longer be used. Tokens without an expiration date never expire.';
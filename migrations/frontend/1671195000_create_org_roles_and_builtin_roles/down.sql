@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS trig_sync_site_admin_role ON users;
+DROP FUNCTION IF EXISTS sync_site_admin_role();
+
+DELETE FROM permissions
+WHERE (namespace, action) IN (('BATCH_CHANGES', 'ADMIN'), ('CODE_INSIGHTS', 'ADMIN'), ('EXTERNAL_SERVICES', 'MANAGE'));
+
+DELETE FROM roles WHERE name = 'SITE_ADMINISTRATOR';
+
+DROP TABLE IF EXISTS org_roles;
@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS org_roles (
+    org_id integer REFERENCES orgs(id) ON DELETE CASCADE DEFERRABLE,
+    role_id integer REFERENCES roles(id) ON DELETE CASCADE DEFERRABLE,
+
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+
+    PRIMARY KEY (org_id, role_id)
+);
+
+INSERT INTO roles (name, readonly)
+VALUES ('SITE_ADMINISTRATOR', TRUE)
+ON CONFLICT (name) DO UPDATE SET readonly = TRUE;
+
+INSERT INTO permissions (namespace, action)
+VALUES
+    ('BATCH_CHANGES', 'ADMIN'),
+    ('CODE_INSIGHTS', 'ADMIN'),
+    ('EXTERNAL_SERVICES', 'MANAGE')
+ON CONFLICT (namespace, action) DO NOTHING;
+
+-- Site admins are members of the built-in SITE_ADMINISTRATOR role.
+INSERT INTO user_roles (user_id, role_id)
+SELECT users.id, roles.id
+FROM users, roles
+WHERE users.site_admin AND users.deleted_at IS NULL AND roles.name = 'SITE_ADMINISTRATOR'
+ON CONFLICT DO NOTHING;
+
+CREATE OR REPLACE FUNCTION sync_site_admin_role() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$ BEGIN
+    IF NEW.site_admin AND NEW.deleted_at IS NULL THEN
+        INSERT INTO user_roles (user_id, role_id)
+        SELECT NEW.id, id FROM roles WHERE name = 'SITE_ADMINISTRATOR'
+        ON CONFLICT DO NOTHING;
+    ELSE
+        DELETE FROM user_roles
+        WHERE user_id = NEW.id AND role_id IN (SELECT id FROM roles WHERE name = 'SITE_ADMINISTRATOR');
+    END IF;
+    RETURN NULL;
+END $$;
+
+DROP TRIGGER IF EXISTS trig_sync_site_admin_role ON users;
+CREATE TRIGGER trig_sync_site_admin_role AFTER INSERT OR UPDATE OF site_admin, deleted_at ON users FOR EACH ROW EXECUTE FUNCTION sync_site_admin_role();
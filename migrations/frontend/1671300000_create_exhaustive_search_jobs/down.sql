@@ -0,0 +1 @@
+DROP TABLE IF EXISTS exhaustive_search_jobs;
@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS exhaustive_search_jobs (
+    id bigserial PRIMARY KEY,
+    initiator_id integer NOT NULL REFERENCES users (id) ON DELETE CASCADE DEFERRABLE,
+    query text NOT NULL,
+    format text NOT NULL,
+
+    state text NOT NULL DEFAULT 'queued',
+    failure_message text,
+    queued_at timestamp with time zone NOT NULL DEFAULT NOW(),
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    process_after timestamp with time zone,
+    num_resets integer NOT NULL DEFAULT 0,
+    num_failures integer NOT NULL DEFAULT 0,
+    last_heartbeat_at timestamp with time zone,
+    execution_logs json[],
+    worker_hostname text NOT NULL DEFAULT '',
+    cancel boolean NOT NULL DEFAULT FALSE,
+
+    created_at timestamp with time zone NOT NULL DEFAULT NOW(),
+    updated_at timestamp with time zone NOT NULL DEFAULT NOW(),
+
+    match_count integer NOT NULL DEFAULT 0,
+    repositories_searched integer NOT NULL DEFAULT 0,
+    limit_hit boolean NOT NULL DEFAULT FALSE,
+    result_key text,
+    result_size bigint
+);
+
+CREATE INDEX IF NOT EXISTS exhaustive_search_jobs_initiator_id_idx ON exhaustive_search_jobs (initiator_id);
+CREATE INDEX IF NOT EXISTS exhaustive_search_jobs_state_idx ON exhaustive_search_jobs (state);
+
+COMMENT ON TABLE exhaustive_search_jobs IS 'Search queries that are run in the background over all matching repositories, with every result written to the upload store.';
+COMMENT ON COLUMN exhaustive_search_jobs.format IS 'The format results are written in, either jsonl or csv.';
+COMMENT ON COLUMN exhaustive_search_jobs.result_key IS 'The key of the object in the upload store that holds the results. It is set once the job completed.';
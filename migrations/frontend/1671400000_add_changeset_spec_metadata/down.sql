@@ -0,0 +1,5 @@
+ALTER TABLE changeset_specs
+  DROP COLUMN IF EXISTS reviewers,
+  DROP COLUMN IF EXISTS team_reviewers,
+  DROP COLUMN IF EXISTS labels,
+  DROP COLUMN IF EXISTS assignees;
@@ -0,0 +1,5 @@
+ALTER TABLE changeset_specs
+  ADD COLUMN IF NOT EXISTS reviewers text[],
+  ADD COLUMN IF NOT EXISTS team_reviewers text[],
+  ADD COLUMN IF NOT EXISTS labels text[],
+  ADD COLUMN IF NOT EXISTS assignees text[];
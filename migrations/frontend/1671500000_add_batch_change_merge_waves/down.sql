@@ -0,0 +1,4 @@
+ALTER TABLE batch_changes
+  DROP COLUMN IF EXISTS merge_wave,
+  DROP COLUMN IF EXISTS merge_wave_started_at,
+  DROP COLUMN IF EXISTS merge_wave_enqueued_at;
@@ -0,0 +1,8 @@
+ALTER TABLE batch_changes
+  ADD COLUMN IF NOT EXISTS merge_wave integer NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS merge_wave_started_at timestamp with time zone,
+  ADD COLUMN IF NOT EXISTS merge_wave_enqueued_at timestamp with time zone;
+
+COMMENT ON COLUMN batch_changes.merge_wave IS 'The index of the merge wave of the batch spec whose changesets are merged next.';
+COMMENT ON COLUMN batch_changes.merge_wave_started_at IS 'When the merge wave became the next wave to merge, after the previous wave was merged.';
+COMMENT ON COLUMN batch_changes.merge_wave_enqueued_at IS 'When the changesets of the merge wave were last enqueued to be merged.';
@@ -0,0 +1 @@
+ALTER TABLE cm_monitors DROP COLUMN IF EXISTS result_fingerprints_stored_at;
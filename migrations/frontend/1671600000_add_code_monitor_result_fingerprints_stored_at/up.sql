@@ -0,0 +1,3 @@
+ALTER TABLE cm_monitors ADD COLUMN IF NOT EXISTS result_fingerprints_stored_at timestamp with time zone;
+
+COMMENT ON COLUMN cm_monitors.result_fingerprints_stored_at IS 'When the result set of the monitor was last stored in cm_result_fingerprints. NULL if it was never stored, in which case the next run of the monitor only stores its result set.';
@@ -118,6 +118,7 @@ type AuthProviders struct {
 	HttpHeader    *HTTPHeaderAuthProvider
 	Github        *GitHubAuthProvider
 	Gitlab        *GitLabAuthProvider
+	Ldap          *LDAPAuthProvider
 }
 
 func (v AuthProviders) MarshalJSON() ([]byte, error) {
@@ -139,6 +140,9 @@ func (v AuthProviders) MarshalJSON() ([]byte, error) {
 	if v.Gitlab != nil {
 		return json.Marshal(v.Gitlab)
 	}
+	if v.Ldap != nil {
+		return json.Marshal(v.Ldap)
+	}
 	return nil, errors.New("tagged union type must have exactly 1 non-nil field value")
 }
 func (v *AuthProviders) UnmarshalJSON(data []byte) error {
@@ -157,14 +161,33 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 		return json.Unmarshal(data, &v.Gitlab)
 	case "http-header":
 		return json.Unmarshal(data, &v.HttpHeader)
+	case "ldap":
+		return json.Unmarshal(data, &v.Ldap)
 	case "openidconnect":
 		return json.Unmarshal(data, &v.Openidconnect)
 	case "saml":
 		return json.Unmarshal(data, &v.Saml)
 	}
-	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab"})
+	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab", "ldap"})
 }
 
+// AzureDevOpsConnection description: Configuration for a connection to Azure DevOps.
+type AzureDevOpsConnection struct {
+	// Exclude description: A list of repositories to never mirror from Azure DevOps. Takes precedence over "orgs" and "projects" configuration.
+	//
+	// Supports excluding by name ({"name": "org/project/repo"}) or by pattern ({"pattern": "^org/project/.*"}).
+	Exclude []*ExcludedAzureDevOpsRepo `json:"exclude,omitempty"`
+	// Orgs description: An array of organization names identifying Azure DevOps organizations whose repositories should be mirrored on Sourcegraph. All repositories of all projects in the organization are mirrored.
+	Orgs []string `json:"orgs,omitempty"`
+	// Projects description: An array of projects "org/project" whose repositories should be mirrored on Sourcegraph.
+	Projects []string `json:"projects,omitempty"`
+	// Token description: A personal access token with the "Code (Read)" and "Project and Team (Read)" scopes. To create and update pull requests with batch changes, the token must also have the "Code (Read & write)" and "Code (Status)" scopes.
+	Token string `json:"token"`
+	// Url description: URL of Azure DevOps Services (https://dev.azure.com) or of an Azure DevOps Server instance, such as https://azuredevops.example.com. On Azure DevOps Server, collections are configured as organizations.
+	Url string `json:"url"`
+	// Username description: The username of the user that owns the personal access token.
+	Username string `json:"username"`
+}
 type BackendInsight struct {
 	// Description description: The description of this insight
 	Description string          `json:"description,omitempty"`
@@ -540,6 +563,7 @@ type EncryptionKeys struct {
 	EnableCache            bool           `json:"enableCache,omitempty"`
 	ExecutorSecretKey      *EncryptionKey `json:"executorSecretKey,omitempty"`
 	ExternalServiceKey     *EncryptionKey `json:"externalServiceKey,omitempty"`
+	OutboundWebhookKey     *EncryptionKey `json:"outboundWebhookKey,omitempty"`
 	UserExternalAccountKey *EncryptionKey `json:"userExternalAccountKey,omitempty"`
 	WebhookKey             *EncryptionKey `json:"webhookKey,omitempty"`
 	WebhookLogKey          *EncryptionKey `json:"webhookLogKey,omitempty"`
@@ -550,6 +574,12 @@ type ExcludedAWSCodeCommitRepo struct {
 	// Name description: The name of an AWS CodeCommit repository ("repo-name") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
 }
+type ExcludedAzureDevOpsRepo struct {
+	// Name description: The name of an Azure DevOps repository ("org/project/repo") to exclude from mirroring.
+	Name string `json:"name,omitempty"`
+	// Pattern description: Regular expression which matches against the name of an Azure DevOps repository ("org/project/repo").
+	Pattern string `json:"pattern,omitempty"`
+}
 type ExcludedBitbucketCloudRepo struct {
 	// Name description: The name of a Bitbucket Cloud repo ("myorg/myrepo") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
@@ -584,6 +614,18 @@ type ExcludedGitLabProject struct {
 	// Name description: The name of a GitLab project ("group/name") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
 }
+type ExcludedGiteaRepo struct {
+	// Archived description: If set to true, archived repositories will be excluded.
+	Archived bool `json:"archived,omitempty"`
+	// Forks description: If set to true, forks will be excluded.
+	Forks bool `json:"forks,omitempty"`
+	// Id description: The ID of a Gitea repository (as returned by the Gitea instance's API) to exclude from mirroring.
+	Id int `json:"id,omitempty"`
+	// Name description: The name of a Gitea repository ("owner/name") to exclude from mirroring.
+	Name string `json:"name,omitempty"`
+	// Pattern description: Regular expression which matches against the name of a Gitea repository ("owner/name").
+	Pattern string `json:"pattern,omitempty"`
+}
 type ExcludedGitoliteRepo struct {
 	// Name description: The name of a Gitolite repo ("my-repo") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
@@ -649,6 +691,8 @@ type ExperimentalFeatures struct {
 	JvmPackages string `json:"jvmPackages,omitempty"`
 	// NpmPackages description: Allow adding npm package code host connections
 	NpmPackages string `json:"npmPackages,omitempty"`
+	// NugetPackages description: Allow adding NuGet package code host connections
+	NugetPackages string `json:"nugetPackages,omitempty"`
 	// Pagure description: Allow adding Pagure code host connections
 	Pagure string `json:"pagure,omitempty"`
 	// PasswordPolicy description: DEPRECATED: this is now a standard feature see: auth.passwordPolicy
@@ -996,6 +1040,40 @@ type GitLabWebhook struct {
 	Secret string `json:"secret"`
 }
 
+// GiteaConnection description: Configuration for a connection to Gitea or Forgejo.
+type GiteaConnection struct {
+	// Exclude description: A list of repositories to never mirror from this Gitea instance. Takes precedence over "orgs", "users" and "repositoryQuery" configuration.
+	//
+	// Supports excluding by name ({"name": "owner/name"}), by ID ({"id": 42}), by pattern ({"pattern": "^owner/.*"}), and forks or archived repositories ({"forks": true}).
+	Exclude []*ExcludedGiteaRepo `json:"exclude,omitempty"`
+	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on this Gitea instance.
+	//
+	// If "http", Sourcegraph will access Gitea repositories using Git URLs of the form http(s)://gitea.example.com/myorg/myrepo.git (using https: if the Gitea instance uses HTTPS).
+	//
+	// If "ssh", Sourcegraph will access Gitea repositories using Git URLs of the form git@gitea.example.com:myorg/myrepo.git. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.
+	GitURLType string `json:"gitURLType,omitempty"`
+	// Orgs description: An array of organization names identifying Gitea organizations whose repositories should be mirrored on Sourcegraph.
+	Orgs []string `json:"orgs,omitempty"`
+	// RateLimit description: Rate limit applied when making API requests to Gitea.
+	RateLimit *GiteaRateLimit `json:"rateLimit,omitempty"`
+	// RepositoryQuery description: An array of strings specifying which Gitea repositories to mirror on Sourcegraph. Each string is a keyword passed to Gitea's repository search API, which matches repository names. The special string "all" mirrors every repository visible to the configured token.
+	RepositoryQuery []string `json:"repositoryQuery,omitempty"`
+	// Token description: A Gitea access token with the "read:repository" and "read:organization" scopes. If omitted, only public repositories are listed and cloned.
+	Token string `json:"token,omitempty"`
+	// Url description: URL of a Gitea or Forgejo instance, such as https://gitea.example.com or https://codeberg.org.
+	Url string `json:"url"`
+	// Users description: An array of user names identifying Gitea users whose repositories should be mirrored on Sourcegraph.
+	Users []string `json:"users,omitempty"`
+}
+
+// GiteaRateLimit description: Rate limit applied when making API requests to Gitea.
+type GiteaRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 500, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 500 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
+
 // Github description: GitHub configuration, both for queries and receiving release webhooks.
 type Github struct {
 	// Repository description: The repository to get the latest version of.
@@ -1142,6 +1220,44 @@ type JVMPackagesConnection struct {
 	Maven *Maven `json:"maven,omitempty"`
 }
 
+// LDAPAuthProvider description: Configures the LDAP authentication provider, which authenticates users with their LDAP (or Active Directory) username and password.
+type LDAPAuthProvider struct {
+	// AllowSignup description: Allows new visitors to sign up for accounts via LDAP authentication. If false, users signing in via LDAP must have an existing Sourcegraph account, which will be linked to their LDAP identity after sign-in.
+	AllowSignup *bool `json:"allowSignup,omitempty"`
+	// BindDN description: The DN to bind as to search for users and groups. If not set, searches are performed anonymously.
+	BindDN string `json:"bindDN,omitempty"`
+	// BindPassword description: The password of the bindDN.
+	BindPassword string `json:"bindPassword,omitempty"`
+	// Certificate description: TLS certificate of the LDAP server, or of the certificate authority that issued it, in PEM format. Required if the certificate is not signed by a trusted authority.
+	Certificate string `json:"certificate,omitempty"`
+	// ConfigID description: An identifier that can be used to reference this authentication provider in other parts of the config.
+	ConfigID    string `json:"configID,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	// DisplayNameAttribute description: The attribute of the user entry that contains the display name.
+	DisplayNameAttribute string `json:"displayNameAttribute,omitempty"`
+	// EmailAttribute description: The attribute of the user entry that contains the email address. Users without an email address cannot sign in.
+	EmailAttribute string `json:"emailAttribute,omitempty"`
+	// GroupBaseDN description: The DN under which the groups of users are searched for. If not set, the groups of users are not synced.
+	GroupBaseDN string `json:"groupBaseDN,omitempty"`
+	// GroupFilter description: The LDAP filter that finds the groups of the user signing in. `{dn}` is replaced with the escaped DN of the user, and `{username}` with their escaped username.
+	GroupFilter string `json:"groupFilter,omitempty"`
+	// GroupNameAttribute description: The attribute of the group entries that contains the name of the group.
+	GroupNameAttribute string `json:"groupNameAttribute,omitempty"`
+	// GroupOrgMap description: Maps LDAP group names to the names of the Sourcegraph organizations that their members are added to when they sign in. Users are removed from the mapped organizations of the groups they are no longer a member of. Organizations that are not mapped to a group are not changed.
+	GroupOrgMap map[string][]string `json:"groupOrgMap,omitempty"`
+	// StartTLS description: Upgrade the connection to TLS with StartTLS after connecting to an ldap:// URL.
+	StartTLS bool   `json:"startTLS,omitempty"`
+	Type     string `json:"type"`
+	// Url description: The URL of the LDAP server. Use the ldaps scheme to connect with TLS.
+	Url string `json:"url"`
+	// UserBaseDN description: The DN under which users are searched for.
+	UserBaseDN string `json:"userBaseDN"`
+	// UserFilter description: The LDAP filter that finds the user signing in. `{username}` is replaced with the escaped username entered by the user.
+	UserFilter string `json:"userFilter,omitempty"`
+	// UsernameAttribute description: The attribute of the user entry that contains the username. The username is normalized to be a valid Sourcegraph username.
+	UsernameAttribute string `json:"usernameAttribute,omitempty"`
+}
+
 // Log description: Configuration for logging and alerting, including to external services.
 type Log struct {
 	// AuditLog description: EXPERIMENTAL: Configuration for audit logging (specially formatted log entries for tracking sensitive events)
@@ -1318,6 +1434,34 @@ type NpmRateLimit struct {
 	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
+
+// NuGetCredentials description: The credentials used to authenticate to the package feed. They're sent as HTTP basic auth to the host of the service index only.
+type NuGetCredentials struct {
+	// Password description: The password, API key or personal access token.
+	Password string `json:"password"`
+	// Username description: The username. Feeds that authenticate with an API key or a personal access token often accept any username.
+	Username string `json:"username,omitempty"`
+}
+
+// NuGetPackagesConnection description: Configuration for a connection to NuGet packages
+type NuGetPackagesConnection struct {
+	// Credentials description: The credentials used to authenticate to the package feed. They're sent as HTTP basic auth to the host of the service index only.
+	Credentials *NuGetCredentials `json:"credentials,omitempty"`
+	// Dependencies description: An array of strings specifying NuGet packages to mirror in Sourcegraph.
+	Dependencies []string `json:"dependencies,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to the configured NuGet feed.
+	RateLimit *NuGetRateLimit `json:"rateLimit,omitempty"`
+	// Repository description: The URL of the NuGet v3 service index (index.json) of the package feed to sync packages from.
+	Repository string `json:"repository,omitempty"`
+}
+
+// NuGetRateLimit description: Rate limit applied when making background API requests to the configured NuGet feed.
+type NuGetRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
 type OAuthIdentity struct {
 	Type string `json:"type"`
 }